@@ -16,13 +16,16 @@ import (
 	btclctypes "github.com/babylonlabs-io/babylon/x/btclightclient/types"
 	btcstakingtypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
 	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	incentivetypes "github.com/babylonlabs-io/babylon/x/incentive/types"
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/chaincfg"
 	cmtcrypto "github.com/cometbft/cometbft/proto/tendermint/crypto"
+	"github.com/cosmos/cosmos-sdk/client"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkquery "github.com/cosmos/cosmos-sdk/types/query"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	sttypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/cosmos/relayer/v2/relayer/provider"
 	"go.uber.org/zap"
@@ -41,6 +44,14 @@ type BabylonController struct {
 	cfg       *fpcfg.BBNConfig
 	btcParams *chaincfg.Params
 	logger    *zap.Logger
+	// feePayers broadcast the public randomness commits and the finality
+	// signatures in rotation, the key in config is used if it is empty
+	feePayers *feePayerPool
+
+	// ctx is the parent of the contexts of the outstanding calls,
+	// it is cancelled upon Close
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
 func NewBabylonController(
@@ -64,11 +75,21 @@ func NewBabylonController(
 		return nil, err
 	}
 
+	feePayers, err := newFeePayerPool(bc.GetKeyring(), cfg.FeePayerKeys, cfg.Key, cfg.AccountPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the fee payer keys: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
 	return &BabylonController{
-		bc,
-		cfg,
-		btcParams,
-		logger,
+		bbnClient: bc,
+		cfg:       cfg,
+		btcParams: btcParams,
+		logger:    logger,
+		feePayers: feePayers,
+		ctx:       ctx,
+		cancel:    cancel,
 	}, nil
 }
 
@@ -103,7 +124,7 @@ func (bc *BabylonController) reliablySendMsg(msg sdk.Msg, expectedErrs []*sdkErr
 
 func (bc *BabylonController) reliablySendMsgs(msgs []sdk.Msg, expectedErrs []*sdkErr.Error, unrecoverableErrs []*sdkErr.Error) (*provider.RelayerTxResponse, error) {
 	return bc.bbnClient.ReliablySendMsgs(
-		context.Background(),
+		bc.ctx,
 		msgs,
 		expectedErrs,
 		unrecoverableErrs,
@@ -154,13 +175,15 @@ func (bc *BabylonController) CommitPubRandList(
 	commitment []byte,
 	sig *schnorr.Signature,
 ) (*types.TxResponse, error) {
-	msg := &finalitytypes.MsgCommitPubRandList{
-		Signer:      bc.mustGetTxSigner(),
-		FpBtcPk:     bbntypes.NewBIP340PubKeyFromBTCPK(fpPk),
-		StartHeight: startHeight,
-		NumPubRand:  numPubRand,
-		Commitment:  commitment,
-		Sig:         bbntypes.NewBIP340SignatureFromBTCSig(sig),
+	buildMsgs := func(signer string) ([]sdk.Msg, error) {
+		return []sdk.Msg{&finalitytypes.MsgCommitPubRandList{
+			Signer:      signer,
+			FpBtcPk:     bbntypes.NewBIP340PubKeyFromBTCPK(fpPk),
+			StartHeight: startHeight,
+			NumPubRand:  numPubRand,
+			Commitment:  commitment,
+			Sig:         bbntypes.NewBIP340SignatureFromBTCSig(sig),
+		}}, nil
 	}
 
 	unrecoverableErrs := []*sdkErr.Error{
@@ -170,7 +193,7 @@ func (bc *BabylonController) CommitPubRandList(
 		btcstakingtypes.ErrFpNotFound,
 	}
 
-	res, err := bc.reliablySendMsg(msg, emptyErrs, unrecoverableErrs)
+	res, err := bc.reliablySendMsgsWithFeePayer(buildMsgs, emptyErrs, unrecoverableErrs)
 	if err != nil {
 		return nil, err
 	}
@@ -204,23 +227,27 @@ func (bc *BabylonController) SubmitBatchFinalitySigs(
 		return nil, fmt.Errorf("the number of blocks %v should match the number of finality signatures %v", len(blocks), len(sigs))
 	}
 
-	msgs := make([]sdk.Msg, 0, len(blocks))
-	for i, b := range blocks {
-		cmtProof := cmtcrypto.Proof{}
-		if err := cmtProof.Unmarshal(proofList[i]); err != nil {
-			return nil, err
+	buildMsgs := func(signer string) ([]sdk.Msg, error) {
+		msgs := make([]sdk.Msg, 0, len(blocks))
+		for i, b := range blocks {
+			cmtProof := cmtcrypto.Proof{}
+			if err := cmtProof.Unmarshal(proofList[i]); err != nil {
+				return nil, err
+			}
+
+			msg := &finalitytypes.MsgAddFinalitySig{
+				Signer:       signer,
+				FpBtcPk:      bbntypes.NewBIP340PubKeyFromBTCPK(fpPk),
+				BlockHeight:  b.Height,
+				PubRand:      bbntypes.NewSchnorrPubRandFromFieldVal(pubRandList[i]),
+				Proof:        &cmtProof,
+				BlockAppHash: b.Hash,
+				FinalitySig:  bbntypes.NewSchnorrEOTSSigFromModNScalar(sigs[i]),
+			}
+			msgs = append(msgs, msg)
 		}
 
-		msg := &finalitytypes.MsgAddFinalitySig{
-			Signer:       bc.mustGetTxSigner(),
-			FpBtcPk:      bbntypes.NewBIP340PubKeyFromBTCPK(fpPk),
-			BlockHeight:  b.Height,
-			PubRand:      bbntypes.NewSchnorrPubRandFromFieldVal(pubRandList[i]),
-			Proof:        &cmtProof,
-			BlockAppHash: b.Hash,
-			FinalitySig:  bbntypes.NewSchnorrEOTSSigFromModNScalar(sigs[i]),
-		}
-		msgs = append(msgs, msg)
+		return msgs, nil
 	}
 
 	unrecoverableErrs := []*sdkErr.Error{
@@ -233,7 +260,7 @@ func (bc *BabylonController) SubmitBatchFinalitySigs(
 		finalitytypes.ErrDuplicatedFinalitySig,
 	}
 
-	res, err := bc.reliablySendMsgs(msgs, expectedErrs, unrecoverableErrs)
+	res, err := bc.reliablySendMsgsWithFeePayer(buildMsgs, expectedErrs, unrecoverableErrs)
 	if err != nil {
 		return nil, err
 	}
@@ -266,6 +293,148 @@ func (bc *BabylonController) UnjailFinalityProvider(fpPk *btcec.PublicKey) (*typ
 	return &types.TxResponse{TxHash: res.TxHash, Events: res.Events}, nil
 }
 
+// WithdrawFinalityProviderRewards withdraws the finality provider rewards of the tx signer
+// and sends the given amount to the recipient in the same transaction if it is not empty
+func (bc *BabylonController) WithdrawFinalityProviderRewards(amount sdk.Coins, recipient string) (*types.TxResponse, error) {
+	signer := bc.mustGetTxSigner()
+	msgs := []sdk.Msg{
+		&incentivetypes.MsgWithdrawReward{
+			Type:    incentivetypes.FinalityProviderType.String(),
+			Address: signer,
+		},
+	}
+	if recipient != "" && recipient != signer {
+		msgs = append(msgs, &banktypes.MsgSend{
+			FromAddress: signer,
+			ToAddress:   recipient,
+			Amount:      amount,
+		})
+	}
+
+	unrecoverableErrs := []*sdkErr.Error{
+		incentivetypes.ErrRewardGaugeNotFound,
+		incentivetypes.ErrNoWithdrawableCoins,
+	}
+
+	res, err := bc.reliablySendMsgs(msgs, emptyErrs, unrecoverableErrs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.TxResponse{TxHash: res.TxHash, Events: res.Events}, nil
+}
+
+// QueryFinalityProviderRewards returns the withdrawable finality provider rewards of the tx signer
+func (bc *BabylonController) QueryFinalityProviderRewards() (sdk.Coins, error) {
+	return bc.queryFinalityProviderRewardsOf(bc.mustGetTxSigner())
+}
+
+// QueryRewardsOfFinalityProvider returns the withdrawable rewards accrued to the
+// Babylon address of the given finality provider
+func (bc *BabylonController) QueryRewardsOfFinalityProvider(fpPk *btcec.PublicKey) (sdk.Coins, error) {
+	fpRes, err := bc.QueryFinalityProvider(fpPk)
+	if err != nil {
+		return nil, err
+	}
+
+	return bc.queryFinalityProviderRewardsOf(fpRes.FinalityProvider.Addr)
+}
+
+// WithdrawRewardsOfFinalityProvider withdraws the rewards of the given finality
+// provider, which is rejected before sending the transaction if its Babylon
+// address is not the tx signer
+func (bc *BabylonController) WithdrawRewardsOfFinalityProvider(
+	fpPk *btcec.PublicKey,
+	amount sdk.Coins,
+	recipient string,
+) (*types.TxResponse, error) {
+	fpRes, err := bc.QueryFinalityProvider(fpPk)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.EqualFold(fpRes.FinalityProvider.Addr, bc.mustGetTxSigner()) {
+		return nil, fmt.Errorf("the signer does not correspond to the finality provider's "+
+			"Babylon address, expected %s got %s", bc.mustGetTxSigner(), fpRes.FinalityProvider.Addr)
+	}
+
+	return bc.WithdrawFinalityProviderRewards(amount, recipient)
+}
+
+// queryFinalityProviderRewardsOf returns the withdrawable finality provider
+// rewards accrued to the given address
+func (bc *BabylonController) queryFinalityProviderRewardsOf(addr string) (sdk.Coins, error) {
+	res, err := bc.bbnClient.QueryClient.RewardGauges(addr)
+	if err != nil {
+		// no reward has been distributed to the address yet
+		if strings.Contains(err.Error(), incentivetypes.ErrRewardGaugeNotFound.Error()) {
+			return sdk.NewCoins(), nil
+		}
+		return nil, fmt.Errorf("failed to query the reward gauges: %w", err)
+	}
+
+	gauge, ok := res.RewardGauges[incentivetypes.FinalityProviderType.String()]
+	if !ok {
+		return sdk.NewCoins(), nil
+	}
+
+	withdrawable, hasNeg := gauge.Coins.SafeSub(gauge.WithdrawnCoins...)
+	if hasNeg {
+		return nil, fmt.Errorf("invalid reward gauge: withdrawn %s exceeds %s", gauge.WithdrawnCoins, gauge.Coins)
+	}
+
+	return withdrawable, nil
+}
+
+// QueryNodeChainID queries the chain id of the node the controller is connected to
+func (bc *BabylonController) QueryNodeChainID() (string, error) {
+	res, err := bc.bbnClient.QueryClient.GetStatus()
+	if err != nil {
+		return "", fmt.Errorf("failed to query the node status: %w", err)
+	}
+
+	return res.NodeInfo.Network, nil
+}
+
+// QuerySignerBalance queries the balance of the key signing the transactions
+func (bc *BabylonController) QuerySignerBalance() (sdk.Coins, error) {
+	ctx, cancel := getContextWithCancel(bc.ctx, bc.cfg.Timeout)
+	defer cancel()
+
+	queryClient := banktypes.NewQueryClient(client.Context{Client: bc.bbnClient.RPCClient})
+	res, err := queryClient.AllBalances(ctx, &banktypes.QueryAllBalancesRequest{Address: bc.mustGetTxSigner()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the balance of the signer: %w", err)
+	}
+
+	return res.Balances, nil
+}
+
+// QueryFeeAccountBalances queries the balances of the key signing the
+// transactions and of the fee payers, keyed by their address
+func (bc *BabylonController) QueryFeeAccountBalances() (map[string]sdk.Coins, error) {
+	addrs := []string{bc.mustGetTxSigner()}
+	if !bc.feePayers.isEmpty() {
+		for _, payer := range bc.feePayers.payers {
+			addrs = append(addrs, payer.signer)
+		}
+	}
+
+	queryClient := banktypes.NewQueryClient(client.Context{Client: bc.bbnClient.RPCClient})
+	balances := make(map[string]sdk.Coins, len(addrs))
+	for _, addr := range addrs {
+		ctx, cancel := getContextWithCancel(bc.ctx, bc.cfg.Timeout)
+		res, err := queryClient.AllBalances(ctx, &banktypes.QueryAllBalancesRequest{Address: addr})
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to query the balance of %s: %w", addr, err)
+		}
+		balances[addr] = res.Balances
+	}
+
+	return balances, nil
+}
+
 // QueryFinalityProviderSlashedOrJailed - returns if the fp has been slashed, jailed, err
 func (bc *BabylonController) QueryFinalityProviderSlashedOrJailed(fpPk *btcec.PublicKey) (bool, bool, error) {
 	fpPubKey := bbntypes.NewBIP340PubKeyFromBTCPK(fpPk)
@@ -277,6 +446,164 @@ func (bc *BabylonController) QueryFinalityProviderSlashedOrJailed(fpPk *btcec.Pu
 	return res.FinalityProvider.SlashedBtcHeight > 0, res.FinalityProvider.Jailed, nil
 }
 
+// QueryFinalityProviderJailedUntil returns the time until which the finality provider is jailed
+// due to downtime as recorded in its signing info
+func (bc *BabylonController) QueryFinalityProviderJailedUntil(fpPk *btcec.PublicKey) (time.Time, error) {
+	fpPubKey := bbntypes.NewBIP340PubKeyFromBTCPK(fpPk)
+	var res *finalitytypes.QuerySigningInfoResponse
+	err := bc.bbnClient.QueryClient.QueryFinality(func(ctx context.Context, queryClient finalitytypes.QueryClient) error {
+		var err error
+		res, err = queryClient.SigningInfo(ctx, &finalitytypes.QuerySigningInfoRequest{FpBtcPkHex: fpPubKey.MarshalHex()})
+		return err
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to query the signing info of the finality provider %s: %w", fpPubKey.MarshalHex(), err)
+	}
+
+	return res.SigningInfo.JailedUntil, nil
+}
+
+// QueryEvidences queries the equivocation evidences at heights not lower than
+// the start height, one per finality provider
+func (bc *BabylonController) QueryEvidences(startHeight uint64) ([]*finalitytypes.Evidence, error) {
+	var (
+		evidences []*finalitytypes.Evidence
+		seenFps   = make(map[string]struct{})
+		nextKey   []byte
+	)
+	for {
+		res, err := bc.bbnClient.QueryClient.ListEvidences(startHeight, &sdkquery.PageRequest{Key: nextKey})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query the evidences since height %d: %w", startHeight, err)
+		}
+
+		for _, e := range res.Evidences {
+			// the evidence of a finality provider is listed for each of its
+			// equivocations, only the first one is kept
+			if _, seen := seenFps[e.FpBtcPkHex]; seen {
+				continue
+			}
+			seenFps[e.FpBtcPkHex] = struct{}{}
+
+			fpPk, err := bbntypes.NewBIP340PubKeyFromHex(e.FpBtcPkHex)
+			if err != nil {
+				return nil, fmt.Errorf("invalid public key %s in the evidence: %w", e.FpBtcPkHex, err)
+			}
+			evidences = append(evidences, &finalitytypes.Evidence{
+				FpBtcPk:              fpPk,
+				BlockHeight:          e.BlockHeight,
+				PubRand:              e.PubRand,
+				CanonicalAppHash:     e.CanonicalAppHash,
+				ForkAppHash:          e.ForkAppHash,
+				CanonicalFinalitySig: e.CanonicalFinalitySig,
+				ForkFinalitySig:      e.ForkFinalitySig,
+			})
+		}
+
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			return evidences, nil
+		}
+		nextKey = res.Pagination.NextKey
+	}
+}
+
+// SubmitSelectiveSlashingEvidence submits the recovered BTC secret key of a
+// finality provider along with one of its active BTC delegations, which
+// slashes the finality provider
+func (bc *BabylonController) SubmitSelectiveSlashingEvidence(recoveredSk *btcec.PrivateKey) (*types.TxResponse, error) {
+	fpPk := bbntypes.NewBIP340PubKeyFromBTCPK(recoveredSk.PubKey())
+
+	stakingTxHash, err := bc.queryActiveStakingTxHash(fpPk)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &btcstakingtypes.MsgSelectiveSlashingEvidence{
+		Signer:           bc.mustGetTxSigner(),
+		StakingTxHash:    stakingTxHash,
+		RecoveredFpBtcSk: recoveredSk.Serialize(),
+	}
+
+	unrecoverableErrs := []*sdkErr.Error{
+		btcstakingtypes.ErrFpNotFound,
+		btcstakingtypes.ErrFpAlreadySlashed,
+		btcstakingtypes.ErrBTCDelegationNotFound,
+	}
+
+	res, err := bc.reliablySendMsg(msg, emptyErrs, unrecoverableErrs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.TxResponse{TxHash: res.TxHash, Events: res.Events}, nil
+}
+
+// queryActiveStakingTxHash returns the staking tx hash of an active BTC
+// delegation to the given finality provider
+func (bc *BabylonController) queryActiveStakingTxHash(fpPk *bbntypes.BIP340PubKey) (string, error) {
+	var nextKey []byte
+	for {
+		res, err := bc.bbnClient.QueryClient.FinalityProviderDelegations(fpPk.MarshalHex(), &sdkquery.PageRequest{Key: nextKey})
+		if err != nil {
+			return "", fmt.Errorf("failed to query the delegations of the finality provider %s: %w", fpPk.MarshalHex(), err)
+		}
+
+		for _, dels := range res.BtcDelegatorDelegations {
+			for _, del := range dels.Dels {
+				if !del.Active {
+					continue
+				}
+				stakingTx, _, err := bbntypes.NewBTCTxFromHex(del.StakingTxHex)
+				if err != nil {
+					return "", fmt.Errorf("invalid staking tx of a delegation to %s: %w", fpPk.MarshalHex(), err)
+				}
+
+				return stakingTx.TxHash().String(), nil
+			}
+		}
+
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			return "", fmt.Errorf("the finality provider %s has no active delegation", fpPk.MarshalHex())
+		}
+		nextKey = res.Pagination.NextKey
+	}
+}
+
+// QueryFinalityProviderDelegations queries the BTC delegations to the
+// finality provider page by page and summarizes them by status
+func (bc *BabylonController) QueryFinalityProviderDelegations(fpPk *btcec.PublicKey) (*types.DelegationSummary, error) {
+	fpPkHex := bbntypes.NewBIP340PubKeyFromBTCPK(fpPk).MarshalHex()
+	summary := &types.DelegationSummary{}
+	var nextKey []byte
+	for {
+		res, err := bc.bbnClient.QueryClient.FinalityProviderDelegations(fpPkHex, &sdkquery.PageRequest{Key: nextKey})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query the delegations of the finality provider %s: %w", fpPkHex, err)
+		}
+
+		for _, dels := range res.BtcDelegatorDelegations {
+			for _, del := range dels.Dels {
+				switch del.StatusDesc {
+				case btcstakingtypes.BTCDelegationStatus_ACTIVE.String():
+					summary.NumActive++
+					summary.ActiveSat += del.TotalSat
+				case btcstakingtypes.BTCDelegationStatus_PENDING.String(), btcstakingtypes.BTCDelegationStatus_VERIFIED.String():
+					summary.NumPending++
+					summary.PendingSat += del.TotalSat
+				case btcstakingtypes.BTCDelegationStatus_UNBONDED.String():
+					summary.NumUnbonded++
+					summary.UnbondedSat += del.TotalSat
+				}
+			}
+		}
+
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			return summary, nil
+		}
+		nextKey = res.Pagination.NextKey
+	}
+}
+
 // QueryFinalityProviderVotingPower queries the voting power of the finality provider at a given height
 func (bc *BabylonController) QueryFinalityProviderVotingPower(fpPk *btcec.PublicKey, blockHeight uint64) (uint64, error) {
 	res, err := bc.bbnClient.QueryClient.FinalityProviderPowerAtHeight(
@@ -354,8 +681,8 @@ func (bc *BabylonController) queryLatestBlocks(startKey []byte, count uint64, st
 	return blocks, nil
 }
 
-func getContextWithCancel(timeout time.Duration) (context.Context, context.CancelFunc) {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+func getContextWithCancel(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(parent, timeout)
 	return ctx, cancel
 }
 
@@ -401,7 +728,7 @@ func (bc *BabylonController) QueryBestBlock() (*types.BlockInfo, error) {
 }
 
 func (bc *BabylonController) queryCometBestBlock() (*types.BlockInfo, error) {
-	ctx, cancel := getContextWithCancel(bc.cfg.Timeout)
+	ctx, cancel := getContextWithCancel(bc.ctx, bc.cfg.Timeout)
 	// this will return 20 items at max in the descending order (highest first)
 	chainInfo, err := bc.bbnClient.RPCClient.BlockchainInfo(ctx, 0, 0)
 	defer cancel()
@@ -422,7 +749,22 @@ func (bc *BabylonController) queryCometBestBlock() (*types.BlockInfo, error) {
 	}, nil
 }
 
+func (bc *BabylonController) QueryLatestBlockTime() (time.Time, error) {
+	ctx, cancel := getContextWithCancel(bc.ctx, bc.cfg.Timeout)
+	defer cancel()
+
+	status, err := bc.bbnClient.RPCClient.Status(ctx)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to query the status of the node: %w", err)
+	}
+
+	return status.SyncInfo.LatestBlockTime, nil
+}
+
+// Close cancels the outstanding calls and stops the Babylon client
 func (bc *BabylonController) Close() error {
+	bc.cancel()
+
 	if !bc.bbnClient.IsRunning() {
 		return nil
 	}
@@ -525,6 +867,15 @@ func (bc *BabylonController) QueryFinalityProvider(fpPk *btcec.PublicKey) (*btcs
 	return res, nil
 }
 
+func (bc *BabylonController) QueryMinCommissionRate() (sdkmath.LegacyDec, error) {
+	res, err := bc.bbnClient.QueryClient.BTCStakingParams()
+	if err != nil {
+		return sdkmath.LegacyDec{}, fmt.Errorf("failed to query the params of the btcstaking module: %w", err)
+	}
+
+	return res.Params.MinCommissionRate, nil
+}
+
 func (bc *BabylonController) EditFinalityProvider(fpPk *btcec.PublicKey,
 	rate *sdkmath.LegacyDec, description []byte) (*btcstakingtypes.MsgEditFinalityProvider, error) {
 	var reqDesc proto.Description
@@ -573,12 +924,59 @@ func (bc *BabylonController) EditFinalityProvider(fpPk *btcec.PublicKey,
 
 	_, err = bc.reliablySendMsg(msg, emptyErrs, emptyErrs)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query the finality provider %s: %w", fpPk.SerializeCompressed(), err)
+		return nil, fmt.Errorf("failed to edit the finality provider %s: %w", fpPubKey.MarshalHex(), err)
 	}
 
 	return msg, nil
 }
 
+// UpdateFinalityProviderCommission updates the commission rate of the finality provider
+// via a MsgEditFinalityProvider keeping its description unchanged. The rate is checked
+// against the minimum commission rate of the btcstaking module before sending the msg
+func (bc *BabylonController) UpdateFinalityProviderCommission(fpPk *btcec.PublicKey, rate sdkmath.LegacyDec) (*types.TxResponse, error) {
+	paramsRes, err := bc.bbnClient.QueryClient.BTCStakingParams()
+	if err != nil {
+		return nil, fmt.Errorf("failed to query staking params: %w", err)
+	}
+	minRate := paramsRes.Params.MinCommissionRate
+	if rate.LT(minRate) {
+		return nil, fmt.Errorf("%w: %s is less than the minimum rate %s", btcstakingtypes.ErrCommissionLTMinRate, rate, minRate)
+	}
+	if rate.GT(sdkmath.LegacyOneDec()) {
+		return nil, fmt.Errorf("%w: %s", btcstakingtypes.ErrCommissionGTMaxRate, rate)
+	}
+
+	fpRes, err := bc.QueryFinalityProvider(fpPk)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.EqualFold(fpRes.FinalityProvider.Addr, bc.mustGetTxSigner()) {
+		return nil, fmt.Errorf("the signer does not correspond to the finality provider's "+
+			"Babylon address, expected %s got %s", bc.mustGetTxSigner(), fpRes.FinalityProvider.Addr)
+	}
+
+	msg := &btcstakingtypes.MsgEditFinalityProvider{
+		Addr:        bc.mustGetTxSigner(),
+		BtcPk:       bbntypes.NewBIP340PubKeyFromBTCPK(fpPk).MustMarshal(),
+		Description: fpRes.FinalityProvider.Description,
+		Commission:  &rate,
+	}
+
+	unrecoverableErrs := []*sdkErr.Error{
+		btcstakingtypes.ErrFpNotFound,
+		btcstakingtypes.ErrCommissionLTMinRate,
+		btcstakingtypes.ErrCommissionGTMaxRate,
+	}
+
+	res, err := bc.reliablySendMsg(msg, emptyErrs, unrecoverableErrs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.TxResponse{TxHash: res.TxHash, Events: res.Events}, nil
+}
+
 func (bc *BabylonController) QueryBtcLightClientTip() (*btclctypes.BTCHeaderInfoResponse, error) {
 	res, err := bc.bbnClient.QueryClient.BTCHeaderChainTip()
 	if err != nil {
@@ -597,10 +995,12 @@ func (bc *BabylonController) QueryCurrentEpoch() (uint64, error) {
 	return res.CurrentEpoch, nil
 }
 
+// QueryVotesAtHeight queries the public keys of the finality providers which
+// have voted for the block at the given height
 func (bc *BabylonController) QueryVotesAtHeight(height uint64) ([]bbntypes.BIP340PubKey, error) {
 	res, err := bc.bbnClient.QueryClient.VotesAtHeight(height)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query BTC delegations: %w", err)
+		return nil, fmt.Errorf("failed to query the votes at height %d: %w", height, err)
 	}
 
 	return res.BtcPks, nil
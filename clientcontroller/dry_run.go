@@ -0,0 +1,220 @@
+package clientcontroller
+
+import (
+	"fmt"
+	"sync"
+
+	"cosmossdk.io/math"
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	btcstakingtypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// dryRunTxHashPrefix prefixes the hashes of the transactions which are not
+// broadcast in dry-run mode
+const dryRunTxHashPrefix = "DRYRUN-"
+
+// DryRunController wraps a client controller and logs the transactions
+// instead of broadcasting them, the queries are forwarded as is. The public
+// randomness committed in dry-run mode is returned by
+// QueryLastCommittedPublicRand so that it is not committed again
+type DryRunController struct {
+	ClientController
+
+	logger *zap.Logger
+	txNum  atomic.Uint64
+
+	mu sync.Mutex
+	// pubRandCommits are the last public randomness commitments of each
+	// finality provider in dry-run mode, keyed by its BTC PK hex
+	pubRandCommits map[string]*dryRunPubRandCommit
+}
+
+type dryRunPubRandCommit struct {
+	startHeight uint64
+	resp        *finalitytypes.PubRandCommitResponse
+}
+
+var _ ClientController = (*DryRunController)(nil)
+
+func NewDryRunController(cc ClientController, logger *zap.Logger) *DryRunController {
+	return &DryRunController{
+		ClientController: cc,
+		logger:           logger,
+		pubRandCommits:   make(map[string]*dryRunPubRandCommit),
+	}
+}
+
+// record logs the transaction which is not broadcast and returns its
+// fake response
+func (dc *DryRunController) record(msg string, fields ...zap.Field) *types.TxResponse {
+	txHash := fmt.Sprintf("%s%d", dryRunTxHashPrefix, dc.txNum.Inc())
+	dc.logger.Info("dry-run: "+msg, append(fields, zap.String("tx_hash", txHash))...)
+
+	return &types.TxResponse{TxHash: txHash}
+}
+
+func (dc *DryRunController) RegisterFinalityProvider(
+	fpPk *btcec.PublicKey,
+	_ []byte,
+	commission *math.LegacyDec,
+	_ []byte,
+) (*types.TxResponse, error) {
+	return dc.record("not broadcasting the finality provider registration",
+		zap.String("pk", bbntypes.NewBIP340PubKeyFromBTCPK(fpPk).MarshalHex()),
+		zap.Stringer("commission", commission),
+	), nil
+}
+
+func (dc *DryRunController) CommitPubRandList(
+	fpPk *btcec.PublicKey,
+	startHeight uint64,
+	numPubRand uint64,
+	commitment []byte,
+	_ *schnorr.Signature,
+) (*types.TxResponse, error) {
+	fpPkHex := bbntypes.NewBIP340PubKeyFromBTCPK(fpPk).MarshalHex()
+
+	dc.mu.Lock()
+	dc.pubRandCommits[fpPkHex] = &dryRunPubRandCommit{
+		startHeight: startHeight,
+		resp:        &finalitytypes.PubRandCommitResponse{NumPubRand: numPubRand, Commitment: commitment},
+	}
+	dc.mu.Unlock()
+
+	return dc.record("not broadcasting the public randomness commitment",
+		zap.String("pk", fpPkHex),
+		zap.Uint64("start_height", startHeight),
+		zap.Uint64("num_pub_rand", numPubRand),
+	), nil
+}
+
+func (dc *DryRunController) SubmitFinalitySig(
+	fpPk *btcec.PublicKey,
+	block *types.BlockInfo,
+	_ *btcec.FieldVal,
+	_ []byte,
+	_ *btcec.ModNScalar,
+) (*types.TxResponse, error) {
+	return dc.record("not broadcasting the finality signature",
+		zap.String("pk", bbntypes.NewBIP340PubKeyFromBTCPK(fpPk).MarshalHex()),
+		zap.Uint64("height", block.Height),
+	), nil
+}
+
+func (dc *DryRunController) SubmitBatchFinalitySigs(
+	fpPk *btcec.PublicKey,
+	blocks []*types.BlockInfo,
+	_ []*btcec.FieldVal,
+	_ [][]byte,
+	_ []*btcec.ModNScalar,
+) (*types.TxResponse, error) {
+	if len(blocks) == 0 {
+		return nil, fmt.Errorf("should not submit batch finality signature with zero block")
+	}
+
+	return dc.record("not broadcasting the finality signatures",
+		zap.String("pk", bbntypes.NewBIP340PubKeyFromBTCPK(fpPk).MarshalHex()),
+		zap.Uint64("start_height", blocks[0].Height),
+		zap.Uint64("end_height", blocks[len(blocks)-1].Height),
+	), nil
+}
+
+func (dc *DryRunController) UnjailFinalityProvider(fpPk *btcec.PublicKey) (*types.TxResponse, error) {
+	return dc.record("not broadcasting the unjailing",
+		zap.String("pk", bbntypes.NewBIP340PubKeyFromBTCPK(fpPk).MarshalHex()),
+	), nil
+}
+
+func (dc *DryRunController) WithdrawFinalityProviderRewards(amount sdk.Coins, recipient string) (*types.TxResponse, error) {
+	return dc.record("not broadcasting the reward withdrawal",
+		zap.Stringer("amount", amount),
+		zap.String("recipient", recipient),
+	), nil
+}
+
+func (dc *DryRunController) WithdrawRewardsOfFinalityProvider(
+	fpPk *btcec.PublicKey,
+	amount sdk.Coins,
+	recipient string,
+) (*types.TxResponse, error) {
+	return dc.record("not broadcasting the reward withdrawal",
+		zap.String("pk", bbntypes.NewBIP340PubKeyFromBTCPK(fpPk).MarshalHex()),
+		zap.Stringer("amount", amount),
+		zap.String("recipient", recipient),
+	), nil
+}
+
+func (dc *DryRunController) SubmitSelectiveSlashingEvidence(recoveredSk *btcec.PrivateKey) (*types.TxResponse, error) {
+	return dc.record("not broadcasting the selective slashing evidence",
+		zap.String("pk", bbntypes.NewBIP340PubKeyFromBTCPK(recoveredSk.PubKey()).MarshalHex()),
+	), nil
+}
+
+func (dc *DryRunController) EditFinalityProvider(
+	fpPk *btcec.PublicKey,
+	commission *math.LegacyDec,
+	_ []byte,
+) (*btcstakingtypes.MsgEditFinalityProvider, error) {
+	dc.record("not broadcasting the finality provider edit",
+		zap.String("pk", bbntypes.NewBIP340PubKeyFromBTCPK(fpPk).MarshalHex()),
+		zap.Stringer("commission", commission),
+	)
+
+	return &btcstakingtypes.MsgEditFinalityProvider{
+		BtcPk:      bbntypes.NewBIP340PubKeyFromBTCPK(fpPk).MustMarshal(),
+		Commission: commission,
+	}, nil
+}
+
+func (dc *DryRunController) UpdateFinalityProviderCommission(fpPk *btcec.PublicKey, rate math.LegacyDec) (*types.TxResponse, error) {
+	return dc.record("not broadcasting the commission update",
+		zap.String("pk", bbntypes.NewBIP340PubKeyFromBTCPK(fpPk).MarshalHex()),
+		zap.Stringer("rate", rate),
+	), nil
+}
+
+// QueryLastCommittedPublicRand returns the last committed public randomness
+// on-chain, with the one committed in dry-run mode if it is more recent
+func (dc *DryRunController) QueryLastCommittedPublicRand(fpPk *btcec.PublicKey, count uint64) (map[uint64]*finalitytypes.PubRandCommitResponse, error) {
+	res, err := dc.ClientController.QueryLastCommittedPublicRand(fpPk, count)
+	if err != nil {
+		return nil, err
+	}
+
+	dc.mu.Lock()
+	commit, ok := dc.pubRandCommits[bbntypes.NewBIP340PubKeyFromBTCPK(fpPk).MarshalHex()]
+	dc.mu.Unlock()
+	if !ok || count == 0 {
+		return res, nil
+	}
+
+	var lowest uint64
+	for startHeight := range res {
+		if startHeight >= commit.startHeight {
+			// the dry-run commitment is outdated
+			return res, nil
+		}
+		if lowest == 0 || startHeight < lowest {
+			lowest = startHeight
+		}
+	}
+
+	merged := make(map[uint64]*finalitytypes.PubRandCommitResponse, len(res)+1)
+	for startHeight, resp := range res {
+		merged[startHeight] = resp
+	}
+	merged[commit.startHeight] = commit.resp
+	if uint64(len(merged)) > count {
+		delete(merged, lowest)
+	}
+
+	return merged, nil
+}
@@ -0,0 +1,53 @@
+package clientcontroller_test
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/babylon/testutil/datagen"
+	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+	"github.com/babylonlabs-io/finality-provider/testutil/mocks"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+func TestDryRunController(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	_, fpPk, err := datagen.GenRandomBTCKeyPair(r)
+	require.NoError(t, err)
+
+	ctl := gomock.NewController(t)
+	mockClientController := mocks.NewMockClientController(ctl)
+	dc := clientcontroller.NewDryRunController(mockClientController, zap.NewNop())
+
+	// the transactions are not forwarded
+	res, err := dc.SubmitBatchFinalitySigs(fpPk, []*types.BlockInfo{{Height: 10}, {Height: 11}}, nil, nil, nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, res.TxHash)
+
+	onChain := map[uint64]*finalitytypes.PubRandCommitResponse{1: {NumPubRand: 100}}
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(fpPk, uint64(1)).Return(onChain, nil).AnyTimes()
+
+	// the outdated dry-run commitment is ignored
+	_, err = dc.CommitPubRandList(fpPk, 1, 50, []byte("outdated"), nil)
+	require.NoError(t, err)
+	commits, err := dc.QueryLastCommittedPublicRand(fpPk, 1)
+	require.NoError(t, err)
+	require.Equal(t, onChain, commits)
+
+	// the dry-run commitment is returned once more recent than on-chain
+	_, err = dc.CommitPubRandList(fpPk, 101, 100, []byte("commitment"), nil)
+	require.NoError(t, err)
+	commits, err = dc.QueryLastCommittedPublicRand(fpPk, 1)
+	require.NoError(t, err)
+	require.Len(t, commits, 1)
+	require.Equal(t, uint64(100), commits[101].NumPubRand)
+	require.Equal(t, []byte("commitment"), commits[101].Commitment)
+}
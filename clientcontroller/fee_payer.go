@@ -0,0 +1,240 @@
+package clientcontroller
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	sdkErr "cosmossdk.io/errors"
+	"github.com/avast/retry-go/v4"
+	bbnclient "github.com/babylonlabs-io/babylon/client/client"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/relayer/v2/relayer/provider"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+)
+
+const (
+	feePayerRtyAttNum  = 5
+	feePayerRtyDel     = 400 * time.Millisecond
+	txInclusionPollDel = 500 * time.Millisecond
+)
+
+// feePayer is a key of the keyring which broadcasts the transactions on
+// behalf of the finality providers. Its use is serialized so that the
+// sequence of its account is not contended
+type feePayer struct {
+	mu sync.Mutex
+
+	name   string
+	addr   sdk.AccAddress
+	signer string
+	sk     *secp256k1.PrivKey
+}
+
+// feePayerPool rotates the fee payers so that the public randomness commits
+// and the finality signatures can be in flight concurrently
+type feePayerPool struct {
+	payers []*feePayer
+	next   atomic.Uint64
+}
+
+func newFeePayerPool(kr keyring.Keyring, names []string, mainKey, accPrefix string) (*feePayerPool, error) {
+	pool := &feePayerPool{}
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		if name == mainKey {
+			return nil, fmt.Errorf("the fee payer key %s should not be the key signing the other transactions", name)
+		}
+		if _, ok := seen[name]; ok {
+			return nil, fmt.Errorf("duplicated fee payer key %s", name)
+		}
+		seen[name] = struct{}{}
+
+		payer, err := newFeePayer(kr, name, accPrefix)
+		if err != nil {
+			return nil, err
+		}
+		pool.payers = append(pool.payers, payer)
+	}
+
+	return pool, nil
+}
+
+func newFeePayer(kr keyring.Keyring, name, accPrefix string) (*feePayer, error) {
+	record, err := kr.Key(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the fee payer key %s: %w", name, err)
+	}
+
+	local := record.GetLocal()
+	if local == nil || local.PrivKey == nil {
+		return nil, fmt.Errorf("the private key of the fee payer key %s is not stored in the keyring", name)
+	}
+
+	sk, ok := local.PrivKey.GetCachedValue().(*secp256k1.PrivKey)
+	if !ok {
+		return nil, fmt.Errorf("the fee payer key %s is not a secp256k1 key", name)
+	}
+
+	addr, err := record.GetAddress()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the address of the fee payer key %s: %w", name, err)
+	}
+
+	signer, err := sdk.Bech32ifyAddressBytes(accPrefix, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &feePayer{
+		name:   name,
+		addr:   addr,
+		signer: signer,
+		sk:     sk,
+	}, nil
+}
+
+func (p *feePayerPool) isEmpty() bool {
+	return p == nil || len(p.payers) == 0
+}
+
+// acquire locks the next free fee payer in rotation, or waits for the next
+// one in rotation if all of them are in use. The fee payer is to be
+// released once its transaction is included
+func (p *feePayerPool) acquire() *feePayer {
+	n := uint64(len(p.payers))
+	start := p.next.Inc() - 1
+	for i := uint64(0); i < n; i++ {
+		payer := p.payers[(start+i)%n]
+		if payer.mu.TryLock() {
+			return payer
+		}
+	}
+
+	payer := p.payers[start%n]
+	payer.mu.Lock()
+
+	return payer
+}
+
+func (p *feePayer) release() {
+	p.mu.Unlock()
+}
+
+// reliablySendMsgsWithFeePayer sends the msgs built for the signer by a fee
+// payer of the pool and waits for their inclusion, or by the Key if there is
+// no fee payer. It is used for the msgs whose signer is not required to be
+// the address of the finality provider
+func (bc *BabylonController) reliablySendMsgsWithFeePayer(
+	buildMsgs func(signer string) ([]sdk.Msg, error),
+	expectedErrs []*sdkErr.Error,
+	unrecoverableErrs []*sdkErr.Error,
+) (*provider.RelayerTxResponse, error) {
+	if bc.feePayers.isEmpty() {
+		msgs, err := buildMsgs(bc.mustGetTxSigner())
+		if err != nil {
+			return nil, err
+		}
+
+		return bc.reliablySendMsgs(msgs, expectedErrs, unrecoverableErrs)
+	}
+
+	payer := bc.feePayers.acquire()
+	defer payer.release()
+
+	msgs, err := buildMsgs(payer.signer)
+	if err != nil {
+		return nil, err
+	}
+
+	var res *provider.RelayerTxResponse
+	if err := retry.Do(func() error {
+		var sendErr error
+		res, sendErr = bc.sendMsgsWithFeePayer(payer, msgs)
+		if sendErr == nil {
+			return nil
+		}
+		if errorContained(sendErr, expectedErrs) {
+			bc.logger.Debug("expected err when submitting the tx, skip retrying",
+				zap.String("fee_payer", payer.name), zap.Error(sendErr))
+			res = nil
+			return nil
+		}
+		if errorContained(sendErr, unrecoverableErrs) {
+			return retry.Unrecoverable(sendErr)
+		}
+
+		return sendErr
+	}, retry.Context(bc.ctx), retry.Attempts(feePayerRtyAttNum), retry.Delay(feePayerRtyDel), retry.LastErrorOnly(true),
+		retry.OnRetry(func(n uint, err error) {
+			bc.logger.Debug("retrying to submit the tx with the fee payer",
+				zap.String("fee_payer", payer.name), zap.Uint("attempt", n+1), zap.Error(err))
+		})); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// sendMsgsWithFeePayer broadcasts the msgs signed by the fee payer and waits
+// for their inclusion so that the next transaction of the fee payer gets the
+// next sequence
+func (bc *BabylonController) sendMsgsWithFeePayer(payer *feePayer, msgs []sdk.Msg) (*provider.RelayerTxResponse, error) {
+	broadcastRes, err := bc.bbnClient.SendMessageWithSigner(bc.ctx, payer.addr, payer.sk, bbnclient.ToProviderMsgs(msgs))
+	if err != nil {
+		return nil, err
+	}
+	if broadcastRes.Code != 0 {
+		return nil, sdkErr.ABCIError(broadcastRes.Codespace, broadcastRes.Code, broadcastRes.Log)
+	}
+
+	ctx, cancel := getContextWithCancel(bc.ctx, bc.cfg.BlockTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(txInclusionPollDel)
+	defer ticker.Stop()
+
+	for {
+		txRes, err := bc.bbnClient.RPCClient.Tx(ctx, broadcastRes.Hash, false)
+		if err == nil {
+			if txRes.TxResult.Code != 0 {
+				return nil, sdkErr.ABCIError(txRes.TxResult.Codespace, txRes.TxResult.Code, txRes.TxResult.Log)
+			}
+
+			return &provider.RelayerTxResponse{
+				Height:    txRes.Height,
+				TxHash:    broadcastRes.Hash.String(),
+				Codespace: txRes.TxResult.Codespace,
+				Code:      txRes.TxResult.Code,
+				Data:      string(txRes.TxResult.Data),
+				Events:    relayerEvents(txRes.TxResult.Events),
+			}, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("the tx %X is not included in time: %w", broadcastRes.Hash, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func relayerEvents(events []abci.Event) []provider.RelayerEvent {
+	res := make([]provider.RelayerEvent, 0, len(events))
+	for _, event := range events {
+		attributes := make(map[string]string, len(event.Attributes))
+		for _, attr := range event.Attributes {
+			attributes[attr.Key] = attr.Value
+		}
+		res = append(res, provider.RelayerEvent{
+			EventType:  event.Type,
+			Attributes: attributes,
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,41 @@
+package clientcontroller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeePayerPoolAcquire(t *testing.T) {
+	t.Parallel()
+	pool := &feePayerPool{
+		payers: []*feePayer{{name: "payer-0"}, {name: "payer-1"}, {name: "payer-2"}},
+	}
+
+	// the fee payers are acquired in rotation
+	payer0 := pool.acquire()
+	require.Equal(t, "payer-0", payer0.name)
+	payer0.release()
+	payer1 := pool.acquire()
+	require.Equal(t, "payer-1", payer1.name)
+
+	// the fee payers in use are skipped
+	payer2 := pool.acquire()
+	require.Equal(t, "payer-2", payer2.name)
+	payer := pool.acquire()
+	require.Equal(t, "payer-0", payer.name)
+
+	payer.release()
+	payer1.release()
+	payer2.release()
+}
+
+func TestNewFeePayerPoolInvalidKeys(t *testing.T) {
+	t.Parallel()
+	_, err := newFeePayerPool(nil, []string{"main"}, "main", "bbn")
+	require.ErrorContains(t, err, "should not be the key signing")
+
+	pool, err := newFeePayerPool(nil, nil, "main", "bbn")
+	require.NoError(t, err)
+	require.True(t, pool.isEmpty())
+}
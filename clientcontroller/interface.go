@@ -2,12 +2,15 @@ package clientcontroller
 
 import (
 	"fmt"
+	"time"
 
 	"cosmossdk.io/math"
+	bbntypes "github.com/babylonlabs-io/babylon/types"
 	btcstakingtypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/btcsuite/btcd/chaincfg"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"go.uber.org/zap"
 
 	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
@@ -44,15 +47,77 @@ type ClientController interface {
 	// UnjailFinalityProvider sends an unjail transaction to the consumer chain
 	UnjailFinalityProvider(fpPk *btcec.PublicKey) (*types.TxResponse, error)
 
+	// WithdrawFinalityProviderRewards withdraws the finality provider rewards of the
+	// key signing the transactions and sends the given amount to the recipient in
+	// the same transaction if the recipient is not empty
+	WithdrawFinalityProviderRewards(amount sdk.Coins, recipient string) (*types.TxResponse, error)
+
+	// QueryFinalityProviderRewards queries the withdrawable finality provider rewards
+	// of the key signing the transactions
+	QueryFinalityProviderRewards() (sdk.Coins, error)
+
+	// QueryRewardsOfFinalityProvider queries the withdrawable rewards accrued to
+	// the Babylon address of the given finality provider
+	QueryRewardsOfFinalityProvider(fpPk *btcec.PublicKey) (sdk.Coins, error)
+
+	// WithdrawRewardsOfFinalityProvider withdraws the rewards of the given finality
+	// provider, whose Babylon address must be the key signing the transactions, and
+	// sends the given amount to the recipient in the same transaction if the
+	// recipient is not empty
+	WithdrawRewardsOfFinalityProvider(fpPk *btcec.PublicKey, amount sdk.Coins, recipient string) (*types.TxResponse, error)
+
+	// QueryNodeChainID queries the chain id of the node the controller is connected to
+	QueryNodeChainID() (string, error)
+
+	// QuerySignerBalance queries the balance of the key signing the transactions
+	QuerySignerBalance() (sdk.Coins, error)
+
+	// QueryFeeAccountBalances queries the balances of the accounts paying the
+	// fees, i.e., the key signing the transactions and the fee payers, keyed
+	// by their address
+	QueryFeeAccountBalances() (map[string]sdk.Coins, error)
+
 	// QueryFinalityProviderVotingPower queries the voting power of the finality provider at a given height
 	QueryFinalityProviderVotingPower(fpPk *btcec.PublicKey, blockHeight uint64) (uint64, error)
 
+	// QueryFinalityProviderDelegations queries the BTC delegations to the
+	// finality provider and summarizes them by status
+	QueryFinalityProviderDelegations(fpPk *btcec.PublicKey) (*types.DelegationSummary, error)
+
 	// QueryFinalityProviderSlashedOrJailed queries if the finality provider is slashed or jailed
 	QueryFinalityProviderSlashedOrJailed(fpPk *btcec.PublicKey) (slashed bool, jailed bool, err error)
 
+	// QueryFinalityProviderJailedUntil queries the time until which the finality provider is jailed
+	QueryFinalityProviderJailedUntil(fpPk *btcec.PublicKey) (time.Time, error)
+
+	// QueryLatestBlockTime queries the timestamp of the latest block
+	QueryLatestBlockTime() (time.Time, error)
+
+	// QueryFinalityProvider queries the finality provider registered on the consumer chain
+	QueryFinalityProvider(fpPk *btcec.PublicKey) (*btcstakingtypes.QueryFinalityProviderResponse, error)
+
+	// QueryMinCommissionRate queries the minimum commission rate of the finality providers
+	QueryMinCommissionRate() (math.LegacyDec, error)
+
+	// QueryEvidences queries the equivocation evidences of the finality providers
+	// at heights not lower than the start height, which allow extracting their keys
+	QueryEvidences(startHeight uint64) ([]*finalitytypes.Evidence, error)
+
+	// QueryVotesAtHeight queries the public keys of the finality providers which
+	// have voted for the block at the given height
+	QueryVotesAtHeight(height uint64) ([]bbntypes.BIP340PubKey, error)
+
+	// SubmitSelectiveSlashingEvidence submits the BTC secret key extracted from an
+	// equivocation evidence to slash the finality provider owning the key
+	SubmitSelectiveSlashingEvidence(recoveredSk *btcec.PrivateKey) (*types.TxResponse, error)
+
 	// EditFinalityProvider edits description and commission of a finality provider
 	EditFinalityProvider(fpPk *btcec.PublicKey, commission *math.LegacyDec, description []byte) (*btcstakingtypes.MsgEditFinalityProvider, error)
 
+	// UpdateFinalityProviderCommission updates the commission rate of a finality provider
+	// after checking it against the limits of the consumer chain
+	UpdateFinalityProviderCommission(fpPk *btcec.PublicKey, rate math.LegacyDec) (*types.TxResponse, error)
+
 	// QueryLatestFinalizedBlocks returns the latest finalized blocks
 	QueryLatestFinalizedBlocks(count uint64) ([]*types.BlockInfo, error)
 
@@ -34,6 +34,18 @@ func IsUnrecoverable(err error) bool {
 	return false
 }
 
+// errorContained returns true when the error contains one of the errors in
+// the list
+func errorContained(err error, errList []*sdkErr.Error) bool {
+	for _, e := range errList {
+		if strings.Contains(err.Error(), e.Error()) {
+			return true
+		}
+	}
+
+	return false
+}
+
 type ExpectedError struct {
 	error
 }
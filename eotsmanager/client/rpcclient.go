@@ -2,27 +2,52 @@ package client
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 
 	"github.com/babylonlabs-io/finality-provider/eotsmanager"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/proto"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/types"
 )
 
-var _ eotsmanager.EOTSManager = &EOTSManagerGRpcClient{}
+var (
+	_ eotsmanager.EOTSManager = &EOTSManagerGRpcClient{}
+	_ eotsmanager.Leaser      = &EOTSManagerGRpcClient{}
+)
 
 type EOTSManagerGRpcClient struct {
 	client proto.EOTSManagerClient
 	conn   *grpc.ClientConn
+
+	// leasesMu protects leases
+	leasesMu sync.RWMutex
+	// leases keeps the leases acquired by this client by EOTS key hex,
+	// they are attached to the requests for EOTS signatures
+	leases map[string]*types.Lease
 }
 
 func NewEOTSManagerGRpcClient(remoteAddr string) (*EOTSManagerGRpcClient, error) {
-	conn, err := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	return NewEOTSManagerGRpcClientWithLogger(remoteAddr, zap.NewNop())
+}
+
+// NewEOTSManagerGRpcClientWithLogger is like NewEOTSManagerGRpcClient but
+// the calls to the EOTS manager are logged at the debug level, and their
+// failures at the warn level
+func NewEOTSManagerGRpcClientWithLogger(remoteAddr string, logger *zap.Logger) (*EOTSManagerGRpcClient, error) {
+	conn, err := grpc.NewClient(remoteAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(loggingInterceptor(logger)),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build gRPC connection to %s: %w", remoteAddr, err)
 	}
@@ -30,6 +55,7 @@ func NewEOTSManagerGRpcClient(remoteAddr string) (*EOTSManagerGRpcClient, error)
 	gClient := &EOTSManagerGRpcClient{
 		client: proto.NewEOTSManagerClient(conn),
 		conn:   conn,
+		leases: make(map[string]*types.Lease),
 	}
 
 	if err := gClient.Ping(); err != nil {
@@ -39,6 +65,27 @@ func NewEOTSManagerGRpcClient(remoteAddr string) (*EOTSManagerGRpcClient, error)
 	return gClient, nil
 }
 
+// loggingInterceptor logs the methods of the calls to the EOTS manager along
+// with their duration, the requests holding the passphrases are not logged
+func loggingInterceptor(logger *zap.Logger) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{},
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		fields := []zap.Field{
+			zap.String("method", method),
+			zap.Duration("duration", time.Since(start)),
+		}
+		if err != nil {
+			logger.Warn("the call to the EOTS manager failed", append(fields, zap.Error(err))...)
+		} else {
+			logger.Debug("called the EOTS manager", fields...)
+		}
+
+		return err
+	}
+}
+
 func (c *EOTSManagerGRpcClient) Ping() error {
 	req := &proto.PingRequest{}
 
@@ -107,9 +154,15 @@ func (c *EOTSManagerGRpcClient) SignEOTS(uid, chaiID, msg []byte, height uint64,
 		Height:     height,
 		Passphrase: passphrase,
 	}
+	c.leasesMu.RLock()
+	if lease, ok := c.leases[hex.EncodeToString(uid)]; ok {
+		req.LeaseHolder = lease.Holder
+		req.LeaseTerm = lease.Term
+	}
+	c.leasesMu.RUnlock()
 	res, err := c.client.SignEOTS(context.Background(), req)
 	if err != nil {
-		return nil, err
+		return nil, eotsmanager.LeaseErrorFromStatus(err)
 	}
 
 	var s btcec.ModNScalar
@@ -118,11 +171,73 @@ func (c *EOTSManagerGRpcClient) SignEOTS(uid, chaiID, msg []byte, height uint64,
 	return &s, nil
 }
 
+// SignEOTSBatch signs a batch of EOTS in one request. It falls back to
+// signing the messages one by one if the EOTS manager does not support
+// batch signing
+func (c *EOTSManagerGRpcClient) SignEOTSBatch(uid, chainID []byte, msgs [][]byte, heights []uint64, passphrase string) ([]*btcec.ModNScalar, error) {
+	req := &proto.SignEOTSBatchRequest{
+		Uid:        uid,
+		ChainId:    chainID,
+		Msgs:       msgs,
+		Heights:    heights,
+		Passphrase: passphrase,
+	}
+	c.leasesMu.RLock()
+	if lease, ok := c.leases[hex.EncodeToString(uid)]; ok {
+		req.LeaseHolder = lease.Holder
+		req.LeaseTerm = lease.Term
+	}
+	c.leasesMu.RUnlock()
+	res, err := c.client.SignEOTSBatch(context.Background(), req)
+	if status.Code(err) == codes.Unimplemented {
+		return c.signEOTSOneByOne(uid, chainID, msgs, heights, passphrase)
+	}
+	if err != nil {
+		return nil, eotsmanager.LeaseErrorFromStatus(err)
+	}
+
+	if len(res.Sigs) != len(msgs) {
+		return nil, fmt.Errorf("the number of signatures %d does not match the number of messages %d", len(res.Sigs), len(msgs))
+	}
+
+	sigs := make([]*btcec.ModNScalar, 0, len(res.Sigs))
+	for _, sigBytes := range res.Sigs {
+		var s btcec.ModNScalar
+		s.SetByteSlice(sigBytes)
+		sigs = append(sigs, &s)
+	}
+
+	return sigs, nil
+}
+
+func (c *EOTSManagerGRpcClient) signEOTSOneByOne(uid, chainID []byte, msgs [][]byte, heights []uint64, passphrase string) ([]*btcec.ModNScalar, error) {
+	if len(msgs) != len(heights) {
+		return nil, fmt.Errorf("the number of messages %d does not match the number of heights %d", len(msgs), len(heights))
+	}
+
+	sigs := make([]*btcec.ModNScalar, 0, len(msgs))
+	for i, msg := range msgs {
+		sig, err := c.SignEOTS(uid, chainID, msg, heights[i], passphrase)
+		if err != nil {
+			return nil, err
+		}
+		sigs = append(sigs, sig)
+	}
+
+	return sigs, nil
+}
+
 func (c *EOTSManagerGRpcClient) SignSchnorrSig(uid, msg []byte, passphrase string) (*schnorr.Signature, error) {
 	req := &proto.SignSchnorrSigRequest{Uid: uid, Msg: msg, Passphrase: passphrase}
+	c.leasesMu.RLock()
+	if lease, ok := c.leases[hex.EncodeToString(uid)]; ok {
+		req.LeaseHolder = lease.Holder
+		req.LeaseTerm = lease.Term
+	}
+	c.leasesMu.RUnlock()
 	res, err := c.client.SignSchnorrSig(context.Background(), req)
 	if err != nil {
-		return nil, err
+		return nil, eotsmanager.LeaseErrorFromStatus(err)
 	}
 
 	sig, err := schnorr.ParseSignature(res.Sig)
@@ -133,6 +248,44 @@ func (c *EOTSManagerGRpcClient) SignSchnorrSig(uid, msg []byte, passphrase strin
 	return sig, nil
 }
 
+// AcquireLease acquires or renews the lease of the EOTS key, the lease is
+// attached to the subsequent requests for EOTS signatures with the key
+func (c *EOTSManagerGRpcClient) AcquireLease(uid []byte, holder string, ttl time.Duration) (*types.Lease, error) {
+	req := &proto.AcquireLeaseRequest{
+		Uid:    uid,
+		Holder: holder,
+		// #nosec G115 -- the ttl is checked to be positive by the config
+		TtlMs: uint64(ttl.Milliseconds()),
+	}
+	res, err := c.client.AcquireLease(context.Background(), req)
+	if err != nil {
+		return nil, eotsmanager.LeaseErrorFromStatus(err)
+	}
+
+	lease := &types.Lease{
+		Holder:    holder,
+		Term:      res.Term,
+		ExpiresAt: time.UnixMilli(res.ExpiresAtUnixMs),
+	}
+	c.leasesMu.Lock()
+	c.leases[hex.EncodeToString(uid)] = lease
+	c.leasesMu.Unlock()
+
+	return lease, nil
+}
+
+// ReleaseLease releases the lease of the EOTS key
+func (c *EOTSManagerGRpcClient) ReleaseLease(uid []byte, holder string) error {
+	c.leasesMu.Lock()
+	delete(c.leases, hex.EncodeToString(uid))
+	c.leasesMu.Unlock()
+
+	req := &proto.ReleaseLeaseRequest{Uid: uid, Holder: holder}
+	_, err := c.client.ReleaseLease(context.Background(), req)
+
+	return eotsmanager.LeaseErrorFromStatus(err)
+}
+
 func (c *EOTSManagerGRpcClient) Close() error {
 	return c.conn.Close()
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/util"
 )
 
 // NewRootCmd creates a new root command for fpd. It is called once in the main function.
@@ -18,6 +19,8 @@ func NewRootCmd() *cobra.Command {
 	}
 
 	rootCmd.PersistentFlags().String(sdkflags.FlagHome, config.DefaultEOTSDir, "The application home directory")
+	util.AddOutputFlag(rootCmd)
+	rootCmd.SetFlagErrorFunc(util.SilenceFlagErrors)
 
 	rootCmd.AddCommand(
 		NewInitCmd(),
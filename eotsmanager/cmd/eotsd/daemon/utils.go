@@ -34,6 +34,10 @@ func getHomePath(cmd *cobra.Command) (string, error) {
 // and exists a value in the config that could be used, it will be set in the ctx.
 func PersistClientCtx(ctx client.Context) func(cmd *cobra.Command, _ []string) error {
 	return func(cmd *cobra.Command, _ []string) error {
+		if err := util.CheckOutputFormat(cmd); err != nil {
+			return err
+		}
+
 		encCfg := params.DefaultEncodingConfig()
 		std.RegisterInterfaces(encCfg.InterfaceRegistry)
 
@@ -5,11 +5,16 @@ import (
 	"os"
 
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/cmd/eotsd/daemon"
+	"github.com/babylonlabs-io/finality-provider/util"
 )
 
 func main() {
-	if err := daemon.NewRootCmd().Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error while executing eotsd CLI: %s", err.Error())
+	if c, err := daemon.NewRootCmd().ExecuteC(); err != nil {
+		if util.IsJSONOutput(c) {
+			util.PrintJSONError(os.Stderr, err)
+		} else {
+			fmt.Fprintf(os.Stderr, "Error while executing eotsd CLI: %s", err.Error())
+		}
 		os.Exit(1)
 	}
 }
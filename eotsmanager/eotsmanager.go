@@ -31,6 +31,11 @@ type EOTSManager interface {
 	// or passPhrase is incorrect
 	SignEOTS(uid []byte, chainID []byte, msg []byte, height uint64, passphrase string) (*btcec.ModNScalar, error)
 
+	// SignEOTSBatch signs a batch of EOTS at once, where msgs[i] is signed
+	// with the secret randomness of the given chain at heights[i]
+	// It fails if any of the messages cannot be signed
+	SignEOTSBatch(uid []byte, chainID []byte, msgs [][]byte, heights []uint64, passphrase string) ([]*btcec.ModNScalar, error)
+
 	// SignSchnorrSig signs a Schnorr signature using the private key of the finality provider
 	// It fails if the finality provider does not exist or the message size is not 32 bytes
 	// or passPhrase is incorrect
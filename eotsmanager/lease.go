@@ -0,0 +1,229 @@
+package eotsmanager
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/types"
+)
+
+// Leaser is implemented by the EOTS managers that elect a leader among the
+// finality provider daemons sharing an EOTS key. Only the leader is allowed
+// to sign EOTS signatures so that a standby daemon cannot double sign
+type Leaser interface {
+	// AcquireLease acquires or renews the lease of the EOTS key for the
+	// given holder. It fails with ErrLeaseHeld if the lease is held by
+	// another holder and has not expired
+	AcquireLease(uid []byte, holder string, ttl time.Duration) (*types.Lease, error)
+
+	// ReleaseLease releases the lease of the EOTS key if it is held by the
+	// given holder so that a standby can take over without waiting for
+	// the lease to expire
+	ReleaseLease(uid []byte, holder string) error
+}
+
+// LeaseStore persists the leases of the EOTS keys
+type LeaseStore interface {
+	SaveLease(uid []byte, lease *types.Lease) error
+	GetLeases() (map[string]*types.Lease, error)
+}
+
+// LeaseTable keeps the leases of the EOTS keys. The table is kept by a
+// single EOTS manager, which fences the finality provider daemons sharing
+// it, and the leases are persisted so that the fencing survives its
+// restarts
+type LeaseTable struct {
+	mu     sync.Mutex
+	leases map[string]*types.Lease
+	// store is nil if the leases are kept in memory only
+	store LeaseStore
+	now   func() time.Time
+}
+
+// NewLeaseTable creates an empty lease table kept in memory only
+func NewLeaseTable() *LeaseTable {
+	return NewLeaseTableWithClock(time.Now)
+}
+
+// NewLeaseTableWithClock creates an empty lease table kept in memory only
+// using the given clock
+func NewLeaseTableWithClock(now func() time.Time) *LeaseTable {
+	return &LeaseTable{
+		leases: make(map[string]*types.Lease),
+		now:    now,
+	}
+}
+
+// NewPersistentLeaseTable creates a lease table loaded from and saving the
+// leases to the given store
+func NewPersistentLeaseTable(s LeaseStore) (*LeaseTable, error) {
+	return NewPersistentLeaseTableWithClock(s, time.Now)
+}
+
+// NewPersistentLeaseTableWithClock is like NewPersistentLeaseTable but uses
+// the given clock
+func NewPersistentLeaseTableWithClock(s LeaseStore, now func() time.Time) (*LeaseTable, error) {
+	leases, err := s.GetLeases()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the leases: %w", err)
+	}
+
+	return &LeaseTable{
+		leases: leases,
+		store:  s,
+		now:    now,
+	}, nil
+}
+
+// save persists the lease of the EOTS key before it is applied to the table
+func (lt *LeaseTable) save(uid []byte, lease *types.Lease) error {
+	if lt.store == nil {
+		return nil
+	}
+	if err := lt.store.SaveLease(uid, lease); err != nil {
+		return fmt.Errorf("failed to save the lease: %w", err)
+	}
+
+	return nil
+}
+
+// Acquire grants the lease of the EOTS key to the holder if it is free,
+// expired, or already held by the holder, in which case it is renewed
+func (lt *LeaseTable) Acquire(uid []byte, holder string, ttl time.Duration) (*types.Lease, error) {
+	if holder == "" {
+		return nil, fmt.Errorf("empty lease holder")
+	}
+	if ttl <= 0 {
+		return nil, fmt.Errorf("the lease ttl must be positive, got %v", ttl)
+	}
+
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+
+	now := lt.now()
+	key := hex.EncodeToString(uid)
+	var lease types.Lease
+	prev, exists := lt.leases[key]
+	switch {
+	case !exists:
+		lease = types.Lease{Holder: holder, Term: 1}
+	case prev.Holder == holder && now.Before(prev.ExpiresAt):
+		// renewal, the term is kept
+		lease = *prev
+	case now.Before(prev.ExpiresAt):
+		return nil, fmt.Errorf("%w: %s until %s", types.ErrLeaseHeld, prev.Holder, prev.ExpiresAt.UTC())
+	default:
+		// the previous lease expired, any signature signed by the previous
+		// term is rejected from now on
+		lease = types.Lease{Holder: holder, Term: prev.Term + 1}
+	}
+	lease.ExpiresAt = now.Add(ttl)
+
+	if err := lt.save(uid, &lease); err != nil {
+		return nil, err
+	}
+	lt.leases[key] = &lease
+
+	res := lease
+
+	return &res, nil
+}
+
+// Release expires the lease of the EOTS key if it is held by the holder
+func (lt *LeaseTable) Release(uid []byte, holder string) error {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+
+	key := hex.EncodeToString(uid)
+	prev, exists := lt.leases[key]
+	if !exists || prev.Holder != holder {
+		return fmt.Errorf("%w: %s", types.ErrNotLeaseHolder, holder)
+	}
+	lease := *prev
+	lease.ExpiresAt = lt.now()
+
+	if err := lt.save(uid, &lease); err != nil {
+		return err
+	}
+	lt.leases[key] = &lease
+
+	return nil
+}
+
+// Check returns an error if the holder does not hold the valid lease of
+// the EOTS key at the given term. The requests without a holder for keys
+// which have never been leased are not subject to the leader election and
+// pass the check, while the ones with a holder are rejected as long as no
+// lease has been granted
+func (lt *LeaseTable) Check(uid []byte, holder string, term uint64) error {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+
+	lease, exists := lt.leases[hex.EncodeToString(uid)]
+	if !exists {
+		if holder != "" {
+			return fmt.Errorf("%w: no lease has been granted to %s", types.ErrNotLeaseHolder, holder)
+		}
+		return nil
+	}
+
+	if lease.Holder != holder || lease.Term != term || !lt.now().Before(lease.ExpiresAt) {
+		return fmt.Errorf("%w: %s at term %d", types.ErrNotLeaseHolder, holder, term)
+	}
+
+	return nil
+}
+
+// leaseErrCodes maps the lease errors to the gRPC status codes they are
+// returned with by the EOTS manager server
+var leaseErrCodes = []struct {
+	err  error
+	code codes.Code
+}{
+	{types.ErrLeaseHeld, codes.AlreadyExists},
+	{types.ErrNotLeaseHolder, codes.PermissionDenied},
+}
+
+// LeaseStatusError converts the lease errors to gRPC status errors, so that
+// the clients can convert them back with LeaseErrorFromStatus. The message of
+// the status is tagged with the text of the lease error, which tells it apart
+// from the other errors returned with the same code, e.g., the authentication
+// failures. The other errors are returned as they are
+func LeaseStatusError(err error) error {
+	for _, c := range leaseErrCodes {
+		if errors.Is(err, c.err) {
+			msg := err.Error()
+			if !strings.HasPrefix(msg, c.err.Error()) {
+				msg = fmt.Sprintf("%s: %s", c.err, msg)
+			}
+			return status.Error(c.code, msg)
+		}
+	}
+
+	return err
+}
+
+// LeaseErrorFromStatus converts the gRPC status errors returned by
+// LeaseStatusError back to the lease errors, which can be matched with
+// errors.Is. Only the statuses tagged with the text of a lease error are
+// converted, the other errors are returned as they are
+func LeaseErrorFromStatus(err error) error {
+	st, ok := status.FromError(err)
+	if !ok || err == nil {
+		return err
+	}
+	for _, c := range leaseErrCodes {
+		if st.Code() == c.code && strings.HasPrefix(st.Message(), c.err.Error()) {
+			return fmt.Errorf("%w%s", c.err, strings.TrimPrefix(st.Message(), c.err.Error()))
+		}
+	}
+
+	return err
+}
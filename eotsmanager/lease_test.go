@@ -0,0 +1,136 @@
+package eotsmanager_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/store"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/types"
+)
+
+func TestLeaseTable(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1000, 0)
+	lt := eotsmanager.NewLeaseTableWithClock(func() time.Time { return now })
+	uid := []byte("eots-key")
+	ttl := 10 * time.Second
+
+	// keys which have never been leased can be signed with by the daemons
+	// not taking part in the leader election only
+	require.NoError(t, lt.Check(uid, "", 0))
+	require.ErrorIs(t, lt.Check(uid, "fpd-1", 1), types.ErrNotLeaseHolder)
+
+	lease, err := lt.Acquire(uid, "fpd-1", ttl)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), lease.Term)
+	require.NoError(t, lt.Check(uid, "fpd-1", 1))
+	require.ErrorIs(t, lt.Check(uid, "", 0), types.ErrNotLeaseHolder)
+
+	// the standby cannot take over a valid lease
+	_, err = lt.Acquire(uid, "fpd-2", ttl)
+	require.ErrorIs(t, err, types.ErrLeaseHeld)
+	require.ErrorIs(t, lt.Check(uid, "fpd-2", 1), types.ErrNotLeaseHolder)
+
+	// the renewal keeps the term
+	now = now.Add(ttl / 2)
+	lease, err = lt.Acquire(uid, "fpd-1", ttl)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), lease.Term)
+
+	// the standby takes over once the lease expires and the former
+	// leader is fenced off
+	now = now.Add(ttl)
+	require.ErrorIs(t, lt.Check(uid, "fpd-1", 1), types.ErrNotLeaseHolder)
+	lease, err = lt.Acquire(uid, "fpd-2", ttl)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), lease.Term)
+	require.NoError(t, lt.Check(uid, "fpd-2", 2))
+	require.ErrorIs(t, lt.Check(uid, "fpd-1", 1), types.ErrNotLeaseHolder)
+
+	// only the holder can release the lease
+	require.ErrorIs(t, lt.Release(uid, "fpd-1"), types.ErrNotLeaseHolder)
+	require.NoError(t, lt.Release(uid, "fpd-2"))
+	lease, err = lt.Acquire(uid, "fpd-1", ttl)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), lease.Term)
+}
+
+func TestPersistentLeaseTable(t *testing.T) {
+	t.Parallel()
+
+	dbBackend, err := config.DefaultDBConfigWithHomePath(t.TempDir()).GetDBBackend()
+	require.NoError(t, err)
+	defer dbBackend.Close()
+	leaseStore, err := store.NewLeaseStore(dbBackend)
+	require.NoError(t, err)
+
+	now := time.Unix(1000, 0)
+	clock := func() time.Time { return now }
+	lt, err := eotsmanager.NewPersistentLeaseTableWithClock(leaseStore, clock)
+	require.NoError(t, err)
+	uid := []byte("eots-key")
+	ttl := 10 * time.Second
+
+	_, err = lt.Acquire(uid, "fpd-1", ttl)
+	require.NoError(t, err)
+	now = now.Add(ttl)
+	lease, err := lt.Acquire(uid, "fpd-2", ttl)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), lease.Term)
+
+	// the fencing is kept once the EOTS manager restarts
+	lt, err = eotsmanager.NewPersistentLeaseTableWithClock(leaseStore, clock)
+	require.NoError(t, err)
+	require.NoError(t, lt.Check(uid, "fpd-2", 2))
+	require.ErrorIs(t, lt.Check(uid, "fpd-1", 1), types.ErrNotLeaseHolder)
+	require.ErrorIs(t, lt.Check(uid, "", 0), types.ErrNotLeaseHolder)
+	_, err = lt.Acquire(uid, "fpd-1", ttl)
+	require.ErrorIs(t, err, types.ErrLeaseHeld)
+
+	// the released lease is taken over at the next term after the restart
+	require.NoError(t, lt.Release(uid, "fpd-2"))
+	lt, err = eotsmanager.NewPersistentLeaseTableWithClock(leaseStore, clock)
+	require.NoError(t, err)
+	lease, err = lt.Acquire(uid, "fpd-1", ttl)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), lease.Term)
+}
+
+func TestLeaseStatusError(t *testing.T) {
+	t.Parallel()
+
+	for _, leaseErr := range []error{types.ErrLeaseHeld, types.ErrNotLeaseHolder} {
+		err := eotsmanager.LeaseErrorFromStatus(eotsmanager.LeaseStatusError(leaseErr))
+		require.ErrorIs(t, err, leaseErr)
+		require.Equal(t, leaseErr.Error(), err.Error())
+	}
+
+	// the other errors are kept as they are
+	otherErr := errors.New("other error")
+	require.Equal(t, otherErr, eotsmanager.LeaseStatusError(otherErr))
+	require.Equal(t, otherErr, eotsmanager.LeaseErrorFromStatus(otherErr))
+	require.NoError(t, eotsmanager.LeaseErrorFromStatus(nil))
+
+	// the lease errors wrapped by other errors are tagged as well
+	wrappedErr := eotsmanager.LeaseStatusError(fmt.Errorf("failed to sign: %w", types.ErrNotLeaseHolder))
+	require.ErrorIs(t, eotsmanager.LeaseErrorFromStatus(wrappedErr), types.ErrNotLeaseHolder)
+
+	// the statuses with the same codes which are not lease errors, e.g., the
+	// authentication failures, are not converted
+	for _, code := range []codes.Code{codes.AlreadyExists, codes.PermissionDenied} {
+		authErr := status.Error(code, "invalid auth token")
+		err := eotsmanager.LeaseErrorFromStatus(authErr)
+		require.Equal(t, authErr, err)
+		require.NotErrorIs(t, err, types.ErrLeaseHeld)
+		require.NotErrorIs(t, err, types.ErrNotLeaseHolder)
+	}
+}
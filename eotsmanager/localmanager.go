@@ -211,6 +211,35 @@ func (lm *LocalEOTSManager) SignEOTS(fpPk []byte, chainID []byte, msg []byte, he
 	return eots.Sign(privKey, privRand, msg)
 }
 
+// SignEOTSBatch signs the messages at the given heights, the EOTS private key
+// is retrieved only once for the whole batch
+func (lm *LocalEOTSManager) SignEOTSBatch(fpPk []byte, chainID []byte, msgs [][]byte, heights []uint64, passphrase string) ([]*btcec.ModNScalar, error) {
+	if len(msgs) != len(heights) {
+		return nil, fmt.Errorf("the number of messages %d does not match the number of heights %d", len(msgs), len(heights))
+	}
+
+	privKey, err := lm.getEOTSPrivKey(fpPk, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get EOTS private key: %w", err)
+	}
+
+	sigs := make([]*btcec.ModNScalar, 0, len(msgs))
+	for i, msg := range msgs {
+		privRand, _ := randgenerator.GenerateRandomness(privKey.Serialize(), chainID, heights[i])
+		sig, err := eots.Sign(privKey, privRand, msg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign EOTS at height %d: %w", heights[i], err)
+		}
+		sigs = append(sigs, sig)
+
+		// Update metrics
+		lm.metrics.IncrementEotsFpTotalEotsSignCounter(hex.EncodeToString(fpPk))
+		lm.metrics.SetEotsFpLastEotsSignHeight(hex.EncodeToString(fpPk), float64(heights[i]))
+	}
+
+	return sigs, nil
+}
+
 func (lm *LocalEOTSManager) SignSchnorrSig(fpPk []byte, msg []byte, passphrase string) (*schnorr.Signature, error) {
 	privKey, err := lm.getEOTSPrivKey(fpPk, passphrase)
 	if err != nil {
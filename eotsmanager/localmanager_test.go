@@ -85,10 +85,25 @@ func FuzzCreateRandomnessPairList(f *testing.F) {
 		require.NoError(t, err)
 		require.Len(t, pubRandList, num)
 
+		msgs := make([][]byte, 0, num)
+		heights := make([]uint64, 0, num)
 		for i := 0; i < num; i++ {
-			sig, err := lm.SignEOTS(fpPk, chainID, datagen.GenRandomByteArray(r, 32), startHeight+uint64(i), passphrase)
+			msg := datagen.GenRandomByteArray(r, 32)
+			sig, err := lm.SignEOTS(fpPk, chainID, msg, startHeight+uint64(i), passphrase)
 			require.NoError(t, err)
 			require.NotNil(t, sig)
+			msgs = append(msgs, msg)
+			heights = append(heights, startHeight+uint64(i))
+		}
+
+		// signing in a batch gives the same signatures
+		sigs, err := lm.SignEOTSBatch(fpPk, chainID, msgs, heights, passphrase)
+		require.NoError(t, err)
+		require.Len(t, sigs, num)
+		for i, sig := range sigs {
+			expectedSig, err := lm.SignEOTS(fpPk, chainID, msgs[i], heights[i], passphrase)
+			require.NoError(t, err)
+			require.True(t, expectedSig.Equals(sig))
 		}
 	})
 }
@@ -24,7 +24,10 @@ const (
 	EOTSManager_CreateRandomnessPairList_FullMethodName = "/proto.EOTSManager/CreateRandomnessPairList"
 	EOTSManager_KeyRecord_FullMethodName                = "/proto.EOTSManager/KeyRecord"
 	EOTSManager_SignEOTS_FullMethodName                 = "/proto.EOTSManager/SignEOTS"
+	EOTSManager_SignEOTSBatch_FullMethodName            = "/proto.EOTSManager/SignEOTSBatch"
 	EOTSManager_SignSchnorrSig_FullMethodName           = "/proto.EOTSManager/SignSchnorrSig"
+	EOTSManager_AcquireLease_FullMethodName             = "/proto.EOTSManager/AcquireLease"
+	EOTSManager_ReleaseLease_FullMethodName             = "/proto.EOTSManager/ReleaseLease"
 )
 
 // EOTSManagerClient is the client API for EOTSManager service.
@@ -40,8 +43,16 @@ type EOTSManagerClient interface {
 	KeyRecord(ctx context.Context, in *KeyRecordRequest, opts ...grpc.CallOption) (*KeyRecordResponse, error)
 	// SignEOTS signs an EOTS with the EOTS private key and the relevant randomness
 	SignEOTS(ctx context.Context, in *SignEOTSRequest, opts ...grpc.CallOption) (*SignEOTSResponse, error)
+	// SignEOTSBatch signs a batch of EOTS at multiple heights with the EOTS
+	// private key and the relevant randomness
+	SignEOTSBatch(ctx context.Context, in *SignEOTSBatchRequest, opts ...grpc.CallOption) (*SignEOTSBatchResponse, error)
 	// SignSchnorrSig signs a Schnorr sig with the EOTS private key
 	SignSchnorrSig(ctx context.Context, in *SignSchnorrSigRequest, opts ...grpc.CallOption) (*SignSchnorrSigResponse, error)
+	// AcquireLease acquires or renews the lease of an EOTS key to elect the
+	// leader among the finality provider daemons sharing the key
+	AcquireLease(ctx context.Context, in *AcquireLeaseRequest, opts ...grpc.CallOption) (*AcquireLeaseResponse, error)
+	// ReleaseLease releases the lease of an EOTS key
+	ReleaseLease(ctx context.Context, in *ReleaseLeaseRequest, opts ...grpc.CallOption) (*ReleaseLeaseResponse, error)
 }
 
 type eOTSManagerClient struct {
@@ -97,6 +108,15 @@ func (c *eOTSManagerClient) SignEOTS(ctx context.Context, in *SignEOTSRequest, o
 	return out, nil
 }
 
+func (c *eOTSManagerClient) SignEOTSBatch(ctx context.Context, in *SignEOTSBatchRequest, opts ...grpc.CallOption) (*SignEOTSBatchResponse, error) {
+	out := new(SignEOTSBatchResponse)
+	err := c.cc.Invoke(ctx, EOTSManager_SignEOTSBatch_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *eOTSManagerClient) SignSchnorrSig(ctx context.Context, in *SignSchnorrSigRequest, opts ...grpc.CallOption) (*SignSchnorrSigResponse, error) {
 	out := new(SignSchnorrSigResponse)
 	err := c.cc.Invoke(ctx, EOTSManager_SignSchnorrSig_FullMethodName, in, out, opts...)
@@ -106,6 +126,24 @@ func (c *eOTSManagerClient) SignSchnorrSig(ctx context.Context, in *SignSchnorrS
 	return out, nil
 }
 
+func (c *eOTSManagerClient) AcquireLease(ctx context.Context, in *AcquireLeaseRequest, opts ...grpc.CallOption) (*AcquireLeaseResponse, error) {
+	out := new(AcquireLeaseResponse)
+	err := c.cc.Invoke(ctx, EOTSManager_AcquireLease_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eOTSManagerClient) ReleaseLease(ctx context.Context, in *ReleaseLeaseRequest, opts ...grpc.CallOption) (*ReleaseLeaseResponse, error) {
+	out := new(ReleaseLeaseResponse)
+	err := c.cc.Invoke(ctx, EOTSManager_ReleaseLease_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // EOTSManagerServer is the server API for EOTSManager service.
 // All implementations must embed UnimplementedEOTSManagerServer
 // for forward compatibility
@@ -119,8 +157,16 @@ type EOTSManagerServer interface {
 	KeyRecord(context.Context, *KeyRecordRequest) (*KeyRecordResponse, error)
 	// SignEOTS signs an EOTS with the EOTS private key and the relevant randomness
 	SignEOTS(context.Context, *SignEOTSRequest) (*SignEOTSResponse, error)
+	// SignEOTSBatch signs a batch of EOTS at multiple heights with the EOTS
+	// private key and the relevant randomness
+	SignEOTSBatch(context.Context, *SignEOTSBatchRequest) (*SignEOTSBatchResponse, error)
 	// SignSchnorrSig signs a Schnorr sig with the EOTS private key
 	SignSchnorrSig(context.Context, *SignSchnorrSigRequest) (*SignSchnorrSigResponse, error)
+	// AcquireLease acquires or renews the lease of an EOTS key to elect the
+	// leader among the finality provider daemons sharing the key
+	AcquireLease(context.Context, *AcquireLeaseRequest) (*AcquireLeaseResponse, error)
+	// ReleaseLease releases the lease of an EOTS key
+	ReleaseLease(context.Context, *ReleaseLeaseRequest) (*ReleaseLeaseResponse, error)
 	mustEmbedUnimplementedEOTSManagerServer()
 }
 
@@ -143,9 +189,18 @@ func (UnimplementedEOTSManagerServer) KeyRecord(context.Context, *KeyRecordReque
 func (UnimplementedEOTSManagerServer) SignEOTS(context.Context, *SignEOTSRequest) (*SignEOTSResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SignEOTS not implemented")
 }
+func (UnimplementedEOTSManagerServer) SignEOTSBatch(context.Context, *SignEOTSBatchRequest) (*SignEOTSBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignEOTSBatch not implemented")
+}
 func (UnimplementedEOTSManagerServer) SignSchnorrSig(context.Context, *SignSchnorrSigRequest) (*SignSchnorrSigResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SignSchnorrSig not implemented")
 }
+func (UnimplementedEOTSManagerServer) AcquireLease(context.Context, *AcquireLeaseRequest) (*AcquireLeaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcquireLease not implemented")
+}
+func (UnimplementedEOTSManagerServer) ReleaseLease(context.Context, *ReleaseLeaseRequest) (*ReleaseLeaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseLease not implemented")
+}
 func (UnimplementedEOTSManagerServer) mustEmbedUnimplementedEOTSManagerServer() {}
 
 // UnsafeEOTSManagerServer may be embedded to opt out of forward compatibility for this service.
@@ -249,6 +304,24 @@ func _EOTSManager_SignEOTS_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EOTSManager_SignEOTSBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignEOTSBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EOTSManagerServer).SignEOTSBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EOTSManager_SignEOTSBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EOTSManagerServer).SignEOTSBatch(ctx, req.(*SignEOTSBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _EOTSManager_SignSchnorrSig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SignSchnorrSigRequest)
 	if err := dec(in); err != nil {
@@ -267,6 +340,42 @@ func _EOTSManager_SignSchnorrSig_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EOTSManager_AcquireLease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcquireLeaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EOTSManagerServer).AcquireLease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EOTSManager_AcquireLease_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EOTSManagerServer).AcquireLease(ctx, req.(*AcquireLeaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EOTSManager_ReleaseLease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseLeaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EOTSManagerServer).ReleaseLease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EOTSManager_ReleaseLease_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EOTSManagerServer).ReleaseLease(ctx, req.(*ReleaseLeaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // EOTSManager_ServiceDesc is the grpc.ServiceDesc for EOTSManager service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -294,10 +403,22 @@ var EOTSManager_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SignEOTS",
 			Handler:    _EOTSManager_SignEOTS_Handler,
 		},
+		{
+			MethodName: "SignEOTSBatch",
+			Handler:    _EOTSManager_SignEOTSBatch_Handler,
+		},
 		{
 			MethodName: "SignSchnorrSig",
 			Handler:    _EOTSManager_SignSchnorrSig_Handler,
 		},
+		{
+			MethodName: "AcquireLease",
+			Handler:    _EOTSManager_AcquireLease_Handler,
+		},
+		{
+			MethodName: "ReleaseLease",
+			Handler:    _EOTSManager_ReleaseLease_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "eotsmanager.proto",
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"google.golang.org/grpc"
 
@@ -15,14 +16,20 @@ type rpcServer struct {
 	proto.UnimplementedEOTSManagerServer
 
 	em eotsmanager.EOTSManager
+
+	// leases elects the leader among the finality provider daemons
+	// sharing an EOTS key and fences the others from signing
+	leases *eotsmanager.LeaseTable
 }
 
 // newRPCServer creates a new RPC sever from the set of input dependencies.
 func newRPCServer(
 	em eotsmanager.EOTSManager,
+	leases *eotsmanager.LeaseTable,
 ) *rpcServer {
 	return &rpcServer{
-		em: em,
+		em:     em,
+		leases: leases,
 	}
 }
 
@@ -88,6 +95,10 @@ func (r *rpcServer) KeyRecord(_ context.Context, req *proto.KeyRecordRequest) (
 // SignEOTS signs an EOTS with the EOTS private key and the relevant randomness
 func (r *rpcServer) SignEOTS(_ context.Context, req *proto.SignEOTSRequest) (
 	*proto.SignEOTSResponse, error) {
+	if err := r.leases.Check(req.Uid, req.LeaseHolder, req.LeaseTerm); err != nil {
+		return nil, eotsmanager.LeaseStatusError(err)
+	}
+
 	sig, err := r.em.SignEOTS(req.Uid, req.ChainId, req.Msg, req.Height, req.Passphrase)
 	if err != nil {
 		return nil, err
@@ -98,9 +109,35 @@ func (r *rpcServer) SignEOTS(_ context.Context, req *proto.SignEOTSRequest) (
 	return &proto.SignEOTSResponse{Sig: sigBytes[:]}, nil
 }
 
+// SignEOTSBatch signs a batch of EOTS with the EOTS private key and the
+// relevant randomness
+func (r *rpcServer) SignEOTSBatch(_ context.Context, req *proto.SignEOTSBatchRequest) (
+	*proto.SignEOTSBatchResponse, error) {
+	if err := r.leases.Check(req.Uid, req.LeaseHolder, req.LeaseTerm); err != nil {
+		return nil, eotsmanager.LeaseStatusError(err)
+	}
+
+	sigs, err := r.em.SignEOTSBatch(req.Uid, req.ChainId, req.Msgs, req.Heights, req.Passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	sigBytesList := make([][]byte, 0, len(sigs))
+	for _, sig := range sigs {
+		sigBytes := sig.Bytes()
+		sigBytesList = append(sigBytesList, sigBytes[:])
+	}
+
+	return &proto.SignEOTSBatchResponse{Sigs: sigBytesList}, nil
+}
+
 // SignSchnorrSig signs a Schnorr sig with the EOTS private key
 func (r *rpcServer) SignSchnorrSig(_ context.Context, req *proto.SignSchnorrSigRequest) (
 	*proto.SignSchnorrSigResponse, error) {
+	if err := r.leases.Check(req.Uid, req.LeaseHolder, req.LeaseTerm); err != nil {
+		return nil, eotsmanager.LeaseStatusError(err)
+	}
+
 	sig, err := r.em.SignSchnorrSig(req.Uid, req.Msg, req.Passphrase)
 	if err != nil {
 		return nil, err
@@ -108,3 +145,28 @@ func (r *rpcServer) SignSchnorrSig(_ context.Context, req *proto.SignSchnorrSigR
 
 	return &proto.SignSchnorrSigResponse{Sig: sig.Serialize()}, nil
 }
+
+// AcquireLease acquires or renews the lease of an EOTS key
+func (r *rpcServer) AcquireLease(_ context.Context, req *proto.AcquireLeaseRequest) (
+	*proto.AcquireLeaseResponse, error) {
+	// #nosec G115 -- the ttl is set to a few seconds
+	lease, err := r.leases.Acquire(req.Uid, req.Holder, time.Duration(req.TtlMs)*time.Millisecond)
+	if err != nil {
+		return nil, eotsmanager.LeaseStatusError(err)
+	}
+
+	return &proto.AcquireLeaseResponse{
+		Term:            lease.Term,
+		ExpiresAtUnixMs: lease.ExpiresAt.UnixMilli(),
+	}, nil
+}
+
+// ReleaseLease releases the lease of an EOTS key
+func (r *rpcServer) ReleaseLease(_ context.Context, req *proto.ReleaseLeaseRequest) (
+	*proto.ReleaseLeaseResponse, error) {
+	if err := r.leases.Release(req.Uid, req.Holder); err != nil {
+		return nil, eotsmanager.LeaseStatusError(err)
+	}
+
+	return &proto.ReleaseLeaseResponse{}, nil
+}
@@ -13,9 +13,13 @@ import (
 	"github.com/lightningnetwork/lnd/signal"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 
 	"github.com/babylonlabs-io/finality-provider/eotsmanager"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/store"
 )
 
 // Server is the main daemon construct for the EOTS manager server. It handles
@@ -27,7 +31,7 @@ type Server struct {
 	cfg    *config.Config
 	logger *zap.Logger
 
-	rpcServer   *rpcServer
+	em          eotsmanager.EOTSManager
 	db          kvdb.Backend
 	interceptor signal.Interceptor
 
@@ -39,7 +43,7 @@ func NewEOTSManagerServer(cfg *config.Config, l *zap.Logger, em eotsmanager.EOTS
 	return &Server{
 		cfg:         cfg,
 		logger:      l,
-		rpcServer:   newRPCServer(em),
+		em:          em,
 		db:          db,
 		interceptor: sig,
 		quit:        make(chan struct{}, 1),
@@ -58,7 +62,7 @@ func (s *Server) RunUntilShutdown() error {
 	if err != nil {
 		return fmt.Errorf("failed to get prometheus address: %w", err)
 	}
-	metricsServer := metrics.Start(promAddr, s.logger)
+	metricsServer := metrics.Start(promAddr, s.cfg.Metrics.Pprof, s.logger)
 
 	defer func() {
 		s.logger.Info("Shutdown complete")
@@ -88,13 +92,33 @@ func (s *Server) RunUntilShutdown() error {
 		}
 	}()
 
+	// the leases are kept in the db so that the standby daemons remain
+	// fenced across the restarts
+	leaseStore, err := store.NewLeaseStore(s.db)
+	if err != nil {
+		return fmt.Errorf("failed to initiate lease store: %w", err)
+	}
+	leases, err := eotsmanager.NewPersistentLeaseTable(leaseStore)
+	if err != nil {
+		return err
+	}
+	rpcServer := newRPCServer(s.em, leases)
+
 	grpcServer := grpc.NewServer()
 	defer grpcServer.Stop()
 
-	if err := s.rpcServer.RegisterWithGrpcServer(grpcServer); err != nil {
+	if err := rpcServer.RegisterWithGrpcServer(grpcServer); err != nil {
 		return fmt.Errorf("failed to register gRPC server: %w", err)
 	}
 
+	// the health service reports SERVING as long as the RPC listener is up,
+	// and the reflection service lets grpcurl and the like call the RPCs
+	// without the compiled protos
+	grpcHealth := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, grpcHealth)
+	defer grpcHealth.Shutdown()
+	reflection.Register(grpcServer)
+
 	// All the necessary components have been registered, so we can
 	// actually start listening for requests.
 	s.startGrpcListen(grpcServer, []net.Listener{lis})
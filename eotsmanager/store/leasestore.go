@@ -0,0 +1,93 @@
+package store
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/lightningnetwork/lnd/kvdb"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/types"
+)
+
+var (
+	// uid -> term || expiry || holder
+	leaseBucketName = []byte("leases")
+)
+
+// leaseValueLen is the length of the encoded lease without its holder
+const leaseValueLen = 8 + 8
+
+// LeaseStore persists the leases of the EOTS keys, so that the fencing of
+// the standby finality provider daemons survives the restarts of the EOTS
+// manager
+type LeaseStore struct {
+	db kvdb.Backend
+}
+
+func NewLeaseStore(db kvdb.Backend) (*LeaseStore, error) {
+	s := &LeaseStore{db}
+	if err := s.initBuckets(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *LeaseStore) initBuckets() error {
+	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		_, err := tx.CreateTopLevelBucket(leaseBucketName)
+		return err
+	})
+}
+
+// SaveLease saves the lease of the EOTS key, replacing the previous one
+func (s *LeaseStore) SaveLease(uid []byte, lease *types.Lease) error {
+	v := make([]byte, leaseValueLen, leaseValueLen+len(lease.Holder))
+	binary.BigEndian.PutUint64(v[:8], lease.Term)
+	// #nosec G115 -- the expiry is after the epoch
+	binary.BigEndian.PutUint64(v[8:16], uint64(lease.ExpiresAt.UnixNano()))
+	v = append(v, lease.Holder...)
+
+	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		bucket := tx.ReadWriteBucket(leaseBucketName)
+		if bucket == nil {
+			return ErrCorruptedEOTSDb
+		}
+
+		return bucket.Put(uid, v)
+	})
+}
+
+// GetLeases returns the saved leases by the hex of the EOTS key
+func (s *LeaseStore) GetLeases() (map[string]*types.Lease, error) {
+	leases := make(map[string]*types.Lease)
+	err := s.db.View(func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(leaseBucketName)
+		if bucket == nil {
+			return ErrCorruptedEOTSDb
+		}
+
+		return bucket.ForEach(func(k, v []byte) error {
+			if len(v) < leaseValueLen {
+				return fmt.Errorf("%w: invalid lease of %x", ErrCorruptedEOTSDb, k)
+			}
+			leases[hex.EncodeToString(k)] = &types.Lease{
+				Holder: string(v[leaseValueLen:]),
+				Term:   binary.BigEndian.Uint64(v[:8]),
+				// #nosec G115 -- the expiry is saved from a valid time
+				ExpiresAt: time.Unix(0, int64(binary.BigEndian.Uint64(v[8:16]))),
+			}
+
+			return nil
+		})
+	}, func() {
+		leases = make(map[string]*types.Lease)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return leases, nil
+}
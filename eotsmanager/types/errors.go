@@ -4,4 +4,6 @@ import "errors"
 
 var (
 	ErrFinalityProviderAlreadyExisted = errors.New("the finality provider has already existed")
+	ErrLeaseHeld                      = errors.New("the lease is held by another holder")
+	ErrNotLeaseHolder                 = errors.New("not the current lease holder")
 )
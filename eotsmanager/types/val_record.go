@@ -1,8 +1,22 @@
 package types
 
-import "github.com/btcsuite/btcd/btcec/v2"
+import (
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+)
 
 type KeyRecord struct {
 	Name    string
 	PrivKey *btcec.PrivateKey
 }
+
+// Lease is the leadership of a finality provider daemon over an EOTS key
+// among the daemons sharing it
+type Lease struct {
+	Holder string
+	// Term is the fencing token of the lease, it increases every time
+	// the lease is granted to a new holder
+	Term      uint64
+	ExpiresAt time.Time
+}
@@ -0,0 +1,235 @@
+package audit
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+// Operation is the type of a privileged operation recorded in the audit log
+type Operation string
+
+const (
+	OpCreateKey              Operation = "create_key"
+	OpCreateFinalityProvider Operation = "create_finality_provider"
+	OpRegister               Operation = "register"
+	OpUnjail                 Operation = "unjail"
+	OpEditFinalityProvider   Operation = "edit_finality_provider"
+	OpUpdateCommission       Operation = "update_commission"
+	OpWithdrawRewards        Operation = "withdraw_rewards"
+	OpExportState            Operation = "export_state"
+	OpReloadConfig           Operation = "reload_config"
+	OpSetLogLevel            Operation = "set_log_level"
+	// OpRPCAdminCall is a call to the admin RPC service, recorded along with
+	// the peer and the scope of its auth token
+	OpRPCAdminCall Operation = "rpc_admin_call"
+)
+
+// maxEntrySize is the max size of an entry of the audit log
+const maxEntrySize = 1024 * 1024
+
+// Entry is an entry of the audit log, which is chained to the previous one
+// through its hash
+type Entry struct {
+	Seq       uint64    `json:"seq"`
+	Time      time.Time `json:"time"`
+	Operation Operation `json:"operation"`
+	// User is the OS user running the process which has recorded the entry
+	User    string            `json:"user"`
+	Details map[string]string `json:"details,omitempty"`
+	// Error is the error of the operation, empty if it has succeeded
+	Error    string `json:"error,omitempty"`
+	PrevHash string `json:"prev_hash"`
+	Hash     string `json:"hash"`
+}
+
+// computeHash returns the hex of the SHA-256 hash of the entry without its
+// hash, which covers the hash of the previous entry
+func (e *Entry) computeHash() (string, error) {
+	unhashed := *e
+	unhashed.Hash = ""
+	// the keys of the details are sorted by the encoding
+	bz, err := json.Marshal(&unhashed)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.Sum256(bz)
+
+	return hex.EncodeToString(h[:]), nil
+}
+
+// Log is an append-only audit log stored as a file of JSON lines. The last
+// entry is re-read before each append, so that the entries appended by the
+// other processes, e.g., the CLI commands, are chained as well
+type Log struct {
+	path string
+	user string
+
+	mu sync.Mutex
+}
+
+// NewLog returns the audit log stored at the given path, the file being
+// created upon the first append
+func NewLog(path string) (*Log, error) {
+	if err := util.MakeDirectory(filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+
+	username := "unknown"
+	if u, err := user.Current(); err == nil {
+		username = u.Username
+	}
+
+	return &Log{path: path, user: username}, nil
+}
+
+// Path returns the path of the audit log
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Append records the given operation along with its details and error,
+// chaining it to the last entry of the log
+func (l *Log) Append(op Operation, details map[string]string, opErr error) (*Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	last, err := lastEntry(l.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the last entry of the audit log: %w", err)
+	}
+
+	entry := &Entry{
+		Seq:       1,
+		Time:      time.Now().UTC(),
+		Operation: op,
+		User:      l.user,
+		Details:   details,
+	}
+	if last != nil {
+		entry.Seq = last.Seq + 1
+		entry.PrevHash = last.Hash
+	}
+	if opErr != nil {
+		entry.Error = opErr.Error()
+	}
+	if entry.Hash, err = entry.computeHash(); err != nil {
+		return nil, err
+	}
+
+	bz, err := json.Marshal(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	// #nosec G304 - The audit log path is provided by the user and not externally
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Write(append(bz, '\n')); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	return entry, f.Close()
+}
+
+// ReadEntries reads all the entries of the audit log at the given path, a
+// missing file being an empty log
+func ReadEntries(path string) ([]*Entry, error) {
+	var entries []*Entry
+	err := scanEntries(path, func(e *Entry) {
+		entries = append(entries, e)
+	})
+
+	return entries, err
+}
+
+func lastEntry(path string) (*Entry, error) {
+	var last *Entry
+	err := scanEntries(path, func(e *Entry) {
+		last = e
+	})
+
+	return last, err
+}
+
+func scanEntries(path string, handle func(*Entry)) error {
+	// #nosec G304 - The audit log path is provided by the user and not externally
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEntrySize)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return fmt.Errorf("invalid entry at line %d of the audit log: %w", line, err)
+		}
+		handle(&e)
+	}
+
+	return scanner.Err()
+}
+
+// VerifyError is the first entry breaking the chain of the audit log
+type VerifyError struct {
+	Seq    uint64
+	Reason string
+}
+
+func (e *VerifyError) Error() string {
+	return fmt.Sprintf("the audit log is tampered at the entry %d: %s", e.Seq, e.Reason)
+}
+
+// Verify checks that the entries are chained, i.e., that their sequence
+// numbers are consecutive, that the hash of each entry matches its content
+// and that each entry refers to the hash of the previous one. It returns a
+// *VerifyError for the first entry breaking the chain
+func Verify(entries []*Entry) error {
+	prevHash := ""
+	for i, e := range entries {
+		if expected := uint64(i) + 1; e.Seq != expected {
+			return &VerifyError{Seq: e.Seq, Reason: fmt.Sprintf("expected the sequence number %d", expected)}
+		}
+		if e.PrevHash != prevHash {
+			return &VerifyError{Seq: e.Seq, Reason: "the previous hash does not match the hash of the previous entry"}
+		}
+		hash, err := e.computeHash()
+		if err != nil {
+			return &VerifyError{Seq: e.Seq, Reason: err.Error()}
+		}
+		if e.Hash != hash {
+			return &VerifyError{Seq: e.Seq, Reason: "the hash does not match the content of the entry"}
+		}
+		prevHash = e.Hash
+	}
+
+	return nil
+}
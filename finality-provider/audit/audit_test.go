@@ -0,0 +1,72 @@
+package audit_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/audit"
+)
+
+func TestAuditLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "audit.log")
+
+	entries, err := audit.ReadEntries(path)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	l, err := audit.NewLog(path)
+	require.NoError(t, err)
+	first, err := l.Append(audit.OpRegister, map[string]string{"fp_btc_pk_hex": "fp-pk", "tx_hash": "tx"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), first.Seq)
+	require.Empty(t, first.PrevHash)
+
+	// the entries of another process are chained as well
+	other, err := audit.NewLog(path)
+	require.NoError(t, err)
+	second, err := other.Append(audit.OpUnjail, map[string]string{"fp_btc_pk_hex": "fp-pk"}, errors.New("not jailed"))
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), second.Seq)
+	require.Equal(t, first.Hash, second.PrevHash)
+
+	third, err := l.Append(audit.OpReloadConfig, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, second.Hash, third.PrevHash)
+
+	entries, err = audit.ReadEntries(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	require.Equal(t, "not jailed", entries[1].Error)
+	require.NoError(t, audit.Verify(entries))
+
+	// tamper with the details of the second entry
+	bz, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(bz)), "\n")
+	var e audit.Entry
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &e))
+	e.Details["fp_btc_pk_hex"] = "other-fp-pk"
+	tampered, err := json.Marshal(&e)
+	require.NoError(t, err)
+	lines[1] = string(tampered)
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0600))
+
+	entries, err = audit.ReadEntries(path)
+	require.NoError(t, err)
+	var verifyErr *audit.VerifyError
+	require.ErrorAs(t, audit.Verify(entries), &verifyErr)
+	require.Equal(t, uint64(2), verifyErr.Seq)
+
+	// delete the second entry
+	require.NoError(t, os.WriteFile(path, []byte(lines[0]+"\n"+lines[2]+"\n"), 0600))
+	entries, err = audit.ReadEntries(path)
+	require.NoError(t, err)
+	require.ErrorAs(t, audit.Verify(entries), &verifyErr)
+	require.Equal(t, uint64(3), verifyErr.Seq)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/spf13/pflag"
 
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/util"
 )
 
 // PersistClientCtx persist some vars from the cmd or config to the client context.
@@ -19,6 +20,10 @@ import (
 // and exists a value in the config that could be used, it will be set in the ctx.
 func PersistClientCtx(ctx client.Context) func(cmd *cobra.Command, _ []string) error {
 	return func(cmd *cobra.Command, _ []string) error {
+		if err := util.CheckOutputFormat(cmd); err != nil {
+			return err
+		}
+
 		encCfg := params.DefaultEncodingConfig()
 		std.RegisterInterfaces(encCfg.InterfaceRegistry)
 		bstypes.RegisterInterfaces(encCfg.InterfaceRegistry)
@@ -67,17 +72,24 @@ func FillContextFromBabylonConfig(ctx client.Context, flagSet *pflag.FlagSet, bb
 	if !flagSet.Changed(flags.FlagChainID) {
 		ctx = ctx.WithChainID(bbnConf.ChainID)
 	}
-	if !flagSet.Changed(flags.FlagKeyringBackend) {
-		kr, err := client.NewKeyringFromBackend(ctx, bbnConf.KeyringBackend)
+	if !flagSet.Changed(flags.FlagKeyringDir) {
+		ctx = ctx.WithKeyringDir(bbnConf.KeyDirectory)
+	}
+	// the keyring is reopened in the directory of the config for the keys
+	// commands to operate on the keyring of the daemon
+	keyringBackend := bbnConf.KeyringBackend
+	if flagSet.Changed(flags.FlagKeyringBackend) {
+		backend, err := flagSet.GetString(flags.FlagKeyringBackend)
 		if err != nil {
 			return ctx, err
 		}
-
-		ctx = ctx.WithKeyring(kr)
+		keyringBackend = backend
 	}
-	if !flagSet.Changed(flags.FlagKeyringDir) {
-		ctx = ctx.WithKeyringDir(bbnConf.KeyDirectory)
+	kr, err := client.NewKeyringFromBackend(ctx, keyringBackend)
+	if err != nil {
+		return ctx, err
 	}
+	ctx = ctx.WithKeyring(kr)
 	if !flagSet.Changed(flags.FlagOutput) {
 		ctx = ctx.WithOutputFormat(bbnConf.OutputFormat)
 	}
@@ -8,6 +8,9 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
 
@@ -83,3 +86,43 @@ func TestPersistClientCtx(t *testing.T) {
 	require.Equal(t, flagHomeValue, ctx.HomeDir)
 	require.Equal(t, flagChainID, ctx.ChainID)
 }
+
+// TestPersistClientCtxKeyring tests that the keyring is opened in the key
+// directory of the config, for the keys commands to share it with the daemon
+func TestPersistClientCtxKeyring(t *testing.T) {
+	t.Parallel()
+	homePath := t.TempDir()
+	keyDir := filepath.Join(t.TempDir(), "keys")
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	cmd.Flags().String(flags.FlagHome, homePath, "The application home directory")
+	cmd.Flags().String(flags.FlagKeyringBackend, keyring.BackendOS, "The keyring backend")
+
+	config := fpcfg.DefaultConfigWithHome(homePath)
+	config.BabylonConfig.KeyDirectory = keyDir
+	config.BabylonConfig.KeyringBackend = keyring.BackendTest
+	fileParser := goflags.NewParser(&config, goflags.Default)
+	err := goflags.NewIniParser(fileParser).WriteFile(fpcfg.CfgFile(homePath), goflags.IniIncludeComments|goflags.IniIncludeDefaults)
+	require.NoError(t, err)
+
+	err = fpcmd.PersistClientCtx(client.Context{})(cmd, []string{})
+	require.NoError(t, err)
+
+	ctx := client.GetClientContextFromCmd(cmd)
+	require.Equal(t, keyDir, ctx.KeyringDir)
+	require.Equal(t, keyring.BackendTest, ctx.Keyring.Backend())
+
+	_, _, err = ctx.Keyring.NewMnemonic("fp", keyring.English, sdk.FullFundraiserPath, keyring.DefaultBIP39Passphrase, hd.Secp256k1)
+	require.NoError(t, err)
+	require.DirExists(t, filepath.Join(keyDir, "keyring-test"))
+
+	// the flag has preference over the backend of the config
+	err = cmd.Flags().Set(flags.FlagKeyringBackend, keyring.BackendMemory)
+	require.NoError(t, err)
+	err = fpcmd.PersistClientCtx(client.Context{})(cmd, []string{})
+	require.NoError(t, err)
+
+	ctx = client.GetClientContextFromCmd(cmd)
+	require.Equal(t, keyring.BackendMemory, ctx.Keyring.Backend())
+}
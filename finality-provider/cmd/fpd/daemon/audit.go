@@ -0,0 +1,115 @@
+package daemon
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/spf13/cobra"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/audit"
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+// auditLogResponse is the output of the audit command
+type auditLogResponse struct {
+	Path    string         `json:"path"`
+	Entries []*audit.Entry `json:"entries"`
+	// Verified tells whether the whole chain of entries is intact
+	Verified bool   `json:"verified"`
+	Error    string `json:"error,omitempty"`
+}
+
+// CommandAudit returns the audit command which prints the entries of the
+// audit log and verifies their hash chain
+func CommandAudit() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "audit",
+		Short: "Print the audit log of the privileged operations and verify that it has not been tampered with.",
+		Long: "Print the entries of the audit log, i.e., the registrations, the unjails, the key creations, the state " +
+			"exports, the config reloads and the calls to the admin RPC service, and verify their hash chain, each " +
+			"entry covering the hash of the previous one. The command fails if an entry has been modified, inserted or " +
+			"deleted, the error giving the first entry breaking the chain. It can be run while the daemon is running.",
+		Example: `fpd audit --home /home/user/.fpd
+fpd audit --operation register --limit 10`,
+		Args: cobra.NoArgs,
+		RunE: runCommandAudit,
+	}
+	cmd.Flags().String(operationFlag, "", "Only print the entries of the given operation, e.g., register or rpc_admin_call")
+	cmd.Flags().Uint64(limitFlag, 0, "Only print the given number of the last entries; 0 prints them all")
+
+	return cmd
+}
+
+func runCommandAudit(cmd *cobra.Command, _ []string) error {
+	operation, err := cmd.Flags().GetString(operationFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", operationFlag, err)
+	}
+	limit, err := cmd.Flags().GetUint64(limitFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", limitFlag, err)
+	}
+
+	clientCtx := client.GetClientContextFromCmd(cmd)
+	homePath, err := filepath.Abs(clientCtx.HomeDir)
+	if err != nil {
+		return err
+	}
+	homePath = util.CleanAndExpandPath(homePath)
+
+	cfg, err := fpcfg.LoadConfig(homePath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.AuditConfig == nil || cfg.AuditConfig.Path == "" {
+		return fmt.Errorf("the audit log is not configured")
+	}
+
+	entries, err := audit.ReadEntries(cfg.AuditConfig.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read the audit log: %w", err)
+	}
+
+	// the whole chain is verified regardless of the printed entries
+	verifyErr := audit.Verify(entries)
+
+	res := &auditLogResponse{
+		Path:     cfg.AuditConfig.Path,
+		Entries:  []*audit.Entry{},
+		Verified: verifyErr == nil,
+	}
+	for _, e := range entries {
+		if operation == "" || string(e.Operation) == operation {
+			res.Entries = append(res.Entries, e)
+		}
+	}
+	if limit > 0 && uint64(len(res.Entries)) > limit {
+		res.Entries = res.Entries[uint64(len(res.Entries))-limit:]
+	}
+	if verifyErr != nil {
+		res.Error = verifyErr.Error()
+	}
+
+	printRespJSON(res)
+
+	return verifyErr
+}
+
+// recordCLIAudit records an operation performed by a command without the
+// daemon in the audit log of the config, a failure to record it being
+// printed rather than returned as the operation has been performed
+func recordCLIAudit(cmd *cobra.Command, cfg *fpcfg.Config, op audit.Operation, details map[string]string, opErr error) {
+	if cfg.AuditConfig == nil || !cfg.AuditConfig.Enabled {
+		return
+	}
+
+	l, err := audit.NewLog(cfg.AuditConfig.Path)
+	if err == nil {
+		_, err = l.Append(op, details, opErr)
+	}
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Failed to record the operation %s in the audit log: %v\n", op, err)
+	}
+}
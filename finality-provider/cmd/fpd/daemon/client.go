@@ -0,0 +1,57 @@
+package daemon
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"google.golang.org/grpc"
+
+	dc "github.com/babylonlabs-io/finality-provider/finality-provider/service/client"
+)
+
+// AddDaemonClientFlags adds the flags of the credentials used to connect to
+// the RPC listener of the daemon
+func AddDaemonClientFlags(f *pflag.FlagSet) {
+	f.String(tlsCertPathFlag, "", "The path to the TLS certificate of the daemon; the connection is in plaintext if empty")
+	f.String(tlsClientCertPathFlag, "", "The path to the TLS client certificate, if the daemon requires one")
+	f.String(tlsClientKeyPathFlag, "", "The path to the key of the TLS client certificate")
+	f.String(authTokenPathFlag, "", "The path to the file holding the auth token, if the daemon requires one")
+}
+
+// newDaemonClient connects to the daemon at the given address with the
+// credentials given through the flags of the command
+func newDaemonClient(cmd *cobra.Command, daemonAddress string) (*dc.FinalityProviderServiceGRpcClient, func() error, error) {
+	getFlag := func(name string) string {
+		if f := cmd.Flags().Lookup(name); f != nil {
+			return f.Value.String()
+		}
+		return ""
+	}
+
+	var opts []grpc.DialOption
+
+	if certPath := getFlag(tlsCertPathFlag); certPath != "" {
+		clientCertPath, clientKeyPath := getFlag(tlsClientCertPathFlag), getFlag(tlsClientKeyPathFlag)
+		if (clientCertPath == "") != (clientKeyPath == "") {
+			return nil, nil, fmt.Errorf("both --%s and --%s should be specified", tlsClientCertPathFlag, tlsClientKeyPathFlag)
+		}
+		tlsOpt, err := dc.WithTLS(certPath, clientCertPath, clientKeyPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		opts = append(opts, tlsOpt)
+	} else if getFlag(tlsClientCertPathFlag) != "" {
+		return nil, nil, fmt.Errorf("--%s requires --%s", tlsClientCertPathFlag, tlsCertPathFlag)
+	}
+
+	if tokenPath := getFlag(authTokenPathFlag); tokenPath != "" {
+		token, err := dc.ReadAuthToken(tokenPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		opts = append(opts, dc.WithAuthToken(token))
+	}
+
+	return dc.NewFinalityProviderServiceGRpcClient(daemonAddress, opts...)
+}
@@ -5,6 +5,7 @@ import (
 	"math"
 	"path/filepath"
 	"strconv"
+	"time"
 
 	bbntypes "github.com/babylonlabs-io/babylon/types"
 	fpcc "github.com/babylonlabs-io/finality-provider/clientcontroller"
@@ -12,13 +13,68 @@ import (
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
-	"github.com/babylonlabs-io/finality-provider/log"
 	"github.com/babylonlabs-io/finality-provider/metrics"
 	"github.com/babylonlabs-io/finality-provider/util"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/spf13/cobra"
 )
 
+// CommandCommitPubRandDaemon returns the commit-pubrand command by connecting to the fpd daemon.
+func CommandCommitPubRandDaemon() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "commit-pubrand [eots-pk]",
+		Short: "Commit the public randomness of a running finality provider right away.",
+		Long: "Commit the public randomness of the running finality provider with the given EOTS public key " +
+			"until the target height without waiting for the next commitment, e.g., before a planned downtime. " +
+			"Without the target height, a single batch is committed after the last committed height. " +
+			"Each batch is a separate transaction paid by the finality provider.",
+		Example: fmt.Sprintf(`fpd commit-pubrand [eots-pk] --target-height 500000 --daemon-address %s`,
+			defaultFpdDaemonAddress),
+		Args: cobra.ExactArgs(1),
+		RunE: runCommandCommitPubRandDaemon,
+	}
+	f := cmd.Flags()
+	f.String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	f.Uint64(targetHeightFlag, 0, "The height the committed public randomness should cover (optional)")
+
+	return cmd
+}
+
+func runCommandCommitPubRandDaemon(cmd *cobra.Command, args []string) error {
+	fpPk, err := bbntypes.NewBIP340PubKeyFromHex(args[0])
+	if err != nil {
+		return err
+	}
+
+	flags := cmd.Flags()
+	daemonAddress, err := flags.GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+	targetHeight, err := flags.GetUint64(targetHeightFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", targetHeightFlag, err)
+	}
+
+	grpcClient, cleanUp, err := newDaemonClient(cmd, daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	res, err := grpcClient.CommitPubRand(cmd.Context(), fpPk, targetHeight)
+	if err != nil {
+		return err
+	}
+	printRespJSON(res)
+
+	return nil
+}
+
 // CommandCommitPubRand returns the commit-pubrand command
 func CommandCommitPubRand() *cobra.Command {
 	var cmd = &cobra.Command{
@@ -62,7 +118,7 @@ func runCommandCommitPubRand(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
 
-	logger, err := log.NewRootLoggerWithFile(fpcfg.LogFile(homePath), cfg.LogLevel)
+	logger, _, err := fpcfg.NewRootLogger(homePath, cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize the logger: %w", err)
 	}
@@ -72,6 +128,10 @@ func runCommandCommitPubRand(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create db backend: %w", err)
 	}
 
+	if err := store.MigrateDB(db, cfg.DatabaseConfig.BackupFilePath(time.Now()), logger); err != nil {
+		return fmt.Errorf("failed to migrate db: %w", err)
+	}
+
 	fpStore, err := store.NewFinalityProviderStore(db)
 	if err != nil {
 		return fmt.Errorf("failed to initiate finality provider store: %w", err)
@@ -80,6 +140,19 @@ func runCommandCommitPubRand(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to initiate public randomness store: %w", err)
 	}
+	signRecordDB, err := cfg.DatabaseConfig.GetSignRecordDBBackend()
+	if err != nil {
+		return fmt.Errorf("failed to open the sign record db: %w", err)
+	}
+	defer func() {
+		if err := signRecordDB.Close(); err != nil {
+			fmt.Printf("Failed to close the sign record db: %v\n", err)
+		}
+	}()
+	signRecordStore, err := store.NewSignRecordStore(signRecordDB)
+	if err != nil {
+		return fmt.Errorf("failed to initiate sign record store: %w", err)
+	}
 	cc, err := fpcc.NewClientController(cfg.ChainType, cfg.BabylonConfig, &cfg.BTCNetParams, logger)
 	if err != nil {
 		return fmt.Errorf("failed to create rpc client for the Babylon chain: %w", err)
@@ -90,7 +163,7 @@ func runCommandCommitPubRand(cmd *cobra.Command, args []string) error {
 	}
 
 	fp, err := service.NewFinalityProviderInstance(
-		fpPk, cfg, fpStore, pubRandStore, cc, em, metrics.NewFpMetrics(), "",
+		cmd.Context(), fpPk, cfg, fpStore, pubRandStore, signRecordStore, nil, cc, em, metrics.NewFpMetrics(), nil, "",
 		make(chan<- *service.CriticalError), logger)
 	if err != nil {
 		return fmt.Errorf("failed to create finality-provider %s instance: %w", fpPk.MarshalHex(), err)
@@ -1,7 +1,9 @@
 package daemon
 
 import (
+	"bufio"
 	"context"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -15,13 +17,14 @@ import (
 	"github.com/babylonlabs-io/babylon/types"
 	"github.com/cosmos/cosmos-sdk/client"
 	sdkflags "github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/input"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
 	fpcmd "github.com/babylonlabs-io/finality-provider/finality-provider/cmd"
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
-	dc "github.com/babylonlabs-io/finality-provider/finality-provider/service/client"
+	"github.com/babylonlabs-io/finality-provider/util"
 )
 
 var (
@@ -35,6 +38,9 @@ func CommandGetDaemonInfo() *cobra.Command {
 		Use:     "get-info",
 		Aliases: []string{"gi"},
 		Short:   "Get information of the running fpd daemon.",
+		Long: "Get the version and the git commit of the running fpd daemon, the chain id, the height of " +
+			"the connected node against the lowest processed height of the running finality providers, " +
+			"whether eotsd is reachable and the number of running finality providers.",
 		Example: fmt.Sprintf(`fpd get-info --daemon-address %s`, defaultFpdDaemonAddress),
 		Args:    cobra.NoArgs,
 		RunE:    runCommandGetDaemonInfo,
@@ -49,7 +55,7 @@ func runCommandGetDaemonInfo(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
 	}
 
-	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	client, cleanUp, err := newDaemonClient(cmd, daemonAddress)
 	if err != nil {
 		return err
 	}
@@ -68,6 +74,143 @@ func runCommandGetDaemonInfo(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
+// CommandReloadConfig returns the reload-config command by connecting to the fpd daemon.
+func CommandReloadConfig() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "reload-config",
+		Short: "Reload the configuration of the running fpd daemon.",
+		Long: "Make the running fpd daemon re-read its config file and apply the changes of the reloadable " +
+			"fields, such as the log level, the intervals and the submission settings, without restarting " +
+			"the finality providers. The same can be achieved by sending SIGHUP to the daemon.",
+		Example: fmt.Sprintf(`fpd reload-config --daemon-address %s`, defaultFpdDaemonAddress),
+		Args:    cobra.NoArgs,
+		RunE:    runCommandReloadConfig,
+	}
+	cmd.Flags().String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	return cmd
+}
+
+func runCommandReloadConfig(cmd *cobra.Command, _ []string) error {
+	daemonAddress, err := cmd.Flags().GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+
+	client, cleanUp, err := newDaemonClient(cmd, daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	res, err := client.ReloadConfig(context.Background())
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(res)
+	return nil
+}
+
+// CommandWithdrawRewards returns the withdraw-rewards command by connecting to the fpd daemon.
+func CommandWithdrawRewards() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "withdraw-rewards",
+		Short: "Withdraw the accumulated finality provider rewards.",
+		Long: "Withdraw all the finality provider rewards accumulated by the key signing the transactions of " +
+			"the running fpd daemon, or by the given finality provider whose Babylon address must be that key, " +
+			"and send them to the recipient, or the recipient in the config if not specified.",
+		Example: fmt.Sprintf(`fpd withdraw-rewards --eots-pk [eots-pk] --recipient bbn1... --daemon-address %s`,
+			defaultFpdDaemonAddress),
+		Args: cobra.NoArgs,
+		RunE: runCommandWithdrawRewards,
+	}
+	cmd.Flags().String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	cmd.Flags().String(recipientFlag, "", "The bech32 address to send the rewards to")
+	cmd.Flags().String(fpEotsPkFlag, "", "The EOTS public key of the finality provider whose rewards are withdrawn (optional)")
+	return cmd
+}
+
+func runCommandWithdrawRewards(cmd *cobra.Command, _ []string) error {
+	daemonAddress, err := cmd.Flags().GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+	recipient, err := cmd.Flags().GetString(recipientFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", recipientFlag, err)
+	}
+	fpPk, err := cmd.Flags().GetString(fpEotsPkFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpEotsPkFlag, err)
+	}
+
+	client, cleanUp, err := newDaemonClient(cmd, daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	res, err := client.WithdrawRewards(context.Background(), fpPk, recipient)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(res)
+	return nil
+}
+
+// CommandQueryRewards returns the query-rewards command by connecting to the fpd daemon.
+func CommandQueryRewards() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:     "query-rewards [eots-pk]",
+		Short:   "Query the withdrawable rewards of a finality provider.",
+		Example: fmt.Sprintf(`fpd query-rewards [eots-pk] --daemon-address %s`, defaultFpdDaemonAddress),
+		Args:    cobra.ExactArgs(1),
+		RunE:    runCommandQueryRewards,
+	}
+	cmd.Flags().String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+
+	return cmd
+}
+
+func runCommandQueryRewards(cmd *cobra.Command, args []string) error {
+	fpPk, err := types.NewBIP340PubKeyFromHex(args[0])
+	if err != nil {
+		return err
+	}
+
+	daemonAddress, err := cmd.Flags().GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+
+	client, cleanUp, err := newDaemonClient(cmd, daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	res, err := client.QueryRewards(cmd.Context(), fpPk)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(res)
+	return nil
+}
+
 // CommandCreateFP returns the create-finality-provider command by connecting to the fpd daemon.
 func CommandCreateFP() *cobra.Command {
 	var cmd = &cobra.Command{
@@ -75,10 +218,15 @@ func CommandCreateFP() *cobra.Command {
 		Aliases: []string{"cfp"},
 		Short:   "Create a finality provider object and save it in database.",
 		Long: "Create a new finality provider object and store it in the finality provider database. " +
-			"It needs to have an operating EOTS manager available and running.",
-		Example: fmt.Sprintf(`fpd create-finality-provider --daemon-address %s ...`, defaultFpdDaemonAddress),
-		Args:    cobra.NoArgs,
-		RunE:    fpcmd.RunEWithClientCtx(runCommandCreateFP),
+			"It needs to have an operating EOTS manager available and running. With --generate-only, the " +
+			"unsigned registration transaction, proof-of-possession included, is printed to be signed externally, " +
+			"e.g., offline with fpd tx sign, the key of the finality provider address being possibly only a public " +
+			"key in the keyring. The signed transaction is then validated and broadcast with --signed.",
+		Example: fmt.Sprintf(`fpd create-finality-provider --daemon-address %s ...
+fpd create-finality-provider --generate-only ... > unsigned-tx.json
+fpd create-finality-provider --signed signed-tx.json`, defaultFpdDaemonAddress),
+		Args: cobra.NoArgs,
+		RunE: fpcmd.RunEWithClientCtx(runCommandCreateFP),
 	}
 
 	f := cmd.Flags()
@@ -86,7 +234,7 @@ func CommandCreateFP() *cobra.Command {
 	f.String(keyNameFlag, "", "The unique name of the finality provider key")
 	f.String(sdkflags.FlagHome, fpcfg.DefaultFpdDir, "The application home directory")
 	f.String(chainIDFlag, "", "The identifier of the consumer chain")
-	f.String(passphraseFlag, "", "The pass phrase used to encrypt the keys")
+	addPassphraseFlags(f, "The pass phrase used to encrypt the keys")
 	f.String(hdPathFlag, "", "The hd path used to derive the private key")
 	f.String(commissionRateFlag, "0.05", "The commission rate for the finality provider, e.g., 0.05")
 	f.String(monikerFlag, "", "A human-readable name for the finality provider")
@@ -96,32 +244,46 @@ func CommandCreateFP() *cobra.Command {
 	f.String(detailsFlag, "", "Other optional details")
 	f.String(fpEotsPkFlag, "", "The hex string of the EOTS public key")
 
-	// make flags required
-	if err := cmd.MarkFlagRequired(chainIDFlag); err != nil {
-		panic(err)
-	}
-	if err := cmd.MarkFlagRequired(keyNameFlag); err != nil {
-		panic(err)
-	}
-	if err := cmd.MarkFlagRequired(monikerFlag); err != nil {
-		panic(err)
-	}
-	if err := cmd.MarkFlagRequired(commissionRateFlag); err != nil {
-		panic(err)
-	}
-	if err := cmd.MarkFlagRequired(fpEotsPkFlag); err != nil {
-		panic(err)
-	}
+	f.Uint64(gasLimitFlag, defaultCreateFPGasLimit, "The gas limit of the unsigned registration transaction printed with --generate-only")
+	f.Bool(generateOnlyFlag, false,
+		"Print the unsigned registration transaction of the created finality provider, to be signed with fpd tx sign, e.g., offline")
+	f.String(signedFlag, "",
+		"The file of the signed registration transaction to broadcast, the other flags being ignored")
 
 	return cmd
 }
 
+// defaultCreateFPGasLimit is the default gas limit of the unsigned
+// registration transaction
+const defaultCreateFPGasLimit = 300000
+
+// createFPRequiredFlags are the flags of the create-finality-provider
+// command which are required unless the signed registration is broadcast
+var createFPRequiredFlags = []string{chainIDFlag, keyNameFlag, monikerFlag, commissionRateFlag, fpEotsPkFlag}
+
 func runCommandCreateFP(ctx client.Context, cmd *cobra.Command, _ []string) error {
 	flags := cmd.Flags()
+	signedTxFile, err := flags.GetString(signedFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", signedFlag, err)
+	}
+	if signedTxFile != "" {
+		return broadcastSignedCreateFP(ctx, cmd, signedTxFile)
+	}
+	for _, name := range createFPRequiredFlags {
+		if !flags.Changed(name) {
+			return fmt.Errorf("required flag \"%s\" not set", name)
+		}
+	}
+
 	daemonAddress, err := flags.GetString(fpdDaemonAddressFlag)
 	if err != nil {
 		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
 	}
+	generateOnly, err := flags.GetBool(generateOnlyFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", generateOnlyFlag, err)
+	}
 
 	commissionRateStr, err := flags.GetString(commissionRateFlag)
 	if err != nil {
@@ -146,7 +308,7 @@ func runCommandCreateFP(ctx client.Context, cmd *cobra.Command, _ []string) erro
 		return fmt.Errorf("keyname cannot be empty")
 	}
 
-	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	client, cleanUp, err := newDaemonClient(cmd, daemonAddress)
 	if err != nil {
 		return err
 	}
@@ -165,9 +327,9 @@ func runCommandCreateFP(ctx client.Context, cmd *cobra.Command, _ []string) erro
 		return fmt.Errorf("chain-id cannot be empty")
 	}
 
-	passphrase, err := flags.GetString(passphraseFlag)
+	passphrase, err := readPassphrase(cmd, util.PassphraseSource{})
 	if err != nil {
-		return fmt.Errorf("failed to read flag %s: %w", passphraseFlag, err)
+		return err
 	}
 
 	hdPath, err := flags.GetString(hdPathFlag)
@@ -198,6 +360,10 @@ func runCommandCreateFP(ctx client.Context, cmd *cobra.Command, _ []string) erro
 		return err
 	}
 
+	if generateOnly {
+		return printUnsignedCreateFP(ctx, cmd, info, &description, &commissionRate)
+	}
+
 	printRespJSON(info.FinalityProvider)
 	return nil
 }
@@ -208,6 +374,8 @@ func CommandUnjailFP() *cobra.Command {
 		Use:     "unjail-finality-provider",
 		Aliases: []string{"ufp"},
 		Short:   "Unjail the given finality provider.",
+		Long: "Send a transaction to the consumer chain to unjail the finality provider with the given EOTS " +
+			"public key and restart its instance. The unjailing is confirmed interactively unless --yes is set.",
 		Example: fmt.Sprintf(`fpd unjail-finality-provider [eots-pk] --daemon-address %s ...`, defaultFpdDaemonAddress),
 		Args:    cobra.ExactArgs(1),
 		RunE:    fpcmd.RunEWithClientCtx(runCommandUnjailFP),
@@ -215,6 +383,7 @@ func CommandUnjailFP() *cobra.Command {
 
 	f := cmd.Flags()
 	f.String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	f.Bool(sdkflags.FlagSkipConfirmation, false, "Skip the confirmation of the unjailing")
 
 	return cmd
 }
@@ -225,8 +394,24 @@ func runCommandUnjailFP(_ client.Context, cmd *cobra.Command, args []string) err
 	if err != nil {
 		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
 	}
+	skipConfirmation, err := flags.GetBool(sdkflags.FlagSkipConfirmation)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", sdkflags.FlagSkipConfirmation, err)
+	}
+
+	if !skipConfirmation {
+		confirmed, err := input.GetConfirmation(
+			fmt.Sprintf("send a transaction to unjail the finality provider %s", args[0]),
+			bufio.NewReader(cmd.InOrStdin()), cmd.ErrOrStderr())
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return fmt.Errorf("aborted")
+		}
+	}
 
-	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	client, cleanUp, err := newDaemonClient(cmd, daemonAddress)
 	if err != nil {
 		return err
 	}
@@ -236,10 +421,11 @@ func runCommandUnjailFP(_ client.Context, cmd *cobra.Command, args []string) err
 		}
 	}()
 
-	_, err = client.UnjailFinalityProvider(context.Background(), args[0])
+	res, err := client.UnjailFinalityProvider(context.Background(), args[0], true)
 	if err != nil {
 		return err
 	}
+	printRespJSON(res)
 
 	return nil
 }
@@ -283,16 +469,53 @@ func CommandLsFP() *cobra.Command {
 		RunE:    runCommandLsFP,
 	}
 	cmd.Flags().String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	cmd.Flags().StringSlice(statusFlag, nil, "Filter finality providers by status (e.g., ACTIVE,INACTIVE)")
+	cmd.Flags().String(chainIDFlag, "", "Filter finality providers by chain ID")
+	cmd.Flags().String(pageKeyFlag, "", "The base64 encoded key to start the listing from, returned as next_key by the previous page")
+	cmd.Flags().Uint64(limitFlag, 0, "The maximum number of finality providers to list, 0 means no limit")
 	return cmd
 }
 
 func runCommandLsFP(cmd *cobra.Command, _ []string) error {
-	daemonAddress, err := cmd.Flags().GetString(fpdDaemonAddressFlag)
+	flags := cmd.Flags()
+	daemonAddress, err := flags.GetString(fpdDaemonAddressFlag)
 	if err != nil {
 		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
 	}
 
-	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	statusStrs, err := flags.GetStringSlice(statusFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", statusFlag, err)
+	}
+	statuses := make([]proto.FinalityProviderStatus, 0, len(statusStrs))
+	for _, statusStr := range statusStrs {
+		status, ok := proto.FinalityProviderStatus_value[strings.ToUpper(statusStr)]
+		if !ok {
+			return fmt.Errorf("invalid finality provider status %s", statusStr)
+		}
+		statuses = append(statuses, proto.FinalityProviderStatus(status))
+	}
+
+	chainID, err := flags.GetString(chainIDFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", chainIDFlag, err)
+	}
+
+	pageKeyStr, err := flags.GetString(pageKeyFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", pageKeyFlag, err)
+	}
+	pageKey, err := base64.StdEncoding.DecodeString(pageKeyStr)
+	if err != nil {
+		return fmt.Errorf("invalid page key %s: %w", pageKeyStr, err)
+	}
+
+	limit, err := flags.GetUint64(limitFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", limitFlag, err)
+	}
+
+	client, cleanUp, err := newDaemonClient(cmd, daemonAddress)
 	if err != nil {
 		return err
 	}
@@ -302,7 +525,7 @@ func runCommandLsFP(cmd *cobra.Command, _ []string) error {
 		}
 	}()
 
-	resp, err := client.QueryFinalityProviderList(context.Background())
+	resp, err := client.QueryFinalityProviderList(context.Background(), statuses, chainID, pageKey, limit)
 	if err != nil {
 		return err
 	}
@@ -336,7 +559,7 @@ func runCommandInfoFP(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
 	}
 
-	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	client, cleanUp, err := newDaemonClient(cmd, daemonAddress)
 	if err != nil {
 		return err
 	}
@@ -367,7 +590,7 @@ func CommandRegisterFP() *cobra.Command {
 	}
 	f := cmd.Flags()
 	f.String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
-	f.String(passphraseFlag, "", "The pass phrase used to encrypt the keys")
+	addPassphraseFlags(f, "The pass phrase used to encrypt the keys")
 	return cmd
 }
 
@@ -383,7 +606,7 @@ func runCommandRegisterFP(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
 	}
 
-	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	client, cleanUp, err := newDaemonClient(cmd, daemonAddress)
 	if err != nil {
 		return err
 	}
@@ -393,9 +616,9 @@ func runCommandRegisterFP(cmd *cobra.Command, args []string) error {
 		}
 	}()
 
-	passphrase, err := flags.GetString(passphraseFlag)
+	passphrase, err := readPassphrase(cmd, util.PassphraseSource{})
 	if err != nil {
-		return fmt.Errorf("failed to read flag %s: %w", passphraseFlag, err)
+		return err
 	}
 
 	res, err := client.RegisterFinalityProvider(context.Background(), fpPk, passphrase)
@@ -444,7 +667,7 @@ func runCommandAddFinalitySig(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to read flag %s: %w", appHashFlag, err)
 	}
 
-	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	client, cleanUp, err := newDaemonClient(cmd, daemonAddress)
 	if err != nil {
 		return err
 	}
@@ -505,7 +728,40 @@ func runCommandEditFinalityDescription(cmd *cobra.Command, args []string) error
 		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
 	}
 
-	grpcClient, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	// only the changed fields are sent, the others keeping their current
+	// values on the chain
+	editFlags := []string{monikerFlag, identityFlag, websiteFlag, securityContactFlag, detailsFlag, commissionRateFlag}
+	changed := false
+	for _, name := range editFlags {
+		changed = changed || flags.Changed(name)
+	}
+	if !changed {
+		return fmt.Errorf("at least one of the fields to edit should be specified")
+	}
+
+	description, err := getDescriptionFromFlags(flags)
+	if err != nil {
+		return err
+	}
+	desc := &proto.Description{
+		Moniker:         description.Moniker,
+		Identity:        description.Identity,
+		Website:         description.Website,
+		SecurityContact: description.SecurityContact,
+		Details:         description.Details,
+	}
+
+	rate, err := flags.GetString(commissionRateFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", commissionRateFlag, err)
+	}
+	if rate != "" {
+		if _, err := math.LegacyNewDecFromStr(rate); err != nil {
+			return fmt.Errorf("invalid commission rate %s: %w", rate, err)
+		}
+	}
+
+	grpcClient, cleanUp, err := newDaemonClient(cmd, daemonAddress)
 	if err != nil {
 		return err
 	}
@@ -515,28 +771,271 @@ func runCommandEditFinalityDescription(cmd *cobra.Command, args []string) error
 		}
 	}()
 
-	moniker, _ := cmd.Flags().GetString(monikerFlag)
-	website, _ := cmd.Flags().GetString(websiteFlag)
-	securityContact, _ := cmd.Flags().GetString(securityContactFlag)
-	details, _ := cmd.Flags().GetString(detailsFlag)
-	identity, _ := cmd.Flags().GetString(identityFlag)
-	rate, _ := cmd.Flags().GetString(commissionRateFlag)
+	if err := grpcClient.EditFinalityProvider(cmd.Context(), fpPk, desc, rate); err != nil {
+		return fmt.Errorf("failed to edit finality provider %v err %w", fpPk.MarshalHex(), err)
+	}
 
-	desc := &proto.Description{
-		Moniker:         moniker,
-		Identity:        identity,
-		Website:         website,
-		SecurityContact: securityContact,
-		Details:         details,
+	// print the finality provider reconciled with the updated values
+	res, err := grpcClient.QueryFinalityProviderInfo(cmd.Context(), fpPk)
+	if err != nil {
+		return err
 	}
 
-	if err := grpcClient.EditFinalityProvider(cmd.Context(), fpPk, desc, rate); err != nil {
-		return fmt.Errorf("failed to edit finality provider %v err %w", fpPk.MarshalHex(), err)
+	printRespJSON(res.FinalityProvider)
+	return nil
+}
+
+// CommandUpdateCommission updates the commission rate of a finality provider
+func CommandUpdateCommission() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "update-commission [eots-pk] [rate]",
+		Short: "Update the commission rate of a finality provider.",
+		Long: "Update the on-chain commission rate of the finality provider with the given EOTS public key. " +
+			"\nThe rate must be between the minimum commission rate of Babylon and 1, " +
+			"and the finality provider must correspond to the Babylon address controlled by the key specified in fpd.conf.",
+		Example: fmt.Sprintf(`fpd update-commission [eots-pk] 0.1 --daemon-address %s`, defaultFpdDaemonAddress),
+		Args:    cobra.ExactArgs(2),
+		RunE:    runCommandUpdateCommission,
+	}
+	cmd.Flags().String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+
+	return cmd
+}
+
+func runCommandUpdateCommission(cmd *cobra.Command, args []string) error {
+	fpPk, err := types.NewBIP340PubKeyFromHex(args[0])
+	if err != nil {
+		return err
 	}
 
+	if _, err := math.LegacyNewDecFromStr(args[1]); err != nil {
+		return fmt.Errorf("invalid commission rate %s: %w", args[1], err)
+	}
+
+	daemonAddress, err := cmd.Flags().GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+
+	grpcClient, cleanUp, err := newDaemonClient(cmd, daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	res, err := grpcClient.UpdateCommission(cmd.Context(), fpPk, args[1])
+	if err != nil {
+		return fmt.Errorf("failed to update the commission rate of finality provider %s: %w", fpPk.MarshalHex(), err)
+	}
+
+	printRespJSON(res)
 	return nil
 }
 
+// CommandPauseFP returns the pause-finality-provider command by connecting to the fpd daemon.
+func CommandPauseFP() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "pause-finality-provider [eots-pk]",
+		Short: "Pause the voting of a running finality provider.",
+		Long: "Stop the running finality provider with the given EOTS public key from voting and committing " +
+			"public randomness, e.g., during the maintenance of the EOTS manager, while keeping its status sync " +
+			"and metrics alive. The blocks received while paused are not voted.",
+		Example: fmt.Sprintf(`fpd pause-finality-provider [eots-pk] --daemon-address %s`, defaultFpdDaemonAddress),
+		Args:    cobra.ExactArgs(1),
+		RunE:    runCommandPauseFP,
+	}
+	cmd.Flags().String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+
+	return cmd
+}
+
+func runCommandPauseFP(cmd *cobra.Command, args []string) error {
+	fpPk, err := types.NewBIP340PubKeyFromHex(args[0])
+	if err != nil {
+		return err
+	}
+
+	daemonAddress, err := cmd.Flags().GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+
+	grpcClient, cleanUp, err := newDaemonClient(cmd, daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	return grpcClient.PauseFinalityProvider(cmd.Context(), fpPk)
+}
+
+// CommandResumeFP returns the resume-finality-provider command by connecting to the fpd daemon.
+func CommandResumeFP() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:     "resume-finality-provider [eots-pk]",
+		Short:   "Resume the voting of a paused finality provider.",
+		Example: fmt.Sprintf(`fpd resume-finality-provider [eots-pk] --daemon-address %s`, defaultFpdDaemonAddress),
+		Args:    cobra.ExactArgs(1),
+		RunE:    runCommandResumeFP,
+	}
+	cmd.Flags().String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+
+	return cmd
+}
+
+func runCommandResumeFP(cmd *cobra.Command, args []string) error {
+	fpPk, err := types.NewBIP340PubKeyFromHex(args[0])
+	if err != nil {
+		return err
+	}
+
+	daemonAddress, err := cmd.Flags().GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+
+	grpcClient, cleanUp, err := newDaemonClient(cmd, daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	return grpcClient.ResumeFinalityProvider(cmd.Context(), fpPk)
+}
+
+// CommandEnterMaintenance returns the enter-maintenance command by connecting to the fpd daemon.
+func CommandEnterMaintenance() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "enter-maintenance",
+		Short: "Freeze the chain submissions and the db writes of the running fpd daemon.",
+		Long: "Make the running fpd daemon stop submitting transactions and writing to its db, e.g., to back up " +
+			"or compact the db, while the status queries, the metrics and the health checks keep being served. " +
+			"The command returns once the running submissions and writes have completed. The blocks received " +
+			"in the meantime are voted after exiting the maintenance mode unless finalized.",
+		Example: fmt.Sprintf(`fpd enter-maintenance --daemon-address %s`, defaultFpdDaemonAddress),
+		Args:    cobra.NoArgs,
+		RunE:    runCommandEnterMaintenance,
+	}
+	cmd.Flags().String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+
+	return cmd
+}
+
+func runCommandEnterMaintenance(cmd *cobra.Command, _ []string) error {
+	daemonAddress, err := cmd.Flags().GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+
+	grpcClient, cleanUp, err := newDaemonClient(cmd, daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	return grpcClient.EnterMaintenance(cmd.Context())
+}
+
+// CommandExitMaintenance returns the exit-maintenance command by connecting to the fpd daemon.
+func CommandExitMaintenance() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:     "exit-maintenance",
+		Short:   "Resume the chain submissions and the db writes of the running fpd daemon.",
+		Example: fmt.Sprintf(`fpd exit-maintenance --daemon-address %s`, defaultFpdDaemonAddress),
+		Args:    cobra.NoArgs,
+		RunE:    runCommandExitMaintenance,
+	}
+	cmd.Flags().String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+
+	return cmd
+}
+
+func runCommandExitMaintenance(cmd *cobra.Command, _ []string) error {
+	daemonAddress, err := cmd.Flags().GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+
+	grpcClient, cleanUp, err := newDaemonClient(cmd, daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	return grpcClient.ExitMaintenance(cmd.Context())
+}
+
+// CommandStopFP returns the stop-fp command by connecting to the fpd daemon.
+func CommandStopFP() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "stop-fp",
+		Short: "Stop the instance of a running finality provider.",
+		Long: "Stop the instance of the finality provider with the given EOTS public key, leaving the daemon " +
+			"and the other finality providers running. The instance is not restarted by the status sync " +
+			"until the daemon restarts or the finality provider is started again.",
+		Example: fmt.Sprintf(`fpd stop-fp --eots-pk [eots-pk] --daemon-address %s`, defaultFpdDaemonAddress),
+		Args:    cobra.NoArgs,
+		RunE:    runCommandStopFP,
+	}
+	cmd.Flags().String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	cmd.Flags().String(fpEotsPkFlag, "", "The hex string of the EOTS public key of the finality provider to stop")
+
+	if err := cmd.MarkFlagRequired(fpEotsPkFlag); err != nil {
+		panic(err)
+	}
+
+	return cmd
+}
+
+func runCommandStopFP(cmd *cobra.Command, _ []string) error {
+	fpPkStr, err := cmd.Flags().GetString(fpEotsPkFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpEotsPkFlag, err)
+	}
+
+	fpPk, err := types.NewBIP340PubKeyFromHex(fpPkStr)
+	if err != nil {
+		return err
+	}
+
+	daemonAddress, err := cmd.Flags().GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+
+	grpcClient, cleanUp, err := newDaemonClient(cmd, daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	return grpcClient.StopFinalityProvider(cmd.Context(), fpPk)
+}
+
 func printRespJSON(resp interface{}) {
 	jsonBytes, err := json.MarshalIndent(resp, "", "    ")
 	if err != nil {
@@ -579,7 +1078,7 @@ func CommandVersion() *cobra.Command {
 		Aliases: []string{"v"},
 		Example: `fpd version`,
 		Args:    cobra.NoArgs,
-		Run: func(cmd *cobra.Command, _ []string) {
+		RunE: func(cmd *cobra.Command, _ []string) error {
 			version := fpversion.Version()
 			commit, ts := fpversion.CommitInfo()
 
@@ -587,6 +1086,14 @@ func CommandVersion() *cobra.Command {
 				version = "main"
 			}
 
+			if util.IsJSONOutput(cmd) {
+				return util.PrintJSON(cmd.OutOrStdout(), map[string]string{
+					"version":       version,
+					"git_commit":    commit,
+					"git_timestamp": ts,
+				})
+			}
+
 			var sb strings.Builder
 			_, _ = sb.WriteString("Version:       " + version)
 			_, _ = sb.WriteString("\n")
@@ -596,6 +1103,8 @@ func CommandVersion() *cobra.Command {
 			_, _ = sb.WriteString("\n")
 
 			cmd.Printf(sb.String()) //nolint:govet // it's not an issue
+
+			return nil
 		},
 	}
 	return cmd
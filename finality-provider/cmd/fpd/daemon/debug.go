@@ -0,0 +1,514 @@
+package daemon
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/spf13/cobra"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/errreport"
+	"github.com/babylonlabs-io/finality-provider/util"
+	fpversion "github.com/babylonlabs-io/finality-provider/version"
+)
+
+const (
+	defaultDebugLogLines = 5000
+	// debugRequestTimeout bounds each request to the running daemon
+	debugRequestTimeout = 30 * time.Second
+	// debugDBTimeout bounds the opening of the db, which fails while the
+	// daemon holds it
+	debugDBTimeout = time.Second
+	// maxDebugLogLineSize is the max size of a line of the log file
+	maxDebugLogLineSize = 1024 * 1024
+
+	debugRedacted = "[REDACTED]"
+)
+
+// the files of the debug bundle
+const (
+	debugManifestFile  = "manifest.json"
+	debugConfigFile    = "config/fpd.conf"
+	debugLogFile       = "logs/fpd.log"
+	debugGoroutineFile = "profiles/goroutine.txt"
+	debugHeapFile      = "profiles/heap.pb.gz"
+	debugStoreFile     = "store.json"
+	debugDoctorFile    = "doctor.json"
+)
+
+// debugSecretConfigKeys are the lowercase names of the config options whose
+// values are redacted from the debug bundle, the other values being scrubbed
+// of the secrets they may embed, e.g., the credentials of the URLs
+var debugSecretConfigKeys = map[string]struct{}{
+	"webhookurl":          {},
+	"slackwebhookurl":     {},
+	"pagerdutyroutingkey": {},
+	"telegrambottoken":    {},
+	"dsn":                 {},
+	"natsurl":             {},
+	"kafkarestproxyurl":   {},
+	"passphrasecommand":   {},
+}
+
+// debugBundleManifest describes the content of the debug bundle
+type debugBundleManifest struct {
+	CreatedAt time.Time `json:"created_at"`
+	Version   string    `json:"version"`
+	HomePath  string    `json:"home_path"`
+	Files     []string  `json:"files"`
+	// Errors are the errors of the parts which could not be collected in
+	// full, keyed by their file
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// debugBundleResponse is the output of the debug bundle command
+type debugBundleResponse struct {
+	Path   string            `json:"path"`
+	Files  []string          `json:"files"`
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// debugStoreStats are the statistics of the db in the debug bundle
+type debugStoreStats struct {
+	Backend   string `json:"backend"`
+	Path      string `json:"path"`
+	SizeBytes int64  `json:"size_bytes"`
+	// Buckets is the number of entries of each top level bucket, only set
+	// if the db is not held by the running daemon
+	Buckets map[string]uint64 `json:"buckets,omitempty"`
+}
+
+// CommandDebug returns the debug command which groups the subcommands
+// helping to troubleshoot the daemon
+func CommandDebug() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        "debug",
+		Short:                      "Troubleshooting subcommands",
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	cmd.AddCommand(
+		CommandDebugBundle(),
+	)
+
+	return cmd
+}
+
+// CommandDebugBundle returns the debug bundle command which collects the
+// diagnostics of the daemon into an archive
+func CommandDebugBundle() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "bundle",
+		Short: "Collect the diagnostics of the daemon into an archive to attach to a support ticket.",
+		Long: "Collect into a tar.gz archive the config with its secrets redacted, the last lines of the log file, the " +
+			"goroutine and heap profiles of the running daemon, the statistics of the db and the result of the doctor " +
+			"checks. The profiles are fetched from the metrics server, which serves them if Pprof is set in the " +
+			"[metrics] section. The entries of the db are counted only if the daemon is stopped, as it holds the db. " +
+			"The parts which cannot be collected are listed along with their errors in the manifest of the archive, " +
+			"the rest being collected anyway.",
+		Example: fmt.Sprintf(`fpd debug bundle --home /home/user/.fpd --daemon-address %s
+fpd debug bundle --output-file fpd-debug.tar.gz --log-lines 10000`, defaultFpdDaemonAddress),
+		Args: cobra.NoArgs,
+		RunE: runCommandDebugBundle,
+	}
+	f := cmd.Flags()
+	f.String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	f.String(outputFileFlag, "", "The path of the archive, fpd-debug-<time>.tar.gz in the current directory if empty")
+	f.Uint64(logLinesFlag, defaultDebugLogLines, "The number of the last lines of the log file to collect")
+
+	return cmd
+}
+
+func runCommandDebugBundle(cmd *cobra.Command, _ []string) error {
+	flags := cmd.Flags()
+	daemonAddress, err := flags.GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+	outputFile, err := flags.GetString(outputFileFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", outputFileFlag, err)
+	}
+	logLines, err := flags.GetUint64(logLinesFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", logLinesFlag, err)
+	}
+
+	clientCtx := client.GetClientContextFromCmd(cmd)
+	homePath, err := filepath.Abs(clientCtx.HomeDir)
+	if err != nil {
+		return err
+	}
+	homePath = util.CleanAndExpandPath(homePath)
+
+	now := time.Now().UTC()
+	if outputFile == "" {
+		outputFile = fmt.Sprintf("fpd-debug-%s.tar.gz", now.Format("20060102-150405"))
+	}
+
+	manifest := &debugBundleManifest{
+		CreatedAt: now,
+		Version:   fpversion.Version(),
+		HomePath:  homePath,
+		Files:     []string{},
+		Errors:    make(map[string]string),
+	}
+
+	// the config is still collected if it is invalid, the defaults being
+	// used for the other parts
+	cfg, err := fpcfg.LoadConfig(homePath)
+	if err != nil {
+		manifest.Errors[debugConfigFile] = err.Error()
+		defaultCfg := fpcfg.DefaultConfigWithHome(homePath)
+		cfg = &defaultCfg
+	}
+
+	// #nosec G304 - The output path is provided by the user and not externally
+	f, err := os.OpenFile(outputFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create the archive %s: %w", outputFile, err)
+	}
+	defer f.Close()
+	gw := gzip.NewWriter(f)
+	b := &debugBundle{tw: tar.NewWriter(gw), manifest: manifest, now: now}
+
+	if err := b.collect(debugConfigFile, func() ([]byte, error) {
+		return readRedactedConfig(fpcfg.CfgFile(homePath))
+	}); err != nil {
+		return err
+	}
+	if err := b.collect(debugLogFile, func() ([]byte, error) {
+		return tailLogFile(fpcfg.LogFile(homePath), logLines)
+	}); err != nil {
+		return err
+	}
+	if err := b.collect(debugGoroutineFile, func() ([]byte, error) {
+		return fetchProfile(cfg, "goroutine?debug=2")
+	}); err != nil {
+		return err
+	}
+	if err := b.collect(debugHeapFile, func() ([]byte, error) {
+		return fetchProfile(cfg, "heap")
+	}); err != nil {
+		return err
+	}
+	if err := b.collect(debugStoreFile, func() ([]byte, error) {
+		stats, err := storeStatistics(cfg.DatabaseConfig)
+		if stats == nil {
+			return nil, err
+		}
+		bz, marshalErr := json.MarshalIndent(stats, "", "    ")
+		if marshalErr != nil {
+			return nil, marshalErr
+		}
+
+		return bz, err
+	}); err != nil {
+		return err
+	}
+	if err := b.collect(debugDoctorFile, func() ([]byte, error) {
+		return runDebugDoctor(cmd, daemonAddress)
+	}); err != nil {
+		return err
+	}
+
+	if len(manifest.Errors) == 0 {
+		manifest.Errors = nil
+	}
+	manifestBz, err := json.MarshalIndent(manifest, "", "    ")
+	if err != nil {
+		return err
+	}
+	if err := b.add(debugManifestFile, manifestBz); err != nil {
+		return err
+	}
+
+	if err := b.tw.Close(); err != nil {
+		return fmt.Errorf("failed to write the archive: %w", err)
+	}
+	if err := gw.Close(); err != nil {
+		return fmt.Errorf("failed to write the archive: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write the archive: %w", err)
+	}
+
+	return util.PrintJSON(cmd.OutOrStdout(), &debugBundleResponse{
+		Path:   outputFile,
+		Files:  manifest.Files,
+		Errors: manifest.Errors,
+	})
+}
+
+// debugBundle writes the files of the debug bundle into the archive and
+// records them in the manifest
+type debugBundle struct {
+	tw       *tar.Writer
+	manifest *debugBundleManifest
+	now      time.Time
+}
+
+// add writes the given file into the archive
+func (b *debugBundle) add(name string, data []byte) error {
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(data)),
+		ModTime: b.now,
+	}
+	if err := b.tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("failed to write %s into the archive: %w", name, err)
+	}
+	if _, err := b.tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s into the archive: %w", name, err)
+	}
+	b.manifest.Files = append(b.manifest.Files, name)
+
+	return nil
+}
+
+// collect writes the file returned by the given function into the archive.
+// The error of the function is recorded in the manifest rather than
+// returned, the partial file returned along with it being written anyway
+func (b *debugBundle) collect(name string, collectFile func() ([]byte, error)) error {
+	data, err := collectFile()
+	if err != nil {
+		b.manifest.Errors[name] = err.Error()
+	}
+	if data == nil {
+		return nil
+	}
+
+	return b.add(name, data)
+}
+
+// readRedactedConfig reads the config file and redacts the values of the
+// secret options, including the commented ones
+func readRedactedConfig(path string) ([]byte, error) {
+	// #nosec G304 - The config path is provided by the user and not externally
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(redactConfig(string(bz))), nil
+}
+
+func redactConfig(content string) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		option := strings.TrimLeft(strings.TrimSpace(line), ";# ")
+		key, value, found := strings.Cut(option, "=")
+		if !found || strings.TrimSpace(value) == "" {
+			continue
+		}
+		if _, secret := debugSecretConfigKeys[strings.ToLower(strings.TrimSpace(key))]; secret {
+			lines[i] = line[:strings.Index(line, "=")+1] + " " + debugRedacted
+		}
+	}
+
+	return errreport.ScrubString(strings.Join(lines, "\n"))
+}
+
+// tailLogFile returns the given number of the last lines of the log file,
+// scrubbed of the secrets
+func tailLogFile(path string, numLines uint64) ([]byte, error) {
+	if numLines == 0 {
+		return nil, nil
+	}
+
+	// #nosec G304 - The log path is derived from the home directory
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	// the last lines are kept in a ring
+	ring := make([]string, numLines)
+	var n uint64
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxDebugLogLineSize)
+	for scanner.Scan() {
+		ring[n%numLines] = scanner.Text()
+		n++
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read the log file %s: %w", path, err)
+	}
+
+	var sb strings.Builder
+	start := uint64(0)
+	if n > numLines {
+		start = n - numLines
+	}
+	for i := start; i < n; i++ {
+		sb.WriteString(ring[i%numLines])
+		sb.WriteByte('\n')
+	}
+
+	return []byte(errreport.ScrubString(sb.String())), nil
+}
+
+// fetchProfile fetches the given pprof profile of the running daemon from
+// its metrics server
+func fetchProfile(cfg *fpcfg.Config, profile string) ([]byte, error) {
+	if cfg.Metrics == nil || !cfg.Metrics.Pprof {
+		return nil, fmt.Errorf("the profiles are not served, set Pprof in the [metrics] section")
+	}
+	addr, err := cfg.Metrics.Address()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), debugRequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://%s/debug/pprof/%s", addr, profile), nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the profile from %s: %w", addr, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch the profile from %s: %s", addr, res.Status)
+	}
+
+	return io.ReadAll(res.Body)
+}
+
+// storeStatistics returns the size of the db and the number of entries of
+// its top level buckets. The statistics are returned along with the error
+// if the entries cannot be counted, e.g., as the daemon holds the db
+func storeStatistics(cfg *fpcfg.DBConfig) (*debugStoreStats, error) {
+	stats := &debugStoreStats{Backend: cfg.Backend}
+	switch cfg.Backend {
+	case fpcfg.DBBackendBolt, "":
+		stats.Path = filepath.Join(cfg.DBPath, cfg.DBFileName)
+	case fpcfg.DBBackendPebble:
+		stats.Path = cfg.PebbleDir()
+	default:
+		return stats, fmt.Errorf("the %s backend is not stored on disk", cfg.Backend)
+	}
+
+	// the db is not opened if it does not exist, as it would be created
+	err := filepath.WalkDir(stats.Path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		stats.SizeBytes += info.Size()
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the size of the db: %w", err)
+	}
+
+	dbCfg := *cfg
+	dbCfg.DBTimeout = debugDBTimeout
+	db, err := dbCfg.GetDBBackend()
+	if err != nil {
+		return stats, fmt.Errorf("failed to open the db, which is held while the daemon is running: %w", err)
+	}
+	defer db.Close()
+
+	err = db.View(func(tx kvdb.RTx) error {
+		return tx.ForEachBucket(func(name []byte) error {
+			bucket := tx.ReadBucket(name)
+			if bucket == nil {
+				return nil
+			}
+			n, err := countBucketEntries(bucket)
+			if err != nil {
+				return err
+			}
+			stats.Buckets[string(name)] = n
+
+			return nil
+		})
+	}, func() {
+		stats.Buckets = make(map[string]uint64)
+	})
+	if err != nil {
+		stats.Buckets = nil
+		return stats, fmt.Errorf("failed to count the entries of the db: %w", err)
+	}
+
+	return stats, nil
+}
+
+// countBucketEntries returns the number of the entries of the bucket,
+// including the ones of its nested buckets
+func countBucketEntries(bucket kvdb.RBucket) (uint64, error) {
+	var n uint64
+	err := bucket.ForEach(func(k, v []byte) error {
+		if v == nil {
+			if nested := bucket.NestedReadBucket(k); nested != nil {
+				nestedN, err := countBucketEntries(nested)
+				n += nestedN
+				return err
+			}
+		}
+		n++
+
+		return nil
+	})
+
+	return n, err
+}
+
+// runDebugDoctor runs the doctor checks of the running daemon with its own
+// passphrase
+func runDebugDoctor(cmd *cobra.Command, daemonAddress string) ([]byte, error) {
+	client, cleanUp, err := newDaemonClient(cmd, daemonAddress)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = cleanUp()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), debugRequestTimeout)
+	defer cancel()
+
+	res, err := client.Doctor(ctx, nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to run the doctor checks of the daemon at %s: %w", daemonAddress, err)
+	}
+
+	bz, err := json.MarshalIndent(res, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+	if res.Status != "ok" {
+		return bz, errors.New("some of the checks are failing")
+	}
+
+	return bz, nil
+}
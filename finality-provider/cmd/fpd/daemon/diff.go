@@ -0,0 +1,124 @@
+package daemon
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/spf13/cobra"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+// CommandDiff returns the diff command which compares the stored finality
+// providers with the chain
+func CommandDiff() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "diff [eots-pk]",
+		Short: "Compare the stored finality providers with the chain.",
+		Long: "Show the discrepancies between the finality providers stored in the db, i.e., their status, " +
+			"commission, description and last voted height, and the on-chain data, e.g., when the status of a " +
+			"finality provider is stuck. All the stored finality providers are compared unless an EOTS public key " +
+			"is given. With --fix, the stored finality providers are reconciled with the chain, the last voted " +
+			"height being never decreased. The daemon must be stopped while fixing.",
+		Example: `fpd diff --home /home/user/.fpd
+fpd diff [eots-pk] --fix --home /home/user/.fpd`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runCommandDiff,
+	}
+	f := cmd.Flags()
+	f.Bool(fixFlag, false, "Reconcile the stored finality providers with the chain")
+	f.Uint64(scanBlocksFlag, defaultRecoverScanBlocks,
+		"The number of the latest blocks searched for the last vote of the finality providers")
+
+	return cmd
+}
+
+func runCommandDiff(cmd *cobra.Command, args []string) error {
+	flags := cmd.Flags()
+	fix, err := flags.GetBool(fixFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fixFlag, err)
+	}
+	scanBlocks, err := flags.GetUint64(scanBlocksFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", scanBlocksFlag, err)
+	}
+
+	var fpPks []*bbntypes.BIP340PubKey
+	if len(args) == 1 {
+		fpPk, err := bbntypes.NewBIP340PubKeyFromHex(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid fp btc pk hex %s: %w", args[0], err)
+		}
+		fpPks = append(fpPks, fpPk)
+	}
+
+	clientCtx := client.GetClientContextFromCmd(cmd)
+	homePath, err := filepath.Abs(clientCtx.HomeDir)
+	if err != nil {
+		return err
+	}
+	homePath = util.CleanAndExpandPath(homePath)
+
+	cfg, err := fpcfg.LoadConfig(homePath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	logger, _, err := fpcfg.NewRootLogger(homePath, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize the logger: %w", err)
+	}
+
+	db, err := cfg.DatabaseConfig.GetDBBackend()
+	if err != nil {
+		return fmt.Errorf("failed to create db backend: %w", err)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Printf("Failed to close the db: %v\n", err)
+		}
+	}()
+
+	if err := store.MigrateDB(db, cfg.DatabaseConfig.BackupFilePath(time.Now()), logger); err != nil {
+		return fmt.Errorf("failed to migrate db: %w", err)
+	}
+
+	fpApp, err := service.NewFinalityProviderAppFromConfig(cfg, db, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create finality-provider app: %w", err)
+	}
+
+	if len(fpPks) == 0 {
+		fpInfos, err := fpApp.ListAllFinalityProvidersInfo()
+		if err != nil {
+			return fmt.Errorf("failed to list the finality providers: %w", err)
+		}
+		for _, fpInfo := range fpInfos {
+			fpPk, err := bbntypes.NewBIP340PubKeyFromHex(fpInfo.BtcPkHex)
+			if err != nil {
+				return fmt.Errorf("invalid stored fp btc pk hex %s: %w", fpInfo.BtcPkHex, err)
+			}
+			fpPks = append(fpPks, fpPk)
+		}
+	}
+
+	diffs := make([]*service.FinalityProviderDiff, 0, len(fpPks))
+	for _, fpPk := range fpPks {
+		diff, err := fpApp.DiffFinalityProvider(fpPk, scanBlocks, fix)
+		if err != nil {
+			return fmt.Errorf("failed to compare the finality provider %s with the chain: %w", fpPk.MarshalHex(), err)
+		}
+		diffs = append(diffs, diff)
+	}
+
+	printRespJSON(diffs)
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+// doctorStatusLabels are the labels of the statuses of the checks
+var doctorStatusLabels = map[string]string{
+	"ok":      "PASS",
+	"warning": "WARN",
+	"failing": "FAIL",
+}
+
+// CommandDoctor returns the doctor command by connecting to the fpd daemon.
+func CommandDoctor() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose the connectivity and the configuration of the running fpd daemon",
+		Long: `Run the preflight checks of the running fpd daemon on demand: the reachability of the node and its
+chain id, the reachability of eotsd and the EOTS keys of the finality providers, the balance of the
+key signing the transactions, the health of the db, the runway of the public randomness and the clock
+skew. The result of each check is printed along with a remediation hint if it does not pass. The keys
+of the running finality providers are checked unless some are given with --eots-pk, and the passphrase
+of the daemon is used unless one is given.`,
+		Example: fmt.Sprintf(`fpd doctor --eots-pk [eots-pk] --daemon-address %s`, defaultFpdDaemonAddress),
+		Args:    cobra.NoArgs,
+		RunE:    runCommandDoctor,
+	}
+	f := cmd.Flags()
+	f.String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	f.StringSlice(fpEotsPkFlag, nil, "The EOTS public keys of the finality providers to check, the running ones if empty")
+	addPassphraseFlags(f, "The pass phrase used to unlock the EOTS keys, the one of the daemon if empty")
+
+	return cmd
+}
+
+func runCommandDoctor(cmd *cobra.Command, _ []string) error {
+	flags := cmd.Flags()
+	daemonAddress, err := flags.GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+	fpPks, err := flags.GetStringSlice(fpEotsPkFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpEotsPkFlag, err)
+	}
+	passphrase, err := readPassphrase(cmd, util.PassphraseSource{})
+	if err != nil {
+		return err
+	}
+
+	client, cleanUp, err := newDaemonClient(cmd, daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	res, err := client.Doctor(context.Background(), fpPks, passphrase)
+	if err != nil {
+		return err
+	}
+
+	if util.IsJSONOutput(cmd) {
+		printRespJSON(res)
+	} else if err := printDoctorTable(res); err != nil {
+		return err
+	}
+
+	if res.Status != "ok" {
+		return fmt.Errorf("some of the checks are failing")
+	}
+
+	return nil
+}
+
+// printDoctorTable prints the result of each check along with its error
+// and its hint
+func printDoctorTable(res *proto.DoctorResponse) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "CHECK\tSTATUS\tDETAILS")
+	for _, c := range res.Checks {
+		status, ok := doctorStatusLabels[c.Status]
+		if !ok {
+			status = c.Status
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", c.Name, status, c.Error)
+		if c.Hint != "" {
+			fmt.Fprintf(w, "\t\thint: %s\n", c.Hint)
+		}
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,183 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/spf13/cobra"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/eventbus"
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+// CommandSubscribeEvents returns the subscribe-events command by connecting to the fpd daemon.
+func CommandSubscribeEvents() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "subscribe-events",
+		Short: "Stream the events of the finality providers as they happen",
+		Long: `Stream the events of the finality providers run by the daemon, e.g., the status changes,
+the votes, the public randomness commits and the critical errors, as JSON until interrupted.
+The events are dropped while the client cannot keep up with them.`,
+		Example: fmt.Sprintf(`fpd subscribe-events --type vote_submitted,status_changed --daemon-address %s`,
+			defaultFpdDaemonAddress),
+		Args: cobra.NoArgs,
+		RunE: runCommandSubscribeEvents,
+	}
+	f := cmd.Flags()
+	f.String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	f.StringSlice(eventTypeFlag, nil, "Only stream the events of the given types, e.g., vote_submitted,status_changed (optional)")
+	f.String(fpEotsPkFlag, "", "Only stream the events of the finality provider with the given EOTS public key (optional)")
+
+	return cmd
+}
+
+func runCommandSubscribeEvents(cmd *cobra.Command, _ []string) error {
+	flags := cmd.Flags()
+	daemonAddress, err := flags.GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+	eventTypes, err := flags.GetStringSlice(eventTypeFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", eventTypeFlag, err)
+	}
+	fpPkHex, err := flags.GetString(fpEotsPkFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpEotsPkFlag, err)
+	}
+
+	client, cleanUp, err := newDaemonClient(cmd, daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	stream, err := client.SubscribeEvents(ctx, eventTypes, fpPkHex)
+	if err != nil {
+		return err
+	}
+	for {
+		ev, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) || ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		printRespJSON(ev)
+	}
+}
+
+// eventHistoryResponse is the output of the events command
+type eventHistoryResponse struct {
+	Path   string            `json:"path"`
+	Events []*eventbus.Event `json:"events"`
+}
+
+// CommandEvents returns the events command which queries the local history
+// of the recent events of the daemon
+func CommandEvents() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "events",
+		Short: "Query the recent events of the finality providers from the local event history",
+		Long: `Query the recent events of the finality providers, e.g., the votes, the status changes and the
+critical errors, from the bounded on-disk history kept by the daemon, so that an incident can be
+reconstructed without a centralized log infrastructure. The history is read directly, whether or not
+the daemon is running. The types are given by their names or by their short names, i.e., created,
+registered, vote, pubrand, status, equivocation, unknown_vote and error.`,
+		Example: `fpd events --since 2h --type vote,status
+fpd events --eots-pk [eots-pk-hex] --limit 20 --home /home/user/.fpd`,
+		Args: cobra.NoArgs,
+		RunE: runCommandEvents,
+	}
+	f := cmd.Flags()
+	f.Duration(sinceFlag, 0, "Only print the events of the given last duration, e.g., 2h; 0 prints the whole history")
+	f.StringSlice(eventTypeFlag, nil, "Only print the events of the given types, e.g., vote,status (optional)")
+	f.String(fpEotsPkFlag, "", "Only print the events of the finality provider with the given EOTS public key (optional)")
+	f.Uint64(limitFlag, 0, "Only print the given number of the last events; 0 prints them all")
+
+	return cmd
+}
+
+func runCommandEvents(cmd *cobra.Command, _ []string) error {
+	flags := cmd.Flags()
+	since, err := flags.GetDuration(sinceFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", sinceFlag, err)
+	}
+	typeNames, err := flags.GetStringSlice(eventTypeFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", eventTypeFlag, err)
+	}
+	fpPkHex, err := flags.GetString(fpEotsPkFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpEotsPkFlag, err)
+	}
+	limit, err := flags.GetUint64(limitFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", limitFlag, err)
+	}
+	if since < 0 {
+		return fmt.Errorf("the duration of --%s should not be negative", sinceFlag)
+	}
+
+	eventTypes, err := eventbus.ParseEventTypes(typeNames)
+	if err != nil {
+		return err
+	}
+
+	clientCtx := client.GetClientContextFromCmd(cmd)
+	homePath, err := filepath.Abs(clientCtx.HomeDir)
+	if err != nil {
+		return err
+	}
+	homePath = util.CleanAndExpandPath(homePath)
+
+	cfg, err := fpcfg.LoadConfig(homePath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.EventBusConfig == nil || cfg.EventBusConfig.HistoryPath == "" {
+		return fmt.Errorf("the event history is not configured")
+	}
+
+	filter := &eventbus.HistoryFilter{
+		Types:   eventTypes,
+		FpBtcPk: fpPkHex,
+	}
+	if since > 0 {
+		filter.Since = time.Now().Add(-since)
+	}
+	events, err := eventbus.ReadHistory(cfg.EventBusConfig.HistoryPath, filter)
+	if err != nil {
+		return err
+	}
+	if limit > 0 && uint64(len(events)) > limit {
+		events = events[uint64(len(events))-limit:]
+	}
+	if events == nil {
+		events = []*eventbus.Event{}
+	}
+
+	printRespJSON(&eventHistoryResponse{
+		Path:   cfg.EventBusConfig.HistoryPath,
+		Events: events,
+	})
+
+	return nil
+}
@@ -15,7 +15,7 @@ import (
 
 	fpcmd "github.com/babylonlabs-io/finality-provider/finality-provider/cmd"
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
-	dc "github.com/babylonlabs-io/finality-provider/finality-provider/service/client"
+	"github.com/babylonlabs-io/finality-provider/util"
 )
 
 // FinalityProviderSigned wraps the finality provider by adding the
@@ -47,7 +47,7 @@ func CommandExportFP() *cobra.Command {
 	)
 	f.String(keyNameFlag, "", "The unique name of the finality provider key")
 	f.String(sdkflags.FlagHome, fpcfg.DefaultFpdDir, "The application home directory")
-	f.String(passphraseFlag, "", "The pass phrase used to encrypt the keys")
+	addPassphraseFlags(f, "The pass phrase used to encrypt the keys")
 	f.String(hdPathFlag, "", "The hd path used to derive the private key")
 
 	return cmd
@@ -60,7 +60,7 @@ func runCommandExportFP(ctx client.Context, cmd *cobra.Command, args []string) e
 		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
 	}
 
-	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	client, cleanUp, err := newDaemonClient(cmd, daemonAddress)
 	if err != nil {
 		return fmt.Errorf("failled to connect to daemon addr %s: %w", daemonAddress, err)
 	}
@@ -122,9 +122,9 @@ func runCommandExportFP(ctx client.Context, cmd *cobra.Command, args []string) e
 		return fmt.Errorf("failed to marshal finality provider %+v: %w", fp, err)
 	}
 
-	passphrase, err := flags.GetString(passphraseFlag)
+	passphrase, err := readPassphrase(cmd, util.PassphraseSource{})
 	if err != nil {
-		return fmt.Errorf("failed to read flag %s: %w", passphraseFlag, err)
+		return err
 	}
 
 	hdPath, err := flags.GetString(hdPathFlag)
@@ -11,6 +11,44 @@ const (
 	hdPathFlag           = "hd-path"
 	chainIDFlag          = "chain-id"
 	signedFlag           = "signed"
+	statusFlag           = "status"
+	pageKeyFlag          = "page-key"
+	limitFlag            = "limit"
+	recipientFlag        = "recipient"
+	dryRunFlag           = "dry-run"
+	scanBlocksFlag       = "scan-blocks"
+	fpAddrFlag           = "fp-addr"
+	startHeightFlag      = "start-height"
+	endHeightFlag        = "end-height"
+	eventTypeFlag        = "type"
+	targetHeightFlag     = "target-height"
+	outputFileFlag       = "output-file"
+	hexFlag              = "hex"
+	logModuleFlag        = "module"
+	beforeHeightFlag     = "before-height"
+	nonInteractiveFlag   = "non-interactive"
+	nodeRPCAddressFlag   = "node-rpc-address"
+	nodeGRPCAddressFlag  = "node-grpc-address"
+	eotsManagerAddrFlag  = "eots-manager-address"
+	createKeyFlag        = "create-key"
+	registerFlag         = "register"
+	generateOnlyFlag     = "generate-only"
+	gasLimitFlag         = "gas-limit"
+	fixFlag              = "fix"
+	operationFlag        = "operation"
+	sinceFlag            = "since"
+	logLinesFlag         = "log-lines"
+
+	// flags for the credentials of the daemon client
+	tlsCertPathFlag       = "tls-cert-path"
+	tlsClientCertPathFlag = "tls-client-cert-path"
+	tlsClientKeyPathFlag  = "tls-client-key-path"
+	authTokenPathFlag     = "auth-token-path"
+
+	// flags for the sources of the passphrase
+	passphraseFileFlag    = "passphrase-file"
+	passphraseCommandFlag = "passphrase-command"
+	passphrasePromptFlag  = "passphrase-prompt"
 
 	// flags for description
 	monikerFlag         = "moniker"
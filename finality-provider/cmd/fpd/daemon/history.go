@@ -0,0 +1,139 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/babylonlabs-io/babylon/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/spf13/cobra"
+)
+
+// CommandHistory returns the history command which groups the subcommands
+// querying the locally recorded submissions of the finality providers
+func CommandHistory() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        "history",
+		Short:                      "Submission history subcommands",
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	cmd.AddCommand(
+		CommandHistoryVotes(),
+		CommandHistoryPubRandCommits(),
+	)
+
+	return cmd
+}
+
+// CommandHistoryVotes returns the history votes command by connecting to the fpd daemon.
+func CommandHistoryVotes() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "votes [fp-eots-pk-hex]",
+		Short: "Query the recorded votes of a finality provider",
+		Long: `Query the locally recorded results of the votes of the finality provider within a range of
+heights, along with the hashes of the transactions including them. The heights not voted are omitted.
+Without the end height, only the start height is queried.`,
+		Example: fmt.Sprintf(`fpd history votes [fp-eots-pk-hex] --start-height 100 --end-height 200 --daemon-address %s`,
+			defaultFpdDaemonAddress),
+		Args: cobra.ExactArgs(1),
+		RunE: runCommandHistoryVotes,
+	}
+	f := cmd.Flags()
+	f.String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	f.Uint64(startHeightFlag, 0, "The first height of the range")
+	f.Uint64(endHeightFlag, 0, "The last height of the range (optional)")
+
+	if err := cmd.MarkFlagRequired(startHeightFlag); err != nil {
+		panic(err)
+	}
+
+	return cmd
+}
+
+func runCommandHistoryVotes(cmd *cobra.Command, args []string) error {
+	fpPk, err := types.NewBIP340PubKeyFromHex(args[0])
+	if err != nil {
+		return err
+	}
+
+	flags := cmd.Flags()
+	daemonAddress, err := flags.GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+	startHeight, err := flags.GetUint64(startHeightFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", startHeightFlag, err)
+	}
+	endHeight, err := flags.GetUint64(endHeightFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", endHeightFlag, err)
+	}
+
+	client, cleanUp, err := newDaemonClient(cmd, daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	votes, err := client.QueryVotes(context.Background(), fpPk, startHeight, endHeight)
+	if err != nil {
+		return err
+	}
+	printRespJSON(votes)
+
+	return nil
+}
+
+// CommandHistoryPubRandCommits returns the history pub-rand-commits command by connecting to the fpd daemon.
+func CommandHistoryPubRandCommits() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "pub-rand-commits [fp-eots-pk-hex]",
+		Short: "Query the recorded public randomness commits of a finality provider",
+		Long: `Query the locally recorded results of the public randomness commits of the finality provider,
+along with the hashes of the transactions including them.`,
+		Example: fmt.Sprintf(`fpd history pub-rand-commits [fp-eots-pk-hex] --daemon-address %s`,
+			defaultFpdDaemonAddress),
+		Args: cobra.ExactArgs(1),
+		RunE: runCommandHistoryPubRandCommits,
+	}
+	cmd.Flags().String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+
+	return cmd
+}
+
+func runCommandHistoryPubRandCommits(cmd *cobra.Command, args []string) error {
+	fpPk, err := types.NewBIP340PubKeyFromHex(args[0])
+	if err != nil {
+		return err
+	}
+
+	daemonAddress, err := cmd.Flags().GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+
+	client, cleanUp, err := newDaemonClient(cmd, daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	commits, err := client.QueryPubRandCommits(context.Background(), fpPk)
+	if err != nil {
+		return err
+	}
+	printRespJSON(commits)
+
+	return nil
+}
@@ -1,12 +1,16 @@
 package daemon
 
 import (
+	"bufio"
 	"fmt"
+	"os"
 	"path/filepath"
 
 	"github.com/cosmos/cosmos-sdk/client"
+	sdkflags "github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/jessevdk/go-flags"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 
 	fpcmd "github.com/babylonlabs-io/finality-provider/finality-provider/cmd"
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
@@ -15,15 +19,37 @@ import (
 
 // CommandInit returns the init command of fpd daemon that starts the config dir.
 func CommandInit() *cobra.Command {
+	defaultConfig := fpcfg.DefaultConfigWithHome(fpcfg.DefaultFpdDir)
+
 	var cmd = &cobra.Command{
-		Use:     "init",
-		Short:   "Initialize a finality-provider home directory.",
-		Long:    `Creates a new finality-provider home directory with default config`,
-		Example: `fpd init --home /home/user/.fpd --force`,
-		Args:    cobra.NoArgs,
-		RunE:    fpcmd.RunEWithClientCtx(runInitCmd),
-	}
-	cmd.Flags().Bool(forceFlag, false, "Override existing configuration")
+		Use:   "init",
+		Short: "Initialize a finality-provider home directory.",
+		Long: "Creates a new finality-provider home directory with a validated config. On a terminal, a wizard " +
+			"asks for the chain id, the node endpoints, the eotsd address and the key, and optionally creates the " +
+			"key and creates and registers the finality provider, the flags giving the default answers. With " +
+			"--non-interactive or without a terminal, the values of the flags are used without asking.",
+		Example: `fpd init --home /home/user/.fpd --force
+fpd init --non-interactive --chain-id bbn-test-5 --node-rpc-address http://localhost:26657 --create-key`,
+		Args: cobra.NoArgs,
+		RunE: fpcmd.RunEWithClientCtx(runInitCmd),
+	}
+	f := cmd.Flags()
+	f.Bool(forceFlag, false, "Override existing configuration")
+	f.Bool(nonInteractiveFlag, false, "Use the values of the flags without asking")
+	f.String(chainIDFlag, defaultConfig.BabylonConfig.ChainID, "The chain id of the Babylon chain")
+	f.String(nodeRPCAddressFlag, defaultConfig.BabylonConfig.RPCAddr, "The RPC address of the Babylon node")
+	f.String(nodeGRPCAddressFlag, defaultConfig.BabylonConfig.GRPCAddr, "The gRPC address of the Babylon node")
+	f.String(eotsManagerAddrFlag, defaultConfig.EOTSManagerAddress, "The RPC address of eotsd")
+	f.String(keyNameFlag, defaultConfig.BabylonConfig.Key, "The name of the key of the finality provider")
+	f.String(sdkflags.FlagKeyringBackend, defaultConfig.BabylonConfig.KeyringBackend, "The keyring backend (os|file|test)")
+	f.Bool(createKeyFlag, false, "Create the key in the keyring if it does not exist")
+	f.Bool(registerFlag, false, "Create and register the finality provider, which requires eotsd and the node to be reachable")
+	f.String(fpEotsPkFlag, "", "The hex string of the EOTS public key of the finality provider to register")
+	f.String(monikerFlag, "", "A human-readable name for the finality provider to register")
+	f.String(commissionRateFlag, "0.05", "The commission rate of the finality provider to register, e.g., 0.05")
+	addPassphraseFlags(f, "The pass phrase used to encrypt the key")
+	f.String(hdPathFlag, "", "The hd path used to derive the private key")
+
 	return cmd
 }
 
@@ -43,6 +69,32 @@ func runInitCmd(ctx client.Context, cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("home path %s already exists", homePath)
 	}
 
+	settings, err := readInitSettings(cmd)
+	if err != nil {
+		return err
+	}
+
+	nonInteractive, err := cmd.Flags().GetBool(nonInteractiveFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", nonInteractiveFlag, err)
+	}
+	// #nosec G115 -- the file descriptor of stdin fits in an int
+	if !nonInteractive && term.IsTerminal(int(os.Stdin.Fd())) {
+		p := &initPrompter{reader: bufio.NewReader(cmd.InOrStdin()), out: cmd.ErrOrStderr()}
+		if err := settings.ask(p); err != nil {
+			return err
+		}
+	}
+	if err := settings.validate(); err != nil {
+		return err
+	}
+
+	cfg := fpcfg.DefaultConfigWithHome(homePath)
+	settings.apply(&cfg)
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
 	if err := util.MakeDirectory(homePath); err != nil {
 		return err
 	}
@@ -52,8 +104,34 @@ func runInitCmd(ctx client.Context, cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
-	defaultConfig := fpcfg.DefaultConfigWithHome(homePath)
-	fileParser := flags.NewParser(&defaultConfig, flags.Default)
+	fileParser := flags.NewParser(&cfg, flags.Default)
+	if err := flags.NewIniParser(fileParser).WriteFile(fpcfg.CfgFile(homePath), flags.IniIncludeComments|flags.IniIncludeDefaults); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Config written to %s\n", fpcfg.CfgFile(homePath))
+
+	if !settings.CreateKey && !settings.Register {
+		return nil
+	}
+
+	passphrase, err := readPassphrase(cmd, util.PassphraseSource{})
+	if err != nil {
+		return err
+	}
+	hdPath, err := cmd.Flags().GetString(hdPathFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", hdPathFlag, err)
+	}
+
+	if settings.CreateKey {
+		if err := createInitKey(cmd, &cfg, passphrase, hdPath); err != nil {
+			return err
+		}
+	}
+
+	if settings.Register {
+		return registerInitFinalityProvider(cmd, homePath, settings, passphrase, hdPath)
+	}
 
-	return flags.NewIniParser(fileParser).WriteFile(fpcfg.CfgFile(homePath), flags.IniIncludeComments|flags.IniIncludeDefaults)
+	return nil
 }
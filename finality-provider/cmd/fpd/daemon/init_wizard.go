@@ -0,0 +1,343 @@
+package daemon
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"cosmossdk.io/math"
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	sdkflags "github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/spf13/cobra"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/audit"
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	fpkr "github.com/babylonlabs-io/finality-provider/keyring"
+)
+
+// initSettings are the values asked by the init wizard, defaulting to the
+// values of the flags
+type initSettings struct {
+	ChainID            string
+	NodeRPCAddress     string
+	NodeGRPCAddress    string
+	EOTSManagerAddress string
+	KeyName            string
+	KeyringBackend     string
+	CreateKey          bool
+	Register           bool
+	EotsPkHex          string
+	Moniker            string
+	CommissionRate     string
+}
+
+func readInitSettings(cmd *cobra.Command) (*initSettings, error) {
+	f := cmd.Flags()
+	s := &initSettings{}
+
+	strFlags := []struct {
+		name  string
+		value *string
+	}{
+		{chainIDFlag, &s.ChainID},
+		{nodeRPCAddressFlag, &s.NodeRPCAddress},
+		{nodeGRPCAddressFlag, &s.NodeGRPCAddress},
+		{eotsManagerAddrFlag, &s.EOTSManagerAddress},
+		{keyNameFlag, &s.KeyName},
+		{sdkflags.FlagKeyringBackend, &s.KeyringBackend},
+		{fpEotsPkFlag, &s.EotsPkHex},
+		{monikerFlag, &s.Moniker},
+		{commissionRateFlag, &s.CommissionRate},
+	}
+	for _, flag := range strFlags {
+		value, err := f.GetString(flag.name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read flag %s: %w", flag.name, err)
+		}
+		*flag.value = value
+	}
+
+	var err error
+	if s.CreateKey, err = f.GetBool(createKeyFlag); err != nil {
+		return nil, fmt.Errorf("failed to read flag %s: %w", createKeyFlag, err)
+	}
+	if s.Register, err = f.GetBool(registerFlag); err != nil {
+		return nil, fmt.Errorf("failed to read flag %s: %w", registerFlag, err)
+	}
+
+	return s, nil
+}
+
+// validate checks the values which are not checked by the validation of
+// the config
+func (s *initSettings) validate() error {
+	if s.ChainID == "" {
+		return fmt.Errorf("the chain id cannot be empty")
+	}
+	if s.KeyName == "" {
+		return fmt.Errorf("the key name cannot be empty")
+	}
+	if !s.Register {
+		return nil
+	}
+
+	if err := validateEotsPkHex(s.EotsPkHex); err != nil {
+		return err
+	}
+	if s.Moniker == "" {
+		return fmt.Errorf("the moniker of the finality provider to register cannot be empty")
+	}
+
+	return validateCommissionRate(s.CommissionRate)
+}
+
+// ask asks for each of the settings, re-asking the invalid answers
+func (s *initSettings) ask(p *initPrompter) error {
+	var err error
+	if s.ChainID, err = p.ask("Chain id of the Babylon chain", s.ChainID, nonEmpty); err != nil {
+		return err
+	}
+	if s.NodeRPCAddress, err = p.ask("RPC address of the Babylon node", s.NodeRPCAddress, nonEmpty); err != nil {
+		return err
+	}
+	if s.NodeGRPCAddress, err = p.ask("gRPC address of the Babylon node", s.NodeGRPCAddress, nonEmpty); err != nil {
+		return err
+	}
+	if s.EOTSManagerAddress, err = p.ask("RPC address of eotsd", s.EOTSManagerAddress, nonEmpty); err != nil {
+		return err
+	}
+	if s.KeyName, err = p.ask("Name of the key of the finality provider", s.KeyName, nonEmpty); err != nil {
+		return err
+	}
+	if s.KeyringBackend, err = p.ask("Keyring backend (os|file|test)", s.KeyringBackend, nonEmpty); err != nil {
+		return err
+	}
+	if s.CreateKey, err = p.confirm(fmt.Sprintf("Create the key %s if it does not exist", s.KeyName), s.CreateKey); err != nil {
+		return err
+	}
+	if s.Register, err = p.confirm("Create and register the finality provider now", s.Register); err != nil {
+		return err
+	}
+	if !s.Register {
+		return nil
+	}
+
+	if s.EotsPkHex, err = p.ask("EOTS public key (hex) created with eotsd", s.EotsPkHex, validateEotsPkHex); err != nil {
+		return err
+	}
+	if s.Moniker, err = p.ask("Moniker of the finality provider", s.Moniker, nonEmpty); err != nil {
+		return err
+	}
+	if s.CommissionRate, err = p.ask("Commission rate", s.CommissionRate, validateCommissionRate); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// apply sets the settings to the given config
+func (s *initSettings) apply(cfg *fpcfg.Config) {
+	cfg.BabylonConfig.ChainID = s.ChainID
+	cfg.BabylonConfig.RPCAddr = s.NodeRPCAddress
+	cfg.BabylonConfig.GRPCAddr = s.NodeGRPCAddress
+	cfg.BabylonConfig.Key = s.KeyName
+	cfg.BabylonConfig.KeyringBackend = s.KeyringBackend
+	cfg.EOTSManagerAddress = s.EOTSManagerAddress
+}
+
+func nonEmpty(value string) error {
+	if value == "" {
+		return fmt.Errorf("the value cannot be empty")
+	}
+
+	return nil
+}
+
+func validateEotsPkHex(value string) error {
+	if _, err := bbntypes.NewBIP340PubKeyFromHex(value); err != nil {
+		return fmt.Errorf("invalid EOTS public key %q: %w", value, err)
+	}
+
+	return nil
+}
+
+func validateCommissionRate(value string) error {
+	rate, err := math.LegacyNewDecFromStr(value)
+	if err != nil {
+		return fmt.Errorf("invalid commission rate %q: %w", value, err)
+	}
+	if rate.IsNegative() || rate.GT(math.LegacyOneDec()) {
+		return fmt.Errorf("the commission rate %s should be within [0, 1]", value)
+	}
+
+	return nil
+}
+
+// initPrompter asks the questions of the init wizard
+type initPrompter struct {
+	reader *bufio.Reader
+	out    io.Writer
+}
+
+// ask asks for a value until it is valid, the empty answer selecting the
+// default value
+func (p *initPrompter) ask(question, defaultValue string, validate func(string) error) (string, error) {
+	for {
+		fmt.Fprintf(p.out, "%s [%s]: ", question, defaultValue)
+		answer, err := p.reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", err
+		}
+		answer = strings.TrimSpace(answer)
+		if answer == "" {
+			answer = defaultValue
+		}
+
+		validationErr := validate(answer)
+		if validationErr == nil {
+			return answer, nil
+		}
+		if errors.Is(err, io.EOF) {
+			return "", validationErr
+		}
+		fmt.Fprintf(p.out, "%v\n", validationErr)
+	}
+}
+
+// confirm asks a yes or no question
+func (p *initPrompter) confirm(question string, defaultValue bool) (bool, error) {
+	defaultAnswer := "y/N"
+	if defaultValue {
+		defaultAnswer = "Y/n"
+	}
+
+	answer, err := p.ask(question, defaultAnswer, func(value string) error {
+		switch strings.ToLower(value) {
+		case "y/n", "y", "yes", "n", "no":
+			return nil
+		default:
+			return fmt.Errorf("the answer should be yes or no")
+		}
+	})
+	if err != nil {
+		return false, err
+	}
+
+	switch strings.ToLower(answer) {
+	case "y", "yes":
+		return true, nil
+	case "n", "no":
+		return false, nil
+	default:
+		return defaultValue, nil
+	}
+}
+
+// createInitKey creates the key of the config in the keyring unless it
+// already exists, and prints its address and mnemonic
+func createInitKey(cmd *cobra.Command, cfg *fpcfg.Config, passphrase, hdPath string) error {
+	bbnCfg := cfg.BabylonConfig
+	kr, err := fpkr.CreateKeyring(bbnCfg.KeyDirectory, bbnCfg.ChainID, bbnCfg.KeyringBackend, strings.NewReader(passphrase+"\n"))
+	if err != nil {
+		return fmt.Errorf("failed to open the keyring: %w", err)
+	}
+	if _, err := kr.Key(bbnCfg.Key); err == nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "The key %s already exists, skip creating it\n", bbnCfg.Key)
+		return nil
+	}
+
+	keyInfo, err := service.CreateChainKey(
+		bbnCfg.KeyDirectory, bbnCfg.ChainID, bbnCfg.Key, bbnCfg.KeyringBackend, passphrase, hdPath, "")
+	details := map[string]string{"key_name": bbnCfg.Key}
+	if keyInfo != nil {
+		details["address"] = keyInfo.AccAddress.String()
+	}
+	recordCLIAudit(cmd, cfg, audit.OpCreateKey, details, err)
+	if err != nil {
+		return fmt.Errorf("failed to create the key %s: %w", bbnCfg.Key, err)
+	}
+	addr, err := sdk.Bech32ifyAddressBytes(bbnCfg.AccountPrefix, keyInfo.AccAddress)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.ErrOrStderr()
+	fmt.Fprintf(out, "Created the key %s with the address %s, which should be funded to register the finality provider\n",
+		bbnCfg.Key, addr)
+	fmt.Fprintf(out, "Write down the mnemonic, which is the only way to recover the key:\n\n%s\n\n", keyInfo.Mnemonic)
+
+	return nil
+}
+
+// registerInitFinalityProvider creates the finality provider with the
+// written config and registers it on the chain, which requires eotsd and the
+// node to be reachable
+func registerInitFinalityProvider(cmd *cobra.Command, homePath string, s *initSettings, passphrase, hdPath string) error {
+	cfg, err := fpcfg.LoadConfig(homePath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	logger, _, err := fpcfg.NewRootLogger(homePath, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize the logger: %w", err)
+	}
+
+	db, err := cfg.DatabaseConfig.GetDBBackend()
+	if err != nil {
+		return fmt.Errorf("failed to create db backend: %w", err)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Printf("Failed to close the db: %v\n", err)
+		}
+	}()
+
+	if err := store.MigrateDB(db, cfg.DatabaseConfig.BackupFilePath(time.Now()), logger); err != nil {
+		return fmt.Errorf("failed to migrate db: %w", err)
+	}
+
+	fpApp, err := service.NewFinalityProviderAppFromConfig(cfg, db, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create finality-provider app: %w", err)
+	}
+	if err := fpApp.Start(); err != nil {
+		return fmt.Errorf("failed to start the finality-provider app: %w", err)
+	}
+	defer func() {
+		if err := fpApp.Stop(); err != nil {
+			fmt.Printf("Failed to stop the finality-provider app: %v\n", err)
+		}
+	}()
+
+	eotsPk, err := bbntypes.NewBIP340PubKeyFromHex(s.EotsPkHex)
+	if err != nil {
+		return fmt.Errorf("invalid EOTS public key: %w", err)
+	}
+	commissionRate, err := math.LegacyNewDecFromStr(s.CommissionRate)
+	if err != nil {
+		return fmt.Errorf("invalid commission rate: %w", err)
+	}
+	description := stakingtypes.Description{Moniker: s.Moniker}
+
+	res, err := fpApp.CreateFinalityProvider(s.KeyName, s.ChainID, passphrase, hdPath, eotsPk, &description, &commissionRate)
+	if err != nil {
+		return fmt.Errorf("failed to create the finality provider: %w", err)
+	}
+	registerRes, err := fpApp.RegisterFinalityProvider(s.EotsPkHex)
+	if err != nil {
+		return fmt.Errorf("failed to register the finality provider %s: %w", s.EotsPkHex, err)
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Registered the finality provider %s in the tx %s\n", s.EotsPkHex, registerRes.TxHash)
+	printRespJSON(res.FpInfo)
+
+	return nil
+}
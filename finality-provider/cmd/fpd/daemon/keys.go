@@ -1,12 +1,32 @@
 package daemon
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdkflags "github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/client/keys"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"github.com/spf13/cobra"
 
+	"github.com/babylonlabs-io/finality-provider/finality-provider/audit"
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
 	"github.com/babylonlabs-io/finality-provider/util"
 )
 
+// keyBalance is the output of the list-balances command for a key
+type keyBalance struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	Balance string `json:"balance,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
 // CommandKeys returns the keys group command and updates the add command to do a
 // post run action to update the config if exists.
 func CommandKeys() *cobra.Command {
@@ -17,5 +37,112 @@ func CommandKeys() *cobra.Command {
 	}
 
 	keyAddCmd.Long += "\nIf this key is needed to run as the default for the finality-provider daemon, remind to update the fpd.conf"
+	keyAddCmd.PostRun = recordKeyAddAudit
+	keysCmd.Long += "\nThe keyring backend and directory default to the ones of the fpd.conf, i.e., the keyring used by the daemon."
+	keysCmd.AddCommand(CommandListBalances())
+
 	return keysCmd
 }
+
+// recordKeyAddAudit records the key created by the add command in the audit
+// log of the config, if any
+func recordKeyAddAudit(cmd *cobra.Command, args []string) {
+	if dryRun, _ := cmd.Flags().GetBool(sdkflags.FlagDryRun); dryRun || len(args) == 0 {
+		return
+	}
+
+	homePath, err := filepath.Abs(client.GetClientContextFromCmd(cmd).HomeDir)
+	if err != nil {
+		return
+	}
+	// the keyring might be used without a config
+	cfg, err := fpcfg.LoadConfig(util.CleanAndExpandPath(homePath))
+	if err != nil {
+		return
+	}
+
+	recordCLIAudit(cmd, cfg, audit.OpCreateKey, map[string]string{"key_name": args[0]}, nil)
+}
+
+// CommandListBalances returns the list-balances command which lists the keys
+// of the keyring along with their address and balance on the Babylon chain
+func CommandListBalances() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "list-balances",
+		Short: "List all the keys along with their address and balance",
+		Long: "List all the keys of the keyring along with their address and their balance queried from the " +
+			"Babylon node configured in the fpd.conf.",
+		Example: `fpd keys list-balances --home /home/user/.fpd`,
+		Args:    cobra.NoArgs,
+		RunE:    runCommandListBalances,
+	}
+
+	return cmd
+}
+
+func runCommandListBalances(cmd *cobra.Command, _ []string) error {
+	clientCtx, err := client.GetClientQueryContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := fpcfg.LoadConfig(clientCtx.HomeDir)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	node, err := client.NewClientFromNode(cfg.BabylonConfig.RPCAddr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Babylon node %s: %w", cfg.BabylonConfig.RPCAddr, err)
+	}
+	queryClient := banktypes.NewQueryClient(clientCtx.WithClient(node))
+
+	records, err := clientCtx.Keyring.List()
+	if err != nil {
+		return fmt.Errorf("failed to list the keys: %w", err)
+	}
+
+	balances := make([]*keyBalance, 0, len(records))
+	for _, record := range records {
+		addr, err := record.GetAddress()
+		if err != nil {
+			return fmt.Errorf("failed to get the address of the key %s: %w", record.Name, err)
+		}
+		bech32Addr, err := sdk.Bech32ifyAddressBytes(cfg.BabylonConfig.AccountPrefix, addr)
+		if err != nil {
+			return fmt.Errorf("failed to encode the address of the key %s: %w", record.Name, err)
+		}
+
+		// an unreachable node is reported for each key rather than hiding
+		// the keys
+		balance := &keyBalance{Name: record.Name, Address: bech32Addr}
+		balances = append(balances, balance)
+		ctx, cancel := context.WithTimeout(cmd.Context(), cfg.BabylonConfig.Timeout)
+		res, err := queryClient.AllBalances(ctx, &banktypes.QueryAllBalancesRequest{Address: bech32Addr})
+		cancel()
+		if err != nil {
+			balance.Error = err.Error()
+			continue
+		}
+		balance.Balance = res.Balances.String()
+	}
+
+	if util.IsJSONOutput(cmd) {
+		printRespJSON(balances)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tADDRESS\tBALANCE")
+	for _, balance := range balances {
+		value := balance.Balance
+		if balance.Error != "" {
+			value = "error: " + balance.Error
+		} else if value == "" {
+			value = "0"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", balance.Name, balance.Address, value)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,67 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/babylonlabs-io/finality-provider/log"
+)
+
+// CommandSetLogLevel returns the set-log-level command by connecting to the fpd daemon.
+func CommandSetLogLevel() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "set-log-level [level]",
+		Short: "Change the log level of the running fpd daemon.",
+		Long: fmt.Sprintf(`Change the log level of the running fpd daemon, or of one of its modules (%s),
+without restarting it, e.g., to switch to the debug logs while diagnosing an incident. The level
+of a module applies to its sub-modules, e.g., fp applies to fp.poller. Without a level, the module
+follows the root level again. The change lasts until the daemon is restarted or the log level of
+the config is reloaded.`, strings.Join(log.Modules(), ", ")),
+		Example: fmt.Sprintf(`fpd set-log-level debug --module fp.poller --daemon-address %s`, defaultFpdDaemonAddress),
+		Args:    cobra.MaximumNArgs(1),
+		RunE:    runCommandSetLogLevel,
+	}
+	cmd.Flags().String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	cmd.Flags().String(logModuleFlag, "", "The module whose log level is set, the root level if empty")
+	return cmd
+}
+
+func runCommandSetLogLevel(cmd *cobra.Command, args []string) error {
+	daemonAddress, err := cmd.Flags().GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+	module, err := cmd.Flags().GetString(logModuleFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", logModuleFlag, err)
+	}
+
+	var level string
+	if len(args) > 0 {
+		level = args[0]
+	}
+	if level == "" && module == "" {
+		return fmt.Errorf("the level should be specified for the root logger")
+	}
+
+	client, cleanUp, err := newDaemonClient(cmd, daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	res, err := client.SetLogLevel(context.Background(), level, module)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(res)
+	return nil
+}
@@ -0,0 +1,49 @@
+package daemon
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+// addPassphraseFlags adds the flags of the sources of the passphrase, at
+// most one of which can be set
+func addPassphraseFlags(f *pflag.FlagSet, usage string) {
+	f.String(passphraseFlag, "", usage)
+	f.String(passphraseFileFlag, "", "The file holding the pass phrase")
+	f.String(passphraseCommandFlag, "", "The shell command printing the pass phrase, e.g., fetching it from a secret manager")
+	f.Bool(passphrasePromptFlag, false, "Prompt for the pass phrase")
+}
+
+// readPassphrase reads the passphrase from the source set by the flags,
+// otherwise from the given default source, e.g., of the config, and from
+// the PASSPHRASE environment variable if neither is set
+func readPassphrase(cmd *cobra.Command, defaultSource util.PassphraseSource) (string, error) {
+	flags := cmd.Flags()
+
+	var (
+		source util.PassphraseSource
+		err    error
+	)
+	if source.Value, err = flags.GetString(passphraseFlag); err != nil {
+		return "", fmt.Errorf("failed to read flag %s: %w", passphraseFlag, err)
+	}
+	if source.File, err = flags.GetString(passphraseFileFlag); err != nil {
+		return "", fmt.Errorf("failed to read flag %s: %w", passphraseFileFlag, err)
+	}
+	if source.Command, err = flags.GetString(passphraseCommandFlag); err != nil {
+		return "", fmt.Errorf("failed to read flag %s: %w", passphraseCommandFlag, err)
+	}
+	if source.Prompt, err = flags.GetBool(passphrasePromptFlag); err != nil {
+		return "", fmt.Errorf("failed to read flag %s: %w", passphrasePromptFlag, err)
+	}
+
+	if !source.IsSet() {
+		source = defaultSource
+	}
+
+	return source.Read(cmd.Context())
+}
@@ -0,0 +1,286 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/spf13/cobra"
+
+	eotsclient "github.com/babylonlabs-io/finality-provider/eotsmanager/client"
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	fpkr "github.com/babylonlabs-io/finality-provider/keyring"
+	"github.com/babylonlabs-io/finality-provider/types"
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+// CommandPop returns the pop command which groups the subcommands to
+// regenerate and validate the proofs-of-possession
+func CommandPop() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        "pop",
+		Short:                      "Proof-of-possession subcommands",
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	cmd.AddCommand(
+		CommandRegeneratePop(),
+		CommandValidatePop(),
+	)
+
+	return cmd
+}
+
+// CommandRegeneratePop returns the pop regenerate command
+func CommandRegeneratePop() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "regenerate [fp-eots-pk-hex]",
+		Short: "Regenerate the proof-of-possession binding an EOTS key to a Babylon address",
+		Long: `Regenerate the proof-of-possession binding the EOTS key to a Babylon address with the EOTS manager,
+e.g., to bind the EOTS key to a different address before the registration, or to re-derive the
+proof-of-possession of a finality provider for verification. The address is the one of the
+--fp-addr flag, otherwise the one of the key named by the --key-name flag, or the key of the config,
+in the keyring. The output has the same verifiable JSON format as the one of the export-pop command
+and can be verified with the pop validate command. The local database is not read nor updated.`,
+		Example: `fpd pop regenerate --home /home/user/.fpd --key-name [key-name] [fp-eots-pk-hex]`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    runCommandRegeneratePop,
+	}
+	f := cmd.Flags()
+	f.String(fpAddrFlag, "", "The bech32 Babylon address bound to the EOTS key, the address of the key name if empty")
+	f.String(keyNameFlag, "", "The name of the key of the Babylon address in the keyring, the key of the config if empty")
+	addPassphraseFlags(f, "The pass phrase used to decrypt the keys")
+
+	return cmd
+}
+
+func runCommandRegeneratePop(cmd *cobra.Command, args []string) error {
+	fpPk, err := bbntypes.NewBIP340PubKeyFromHex(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid fp btc pk hex %s: %w", args[0], err)
+	}
+
+	clientCtx := client.GetClientContextFromCmd(cmd)
+	homePath, err := filepath.Abs(clientCtx.HomeDir)
+	if err != nil {
+		return err
+	}
+	homePath = util.CleanAndExpandPath(homePath)
+
+	cfg, err := fpcfg.LoadConfig(homePath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	passphrase, err := readPassphrase(cmd, util.PassphraseSource{})
+	if err != nil {
+		return err
+	}
+
+	fpAddrStr, fpAddr, err := loadPopAddress(cmd, homePath, cfg, passphrase)
+	if err != nil {
+		return err
+	}
+
+	em, err := eotsclient.NewEOTSManagerGRpcClient(cfg.EOTSManagerAddress)
+	if err != nil {
+		return fmt.Errorf("failed to create EOTS manager client: %w", err)
+	}
+	defer func() {
+		if err := em.Close(); err != nil {
+			fmt.Printf("Failed to close the EOTS manager client: %v\n", err)
+		}
+	}()
+
+	pop, err := service.CreatePopWithEOTSManager(em, fpAddr, fpPk, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to create proof-of-possession of the finality-provider: %w", err)
+	}
+
+	export, err := types.NewPopExport(fpPk, fpAddrStr, pop.BtcSig)
+	if err != nil {
+		return err
+	}
+
+	// the regenerated proof-of-possession is checked the same way as by
+	// the pop validate command before being printed
+	if err := types.VerifyPopExport(export); err != nil {
+		return fmt.Errorf("the regenerated proof-of-possession is invalid: %w", err)
+	}
+
+	printRespJSON(export)
+
+	return nil
+}
+
+// loadPopAddress returns the address to bind the EOTS key to, in bech32 and
+// in bytes, from the fp-addr flag or otherwise from the keyring
+func loadPopAddress(cmd *cobra.Command, homePath string, cfg *fpcfg.Config, passphrase string) (string, sdk.AccAddress, error) {
+	fpAddrStr, err := cmd.Flags().GetString(fpAddrFlag)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read flag %s: %w", fpAddrFlag, err)
+	}
+	if fpAddrStr != "" {
+		// the address is decoded regardless of its bech32 prefix so that it
+		// does not depend on the global sdk config
+		_, addrBytes, err := bech32.DecodeAndConvert(fpAddrStr)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid finality provider address %s: %w", fpAddrStr, err)
+		}
+
+		return fpAddrStr, addrBytes, nil
+	}
+
+	keyName, err := loadKeyName(homePath, cmd)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to load key name: %w", err)
+	}
+
+	input := strings.NewReader("")
+	kr, err := fpkr.CreateKeyring(cfg.BabylonConfig.KeyDirectory, cfg.BabylonConfig.ChainID, cfg.BabylonConfig.KeyringBackend, input)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create keyring: %w", err)
+	}
+	krController, err := fpkr.NewChainKeyringControllerWithKeyring(kr, keyName, input)
+	if err != nil {
+		return "", nil, err
+	}
+	fpAddr, err := krController.Address(passphrase)
+	if err != nil {
+		return "", nil, fmt.Errorf("the keyname %s does not exist, add the key first: %w", keyName, err)
+	}
+
+	fpAddrStr, err = sdk.Bech32ifyAddressBytes(cfg.BabylonConfig.AccountPrefix, fpAddr)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return fpAddrStr, fpAddr, nil
+}
+
+// CommandValidatePop returns the pop validate command
+func CommandValidatePop() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:     "validate [pop-file]",
+		Short:   "Validate the proof-of-possession output by the pop regenerate or the export-pop command",
+		Example: `fpd pop validate ./pop.json`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    runCommandVerifyPop,
+	}
+
+	return cmd
+}
+
+// CommandExportPop returns the export-pop command
+func CommandExportPop() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "export-pop [fp-eots-pk-hex]",
+		Short: "Export the proof-of-possession of a finality provider in a verifiable JSON format",
+		Long: `Export the proof-of-possession of a finality provider from the local database.
+The output binds the EOTS public key to the Babylon address of the finality provider
+and can be verified by anyone with the verify-pop command.
+NOTE: the fpd daemon should be stopped as it holds the lock of the database.`,
+		Example: `fpd export-pop --home /home/user/.fpd [fp-eots-pk-hex]`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    runCommandExportPop,
+	}
+
+	return cmd
+}
+
+func runCommandExportPop(cmd *cobra.Command, args []string) error {
+	fpPk, err := bbntypes.NewBIP340PubKeyFromHex(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid fp btc pk hex %s: %w", args[0], err)
+	}
+
+	clientCtx := client.GetClientContextFromCmd(cmd)
+	homePath, err := filepath.Abs(clientCtx.HomeDir)
+	if err != nil {
+		return err
+	}
+	homePath = util.CleanAndExpandPath(homePath)
+
+	cfg, err := fpcfg.LoadConfig(homePath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	db, err := cfg.DatabaseConfig.GetDBBackend()
+	if err != nil {
+		return fmt.Errorf("failed to create db backend: %w", err)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Printf("Failed to close the db: %v\n", err)
+		}
+	}()
+
+	fpStore, err := store.NewFinalityProviderStore(db)
+	if err != nil {
+		return fmt.Errorf("failed to initiate finality provider store: %w", err)
+	}
+
+	fp, err := fpStore.GetFinalityProvider(fpPk.MustToBTCPK())
+	if err != nil {
+		return fmt.Errorf("failed to get finality provider %s: %w", fpPk.MarshalHex(), err)
+	}
+
+	export, err := fp.ToPopExport()
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(export)
+
+	return nil
+}
+
+// CommandVerifyPop returns the verify-pop command
+func CommandVerifyPop() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:     "verify-pop [pop-file]",
+		Short:   "Verify the proof-of-possession exported by the export-pop command",
+		Example: `fpd verify-pop ./pop.json`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    runCommandVerifyPop,
+	}
+
+	return cmd
+}
+
+func runCommandVerifyPop(cmd *cobra.Command, args []string) error {
+	bz, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", args[0], err)
+	}
+
+	var export types.PopExport
+	if err := json.Unmarshal(bz, &export); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", args[0], err)
+	}
+
+	if err := types.VerifyPopExport(&export); err != nil {
+		return err
+	}
+
+	if util.IsJSONOutput(cmd) {
+		return util.PrintJSON(cmd.OutOrStdout(), map[string]interface{}{
+			"valid":       true,
+			"eots_pk_hex": export.EotsPkHex,
+			"fp_addr":     export.FpAddr,
+		})
+	}
+	fmt.Printf("The proof-of-possession of %s for %s is valid\n", export.EotsPkHex, export.FpAddr)
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package daemon
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+// CommandPrune returns the unsafe-prune command which deletes the local data
+// below a finalized height and compacts the db
+func CommandPrune() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "unsafe-prune",
+		Short: "Delete the public randomness proofs and the vote history below a finalized height, then compact the db.",
+		Long: "Delete the public randomness proofs and the vote records of all the stored finality providers at " +
+			"heights below the given height, which must not be above the last finalized height, then compact the db " +
+			"to reclaim the disk space. The pruned data cannot be recovered. The sign records, which protect against " +
+			"double signing, are kept in a separate db which is not pruned. The daemon must be stopped while pruning.",
+		Example: `fpd unsafe-prune --before-height 100000 --home /home/user/.fpd`,
+		Args:    cobra.NoArgs,
+		RunE:    runCommandPrune,
+	}
+	cmd.Flags().Uint64(beforeHeightFlag, 0, "The height below which the data is deleted")
+
+	if err := cmd.MarkFlagRequired(beforeHeightFlag); err != nil {
+		panic(err)
+	}
+
+	return cmd
+}
+
+func runCommandPrune(cmd *cobra.Command, _ []string) error {
+	beforeHeight, err := cmd.Flags().GetUint64(beforeHeightFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", beforeHeightFlag, err)
+	}
+
+	clientCtx := client.GetClientContextFromCmd(cmd)
+	homePath, err := filepath.Abs(clientCtx.HomeDir)
+	if err != nil {
+		return err
+	}
+	homePath = util.CleanAndExpandPath(homePath)
+
+	cfg, err := fpcfg.LoadConfig(homePath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	logger, _, err := fpcfg.NewRootLogger(homePath, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize the logger: %w", err)
+	}
+
+	res, err := pruneDB(cfg, beforeHeight, logger)
+	if err != nil {
+		return err
+	}
+
+	// the db is compacted once closed
+	if err := cfg.DatabaseConfig.CompactDB(); err != nil {
+		return fmt.Errorf("failed to compact the db: %w", err)
+	}
+
+	printRespJSON(res)
+
+	return nil
+}
+
+func pruneDB(cfg *fpcfg.Config, beforeHeight uint64, logger *zap.Logger) (*service.PruneResult, error) {
+	db, err := cfg.DatabaseConfig.GetDBBackend()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create db backend: %w", err)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			logger.Error("failed to close the db", zap.Error(err))
+		}
+	}()
+
+	if err := store.MigrateDB(db, cfg.DatabaseConfig.BackupFilePath(time.Now()), logger); err != nil {
+		return nil, fmt.Errorf("failed to migrate db: %w", err)
+	}
+
+	fpApp, err := service.NewFinalityProviderAppFromConfig(cfg, db, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create finality-provider app: %w", err)
+	}
+	// the app is stopped before the db is closed, releasing the sign record
+	// db and the clients
+	defer func() {
+		if err := fpApp.Stop(); err != nil {
+			logger.Error("failed to stop the finality-provider app", zap.Error(err))
+		}
+	}()
+
+	res, err := fpApp.PruneData(beforeHeight)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prune the db: %w", err)
+	}
+
+	return res, nil
+}
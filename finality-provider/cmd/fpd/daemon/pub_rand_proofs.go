@@ -0,0 +1,94 @@
+package daemon
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/babylonlabs-io/babylon/types"
+	"github.com/spf13/cobra"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+)
+
+// ExportedPubRandProof is a line of the output of the export-pub-rand-proofs
+// command
+type ExportedPubRandProof struct {
+	Height uint64 `json:"height"`
+	// Proof is the hex of the protobuf encoding of the merkle proof
+	Proof string `json:"proof"`
+}
+
+// CommandExportPubRandProofs returns the export-pub-rand-proofs command by connecting to the fpd daemon.
+func CommandExportPubRandProofs() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "export-pub-rand-proofs [fp-eots-pk-hex]",
+		Short: "Export the proofs of the public randomness of a finality provider",
+		Long: `Stream the proofs of the public randomness of the finality provider stored by the running fpd
+daemon within a range of heights, as one JSON object per line, e.g., for an external verifier or a
+backup. Without --end-height, the proofs are exported up to the last one. The proofs moved to the
+archive are not exported.`,
+		Example: fmt.Sprintf(`fpd export-pub-rand-proofs [fp-eots-pk-hex] --start-height 100 --output proofs.jsonl --daemon-address %s`,
+			defaultFpdDaemonAddress),
+		Args: cobra.ExactArgs(1),
+		RunE: runCommandExportPubRandProofs,
+	}
+	f := cmd.Flags()
+	f.String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	f.Uint64(startHeightFlag, 0, "The first height of the exported proofs")
+	f.Uint64(endHeightFlag, 0, "The last height of the exported proofs, up to the last proof if zero")
+	f.String(outputFileFlag, "", "The file to write the proofs to, which must not exist; the standard output if empty")
+
+	return cmd
+}
+
+func runCommandExportPubRandProofs(cmd *cobra.Command, args []string) error {
+	fpPk, err := types.NewBIP340PubKeyFromHex(args[0])
+	if err != nil {
+		return err
+	}
+
+	flags := cmd.Flags()
+	daemonAddress, err := flags.GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+	startHeight, err := flags.GetUint64(startHeightFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", startHeightFlag, err)
+	}
+	endHeight, err := flags.GetUint64(endHeightFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", endHeightFlag, err)
+	}
+	output, err := flags.GetString(outputFileFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", outputFileFlag, err)
+	}
+
+	client, cleanUp, err := newDaemonClient(cmd, daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	export := func(w io.Writer) error {
+		enc := json.NewEncoder(w)
+		return client.ExportPubRandProofs(cmd.Context(), fpPk.MarshalHex(), startHeight, endHeight,
+			func(p *proto.PubRandProof) error {
+				return enc.Encode(&ExportedPubRandProof{Height: p.Height, Proof: hex.EncodeToString(p.Proof)})
+			})
+	}
+
+	if output == "" {
+		return export(os.Stdout)
+	}
+
+	return writeNewFile(output, export)
+}
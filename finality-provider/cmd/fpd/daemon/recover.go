@@ -0,0 +1,111 @@
+package daemon
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/spf13/cobra"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+const defaultRecoverScanBlocks = 1000
+
+// CommandRecoverFP returns the recover command which reconstructs the
+// finality provider stored in the db from the on-chain data
+func CommandRecoverFP() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "recover",
+		Short: "Recover the local state of a registered finality provider from the chain.",
+		Long: "Reconstruct the finality provider stored in the db, i.e., its address, description, commission, " +
+			"status and last voted height, from the on-chain data, e.g., after the fpd home directory is lost " +
+			"while the EOTS key is kept. The chain key of the finality provider address must be in the keyring. " +
+			"The daemon must be stopped while recovering.",
+		Example: `fpd recover --eots-pk [eots-pk] --key-name [key-name] --home /home/user/.fpd`,
+		Args:    cobra.NoArgs,
+		RunE:    runCommandRecoverFP,
+	}
+	f := cmd.Flags()
+	f.String(fpEotsPkFlag, "", "The hex string of the EOTS public key of the finality provider to recover")
+	f.String(keyNameFlag, "", "The name of the key of the finality provider address, the key of the config if empty")
+	f.Uint64(scanBlocksFlag, defaultRecoverScanBlocks,
+		"The number of the latest blocks searched for the last vote of the finality provider")
+
+	if err := cmd.MarkFlagRequired(fpEotsPkFlag); err != nil {
+		panic(err)
+	}
+
+	return cmd
+}
+
+func runCommandRecoverFP(cmd *cobra.Command, _ []string) error {
+	flags := cmd.Flags()
+	fpPkStr, err := flags.GetString(fpEotsPkFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpEotsPkFlag, err)
+	}
+	fpPk, err := bbntypes.NewBIP340PubKeyFromHex(fpPkStr)
+	if err != nil {
+		return fmt.Errorf("invalid fp btc pk hex %s: %w", fpPkStr, err)
+	}
+	scanBlocks, err := flags.GetUint64(scanBlocksFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", scanBlocksFlag, err)
+	}
+
+	clientCtx := client.GetClientContextFromCmd(cmd)
+	homePath, err := filepath.Abs(clientCtx.HomeDir)
+	if err != nil {
+		return err
+	}
+	homePath = util.CleanAndExpandPath(homePath)
+
+	keyName, err := loadKeyName(homePath, cmd)
+	if err != nil {
+		return fmt.Errorf("failed to load key name: %w", err)
+	}
+
+	cfg, err := fpcfg.LoadConfig(homePath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	logger, _, err := fpcfg.NewRootLogger(homePath, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize the logger: %w", err)
+	}
+
+	db, err := cfg.DatabaseConfig.GetDBBackend()
+	if err != nil {
+		return fmt.Errorf("failed to create db backend: %w", err)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			fmt.Printf("Failed to close the db: %v\n", err)
+		}
+	}()
+
+	if err := store.MigrateDB(db, cfg.DatabaseConfig.BackupFilePath(time.Now()), logger); err != nil {
+		return fmt.Errorf("failed to migrate db: %w", err)
+	}
+
+	fpApp, err := service.NewFinalityProviderAppFromConfig(cfg, db, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create finality-provider app: %w", err)
+	}
+
+	info, err := fpApp.RecoverFinalityProvider(fpPk, keyName, scanBlocks)
+	if err != nil {
+		return fmt.Errorf("failed to recover the finality provider %s: %w", fpPk.MarshalHex(), err)
+	}
+
+	printRespJSON(info)
+
+	return nil
+}
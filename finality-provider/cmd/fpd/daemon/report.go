@@ -0,0 +1,88 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/babylonlabs-io/babylon/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/spf13/cobra"
+)
+
+// CommandReport returns the report command which groups the subcommands
+// reporting on the finality providers
+func CommandReport() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        "report",
+		Short:                      "Report subcommands",
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	cmd.AddCommand(
+		CommandReportParticipation(),
+	)
+
+	return cmd
+}
+
+// CommandReportParticipation returns the report participation command by connecting to the fpd daemon.
+func CommandReportParticipation() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "participation [fp-eots-pk-hex]",
+		Short: "Report the missed votes of a finality provider against the chain",
+		Long: `Compare the votes of the finality provider recorded on the consumer chain within a window of
+heights with the local records and report the heights at which the finality provider had voting power
+but no vote, along with the probable reason of each missed vote. Without the height flags, the window
+is the one of the participation report config ending at the confirmation depth below the chain tip.`,
+		Example: fmt.Sprintf(`fpd report participation [fp-eots-pk-hex] --start-height 100 --end-height 200 --daemon-address %s`,
+			defaultFpdDaemonAddress),
+		Args: cobra.ExactArgs(1),
+		RunE: runCommandReportParticipation,
+	}
+	f := cmd.Flags()
+	f.String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	f.Uint64(startHeightFlag, 0, "The first height of the report window (optional)")
+	f.Uint64(endHeightFlag, 0, "The last height of the report window (optional)")
+
+	return cmd
+}
+
+func runCommandReportParticipation(cmd *cobra.Command, args []string) error {
+	fpPk, err := types.NewBIP340PubKeyFromHex(args[0])
+	if err != nil {
+		return err
+	}
+
+	flags := cmd.Flags()
+	daemonAddress, err := flags.GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+	startHeight, err := flags.GetUint64(startHeightFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", startHeightFlag, err)
+	}
+	endHeight, err := flags.GetUint64(endHeightFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", endHeightFlag, err)
+	}
+
+	client, cleanUp, err := newDaemonClient(cmd, daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	report, err := client.QueryParticipationReport(context.Background(), fpPk, startHeight, endHeight)
+	if err != nil {
+		return err
+	}
+	printRespJSON(report)
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package daemon
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+// SignedSchnorrMessage is the output of the sign-schnorr-message command
+type SignedSchnorrMessage struct {
+	// MsgHash is the signed hash of the message prefixed with the domain
+	// separation prefix
+	MsgHash string `json:"msg_hash"`
+	// Signature is the BIP-340 Schnorr signature over MsgHash
+	Signature string `json:"signature"`
+}
+
+// CommandSignSchnorrMessage returns the sign-schnorr-message command by connecting to the fpd daemon.
+func CommandSignSchnorrMessage() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "sign-schnorr-message [eots-pk] [message]",
+		Short: "Sign an arbitrary message with the EOTS key of a finality provider",
+		Long: `Sign an arbitrary message with the EOTS key of a finality provider managed by the daemon, to prove
+its identity off-chain. The SHA-256 hash of the message prefixed with "Babylon Finality Provider Signed
+Message:\n" is signed with BIP-340 Schnorr. The messages of 32 or 40 bytes, shaped like the payloads
+signed on-chain, and their hex encodings are refused, and each request is logged by the daemon.`,
+		Example: fmt.Sprintf(`fpd sign-schnorr-message [eots-pk] "I am the operator of this finality provider" --daemon-address %s`,
+			defaultFpdDaemonAddress),
+		Args: cobra.ExactArgs(2),
+		RunE: runCommandSignSchnorrMessage,
+	}
+	f := cmd.Flags()
+	f.String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	f.Bool(hexFlag, false, "Whether the message is hex-encoded")
+	addPassphraseFlags(f, "The pass phrase used to unlock the EOTS key")
+
+	return cmd
+}
+
+func runCommandSignSchnorrMessage(cmd *cobra.Command, args []string) error {
+	flags := cmd.Flags()
+	daemonAddress, err := flags.GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+	isHex, err := flags.GetBool(hexFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", hexFlag, err)
+	}
+
+	msg := []byte(args[1])
+	if isHex {
+		if msg, err = hex.DecodeString(args[1]); err != nil {
+			return fmt.Errorf("invalid hex message: %w", err)
+		}
+	}
+
+	passphrase, err := readPassphrase(cmd, util.PassphraseSource{})
+	if err != nil {
+		return err
+	}
+
+	client, cleanUp, err := newDaemonClient(cmd, daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	res, err := client.SignSchnorrMessage(context.Background(), args[0], msg, passphrase)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(SignedSchnorrMessage{
+		MsgHash:   hex.EncodeToString(res.MsgHash),
+		Signature: hex.EncodeToString(res.Signature),
+	})
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net"
 	"path/filepath"
+	"time"
 
 	"github.com/babylonlabs-io/babylon/types"
 	"github.com/btcsuite/btcwallet/walletdb"
@@ -16,7 +17,7 @@ import (
 	fpcmd "github.com/babylonlabs-io/finality-provider/finality-provider/cmd"
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
-	"github.com/babylonlabs-io/finality-provider/log"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
 	"github.com/babylonlabs-io/finality-provider/util"
 )
 
@@ -30,9 +31,12 @@ func CommandStart() *cobra.Command {
 		Args:    cobra.NoArgs,
 		RunE:    fpcmd.RunEWithClientCtx(runStartCmd),
 	}
-	cmd.Flags().String(fpEotsPkFlag, "", "The EOTS public key of the finality-provider to start")
-	cmd.Flags().String(passphraseFlag, "", "The pass phrase used to decrypt the private key")
+	cmd.Flags().StringSlice(fpEotsPkFlag, nil,
+		"The EOTS public key of the finality-provider to start; can be specified multiple times, "+
+			"in addition to the finality providers in the config")
+	addPassphraseFlags(cmd.Flags(), "The pass phrase used to decrypt the private key")
 	cmd.Flags().String(rpcListenerFlag, "", "The address that the RPC server listens to")
+	cmd.Flags().Bool(dryRunFlag, false, "Sign the transactions but log them instead of broadcasting them")
 	return cmd
 }
 
@@ -44,7 +48,7 @@ func runStartCmd(ctx client.Context, cmd *cobra.Command, _ []string) error {
 	homePath = util.CleanAndExpandPath(homePath)
 	flags := cmd.Flags()
 
-	fpStr, err := flags.GetString(fpEotsPkFlag)
+	fpStrs, err := flags.GetStringSlice(fpEotsPkFlag)
 	if err != nil {
 		return fmt.Errorf("failed to read flag %s: %w", fpEotsPkFlag, err)
 	}
@@ -54,11 +58,6 @@ func runStartCmd(ctx client.Context, cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to read flag %s: %w", rpcListenerFlag, err)
 	}
 
-	passphrase, err := flags.GetString(passphraseFlag)
-	if err != nil {
-		return fmt.Errorf("failed to read flag %s: %w", passphraseFlag, err)
-	}
-
 	cfg, err := fpcfg.LoadConfig(homePath)
 	if err != nil {
 		return fmt.Errorf("failed to load configuration: %w", err)
@@ -72,22 +71,59 @@ func runStartCmd(ctx client.Context, cmd *cobra.Command, _ []string) error {
 		cfg.RPCListener = rpcListener
 	}
 
-	logger, err := log.NewRootLoggerWithFile(fpcfg.LogFile(homePath), cfg.LogLevel)
+	dryRun, err := flags.GetBool(dryRunFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", dryRunFlag, err)
+	}
+	if dryRun {
+		cfg.DryRun = true
+	}
+
+	passphrase, err := readPassphrase(cmd, util.PassphraseSource{
+		File:    cfg.PassphraseFile,
+		Command: cfg.PassphraseCommand,
+	})
+	if err != nil {
+		return err
+	}
+
+	logger, logLevels, err := fpcfg.NewRootLogger(homePath, cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize the logger: %w", err)
 	}
+	// the summaries of the deduplicated entries pending upon shutdown are
+	// logged by the sync
+	defer func() {
+		_ = logger.Sync()
+	}()
 
-	dbBackend, err := cfg.DatabaseConfig.GetDBBackend()
+	var dbBackend walletdb.DB
+	if cfg.DryRun {
+		// the rehearsal does not alter the state of the finality providers
+		dbBackend, err = store.OpenInMemoryCopy(cfg.DatabaseConfig.GetDBBackend)
+	} else {
+		dbBackend, err = cfg.DatabaseConfig.GetDBBackend()
+	}
 	if err != nil {
 		return fmt.Errorf("failed to create db backend: %w", err)
 	}
 
+	if err := store.MigrateDB(dbBackend, cfg.DatabaseConfig.BackupFilePath(time.Now()), logger); err != nil {
+		return fmt.Errorf("failed to migrate db: %w", err)
+	}
+
 	fpApp, err := loadApp(logger, cfg, dbBackend)
 	if err != nil {
 		return fmt.Errorf("failed to load app: %w", err)
 	}
 
-	if err := startApp(fpApp, fpStr, passphrase); err != nil {
+	// the config is reloaded from the config file upon SIGHUP or RPC request,
+	// and the log levels are changed upon reload or RPC request
+	fpApp.EnableConfigReload(func() (*fpcfg.Config, error) {
+		return fpcfg.LoadConfig(homePath)
+	}, logLevels)
+
+	if err := startApp(fpApp, mergeFpPks(cfg.FinalityProviders, fpStrs), passphrase); err != nil {
 		return fmt.Errorf("failed to start app: %w", err)
 	}
 
@@ -115,11 +151,49 @@ func loadApp(
 	return fpApp, nil
 }
 
+// mergeFpPks returns the public keys of the finality providers to start
+// from both the config and the flags, without duplicates
+func mergeFpPks(cfgFpPks, flagFpPks []string) []string {
+	seen := make(map[string]struct{})
+	fpPks := make([]string, 0, len(cfgFpPks)+len(flagFpPks))
+	for _, fpPk := range append(append([]string{}, cfgFpPks...), flagFpPks...) {
+		if _, ok := seen[fpPk]; ok {
+			continue
+		}
+		seen[fpPk] = struct{}{}
+		fpPks = append(fpPks, fpPk)
+	}
+
+	return fpPks
+}
+
 // startApp starts the app and the handle of finality providers if needed based on flags.
 func startApp(
 	fpApp *service.FinalityProviderApp,
-	fpPkStr, passphrase string,
+	fpPkStrs []string,
+	passphrase string,
 ) error {
+	if !fpApp.GetConfig().PreflightConfig.Skip {
+		fpPks := make([]*types.BIP340PubKey, 0, len(fpPkStrs))
+		for _, fpPkStr := range fpPkStrs {
+			fpPk, err := types.NewBIP340PubKeyFromHex(fpPkStr)
+			if err != nil {
+				return fmt.Errorf("invalid finality provider public key %s: %w", fpPkStr, err)
+			}
+			fpPks = append(fpPks, fpPk)
+		}
+
+		report := fpApp.Preflight(fpPks, passphrase)
+		fpApp.LogPreflightReport(report)
+		if err := report.Err(); err != nil {
+			return fmt.Errorf("preflight checks failed, set preflightconfig.skip to bypass them: %w", err)
+		}
+	}
+
+	// the finality providers started later by the status sync share the
+	// passphrase
+	fpApp.SetPassphrase(passphrase)
+
 	// only start the app without starting any finality provider instance
 	// this is needed for new finality provider registration or unjailing
 	// finality providers
@@ -127,24 +201,21 @@ func startApp(
 		return fmt.Errorf("failed to start the finality provider app: %w", err)
 	}
 
-	// no fp instance will be started if public key is not specified
-	if fpPkStr == "" {
-		return nil
-	}
-
-	// start the finality-provider instance with the given public key
-	fpPk, err := types.NewBIP340PubKeyFromHex(fpPkStr)
-	if err != nil {
-		return fmt.Errorf("invalid finality provider public key %s: %w", fpPkStr, err)
-	}
+	// each finality provider runs in its own instance
+	for _, fpPkStr := range fpPkStrs {
+		fpPk, err := types.NewBIP340PubKeyFromHex(fpPkStr)
+		if err != nil {
+			return fmt.Errorf("invalid finality provider public key %s: %w", fpPkStr, err)
+		}
 
-	if err := fpApp.StartHandlingFinalityProvider(fpPk, passphrase); err != nil {
-		if errors.Is(err, service.ErrFinalityProviderJailed) {
-			fpApp.Logger().Error("failed to start finality provider", zap.Error(err))
-			// do not return error as we still want the service to start
-			return nil
+		if err := fpApp.StartHandlingFinalityProvider(fpPk, passphrase); err != nil {
+			if errors.Is(err, service.ErrFinalityProviderJailed) {
+				fpApp.Logger().Error("failed to start finality provider", zap.String("pk", fpPkStr), zap.Error(err))
+				// do not return error as we still want the service to start
+				continue
+			}
+			return fmt.Errorf("failed to start the finality-provider instance %s: %w", fpPkStr, err)
 		}
-		return fmt.Errorf("failed to start the finality-provider instance %s: %w", fpPkStr, err)
 	}
 
 	return nil
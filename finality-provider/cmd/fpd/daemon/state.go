@@ -0,0 +1,154 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+// CommandExportState returns the export-state command by connecting to the fpd daemon.
+func CommandExportState() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "export-state",
+		Short: "Download the encrypted backup of the state of the running fpd daemon.",
+		Long: "Stream the copy of the db of the running fpd daemon, encrypted with the given pass phrase, " +
+			"into the output file, which must not exist. The backup is decrypted with decrypt-state.",
+		Example: fmt.Sprintf(`fpd export-state --output fpd-state.bak --passphrase-file backup.pass --daemon-address %s`,
+			defaultFpdDaemonAddress),
+		Args: cobra.NoArgs,
+		RunE: runCommandExportState,
+	}
+	f := cmd.Flags()
+	f.String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	f.String(outputFileFlag, "", "The file to write the backup to")
+	addPassphraseFlags(f, "The pass phrase encrypting the backup")
+
+	if err := cmd.MarkFlagRequired(outputFileFlag); err != nil {
+		panic(err)
+	}
+
+	return cmd
+}
+
+func runCommandExportState(cmd *cobra.Command, _ []string) error {
+	flags := cmd.Flags()
+	daemonAddress, err := flags.GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+	output, err := flags.GetString(outputFileFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", outputFileFlag, err)
+	}
+	passphrase, err := readPassphrase(cmd, util.PassphraseSource{})
+	if err != nil {
+		return err
+	}
+	if len(passphrase) < store.MinBackupPassphraseLen {
+		return fmt.Errorf("the pass phrase should have at least %d characters", store.MinBackupPassphraseLen)
+	}
+
+	client, cleanUp, err := newDaemonClient(cmd, daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	return writeNewFile(output, func(w io.Writer) error {
+		return client.ExportState(cmd.Context(), passphrase, w)
+	})
+}
+
+// CommandDecryptState returns the decrypt-state command.
+func CommandDecryptState() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "decrypt-state [backup-file]",
+		Short: "Decrypt a backup of the state of fpd.",
+		Long: "Decrypt the backup downloaded by export-state into the output file, which must not exist. " +
+			"With the bbolt backend, the output is the db file to restore as the dbfilename of the stopped " +
+			"daemon, with the other backends, it is the dump of their keys and values.",
+		Example: `fpd decrypt-state fpd-state.bak --output finality-provider.db --passphrase-file backup.pass`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    runCommandDecryptState,
+	}
+	f := cmd.Flags()
+	f.String(outputFileFlag, "", "The file to write the decrypted db to")
+	addPassphraseFlags(f, "The pass phrase encrypting the backup")
+
+	if err := cmd.MarkFlagRequired(outputFileFlag); err != nil {
+		panic(err)
+	}
+
+	return cmd
+}
+
+func runCommandDecryptState(cmd *cobra.Command, args []string) error {
+	output, err := cmd.Flags().GetString(outputFileFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", outputFileFlag, err)
+	}
+	passphrase, err := readPassphrase(cmd, util.PassphraseSource{})
+	if err != nil {
+		return err
+	}
+
+	bundle, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer bundle.Close()
+
+	backend, copyReader, err := store.ReadBackupBundle(bundle, []byte(passphrase))
+	if err != nil {
+		return err
+	}
+
+	if err := writeNewFile(output, func(w io.Writer) error {
+		_, err := io.Copy(w, copyReader)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	if util.IsJSONOutput(cmd) {
+		return util.PrintJSON(cmd.OutOrStdout(), map[string]string{
+			"backend": backend,
+			"output":  output,
+		})
+	}
+	cmd.Printf("Decrypted the db of the %s backend into %s\n", backend, output)
+
+	return nil
+}
+
+// writeNewFile creates the file only readable by the user, which must not
+// exist, and writes it with write, the file is removed if it fails
+func writeNewFile(path string, write func(w io.Writer) error) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+
+	err = write(f)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Join(err, os.Remove(path))
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	dc "github.com/babylonlabs-io/finality-provider/finality-provider/service/client"
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+// daemonStatus is the output of the status command
+type daemonStatus struct {
+	Daemon    *proto.GetInfoResponse `json:"daemon"`
+	Instances []*instanceStatus      `json:"instances"`
+}
+
+// instanceStatus is the status of a running finality provider instance
+type instanceStatus struct {
+	BtcPkHex string                             `json:"btc_pk_hex"`
+	Status   *proto.QueryInstanceStatusResponse `json:"status,omitempty"`
+	// PubRandRunway is the number of heights beyond the tip covered by the
+	// committed public randomness, nil if unknown
+	PubRandRunway *int64 `json:"pub_rand_runway,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+// CommandStatus returns the status command by connecting to the fpd daemon.
+func CommandStatus() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "status",
+		Short: "Show the status of the fpd daemon and of its running finality providers",
+		Long: `Show the status of the running fpd daemon, i.e., the tip of the consumer chain, the reachability of
+eotsd and the balance of the key signing the transactions, along with the status of each of its running
+finality providers, i.e., their status, their last voted and processed heights, the runway of their
+committed public randomness and the end of their jailing.`,
+		Example: fmt.Sprintf(`fpd status --daemon-address %s`, defaultFpdDaemonAddress),
+		Args:    cobra.NoArgs,
+		RunE:    runCommandStatus,
+	}
+	cmd.Flags().String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+
+	return cmd
+}
+
+func runCommandStatus(cmd *cobra.Command, _ []string) error {
+	daemonAddress, err := cmd.Flags().GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+
+	client, cleanUp, err := newDaemonClient(cmd, daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	status, err := queryDaemonStatus(context.Background(), client)
+	if err != nil {
+		return err
+	}
+
+	if util.IsJSONOutput(cmd) {
+		printRespJSON(status)
+		return nil
+	}
+
+	return printDaemonStatus(status)
+}
+
+// queryDaemonStatus aggregates the info of the daemon with the status of
+// each of its running finality providers
+func queryDaemonStatus(ctx context.Context, client *dc.FinalityProviderServiceGRpcClient) (*daemonStatus, error) {
+	info, err := client.GetInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fps, err := client.QueryFinalityProviderList(ctx, nil, "", nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &daemonStatus{Daemon: info, Instances: []*instanceStatus{}}
+	for _, fp := range fps.FinalityProviders {
+		if !fp.IsRunning {
+			continue
+		}
+
+		// the instance might have stopped since it was listed
+		instance := &instanceStatus{BtcPkHex: fp.BtcPkHex}
+		status.Instances = append(status.Instances, instance)
+		res, err := client.QueryInstanceStatus(ctx, fp.BtcPkHex)
+		if err != nil {
+			instance.Error = err.Error()
+			continue
+		}
+		instance.Status = res
+		if res.ChainTip > 0 && res.LastCommittedPubRandHeight > 0 {
+			// #nosec G115 -- the heights are far below the max int64
+			runway := int64(res.LastCommittedPubRandHeight) - int64(res.ChainTip)
+			instance.PubRandRunway = &runway
+		}
+	}
+
+	return status, nil
+}
+
+// printDaemonStatus prints the status of the daemon followed by a row for
+// each of its running finality providers
+func printDaemonStatus(status *daemonStatus) error {
+	info := status.Daemon
+	eotsd := "connected"
+	if !info.EotsdConnected {
+		eotsd = "unreachable"
+	}
+	tip := "unreachable"
+	if info.NodeHeight > 0 {
+		tip = strconv.FormatUint(info.NodeHeight, 10)
+	}
+	balance := info.SignerBalance
+	if balance == "" {
+		balance = "unknown"
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "Version:\t%s\n", info.Version)
+	fmt.Fprintf(w, "Chain:\t%s\n", info.ChainId)
+	fmt.Fprintf(w, "Chain tip:\t%s\n", tip)
+	fmt.Fprintf(w, "EOTS manager:\t%s\n", eotsd)
+	fmt.Fprintf(w, "Signer balance:\t%s\n", balance)
+	fmt.Fprintf(w, "Maintenance:\t%t\n", info.InMaintenance)
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if len(status.Instances) == 0 {
+		fmt.Println("\nNo finality provider is running")
+		return nil
+	}
+
+	fmt.Println()
+	w = tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "FINALITY PROVIDER\tSTATUS\tLAST VOTED\tLAST PROCESSED\tPUB RAND RUNWAY\tJAILED UNTIL")
+	for _, instance := range status.Instances {
+		if instance.Status == nil {
+			fmt.Fprintf(w, "%s\terror: %s\t\t\t\t\n", instance.BtcPkHex, instance.Error)
+			continue
+		}
+
+		res := instance.Status
+		state := res.Status
+		if res.IsPaused {
+			state += " (paused)"
+		}
+		runway := "unknown"
+		if instance.PubRandRunway != nil {
+			runway = strconv.FormatInt(*instance.PubRandRunway, 10)
+		}
+		jailedUntil := "-"
+		if res.Jailed {
+			jailedUntil = "unknown"
+			if res.JailedUntil > 0 {
+				jailedUntil = time.Unix(res.JailedUntil, 0).UTC().Format(time.RFC3339)
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\t%s\n",
+			instance.BtcPkHex, state, res.LastVotedHeight, res.LastProcessedHeight, runway, jailedUntil)
+	}
+
+	return w.Flush()
+}
@@ -4,14 +4,22 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/cosmos/cosmos-sdk/client"
-	"github.com/spf13/cobra"
-
+	"cosmossdk.io/math"
+	bbntypes "github.com/babylonlabs-io/babylon/types"
 	btcstakingcli "github.com/babylonlabs-io/babylon/x/btcstaking/client/cli"
 	btcstakingtypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	sdkflags "github.com/cosmos/cosmos-sdk/client/flags"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	authclient "github.com/cosmos/cosmos-sdk/x/auth/client"
 	authcli "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/spf13/cobra"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/util"
 )
 
 // CommandTxs returns the transaction commands for finality provider related msgs.
@@ -59,55 +67,13 @@ func NewValidateSignedFinalityProviderCmd() *cobra.Command {
 				return err
 			}
 
-			msgsV2, err := stdTx.GetMsgsV2()
-			if err != nil {
+			if err := validateSignedCreateFPTx(ctx, stdTx, args[0]); err != nil {
 				return err
 			}
 
-			msgs := stdTx.GetMsgs()
-			if len(msgs) == 0 {
-				return fmt.Errorf("invalid msg, there is no msg inside %s file", args[0])
-			}
-
-			for i, sdkMsg := range msgs {
-				msgV2 := msgsV2[i]
-				msg, ok := sdkMsg.(*btcstakingtypes.MsgCreateFinalityProvider)
-				if !ok {
-					return fmt.Errorf("unable to parse %+v to MsgCreateFinalityProvider", msg)
-				}
-
-				if err := msg.ValidateBasic(); err != nil {
-					return fmt.Errorf("error validating basic msg: %w", err)
-				}
-
-				signers, err := ctx.Codec.GetMsgV2Signers(msgV2)
-				if err != nil {
-					return fmt.Errorf("failed to get signers from msg %+v: %w", msg, err)
-				}
-
-				if len(signers) == 0 {
-					return fmt.Errorf("no signer at msg %+v", msgV2)
-				}
-
-				signerAddrStr, err := ctx.Codec.InterfaceRegistry().SigningContext().AddressCodec().BytesToString(signers[0])
-				if err != nil {
-					return err
-				}
-
-				signerBbnAddr, err := sdk.AccAddressFromBech32(signerAddrStr)
-				if err != nil {
-					return fmt.Errorf("invalid signer address %s, please sign with a valid bbn address, err: %w", signerAddrStr, err)
-				}
-
-				if !strings.EqualFold(msg.Addr, signerAddrStr) {
-					return fmt.Errorf("signer address: %s is different from finality provider address: %s", signerAddrStr, msg.Addr)
-				}
-
-				if err := msg.Pop.VerifyBIP340(signerBbnAddr, msg.BtcPk); err != nil {
-					return fmt.Errorf("invalid Proof of Possession with signer %s: %w", signerBbnAddr.String(), err)
-				}
+			if util.IsJSONOutput(cmd) {
+				return util.PrintJSON(cmd.OutOrStdout(), map[string]bool{"valid": true})
 			}
-
 			_, err = cmd.OutOrStdout().Write([]byte("The signed MsgCreateFinalityProvider is valid"))
 			return err
 		},
@@ -115,3 +81,175 @@ func NewValidateSignedFinalityProviderCmd() *cobra.Command {
 
 	return cmd
 }
+
+// validateSignedCreateFPTx checks that each message of the given transaction
+// read from the given file is a valid MsgCreateFinalityProvider whose proof
+// of possession is valid against its signer
+func validateSignedCreateFPTx(ctx client.Context, stdTx sdk.Tx, filePath string) error {
+	msgsV2, err := stdTx.GetMsgsV2()
+	if err != nil {
+		return err
+	}
+
+	msgs := stdTx.GetMsgs()
+	if len(msgs) == 0 {
+		return fmt.Errorf("invalid msg, there is no msg inside %s file", filePath)
+	}
+
+	for i, sdkMsg := range msgs {
+		msgV2 := msgsV2[i]
+		msg, ok := sdkMsg.(*btcstakingtypes.MsgCreateFinalityProvider)
+		if !ok {
+			return fmt.Errorf("unable to parse %+v to MsgCreateFinalityProvider", msg)
+		}
+
+		if err := msg.ValidateBasic(); err != nil {
+			return fmt.Errorf("error validating basic msg: %w", err)
+		}
+
+		signers, err := ctx.Codec.GetMsgV2Signers(msgV2)
+		if err != nil {
+			return fmt.Errorf("failed to get signers from msg %+v: %w", msg, err)
+		}
+
+		if len(signers) == 0 {
+			return fmt.Errorf("no signer at msg %+v", msgV2)
+		}
+
+		signerAddrStr, err := ctx.Codec.InterfaceRegistry().SigningContext().AddressCodec().BytesToString(signers[0])
+		if err != nil {
+			return err
+		}
+
+		signerBbnAddr, err := sdk.AccAddressFromBech32(signerAddrStr)
+		if err != nil {
+			return fmt.Errorf("invalid signer address %s, please sign with a valid bbn address, err: %w", signerAddrStr, err)
+		}
+
+		if !strings.EqualFold(msg.Addr, signerAddrStr) {
+			return fmt.Errorf("signer address: %s is different from finality provider address: %s", signerAddrStr, msg.Addr)
+		}
+
+		if err := msg.Pop.VerifyBIP340(signerBbnAddr, msg.BtcPk); err != nil {
+			return fmt.Errorf("invalid Proof of Possession with signer %s: %w", signerBbnAddr.String(), err)
+		}
+	}
+
+	return nil
+}
+
+// printUnsignedCreateFP prints the unsigned transaction registering the
+// created finality provider, paying the fees of the gas limit at the gas
+// prices of the config, for it to be signed with fpd tx sign, e.g., offline
+func printUnsignedCreateFP(
+	ctx client.Context,
+	cmd *cobra.Command,
+	res *proto.CreateFinalityProviderResponse,
+	description *stakingtypes.Description,
+	commission *math.LegacyDec,
+) error {
+	gasLimit, err := cmd.Flags().GetUint64(gasLimitFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", gasLimitFlag, err)
+	}
+
+	cfg, err := fpcfg.LoadConfig(ctx.HomeDir)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	btcPk, err := bbntypes.NewBIP340PubKeyFromHex(res.FinalityProvider.BtcPkHex)
+	if err != nil {
+		return err
+	}
+	pop, err := btcstakingtypes.NewPoPBTCFromHex(res.PopHex)
+	if err != nil {
+		return fmt.Errorf("invalid proof-of-possession: %w", err)
+	}
+
+	msg := &btcstakingtypes.MsgCreateFinalityProvider{
+		Addr:        res.FinalityProvider.FpAddr,
+		Description: description,
+		Commission:  commission,
+		BtcPk:       btcPk,
+		Pop:         pop,
+	}
+	if err := msg.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid registration msg: %w", err)
+	}
+
+	gasPrices, err := sdk.ParseDecCoins(cfg.BabylonConfig.GasPrices)
+	if err != nil {
+		return fmt.Errorf("invalid gas prices %s: %w", cfg.BabylonConfig.GasPrices, err)
+	}
+	// #nosec G115 -- the gas limit is far below the max int64
+	gas := math.LegacyNewDec(int64(gasLimit))
+	fees := make(sdk.Coins, len(gasPrices))
+	for i, gasPrice := range gasPrices {
+		fees[i] = sdk.NewCoin(gasPrice.Denom, gasPrice.Amount.Mul(gas).Ceil().RoundInt())
+	}
+
+	txBuilder := ctx.TxConfig.NewTxBuilder()
+	if err := txBuilder.SetMsgs(msg); err != nil {
+		return err
+	}
+	txBuilder.SetGasLimit(gasLimit)
+	txBuilder.SetFeeAmount(fees.Sort())
+
+	txJSON, err := ctx.TxConfig.TxJSONEncoder()(txBuilder.GetTx())
+	if err != nil {
+		return fmt.Errorf("failed to encode the registration transaction: %w", err)
+	}
+	_, err = fmt.Fprintf(cmd.OutOrStdout(), "%s\n", txJSON)
+
+	return err
+}
+
+// broadcastSignedCreateFP validates the signed registration transaction of
+// the given file and broadcasts it to the node of the config
+func broadcastSignedCreateFP(ctx client.Context, cmd *cobra.Command, filePath string) error {
+	stdTx, err := authclient.ReadTxFromFile(ctx, filePath)
+	if err != nil {
+		return err
+	}
+	if err := validateSignedCreateFPTx(ctx, stdTx, filePath); err != nil {
+		return err
+	}
+
+	sigTx, ok := stdTx.(authsigning.SigVerifiableTx)
+	if !ok {
+		return fmt.Errorf("the transaction of %s cannot be verified", filePath)
+	}
+	sigs, err := sigTx.GetSignaturesV2()
+	if err != nil {
+		return err
+	}
+	if len(sigs) == 0 {
+		return fmt.Errorf("the transaction of %s is not signed", filePath)
+	}
+
+	cfg, err := fpcfg.LoadConfig(ctx.HomeDir)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	node, err := client.NewClientFromNode(cfg.BabylonConfig.RPCAddr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Babylon node %s: %w", cfg.BabylonConfig.RPCAddr, err)
+	}
+
+	txBytes, err := ctx.TxConfig.TxEncoder()(stdTx)
+	if err != nil {
+		return err
+	}
+	res, err := ctx.WithClient(node).WithBroadcastMode(sdkflags.BroadcastSync).BroadcastTx(txBytes)
+	if err != nil {
+		return fmt.Errorf("failed to broadcast the registration transaction: %w", err)
+	}
+	if res.Code != 0 {
+		return fmt.Errorf("the registration transaction %s failed with code %d: %s", res.TxHash, res.Code, res.RawLog)
+	}
+
+	printRespJSON(&proto.RegisterFinalityProviderResponse{TxHash: res.TxHash})
+
+	return nil
+}
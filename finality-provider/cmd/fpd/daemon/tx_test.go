@@ -116,7 +116,7 @@ func rootCmd(outputBuff *bytes.Buffer) *cobra.Command {
 		daemon.CommandInit(), daemon.CommandStart(), daemon.CommandKeys(),
 		daemon.CommandGetDaemonInfo(), daemon.CommandCreateFP(), daemon.CommandLsFP(),
 		daemon.CommandInfoFP(), daemon.CommandRegisterFP(), daemon.CommandAddFinalitySig(),
-		daemon.CommandExportFP(), daemon.CommandTxs(),
+		daemon.CommandExportFP(), daemon.CommandTxs(), daemon.CommandDebug(),
 	)
 
 	return cmd
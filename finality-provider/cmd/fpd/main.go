@@ -11,6 +11,7 @@ import (
 	fpcmd "github.com/babylonlabs-io/finality-provider/finality-provider/cmd"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/cmd/fpd/daemon"
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/util"
 )
 
 // NewRootCmd creates a new root command for fpd. It is called once in the main function.
@@ -23,6 +24,9 @@ func NewRootCmd() *cobra.Command {
 		PersistentPreRunE: fpcmd.PersistClientCtx(client.Context{}),
 	}
 	rootCmd.PersistentFlags().String(flags.FlagHome, fpcfg.DefaultFpdDir, "The application home directory")
+	daemon.AddDaemonClientFlags(rootCmd.PersistentFlags())
+	util.AddOutputFlag(rootCmd)
+	rootCmd.SetFlagErrorFunc(util.SilenceFlagErrors)
 
 	return rootCmd
 }
@@ -35,11 +39,28 @@ func main() {
 		daemon.CommandInfoFP(), daemon.CommandRegisterFP(), daemon.CommandAddFinalitySig(),
 		daemon.CommandExportFP(), daemon.CommandTxs(), daemon.CommandUnjailFP(),
 		daemon.CommandEditFinalityDescription(), daemon.CommandVersion(),
-		daemon.CommandCommitPubRand(),
+		daemon.CommandCommitPubRand(), daemon.CommandExportPop(), daemon.CommandVerifyPop(),
+		daemon.CommandReloadConfig(), daemon.CommandWithdrawRewards(), daemon.CommandQueryRewards(),
+		daemon.CommandUpdateCommission(), daemon.CommandPauseFP(), daemon.CommandResumeFP(),
+		daemon.CommandStopFP(), daemon.CommandRecoverFP(), daemon.CommandEnterMaintenance(),
+		daemon.CommandExitMaintenance(), daemon.CommandPop(), daemon.CommandReport(),
+		daemon.CommandSubscribeEvents(), daemon.CommandHistory(), daemon.CommandCommitPubRandDaemon(),
+		daemon.CommandExportState(), daemon.CommandDecryptState(), daemon.CommandSignSchnorrMessage(),
+		daemon.CommandSetLogLevel(), daemon.CommandDoctor(), daemon.CommandExportPubRandProofs(),
+		daemon.CommandStatus(),
+		daemon.CommandPrune(),
+		daemon.CommandDiff(),
+		daemon.CommandAudit(),
+		daemon.CommandEvents(),
+		daemon.CommandDebug(),
 	)
 
-	if err := cmd.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "Whoops. There was an error while executing your fpd CLI '%s'", err)
+	if c, err := cmd.ExecuteC(); err != nil {
+		if util.IsJSONOutput(c) {
+			util.PrintJSONError(os.Stderr, err)
+		} else {
+			fmt.Fprintf(os.Stderr, "Whoops. There was an error while executing your fpd CLI '%s'", err)
+		}
 		os.Exit(1)
 	}
 }
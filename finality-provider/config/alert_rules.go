@@ -0,0 +1,139 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultAlertRulesEvalInterval   = time.Minute
+	defaultAlertRulesMaxScanHeights = uint64(10000)
+)
+
+// AlertRuleKind is the measure compared by an alert rule
+type AlertRuleKind string
+
+const (
+	// AlertRuleConsecutiveMisses is the number of the last recorded votes
+	// which have failed in a row
+	AlertRuleConsecutiveMisses AlertRuleKind = "consecutive_misses"
+	// AlertRuleParticipation is the share of the recorded votes within the
+	// window which have been submitted
+	AlertRuleParticipation AlertRuleKind = "participation"
+)
+
+// AlertRule is a parsed alert rule, i.e., either
+// consecutive_misses><count> or participation<<percent>%/<window>
+type AlertRule struct {
+	Kind AlertRuleKind
+	// MaxMisses is the number of consecutive misses above which the rule
+	// fires, only set for the consecutive misses
+	MaxMisses uint64
+	// MinParticipation is the percentage of the submitted votes below which
+	// the rule fires, only set for the participation
+	MinParticipation float64
+	// Window is the period over which the participation is measured, only
+	// set for the participation
+	Window time.Duration
+}
+
+func (r *AlertRule) String() string {
+	if r.Kind == AlertRuleParticipation {
+		return fmt.Sprintf("%s<%s%%/%s", r.Kind, strconv.FormatFloat(r.MinParticipation, 'f', -1, 64), r.Window)
+	}
+
+	return fmt.Sprintf("%s>%d", r.Kind, r.MaxMisses)
+}
+
+// ParseAlertRule parses an alert rule such as consecutive_misses>3 or
+// participation<98%/1h
+func ParseAlertRule(s string) (*AlertRule, error) {
+	s = strings.ReplaceAll(s, " ", "")
+
+	if value, ok := strings.CutPrefix(s, string(AlertRuleConsecutiveMisses)+">"); ok {
+		maxMisses, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid alert rule %q: invalid number of misses: %w", s, err)
+		}
+
+		return &AlertRule{Kind: AlertRuleConsecutiveMisses, MaxMisses: maxMisses}, nil
+	}
+
+	if value, ok := strings.CutPrefix(s, string(AlertRuleParticipation)+"<"); ok {
+		percent, window, found := strings.Cut(value, "%/")
+		if !found {
+			return nil, fmt.Errorf("invalid alert rule %q: expected participation<<percent>%%/<window>", s)
+		}
+		minParticipation, err := strconv.ParseFloat(percent, 64)
+		if err != nil || minParticipation <= 0 || minParticipation > 100 {
+			return nil, fmt.Errorf("invalid alert rule %q: the percentage should be within (0, 100]", s)
+		}
+		d, err := time.ParseDuration(window)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid alert rule %q: the window should be a positive duration", s)
+		}
+
+		return &AlertRule{Kind: AlertRuleParticipation, MinParticipation: minParticipation, Window: d}, nil
+	}
+
+	return nil, fmt.Errorf("invalid alert rule %q: expected %s><count> or %s<<percent>%%/<window>",
+		s, AlertRuleConsecutiveMisses, AlertRuleParticipation)
+}
+
+// AlertRulesConfig defines the alert rules evaluated against the submission
+// history of the running finality providers, which alert the notifiers
+// without an external monitoring stack
+type AlertRulesConfig struct {
+	Enabled        bool          `long:"enabled" description:"Evaluate the alert rules against the submission history of the running finality providers"`
+	EvalInterval   time.Duration `long:"evalinterval" description:"The interval between each evaluation of the alert rules"`
+	MaxScanHeights uint64        `long:"maxscanheights" description:"The number of heights below the last processed height whose vote records are evaluated"`
+	Rules          []string      `long:"rule" description:"An alert rule, either consecutive_misses><count> or participation<<percent>%/<window>, e.g., participation<98%/1h; can be specified multiple times"`
+}
+
+func DefaultAlertRulesConfig() AlertRulesConfig {
+	return AlertRulesConfig{
+		EvalInterval:   defaultAlertRulesEvalInterval,
+		MaxScanHeights: defaultAlertRulesMaxScanHeights,
+		Rules:          []string{"consecutive_misses>3", "participation<98%/1h"},
+	}
+}
+
+// ParsedRules returns the parsed alert rules
+func (cfg *AlertRulesConfig) ParsedRules() ([]*AlertRule, error) {
+	rules := make([]*AlertRule, 0, len(cfg.Rules))
+	for _, s := range cfg.Rules {
+		rule, err := ParseAlertRule(s)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+func (cfg *AlertRulesConfig) Validate() error {
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+
+	if cfg.EvalInterval <= 0 {
+		return fmt.Errorf("the alert rules evaluation interval should be positive")
+	}
+
+	if cfg.MaxScanHeights == 0 {
+		return fmt.Errorf("the max scan heights of the alert rules should be positive")
+	}
+
+	if len(cfg.Rules) == 0 {
+		return fmt.Errorf("at least one alert rule should be specified")
+	}
+
+	if _, err := cfg.ParsedRules(); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// ArchiveBackendLocal archives to files under a local directory
+	ArchiveBackendLocal = "local"
+	// ArchiveBackendS3 archives to an S3 compatible object storage
+	ArchiveBackendS3 = "s3"
+	// ArchiveBackendGCS archives to Google Cloud Storage
+	ArchiveBackendGCS = "gcs"
+
+	defaultArchiveInterval      = 1 * time.Hour
+	defaultArchiveRetainHeights = uint64(100000)
+	defaultArchiveObjectPrefix  = "pub-rand-proofs"
+)
+
+// ArchiveConfig defines the archival of the public randomness proofs of
+// long-finalized heights to cold storage
+type ArchiveConfig struct {
+	Backend       string        `long:"backend" description:"The backend to archive public randomness proofs to; empty disables the archival" choice:"" choice:"local" choice:"s3" choice:"gcs"`
+	LocalDir      string        `long:"localdir" description:"The directory to archive to if the backend is local"`
+	Bucket        string        `long:"bucket" description:"The bucket to archive to if the backend is s3 or gcs"`
+	ObjectPrefix  string        `long:"objectprefix" description:"The prefix of the archived object names"`
+	S3Region      string        `long:"s3region" description:"The region of the S3 bucket"`
+	S3Endpoint    string        `long:"s3endpoint" description:"The custom endpoint of an S3 compatible object storage; empty for AWS S3"`
+	Interval      time.Duration `long:"interval" description:"The interval between each archival of public randomness proofs"`
+	RetainHeights uint64        `long:"retainheights" description:"The number of heights below the last finalized height whose proofs are kept in the database"`
+}
+
+func DefaultArchiveConfig() ArchiveConfig {
+	return ArchiveConfig{
+		ObjectPrefix:  defaultArchiveObjectPrefix,
+		Interval:      defaultArchiveInterval,
+		RetainHeights: defaultArchiveRetainHeights,
+	}
+}
+
+// Enabled returns whether the archival is enabled
+func (cfg *ArchiveConfig) Enabled() bool {
+	return cfg != nil && cfg.Backend != ""
+}
+
+func (cfg *ArchiveConfig) Validate() error {
+	if !cfg.Enabled() {
+		return nil
+	}
+
+	switch cfg.Backend {
+	case ArchiveBackendLocal:
+		if cfg.LocalDir == "" {
+			return fmt.Errorf("the local directory should be specified for the local archive backend")
+		}
+	case ArchiveBackendS3, ArchiveBackendGCS:
+		if cfg.Bucket == "" {
+			return fmt.Errorf("the bucket should be specified for the %s archive backend", cfg.Backend)
+		}
+	default:
+		return fmt.Errorf("invalid archive backend: %s", cfg.Backend)
+	}
+
+	if cfg.Interval <= 0 {
+		return fmt.Errorf("the archive interval should be positive")
+	}
+
+	return nil
+}
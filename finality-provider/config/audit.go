@@ -0,0 +1,35 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+const defaultAuditLogFilename = "audit.log"
+
+// AuditConfig defines the audit log of the privileged operations, e.g.,
+// the registrations, the unjails, the key creations, the state exports, the
+// config reloads and the calls to the admin RPC service
+type AuditConfig struct {
+	Enabled bool   `long:"enabled" description:"Record the privileged operations in an append-only hash-chained audit log"`
+	Path    string `long:"path" description:"The path to the audit log"`
+}
+
+func DefaultAuditConfigWithHome(homePath string) AuditConfig {
+	return AuditConfig{
+		Enabled: true,
+		Path:    filepath.Join(LogDir(homePath), defaultAuditLogFilename),
+	}
+}
+
+func (cfg *AuditConfig) Validate() error {
+	if cfg == nil {
+		return nil
+	}
+
+	if cfg.Enabled && cfg.Path == "" {
+		return fmt.Errorf("the path to the audit log should be specified")
+	}
+
+	return nil
+}
@@ -21,6 +21,10 @@ type BBNConfig struct {
 	BlockTimeout   time.Duration `long:"block-timeout" description:"block timeout when waiting for block events"`
 	OutputFormat   string        `long:"output-format" description:"default output when printint responses"`
 	SignModeStr    string        `long:"sign-mode" description:"sign mode to use"`
+	// FeePayerKeys are the names of the keys of the keyring which broadcast
+	// the public randomness commits and the finality signatures in rotation,
+	// the Key is used if empty
+	FeePayerKeys []string `long:"fee-payer-key" description:"name of a key of the keyring broadcasting the public randomness commits and the finality signatures in rotation with the other fee payer keys, can be specified multiple times"`
 }
 
 func DefaultBBNConfig() BBNConfig {
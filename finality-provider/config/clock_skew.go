@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	defaultClockSkewCheckInterval = time.Minute
+	defaultClockSkewWarnThreshold = 30 * time.Second
+	defaultClockSkewMaxSkew       = 2 * time.Minute
+)
+
+// ClockSkewConfig defines the detection of the drift of the local clock
+// relative to the timestamps of the latest blocks of the consumer chain
+type ClockSkewConfig struct {
+	Enabled       bool          `long:"enabled" description:"Whether the local clock is compared to the timestamps of the latest blocks"`
+	CheckInterval time.Duration `long:"checkinterval" description:"The interval between the checks of the clock skew"`
+	WarnThreshold time.Duration `long:"warnthreshold" description:"The clock skew above which a warning is logged, it should allow for the block time of the chain"`
+	MaxSkew       time.Duration `long:"maxskew" description:"The clock skew above which the preflight checks fail and the daemon refuses to start; 0 only warns"`
+}
+
+func DefaultClockSkewConfig() ClockSkewConfig {
+	return ClockSkewConfig{
+		Enabled:       true,
+		CheckInterval: defaultClockSkewCheckInterval,
+		WarnThreshold: defaultClockSkewWarnThreshold,
+		MaxSkew:       defaultClockSkewMaxSkew,
+	}
+}
+
+func (cfg *ClockSkewConfig) Validate() error {
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+
+	if cfg.CheckInterval <= 0 {
+		return fmt.Errorf("the clock skew check interval should be positive")
+	}
+
+	if cfg.WarnThreshold <= 0 {
+		return fmt.Errorf("the clock skew warn threshold should be positive")
+	}
+
+	if cfg.MaxSkew != 0 && cfg.MaxSkew < cfg.WarnThreshold {
+		return fmt.Errorf("the max clock skew %v should not be lower than the warn threshold %v", cfg.MaxSkew, cfg.WarnThreshold)
+	}
+
+	return nil
+}
@@ -3,10 +3,12 @@ package config
 import (
 	"fmt"
 	"net"
+	"os"
 	"path/filepath"
 	"strconv"
 	"time"
 
+	bbntypes "github.com/babylonlabs-io/babylon/types"
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/jessevdk/go-flags"
@@ -35,6 +37,10 @@ const (
 	defaultSyncFpStatusInterval        = 30 * time.Second
 	defaultSignatureSubmissionInterval = 1 * time.Second
 	defaultMaxSubmissionRetries        = 20
+	defaultShutdownGracePeriod         = 10 * time.Second
+	defaultRPCDrainTimeout             = 10 * time.Second
+	defaultRPCUnixSocketPerm           = "0600"
+	defaultCatchUpThreshold            = 1000
 	defaultBitcoinNetwork              = "signet"
 	defaultDataDirname                 = "data"
 )
@@ -59,14 +65,20 @@ type Config struct {
 	NumPubRand                  uint32        `long:"numPubRand" description:"The number of Schnorr public randomness for each commitment"`
 	NumPubRandMax               uint32        `long:"numpubrandmax" description:"The upper bound of the number of Schnorr public randomness for each commitment"`
 	MinRandHeightGap            uint32        `long:"minrandheightgap" description:"The minimum gap between the last committed rand height and the current Babylon block height"`
+	PubRandRunway               string        `long:"pubrandrunway" description:"The remaining committed heights below which the next public randomness is committed, either a number of blocks or a percentage of numPubRand, e.g., 20%; minrandheightgap is used if empty"`
 	MaxSubmissionRetries        uint32        `long:"maxsubmissionretries" description:"The maximum number of retries to submit finality signature or public randomness"`
 	EOTSManagerAddress          string        `long:"eotsmanageraddress" description:"The address of the remote EOTS manager; Empty if the EOTS manager is running locally"`
 	BatchSubmissionSize         uint32        `long:"batchsubmissionsize" description:"The size of a batch in one submission"`
 	StatusUpdateInterval        time.Duration `long:"statusupdateinterval" description:"The interval between each update of finality-provider status"`
 	RandomnessCommitInterval    time.Duration `long:"randomnesscommitinterval" description:"The interval between each attempt to commit public randomness"`
 	SubmissionRetryInterval     time.Duration `long:"submissionretryinterval" description:"The interval between each attempt to submit finality signature or public randomness after a failure"`
-	SyncFpStatusInterval        time.Duration `long:"syncfpstatusinterval" description:"The duration of time that it should sync FP status with the client blockchain"`
+	SyncFpStatusInterval        time.Duration `long:"syncfpstatusinterval" description:"The interval between each reconciliation of the stored finality providers with the chain, which updates the status of the finality providers not running and starts their instances if their status allows it"`
 	SignatureSubmissionInterval time.Duration `long:"signaturesubmissioninterval" description:"The interval between each finality signature(s) submission"`
+	ShutdownGracePeriod         time.Duration `long:"shutdowngraceperiod" description:"The maximum duration to wait for the in-flight operations to complete upon shutdown before they are cancelled"`
+	RPCDrainTimeout             time.Duration `long:"rpcdraintimeout" description:"The maximum duration to wait for the in-flight RPC requests to complete upon shutdown before they are cancelled, the new ones being rejected as unavailable meanwhile; 0 cancels them right away"`
+	CatchUpThreshold            uint64        `long:"catchupthreshold" description:"The minimum number of blocks the finality provider is behind the tip upon start to process the missed blocks in batches of batchsubmissionsize instead of polling them one by one; 0 disables the catch-up"`
+	DryRun                      bool          `long:"dryrun" description:"Sign the transactions, including the finality signatures, but log them instead of broadcasting them"`
+	InactivePubRandCommit       bool          `long:"inactivepubrandcommit" description:"Whether the registered or inactive finality providers keep committing public randomness on schedule so that they can vote as soon as they gain voting power"`
 
 	BitcoinNetwork string `long:"bitcoinnetwork" description:"Bitcoin network to run on" choise:"mainnet" choice:"regtest" choice:"testnet" choice:"simnet" choice:"signet"`
 
@@ -80,7 +92,77 @@ type Config struct {
 
 	RPCListener string `long:"rpclistener" description:"the listener for RPC connections, e.g., 127.0.0.1:1234"`
 
+	AdminRPCListener string `long:"adminrpclistener" description:"the listener for the RPC connections to the admin service, e.g., 127.0.0.1:1235; if empty, the admin service is served on the RPC listener along with the read-only one"`
+
+	RPCUnixSocket     string `long:"rpcunixsocket" description:"The path of a unix domain socket serving the read-only and the admin services in addition to the RPC listener, e.g., for the local CLI with --daemon-address unix:///path/to/fpd.sock; disabled if empty"`
+	RPCUnixSocketPerm string `long:"rpcunixsocketperm" description:"The file permissions of the unix domain socket, in octal"`
+
+	FinalityProviders []string `long:"finalityprovider" description:"The EOTS public key of a finality provider to start along with the daemon; can be specified multiple times to run multiple finality providers in one daemon"`
+
+	PassphraseFile    string `long:"passphrasefile" description:"The file holding the pass phrase of the keys of the finality providers started along with the daemon, unless given through the flags"`
+	PassphraseCommand string `long:"passphrasecommand" description:"The shell command printing the pass phrase of the keys of the finality providers started along with the daemon, e.g., fetching it from a secret manager, unless given through the flags"`
+
 	Metrics *metrics.Config `group:"metrics" namespace:"metrics"`
+
+	ArchiveConfig *ArchiveConfig `group:"archiveconfig" namespace:"archiveconfig"`
+
+	HAConfig *HAConfig `group:"haconfig" namespace:"haconfig"`
+
+	AutoUnjailConfig *AutoUnjailConfig `group:"autounjailconfig" namespace:"autounjailconfig"`
+
+	NotifierConfig *NotifierConfig `group:"notifierconfig" namespace:"notifierconfig"`
+
+	RewardWithdrawalConfig *RewardWithdrawalConfig `group:"rewardwithdrawalconfig" namespace:"rewardwithdrawalconfig"`
+
+	HealthConfig *HealthConfig `group:"healthconfig" namespace:"healthconfig"`
+
+	RestGatewayConfig *RestGatewayConfig `group:"restgatewayconfig" namespace:"restgatewayconfig"`
+
+	RPCAuthConfig *RPCAuthConfig `group:"rpcauthconfig" namespace:"rpcauthconfig"`
+
+	EventBusConfig *EventBusConfig `group:"eventbusconfig" namespace:"eventbusconfig"`
+
+	SupervisorConfig *SupervisorConfig `group:"supervisorconfig" namespace:"supervisorconfig"`
+
+	EquivocationWatcherConfig *EquivocationWatcherConfig `group:"equivocationwatcherconfig" namespace:"equivocationwatcherconfig"`
+
+	SelfCompromiseConfig *SelfCompromiseConfig `group:"selfcompromiseconfig" namespace:"selfcompromiseconfig"`
+
+	PreflightConfig *PreflightConfig `group:"preflightconfig" namespace:"preflightconfig"`
+
+	VoteTimingConfig *VoteTimingConfig `group:"votetimingconfig" namespace:"votetimingconfig"`
+
+	CriticalErrorConfig *CriticalErrorConfig `group:"criticalerrorconfig" namespace:"criticalerrorconfig"`
+
+	VoteRetryConfig *VoteRetryConfig `group:"voteretryconfig" namespace:"voteretryconfig"`
+
+	ClockSkewConfig *ClockSkewConfig `group:"clockskewconfig" namespace:"clockskewconfig"`
+
+	SubmissionLimitConfig *SubmissionLimitConfig `group:"submissionlimitconfig" namespace:"submissionlimitconfig"`
+
+	ParticipationReportConfig *ParticipationReportConfig `group:"participationreportconfig" namespace:"participationreportconfig"`
+
+	DelegationMonitorConfig *DelegationMonitorConfig `group:"delegationmonitorconfig" namespace:"delegationmonitorconfig"`
+
+	FinalityLagConfig *FinalityLagConfig `group:"finalitylagconfig" namespace:"finalitylagconfig"`
+
+	SubmissionHistoryConfig *SubmissionHistoryConfig `group:"submissionhistoryconfig" namespace:"submissionhistoryconfig"`
+
+	RPCInterceptorConfig *RPCInterceptorConfig `group:"rpcinterceptorconfig" namespace:"rpcinterceptorconfig"`
+
+	LogConfig *LogConfig `group:"logconfig" namespace:"logconfig"`
+
+	AuditConfig *AuditConfig `group:"auditconfig" namespace:"auditconfig"`
+
+	NodeHealthConfig *NodeHealthConfig `group:"nodehealthconfig" namespace:"nodehealthconfig"`
+
+	ErrorReporterConfig *ErrorReporterConfig `group:"errorreporterconfig" namespace:"errorreporterconfig"`
+
+	AlertRulesConfig *AlertRulesConfig `group:"alertrulesconfig" namespace:"alertrulesconfig"`
+
+	FeeBalanceConfig *FeeBalanceConfig `group:"feebalanceconfig" namespace:"feebalanceconfig"`
+
+	ParticipationRateConfig *ParticipationRateConfig `group:"participationrateconfig" namespace:"participationrateconfig"`
 }
 
 func DefaultConfigWithHome(homePath string) Config {
@@ -88,6 +170,37 @@ func DefaultConfigWithHome(homePath string) Config {
 	bbnCfg.Key = defaultFinalityProviderKeyName
 	bbnCfg.KeyDirectory = homePath
 	pollerCfg := DefaultChainPollerConfig()
+	archiveCfg := DefaultArchiveConfig()
+	haCfg := DefaultHAConfig()
+	autoUnjailCfg := DefaultAutoUnjailConfig()
+	notifierCfg := DefaultNotifierConfig()
+	rewardWithdrawalCfg := DefaultRewardWithdrawalConfig()
+	healthCfg := DefaultHealthConfig()
+	restGatewayCfg := DefaultRestGatewayConfig()
+	rpcAuthCfg := DefaultRPCAuthConfigWithHome(homePath)
+	eventBusCfg := DefaultEventBusConfig()
+	eventBusCfg.HistoryPath = DefaultEventHistoryPath(homePath)
+	supervisorCfg := DefaultSupervisorConfig()
+	equivocationWatcherCfg := DefaultEquivocationWatcherConfig()
+	selfCompromiseCfg := DefaultSelfCompromiseConfig()
+	preflightCfg := DefaultPreflightConfig()
+	voteTimingCfg := DefaultVoteTimingConfig()
+	criticalErrorCfg := DefaultCriticalErrorConfig()
+	voteRetryCfg := DefaultVoteRetryConfig()
+	clockSkewCfg := DefaultClockSkewConfig()
+	submissionLimitCfg := DefaultSubmissionLimitConfig()
+	participationReportCfg := DefaultParticipationReportConfig()
+	delegationMonitorCfg := DefaultDelegationMonitorConfig()
+	finalityLagCfg := DefaultFinalityLagConfig()
+	submissionHistoryCfg := DefaultSubmissionHistoryConfig()
+	rpcInterceptorCfg := DefaultRPCInterceptorConfig()
+	logCfg := DefaultLogConfig()
+	auditCfg := DefaultAuditConfigWithHome(homePath)
+	nodeHealthCfg := DefaultNodeHealthConfig()
+	errorReporterCfg := DefaultErrorReporterConfig()
+	alertRulesCfg := DefaultAlertRulesConfig()
+	feeBalanceCfg := DefaultFeeBalanceConfig()
+	participationRateCfg := DefaultParticipationRateConfig()
 	cfg := Config{
 		ChainType:                   defaultChainType,
 		LogLevel:                    defaultLogLevel.String(),
@@ -107,8 +220,43 @@ func DefaultConfigWithHome(homePath string) Config {
 		BTCNetParams:                defaultBTCNetParams,
 		EOTSManagerAddress:          defaultEOTSManagerAddress,
 		RPCListener:                 DefaultRPCListener,
+		RPCUnixSocketPerm:           defaultRPCUnixSocketPerm,
 		Metrics:                     metrics.DefaultFpConfig(),
 		SyncFpStatusInterval:        defaultSyncFpStatusInterval,
+		ShutdownGracePeriod:         defaultShutdownGracePeriod,
+		RPCDrainTimeout:             defaultRPCDrainTimeout,
+		CatchUpThreshold:            defaultCatchUpThreshold,
+		InactivePubRandCommit:       true,
+		ArchiveConfig:               &archiveCfg,
+		HAConfig:                    &haCfg,
+		AutoUnjailConfig:            &autoUnjailCfg,
+		NotifierConfig:              &notifierCfg,
+		RewardWithdrawalConfig:      &rewardWithdrawalCfg,
+		HealthConfig:                &healthCfg,
+		RestGatewayConfig:           &restGatewayCfg,
+		RPCAuthConfig:               &rpcAuthCfg,
+		EventBusConfig:              &eventBusCfg,
+		SupervisorConfig:            &supervisorCfg,
+		EquivocationWatcherConfig:   &equivocationWatcherCfg,
+		SelfCompromiseConfig:        &selfCompromiseCfg,
+		PreflightConfig:             &preflightCfg,
+		VoteTimingConfig:            &voteTimingCfg,
+		CriticalErrorConfig:         &criticalErrorCfg,
+		VoteRetryConfig:             &voteRetryCfg,
+		ClockSkewConfig:             &clockSkewCfg,
+		SubmissionLimitConfig:       &submissionLimitCfg,
+		ParticipationReportConfig:   &participationReportCfg,
+		DelegationMonitorConfig:     &delegationMonitorCfg,
+		FinalityLagConfig:           &finalityLagCfg,
+		SubmissionHistoryConfig:     &submissionHistoryCfg,
+		RPCInterceptorConfig:        &rpcInterceptorCfg,
+		LogConfig:                   &logCfg,
+		AuditConfig:                 &auditCfg,
+		NodeHealthConfig:            &nodeHealthCfg,
+		ErrorReporterConfig:         &errorReporterCfg,
+		AlertRulesConfig:            &alertRulesCfg,
+		FeeBalanceConfig:            &feeBalanceCfg,
+		ParticipationRateConfig:     &participationRateCfg,
 	}
 
 	if err := cfg.Validate(); err != nil {
@@ -192,6 +340,35 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("invalid RPC listener address %s, %w", cfg.RPCListener, err)
 	}
 
+	if cfg.AdminRPCListener != "" {
+		if _, err := net.ResolveTCPAddr("tcp", cfg.AdminRPCListener); err != nil {
+			return fmt.Errorf("invalid admin RPC listener address %s, %w", cfg.AdminRPCListener, err)
+		}
+		if cfg.AdminRPCListener == cfg.RPCListener {
+			return fmt.Errorf("the admin RPC listener should differ from the RPC listener")
+		}
+	}
+
+	if _, err := cfg.RPCUnixSocketFileMode(); err != nil {
+		return err
+	}
+
+	if _, err := parsePubRandRunway(cfg.PubRandRunway, cfg.NumPubRand); err != nil {
+		return err
+	}
+
+	if cfg.ShutdownGracePeriod < 0 {
+		return fmt.Errorf("shutdown grace period cannot be negative")
+	}
+
+	if cfg.RPCDrainTimeout < 0 {
+		return fmt.Errorf("the RPC drain timeout cannot be negative")
+	}
+
+	if err := cfg.PollerConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid chain poller config: %w", err)
+	}
+
 	if cfg.Metrics == nil {
 		return fmt.Errorf("empty metrics config")
 	}
@@ -200,6 +377,150 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("invalid metrics config")
 	}
 
+	if err := cfg.ArchiveConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid archive config: %w", err)
+	}
+
+	if err := cfg.HAConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid high availability config: %w", err)
+	}
+
+	if err := cfg.AutoUnjailConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid auto unjail config: %w", err)
+	}
+
+	if err := cfg.NotifierConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid notifier config: %w", err)
+	}
+
+	if err := cfg.RewardWithdrawalConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid reward withdrawal config: %w", err)
+	}
+
+	if err := cfg.HealthConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid health config: %w", err)
+	}
+
+	if err := cfg.RestGatewayConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid REST gateway config: %w", err)
+	}
+
+	if err := cfg.RPCAuthConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid RPC auth config: %w", err)
+	}
+
+	if cfg.RestGatewayConfig != nil && cfg.RestGatewayConfig.Enabled &&
+		cfg.RPCAuthConfig != nil && cfg.RPCAuthConfig.ClientCAPath != "" {
+		return fmt.Errorf("the REST gateway cannot be enabled along with the client certificate authentication")
+	}
+
+	if err := cfg.EventBusConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid event bus config: %w", err)
+	}
+
+	if err := cfg.SupervisorConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid supervisor config: %w", err)
+	}
+
+	if err := cfg.EquivocationWatcherConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid equivocation watcher config: %w", err)
+	}
+
+	if err := cfg.SelfCompromiseConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid self-compromise config: %w", err)
+	}
+
+	if cfg.PassphraseFile != "" && cfg.PassphraseCommand != "" {
+		return fmt.Errorf("the passphrase should be read from either the file or the command")
+	}
+
+	if err := cfg.PreflightConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid preflight config: %w", err)
+	}
+
+	if err := cfg.VoteTimingConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid vote timing config: %w", err)
+	}
+
+	if err := cfg.CriticalErrorConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid critical error config: %w", err)
+	}
+
+	if err := cfg.VoteRetryConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid vote retry config: %w", err)
+	}
+
+	if err := cfg.ClockSkewConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid clock skew config: %w", err)
+	}
+
+	if err := cfg.ParticipationReportConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid participation report config: %w", err)
+	}
+
+	if err := cfg.DelegationMonitorConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid delegation monitor config: %w", err)
+	}
+
+	if err := cfg.FinalityLagConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid finality lag config: %w", err)
+	}
+
+	if err := cfg.RPCInterceptorConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid RPC interceptor config: %w", err)
+	}
+
+	if err := cfg.LogConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid log config: %w", err)
+	}
+
+	if err := cfg.AuditConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid audit config: %w", err)
+	}
+
+	if err := cfg.NodeHealthConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid node health config: %w", err)
+	}
+
+	if err := cfg.ErrorReporterConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid error reporter config: %w", err)
+	}
+
+	if err := cfg.AlertRulesConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid alert rules config: %w", err)
+	}
+
+	if err := cfg.FeeBalanceConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid fee balance config: %w", err)
+	}
+
+	if err := cfg.ParticipationRateConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid participation rate config: %w", err)
+	}
+
+	// the alert rules are evaluated against the submission history
+	if cfg.AlertRulesConfig != nil && cfg.AlertRulesConfig.Enabled &&
+		(cfg.SubmissionHistoryConfig == nil || !cfg.SubmissionHistoryConfig.Enabled) {
+		return fmt.Errorf("the alert rules cannot be enabled without the submission history")
+	}
+
+	// the votes signed by the other daemons are not recorded locally
+	if cfg.SelfCompromiseConfig != nil && cfg.SelfCompromiseConfig.Enabled &&
+		cfg.HAConfig != nil && cfg.HAConfig.Enabled {
+		return fmt.Errorf("the self-compromise detection cannot be enabled along with high availability")
+	}
+
+	seenFps := make(map[string]struct{}, len(cfg.FinalityProviders))
+	for _, fpPkHex := range cfg.FinalityProviders {
+		if _, err := bbntypes.NewBIP340PubKeyFromHex(fpPkHex); err != nil {
+			return fmt.Errorf("invalid finality provider public key %s: %w", fpPkHex, err)
+		}
+		if _, seen := seenFps[fpPkHex]; seen {
+			return fmt.Errorf("duplicate finality provider public key %s", fpPkHex)
+		}
+		seenFps[fpPkHex] = struct{}{}
+	}
+
 	// All good, return the sanitized result.
 	return nil
 }
@@ -221,3 +542,14 @@ func NetParamsBTC(btcNet string) (chaincfg.Params, error) {
 		return chaincfg.Params{}, fmt.Errorf("invalid network: %v", btcNet)
 	}
 }
+
+// RPCUnixSocketFileMode returns the file permissions of the unix domain
+// socket of the RPC server
+func (cfg *Config) RPCUnixSocketFileMode() (os.FileMode, error) {
+	perm, err := strconv.ParseUint(cfg.RPCUnixSocketPerm, 8, 32)
+	if err != nil || perm > 0o777 {
+		return 0, fmt.Errorf("invalid file permissions %q of the RPC unix socket, expected octal permissions such as 0600", cfg.RPCUnixSocketPerm)
+	}
+
+	return os.FileMode(perm), nil
+}
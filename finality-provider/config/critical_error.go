@@ -0,0 +1,73 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	// CriticalErrorPolicyHalt terminates the daemon
+	CriticalErrorPolicyHalt = "halt"
+	// CriticalErrorPolicyRetry restarts the instance according to the
+	// supervisor config
+	CriticalErrorPolicyRetry = "retry"
+	// CriticalErrorPolicyStop stops the instance, the status of the jailed
+	// or slashed finality provider is updated
+	CriticalErrorPolicyStop = "stop"
+	// CriticalErrorPolicyAlert alerts the configured notifiers and keeps
+	// the instance running
+	CriticalErrorPolicyAlert = "alert"
+	// CriticalErrorPolicyRecommit commits public randomness right away and
+	// keeps the instance running
+	CriticalErrorPolicyRecommit = "recommit"
+)
+
+// CriticalErrorConfig defines how the critical errors reported by the
+// finality provider instances are handled, per class of error
+type CriticalErrorConfig struct {
+	Slashed    string `long:"slashed" description:"The policy when the finality provider is slashed" choice:"stop" choice:"halt"`
+	Jailed     string `long:"jailed" description:"The policy when the finality provider is jailed" choice:"stop" choice:"halt"`
+	PubRand    string `long:"pubrand" description:"The policy when the public randomness of a vote is missing or expired" choice:"recommit" choice:"retry" choice:"alert" choice:"halt"`
+	DoubleSign string `long:"doublesign" description:"The policy when signing a block conflicting with a signed one is refused" choice:"halt" choice:"stop" choice:"alert"`
+	Panic      string `long:"panic" description:"The policy when the instance panics" choice:"retry" choice:"halt"`
+	Other      string `long:"other" description:"The policy for the other critical errors, e.g., when the submissions fail after the max retries" choice:"retry" choice:"alert" choice:"halt"`
+}
+
+func DefaultCriticalErrorConfig() CriticalErrorConfig {
+	return CriticalErrorConfig{
+		Slashed:    CriticalErrorPolicyStop,
+		Jailed:     CriticalErrorPolicyStop,
+		PubRand:    CriticalErrorPolicyRecommit,
+		DoubleSign: CriticalErrorPolicyHalt,
+		Panic:      CriticalErrorPolicyRetry,
+		Other:      CriticalErrorPolicyRetry,
+	}
+}
+
+func (cfg *CriticalErrorConfig) Validate() error {
+	if cfg == nil {
+		return nil
+	}
+
+	policies := []struct {
+		class   string
+		policy  string
+		allowed []string
+	}{
+		{"slashed", cfg.Slashed, []string{CriticalErrorPolicyStop, CriticalErrorPolicyHalt}},
+		{"jailed", cfg.Jailed, []string{CriticalErrorPolicyStop, CriticalErrorPolicyHalt}},
+		{"pubrand", cfg.PubRand, []string{CriticalErrorPolicyRecommit, CriticalErrorPolicyRetry, CriticalErrorPolicyAlert, CriticalErrorPolicyHalt}},
+		{"doublesign", cfg.DoubleSign, []string{CriticalErrorPolicyHalt, CriticalErrorPolicyStop, CriticalErrorPolicyAlert}},
+		{"panic", cfg.Panic, []string{CriticalErrorPolicyRetry, CriticalErrorPolicyHalt}},
+		{"other", cfg.Other, []string{CriticalErrorPolicyRetry, CriticalErrorPolicyAlert, CriticalErrorPolicyHalt}},
+	}
+	for _, p := range policies {
+		if !slices.Contains(p.allowed, p.policy) {
+			return fmt.Errorf("invalid %s policy %s, should be one of %s",
+				p.class, p.policy, strings.Join(p.allowed, ", "))
+		}
+	}
+
+	return nil
+}
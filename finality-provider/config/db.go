@@ -1,16 +1,36 @@
 package config
 
 import (
+	"fmt"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/lightningnetwork/lnd/kvdb"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store/pebbledb"
 )
 
 const (
 	defaultDBName = "finality-provider.db"
+	// signRecordDBName is the name of the database of the sign records,
+	// which is kept apart so that restoring or pruning the main database
+	// does not erase them
+	signRecordDBName = "sign-records.db"
+
+	// DBBackendBolt stores the data in a single bbolt file
+	DBBackendBolt = "bbolt"
+	// DBBackendPebble stores the data in a pebble directory
+	DBBackendPebble = "pebble"
+	// DBBackendMemory keeps the data in memory only, which is lost
+	// once fpd stops
+	DBBackendMemory = "memory"
 )
 
 type DBConfig struct {
+	// Backend is the key-value store backing the database.
+	Backend string `long:"backend" description:"The key-value store backing the database. The bolt specific options are ignored by the other backends. The memory backend loses all the data once fpd stops." choice:"bbolt" choice:"pebble" choice:"memory"`
+
 	// DBPath is the directory path in which the database file should be
 	// stored.
 	DBPath string `long:"dbpath" description:"The directory path in which the database file should be stored."`
@@ -46,6 +66,7 @@ func DefaultDBConfig() *DBConfig {
 
 func DefaultDBConfigWithHomePath(homePath string) *DBConfig {
 	return &DBConfig{
+		Backend:           DBBackendBolt,
 		DBPath:            DataDir(homePath),
 		DBFileName:        defaultDBName,
 		NoFreelistSync:    true,
@@ -67,5 +88,70 @@ func (db *DBConfig) DBConfigToBoltBackendConfig() *kvdb.BoltBackendConfig {
 }
 
 func (db *DBConfig) GetDBBackend() (kvdb.Backend, error) {
-	return kvdb.GetBoltBackend(db.DBConfigToBoltBackendConfig())
+	switch db.Backend {
+	case DBBackendBolt, "":
+		return kvdb.GetBoltBackend(db.DBConfigToBoltBackendConfig())
+	case DBBackendPebble:
+		return pebbledb.Open(db.PebbleDir())
+	case DBBackendMemory:
+		return pebbledb.OpenInMemory()
+	default:
+		return nil, fmt.Errorf("unsupported db backend: %s", db.Backend)
+	}
+}
+
+// GetSignRecordDBBackend returns the database of the sign records, which is
+// backed by the same key-value store as the main database, next to it
+func (db *DBConfig) GetSignRecordDBBackend() (kvdb.Backend, error) {
+	signRecordDB := *db
+	signRecordDB.DBFileName = signRecordDBName
+
+	return signRecordDB.GetDBBackend()
+}
+
+// CompactDB compacts the database, which should not be open, so that the
+// space of the deleted data is reclaimed. The bolt file is compacted into a
+// copy which replaces it, requiring as much free disk space
+func (db *DBConfig) CompactDB() error {
+	switch db.Backend {
+	case DBBackendBolt, "":
+		// the bolt file is compacted upon opening
+		cfg := db.DBConfigToBoltBackendConfig()
+		cfg.AutoCompact = true
+		cfg.AutoCompactMinAge = 0
+		backend, err := kvdb.GetBoltBackend(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to compact the bolt db: %w", err)
+		}
+		return backend.Close()
+	case DBBackendPebble:
+		pdb, err := pebbledb.Open(db.PebbleDir())
+		if err != nil {
+			return err
+		}
+		if err := pdb.Compact(); err != nil {
+			_ = pdb.Close()
+			return fmt.Errorf("failed to compact the pebble db: %w", err)
+		}
+		return pdb.Close()
+	case DBBackendMemory:
+		return nil
+	default:
+		return fmt.Errorf("unsupported db backend: %s", db.Backend)
+	}
+}
+
+// PebbleDir returns the directory of the pebble database, named after
+// the database file so that it does not collide with the bolt file
+func (db *DBConfig) PebbleDir() string {
+	name := strings.TrimSuffix(db.DBFileName, filepath.Ext(db.DBFileName))
+
+	return filepath.Join(db.DBPath, name+".pebble")
+}
+
+// BackupFilePath returns the path of the file to back up the database to
+// before migrating it, suffixed by the given time to avoid overwriting
+// previous backups
+func (db *DBConfig) BackupFilePath(t time.Time) string {
+	return filepath.Join(db.DBPath, fmt.Sprintf("%s.backup-%s", db.DBFileName, t.UTC().Format("20060102-150405")))
 }
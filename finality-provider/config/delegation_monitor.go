@@ -0,0 +1,36 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	defaultDelegationMonitorInterval = 5 * time.Minute
+)
+
+// DelegationMonitorConfig defines the periodic query of the BTC delegations
+// to the running finality providers, which are exposed by the metrics and
+// the finality provider info
+type DelegationMonitorConfig struct {
+	Enabled  bool          `long:"enabled" description:"Periodically query the BTC delegations to the running finality providers"`
+	Interval time.Duration `long:"interval" description:"The interval between each query of the delegations"`
+}
+
+func DefaultDelegationMonitorConfig() DelegationMonitorConfig {
+	return DelegationMonitorConfig{
+		Interval: defaultDelegationMonitorInterval,
+	}
+}
+
+func (cfg *DelegationMonitorConfig) Validate() error {
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+
+	if cfg.Interval <= 0 {
+		return fmt.Errorf("the delegation monitor interval should be positive")
+	}
+
+	return nil
+}
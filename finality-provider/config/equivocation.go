@@ -0,0 +1,40 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	defaultEquivocationPollInterval   = 1 * time.Minute
+	defaultEquivocationLookbackBlocks = uint64(10000)
+)
+
+// EquivocationWatcherConfig defines the monitoring of the equivocation
+// evidences recorded on-chain against the finality providers
+type EquivocationWatcherConfig struct {
+	Enabled                bool          `long:"enabled" description:"Periodically scan the on-chain equivocation evidences and alert on the new ones"`
+	PollInterval           time.Duration `long:"pollinterval" description:"The interval between each scan of the equivocation evidences"`
+	LookbackBlocks         uint64        `long:"lookbackblocks" description:"The number of blocks before the tip upon start from which the evidences are scanned"`
+	IgnoreOwnKeys          bool          `long:"ignoreownkeys" description:"Do not report the evidences against the finality providers managed by the daemon"`
+	SubmitSlashingEvidence bool          `long:"submitslashingevidence" description:"Submit the key extracted from an evidence to slash the equivocating finality provider if it is not slashed yet"`
+}
+
+func DefaultEquivocationWatcherConfig() EquivocationWatcherConfig {
+	return EquivocationWatcherConfig{
+		PollInterval:   defaultEquivocationPollInterval,
+		LookbackBlocks: defaultEquivocationLookbackBlocks,
+	}
+}
+
+func (cfg *EquivocationWatcherConfig) Validate() error {
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+
+	if cfg.PollInterval <= 0 {
+		return fmt.Errorf("the equivocation poll interval should be positive")
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+const defaultErrorReporterTimeout = 5 * time.Second
+
+// ErrorReporterConfig defines the reporting of the panics and the critical
+// errors to a Sentry-compatible endpoint, e.g., Sentry or GlitchTip
+type ErrorReporterConfig struct {
+	Enabled     bool          `long:"enabled" description:"Report the panics and the critical errors to a Sentry-compatible endpoint"`
+	DSN         string        `long:"dsn" description:"The DSN of the Sentry-compatible project to which the errors are reported"`
+	Environment string        `long:"environment" description:"The environment attached to the reports, e.g., mainnet or testnet"`
+	Timeout     time.Duration `long:"timeout" description:"The timeout of sending a report"`
+}
+
+func DefaultErrorReporterConfig() ErrorReporterConfig {
+	return ErrorReporterConfig{
+		Enabled: false,
+		Timeout: defaultErrorReporterTimeout,
+	}
+}
+
+func (cfg *ErrorReporterConfig) Validate() error {
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+
+	if cfg.DSN == "" {
+		return fmt.Errorf("the DSN of the error reporter should be specified")
+	}
+
+	if cfg.Timeout <= 0 {
+		return fmt.Errorf("the error reporter timeout should be positive")
+	}
+
+	return nil
+}
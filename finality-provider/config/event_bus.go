@@ -0,0 +1,97 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"time"
+)
+
+const (
+	defaultEventBusBufferSize  = 1000
+	defaultEventBusNatsSubject = "fpd.events"
+	defaultEventBusKafkaTopic  = "fpd-events"
+	defaultEventBusTimeout     = 10 * time.Second
+	defaultEventHistorySize    = 10000
+	defaultEventHistoryDirname = "events"
+	defaultEventHistoryFile    = "history.jsonl"
+)
+
+// EventBusConfig defines the sinks to which the events of the finality
+// providers, e.g., the submitted votes, the randomness commitments and the
+// status changes, are streamed, along with the local history of the recent
+// events. Each sink is enabled by setting its destination
+type EventBusConfig struct {
+	BufferSize        uint32        `long:"buffersize" description:"The number of events buffered for each sink; the events are dropped while the buffer of a sink is full"`
+	HistoryPath       string        `long:"historypath" description:"The path of the bounded on-disk history of the recent events, which is queried by fpd events; empty disables the history"`
+	HistorySize       uint32        `long:"historysize" description:"The max number of events kept in the history, the oldest ones being dropped"`
+	FilePath          string        `long:"filepath" description:"The path of the file to which the events are appended as JSON lines; empty disables the file sink"`
+	NatsURL           string        `long:"natsurl" description:"The URL of the NATS server to which the events are published, e.g., nats://127.0.0.1:4222; empty disables the NATS sink"`
+	NatsSubject       string        `long:"natssubject" description:"The NATS subject on which the events are published"`
+	KafkaRestProxyURL string        `long:"kafkarestproxyurl" description:"The URL of the Kafka REST proxy through which the events are produced, e.g., http://127.0.0.1:8082; empty disables the Kafka sink"`
+	KafkaTopic        string        `long:"kafkatopic" description:"The Kafka topic to which the events are produced"`
+	Timeout           time.Duration `long:"timeout" description:"The timeout of writing an event to a sink"`
+}
+
+func DefaultEventBusConfig() EventBusConfig {
+	return EventBusConfig{
+		BufferSize:  defaultEventBusBufferSize,
+		HistorySize: defaultEventHistorySize,
+		NatsSubject: defaultEventBusNatsSubject,
+		KafkaTopic:  defaultEventBusKafkaTopic,
+		Timeout:     defaultEventBusTimeout,
+	}
+}
+
+// Enabled returns whether at least one sink is configured
+func (cfg *EventBusConfig) Enabled() bool {
+	return cfg != nil && (cfg.HistoryPath != "" || cfg.FilePath != "" || cfg.NatsURL != "" || cfg.KafkaRestProxyURL != "")
+}
+
+// DefaultEventHistoryPath returns the default path of the event history in
+// the data directory of the given home
+func DefaultEventHistoryPath(homePath string) string {
+	return filepath.Join(DataDir(homePath), defaultEventHistoryDirname, defaultEventHistoryFile)
+}
+
+func (cfg *EventBusConfig) Validate() error {
+	if !cfg.Enabled() {
+		return nil
+	}
+
+	if cfg.BufferSize == 0 {
+		return fmt.Errorf("the event buffer size should be positive")
+	}
+
+	if cfg.Timeout <= 0 {
+		return fmt.Errorf("the event sink timeout should be positive")
+	}
+
+	if cfg.HistoryPath != "" && cfg.HistorySize < 2 {
+		return fmt.Errorf("the event history size should be at least 2")
+	}
+
+	if cfg.NatsURL != "" {
+		u, err := url.Parse(cfg.NatsURL)
+		if err != nil {
+			return fmt.Errorf("invalid NATS URL %s: %w", cfg.NatsURL, err)
+		}
+		if u.Scheme != "nats" || u.Host == "" {
+			return fmt.Errorf("invalid NATS URL %s: expected nats://host:port", cfg.NatsURL)
+		}
+		if cfg.NatsSubject == "" {
+			return fmt.Errorf("the NATS subject should be specified")
+		}
+	}
+
+	if cfg.KafkaRestProxyURL != "" {
+		if _, err := url.ParseRequestURI(cfg.KafkaRestProxyURL); err != nil {
+			return fmt.Errorf("invalid Kafka REST proxy URL %s: %w", cfg.KafkaRestProxyURL, err)
+		}
+		if cfg.KafkaTopic == "" {
+			return fmt.Errorf("the Kafka topic should be specified")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	defaultFeeBalancePollInterval = 5 * time.Minute
+	defaultFeeBalanceRunwayWindow = 24 * time.Hour
+	defaultFeeBalanceSpendWindow  = 6 * time.Hour
+)
+
+// FeeBalanceConfig defines the monitoring of the balances of the accounts
+// paying the fees, i.e., the key signing the transactions and the fee
+// payers, which warns before they run out
+type FeeBalanceConfig struct {
+	Enabled      bool          `long:"enabled" description:"Periodically query the balances of the accounts paying the fees"`
+	PollInterval time.Duration `long:"pollinterval" description:"The interval between each query of the balances"`
+	MinBalance   string        `long:"minbalance" description:"The balance of an account paying the fees below which a warning is emitted, e.g., 1000000ubbn; empty disables the threshold"`
+	RunwayWindow time.Duration `long:"runwaywindow" description:"The period of voting whose projected spend the balance of each account should cover, a warning being emitted otherwise; 0 disables the projection"`
+	SpendWindow  time.Duration `long:"spendwindow" description:"The period over which the spend rate of each account is measured for the projection"`
+}
+
+func DefaultFeeBalanceConfig() FeeBalanceConfig {
+	return FeeBalanceConfig{
+		Enabled:      true,
+		PollInterval: defaultFeeBalancePollInterval,
+		RunwayWindow: defaultFeeBalanceRunwayWindow,
+		SpendWindow:  defaultFeeBalanceSpendWindow,
+	}
+}
+
+// MinBalanceCoins returns the coins of the min balance, which has been
+// validated
+func (cfg *FeeBalanceConfig) MinBalanceCoins() sdk.Coins {
+	coins, _ := sdk.ParseCoinsNormalized(cfg.MinBalance)
+
+	return coins
+}
+
+func (cfg *FeeBalanceConfig) Validate() error {
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+
+	if cfg.PollInterval <= 0 {
+		return fmt.Errorf("the fee balance poll interval should be positive")
+	}
+
+	if _, err := sdk.ParseCoinsNormalized(cfg.MinBalance); err != nil {
+		return fmt.Errorf("invalid min fee balance %s: %w", cfg.MinBalance, err)
+	}
+
+	if cfg.RunwayWindow < 0 {
+		return fmt.Errorf("the fee balance runway window should not be negative")
+	}
+
+	if cfg.RunwayWindow > 0 && cfg.SpendWindow < 2*cfg.PollInterval {
+		return fmt.Errorf("the fee spend window should cover at least two poll intervals")
+	}
+
+	return nil
+}
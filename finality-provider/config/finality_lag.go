@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	defaultFinalityLagCheckInterval      = 30 * time.Second
+	defaultFinalityLagMaxVoteLag         = uint64(50)
+	defaultFinalityLagMaxFinalizationLag = uint64(100)
+	defaultFinalityLagSustainedFor       = 5 * time.Minute
+)
+
+// FinalityLagConfig defines the monitoring of the gap between the tip of the
+// consumer chain and both the last voted height of the running finality
+// providers and the last finalized height
+type FinalityLagConfig struct {
+	Enabled            bool          `long:"enabled" description:"Periodically measure the gap between the tip and the last voted and finalized heights"`
+	CheckInterval      time.Duration `long:"checkinterval" description:"The interval between each measure of the gaps"`
+	MaxVoteLag         uint64        `long:"maxvotelag" description:"The number of blocks between the tip and the last voted height of an active finality provider above which it is lagging; 0 disables the alert"`
+	MaxFinalizationLag uint64        `long:"maxfinalizationlag" description:"The number of blocks between the tip and the last finalized height above which the finalization is lagging; 0 disables the alert"`
+	SustainedFor       time.Duration `long:"sustainedfor" description:"How long a gap should stay above its threshold before the notifiers are alerted"`
+}
+
+func DefaultFinalityLagConfig() FinalityLagConfig {
+	return FinalityLagConfig{
+		Enabled:            true,
+		CheckInterval:      defaultFinalityLagCheckInterval,
+		MaxVoteLag:         defaultFinalityLagMaxVoteLag,
+		MaxFinalizationLag: defaultFinalityLagMaxFinalizationLag,
+		SustainedFor:       defaultFinalityLagSustainedFor,
+	}
+}
+
+func (cfg *FinalityLagConfig) Validate() error {
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+
+	if cfg.CheckInterval <= 0 {
+		return fmt.Errorf("the finality lag check interval should be positive")
+	}
+
+	if cfg.SustainedFor < 0 {
+		return fmt.Errorf("the sustained period of the finality lag should not be negative")
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	defaultLeaseTTL = 15 * time.Second
+)
+
+// HAConfig defines the active/standby high availability of the finality
+// providers, in which several daemons run the same finality providers
+// against the same EOTS manager and only the one holding the lease of the
+// EOTS key, i.e., the leader, submits finality signatures
+type HAConfig struct {
+	Enabled  bool          `long:"enabled" description:"Elect a leader among the daemons running the same finality providers; only the leader signs"`
+	HolderID string        `long:"holderid" description:"The identifier of this daemon in the leader election, unique among the daemons; defaults to the hostname"`
+	LeaseTTL time.Duration `long:"leasettl" description:"The duration of the lease of the leader; a standby takes over once the lease of a failed leader expires"`
+}
+
+func DefaultHAConfig() HAConfig {
+	// the hostname is only a default, an empty holder id is rejected
+	// if the high availability is enabled
+	hostname, _ := os.Hostname()
+
+	return HAConfig{
+		HolderID: hostname,
+		LeaseTTL: defaultLeaseTTL,
+	}
+}
+
+func (cfg *HAConfig) Validate() error {
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+
+	if cfg.HolderID == "" {
+		return fmt.Errorf("the holder id should be specified if high availability is enabled")
+	}
+
+	if cfg.LeaseTTL <= 0 {
+		return fmt.Errorf("the lease ttl should be positive, got %v", cfg.LeaseTTL)
+	}
+
+	return nil
+}
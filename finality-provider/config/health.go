@@ -0,0 +1,60 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"time"
+)
+
+const (
+	defaultHealthListenAddr      = "127.0.0.1:2114"
+	defaultHealthCheckInterval   = 10 * time.Second
+	defaultHealthCheckTimeout    = 5 * time.Second
+	defaultHealthMaxBlockLag     = 30
+	defaultHealthMaxHeartbeatAge = 5 * time.Minute
+)
+
+// HealthConfig defines the health checks exposed through the liveness and
+// readiness HTTP endpoints and the gRPC health service
+type HealthConfig struct {
+	Enabled         bool          `long:"enabled" description:"Serve the liveness and readiness endpoints and register the gRPC health service"`
+	ListenAddr      string        `long:"listenaddr" description:"The address on which the liveness and readiness endpoints are served, e.g., 127.0.0.1:2114"`
+	CheckInterval   time.Duration `long:"checkinterval" description:"The interval between each update of the status reported by the gRPC health service"`
+	CheckTimeout    time.Duration `long:"checktimeout" description:"The timeout of each health check"`
+	MaxBlockLag     uint64        `long:"maxblocklag" description:"The maximum number of blocks a running finality provider can be behind the tip to be ready; 0 disables the check"`
+	MaxHeartbeatAge time.Duration `long:"maxheartbeatage" description:"The maximum duration since the last iteration of the signature submission loop of a running finality provider to be alive"`
+}
+
+func DefaultHealthConfig() HealthConfig {
+	return HealthConfig{
+		ListenAddr:      defaultHealthListenAddr,
+		CheckInterval:   defaultHealthCheckInterval,
+		CheckTimeout:    defaultHealthCheckTimeout,
+		MaxBlockLag:     defaultHealthMaxBlockLag,
+		MaxHeartbeatAge: defaultHealthMaxHeartbeatAge,
+	}
+}
+
+func (cfg *HealthConfig) Validate() error {
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+
+	if _, _, err := net.SplitHostPort(cfg.ListenAddr); err != nil {
+		return fmt.Errorf("invalid health listen address %s: %w", cfg.ListenAddr, err)
+	}
+
+	if cfg.CheckInterval <= 0 {
+		return fmt.Errorf("the health check interval should be positive")
+	}
+
+	if cfg.CheckTimeout <= 0 {
+		return fmt.Errorf("the health check timeout should be positive")
+	}
+
+	if cfg.MaxHeartbeatAge <= 0 {
+		return fmt.Errorf("the max heartbeat age should be positive")
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/log"
+)
+
+const (
+	defaultLogFormat     = "console"
+	defaultLogMaxSizeMB  = 100
+	defaultLogMaxAge     = 30 * 24 * time.Hour
+	defaultLogMaxBackups = 10
+
+	defaultLogSamplingTick = time.Second
+	defaultLogDedupWindow  = time.Minute
+)
+
+// LogConfig defines the format of the logs, the rotation of the log file,
+// the levels of the modules set apart from the root log level and the
+// sampling and the deduplication of the entries per level
+type LogConfig struct {
+	Format         string        `long:"format" description:"The format of the logs" choice:"console" choice:"json" choice:"logfmt"`
+	MaxSizeMB      int           `long:"maxsizemb" description:"The size in megabytes above which the log file is rotated; 0 disables the rotation"`
+	MaxAge         time.Duration `long:"maxage" description:"The age above which the rotated log files are deleted; 0 keeps them regardless of their age"`
+	MaxBackups     int           `long:"maxbackups" description:"The number of rotated log files above which the oldest ones are deleted; 0 keeps them all"`
+	ModuleLevels   []string      `long:"modulelevel" description:"The log level of a module as <module>=<level>, e.g., fp.poller=debug, overriding the root log level; can be specified multiple times"`
+	SamplingTick   time.Duration `long:"samplingtick" description:"The interval over which the entries with the same level and message are counted by the sampling"`
+	SamplingLevels []string      `long:"samplinglevel" description:"The sampling of a log level as <level>=<first>:<thereafter>, i.e., the first entries with the same message are logged per tick, then every thereafter-th one; can be specified multiple times"`
+	DedupLevels    []string      `long:"deduplevel" description:"A log level whose identical repeated entries are logged once per dedup window, followed by a summary of the number of repetitions; can be specified multiple times"`
+	DedupWindow    time.Duration `long:"dedupwindow" description:"The window over which the repetitions of an entry of a deduplicated level are counted"`
+}
+
+func DefaultLogConfig() LogConfig {
+	return LogConfig{
+		Format:       defaultLogFormat,
+		MaxSizeMB:    defaultLogMaxSizeMB,
+		MaxAge:       defaultLogMaxAge,
+		MaxBackups:   defaultLogMaxBackups,
+		SamplingTick: defaultLogSamplingTick,
+		// the debug and info entries are sampled, the warn and error ones
+		// are deduplicated
+		SamplingLevels: []string{"debug=100:100", "info=100:100"},
+		DedupLevels:    []string{"warn", "error"},
+		DedupWindow:    defaultLogDedupWindow,
+	}
+}
+
+func (cfg *LogConfig) Validate() error {
+	if cfg == nil {
+		return nil
+	}
+
+	switch cfg.Format {
+	// the format is empty in the config files written before the log config
+	case "", "console", "json", "logfmt":
+	default:
+		return fmt.Errorf("unsupported log format %q", cfg.Format)
+	}
+
+	if cfg.MaxSizeMB < 0 {
+		return fmt.Errorf("the max size of the log file should not be negative")
+	}
+
+	if cfg.MaxAge < 0 {
+		return fmt.Errorf("the max age of the rotated log files should not be negative")
+	}
+
+	if cfg.MaxBackups < 0 {
+		return fmt.Errorf("the max number of rotated log files should not be negative")
+	}
+
+	if _, err := log.ParseModuleLevels(cfg.ModuleLevels); err != nil {
+		return fmt.Errorf("invalid module levels: %w", err)
+	}
+
+	if cfg.SamplingTick < 0 {
+		return fmt.Errorf("the log sampling tick should not be negative")
+	}
+
+	if cfg.DedupWindow < 0 {
+		return fmt.Errorf("the log dedup window should not be negative")
+	}
+
+	if _, err := cfg.Sampling(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// LogFormat returns the format of the logs, console by default
+func (cfg *LogConfig) LogFormat() string {
+	if cfg == nil || cfg.Format == "" {
+		return defaultLogFormat
+	}
+
+	return cfg.Format
+}
+
+// Rotation returns the rotation of the log file, none by default
+func (cfg *LogConfig) Rotation() log.RotationConfig {
+	if cfg == nil {
+		return log.RotationConfig{}
+	}
+
+	return log.RotationConfig{
+		MaxSizeMB:  cfg.MaxSizeMB,
+		MaxAge:     cfg.MaxAge,
+		MaxBackups: cfg.MaxBackups,
+	}
+}
+
+// Sampling returns the sampling and the deduplication of the entries, none
+// by default
+func (cfg *LogConfig) Sampling() (log.SamplingConfig, error) {
+	if cfg == nil {
+		return log.SamplingConfig{}, nil
+	}
+
+	levels, err := log.ParseLevelSamplings(cfg.SamplingLevels)
+	if err != nil {
+		return log.SamplingConfig{}, fmt.Errorf("invalid sampling levels: %w", err)
+	}
+	dedupLevels, err := log.ParseDedupLevels(cfg.DedupLevels, levels)
+	if err != nil {
+		return log.SamplingConfig{}, fmt.Errorf("invalid dedup levels: %w", err)
+	}
+
+	return log.SamplingConfig{
+		Tick:        cfg.SamplingTick,
+		Levels:      levels,
+		DedupLevels: dedupLevels,
+		DedupWindow: cfg.DedupWindow,
+	}, nil
+}
+
+// NewRootLogger creates the logger writing to stdout and to the log file of
+// the home directory according to the config, along with its levels which
+// can be changed at runtime
+func NewRootLogger(homePath string, cfg *Config) (*zap.Logger, *log.Levels, error) {
+	lvl, err := log.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse the log level: %w", err)
+	}
+	levels := log.NewLevels(lvl)
+
+	if cfg.LogConfig != nil {
+		moduleLevels, err := log.ParseModuleLevels(cfg.LogConfig.ModuleLevels)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse the module levels: %w", err)
+		}
+		levels.SetModuleLevels(moduleLevels)
+	}
+
+	sampling, err := cfg.LogConfig.Sampling()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logger, err := log.NewRootLoggerWithRotatingFile(LogFile(homePath), cfg.LogConfig.LogFormat(), cfg.LogConfig.Rotation(), sampling, levels)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return logger, levels, nil
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	defaultNodeHealthCheckInterval = 15 * time.Second
+	defaultNodeHealthTimeout       = 5 * time.Second
+	defaultNodeHealthMaxBlockLag   = uint64(5)
+)
+
+// NodeHealthConfig defines the monitoring of the health of the configured
+// Babylon node, i.e., its latest height relative to a reference node, the
+// latency of its RPC and the disconnections of its websocket
+type NodeHealthConfig struct {
+	Enabled          bool          `long:"enabled" description:"Periodically measure the health of the configured Babylon node"`
+	CheckInterval    time.Duration `long:"checkinterval" description:"The interval between each measure of the health of the node"`
+	Timeout          time.Duration `long:"timeout" description:"The timeout of the status requests to the nodes"`
+	ReferenceRPCAddr string        `long:"referencerpcaddress" description:"The RPC address of a reference Babylon node, e.g., a public one, whose latest height is the tip of the network; empty disables the measure of the lag"`
+	MaxBlockLag      uint64        `long:"maxblocklag" description:"The number of blocks the configured node can be behind the reference node before a warning is logged"`
+	Websocket        bool          `long:"websocket" description:"Keep a websocket connection to the configured node to count its disconnections"`
+}
+
+func DefaultNodeHealthConfig() NodeHealthConfig {
+	return NodeHealthConfig{
+		Enabled:       true,
+		CheckInterval: defaultNodeHealthCheckInterval,
+		Timeout:       defaultNodeHealthTimeout,
+		MaxBlockLag:   defaultNodeHealthMaxBlockLag,
+		Websocket:     true,
+	}
+}
+
+func (cfg *NodeHealthConfig) Validate() error {
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+
+	if cfg.CheckInterval <= 0 {
+		return fmt.Errorf("the node health check interval should be positive")
+	}
+
+	if cfg.Timeout <= 0 {
+		return fmt.Errorf("the node health timeout should be positive")
+	}
+
+	return nil
+}
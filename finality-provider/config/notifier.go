@@ -0,0 +1,69 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	defaultNotifierTimeout              = 10 * time.Second
+	defaultNotifierCooldown             = 10 * time.Minute
+	defaultNotifierMaxPerHour           = 30
+	defaultNotifierMissedVotesThreshold = 10
+	defaultNotifierMinPubRandRunway     = 100
+)
+
+// NotifierConfig defines the notifiers alerted when a finality provider
+// is detected to be jailed or slashed, misses votes, runs out of public
+// randomness or reports a critical error, along with the rate limits of the
+// alerts. Each notifier is enabled by setting its destination
+type NotifierConfig struct {
+	WebhookURL           string        `long:"webhookurl" description:"The URL to which the events are posted as JSON; empty disables the webhook notifier"`
+	SlackWebhookURL      string        `long:"slackwebhookurl" description:"The Slack incoming webhook URL; empty disables the Slack notifier"`
+	PagerDutyRoutingKey  string        `long:"pagerdutyroutingkey" description:"The routing key of the PagerDuty Events API v2 integration; empty disables the PagerDuty notifier"`
+	TelegramBotToken     string        `long:"telegrambottoken" description:"The token of the Telegram bot sending the messages; empty disables the Telegram notifier"`
+	TelegramChatID       string        `long:"telegramchatid" description:"The ID of the Telegram chat to which the messages are sent"`
+	Timeout              time.Duration `long:"timeout" description:"The timeout of sending a notification"`
+	Cooldown             time.Duration `long:"cooldown" description:"The min interval between two notifications of the same event of a finality provider, the ones in between being suppressed; 0 disables the cooldown"`
+	MaxPerHour           uint32        `long:"maxperhour" description:"The max number of notifications sent per hour, the other ones being suppressed; 0 disables the limit"`
+	MissedVotesThreshold uint64        `long:"missedvotesthreshold" description:"The number of consecutive missed votes of a finality provider upon which a notification is sent; 0 disables the notification"`
+	MinPubRandRunway     uint64        `long:"minpubrandrunway" description:"The number of blocks of committed public randomness ahead of the tip below which a notification is sent; 0 disables the notification"`
+}
+
+func DefaultNotifierConfig() NotifierConfig {
+	return NotifierConfig{
+		Timeout:              defaultNotifierTimeout,
+		Cooldown:             defaultNotifierCooldown,
+		MaxPerHour:           defaultNotifierMaxPerHour,
+		MissedVotesThreshold: defaultNotifierMissedVotesThreshold,
+		MinPubRandRunway:     defaultNotifierMinPubRandRunway,
+	}
+}
+
+// Enabled returns whether at least one notifier is configured
+func (cfg *NotifierConfig) Enabled() bool {
+	return cfg != nil && (cfg.WebhookURL != "" ||
+		cfg.SlackWebhookURL != "" ||
+		cfg.PagerDutyRoutingKey != "" ||
+		cfg.TelegramBotToken != "")
+}
+
+func (cfg *NotifierConfig) Validate() error {
+	if !cfg.Enabled() {
+		return nil
+	}
+
+	if cfg.Cooldown < 0 {
+		return fmt.Errorf("the notifier cooldown should not be negative")
+	}
+
+	if cfg.TelegramBotToken != "" && cfg.TelegramChatID == "" {
+		return fmt.Errorf("the chat ID should be specified for the Telegram notifier")
+	}
+
+	if cfg.Timeout <= 0 {
+		return fmt.Errorf("the notifier timeout should be positive")
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	defaultParticipationRateUpdateInterval = 1 * time.Minute
+)
+
+// MaxParticipationRateWindow bounds the rolling windows of the participation
+// rate, as the heights within the longest one are kept in memory
+const MaxParticipationRateWindow = 30 * 24 * time.Hour
+
+var defaultParticipationRateWindows = []string{"1h", "24h", "168h"}
+
+// ParticipationRateConfig defines the rolling participation rate of the
+// running finality providers, i.e., the share of the heights with voting
+// power which they voted
+type ParticipationRateConfig struct {
+	Enabled        bool          `long:"enabled" description:"Track the share of the heights with voting power voted by each running finality provider over rolling windows"`
+	UpdateInterval time.Duration `long:"updateinterval" description:"The interval between each update of the participation rate metrics"`
+	Windows        []string      `long:"window" description:"A rolling window of the participation rate, e.g., 24h; can be specified multiple times"`
+}
+
+func DefaultParticipationRateConfig() ParticipationRateConfig {
+	windows := make([]string, len(defaultParticipationRateWindows))
+	copy(windows, defaultParticipationRateWindows)
+
+	return ParticipationRateConfig{
+		Enabled:        true,
+		UpdateInterval: defaultParticipationRateUpdateInterval,
+		Windows:        windows,
+	}
+}
+
+// ParsedWindows returns the durations of the windows in the order of the
+// config
+func (cfg *ParticipationRateConfig) ParsedWindows() ([]time.Duration, error) {
+	windows := make([]time.Duration, 0, len(cfg.Windows))
+	seen := make(map[time.Duration]struct{}, len(cfg.Windows))
+	for _, w := range cfg.Windows {
+		d, err := time.ParseDuration(w)
+		if err != nil {
+			return nil, fmt.Errorf("invalid participation rate window %s: %w", w, err)
+		}
+		if d <= 0 || d > MaxParticipationRateWindow {
+			return nil, fmt.Errorf("the participation rate window %s should be within (0, %s]", w, MaxParticipationRateWindow)
+		}
+		if _, ok := seen[d]; ok {
+			return nil, fmt.Errorf("duplicate participation rate window %s", w)
+		}
+		seen[d] = struct{}{}
+		windows = append(windows, d)
+	}
+
+	return windows, nil
+}
+
+func (cfg *ParticipationRateConfig) Validate() error {
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+
+	if cfg.UpdateInterval <= 0 {
+		return fmt.Errorf("the participation rate update interval should be positive")
+	}
+
+	if len(cfg.Windows) == 0 {
+		return fmt.Errorf("at least one participation rate window should be specified")
+	}
+
+	if _, err := cfg.ParsedWindows(); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	defaultParticipationReportInterval          = 1 * time.Hour
+	defaultParticipationReportWindowBlocks      = uint64(1000)
+	defaultParticipationReportConfirmationDepth = uint64(10)
+)
+
+// MaxParticipationReportWindowBlocks bounds the number of heights whose votes
+// are queried for a report
+const MaxParticipationReportWindowBlocks = uint64(100000)
+
+// ParticipationReportConfig defines the periodic comparison of the on-chain
+// votes of the running finality providers with the local records, which
+// reports the missed votes with their probable reasons
+type ParticipationReportConfig struct {
+	Enabled           bool          `long:"enabled" description:"Periodically report the missed votes of the running finality providers"`
+	Interval          time.Duration `long:"interval" description:"The interval between each report"`
+	WindowBlocks      uint64        `long:"windowblocks" description:"The number of heights covered by each report, also the default of the report participation command"`
+	ConfirmationDepth uint64        `long:"confirmationdepth" description:"The number of blocks below the tip which are not reported yet, as their votes might still be included"`
+}
+
+func DefaultParticipationReportConfig() ParticipationReportConfig {
+	return ParticipationReportConfig{
+		Interval:          defaultParticipationReportInterval,
+		WindowBlocks:      defaultParticipationReportWindowBlocks,
+		ConfirmationDepth: defaultParticipationReportConfirmationDepth,
+	}
+}
+
+func (cfg *ParticipationReportConfig) Validate() error {
+	if cfg == nil {
+		return nil
+	}
+
+	if cfg.WindowBlocks == 0 || cfg.WindowBlocks > MaxParticipationReportWindowBlocks {
+		return fmt.Errorf("the window of the participation report should be within [1, %d] blocks", MaxParticipationReportWindowBlocks)
+	}
+
+	if cfg.Enabled && cfg.Interval <= 0 {
+		return fmt.Errorf("the participation report interval should be positive")
+	}
+
+	return nil
+}
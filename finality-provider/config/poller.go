@@ -1,25 +1,56 @@
 package config
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 var (
 	defaultBufferSize        = uint32(1000)
+	defaultPrefetchSize      = uint32(10)
 	defaultPollingInterval   = 1 * time.Second
 	defaultStaticStartHeight = uint64(1)
 )
 
 type ChainPollerConfig struct {
-	BufferSize                     uint32        `long:"buffersize" description:"The maximum number of Babylon blocks that can be stored in the buffer"`
+	BufferSize                     uint32        `long:"buffersize" description:"The maximum number of Babylon blocks prefetched ahead of the finality provider; the poller stops polling while the buffer is full"`
+	PrefetchSize                   uint32        `long:"prefetchsize" description:"The maximum number of Babylon blocks retrieved in a single polling cycle while catching up"`
 	PollInterval                   time.Duration `long:"pollinterval" description:"The interval between each polling of blocks; the value should be set depending on the block production time but could be set smaller for quick catching up"`
-	StaticChainScanningStartHeight uint64        `long:"staticchainscanningstartheight" description:"The static height from which we start polling the chain"`
-	AutoChainScanningMode          bool          `long:"autochainscanningmode" description:"Automatically discover the height from which to start polling the chain"`
+	StaticChainScanningStartHeight uint64        `long:"staticchainscanningstartheight" description:"The static height from which we start polling the chain; ignored once the finality provider has processed blocks, after which it resumes from the last processed height"`
+	AutoChainScanningMode          bool          `long:"autochainscanningmode" description:"Automatically discover the height from which to start polling the chain; ignored once the finality provider has processed blocks, after which it resumes from the last processed height"`
 }
 
 func DefaultChainPollerConfig() ChainPollerConfig {
 	return ChainPollerConfig{
 		BufferSize:                     defaultBufferSize,
+		PrefetchSize:                   defaultPrefetchSize,
 		PollInterval:                   defaultPollingInterval,
 		StaticChainScanningStartHeight: defaultStaticStartHeight,
 		AutoChainScanningMode:          true,
 	}
 }
+
+func (cfg *ChainPollerConfig) Validate() error {
+	if cfg == nil {
+		return nil
+	}
+
+	if cfg.BufferSize == 0 {
+		return fmt.Errorf("the buffer size of the poller should be positive")
+	}
+
+	if cfg.PrefetchSize == 0 {
+		return fmt.Errorf("the prefetch size of the poller should be positive")
+	}
+
+	if cfg.PrefetchSize > cfg.BufferSize {
+		return fmt.Errorf("the prefetch size %d of the poller should not be larger than the buffer size %d",
+			cfg.PrefetchSize, cfg.BufferSize)
+	}
+
+	if cfg.PollInterval <= 0 {
+		return fmt.Errorf("the poll interval of the poller should be positive")
+	}
+
+	return nil
+}
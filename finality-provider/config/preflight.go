@@ -0,0 +1,47 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const defaultPreflightCheckTimeout = 10 * time.Second
+
+// PreflightConfig defines the checks run upon start before the finality
+// provider instances are started
+type PreflightConfig struct {
+	Skip         bool          `long:"skip" description:"Skip the preflight checks upon start"`
+	MinBalance   string        `long:"minbalance" description:"The minimum balance of the key signing the transactions, e.g., 1000000ubbn; empty only checks that the key exists"`
+	CheckTimeout time.Duration `long:"checktimeout" description:"The timeout of each preflight check"`
+}
+
+func DefaultPreflightConfig() PreflightConfig {
+	return PreflightConfig{
+		CheckTimeout: defaultPreflightCheckTimeout,
+	}
+}
+
+// MinBalanceCoins returns the coins of the minimum balance, which has been
+// validated
+func (cfg *PreflightConfig) MinBalanceCoins() sdk.Coins {
+	coins, _ := sdk.ParseCoinsNormalized(cfg.MinBalance)
+	return coins
+}
+
+func (cfg *PreflightConfig) Validate() error {
+	if cfg == nil || cfg.Skip {
+		return nil
+	}
+
+	if cfg.CheckTimeout <= 0 {
+		return fmt.Errorf("the preflight check timeout should be positive")
+	}
+
+	if _, err := sdk.ParseCoinsNormalized(cfg.MinBalance); err != nil {
+		return fmt.Errorf("invalid min balance %s: %w", cfg.MinBalance, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PubRandRunwayBlocks returns the number of remaining committed heights
+// below which the next public randomness is committed. The runway is either
+// a number of blocks or a percentage of NumPubRand, e.g., 20%, and defaults
+// to MinRandHeightGap if not specified
+func (cfg *Config) PubRandRunwayBlocks() uint64 {
+	// the runway has been validated
+	runway, _ := parsePubRandRunway(cfg.PubRandRunway, cfg.NumPubRand)
+	if runway == 0 {
+		return uint64(cfg.MinRandHeightGap)
+	}
+
+	return runway
+}
+
+func parsePubRandRunway(runway string, numPubRand uint32) (uint64, error) {
+	runway = strings.TrimSpace(runway)
+	if runway == "" {
+		return 0, nil
+	}
+
+	if percentage, ok := strings.CutSuffix(runway, "%"); ok {
+		p, err := strconv.ParseUint(strings.TrimSpace(percentage), 10, 64)
+		if err != nil || p == 0 || p > 100 {
+			return 0, fmt.Errorf("invalid public randomness runway %s, the percentage should be within (0, 100]", runway)
+		}
+
+		return max(uint64(numPubRand)*p/100, 1), nil
+	}
+
+	blocks, err := strconv.ParseUint(runway, 10, 64)
+	if err != nil || blocks == 0 {
+		return 0, fmt.Errorf("invalid public randomness runway %s, the number of blocks should be positive", runway)
+	}
+
+	return blocks, nil
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"fmt"
+	"net"
+)
+
+const (
+	defaultRestGatewayListenAddr = "127.0.0.1:12582"
+)
+
+// RestGatewayConfig defines the REST gateway, which serves the read-only
+// RPCs of the daemon as JSON over plain HTTP
+type RestGatewayConfig struct {
+	Enabled    bool   `long:"enabled" description:"Serve the read-only RPCs and their OpenAPI spec over HTTP"`
+	ListenAddr string `long:"listenaddr" description:"The address on which the REST gateway is served, e.g., 127.0.0.1:12582"`
+	// CORSAllowedOrigins lets the dashboards served from other origins
+	// query the gateway and subscribe to the events from browsers
+	CORSAllowedOrigins []string `long:"corsallowedorigin" description:"An origin allowed to make cross-origin requests to the REST gateway, e.g., https://dashboard.example.com, or * for any origin; can be specified multiple times"`
+}
+
+func DefaultRestGatewayConfig() RestGatewayConfig {
+	return RestGatewayConfig{
+		ListenAddr: defaultRestGatewayListenAddr,
+	}
+}
+
+func (cfg *RestGatewayConfig) Validate() error {
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+
+	if _, _, err := net.SplitHostPort(cfg.ListenAddr); err != nil {
+		return fmt.Errorf("invalid REST gateway listen address %s: %w", cfg.ListenAddr, err)
+	}
+
+	for _, origin := range cfg.CORSAllowedOrigins {
+		if origin == "" {
+			return fmt.Errorf("the CORS allowed origin cannot be empty")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+)
+
+const defaultRewardWithdrawalInterval = 24 * time.Hour
+
+// RewardWithdrawalConfig defines the periodic withdrawal of the finality
+// provider rewards accumulated by the key signing the transactions
+type RewardWithdrawalConfig struct {
+	Enabled   bool          `long:"enabled" description:"Periodically withdraw the accumulated finality provider rewards"`
+	Interval  time.Duration `long:"interval" description:"The interval between each check of the withdrawable rewards"`
+	Recipient string        `long:"recipient" description:"The bech32 address to which the withdrawn rewards are sent; empty keeps them in the address of the key signing the transactions"`
+	Threshold string        `long:"threshold" description:"The minimum withdrawable rewards to trigger a withdrawal, e.g., 1000000ubbn; empty withdraws any non-zero rewards"`
+}
+
+func DefaultRewardWithdrawalConfig() RewardWithdrawalConfig {
+	return RewardWithdrawalConfig{
+		Interval: defaultRewardWithdrawalInterval,
+	}
+}
+
+// ThresholdCoins returns the coins of the threshold, which has been validated
+func (cfg *RewardWithdrawalConfig) ThresholdCoins() sdk.Coins {
+	coins, _ := sdk.ParseCoinsNormalized(cfg.Threshold)
+	return coins
+}
+
+func (cfg *RewardWithdrawalConfig) Validate() error {
+	if cfg == nil {
+		return nil
+	}
+
+	// the recipient can be used by the withdraw-rewards command even if
+	// the periodic withdrawal is disabled
+	if cfg.Recipient != "" {
+		if _, _, err := bech32.DecodeAndConvert(cfg.Recipient); err != nil {
+			return fmt.Errorf("invalid recipient address %s: %w", cfg.Recipient, err)
+		}
+	}
+
+	if !cfg.Enabled {
+		return nil
+	}
+
+	if cfg.Interval <= 0 {
+		return fmt.Errorf("the reward withdrawal interval should be positive")
+	}
+
+	if _, err := sdk.ParseCoinsNormalized(cfg.Threshold); err != nil {
+		return fmt.Errorf("invalid threshold %s: %w", cfg.Threshold, err)
+	}
+
+	return nil
+}
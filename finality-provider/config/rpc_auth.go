@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+const (
+	defaultTLSCertFileName           = "tls.cert"
+	defaultTLSKeyFileName            = "tls.key"
+	defaultAuthTokenFileName         = "rpc.token"
+	defaultReadOnlyAuthTokenFileName = "readonly.token"
+)
+
+// RPCAuthConfig defines the transport security and the authentication of
+// the RPC listener
+type RPCAuthConfig struct {
+	TLSEnabled        bool     `long:"tlsenabled" description:"Serve the RPC listener over TLS"`
+	TLSCertPath       string   `long:"tlscertpath" description:"The path to the TLS certificate of the RPC listener; a self-signed certificate is generated along with its key if neither exists"`
+	TLSKeyPath        string   `long:"tlskeypath" description:"The path to the TLS key of the RPC listener"`
+	TLSExtraDomains   []string `long:"tlsextradomain" description:"An extra domain of the generated TLS certificate, which is always valid for localhost; can be specified multiple times"`
+	TLSExtraIPs       []string `long:"tlsextraip" description:"An extra IP address of the generated TLS certificate, which is always valid for the loopback addresses; can be specified multiple times"`
+	ClientCAPath      string   `long:"clientcapath" description:"The path to the CA certificate of the clients; if set, the clients should present a TLS certificate signed by it"`
+	TokenAuthEnabled  bool     `long:"tokenauthenabled" description:"Require the clients to present the auth token in the authorization metadata of each request"`
+	TokenPath         string   `long:"tokenpath" description:"The path to the file holding the auth token, which grants access to all the services; a random token is generated if the file does not exist"`
+	ReadOnlyTokenPath string   `long:"readonlytokenpath" description:"The path to the file holding the read-only auth token, which grants access to the read-only service only; a random token is generated if the file does not exist"`
+	ScopedTokensPath  string   `long:"scopedtokenspath" description:"The path to the JSON file of the auth tokens restricted to the given finality providers, e.g., of the customers of a hosting provider; empty disables the scoped tokens"`
+}
+
+func DefaultRPCAuthConfigWithHome(homePath string) RPCAuthConfig {
+	return RPCAuthConfig{
+		TLSCertPath:       filepath.Join(homePath, defaultTLSCertFileName),
+		TLSKeyPath:        filepath.Join(homePath, defaultTLSKeyFileName),
+		TokenPath:         filepath.Join(homePath, defaultAuthTokenFileName),
+		ReadOnlyTokenPath: filepath.Join(homePath, defaultReadOnlyAuthTokenFileName),
+	}
+}
+
+func (cfg *RPCAuthConfig) Validate() error {
+	if cfg == nil {
+		return nil
+	}
+
+	if cfg.TLSEnabled && (cfg.TLSCertPath == "" || cfg.TLSKeyPath == "") {
+		return fmt.Errorf("the TLS certificate and key paths should be specified")
+	}
+
+	if cfg.ClientCAPath != "" && !cfg.TLSEnabled {
+		return fmt.Errorf("the client certificate authentication requires TLS to be enabled")
+	}
+
+	if cfg.TokenAuthEnabled && (cfg.TokenPath == "" || cfg.ReadOnlyTokenPath == "") {
+		return fmt.Errorf("the auth token paths should be specified")
+	}
+
+	if cfg.ScopedTokensPath != "" && !cfg.TokenAuthEnabled {
+		return fmt.Errorf("the scoped auth tokens require the token authentication to be enabled")
+	}
+
+	if cfg.TokenAuthEnabled && cfg.TokenPath == cfg.ReadOnlyTokenPath {
+		return fmt.Errorf("the auth token and the read-only auth token should be stored in different files")
+	}
+
+	return nil
+}
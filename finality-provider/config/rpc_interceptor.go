@@ -0,0 +1,40 @@
+package config
+
+import (
+	"fmt"
+)
+
+const (
+	defaultRPCRateLimitBurst = 20
+)
+
+// RPCInterceptorConfig defines the interceptors of the RPC requests, which
+// always record the per-method metrics and recover from the panics of the
+// handlers
+type RPCInterceptorConfig struct {
+	LogRequests    bool    `long:"logrequests" description:"Log each RPC request along with its method, status code, duration and correlation id"`
+	RateLimit      float64 `long:"ratelimit" description:"The maximum number of RPC requests per second across all the clients, the requests above which are rejected; 0 disables the rate limit"`
+	RateLimitBurst int     `long:"ratelimitburst" description:"The maximum number of RPC requests allowed at once above the rate limit"`
+}
+
+func DefaultRPCInterceptorConfig() RPCInterceptorConfig {
+	return RPCInterceptorConfig{
+		RateLimitBurst: defaultRPCRateLimitBurst,
+	}
+}
+
+func (cfg *RPCInterceptorConfig) Validate() error {
+	if cfg == nil {
+		return nil
+	}
+
+	if cfg.RateLimit < 0 {
+		return fmt.Errorf("the RPC rate limit should not be negative")
+	}
+
+	if cfg.RateLimit > 0 && cfg.RateLimitBurst < 1 {
+		return fmt.Errorf("the RPC rate limit burst should be positive")
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	defaultSelfCompromiseCheckInterval     = 1 * time.Minute
+	defaultSelfCompromiseConfirmationDepth = uint64(10)
+)
+
+// SelfCompromiseConfig defines the detection of the finality votes attributed
+// on-chain to the managed finality providers which have not been signed by
+// the daemon, which implies that their EOTS keys are used elsewhere
+type SelfCompromiseConfig struct {
+	Enabled           bool          `long:"enabled" description:"Periodically check that the on-chain votes of the running finality providers have been signed by this daemon"`
+	CheckInterval     time.Duration `long:"checkinterval" description:"The interval between each check of the on-chain votes"`
+	ConfirmationDepth uint64        `long:"confirmationdepth" description:"The number of blocks below the tip which are not checked yet, as their votes might still be included"`
+	HaltSigning       bool          `long:"haltsigning" description:"Pause the voting of a finality provider once an unknown vote is found, until it is resumed by the operator"`
+}
+
+func DefaultSelfCompromiseConfig() SelfCompromiseConfig {
+	return SelfCompromiseConfig{
+		CheckInterval:     defaultSelfCompromiseCheckInterval,
+		ConfirmationDepth: defaultSelfCompromiseConfirmationDepth,
+	}
+}
+
+func (cfg *SelfCompromiseConfig) Validate() error {
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+
+	if cfg.CheckInterval <= 0 {
+		return fmt.Errorf("the self-compromise check interval should be positive")
+	}
+
+	return nil
+}
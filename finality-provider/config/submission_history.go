@@ -0,0 +1,19 @@
+package config
+
+const (
+	defaultSubmissionHistoryRetainVoteHeights = uint64(1000000)
+)
+
+// SubmissionHistoryConfig defines the local history of the results of the
+// votes and the public randomness commits of the finality providers
+type SubmissionHistoryConfig struct {
+	Enabled           bool   `long:"enabled" description:"Record the results of the votes and the public randomness commits of the finality providers"`
+	RetainVoteHeights uint64 `long:"retainvoteheights" description:"The number of heights below the last voted height whose vote records are kept; 0 keeps all of them"`
+}
+
+func DefaultSubmissionHistoryConfig() SubmissionHistoryConfig {
+	return SubmissionHistoryConfig{
+		Enabled:           true,
+		RetainVoteHeights: defaultSubmissionHistoryRetainVoteHeights,
+	}
+}
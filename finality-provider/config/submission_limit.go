@@ -0,0 +1,20 @@
+package config
+
+const defaultMaxSubmissionsPerMinute = 60
+
+// SubmissionLimitConfig caps the transactions submitted by each finality
+// provider instance so that a runaway bug does not drain the fee account
+type SubmissionLimitConfig struct {
+	MaxSubmissionsPerMinute uint32 `long:"maxsubmissionsperminute" description:"The maximum number of the transactions, i.e., the votes, the public randomness commits and the unjails, submitted by a finality provider instance within a minute; 0 disables the limit"`
+}
+
+func DefaultSubmissionLimitConfig() SubmissionLimitConfig {
+	return SubmissionLimitConfig{
+		MaxSubmissionsPerMinute: defaultMaxSubmissionsPerMinute,
+	}
+}
+
+// Enabled returns whether the submissions are limited
+func (cfg *SubmissionLimitConfig) Enabled() bool {
+	return cfg != nil && cfg.MaxSubmissionsPerMinute > 0
+}
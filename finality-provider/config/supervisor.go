@@ -0,0 +1,63 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// RestartPolicyNever terminates the daemon when an instance crashes
+	RestartPolicyNever = "never"
+	// RestartPolicyOnFailure restarts the instance when it crashes
+	RestartPolicyOnFailure = "on-failure"
+
+	defaultSupervisorMaxRestarts    = uint32(5)
+	defaultSupervisorRestartWindow  = 1 * time.Hour
+	defaultSupervisorInitialBackoff = 5 * time.Second
+	defaultSupervisorMaxBackoff     = 5 * time.Minute
+)
+
+// SupervisorConfig defines how the finality provider instances which crash
+// due to an unexpected error or a panic are restarted
+type SupervisorConfig struct {
+	RestartPolicy  string        `long:"restartpolicy" description:"Whether a crashed finality provider instance is restarted or the daemon is terminated" choice:"on-failure" choice:"never"`
+	MaxRestarts    uint32        `long:"maxrestarts" description:"The maximum number of restarts of a finality provider instance within the restart window, after which the daemon is terminated"`
+	RestartWindow  time.Duration `long:"restartwindow" description:"The period over which the restarts of a finality provider instance are counted"`
+	InitialBackoff time.Duration `long:"initialbackoff" description:"The duration to wait before the first restart within the restart window, doubled after each restart"`
+	MaxBackoff     time.Duration `long:"maxbackoff" description:"The maximum duration to wait before a restart"`
+}
+
+func DefaultSupervisorConfig() SupervisorConfig {
+	return SupervisorConfig{
+		RestartPolicy:  RestartPolicyOnFailure,
+		MaxRestarts:    defaultSupervisorMaxRestarts,
+		RestartWindow:  defaultSupervisorRestartWindow,
+		InitialBackoff: defaultSupervisorInitialBackoff,
+		MaxBackoff:     defaultSupervisorMaxBackoff,
+	}
+}
+
+func (cfg *SupervisorConfig) Validate() error {
+	if cfg == nil || cfg.RestartPolicy == RestartPolicyNever {
+		return nil
+	}
+
+	if cfg.RestartPolicy != RestartPolicyOnFailure {
+		return fmt.Errorf("invalid restart policy %s, should be one of %s, %s",
+			cfg.RestartPolicy, RestartPolicyOnFailure, RestartPolicyNever)
+	}
+
+	if cfg.MaxRestarts == 0 {
+		return fmt.Errorf("the max restarts should be positive")
+	}
+
+	if cfg.RestartWindow <= 0 {
+		return fmt.Errorf("the restart window should be positive")
+	}
+
+	if cfg.InitialBackoff <= 0 || cfg.MaxBackoff < cfg.InitialBackoff {
+		return fmt.Errorf("the initial backoff should be positive and not larger than the max backoff")
+	}
+
+	return nil
+}
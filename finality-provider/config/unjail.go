@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	defaultAutoUnjailMaxAttempts    = uint32(5)
+	defaultAutoUnjailInitialBackoff = 1 * time.Minute
+	defaultAutoUnjailMaxBackoff     = 1 * time.Hour
+)
+
+// AutoUnjailConfig defines the automatic unjailing of the finality providers
+// jailed due to downtime once their jailing period has elapsed
+type AutoUnjailConfig struct {
+	Enabled        bool          `long:"enabled" description:"Automatically send the unjail transaction once the jailing period of a running finality provider has elapsed"`
+	MaxAttempts    uint32        `long:"maxattempts" description:"The maximum number of attempts to unjail a finality provider before giving up"`
+	InitialBackoff time.Duration `long:"initialbackoff" description:"The duration to wait after the first failed attempt, doubled after each failed attempt"`
+	MaxBackoff     time.Duration `long:"maxbackoff" description:"The maximum duration to wait between two attempts"`
+}
+
+func DefaultAutoUnjailConfig() AutoUnjailConfig {
+	return AutoUnjailConfig{
+		MaxAttempts:    defaultAutoUnjailMaxAttempts,
+		InitialBackoff: defaultAutoUnjailInitialBackoff,
+		MaxBackoff:     defaultAutoUnjailMaxBackoff,
+	}
+}
+
+func (cfg *AutoUnjailConfig) Validate() error {
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+
+	if cfg.MaxAttempts == 0 {
+		return fmt.Errorf("the max attempts should be positive")
+	}
+
+	if cfg.InitialBackoff <= 0 || cfg.MaxBackoff < cfg.InitialBackoff {
+		return fmt.Errorf("the initial backoff should be positive and not larger than the max backoff")
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	defaultVoteRetryInterval     = 30 * time.Second
+	defaultVoteRetryMaxAttempts  = uint32(10)
+	defaultVoteRetryWindowBlocks = uint64(100)
+)
+
+// VoteRetryConfig defines the durable queue of the finality votes whose
+// submission failed after the max retries, which are retried while they
+// are within the voting window
+type VoteRetryConfig struct {
+	Enabled       bool          `long:"enabled" description:"Whether the failed votes are queued in the db and retried"`
+	RetryInterval time.Duration `long:"retryinterval" description:"The interval between the retries of the queued votes"`
+	MaxAttempts   uint32        `long:"maxattempts" description:"The number of retries of a queued vote before it is abandoned"`
+	WindowBlocks  uint64        `long:"windowblocks" description:"The number of blocks below the tip within which a queued vote is retried, it is abandoned otherwise"`
+}
+
+func DefaultVoteRetryConfig() VoteRetryConfig {
+	return VoteRetryConfig{
+		Enabled:       true,
+		RetryInterval: defaultVoteRetryInterval,
+		MaxAttempts:   defaultVoteRetryMaxAttempts,
+		WindowBlocks:  defaultVoteRetryWindowBlocks,
+	}
+}
+
+func (cfg *VoteRetryConfig) Validate() error {
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+
+	if cfg.RetryInterval <= 0 {
+		return fmt.Errorf("the retry interval of the failed votes should be positive")
+	}
+
+	if cfg.MaxAttempts == 0 {
+		return fmt.Errorf("the max attempts of the failed votes should be positive")
+	}
+
+	if cfg.WindowBlocks == 0 {
+		return fmt.Errorf("the window blocks of the failed votes should be positive")
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"fmt"
+)
+
+const (
+	// VoteTimingImmediate votes for each block as soon as it is received
+	VoteTimingImmediate = "immediate"
+	// VoteTimingDelayed votes for a block once the given number of
+	// subsequent blocks are received
+	VoteTimingDelayed = "delayed"
+	// VoteTimingQuorumWindow votes for a block once it is within the given
+	// number of blocks above the last finalized block
+	VoteTimingQuorumWindow = "quorum-window"
+
+	defaultVoteTimingDelayBlocks  = uint64(1)
+	defaultVoteTimingQuorumWindow = uint64(10)
+)
+
+// VoteTimingConfig defines when the finality providers vote for the
+// received blocks, trading the latency of the finalization for a lower
+// exposure to reorgs of the consumer chain
+type VoteTimingConfig struct {
+	Strategy     string `long:"strategy" description:"When a received block is voted" choice:"immediate" choice:"delayed" choice:"quorum-window"`
+	DelayBlocks  uint64 `long:"delayblocks" description:"The number of subsequent blocks to receive before voting for a block with the delayed strategy"`
+	QuorumWindow uint64 `long:"quorumwindow" description:"The number of blocks above the last finalized block which are voted with the quorum-window strategy"`
+}
+
+func DefaultVoteTimingConfig() VoteTimingConfig {
+	return VoteTimingConfig{
+		Strategy:     VoteTimingImmediate,
+		DelayBlocks:  defaultVoteTimingDelayBlocks,
+		QuorumWindow: defaultVoteTimingQuorumWindow,
+	}
+}
+
+func (cfg *VoteTimingConfig) Validate() error {
+	if cfg == nil {
+		return nil
+	}
+
+	switch cfg.Strategy {
+	case VoteTimingImmediate:
+	case VoteTimingDelayed:
+		if cfg.DelayBlocks == 0 {
+			return fmt.Errorf("the delay blocks should be positive with the %s strategy", VoteTimingDelayed)
+		}
+	case VoteTimingQuorumWindow:
+		if cfg.QuorumWindow == 0 {
+			return fmt.Errorf("the quorum window should be positive with the %s strategy", VoteTimingQuorumWindow)
+		}
+	default:
+		return fmt.Errorf("invalid vote timing strategy %s, should be one of %s, %s, %s",
+			cfg.Strategy, VoteTimingImmediate, VoteTimingDelayed, VoteTimingQuorumWindow)
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package errreport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+)
+
+// Kind is the kind of a reported error
+type Kind string
+
+const (
+	// KindPanic is a recovered panic of the daemon
+	KindPanic Kind = "panic"
+	// KindCriticalError is a critical error reported by a finality-provider
+	// instance
+	KindCriticalError Kind = "critical_error"
+)
+
+// Report describes a panic or a critical error along with its context
+type Report struct {
+	Kind Kind `json:"kind"`
+	// Component is the part of the daemon which has failed, e.g., the
+	// finality-provider instance or the RPC server
+	Component string `json:"component"`
+	Message   string `json:"message"`
+	// Class is the class of the critical error, empty for the panics of the
+	// RPC handlers
+	Class   string `json:"class,omitempty"`
+	FpBtcPk string `json:"fp_btc_pk,omitempty"`
+	ChainID string `json:"chain_id"`
+	// Height is the last processed height of the finality provider, zero if
+	// the error is not related to a finality provider
+	Height uint64    `json:"height,omitempty"`
+	Stack  string    `json:"stack,omitempty"`
+	Time   time.Time `json:"time"`
+}
+
+// Title returns a one-line human readable description of the report
+func (r *Report) Title() string {
+	if r.FpBtcPk == "" {
+		return fmt.Sprintf("%s of the %s: %s", r.Kind, r.Component, r.Message)
+	}
+
+	return fmt.Sprintf("%s of the finality provider %s at height %d: %s", r.Kind, r.FpBtcPk, r.Height, r.Message)
+}
+
+// Reporter ships the reports of the errors to an external service
+type Reporter interface {
+	Report(ctx context.Context, report *Report) error
+}
+
+// New creates the reporter configured by the given config, nil if the error
+// reporting is disabled
+func New(cfg *fpcfg.ErrorReporterConfig) (Reporter, error) {
+	if cfg == nil || !cfg.Enabled {
+		return nil, nil
+	}
+
+	client := &http.Client{Timeout: cfg.Timeout}
+
+	return NewSentryReporter(client, cfg.DSN, cfg.Environment)
+}
+
+// MultiReporter ships the reports to each of the reporters, a failing
+// reporter does not prevent the others from receiving the report
+type MultiReporter []Reporter
+
+func (m MultiReporter) Report(ctx context.Context, report *Report) error {
+	var errs []error
+	for _, r := range m {
+		if err := r.Report(ctx, report); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+const redacted = "[REDACTED]"
+
+var (
+	// secretAssignmentRegex matches the secrets given as key-value pairs,
+	// e.g., passphrase=foo or "token": "bar"
+	secretAssignmentRegex = regexp.MustCompile(
+		`(?i)((?:passphrase|password|passwd|secret|token|mnemonic|seed|private[_-]?key|priv[_-]?key|api[_-]?key|authorization)"?\s*[:=]\s*)("[^"]*"|'[^']*'|[^\s,;&]+)`)
+	// bearerRegex matches the bearer tokens of the authorization headers
+	bearerRegex = regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9\-._~+/]+=*`)
+	// urlCredentialsRegex matches the credentials of the URLs, e.g., of a
+	// DSN or of a RPC address
+	urlCredentialsRegex = regexp.MustCompile(`(://)[^/\s:@]+(?::[^/\s@]*)?@`)
+	// privateKeyRegex matches the PEM-encoded private keys
+	privateKeyRegex = regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`)
+)
+
+// ScrubString redacts the secrets found in the given string, i.e., the
+// passphrases, the tokens, the mnemonics, the private keys and the
+// credentials of the URLs
+func ScrubString(s string) string {
+	s = privateKeyRegex.ReplaceAllString(s, redacted)
+	s = bearerRegex.ReplaceAllString(s, "${1}"+redacted)
+	s = secretAssignmentRegex.ReplaceAllString(s, "${1}"+redacted)
+	s = urlCredentialsRegex.ReplaceAllString(s, "${1}"+redacted+"@")
+
+	return s
+}
+
+// Scrub returns a copy of the report whose free-form fields are redacted of
+// the secrets, so that it can be shipped to an external service
+func Scrub(report *Report) *Report {
+	scrubbed := *report
+	scrubbed.Message = ScrubString(report.Message)
+	scrubbed.Stack = ScrubString(report.Stack)
+
+	return &scrubbed
+}
@@ -0,0 +1,168 @@
+package errreport
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/babylonlabs-io/finality-provider/version"
+)
+
+const (
+	sentryClient  = "fpd"
+	sentryVersion = 7
+)
+
+// SentryReporter ships the reports as events to the store endpoint of a
+// Sentry-compatible project, e.g., Sentry or GlitchTip
+type SentryReporter struct {
+	client      *http.Client
+	storeURL    string
+	publicKey   string
+	secretKey   string
+	environment string
+}
+
+// NewSentryReporter creates a reporter for the project of the given DSN, i.e.,
+// {scheme}://{public_key}[:{secret_key}]@{host}[/{path}]/{project_id}
+func NewSentryReporter(client *http.Client, dsn, environment string) (*SentryReporter, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DSN: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid DSN: unsupported scheme %q", u.Scheme)
+	}
+	if u.User == nil || u.User.Username() == "" {
+		return nil, fmt.Errorf("invalid DSN: missing public key")
+	}
+
+	path := strings.TrimSuffix(u.Path, "/")
+	idx := strings.LastIndex(path, "/")
+	if idx < 0 || path[idx+1:] == "" {
+		return nil, fmt.Errorf("invalid DSN: missing project ID")
+	}
+	projectID := path[idx+1:]
+
+	secretKey, _ := u.User.Password()
+	storeURL := url.URL{
+		Scheme: u.Scheme,
+		Host:   u.Host,
+		Path:   fmt.Sprintf("%s/api/%s/store/", path[:idx], projectID),
+	}
+
+	return &SentryReporter{
+		client:      client,
+		storeURL:    storeURL.String(),
+		publicKey:   u.User.Username(),
+		secretKey:   secretKey,
+		environment: environment,
+	}, nil
+}
+
+// sentryEvent is the subset of the Sentry event payload filled by the reporter
+type sentryEvent struct {
+	EventID     string                 `json:"event_id"`
+	Timestamp   string                 `json:"timestamp"`
+	Platform    string                 `json:"platform"`
+	Level       string                 `json:"level"`
+	Logger      string                 `json:"logger"`
+	Release     string                 `json:"release"`
+	Environment string                 `json:"environment,omitempty"`
+	Message     string                 `json:"message"`
+	Tags        map[string]string      `json:"tags"`
+	Extra       map[string]interface{} `json:"extra"`
+}
+
+func (r *SentryReporter) Report(ctx context.Context, report *Report) error {
+	event, err := r.newEvent(report)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.storeURL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sentry-Auth", r.authHeader())
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to report the error to Sentry: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("failed to report the error to Sentry: unexpected status code %d: %s", resp.StatusCode, msg)
+	}
+
+	return nil
+}
+
+func (r *SentryReporter) newEvent(report *Report) (*sentryEvent, error) {
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, fmt.Errorf("failed to generate the event ID: %w", err)
+	}
+
+	level := "error"
+	if report.Kind == KindPanic {
+		level = "fatal"
+	}
+
+	tags := map[string]string{
+		"kind":      string(report.Kind),
+		"component": report.Component,
+		"chain_id":  report.ChainID,
+	}
+	if report.Class != "" {
+		tags["class"] = report.Class
+	}
+	if report.FpBtcPk != "" {
+		tags["fp_btc_pk"] = report.FpBtcPk
+	}
+
+	extra := map[string]interface{}{}
+	if report.Height > 0 {
+		extra["height"] = report.Height
+	}
+	if report.Stack != "" {
+		extra["stack"] = report.Stack
+	}
+
+	return &sentryEvent{
+		EventID:     hex.EncodeToString(id[:]),
+		Timestamp:   report.Time.UTC().Format("2006-01-02T15:04:05Z"),
+		Platform:    "go",
+		Level:       level,
+		Logger:      sentryClient,
+		Release:     version.Version(),
+		Environment: r.environment,
+		Message:     report.Title(),
+		Tags:        tags,
+		Extra:       extra,
+	}, nil
+}
+
+func (r *SentryReporter) authHeader() string {
+	header := fmt.Sprintf("Sentry sentry_version=%d, sentry_client=%s/%s, sentry_key=%s",
+		sentryVersion, sentryClient, version.Version(), r.publicKey)
+	if r.secretKey != "" {
+		header += ", sentry_secret=" + r.secretKey
+	}
+
+	return header
+}
@@ -0,0 +1,209 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+)
+
+// droppedEventsLogInterval is the number of dropped events of a subscriber
+// between each warning
+const droppedEventsLogInterval = 1000
+
+// Sink writes the events to a downstream system
+type Sink interface {
+	Name() string
+	Write(ctx context.Context, event *Event) error
+	Close() error
+}
+
+// Bus dispatches the published events to its subscribers. Publishing never
+// blocks: the events are dropped for the subscribers whose buffer is full.
+// Publish and Close of a nil Bus are no-ops so that the publishers do not
+// need to check whether the bus is enabled
+type Bus struct {
+	mu         sync.Mutex
+	subs       map[uint64]*subscriber
+	nextID     uint64
+	closed     bool
+	bufferSize int
+
+	logger *zap.Logger
+	wg     sync.WaitGroup
+}
+
+type subscriber struct {
+	name    string
+	events  chan *Event
+	dropped uint64
+}
+
+// NewBus creates a bus which buffers bufferSize events for each subscriber
+func NewBus(bufferSize int, logger *zap.Logger) *Bus {
+	return &Bus{
+		subs:       make(map[uint64]*subscriber),
+		bufferSize: bufferSize,
+		logger:     logger,
+	}
+}
+
+// New creates a bus with the sinks of the given config, nil if no sink
+// is configured
+func New(cfg *fpcfg.EventBusConfig, logger *zap.Logger) (*Bus, error) {
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+
+	var sinks []Sink
+	if cfg.HistoryPath != "" {
+		s, err := NewHistorySink(cfg.HistoryPath, cfg.HistorySize)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, s)
+	}
+	if cfg.FilePath != "" {
+		s, err := NewFileSink(cfg.FilePath)
+		if err != nil {
+			return nil, errors.Join(err, closeSinks(sinks))
+		}
+		sinks = append(sinks, s)
+	}
+	if cfg.NatsURL != "" {
+		s, err := NewNatsSink(cfg.NatsURL, cfg.NatsSubject, cfg.Timeout)
+		if err != nil {
+			return nil, errors.Join(err, closeSinks(sinks))
+		}
+		sinks = append(sinks, s)
+	}
+	if cfg.KafkaRestProxyURL != "" {
+		sinks = append(sinks, NewKafkaRestSink(cfg.KafkaRestProxyURL, cfg.KafkaTopic, cfg.Timeout))
+	}
+
+	b := NewBus(int(cfg.BufferSize), logger)
+	for _, s := range sinks {
+		b.AddSink(s, cfg.Timeout)
+	}
+
+	return b, nil
+}
+
+// Subscribe returns a channel receiving the events published from now on
+// and a function to unsubscribe, which closes the channel
+func (b *Bus) Subscribe(name string) (<-chan *Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	events := make(chan *Event, b.bufferSize)
+	if b.closed {
+		close(events)
+		return events, func() {}
+	}
+
+	id := b.nextID
+	b.nextID++
+	b.subs[id] = &subscriber{name: name, events: events}
+
+	return events, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if sub, ok := b.subs[id]; ok {
+			delete(b.subs, id)
+			close(sub.events)
+		}
+	}
+}
+
+// AddSink subscribes the sink to the bus and writes each event to it with
+// the given timeout until the bus is closed, after which the sink is closed
+func (b *Bus) AddSink(sink Sink, timeout time.Duration) {
+	events, _ := b.Subscribe(sink.Name())
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+
+		for ev := range events {
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			if err := sink.Write(ctx, ev); err != nil {
+				b.logger.Warn("failed to write the event to the sink",
+					zap.String("sink", sink.Name()),
+					zap.String("type", string(ev.Type)),
+					zap.String("pk", ev.FpBtcPk),
+					zap.Error(err),
+				)
+			}
+			cancel()
+		}
+
+		if err := sink.Close(); err != nil {
+			b.logger.Warn("failed to close the sink", zap.String("sink", sink.Name()), zap.Error(err))
+		}
+	}()
+}
+
+// Publish dispatches the event to the subscribers, the time of the event
+// is set to now if empty
+func (b *Bus) Publish(ev *Event) {
+	if b == nil {
+		return
+	}
+
+	if ev.Time.IsZero() {
+		ev.Time = time.Now().UTC()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, sub := range b.subs {
+		select {
+		case sub.events <- ev:
+		default:
+			sub.dropped++
+			if sub.dropped%droppedEventsLogInterval == 1 {
+				b.logger.Warn("the event buffer of the subscriber is full, dropping events",
+					zap.String("subscriber", sub.name),
+					zap.Uint64("dropped", sub.dropped),
+				)
+			}
+		}
+	}
+}
+
+// Close unsubscribes all the subscribers and waits for the sinks to write
+// the buffered events and close
+func (b *Bus) Close() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		for id, sub := range b.subs {
+			delete(b.subs, id)
+			close(sub.events)
+		}
+	}
+	b.mu.Unlock()
+
+	b.wg.Wait()
+}
+
+func closeSinks(sinks []Sink) error {
+	var errs []error
+	for _, s := range sinks {
+		if err := s.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close the %s sink: %w", s.Name(), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
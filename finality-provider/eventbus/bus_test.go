@@ -0,0 +1,202 @@
+package eventbus_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/eventbus"
+)
+
+func TestBus(t *testing.T) {
+	t.Parallel()
+
+	// a nil bus is a no-op
+	var nilBus *eventbus.Bus
+	nilBus.Publish(&eventbus.Event{Type: eventbus.EventVoteSubmitted})
+	nilBus.Close()
+
+	cfg := fpcfg.DefaultEventBusConfig()
+	b, err := eventbus.New(&cfg, zap.NewNop())
+	require.NoError(t, err)
+	require.Nil(t, b)
+
+	cfg.FilePath = filepath.Join(t.TempDir(), "events", "events.jsonl")
+	cfg.BufferSize = 2
+	b, err = eventbus.New(&cfg, zap.NewNop())
+	require.NoError(t, err)
+	require.NotNil(t, b)
+
+	// the events are dropped for the subscriber which does not consume them
+	events, unsubscribe := b.Subscribe("test")
+	published := []*eventbus.Event{
+		{Type: eventbus.EventVoteSubmitted, FpBtcPk: "fp-pk", StartHeight: 10, EndHeight: 12, TxHash: "tx1"},
+		{Type: eventbus.EventPubRandCommitted, FpBtcPk: "fp-pk", StartHeight: 13, EndHeight: 112, TxHash: "tx2"},
+		{Type: eventbus.EventStatusChanged, FpBtcPk: "fp-pk", OldStatus: "ACTIVE", NewStatus: "JAILED"},
+	}
+	for _, ev := range published {
+		b.Publish(ev)
+		// let the file sink consume the event to not drop it
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.Len(t, events, 2)
+	require.Equal(t, published[0], <-events)
+	unsubscribe()
+
+	// the buffered events are written to the file upon close
+	b.Close()
+	f, err := os.Open(cfg.FilePath)
+	require.NoError(t, err)
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for _, ev := range published {
+		require.True(t, scanner.Scan())
+		var written eventbus.Event
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &written))
+		require.Equal(t, ev.Type, written.Type)
+		require.Equal(t, ev.StartHeight, written.StartHeight)
+		require.Equal(t, ev.NewStatus, written.NewStatus)
+		require.False(t, written.Time.IsZero())
+	}
+	require.False(t, scanner.Scan())
+}
+
+func TestKafkaRestSink(t *testing.T) {
+	t.Parallel()
+
+	requests := make(chan *http.Request, 1)
+	bodies := make(chan map[string]interface{}, 1)
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		requests <- r
+		bodies <- body
+	}))
+	defer proxy.Close()
+
+	s := eventbus.NewKafkaRestSink(proxy.URL+"/", "fpd-events", time.Second)
+	defer s.Close()
+
+	ev := &eventbus.Event{Type: eventbus.EventVoteSubmitted, FpBtcPk: "fp-pk", StartHeight: 10}
+	require.NoError(t, s.Write(context.Background(), ev))
+
+	req := <-requests
+	require.Equal(t, "/topics/fpd-events", req.URL.Path)
+	require.Equal(t, "application/vnd.kafka.json.v2+json", req.Header.Get("Content-Type"))
+	records := (<-bodies)["records"].([]interface{})
+	require.Len(t, records, 1)
+	record := records[0].(map[string]interface{})
+	require.Equal(t, "fp-pk", record["key"])
+	require.Equal(t, string(eventbus.EventVoteSubmitted), record["value"].(map[string]interface{})["type"])
+}
+
+func TestNatsSink(t *testing.T) {
+	t.Parallel()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+
+	// a fake NATS server accepting the connection and receiving a message
+	received := make(chan string, 1)
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		fmt.Fprint(conn, "INFO {\"server_id\":\"test\",\"tls_required\":false}\r\n")
+		connect, _ := r.ReadString('\n')
+		if !strings.HasPrefix(connect, "CONNECT ") {
+			return
+		}
+		if ping, _ := r.ReadString('\n'); ping != "PING\r\n" {
+			return
+		}
+		fmt.Fprint(conn, "PONG\r\n")
+
+		pub, _ := r.ReadString('\n')
+		payload, _ := r.ReadString('\n')
+		received <- pub + payload
+	}()
+
+	s, err := eventbus.NewNatsSink("nats://"+lis.Addr().String(), "fpd.events", time.Second)
+	require.NoError(t, err)
+	defer s.Close()
+
+	ev := &eventbus.Event{Type: eventbus.EventStatusChanged, FpBtcPk: "fp-pk", NewStatus: "ACTIVE"}
+	require.NoError(t, s.Write(context.Background(), ev))
+
+	msg := <-received
+	header, payload, ok := strings.Cut(msg, "\r\n")
+	require.True(t, ok)
+	payload = strings.TrimSuffix(payload, "\r\n")
+	require.Equal(t, fmt.Sprintf("PUB fpd.events %d", len(payload)), header)
+	var published eventbus.Event
+	require.NoError(t, json.Unmarshal([]byte(payload), &published))
+	require.Equal(t, ev.Type, published.Type)
+	require.Equal(t, ev.NewStatus, published.NewStatus)
+}
+
+func TestHistorySink(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "events", "history.jsonl")
+	s, err := eventbus.NewHistorySink(path, 4)
+	require.NoError(t, err)
+
+	start := time.Now().UTC()
+	for i := uint64(1); i <= 5; i++ {
+		ev := &eventbus.Event{Type: eventbus.EventVoteSubmitted, FpBtcPk: "fp-pk", StartHeight: i, Time: start.Add(time.Duration(i) * time.Minute)}
+		require.NoError(t, s.Write(context.Background(), ev))
+	}
+	require.NoError(t, s.Write(context.Background(), &eventbus.Event{
+		Type: eventbus.EventStatusChanged, FpBtcPk: "other-pk", NewStatus: "JAILED", Time: start.Add(6 * time.Minute),
+	}))
+	require.NoError(t, s.Close())
+
+	// the oldest events are dropped once the history is full
+	events, err := eventbus.ReadHistory(path, &eventbus.HistoryFilter{})
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+	require.Equal(t, uint64(3), events[0].StartHeight)
+	require.Equal(t, eventbus.EventStatusChanged, events[3].Type)
+
+	// the history of the previous run is kept and filtered
+	s, err = eventbus.NewHistorySink(path, 4)
+	require.NoError(t, err)
+	require.NoError(t, s.Write(context.Background(), &eventbus.Event{
+		Type: eventbus.EventCriticalError, FpBtcPk: "fp-pk", Error: "boom", Time: start.Add(7 * time.Minute),
+	}))
+	require.NoError(t, s.Close())
+
+	types, err := eventbus.ParseEventTypes([]string{"vote", "critical_error"})
+	require.NoError(t, err)
+	events, err = eventbus.ReadHistory(path, &eventbus.HistoryFilter{
+		Since:   start.Add(5 * time.Minute),
+		Types:   types,
+		FpBtcPk: "fp-pk",
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, uint64(5), events[0].StartHeight)
+	require.Equal(t, "boom", events[1].Error)
+
+	_, err = eventbus.ParseEventTypes([]string{"unknown"})
+	require.Error(t, err)
+}
@@ -0,0 +1,51 @@
+package eventbus
+
+import "time"
+
+// EventType is the type of an event of a finality provider
+type EventType string
+
+const (
+	// EventFpCreated is published when a finality provider is created
+	EventFpCreated EventType = "fp_created"
+	// EventFpRegistered is published when a finality provider is
+	// registered on Babylon
+	EventFpRegistered EventType = "fp_registered"
+	// EventVoteSubmitted is published when the finality signatures of a
+	// finality provider are submitted
+	EventVoteSubmitted EventType = "vote_submitted"
+	// EventPubRandCommitted is published when a finality provider commits
+	// public randomness
+	EventPubRandCommitted EventType = "pub_rand_committed"
+	// EventStatusChanged is published when the status of a running
+	// finality provider changes
+	EventStatusChanged EventType = "status_changed"
+	// EventEquivocationDetected is published when an equivocation evidence
+	// against a finality provider is found on-chain
+	EventEquivocationDetected EventType = "equivocation_detected"
+	// EventUnknownVoteDetected is published when a vote of a finality
+	// provider which has not been signed by the daemon is found on-chain
+	EventUnknownVoteDetected EventType = "unknown_vote_detected"
+	// EventCriticalError is published when a running finality provider
+	// reports a critical error
+	EventCriticalError EventType = "critical_error"
+)
+
+// Event describes something significant that happened to a finality
+// provider, the fields not relevant to the type are left empty
+type Event struct {
+	Type    EventType `json:"type"`
+	Time    time.Time `json:"time"`
+	FpBtcPk string    `json:"fp_btc_pk"`
+	TxHash  string    `json:"tx_hash,omitempty"`
+	// StartHeight and EndHeight are the range of the voted heights, or of
+	// the heights of the committed public randomness
+	StartHeight uint64 `json:"start_height,omitempty"`
+	EndHeight   uint64 `json:"end_height,omitempty"`
+	// Height is the height of the equivocation or of the unknown vote
+	Height    uint64 `json:"height,omitempty"`
+	OldStatus string `json:"old_status,omitempty"`
+	NewStatus string `json:"new_status,omitempty"`
+	// Error is the message of the critical error
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,242 @@
+package eventbus
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// previousSegmentSuffix is the suffix of the previous segment of the history
+const previousSegmentSuffix = ".1"
+
+// eventTypeAliases are the short names of the event types accepted by
+// ParseEventTypes
+var eventTypeAliases = map[string]EventType{
+	"created":      EventFpCreated,
+	"registered":   EventFpRegistered,
+	"vote":         EventVoteSubmitted,
+	"pubrand":      EventPubRandCommitted,
+	"status":       EventStatusChanged,
+	"equivocation": EventEquivocationDetected,
+	"unknown_vote": EventUnknownVoteDetected,
+	"error":        EventCriticalError,
+}
+
+// EventTypes returns all the event types
+func EventTypes() []EventType {
+	return []EventType{
+		EventFpCreated,
+		EventFpRegistered,
+		EventVoteSubmitted,
+		EventPubRandCommitted,
+		EventStatusChanged,
+		EventEquivocationDetected,
+		EventUnknownVoteDetected,
+		EventCriticalError,
+	}
+}
+
+// ParseEventTypes parses the event types given by their names or by their
+// short names, e.g., vote for vote_submitted or status for status_changed
+func ParseEventTypes(names []string) ([]EventType, error) {
+	types := make([]EventType, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if t, ok := eventTypeAliases[name]; ok {
+			types = append(types, t)
+			continue
+		}
+
+		found := false
+		for _, t := range EventTypes() {
+			if string(t) == name {
+				types = append(types, t)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown event type %q", name)
+		}
+	}
+
+	return types, nil
+}
+
+// HistorySink keeps the last events on disk in a bounded ring buffer of two
+// segments, i.e., the current file and the previous one, which is replaced
+// once the current file is full. Between half and all of the size events are
+// kept, which can be read with ReadHistory while the daemon is running
+type HistorySink struct {
+	path        string
+	segmentSize int
+
+	mu   sync.Mutex
+	file *os.File
+	// count is the number of events in the current segment
+	count int
+}
+
+// NewHistorySink creates a history keeping up to size events at the given
+// path, the events of the previous runs being kept
+func NewHistorySink(path string, size uint32) (*HistorySink, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create the directory of the event history: %w", err)
+	}
+
+	count := 0
+	err := scanHistory(path, func(_ *Event) {
+		count++
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the event history %s: %w", path, err)
+	}
+
+	// #nosec G304 - The event history path is provided by the user and not externally
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the event history %s: %w", path, err)
+	}
+
+	return &HistorySink{
+		path:        path,
+		segmentSize: max(int(size/2), 1),
+		file:        f,
+		count:       count,
+	}, nil
+}
+
+func (s *HistorySink) Name() string {
+	return "history"
+}
+
+func (s *HistorySink) Write(_ context.Context, event *Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.count >= s.segmentSize {
+		if err := s.rotate(); err != nil {
+			return fmt.Errorf("failed to rotate the event history: %w", err)
+		}
+	}
+
+	if _, err := s.file.Write(line); err != nil {
+		return fmt.Errorf("failed to append the event to the history: %w", err)
+	}
+	s.count++
+
+	return nil
+}
+
+// rotate replaces the previous segment with the current one and starts a
+// new segment
+func (s *HistorySink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(s.path, s.path+previousSegmentSuffix); err != nil {
+		return err
+	}
+
+	// #nosec G304 - The event history path is provided by the user and not externally
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	s.file = f
+	s.count = 0
+
+	return nil
+}
+
+func (s *HistorySink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.file.Close()
+}
+
+// HistoryFilter selects the events read from the history, the empty fields
+// selecting all the events
+type HistoryFilter struct {
+	Since   time.Time
+	Types   []EventType
+	FpBtcPk string
+}
+
+// Match returns whether the event is selected by the filter
+func (f *HistoryFilter) Match(ev *Event) bool {
+	if !f.Since.IsZero() && ev.Time.Before(f.Since) {
+		return false
+	}
+	if f.FpBtcPk != "" && ev.FpBtcPk != f.FpBtcPk {
+		return false
+	}
+	if len(f.Types) == 0 {
+		return true
+	}
+	for _, t := range f.Types {
+		if ev.Type == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ReadHistory returns the events of the history at the given path which are
+// selected by the filter, from the oldest to the newest
+func ReadHistory(path string, filter *HistoryFilter) ([]*Event, error) {
+	var events []*Event
+	handle := func(ev *Event) {
+		if filter.Match(ev) {
+			events = append(events, ev)
+		}
+	}
+
+	for _, segment := range []string{path + previousSegmentSuffix, path} {
+		if err := scanHistory(segment, handle); err != nil {
+			return nil, fmt.Errorf("failed to read the event history %s: %w", segment, err)
+		}
+	}
+
+	return events, nil
+}
+
+func scanHistory(path string, handle func(*Event)) error {
+	// #nosec G304 - The event history path is provided by the user and not externally
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var ev Event
+		// the last line may be partially written if the history is read
+		// while the daemon is running, it is skipped
+		if err := json.Unmarshal(scanner.Bytes(), &ev); err != nil {
+			continue
+		}
+		handle(&ev)
+	}
+
+	return scanner.Err()
+}
@@ -0,0 +1,76 @@
+package eventbus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const kafkaRestContentType = "application/vnd.kafka.json.v2+json"
+
+// KafkaRestSink produces the events to a Kafka topic through the REST
+// proxy, keyed by the public key of the finality provider so that the
+// events of a finality provider are kept in order within a partition
+type KafkaRestSink struct {
+	client *http.Client
+	url    string
+}
+
+func NewKafkaRestSink(proxyURL, topic string, timeout time.Duration) *KafkaRestSink {
+	return &KafkaRestSink{
+		client: &http.Client{Timeout: timeout},
+		url:    strings.TrimSuffix(proxyURL, "/") + "/topics/" + url.PathEscape(topic),
+	}
+}
+
+type kafkaRecord struct {
+	Key   string `json:"key"`
+	Value *Event `json:"value"`
+}
+
+type kafkaProduceRequest struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+func (s *KafkaRestSink) Name() string {
+	return "kafka"
+}
+
+func (s *KafkaRestSink) Write(ctx context.Context, event *Event) error {
+	body, err := json.Marshal(&kafkaProduceRequest{
+		Records: []kafkaRecord{{Key: event.FpBtcPk, Value: event}},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", kafkaRestContentType)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to produce the event to Kafka: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("failed to produce the event to Kafka: unexpected status code %d: %s", resp.StatusCode, msg)
+	}
+
+	return nil
+}
+
+func (s *KafkaRestSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
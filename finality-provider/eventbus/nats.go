@@ -0,0 +1,217 @@
+package eventbus
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NatsSink publishes the events to a NATS subject through the core NATS
+// protocol. The connection is established upon the first event and
+// re-established upon failure. TLS is not supported
+type NatsSink struct {
+	addr    string
+	user    *url.Userinfo
+	subject string
+	timeout time.Duration
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func NewNatsSink(natsURL, subject string, timeout time.Duration) (*NatsSink, error) {
+	u, err := url.Parse(natsURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid NATS URL %s: %w", natsURL, err)
+	}
+
+	return &NatsSink{
+		addr:    u.Host,
+		user:    u.User,
+		subject: subject,
+		timeout: timeout,
+	}, nil
+}
+
+type natsConnectOptions struct {
+	Verbose  bool   `json:"verbose"`
+	Pedantic bool   `json:"pedantic"`
+	Name     string `json:"name"`
+	User     string `json:"user,omitempty"`
+	Pass     string `json:"pass,omitempty"`
+}
+
+type natsServerInfo struct {
+	TLSRequired bool `json:"tls_required"`
+}
+
+func (s *NatsSink) Name() string {
+	return "nats"
+}
+
+func (s *NatsSink) Write(ctx context.Context, event *Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		if err := s.connect(ctx); err != nil {
+			return fmt.Errorf("failed to connect to NATS: %w", err)
+		}
+	}
+
+	msg := make([]byte, 0, len(s.subject)+len(payload)+32)
+	msg = fmt.Appendf(msg, "PUB %s %d\r\n", s.subject, len(payload))
+	msg = append(msg, payload...)
+	msg = append(msg, "\r\n"...)
+
+	if err := s.conn.SetWriteDeadline(deadline(ctx, s.timeout)); err != nil {
+		s.closeConn()
+		return err
+	}
+	if _, err := s.conn.Write(msg); err != nil {
+		s.closeConn()
+		return fmt.Errorf("failed to publish the event to NATS: %w", err)
+	}
+
+	return nil
+}
+
+func (s *NatsSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closeConn()
+	return nil
+}
+
+// connect dials the server, reads its INFO, sends CONNECT and waits for the
+// PONG replying to a PING so that an authorization error is reported
+func (s *NatsSink) connect(ctx context.Context) error {
+	dialer := &net.Dialer{Timeout: s.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	if err := conn.SetDeadline(deadline(ctx, s.timeout)); err != nil {
+		conn.Close()
+		return err
+	}
+
+	r := bufio.NewReader(conn)
+	line, err := r.ReadString('\n')
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to read the server info: %w", err)
+	}
+	infoJSON, ok := strings.CutPrefix(strings.TrimSpace(line), "INFO ")
+	if !ok {
+		conn.Close()
+		return fmt.Errorf("unexpected server greeting: %s", line)
+	}
+	var info natsServerInfo
+	if err := json.Unmarshal([]byte(infoJSON), &info); err != nil {
+		conn.Close()
+		return fmt.Errorf("invalid server info: %w", err)
+	}
+	if info.TLSRequired {
+		conn.Close()
+		return errors.New("the server requires TLS, which is not supported")
+	}
+
+	opts := natsConnectOptions{Name: "fpd"}
+	if s.user != nil {
+		opts.User = s.user.Username()
+		opts.Pass, _ = s.user.Password()
+	}
+	optsJSON, err := json.Marshal(opts)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	if _, err := fmt.Fprintf(conn, "CONNECT %s\r\nPING\r\n", optsJSON); err != nil {
+		conn.Close()
+		return err
+	}
+
+	line, err = r.ReadString('\n')
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to read the reply to CONNECT: %w", err)
+	}
+	if reply := strings.TrimSpace(line); reply != "PONG" {
+		conn.Close()
+		return fmt.Errorf("unexpected reply to CONNECT: %s", reply)
+	}
+
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		conn.Close()
+		return err
+	}
+
+	s.conn = conn
+	go s.readLoop(conn, r)
+
+	return nil
+}
+
+// readLoop answers the PINGs of the server, which otherwise closes the
+// connection, until the connection is closed
+func (s *NatsSink) readLoop(conn net.Conn, r *bufio.Reader) {
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			break
+		}
+
+		if strings.TrimSpace(line) != "PING" {
+			continue
+		}
+
+		s.mu.Lock()
+		if s.conn != conn {
+			s.mu.Unlock()
+			return
+		}
+		_ = conn.SetWriteDeadline(time.Now().Add(s.timeout))
+		_, err = conn.Write([]byte("PONG\r\n"))
+		s.mu.Unlock()
+		if err != nil {
+			break
+		}
+	}
+
+	s.mu.Lock()
+	if s.conn == conn {
+		s.closeConn()
+	}
+	s.mu.Unlock()
+}
+
+func (s *NatsSink) closeConn() {
+	if s.conn != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+	}
+}
+
+// deadline returns the deadline of the context if any, or the given
+// timeout from now otherwise
+func deadline(ctx context.Context, timeout time.Duration) time.Time {
+	if d, ok := ctx.Deadline(); ok {
+		return d
+	}
+
+	return time.Now().Add(timeout)
+}
@@ -0,0 +1,129 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+)
+
+// rateLimitWindow is the window of the max number of notifications
+const rateLimitWindow = time.Hour
+
+// ErrRateLimited is returned by the dispatcher when an event is suppressed
+// to avoid an alert storm
+var ErrRateLimited = errors.New("the notification is rate limited")
+
+// Dispatcher sends the events to the built-in notifiers of the config and to
+// the registered ones. The events are rate limited to avoid alert storms:
+// the same event of a finality provider is sent once per cooldown and at
+// most a max number of events are sent per hour, the suppressed events being
+// counted in the next event sent. Notify and Enabled of a nil Dispatcher
+// are no-ops
+type Dispatcher struct {
+	cooldown   time.Duration
+	maxPerHour int
+
+	mu        sync.Mutex
+	notifiers multiNotifier
+	// lastSent maps the key of the events to the time of the last event of
+	// the key which has been sent
+	lastSent map[string]time.Time
+	// suppressed maps the key of the events to the number of the events of
+	// the key which have been suppressed since the last one sent
+	suppressed map[string]uint64
+	// sent are the times of the events sent within the last hour
+	sent []time.Time
+}
+
+// NewDispatcher creates a dispatcher with the built-in notifiers and the
+// rate limits of the given config
+func NewDispatcher(cfg *fpcfg.NotifierConfig) *Dispatcher {
+	d := &Dispatcher{
+		lastSent:   make(map[string]time.Time),
+		suppressed: make(map[string]uint64),
+	}
+	if cfg != nil {
+		d.cooldown = cfg.Cooldown
+		d.maxPerHour = int(cfg.MaxPerHour)
+	}
+	if n := New(cfg); n != nil {
+		d.notifiers = append(d.notifiers, n)
+	}
+
+	return d
+}
+
+// Register adds a notifier to which the events are sent along with the
+// built-in ones, so that the alerts can be sent to other destinations
+// without forking the daemon
+func (d *Dispatcher) Register(n Notifier) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.notifiers = append(d.notifiers, n)
+}
+
+// Enabled returns whether at least one notifier is configured or registered
+func (d *Dispatcher) Enabled() bool {
+	if d == nil {
+		return false
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return len(d.notifiers) > 0
+}
+
+// Notify sends the event to the notifiers unless it is rate limited, in
+// which case ErrRateLimited is returned
+func (d *Dispatcher) Notify(ctx context.Context, event *Event) error {
+	if d == nil {
+		return nil
+	}
+
+	notifiers, err := d.admit(event)
+	if err != nil {
+		return err
+	}
+
+	return notifiers.Notify(ctx, event)
+}
+
+// admit applies the rate limits to the event, setting the number of the
+// suppressed events of its key, and returns the notifiers to send it to
+func (d *Dispatcher) admit(event *Event) (multiNotifier, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	key := fmt.Sprintf("%s-%s-%s", event.ChainID, event.FpBtcPk, event.Type)
+
+	if d.cooldown > 0 {
+		if last, ok := d.lastSent[key]; ok && now.Sub(last) < d.cooldown {
+			d.suppressed[key]++
+			return nil, fmt.Errorf("%w: the same event has been sent %s ago", ErrRateLimited, now.Sub(last).Round(time.Second))
+		}
+	}
+
+	if d.maxPerHour > 0 {
+		for len(d.sent) > 0 && now.Sub(d.sent[0]) >= rateLimitWindow {
+			d.sent = d.sent[1:]
+		}
+		if len(d.sent) >= d.maxPerHour {
+			d.suppressed[key]++
+			return nil, fmt.Errorf("%w: %d notifications have been sent within the last hour", ErrRateLimited, len(d.sent))
+		}
+		d.sent = append(d.sent, now)
+	}
+
+	d.lastSent[key] = now
+	event.Suppressed = d.suppressed[key]
+	delete(d.suppressed, key)
+
+	return d.notifiers, nil
+}
@@ -0,0 +1,158 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+)
+
+// EventType is the type of the status change of a finality provider
+type EventType string
+
+const (
+	// EventJailed is fired when a finality provider is detected to be jailed
+	EventJailed EventType = "jailed"
+	// EventSlashed is fired when a finality provider is detected to be slashed
+	EventSlashed EventType = "slashed"
+	// EventEquivocated is fired when an equivocation evidence against a
+	// finality provider is found on-chain
+	EventEquivocated EventType = "equivocated"
+	// EventCompromised is fired when a vote of a managed finality provider
+	// which has not been signed by the daemon is found on-chain
+	EventCompromised EventType = "compromised"
+	// EventFailing is fired when a finality provider instance reports a
+	// critical error which is handled by alerting
+	EventFailing EventType = "failing"
+	// EventRateLimited is fired when the submissions of a finality provider
+	// start being held back by the submission rate limiter
+	EventRateLimited EventType = "rate_limited"
+	// EventVoteLagging is fired when the last voted height of an active
+	// finality provider stays too far behind the tip
+	EventVoteLagging EventType = "vote_lagging"
+	// EventFinalizationLagging is fired when the last finalized height stays
+	// too far behind the tip
+	EventFinalizationLagging EventType = "finalization_lagging"
+	// EventMissedVotes is fired when the consecutive missed votes of a
+	// finality provider reach the threshold
+	EventMissedVotes EventType = "missed_votes"
+	// EventPubRandRunwayLow is fired when the committed public randomness of
+	// a finality provider falls below the min runway
+	EventPubRandRunwayLow EventType = "pub_rand_runway_low"
+	// EventLowParticipation is fired when the share of the submitted votes
+	// of a finality provider within the window of an alert rule falls below
+	// its min
+	EventLowParticipation EventType = "low_participation"
+	// EventLowBalance is fired when the balance of an account paying the
+	// fees falls below the min or the projected spend
+	EventLowBalance EventType = "low_balance"
+)
+
+// Event describes a jailed, slashed, equivocating, compromised, failing,
+// rate limited or lagging finality provider, or one missing votes, running
+// out of public randomness or funds for the fees, or participating too little
+type Event struct {
+	Type      EventType `json:"type"`
+	FpBtcPk   string    `json:"fp_btc_pk"`
+	ChainID   string    `json:"chain_id"`
+	OldStatus string    `json:"old_status"`
+	// Height is the latest height of the consumer chain upon detection,
+	// zero if it could not be queried
+	Height          uint64    `json:"height"`
+	LastVotedHeight uint64    `json:"last_voted_height"`
+	ProbableCause   string    `json:"probable_cause"`
+	Time            time.Time `json:"time"`
+	// Suppressed is the number of the same events which have been
+	// suppressed by the rate limits since the last one sent
+	Suppressed uint64 `json:"suppressed,omitempty"`
+}
+
+// Summary returns a one-line human readable description of the event
+func (e *Event) Summary() string {
+	summary := fmt.Sprintf("finality provider %s on %s is %s at height %d (last voted height: %d, probable cause: %s)",
+		e.FpBtcPk, e.ChainID, e.Type, e.Height, e.LastVotedHeight, e.ProbableCause)
+	if e.Suppressed > 0 {
+		summary += fmt.Sprintf(", %d similar alerts suppressed", e.Suppressed)
+	}
+
+	return summary
+}
+
+// Notifier alerts the operator of an event
+type Notifier interface {
+	Notify(ctx context.Context, event *Event) error
+}
+
+// New creates a notifier which alerts all the configured destinations,
+// nil if no destination is configured
+func New(cfg *fpcfg.NotifierConfig) Notifier {
+	if !cfg.Enabled() {
+		return nil
+	}
+
+	client := &http.Client{Timeout: cfg.Timeout}
+
+	var notifiers multiNotifier
+	if cfg.WebhookURL != "" {
+		notifiers = append(notifiers, NewWebhookNotifier(client, cfg.WebhookURL))
+	}
+	if cfg.SlackWebhookURL != "" {
+		notifiers = append(notifiers, NewSlackNotifier(client, cfg.SlackWebhookURL))
+	}
+	if cfg.PagerDutyRoutingKey != "" {
+		notifiers = append(notifiers, NewPagerDutyNotifier(client, cfg.PagerDutyRoutingKey))
+	}
+	if cfg.TelegramBotToken != "" {
+		notifiers = append(notifiers, NewTelegramNotifier(client, cfg.TelegramBotToken, cfg.TelegramChatID))
+	}
+
+	return notifiers
+}
+
+// multiNotifier alerts each of the notifiers, a failing notifier
+// does not prevent the others from being alerted
+type multiNotifier []Notifier
+
+func (m multiNotifier) Notify(ctx context.Context, event *Event) error {
+	var errs []error
+	for _, n := range m {
+		if err := n.Notify(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// postJSON posts the given body as JSON to the url and expects a 2xx response
+func postJSON(ctx context.Context, client *http.Client, url string, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, msg)
+	}
+
+	return nil
+}
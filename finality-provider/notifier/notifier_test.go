@@ -0,0 +1,119 @@
+package notifier_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/notifier"
+)
+
+func TestNotifier(t *testing.T) {
+	event := &notifier.Event{
+		Type:            notifier.EventJailed,
+		FpBtcPk:         "fp-pk",
+		ChainID:         "chain-test",
+		OldStatus:       "ACTIVE",
+		Height:          100,
+		LastVotedHeight: 90,
+		ProbableCause:   "missed votes",
+		Time:            time.Now().UTC(),
+	}
+
+	webhookEvents := make(chan *notifier.Event, 1)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var e notifier.Event
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&e))
+		webhookEvents <- &e
+	}))
+	defer webhook.Close()
+
+	slack := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid token", http.StatusForbidden)
+	}))
+	defer slack.Close()
+
+	require.Nil(t, notifier.New(nil))
+	cfg := fpcfg.DefaultNotifierConfig()
+	require.Nil(t, notifier.New(&cfg))
+
+	cfg.WebhookURL = webhook.URL
+	cfg.SlackWebhookURL = slack.URL
+	n := notifier.New(&cfg)
+	require.NotNil(t, n)
+
+	// the failing Slack notifier does not prevent the webhook from being notified
+	err := n.Notify(context.Background(), event)
+	require.ErrorContains(t, err, "failed to notify Slack")
+	received := <-webhookEvents
+	require.Equal(t, event.Type, received.Type)
+	require.Equal(t, event.FpBtcPk, received.FpBtcPk)
+	require.Equal(t, event.Height, received.Height)
+	require.Equal(t, event.LastVotedHeight, received.LastVotedHeight)
+}
+
+// recordingNotifier records the events it is notified of
+type recordingNotifier struct {
+	events []*notifier.Event
+}
+
+func (n *recordingNotifier) Notify(_ context.Context, event *notifier.Event) error {
+	n.events = append(n.events, event)
+	return nil
+}
+
+func TestDispatcher(t *testing.T) {
+	// a nil dispatcher and a dispatcher without notifier are disabled
+	var nilDispatcher *notifier.Dispatcher
+	require.False(t, nilDispatcher.Enabled())
+	require.NoError(t, nilDispatcher.Notify(context.Background(), &notifier.Event{}))
+
+	cfg := fpcfg.DefaultNotifierConfig()
+	cfg.Cooldown = time.Hour
+	cfg.MaxPerHour = 2
+	d := notifier.NewDispatcher(&cfg)
+	require.False(t, d.Enabled())
+
+	n := &recordingNotifier{}
+	d.Register(n)
+	require.True(t, d.Enabled())
+
+	missed := func(fpPk string) *notifier.Event {
+		return &notifier.Event{Type: notifier.EventMissedVotes, FpBtcPk: fpPk, ChainID: "chain-test"}
+	}
+
+	// the same event of a finality provider is suppressed within the cooldown
+	require.NoError(t, d.Notify(context.Background(), missed("fp-1")))
+	err := d.Notify(context.Background(), missed("fp-1"))
+	require.ErrorIs(t, err, notifier.ErrRateLimited)
+
+	// the events of the other finality providers are sent up to the max
+	// number per hour
+	require.NoError(t, d.Notify(context.Background(), missed("fp-2")))
+	err = d.Notify(context.Background(), missed("fp-3"))
+	require.ErrorIs(t, err, notifier.ErrRateLimited)
+	require.Len(t, n.events, 2)
+	require.Equal(t, "fp-1", n.events[0].FpBtcPk)
+	require.Equal(t, "fp-2", n.events[1].FpBtcPk)
+
+	// the suppressed events are counted in the next event sent
+	cfg.Cooldown = 50 * time.Millisecond
+	cfg.MaxPerHour = 0
+	d = notifier.NewDispatcher(&cfg)
+	d.Register(n)
+	require.NoError(t, d.Notify(context.Background(), missed("fp-1")))
+	require.ErrorIs(t, d.Notify(context.Background(), missed("fp-1")), notifier.ErrRateLimited)
+	require.ErrorIs(t, d.Notify(context.Background(), missed("fp-1")), notifier.ErrRateLimited)
+	time.Sleep(cfg.Cooldown)
+	require.NoError(t, d.Notify(context.Background(), missed("fp-1")))
+	require.Len(t, n.events, 4)
+	require.Zero(t, n.events[2].Suppressed)
+	require.Equal(t, uint64(2), n.events[3].Suppressed)
+	require.Contains(t, n.events[3].Summary(), "2 similar alerts suppressed")
+}
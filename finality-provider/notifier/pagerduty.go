@@ -0,0 +1,41 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// PagerDutyNotifier triggers PagerDuty incidents through the Events API v2
+type PagerDutyNotifier struct {
+	client     *http.Client
+	url        string
+	routingKey string
+}
+
+func NewPagerDutyNotifier(client *http.Client, routingKey string) *PagerDutyNotifier {
+	return &PagerDutyNotifier{client: client, url: pagerDutyEventsURL, routingKey: routingKey}
+}
+
+func (n *PagerDutyNotifier) Notify(ctx context.Context, event *Event) error {
+	msg := map[string]interface{}{
+		"routing_key":  n.routingKey,
+		"event_action": "trigger",
+		// the same status change of a finality provider is a single incident
+		"dedup_key": fmt.Sprintf("%s-%s-%s", event.ChainID, event.FpBtcPk, event.Type),
+		"payload": map[string]interface{}{
+			"summary":        event.Summary(),
+			"source":         event.FpBtcPk,
+			"severity":       "critical",
+			"timestamp":      event.Time,
+			"custom_details": event,
+		},
+	}
+	if err := postJSON(ctx, n.client, n.url, msg); err != nil {
+		return fmt.Errorf("failed to notify PagerDuty: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// SlackNotifier sends the events to a Slack channel through an incoming webhook
+type SlackNotifier struct {
+	client     *http.Client
+	webhookURL string
+}
+
+func NewSlackNotifier(client *http.Client, webhookURL string) *SlackNotifier {
+	return &SlackNotifier{client: client, webhookURL: webhookURL}
+}
+
+func (n *SlackNotifier) Notify(ctx context.Context, event *Event) error {
+	msg := map[string]string{"text": ":rotating_light: " + event.Summary()}
+	if err := postJSON(ctx, n.client, n.webhookURL, msg); err != nil {
+		return fmt.Errorf("failed to notify Slack: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const telegramAPIURL = "https://api.telegram.org"
+
+// TelegramNotifier sends the events to a Telegram chat through a bot
+type TelegramNotifier struct {
+	client *http.Client
+	url    string
+	chatID string
+}
+
+func NewTelegramNotifier(client *http.Client, botToken, chatID string) *TelegramNotifier {
+	return &TelegramNotifier{
+		client: client,
+		url:    fmt.Sprintf("%s/bot%s/sendMessage", telegramAPIURL, botToken),
+		chatID: chatID,
+	}
+}
+
+func (n *TelegramNotifier) Notify(ctx context.Context, event *Event) error {
+	msg := map[string]string{
+		"chat_id": n.chatID,
+		"text":    event.Summary(),
+	}
+	if err := postJSON(ctx, n.client, n.url, msg); err != nil {
+		// strip the url from the error as it contains the bot token
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("failed to notify Telegram: %w", err)
+	}
+
+	return nil
+}
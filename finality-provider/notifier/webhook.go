@@ -0,0 +1,25 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// WebhookNotifier posts the events as JSON to a URL
+type WebhookNotifier struct {
+	client *http.Client
+	url    string
+}
+
+func NewWebhookNotifier(client *http.Client, url string) *WebhookNotifier {
+	return &WebhookNotifier{client: client, url: url}
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, event *Event) error {
+	if err := postJSON(ctx, n.client, n.url, event); err != nil {
+		return fmt.Errorf("failed to notify the webhook: %w", err)
+	}
+
+	return nil
+}
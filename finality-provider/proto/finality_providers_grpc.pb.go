@@ -20,14 +20,15 @@ const _ = grpc.SupportPackageIsVersion7
 
 const (
 	FinalityProviders_GetInfo_FullMethodName                   = "/proto.FinalityProviders/GetInfo"
-	FinalityProviders_CreateFinalityProvider_FullMethodName    = "/proto.FinalityProviders/CreateFinalityProvider"
-	FinalityProviders_RegisterFinalityProvider_FullMethodName  = "/proto.FinalityProviders/RegisterFinalityProvider"
-	FinalityProviders_AddFinalitySignature_FullMethodName      = "/proto.FinalityProviders/AddFinalitySignature"
-	FinalityProviders_UnjailFinalityProvider_FullMethodName    = "/proto.FinalityProviders/UnjailFinalityProvider"
 	FinalityProviders_QueryFinalityProvider_FullMethodName     = "/proto.FinalityProviders/QueryFinalityProvider"
 	FinalityProviders_QueryFinalityProviderList_FullMethodName = "/proto.FinalityProviders/QueryFinalityProviderList"
-	FinalityProviders_SignMessageFromChainKey_FullMethodName   = "/proto.FinalityProviders/SignMessageFromChainKey"
-	FinalityProviders_EditFinalityProvider_FullMethodName      = "/proto.FinalityProviders/EditFinalityProvider"
+	FinalityProviders_QueryParticipationReport_FullMethodName  = "/proto.FinalityProviders/QueryParticipationReport"
+	FinalityProviders_QueryVotes_FullMethodName                = "/proto.FinalityProviders/QueryVotes"
+	FinalityProviders_QueryPubRandCommits_FullMethodName       = "/proto.FinalityProviders/QueryPubRandCommits"
+	FinalityProviders_QueryRewards_FullMethodName              = "/proto.FinalityProviders/QueryRewards"
+	FinalityProviders_QueryInstanceStatus_FullMethodName       = "/proto.FinalityProviders/QueryInstanceStatus"
+	FinalityProviders_SubscribeEvents_FullMethodName           = "/proto.FinalityProviders/SubscribeEvents"
+	FinalityProviders_ExportPubRandProofs_FullMethodName       = "/proto.FinalityProviders/ExportPubRandProofs"
 )
 
 // FinalityProvidersClient is the client API for FinalityProviders service.
@@ -36,25 +37,34 @@ const (
 type FinalityProvidersClient interface {
 	// GetInfo returns the information of the daemon
 	GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error)
-	// CreateFinalityProvider generates and saves a finality provider object
-	CreateFinalityProvider(ctx context.Context, in *CreateFinalityProviderRequest, opts ...grpc.CallOption) (*CreateFinalityProviderResponse, error)
-	// RegisterFinalityProvider sends a transactions to the consumer chain to register a BTC
-	// finality provider
-	RegisterFinalityProvider(ctx context.Context, in *RegisterFinalityProviderRequest, opts ...grpc.CallOption) (*RegisterFinalityProviderResponse, error)
-	// AddFinalitySignature sends a transactions to the consumer chain to add a Finality
-	// signature for a block
-	AddFinalitySignature(ctx context.Context, in *AddFinalitySignatureRequest, opts ...grpc.CallOption) (*AddFinalitySignatureResponse, error)
-	// UnjailFinalityProvider sends a transactions to the consumer chain to unjail a given
-	// finality provider
-	UnjailFinalityProvider(ctx context.Context, in *UnjailFinalityProviderRequest, opts ...grpc.CallOption) (*UnjailFinalityProviderResponse, error)
 	// QueryFinalityProvider queries the finality provider
 	QueryFinalityProvider(ctx context.Context, in *QueryFinalityProviderRequest, opts ...grpc.CallOption) (*QueryFinalityProviderResponse, error)
 	// QueryFinalityProviderList queries a list of finality providers
 	QueryFinalityProviderList(ctx context.Context, in *QueryFinalityProviderListRequest, opts ...grpc.CallOption) (*QueryFinalityProviderListResponse, error)
-	// SignMessageFromChainKey signs a message from the chain keyring.
-	SignMessageFromChainKey(ctx context.Context, in *SignMessageFromChainKeyRequest, opts ...grpc.CallOption) (*SignMessageFromChainKeyResponse, error)
-	// EditFinalityProvider edits finality provider
-	EditFinalityProvider(ctx context.Context, in *EditFinalityProviderRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	// QueryParticipationReport compares the on-chain votes of the given
+	// finality provider over a height window with the local records and
+	// reports the missed votes with their probable reasons
+	QueryParticipationReport(ctx context.Context, in *QueryParticipationReportRequest, opts ...grpc.CallOption) (*QueryParticipationReportResponse, error)
+	// QueryVotes returns the locally recorded results of the votes of the
+	// given finality provider within a range of heights
+	QueryVotes(ctx context.Context, in *QueryVotesRequest, opts ...grpc.CallOption) (*QueryVotesResponse, error)
+	// QueryPubRandCommits returns the locally recorded results of the public
+	// randomness commits of the given finality provider
+	QueryPubRandCommits(ctx context.Context, in *QueryPubRandCommitsRequest, opts ...grpc.CallOption) (*QueryPubRandCommitsResponse, error)
+	// QueryRewards queries the withdrawable rewards of the given finality
+	// provider on the consumer chain
+	QueryRewards(ctx context.Context, in *QueryRewardsRequest, opts ...grpc.CallOption) (*QueryRewardsResponse, error)
+	// QueryInstanceStatus queries the live status of the running instance of
+	// the given finality provider along with its state on the consumer chain
+	QueryInstanceStatus(ctx context.Context, in *QueryInstanceStatusRequest, opts ...grpc.CallOption) (*QueryInstanceStatusResponse, error)
+	// SubscribeEvents streams the events of the finality providers, e.g.,
+	// the status changes, the votes, the commits and the critical errors,
+	// as they happen until the client cancels the stream
+	SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (FinalityProviders_SubscribeEventsClient, error)
+	// ExportPubRandProofs streams the proofs of the public randomness of the
+	// given finality provider within a range of heights, in ascending order
+	// of heights
+	ExportPubRandProofs(ctx context.Context, in *ExportPubRandProofsRequest, opts ...grpc.CallOption) (FinalityProviders_ExportPubRandProofsClient, error)
 }
 
 type finalityProvidersClient struct {
@@ -74,76 +84,131 @@ func (c *finalityProvidersClient) GetInfo(ctx context.Context, in *GetInfoReques
 	return out, nil
 }
 
-func (c *finalityProvidersClient) CreateFinalityProvider(ctx context.Context, in *CreateFinalityProviderRequest, opts ...grpc.CallOption) (*CreateFinalityProviderResponse, error) {
-	out := new(CreateFinalityProviderResponse)
-	err := c.cc.Invoke(ctx, FinalityProviders_CreateFinalityProvider_FullMethodName, in, out, opts...)
+func (c *finalityProvidersClient) QueryFinalityProvider(ctx context.Context, in *QueryFinalityProviderRequest, opts ...grpc.CallOption) (*QueryFinalityProviderResponse, error) {
+	out := new(QueryFinalityProviderResponse)
+	err := c.cc.Invoke(ctx, FinalityProviders_QueryFinalityProvider_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *finalityProvidersClient) RegisterFinalityProvider(ctx context.Context, in *RegisterFinalityProviderRequest, opts ...grpc.CallOption) (*RegisterFinalityProviderResponse, error) {
-	out := new(RegisterFinalityProviderResponse)
-	err := c.cc.Invoke(ctx, FinalityProviders_RegisterFinalityProvider_FullMethodName, in, out, opts...)
+func (c *finalityProvidersClient) QueryFinalityProviderList(ctx context.Context, in *QueryFinalityProviderListRequest, opts ...grpc.CallOption) (*QueryFinalityProviderListResponse, error) {
+	out := new(QueryFinalityProviderListResponse)
+	err := c.cc.Invoke(ctx, FinalityProviders_QueryFinalityProviderList_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *finalityProvidersClient) AddFinalitySignature(ctx context.Context, in *AddFinalitySignatureRequest, opts ...grpc.CallOption) (*AddFinalitySignatureResponse, error) {
-	out := new(AddFinalitySignatureResponse)
-	err := c.cc.Invoke(ctx, FinalityProviders_AddFinalitySignature_FullMethodName, in, out, opts...)
+func (c *finalityProvidersClient) QueryParticipationReport(ctx context.Context, in *QueryParticipationReportRequest, opts ...grpc.CallOption) (*QueryParticipationReportResponse, error) {
+	out := new(QueryParticipationReportResponse)
+	err := c.cc.Invoke(ctx, FinalityProviders_QueryParticipationReport_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *finalityProvidersClient) UnjailFinalityProvider(ctx context.Context, in *UnjailFinalityProviderRequest, opts ...grpc.CallOption) (*UnjailFinalityProviderResponse, error) {
-	out := new(UnjailFinalityProviderResponse)
-	err := c.cc.Invoke(ctx, FinalityProviders_UnjailFinalityProvider_FullMethodName, in, out, opts...)
+func (c *finalityProvidersClient) QueryVotes(ctx context.Context, in *QueryVotesRequest, opts ...grpc.CallOption) (*QueryVotesResponse, error) {
+	out := new(QueryVotesResponse)
+	err := c.cc.Invoke(ctx, FinalityProviders_QueryVotes_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *finalityProvidersClient) QueryFinalityProvider(ctx context.Context, in *QueryFinalityProviderRequest, opts ...grpc.CallOption) (*QueryFinalityProviderResponse, error) {
-	out := new(QueryFinalityProviderResponse)
-	err := c.cc.Invoke(ctx, FinalityProviders_QueryFinalityProvider_FullMethodName, in, out, opts...)
+func (c *finalityProvidersClient) QueryPubRandCommits(ctx context.Context, in *QueryPubRandCommitsRequest, opts ...grpc.CallOption) (*QueryPubRandCommitsResponse, error) {
+	out := new(QueryPubRandCommitsResponse)
+	err := c.cc.Invoke(ctx, FinalityProviders_QueryPubRandCommits_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *finalityProvidersClient) QueryFinalityProviderList(ctx context.Context, in *QueryFinalityProviderListRequest, opts ...grpc.CallOption) (*QueryFinalityProviderListResponse, error) {
-	out := new(QueryFinalityProviderListResponse)
-	err := c.cc.Invoke(ctx, FinalityProviders_QueryFinalityProviderList_FullMethodName, in, out, opts...)
+func (c *finalityProvidersClient) QueryRewards(ctx context.Context, in *QueryRewardsRequest, opts ...grpc.CallOption) (*QueryRewardsResponse, error) {
+	out := new(QueryRewardsResponse)
+	err := c.cc.Invoke(ctx, FinalityProviders_QueryRewards_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *finalityProvidersClient) SignMessageFromChainKey(ctx context.Context, in *SignMessageFromChainKeyRequest, opts ...grpc.CallOption) (*SignMessageFromChainKeyResponse, error) {
-	out := new(SignMessageFromChainKeyResponse)
-	err := c.cc.Invoke(ctx, FinalityProviders_SignMessageFromChainKey_FullMethodName, in, out, opts...)
+func (c *finalityProvidersClient) QueryInstanceStatus(ctx context.Context, in *QueryInstanceStatusRequest, opts ...grpc.CallOption) (*QueryInstanceStatusResponse, error) {
+	out := new(QueryInstanceStatusResponse)
+	err := c.cc.Invoke(ctx, FinalityProviders_QueryInstanceStatus_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *finalityProvidersClient) EditFinalityProvider(ctx context.Context, in *EditFinalityProviderRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
-	out := new(EmptyResponse)
-	err := c.cc.Invoke(ctx, FinalityProviders_EditFinalityProvider_FullMethodName, in, out, opts...)
+func (c *finalityProvidersClient) SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (FinalityProviders_SubscribeEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &FinalityProviders_ServiceDesc.Streams[0], FinalityProviders_SubscribeEvents_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &finalityProvidersSubscribeEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FinalityProviders_SubscribeEventsClient interface {
+	Recv() (*FinalityProviderEvent, error)
+	grpc.ClientStream
+}
+
+type finalityProvidersSubscribeEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *finalityProvidersSubscribeEventsClient) Recv() (*FinalityProviderEvent, error) {
+	m := new(FinalityProviderEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *finalityProvidersClient) ExportPubRandProofs(ctx context.Context, in *ExportPubRandProofsRequest, opts ...grpc.CallOption) (FinalityProviders_ExportPubRandProofsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &FinalityProviders_ServiceDesc.Streams[1], FinalityProviders_ExportPubRandProofs_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &finalityProvidersExportPubRandProofsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FinalityProviders_ExportPubRandProofsClient interface {
+	Recv() (*PubRandProof, error)
+	grpc.ClientStream
+}
+
+type finalityProvidersExportPubRandProofsClient struct {
+	grpc.ClientStream
+}
+
+func (x *finalityProvidersExportPubRandProofsClient) Recv() (*PubRandProof, error) {
+	m := new(PubRandProof)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
 // FinalityProvidersServer is the server API for FinalityProviders service.
@@ -152,25 +217,34 @@ func (c *finalityProvidersClient) EditFinalityProvider(ctx context.Context, in *
 type FinalityProvidersServer interface {
 	// GetInfo returns the information of the daemon
 	GetInfo(context.Context, *GetInfoRequest) (*GetInfoResponse, error)
-	// CreateFinalityProvider generates and saves a finality provider object
-	CreateFinalityProvider(context.Context, *CreateFinalityProviderRequest) (*CreateFinalityProviderResponse, error)
-	// RegisterFinalityProvider sends a transactions to the consumer chain to register a BTC
-	// finality provider
-	RegisterFinalityProvider(context.Context, *RegisterFinalityProviderRequest) (*RegisterFinalityProviderResponse, error)
-	// AddFinalitySignature sends a transactions to the consumer chain to add a Finality
-	// signature for a block
-	AddFinalitySignature(context.Context, *AddFinalitySignatureRequest) (*AddFinalitySignatureResponse, error)
-	// UnjailFinalityProvider sends a transactions to the consumer chain to unjail a given
-	// finality provider
-	UnjailFinalityProvider(context.Context, *UnjailFinalityProviderRequest) (*UnjailFinalityProviderResponse, error)
 	// QueryFinalityProvider queries the finality provider
 	QueryFinalityProvider(context.Context, *QueryFinalityProviderRequest) (*QueryFinalityProviderResponse, error)
 	// QueryFinalityProviderList queries a list of finality providers
 	QueryFinalityProviderList(context.Context, *QueryFinalityProviderListRequest) (*QueryFinalityProviderListResponse, error)
-	// SignMessageFromChainKey signs a message from the chain keyring.
-	SignMessageFromChainKey(context.Context, *SignMessageFromChainKeyRequest) (*SignMessageFromChainKeyResponse, error)
-	// EditFinalityProvider edits finality provider
-	EditFinalityProvider(context.Context, *EditFinalityProviderRequest) (*EmptyResponse, error)
+	// QueryParticipationReport compares the on-chain votes of the given
+	// finality provider over a height window with the local records and
+	// reports the missed votes with their probable reasons
+	QueryParticipationReport(context.Context, *QueryParticipationReportRequest) (*QueryParticipationReportResponse, error)
+	// QueryVotes returns the locally recorded results of the votes of the
+	// given finality provider within a range of heights
+	QueryVotes(context.Context, *QueryVotesRequest) (*QueryVotesResponse, error)
+	// QueryPubRandCommits returns the locally recorded results of the public
+	// randomness commits of the given finality provider
+	QueryPubRandCommits(context.Context, *QueryPubRandCommitsRequest) (*QueryPubRandCommitsResponse, error)
+	// QueryRewards queries the withdrawable rewards of the given finality
+	// provider on the consumer chain
+	QueryRewards(context.Context, *QueryRewardsRequest) (*QueryRewardsResponse, error)
+	// QueryInstanceStatus queries the live status of the running instance of
+	// the given finality provider along with its state on the consumer chain
+	QueryInstanceStatus(context.Context, *QueryInstanceStatusRequest) (*QueryInstanceStatusResponse, error)
+	// SubscribeEvents streams the events of the finality providers, e.g.,
+	// the status changes, the votes, the commits and the critical errors,
+	// as they happen until the client cancels the stream
+	SubscribeEvents(*SubscribeEventsRequest, FinalityProviders_SubscribeEventsServer) error
+	// ExportPubRandProofs streams the proofs of the public randomness of the
+	// given finality provider within a range of heights, in ascending order
+	// of heights
+	ExportPubRandProofs(*ExportPubRandProofsRequest, FinalityProviders_ExportPubRandProofsServer) error
 	mustEmbedUnimplementedFinalityProvidersServer()
 }
 
@@ -181,29 +255,32 @@ type UnimplementedFinalityProvidersServer struct {
 func (UnimplementedFinalityProvidersServer) GetInfo(context.Context, *GetInfoRequest) (*GetInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInfo not implemented")
 }
-func (UnimplementedFinalityProvidersServer) CreateFinalityProvider(context.Context, *CreateFinalityProviderRequest) (*CreateFinalityProviderResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateFinalityProvider not implemented")
-}
-func (UnimplementedFinalityProvidersServer) RegisterFinalityProvider(context.Context, *RegisterFinalityProviderRequest) (*RegisterFinalityProviderResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RegisterFinalityProvider not implemented")
-}
-func (UnimplementedFinalityProvidersServer) AddFinalitySignature(context.Context, *AddFinalitySignatureRequest) (*AddFinalitySignatureResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddFinalitySignature not implemented")
-}
-func (UnimplementedFinalityProvidersServer) UnjailFinalityProvider(context.Context, *UnjailFinalityProviderRequest) (*UnjailFinalityProviderResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UnjailFinalityProvider not implemented")
-}
 func (UnimplementedFinalityProvidersServer) QueryFinalityProvider(context.Context, *QueryFinalityProviderRequest) (*QueryFinalityProviderResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method QueryFinalityProvider not implemented")
 }
 func (UnimplementedFinalityProvidersServer) QueryFinalityProviderList(context.Context, *QueryFinalityProviderListRequest) (*QueryFinalityProviderListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method QueryFinalityProviderList not implemented")
 }
-func (UnimplementedFinalityProvidersServer) SignMessageFromChainKey(context.Context, *SignMessageFromChainKeyRequest) (*SignMessageFromChainKeyResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SignMessageFromChainKey not implemented")
+func (UnimplementedFinalityProvidersServer) QueryParticipationReport(context.Context, *QueryParticipationReportRequest) (*QueryParticipationReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryParticipationReport not implemented")
 }
-func (UnimplementedFinalityProvidersServer) EditFinalityProvider(context.Context, *EditFinalityProviderRequest) (*EmptyResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method EditFinalityProvider not implemented")
+func (UnimplementedFinalityProvidersServer) QueryVotes(context.Context, *QueryVotesRequest) (*QueryVotesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryVotes not implemented")
+}
+func (UnimplementedFinalityProvidersServer) QueryPubRandCommits(context.Context, *QueryPubRandCommitsRequest) (*QueryPubRandCommitsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryPubRandCommits not implemented")
+}
+func (UnimplementedFinalityProvidersServer) QueryRewards(context.Context, *QueryRewardsRequest) (*QueryRewardsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryRewards not implemented")
+}
+func (UnimplementedFinalityProvidersServer) QueryInstanceStatus(context.Context, *QueryInstanceStatusRequest) (*QueryInstanceStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryInstanceStatus not implemented")
+}
+func (UnimplementedFinalityProvidersServer) SubscribeEvents(*SubscribeEventsRequest, FinalityProviders_SubscribeEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeEvents not implemented")
+}
+func (UnimplementedFinalityProvidersServer) ExportPubRandProofs(*ExportPubRandProofsRequest, FinalityProviders_ExportPubRandProofsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportPubRandProofs not implemented")
 }
 func (UnimplementedFinalityProvidersServer) mustEmbedUnimplementedFinalityProvidersServer() {}
 
@@ -236,148 +313,172 @@ func _FinalityProviders_GetInfo_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
-func _FinalityProviders_CreateFinalityProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateFinalityProviderRequest)
+func _FinalityProviders_QueryFinalityProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryFinalityProviderRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FinalityProvidersServer).CreateFinalityProvider(ctx, in)
+		return srv.(FinalityProvidersServer).QueryFinalityProvider(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: FinalityProviders_CreateFinalityProvider_FullMethodName,
+		FullMethod: FinalityProviders_QueryFinalityProvider_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FinalityProvidersServer).CreateFinalityProvider(ctx, req.(*CreateFinalityProviderRequest))
+		return srv.(FinalityProvidersServer).QueryFinalityProvider(ctx, req.(*QueryFinalityProviderRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _FinalityProviders_RegisterFinalityProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RegisterFinalityProviderRequest)
+func _FinalityProviders_QueryFinalityProviderList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryFinalityProviderListRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FinalityProvidersServer).RegisterFinalityProvider(ctx, in)
+		return srv.(FinalityProvidersServer).QueryFinalityProviderList(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: FinalityProviders_RegisterFinalityProvider_FullMethodName,
+		FullMethod: FinalityProviders_QueryFinalityProviderList_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FinalityProvidersServer).RegisterFinalityProvider(ctx, req.(*RegisterFinalityProviderRequest))
+		return srv.(FinalityProvidersServer).QueryFinalityProviderList(ctx, req.(*QueryFinalityProviderListRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _FinalityProviders_AddFinalitySignature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddFinalitySignatureRequest)
+func _FinalityProviders_QueryParticipationReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryParticipationReportRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FinalityProvidersServer).AddFinalitySignature(ctx, in)
+		return srv.(FinalityProvidersServer).QueryParticipationReport(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: FinalityProviders_AddFinalitySignature_FullMethodName,
+		FullMethod: FinalityProviders_QueryParticipationReport_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FinalityProvidersServer).AddFinalitySignature(ctx, req.(*AddFinalitySignatureRequest))
+		return srv.(FinalityProvidersServer).QueryParticipationReport(ctx, req.(*QueryParticipationReportRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _FinalityProviders_UnjailFinalityProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UnjailFinalityProviderRequest)
+func _FinalityProviders_QueryVotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryVotesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FinalityProvidersServer).UnjailFinalityProvider(ctx, in)
+		return srv.(FinalityProvidersServer).QueryVotes(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: FinalityProviders_UnjailFinalityProvider_FullMethodName,
+		FullMethod: FinalityProviders_QueryVotes_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FinalityProvidersServer).UnjailFinalityProvider(ctx, req.(*UnjailFinalityProviderRequest))
+		return srv.(FinalityProvidersServer).QueryVotes(ctx, req.(*QueryVotesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _FinalityProviders_QueryFinalityProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(QueryFinalityProviderRequest)
+func _FinalityProviders_QueryPubRandCommits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryPubRandCommitsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FinalityProvidersServer).QueryFinalityProvider(ctx, in)
+		return srv.(FinalityProvidersServer).QueryPubRandCommits(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: FinalityProviders_QueryFinalityProvider_FullMethodName,
+		FullMethod: FinalityProviders_QueryPubRandCommits_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FinalityProvidersServer).QueryFinalityProvider(ctx, req.(*QueryFinalityProviderRequest))
+		return srv.(FinalityProvidersServer).QueryPubRandCommits(ctx, req.(*QueryPubRandCommitsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _FinalityProviders_QueryFinalityProviderList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(QueryFinalityProviderListRequest)
+func _FinalityProviders_QueryRewards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryRewardsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FinalityProvidersServer).QueryFinalityProviderList(ctx, in)
+		return srv.(FinalityProvidersServer).QueryRewards(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: FinalityProviders_QueryFinalityProviderList_FullMethodName,
+		FullMethod: FinalityProviders_QueryRewards_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FinalityProvidersServer).QueryFinalityProviderList(ctx, req.(*QueryFinalityProviderListRequest))
+		return srv.(FinalityProvidersServer).QueryRewards(ctx, req.(*QueryRewardsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _FinalityProviders_SignMessageFromChainKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SignMessageFromChainKeyRequest)
+func _FinalityProviders_QueryInstanceStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryInstanceStatusRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FinalityProvidersServer).SignMessageFromChainKey(ctx, in)
+		return srv.(FinalityProvidersServer).QueryInstanceStatus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: FinalityProviders_SignMessageFromChainKey_FullMethodName,
+		FullMethod: FinalityProviders_QueryInstanceStatus_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FinalityProvidersServer).SignMessageFromChainKey(ctx, req.(*SignMessageFromChainKeyRequest))
+		return srv.(FinalityProvidersServer).QueryInstanceStatus(ctx, req.(*QueryInstanceStatusRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _FinalityProviders_EditFinalityProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EditFinalityProviderRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(FinalityProvidersServer).EditFinalityProvider(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: FinalityProviders_EditFinalityProvider_FullMethodName,
+func _FinalityProviders_SubscribeEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FinalityProvidersServer).EditFinalityProvider(ctx, req.(*EditFinalityProviderRequest))
+	return srv.(FinalityProvidersServer).SubscribeEvents(m, &finalityProvidersSubscribeEventsServer{stream})
+}
+
+type FinalityProviders_SubscribeEventsServer interface {
+	Send(*FinalityProviderEvent) error
+	grpc.ServerStream
+}
+
+type finalityProvidersSubscribeEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *finalityProvidersSubscribeEventsServer) Send(m *FinalityProviderEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _FinalityProviders_ExportPubRandProofs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportPubRandProofsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return interceptor(ctx, in, info, handler)
+	return srv.(FinalityProvidersServer).ExportPubRandProofs(m, &finalityProvidersExportPubRandProofsServer{stream})
+}
+
+type FinalityProviders_ExportPubRandProofsServer interface {
+	Send(*PubRandProof) error
+	grpc.ServerStream
+}
+
+type finalityProvidersExportPubRandProofsServer struct {
+	grpc.ServerStream
+}
+
+func (x *finalityProvidersExportPubRandProofsServer) Send(m *PubRandProof) error {
+	return x.ServerStream.SendMsg(m)
 }
 
 // FinalityProviders_ServiceDesc is the grpc.ServiceDesc for FinalityProviders service.
@@ -392,38 +493,951 @@ var FinalityProviders_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _FinalityProviders_GetInfo_Handler,
 		},
 		{
-			MethodName: "CreateFinalityProvider",
-			Handler:    _FinalityProviders_CreateFinalityProvider_Handler,
+			MethodName: "QueryFinalityProvider",
+			Handler:    _FinalityProviders_QueryFinalityProvider_Handler,
 		},
 		{
-			MethodName: "RegisterFinalityProvider",
-			Handler:    _FinalityProviders_RegisterFinalityProvider_Handler,
+			MethodName: "QueryFinalityProviderList",
+			Handler:    _FinalityProviders_QueryFinalityProviderList_Handler,
 		},
 		{
-			MethodName: "AddFinalitySignature",
-			Handler:    _FinalityProviders_AddFinalitySignature_Handler,
+			MethodName: "QueryParticipationReport",
+			Handler:    _FinalityProviders_QueryParticipationReport_Handler,
 		},
 		{
-			MethodName: "UnjailFinalityProvider",
-			Handler:    _FinalityProviders_UnjailFinalityProvider_Handler,
+			MethodName: "QueryVotes",
+			Handler:    _FinalityProviders_QueryVotes_Handler,
 		},
 		{
-			MethodName: "QueryFinalityProvider",
-			Handler:    _FinalityProviders_QueryFinalityProvider_Handler,
+			MethodName: "QueryPubRandCommits",
+			Handler:    _FinalityProviders_QueryPubRandCommits_Handler,
 		},
 		{
-			MethodName: "QueryFinalityProviderList",
-			Handler:    _FinalityProviders_QueryFinalityProviderList_Handler,
+			MethodName: "QueryRewards",
+			Handler:    _FinalityProviders_QueryRewards_Handler,
 		},
 		{
-			MethodName: "SignMessageFromChainKey",
-			Handler:    _FinalityProviders_SignMessageFromChainKey_Handler,
+			MethodName: "QueryInstanceStatus",
+			Handler:    _FinalityProviders_QueryInstanceStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeEvents",
+			Handler:       _FinalityProviders_SubscribeEvents_Handler,
+			ServerStreams: true,
 		},
 		{
-			MethodName: "EditFinalityProvider",
-			Handler:    _FinalityProviders_EditFinalityProvider_Handler,
+			StreamName:    "ExportPubRandProofs",
+			Handler:       _FinalityProviders_ExportPubRandProofs_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "finality_providers.proto",
+}
+
+const (
+	FinalityProvidersAdmin_CreateFinalityProvider_FullMethodName   = "/proto.FinalityProvidersAdmin/CreateFinalityProvider"
+	FinalityProvidersAdmin_RegisterFinalityProvider_FullMethodName = "/proto.FinalityProvidersAdmin/RegisterFinalityProvider"
+	FinalityProvidersAdmin_AddFinalitySignature_FullMethodName     = "/proto.FinalityProvidersAdmin/AddFinalitySignature"
+	FinalityProvidersAdmin_UnjailFinalityProvider_FullMethodName   = "/proto.FinalityProvidersAdmin/UnjailFinalityProvider"
+	FinalityProvidersAdmin_SignMessageFromChainKey_FullMethodName  = "/proto.FinalityProvidersAdmin/SignMessageFromChainKey"
+	FinalityProvidersAdmin_EditFinalityProvider_FullMethodName     = "/proto.FinalityProvidersAdmin/EditFinalityProvider"
+	FinalityProvidersAdmin_StartFinalityProvider_FullMethodName    = "/proto.FinalityProvidersAdmin/StartFinalityProvider"
+	FinalityProvidersAdmin_StopFinalityProvider_FullMethodName     = "/proto.FinalityProvidersAdmin/StopFinalityProvider"
+	FinalityProvidersAdmin_PauseFinalityProvider_FullMethodName    = "/proto.FinalityProvidersAdmin/PauseFinalityProvider"
+	FinalityProvidersAdmin_ResumeFinalityProvider_FullMethodName   = "/proto.FinalityProvidersAdmin/ResumeFinalityProvider"
+	FinalityProvidersAdmin_ReloadConfig_FullMethodName             = "/proto.FinalityProvidersAdmin/ReloadConfig"
+	FinalityProvidersAdmin_WithdrawRewards_FullMethodName          = "/proto.FinalityProvidersAdmin/WithdrawRewards"
+	FinalityProvidersAdmin_UpdateCommission_FullMethodName         = "/proto.FinalityProvidersAdmin/UpdateCommission"
+	FinalityProvidersAdmin_EnterMaintenance_FullMethodName         = "/proto.FinalityProvidersAdmin/EnterMaintenance"
+	FinalityProvidersAdmin_ExitMaintenance_FullMethodName          = "/proto.FinalityProvidersAdmin/ExitMaintenance"
+	FinalityProvidersAdmin_CommitPubRand_FullMethodName            = "/proto.FinalityProvidersAdmin/CommitPubRand"
+	FinalityProvidersAdmin_ExportState_FullMethodName              = "/proto.FinalityProvidersAdmin/ExportState"
+	FinalityProvidersAdmin_SignSchnorrMessage_FullMethodName       = "/proto.FinalityProvidersAdmin/SignSchnorrMessage"
+	FinalityProvidersAdmin_SetLogLevel_FullMethodName              = "/proto.FinalityProvidersAdmin/SetLogLevel"
+	FinalityProvidersAdmin_Doctor_FullMethodName                   = "/proto.FinalityProvidersAdmin/Doctor"
+)
+
+// FinalityProvidersAdminClient is the client API for FinalityProvidersAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FinalityProvidersAdminClient interface {
+	// CreateFinalityProvider generates and saves a finality provider object
+	CreateFinalityProvider(ctx context.Context, in *CreateFinalityProviderRequest, opts ...grpc.CallOption) (*CreateFinalityProviderResponse, error)
+	// RegisterFinalityProvider sends a transactions to the consumer chain to register a BTC
+	// finality provider
+	RegisterFinalityProvider(ctx context.Context, in *RegisterFinalityProviderRequest, opts ...grpc.CallOption) (*RegisterFinalityProviderResponse, error)
+	// AddFinalitySignature sends a transactions to the consumer chain to add a Finality
+	// signature for a block
+	AddFinalitySignature(ctx context.Context, in *AddFinalitySignatureRequest, opts ...grpc.CallOption) (*AddFinalitySignatureResponse, error)
+	// UnjailFinalityProvider sends a transactions to the consumer chain to unjail a given
+	// finality provider
+	UnjailFinalityProvider(ctx context.Context, in *UnjailFinalityProviderRequest, opts ...grpc.CallOption) (*UnjailFinalityProviderResponse, error)
+	// SignMessageFromChainKey signs a message from the chain keyring.
+	SignMessageFromChainKey(ctx context.Context, in *SignMessageFromChainKeyRequest, opts ...grpc.CallOption) (*SignMessageFromChainKeyResponse, error)
+	// EditFinalityProvider edits finality provider
+	EditFinalityProvider(ctx context.Context, in *EditFinalityProviderRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	// StartFinalityProvider starts the instance of the given finality provider
+	// alongside the ones already running in the daemon
+	StartFinalityProvider(ctx context.Context, in *StartFinalityProviderRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	// StopFinalityProvider stops the instance of the given finality provider,
+	// leaving the daemon and the other instances running
+	StopFinalityProvider(ctx context.Context, in *StopFinalityProviderRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	// PauseFinalityProvider stops the given finality provider from voting
+	// and committing randomness while keeping its status sync and metrics
+	PauseFinalityProvider(ctx context.Context, in *PauseFinalityProviderRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	// ResumeFinalityProvider resumes the voting of the given paused finality
+	// provider
+	ResumeFinalityProvider(ctx context.Context, in *ResumeFinalityProviderRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	// ReloadConfig re-reads the config file of the daemon and applies
+	// the changes of the reloadable fields without restarting the
+	// finality provider instances
+	ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigResponse, error)
+	// WithdrawRewards withdraws the accumulated finality provider rewards,
+	// of the given finality provider if any, and sends them to the recipient
+	WithdrawRewards(ctx context.Context, in *WithdrawRewardsRequest, opts ...grpc.CallOption) (*WithdrawRewardsResponse, error)
+	// UpdateCommission sends a transaction to the consumer chain to update
+	// the commission rate of a given finality provider
+	UpdateCommission(ctx context.Context, in *UpdateCommissionRequest, opts ...grpc.CallOption) (*UpdateCommissionResponse, error)
+	// EnterMaintenance freezes the chain submissions and the db writes of
+	// the daemon, e.g., for a backup or a compaction of the db, while the
+	// queries, the metrics and the health checks keep being served
+	EnterMaintenance(ctx context.Context, in *EnterMaintenanceRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	// ExitMaintenance resumes the chain submissions and the db writes
+	// frozen by EnterMaintenance
+	ExitMaintenance(ctx context.Context, in *ExitMaintenanceRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	// CommitPubRand commits the public randomness of the given finality
+	// provider until the target height right away, without waiting for the
+	// randomness commitment loop
+	CommitPubRand(ctx context.Context, in *CommitPubRandRequest, opts ...grpc.CallOption) (*CommitPubRandResponse, error)
+	// ExportState streams the backup bundle of the db of the daemon,
+	// encrypted with the given passphrase, as a sequence of chunks
+	ExportState(ctx context.Context, in *ExportStateRequest, opts ...grpc.CallOption) (FinalityProvidersAdmin_ExportStateClient, error)
+	// SignSchnorrMessage signs an arbitrary message with the EOTS key of a
+	// finality provider, prefixed with the domain separation prefix, so
+	// that the identity of the finality provider can be proven off-chain
+	SignSchnorrMessage(ctx context.Context, in *SignSchnorrMessageRequest, opts ...grpc.CallOption) (*SignSchnorrMessageResponse, error)
+	// SetLogLevel changes the log level of the daemon, or of one of its
+	// modules, at runtime until the daemon is restarted
+	SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error)
+	// Doctor runs the preflight checks of the connectivity and the
+	// configuration of the daemon on demand, with a remediation hint for
+	// each check which does not pass
+	Doctor(ctx context.Context, in *DoctorRequest, opts ...grpc.CallOption) (*DoctorResponse, error)
+}
+
+type finalityProvidersAdminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFinalityProvidersAdminClient(cc grpc.ClientConnInterface) FinalityProvidersAdminClient {
+	return &finalityProvidersAdminClient{cc}
+}
+
+func (c *finalityProvidersAdminClient) CreateFinalityProvider(ctx context.Context, in *CreateFinalityProviderRequest, opts ...grpc.CallOption) (*CreateFinalityProviderResponse, error) {
+	out := new(CreateFinalityProviderResponse)
+	err := c.cc.Invoke(ctx, FinalityProvidersAdmin_CreateFinalityProvider_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersAdminClient) RegisterFinalityProvider(ctx context.Context, in *RegisterFinalityProviderRequest, opts ...grpc.CallOption) (*RegisterFinalityProviderResponse, error) {
+	out := new(RegisterFinalityProviderResponse)
+	err := c.cc.Invoke(ctx, FinalityProvidersAdmin_RegisterFinalityProvider_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersAdminClient) AddFinalitySignature(ctx context.Context, in *AddFinalitySignatureRequest, opts ...grpc.CallOption) (*AddFinalitySignatureResponse, error) {
+	out := new(AddFinalitySignatureResponse)
+	err := c.cc.Invoke(ctx, FinalityProvidersAdmin_AddFinalitySignature_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersAdminClient) UnjailFinalityProvider(ctx context.Context, in *UnjailFinalityProviderRequest, opts ...grpc.CallOption) (*UnjailFinalityProviderResponse, error) {
+	out := new(UnjailFinalityProviderResponse)
+	err := c.cc.Invoke(ctx, FinalityProvidersAdmin_UnjailFinalityProvider_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersAdminClient) SignMessageFromChainKey(ctx context.Context, in *SignMessageFromChainKeyRequest, opts ...grpc.CallOption) (*SignMessageFromChainKeyResponse, error) {
+	out := new(SignMessageFromChainKeyResponse)
+	err := c.cc.Invoke(ctx, FinalityProvidersAdmin_SignMessageFromChainKey_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersAdminClient) EditFinalityProvider(ctx context.Context, in *EditFinalityProviderRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, FinalityProvidersAdmin_EditFinalityProvider_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersAdminClient) StartFinalityProvider(ctx context.Context, in *StartFinalityProviderRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, FinalityProvidersAdmin_StartFinalityProvider_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersAdminClient) StopFinalityProvider(ctx context.Context, in *StopFinalityProviderRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, FinalityProvidersAdmin_StopFinalityProvider_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersAdminClient) PauseFinalityProvider(ctx context.Context, in *PauseFinalityProviderRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, FinalityProvidersAdmin_PauseFinalityProvider_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersAdminClient) ResumeFinalityProvider(ctx context.Context, in *ResumeFinalityProviderRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, FinalityProvidersAdmin_ResumeFinalityProvider_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersAdminClient) ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigResponse, error) {
+	out := new(ReloadConfigResponse)
+	err := c.cc.Invoke(ctx, FinalityProvidersAdmin_ReloadConfig_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersAdminClient) WithdrawRewards(ctx context.Context, in *WithdrawRewardsRequest, opts ...grpc.CallOption) (*WithdrawRewardsResponse, error) {
+	out := new(WithdrawRewardsResponse)
+	err := c.cc.Invoke(ctx, FinalityProvidersAdmin_WithdrawRewards_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersAdminClient) UpdateCommission(ctx context.Context, in *UpdateCommissionRequest, opts ...grpc.CallOption) (*UpdateCommissionResponse, error) {
+	out := new(UpdateCommissionResponse)
+	err := c.cc.Invoke(ctx, FinalityProvidersAdmin_UpdateCommission_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersAdminClient) EnterMaintenance(ctx context.Context, in *EnterMaintenanceRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, FinalityProvidersAdmin_EnterMaintenance_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersAdminClient) ExitMaintenance(ctx context.Context, in *ExitMaintenanceRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, FinalityProvidersAdmin_ExitMaintenance_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersAdminClient) CommitPubRand(ctx context.Context, in *CommitPubRandRequest, opts ...grpc.CallOption) (*CommitPubRandResponse, error) {
+	out := new(CommitPubRandResponse)
+	err := c.cc.Invoke(ctx, FinalityProvidersAdmin_CommitPubRand_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersAdminClient) ExportState(ctx context.Context, in *ExportStateRequest, opts ...grpc.CallOption) (FinalityProvidersAdmin_ExportStateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &FinalityProvidersAdmin_ServiceDesc.Streams[0], FinalityProvidersAdmin_ExportState_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &finalityProvidersAdminExportStateClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FinalityProvidersAdmin_ExportStateClient interface {
+	Recv() (*ExportStateResponse, error)
+	grpc.ClientStream
+}
+
+type finalityProvidersAdminExportStateClient struct {
+	grpc.ClientStream
+}
+
+func (x *finalityProvidersAdminExportStateClient) Recv() (*ExportStateResponse, error) {
+	m := new(ExportStateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *finalityProvidersAdminClient) SignSchnorrMessage(ctx context.Context, in *SignSchnorrMessageRequest, opts ...grpc.CallOption) (*SignSchnorrMessageResponse, error) {
+	out := new(SignSchnorrMessageResponse)
+	err := c.cc.Invoke(ctx, FinalityProvidersAdmin_SignSchnorrMessage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersAdminClient) SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error) {
+	out := new(SetLogLevelResponse)
+	err := c.cc.Invoke(ctx, FinalityProvidersAdmin_SetLogLevel_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersAdminClient) Doctor(ctx context.Context, in *DoctorRequest, opts ...grpc.CallOption) (*DoctorResponse, error) {
+	out := new(DoctorResponse)
+	err := c.cc.Invoke(ctx, FinalityProvidersAdmin_Doctor_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FinalityProvidersAdminServer is the server API for FinalityProvidersAdmin service.
+// All implementations must embed UnimplementedFinalityProvidersAdminServer
+// for forward compatibility
+type FinalityProvidersAdminServer interface {
+	// CreateFinalityProvider generates and saves a finality provider object
+	CreateFinalityProvider(context.Context, *CreateFinalityProviderRequest) (*CreateFinalityProviderResponse, error)
+	// RegisterFinalityProvider sends a transactions to the consumer chain to register a BTC
+	// finality provider
+	RegisterFinalityProvider(context.Context, *RegisterFinalityProviderRequest) (*RegisterFinalityProviderResponse, error)
+	// AddFinalitySignature sends a transactions to the consumer chain to add a Finality
+	// signature for a block
+	AddFinalitySignature(context.Context, *AddFinalitySignatureRequest) (*AddFinalitySignatureResponse, error)
+	// UnjailFinalityProvider sends a transactions to the consumer chain to unjail a given
+	// finality provider
+	UnjailFinalityProvider(context.Context, *UnjailFinalityProviderRequest) (*UnjailFinalityProviderResponse, error)
+	// SignMessageFromChainKey signs a message from the chain keyring.
+	SignMessageFromChainKey(context.Context, *SignMessageFromChainKeyRequest) (*SignMessageFromChainKeyResponse, error)
+	// EditFinalityProvider edits finality provider
+	EditFinalityProvider(context.Context, *EditFinalityProviderRequest) (*EmptyResponse, error)
+	// StartFinalityProvider starts the instance of the given finality provider
+	// alongside the ones already running in the daemon
+	StartFinalityProvider(context.Context, *StartFinalityProviderRequest) (*EmptyResponse, error)
+	// StopFinalityProvider stops the instance of the given finality provider,
+	// leaving the daemon and the other instances running
+	StopFinalityProvider(context.Context, *StopFinalityProviderRequest) (*EmptyResponse, error)
+	// PauseFinalityProvider stops the given finality provider from voting
+	// and committing randomness while keeping its status sync and metrics
+	PauseFinalityProvider(context.Context, *PauseFinalityProviderRequest) (*EmptyResponse, error)
+	// ResumeFinalityProvider resumes the voting of the given paused finality
+	// provider
+	ResumeFinalityProvider(context.Context, *ResumeFinalityProviderRequest) (*EmptyResponse, error)
+	// ReloadConfig re-reads the config file of the daemon and applies
+	// the changes of the reloadable fields without restarting the
+	// finality provider instances
+	ReloadConfig(context.Context, *ReloadConfigRequest) (*ReloadConfigResponse, error)
+	// WithdrawRewards withdraws the accumulated finality provider rewards,
+	// of the given finality provider if any, and sends them to the recipient
+	WithdrawRewards(context.Context, *WithdrawRewardsRequest) (*WithdrawRewardsResponse, error)
+	// UpdateCommission sends a transaction to the consumer chain to update
+	// the commission rate of a given finality provider
+	UpdateCommission(context.Context, *UpdateCommissionRequest) (*UpdateCommissionResponse, error)
+	// EnterMaintenance freezes the chain submissions and the db writes of
+	// the daemon, e.g., for a backup or a compaction of the db, while the
+	// queries, the metrics and the health checks keep being served
+	EnterMaintenance(context.Context, *EnterMaintenanceRequest) (*EmptyResponse, error)
+	// ExitMaintenance resumes the chain submissions and the db writes
+	// frozen by EnterMaintenance
+	ExitMaintenance(context.Context, *ExitMaintenanceRequest) (*EmptyResponse, error)
+	// CommitPubRand commits the public randomness of the given finality
+	// provider until the target height right away, without waiting for the
+	// randomness commitment loop
+	CommitPubRand(context.Context, *CommitPubRandRequest) (*CommitPubRandResponse, error)
+	// ExportState streams the backup bundle of the db of the daemon,
+	// encrypted with the given passphrase, as a sequence of chunks
+	ExportState(*ExportStateRequest, FinalityProvidersAdmin_ExportStateServer) error
+	// SignSchnorrMessage signs an arbitrary message with the EOTS key of a
+	// finality provider, prefixed with the domain separation prefix, so
+	// that the identity of the finality provider can be proven off-chain
+	SignSchnorrMessage(context.Context, *SignSchnorrMessageRequest) (*SignSchnorrMessageResponse, error)
+	// SetLogLevel changes the log level of the daemon, or of one of its
+	// modules, at runtime until the daemon is restarted
+	SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error)
+	// Doctor runs the preflight checks of the connectivity and the
+	// configuration of the daemon on demand, with a remediation hint for
+	// each check which does not pass
+	Doctor(context.Context, *DoctorRequest) (*DoctorResponse, error)
+	mustEmbedUnimplementedFinalityProvidersAdminServer()
+}
+
+// UnimplementedFinalityProvidersAdminServer must be embedded to have forward compatible implementations.
+type UnimplementedFinalityProvidersAdminServer struct {
+}
+
+func (UnimplementedFinalityProvidersAdminServer) CreateFinalityProvider(context.Context, *CreateFinalityProviderRequest) (*CreateFinalityProviderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFinalityProvider not implemented")
+}
+func (UnimplementedFinalityProvidersAdminServer) RegisterFinalityProvider(context.Context, *RegisterFinalityProviderRequest) (*RegisterFinalityProviderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterFinalityProvider not implemented")
+}
+func (UnimplementedFinalityProvidersAdminServer) AddFinalitySignature(context.Context, *AddFinalitySignatureRequest) (*AddFinalitySignatureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddFinalitySignature not implemented")
+}
+func (UnimplementedFinalityProvidersAdminServer) UnjailFinalityProvider(context.Context, *UnjailFinalityProviderRequest) (*UnjailFinalityProviderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnjailFinalityProvider not implemented")
+}
+func (UnimplementedFinalityProvidersAdminServer) SignMessageFromChainKey(context.Context, *SignMessageFromChainKeyRequest) (*SignMessageFromChainKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignMessageFromChainKey not implemented")
+}
+func (UnimplementedFinalityProvidersAdminServer) EditFinalityProvider(context.Context, *EditFinalityProviderRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditFinalityProvider not implemented")
+}
+func (UnimplementedFinalityProvidersAdminServer) StartFinalityProvider(context.Context, *StartFinalityProviderRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartFinalityProvider not implemented")
+}
+func (UnimplementedFinalityProvidersAdminServer) StopFinalityProvider(context.Context, *StopFinalityProviderRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopFinalityProvider not implemented")
+}
+func (UnimplementedFinalityProvidersAdminServer) PauseFinalityProvider(context.Context, *PauseFinalityProviderRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseFinalityProvider not implemented")
+}
+func (UnimplementedFinalityProvidersAdminServer) ResumeFinalityProvider(context.Context, *ResumeFinalityProviderRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeFinalityProvider not implemented")
+}
+func (UnimplementedFinalityProvidersAdminServer) ReloadConfig(context.Context, *ReloadConfigRequest) (*ReloadConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadConfig not implemented")
+}
+func (UnimplementedFinalityProvidersAdminServer) WithdrawRewards(context.Context, *WithdrawRewardsRequest) (*WithdrawRewardsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WithdrawRewards not implemented")
+}
+func (UnimplementedFinalityProvidersAdminServer) UpdateCommission(context.Context, *UpdateCommissionRequest) (*UpdateCommissionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCommission not implemented")
+}
+func (UnimplementedFinalityProvidersAdminServer) EnterMaintenance(context.Context, *EnterMaintenanceRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnterMaintenance not implemented")
+}
+func (UnimplementedFinalityProvidersAdminServer) ExitMaintenance(context.Context, *ExitMaintenanceRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExitMaintenance not implemented")
+}
+func (UnimplementedFinalityProvidersAdminServer) CommitPubRand(context.Context, *CommitPubRandRequest) (*CommitPubRandResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommitPubRand not implemented")
+}
+func (UnimplementedFinalityProvidersAdminServer) ExportState(*ExportStateRequest, FinalityProvidersAdmin_ExportStateServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportState not implemented")
+}
+func (UnimplementedFinalityProvidersAdminServer) SignSchnorrMessage(context.Context, *SignSchnorrMessageRequest) (*SignSchnorrMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignSchnorrMessage not implemented")
+}
+func (UnimplementedFinalityProvidersAdminServer) SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLogLevel not implemented")
+}
+func (UnimplementedFinalityProvidersAdminServer) Doctor(context.Context, *DoctorRequest) (*DoctorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Doctor not implemented")
+}
+func (UnimplementedFinalityProvidersAdminServer) mustEmbedUnimplementedFinalityProvidersAdminServer() {
+}
+
+// UnsafeFinalityProvidersAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FinalityProvidersAdminServer will
+// result in compilation errors.
+type UnsafeFinalityProvidersAdminServer interface {
+	mustEmbedUnimplementedFinalityProvidersAdminServer()
+}
+
+func RegisterFinalityProvidersAdminServer(s grpc.ServiceRegistrar, srv FinalityProvidersAdminServer) {
+	s.RegisterService(&FinalityProvidersAdmin_ServiceDesc, srv)
+}
+
+func _FinalityProvidersAdmin_CreateFinalityProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFinalityProviderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersAdminServer).CreateFinalityProvider(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProvidersAdmin_CreateFinalityProvider_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersAdminServer).CreateFinalityProvider(ctx, req.(*CreateFinalityProviderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProvidersAdmin_RegisterFinalityProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterFinalityProviderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersAdminServer).RegisterFinalityProvider(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProvidersAdmin_RegisterFinalityProvider_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersAdminServer).RegisterFinalityProvider(ctx, req.(*RegisterFinalityProviderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProvidersAdmin_AddFinalitySignature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddFinalitySignatureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersAdminServer).AddFinalitySignature(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProvidersAdmin_AddFinalitySignature_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersAdminServer).AddFinalitySignature(ctx, req.(*AddFinalitySignatureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProvidersAdmin_UnjailFinalityProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnjailFinalityProviderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersAdminServer).UnjailFinalityProvider(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProvidersAdmin_UnjailFinalityProvider_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersAdminServer).UnjailFinalityProvider(ctx, req.(*UnjailFinalityProviderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProvidersAdmin_SignMessageFromChainKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignMessageFromChainKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersAdminServer).SignMessageFromChainKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProvidersAdmin_SignMessageFromChainKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersAdminServer).SignMessageFromChainKey(ctx, req.(*SignMessageFromChainKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProvidersAdmin_EditFinalityProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditFinalityProviderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersAdminServer).EditFinalityProvider(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProvidersAdmin_EditFinalityProvider_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersAdminServer).EditFinalityProvider(ctx, req.(*EditFinalityProviderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProvidersAdmin_StartFinalityProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartFinalityProviderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersAdminServer).StartFinalityProvider(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProvidersAdmin_StartFinalityProvider_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersAdminServer).StartFinalityProvider(ctx, req.(*StartFinalityProviderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProvidersAdmin_StopFinalityProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopFinalityProviderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersAdminServer).StopFinalityProvider(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProvidersAdmin_StopFinalityProvider_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersAdminServer).StopFinalityProvider(ctx, req.(*StopFinalityProviderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProvidersAdmin_PauseFinalityProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseFinalityProviderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersAdminServer).PauseFinalityProvider(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProvidersAdmin_PauseFinalityProvider_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersAdminServer).PauseFinalityProvider(ctx, req.(*PauseFinalityProviderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProvidersAdmin_ResumeFinalityProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeFinalityProviderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersAdminServer).ResumeFinalityProvider(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProvidersAdmin_ResumeFinalityProvider_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersAdminServer).ResumeFinalityProvider(ctx, req.(*ResumeFinalityProviderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProvidersAdmin_ReloadConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersAdminServer).ReloadConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProvidersAdmin_ReloadConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersAdminServer).ReloadConfig(ctx, req.(*ReloadConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProvidersAdmin_WithdrawRewards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WithdrawRewardsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersAdminServer).WithdrawRewards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProvidersAdmin_WithdrawRewards_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersAdminServer).WithdrawRewards(ctx, req.(*WithdrawRewardsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProvidersAdmin_UpdateCommission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCommissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersAdminServer).UpdateCommission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProvidersAdmin_UpdateCommission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersAdminServer).UpdateCommission(ctx, req.(*UpdateCommissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProvidersAdmin_EnterMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnterMaintenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersAdminServer).EnterMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProvidersAdmin_EnterMaintenance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersAdminServer).EnterMaintenance(ctx, req.(*EnterMaintenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProvidersAdmin_ExitMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExitMaintenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersAdminServer).ExitMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProvidersAdmin_ExitMaintenance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersAdminServer).ExitMaintenance(ctx, req.(*ExitMaintenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProvidersAdmin_CommitPubRand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitPubRandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersAdminServer).CommitPubRand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProvidersAdmin_CommitPubRand_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersAdminServer).CommitPubRand(ctx, req.(*CommitPubRandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProvidersAdmin_ExportState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportStateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FinalityProvidersAdminServer).ExportState(m, &finalityProvidersAdminExportStateServer{stream})
+}
+
+type FinalityProvidersAdmin_ExportStateServer interface {
+	Send(*ExportStateResponse) error
+	grpc.ServerStream
+}
+
+type finalityProvidersAdminExportStateServer struct {
+	grpc.ServerStream
+}
+
+func (x *finalityProvidersAdminExportStateServer) Send(m *ExportStateResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _FinalityProvidersAdmin_SignSchnorrMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignSchnorrMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersAdminServer).SignSchnorrMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProvidersAdmin_SignSchnorrMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersAdminServer).SignSchnorrMessage(ctx, req.(*SignSchnorrMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProvidersAdmin_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLogLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersAdminServer).SetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProvidersAdmin_SetLogLevel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersAdminServer).SetLogLevel(ctx, req.(*SetLogLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProvidersAdmin_Doctor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DoctorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersAdminServer).Doctor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProvidersAdmin_Doctor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersAdminServer).Doctor(ctx, req.(*DoctorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FinalityProvidersAdmin_ServiceDesc is the grpc.ServiceDesc for FinalityProvidersAdmin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FinalityProvidersAdmin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.FinalityProvidersAdmin",
+	HandlerType: (*FinalityProvidersAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateFinalityProvider",
+			Handler:    _FinalityProvidersAdmin_CreateFinalityProvider_Handler,
+		},
+		{
+			MethodName: "RegisterFinalityProvider",
+			Handler:    _FinalityProvidersAdmin_RegisterFinalityProvider_Handler,
+		},
+		{
+			MethodName: "AddFinalitySignature",
+			Handler:    _FinalityProvidersAdmin_AddFinalitySignature_Handler,
+		},
+		{
+			MethodName: "UnjailFinalityProvider",
+			Handler:    _FinalityProvidersAdmin_UnjailFinalityProvider_Handler,
+		},
+		{
+			MethodName: "SignMessageFromChainKey",
+			Handler:    _FinalityProvidersAdmin_SignMessageFromChainKey_Handler,
+		},
+		{
+			MethodName: "EditFinalityProvider",
+			Handler:    _FinalityProvidersAdmin_EditFinalityProvider_Handler,
+		},
+		{
+			MethodName: "StartFinalityProvider",
+			Handler:    _FinalityProvidersAdmin_StartFinalityProvider_Handler,
+		},
+		{
+			MethodName: "StopFinalityProvider",
+			Handler:    _FinalityProvidersAdmin_StopFinalityProvider_Handler,
+		},
+		{
+			MethodName: "PauseFinalityProvider",
+			Handler:    _FinalityProvidersAdmin_PauseFinalityProvider_Handler,
+		},
+		{
+			MethodName: "ResumeFinalityProvider",
+			Handler:    _FinalityProvidersAdmin_ResumeFinalityProvider_Handler,
+		},
+		{
+			MethodName: "ReloadConfig",
+			Handler:    _FinalityProvidersAdmin_ReloadConfig_Handler,
+		},
+		{
+			MethodName: "WithdrawRewards",
+			Handler:    _FinalityProvidersAdmin_WithdrawRewards_Handler,
+		},
+		{
+			MethodName: "UpdateCommission",
+			Handler:    _FinalityProvidersAdmin_UpdateCommission_Handler,
+		},
+		{
+			MethodName: "EnterMaintenance",
+			Handler:    _FinalityProvidersAdmin_EnterMaintenance_Handler,
+		},
+		{
+			MethodName: "ExitMaintenance",
+			Handler:    _FinalityProvidersAdmin_ExitMaintenance_Handler,
+		},
+		{
+			MethodName: "CommitPubRand",
+			Handler:    _FinalityProvidersAdmin_CommitPubRand_Handler,
+		},
+		{
+			MethodName: "SignSchnorrMessage",
+			Handler:    _FinalityProvidersAdmin_SignSchnorrMessage_Handler,
+		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    _FinalityProvidersAdmin_SetLogLevel_Handler,
+		},
+		{
+			MethodName: "Doctor",
+			Handler:    _FinalityProvidersAdmin_Doctor_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportState",
+			Handler:       _FinalityProvidersAdmin_ExportState_Handler,
+			ServerStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "finality_providers.proto",
 }
@@ -0,0 +1,10 @@
+package proto
+
+import (
+	_ "embed"
+)
+
+// OpenAPISpec is the OpenAPI spec of the REST gateway of the daemon
+//
+//go:embed finality_providers.swagger.json
+var OpenAPISpec []byte
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// skipBeforeActivation fast-forwards the given start height to the heights
+// the finality provider can vote for, i.e., no lower than the finality
+// activation height of the chain params and, if the finality provider has
+// never voted, no lower than the first height at which it has voting power.
+// Otherwise, each block before the activation would be queried for the
+// voting power only to be skipped
+func (fp *FinalityProviderInstance) skipBeforeActivation(startHeight uint64) (uint64, error) {
+	finalityActivationHeight, err := fp.getFinalityActivationHeightWithRetry()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get finality activation height: %w", err)
+	}
+	if startHeight < finalityActivationHeight {
+		fp.logger.Info("fast-forwarding to the finality activation height",
+			zap.String("pk", fp.GetBtcPkHex()),
+			zap.Uint64("start_height", startHeight),
+			zap.Uint64("finality_activation_height", finalityActivationHeight),
+		)
+		startHeight = finalityActivationHeight
+	}
+
+	// the finality provider has been activated before if it has voted
+	if fp.GetLastVotedHeight() > 0 {
+		return startHeight, nil
+	}
+
+	fpActivationHeight, activated, err := fp.queryActivationHeight(startHeight)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the activation height of the finality provider: %w", err)
+	}
+	if activated && startHeight < fpActivationHeight {
+		fp.logger.Info("fast-forwarding to the activation height of the finality provider",
+			zap.String("pk", fp.GetBtcPkHex()),
+			zap.Uint64("start_height", startHeight),
+			zap.Uint64("fp_activation_height", fpActivationHeight),
+		)
+		startHeight = fpActivationHeight
+	}
+
+	return startHeight, nil
+}
+
+// queryActivationHeight returns the first height from the given height up
+// to the tip at which the finality provider has voting power, false if it
+// has no voting power at the tip. As the finality provider has never voted,
+// its voting power is assumed to be zero until it is activated, so that the
+// height is found by a binary search
+func (fp *FinalityProviderInstance) queryActivationHeight(fromHeight uint64) (uint64, bool, error) {
+	tip, err := fp.getLatestBlockWithRetry()
+	if err != nil {
+		return 0, false, err
+	}
+	if tip.Height < fromHeight {
+		return 0, false, nil
+	}
+
+	power, err := fp.GetVotingPowerWithRetry(tip.Height)
+	if err != nil {
+		return 0, false, err
+	}
+	if power == 0 {
+		return 0, false, nil
+	}
+
+	low, high := fromHeight, tip.Height
+	for low < high {
+		mid := low + (high-low)/2
+		power, err := fp.GetVotingPowerWithRetry(mid)
+		if err != nil {
+			return 0, false, err
+		}
+		if power > 0 {
+			high = mid
+		} else {
+			low = mid + 1
+		}
+	}
+
+	return low, true, nil
+}
+
+// GetStartHeight returns the height from which the running finality
+// provider started processing the blocks, zero if it is not started
+func (fp *FinalityProviderInstance) GetStartHeight() uint64 {
+	if !fp.IsRunning() {
+		return 0
+	}
+
+	return fp.startHeight.Load()
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"go.uber.org/zap"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/notifier"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+)
+
+// alertRuleEvents are the events fired by the kinds of the alert rules
+var alertRuleEvents = map[fpcfg.AlertRuleKind]notifier.EventType{
+	fpcfg.AlertRuleConsecutiveMisses: notifier.EventMissedVotes,
+	fpcfg.AlertRuleParticipation:     notifier.EventLowParticipation,
+}
+
+func (fpm *FinalityProviderManager) alertRulesEnabled() bool {
+	return fpm.config().AlertRulesConfig != nil && fpm.config().AlertRulesConfig.Enabled
+}
+
+// evaluateAlertRule measures the given rule over the vote records in
+// ascending order of height. It returns the measured value and whether the
+// rule fires, no record never firing
+func evaluateAlertRule(rule *fpcfg.AlertRule, records []*store.SubmissionRecord, now time.Time) (float64, bool) {
+	switch rule.Kind {
+	case fpcfg.AlertRuleConsecutiveMisses:
+		if len(records) == 0 {
+			return 0, false
+		}
+		var misses uint64
+		for i := len(records) - 1; i >= 0 && records[i].Result == store.SubmissionResultFailed; i-- {
+			misses++
+		}
+
+		return float64(misses), misses > rule.MaxMisses
+	case fpcfg.AlertRuleParticipation:
+		cutoff := now.Add(-rule.Window)
+		var total, submitted int
+		for _, rec := range records {
+			if rec.Timestamp.Before(cutoff) {
+				continue
+			}
+			total++
+			if rec.Result == store.SubmissionResultSubmitted {
+				submitted++
+			}
+		}
+		if total == 0 {
+			return 0, false
+		}
+		participation := float64(submitted) * 100 / float64(total)
+
+		return participation, participation < rule.MinParticipation
+	default:
+		return 0, false
+	}
+}
+
+// formatAlertRuleValue returns the human readable measure of the rule
+func formatAlertRuleValue(rule *fpcfg.AlertRule, value float64) string {
+	if rule.Kind == fpcfg.AlertRuleParticipation {
+		return fmt.Sprintf("%.2f%% of the votes submitted over %s", value, rule.Window)
+	}
+
+	return fmt.Sprintf("%d consecutive missed votes", uint64(value))
+}
+
+// evaluateAlertRules evaluates the rules against the recorded votes of the
+// given finality provider, alerting the notifiers once a rule starts firing.
+// The firing rules are tracked in the given set, keyed by the finality
+// provider and the rule
+func (fpm *FinalityProviderManager) evaluateAlertRules(
+	fpi *FinalityProviderInstance,
+	rules []*fpcfg.AlertRule,
+	firing map[string]struct{},
+) {
+	pkHex := fpi.GetBtcPkHex()
+	toHeight := max(fpi.GetLastProcessedHeight(), fpi.GetLastVotedHeight())
+	fromHeight := uint64(0)
+	if maxScan := fpm.config().AlertRulesConfig.MaxScanHeights; toHeight >= maxScan {
+		fromHeight = toHeight - maxScan + 1
+	}
+
+	records, err := fpm.history.ListSubmissions(fpi.GetChainID(), fpi.GetBtcPkBIP340().MustMarshal(),
+		store.SubmissionVote, fromHeight, math.MaxUint64)
+	if err != nil {
+		fpm.logger.Warn("failed to list the vote records of the alert rules",
+			zap.String("pk", pkHex),
+			zap.Error(err),
+		)
+		return
+	}
+
+	now := time.Now()
+	for _, rule := range rules {
+		value, fires := evaluateAlertRule(rule, records, now)
+		key := pkHex + "/" + rule.String()
+		_, wasFiring := firing[key]
+
+		switch {
+		case fires && !wasFiring:
+			firing[key] = struct{}{}
+			fpm.logger.Warn("the alert rule is firing",
+				zap.String("pk", pkHex),
+				zap.Stringer("rule", rule),
+				zap.String("value", formatAlertRuleValue(rule, value)),
+			)
+			fpm.notifyAlertRule(fpi, rule, value)
+		case !fires && wasFiring:
+			delete(firing, key)
+			fpm.logger.Info("the alert rule is resolved",
+				zap.String("pk", pkHex),
+				zap.Stringer("rule", rule),
+			)
+		}
+	}
+}
+
+// notifyAlertRule alerts the notifiers that the given rule fires for the
+// given finality provider, the probable cause giving the rule and its measure
+func (fpm *FinalityProviderManager) notifyAlertRule(fpi *FinalityProviderInstance, rule *fpcfg.AlertRule, value float64) {
+	if !fpm.notifier.Enabled() {
+		return
+	}
+
+	eventType := alertRuleEvents[rule.Kind]
+	fpm.sendNotification(&notifier.Event{
+		Type:            eventType,
+		FpBtcPk:         fpi.GetBtcPkHex(),
+		ChainID:         string(fpi.GetChainID()),
+		OldStatus:       fpi.GetStatus().String(),
+		LastVotedHeight: fpi.GetLastVotedHeight(),
+		ProbableCause: fmt.Sprintf("the alert rule %s fires with %s; %s",
+			rule, formatAlertRuleValue(rule, value), probableCauses[eventType]),
+		Time: time.Now().UTC(),
+	})
+}
+
+// alertRulesLoop periodically evaluates the alert rules against the recorded
+// votes of the running finality providers
+func (fpm *FinalityProviderManager) alertRulesLoop() {
+	defer fpm.wg.Done()
+
+	cfg := fpm.config().AlertRulesConfig
+	rules, err := cfg.ParsedRules()
+	if err != nil {
+		fpm.logger.Error("failed to parse the alert rules", zap.Error(err))
+		return
+	}
+
+	fpm.logger.Info("starting alert rules loop",
+		zap.Float64("interval seconds", cfg.EvalInterval.Seconds()),
+		zap.Strings("rules", cfg.Rules),
+	)
+
+	ticker := time.NewTicker(cfg.EvalInterval)
+	defer ticker.Stop()
+
+	firing := make(map[string]struct{})
+	for {
+		select {
+		case <-ticker.C:
+			for _, fpi := range fpm.ListRunningInstances() {
+				fpm.evaluateAlertRules(fpi, rules, firing)
+			}
+		case <-fpm.quit:
+			fpm.logger.Info("exiting alert rules loop")
+			return
+		}
+	}
+}
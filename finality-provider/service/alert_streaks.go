@@ -0,0 +1,69 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+	"github.com/babylonlabs-io/finality-provider/metrics"
+)
+
+// alertStreaks tracks the consecutive failures of a finality provider
+// instance which are exposed as metrics for alerting, each streak being
+// reset once the finality provider succeeds again
+type alertStreaks struct {
+	fpBtcPkHex string
+	metrics    *metrics.FpMetrics
+
+	mu sync.Mutex
+	// missedHeights is the number of the consecutive heights whose vote
+	// has failed or has been skipped while paused
+	missedHeights uint64
+	// failedBroadcasts are the numbers of the consecutive failed
+	// broadcasts by submission
+	failedBroadcasts map[string]uint64
+}
+
+func newAlertStreaks(fpBtcPkHex string, m *metrics.FpMetrics) *alertStreaks {
+	return &alertStreaks{
+		fpBtcPkHex:       fpBtcPkHex,
+		metrics:          m,
+		failedBroadcasts: make(map[string]uint64),
+	}
+}
+
+// addMissedHeights adds the given number of heights to the missed heights
+// and returns the missed heights
+func (s *alertStreaks) addMissedHeights(n int) uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if n <= 0 {
+		return s.missedHeights
+	}
+
+	s.missedHeights += uint64(n)
+	s.metrics.RecordFpConsecutiveMissedHeights(s.fpBtcPkHex, s.missedHeights)
+
+	return s.missedHeights
+}
+
+// recordBroadcast records the result of the broadcast of the given
+// submission, the expected errors, e.g., a duplicated vote, not being
+// failures. A successful vote also resets the missed heights
+func (s *alertStreaks) recordBroadcast(submission string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err != nil && !clientcontroller.IsExpected(err) {
+		s.failedBroadcasts[submission]++
+		s.metrics.RecordFpConsecutiveFailedBroadcasts(s.fpBtcPkHex, submission, s.failedBroadcasts[submission])
+		return
+	}
+
+	s.failedBroadcasts[submission] = 0
+	s.metrics.RecordFpConsecutiveFailedBroadcasts(s.fpBtcPkHex, submission, 0)
+	if submission == submissionVote {
+		s.missedHeights = 0
+		s.metrics.RecordFpConsecutiveMissedHeights(s.fpBtcPkHex, 0)
+	}
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/notifier"
+	"github.com/babylonlabs-io/finality-provider/metrics"
+)
+
+func TestAlertStreaksMissedHeights(t *testing.T) {
+	t.Parallel()
+	s := newAlertStreaks("fp-streaks", metrics.NewFpMetrics())
+
+	require.Equal(t, uint64(0), s.addMissedHeights(0))
+	require.Equal(t, uint64(3), s.addMissedHeights(3))
+	require.Equal(t, uint64(5), s.addMissedHeights(2))
+
+	// a failed vote does not reset the streak
+	s.recordBroadcast(submissionVote, errors.New("broadcast failed"))
+	require.Equal(t, uint64(6), s.addMissedHeights(1))
+	require.Equal(t, uint64(1), s.failedBroadcasts[submissionVote])
+
+	// an included public randomness commit does not reset the missed votes
+	s.recordBroadcast(submissionPubRandCommit, nil)
+	require.Equal(t, uint64(6), s.addMissedHeights(0))
+
+	// an expected error, e.g., a duplicated vote, resets the streaks
+	s.recordBroadcast(submissionVote, clientcontroller.Expected(errors.New("duplicated vote")))
+	require.Equal(t, uint64(0), s.addMissedHeights(0))
+	require.Equal(t, uint64(0), s.failedBroadcasts[submissionVote])
+
+	// an included vote resets the streaks
+	s.addMissedHeights(4)
+	s.recordBroadcast(submissionVote, errors.New("broadcast failed"))
+	s.recordBroadcast(submissionVote, nil)
+	require.Equal(t, uint64(0), s.addMissedHeights(0))
+	require.Equal(t, uint64(0), s.failedBroadcasts[submissionVote])
+}
+
+func TestInstanceAlertsMissedVotesThreshold(t *testing.T) {
+	t.Parallel()
+
+	var fired []notifier.EventType
+	notify := func(eventType notifier.EventType, _ uint64) {
+		fired = append(fired, eventType)
+	}
+
+	// the alert is disabled without a config or a threshold
+	var nilAlerts *instanceAlerts
+	nilAlerts.checkMissedVotes(100)
+	require.Nil(t, newInstanceAlerts(nil, notify))
+	newInstanceAlerts(&fpcfg.NotifierConfig{}, notify).checkMissedVotes(100)
+	require.Empty(t, fired)
+
+	alerts := newInstanceAlerts(&fpcfg.NotifierConfig{MissedVotesThreshold: 3}, notify)
+	alerts.checkMissedVotes(2)
+	require.Empty(t, fired)
+
+	// the alert fires once the threshold is reached, then once per streak
+	alerts.checkMissedVotes(3)
+	alerts.checkMissedVotes(4)
+	alerts.checkMissedVotes(10)
+	require.Equal(t, []notifier.EventType{notifier.EventMissedVotes}, fired)
+
+	// the alert fires again once the streak is broken and reaches the
+	// threshold again
+	alerts.checkMissedVotes(0)
+	alerts.checkMissedVotes(3)
+	require.Equal(t, []notifier.EventType{notifier.EventMissedVotes, notifier.EventMissedVotes}, fired)
+}
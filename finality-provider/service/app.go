@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
@@ -16,19 +18,31 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/lightningnetwork/lnd/kvdb"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
+	protobuf "google.golang.org/protobuf/proto"
 
 	"github.com/babylonlabs-io/finality-provider/clientcontroller"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/client"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/audit"
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/errreport"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/eventbus"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/notifier"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store/archive"
 	fpkr "github.com/babylonlabs-io/finality-provider/keyring"
+	"github.com/babylonlabs-io/finality-provider/log"
 	"github.com/babylonlabs-io/finality-provider/metrics"
 	"github.com/babylonlabs-io/finality-provider/types"
 )
 
+// metricsUpdatePageSize is the number of finality providers loaded
+// from the store at a time when updating metrics
+const metricsUpdatePageSize = 100
+
 type FinalityProviderApp struct {
 	startOnce sync.Once
 	stopOnce  sync.Once
@@ -36,19 +50,53 @@ type FinalityProviderApp struct {
 	wg   sync.WaitGroup
 	quit chan struct{}
 
+	// ctx is the root context threaded through the finality provider
+	// instances, it is cancelled upon shutdown to abort outstanding calls
+	ctx    context.Context
+	cancel context.CancelFunc
+
 	cc           clientcontroller.ClientController
 	kr           keyring.Keyring
+	db           kvdb.Backend
 	fps          *store.FinalityProviderStore
 	pubRandStore *store.PubRandProofStore
-	config       *fpcfg.Config
-	logger       *zap.Logger
-	input        *strings.Reader
+	// cfgSnapshot holds the current config, shared with the manager
+	cfgSnapshot *atomic.Pointer[fpcfg.Config]
+	logger      *zap.Logger
+	input       *strings.Reader
+	// passphrase unlocks the keys of the finality providers started by the
+	// status sync, it is set before the app starts
+	passphrase string
+
+	// signRecordDB is the database of the sign records, apart from db so
+	// that neither restoring nor pruning db erases the records
+	signRecordDB kvdb.Backend
+	// signRecordStore keeps the messages signed by the finality providers
+	// to refuse signing conflicting messages
+	signRecordStore *store.SignRecordStore
+	// voteRetryStore queues the failed votes of the finality providers
+	voteRetryStore *store.VoteRetryStore
+	// outboxStore records the submissions of the finality providers until
+	// they are confirmed
+	outboxStore *store.OutboxStore
+	// historyStore records the results of the submissions of the finality
+	// providers
+	historyStore *store.SubmissionHistoryStore
 
 	fpManager   *FinalityProviderManager
 	eotsManager eotsmanager.EOTSManager
 
 	metrics *metrics.FpMetrics
 
+	// pubRandArchive is nil if the archival is disabled
+	pubRandArchive store.PubRandProofArchive
+
+	// reloadMu serializes the config reloads
+	reloadMu sync.Mutex
+	// configLoader is nil if the config reload is not enabled
+	configLoader ConfigLoader
+	logLevels    *log.Levels
+
 	createFinalityProviderRequestChan   chan *createFinalityProviderRequest
 	registerFinalityProviderRequestChan chan *registerFinalityProviderRequest
 	finalityProviderRegisteredEventChan chan *finalityProviderRegisteredEvent
@@ -59,14 +107,18 @@ func NewFinalityProviderAppFromConfig(
 	db kvdb.Backend,
 	logger *zap.Logger,
 ) (*FinalityProviderApp, error) {
-	cc, err := clientcontroller.NewClientController(cfg.ChainType, cfg.BabylonConfig, &cfg.BTCNetParams, logger)
+	cc, err := clientcontroller.NewClientController(cfg.ChainType, cfg.BabylonConfig, &cfg.BTCNetParams, logger.Named(log.ModuleClientController))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create rpc client for the consumer chain %s: %w", cfg.ChainType, err)
 	}
+	if cfg.DryRun {
+		logger.Warn("running in dry-run mode, the transactions are not broadcast")
+		cc = clientcontroller.NewDryRunController(cc, logger.Named(log.ModuleClientController))
+	}
 
 	// if the EOTSManagerAddress is empty, run a local EOTS manager;
 	// otherwise connect a remote one with a gRPC client
-	em, err := client.NewEOTSManagerGRpcClient(cfg.EOTSManagerAddress)
+	em, err := client.NewEOTSManagerGRpcClientWithLogger(cfg.EOTSManagerAddress, logger.Named(log.ModuleEOTSClient))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create EOTS manager client: %w", err)
 	}
@@ -90,6 +142,18 @@ func NewFinalityProviderApp(
 	if err != nil {
 		return nil, fmt.Errorf("failed to initiate public randomness store: %w", err)
 	}
+	voteRetryStore, err := store.NewVoteRetryStore(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initiate vote retry store: %w", err)
+	}
+	outboxStore, err := store.NewOutboxStore(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initiate outbox store: %w", err)
+	}
+	historyStore, err := store.NewSubmissionHistoryStore(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initiate submission history store: %w", err)
+	}
 
 	input := strings.NewReader("")
 	kr, err := fpkr.CreateKeyring(
@@ -102,33 +166,76 @@ func NewFinalityProviderApp(
 		return nil, fmt.Errorf("failed to create keyring: %w", err)
 	}
 
+	var pubRandArchive store.PubRandProofArchive
+	if config.ArchiveConfig.Enabled() {
+		pubRandArchive, err = archive.NewObjectArchive(context.Background(), config.ArchiveConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create public randomness proof archive: %w", err)
+		}
+	}
+
+	var signRecordDB kvdb.Backend
+	if config.DryRun {
+		// the rehearsal refuses to sign at the heights signed by the
+		// daemon without recording its own signatures
+		signRecordDB, err = store.OpenInMemoryCopy(config.DatabaseConfig.GetSignRecordDBBackend)
+	} else {
+		signRecordDB, err = config.DatabaseConfig.GetSignRecordDBBackend()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the sign record db: %w", err)
+	}
+	signRecordStore, err := store.NewSignRecordStore(signRecordDB)
+	if err != nil {
+		_ = signRecordDB.Close()
+		return nil, fmt.Errorf("failed to initiate sign record store: %w", err)
+	}
+
 	fpMetrics := metrics.NewFpMetrics()
 
-	fpm, err := NewFinalityProviderManager(fpStore, pubRandStore, config, cc, em, fpMetrics, logger)
+	ctx, cancel := context.WithCancel(context.Background())
+	fpm, err := NewFinalityProviderManager(ctx, fpStore, pubRandStore, signRecordStore, voteRetryStore, outboxStore, historyStore, config, cc, em, fpMetrics, logger)
 	if err != nil {
+		cancel()
+		_ = signRecordDB.Close()
 		return nil, fmt.Errorf("failed to create finality-provider manager: %w", err)
 	}
 
 	return &FinalityProviderApp{
 		cc:                                  cc,
+		db:                                  db,
+		signRecordDB:                        signRecordDB,
 		fps:                                 fpStore,
 		pubRandStore:                        pubRandStore,
+		signRecordStore:                     signRecordStore,
+		voteRetryStore:                      voteRetryStore,
+		outboxStore:                         outboxStore,
+		historyStore:                        historyStore,
+		pubRandArchive:                      pubRandArchive,
 		kr:                                  kr,
-		config:                              config,
+		cfgSnapshot:                         fpm.cfgSnapshot,
 		logger:                              logger,
 		input:                               input,
 		fpManager:                           fpm,
 		eotsManager:                         em,
 		metrics:                             fpMetrics,
 		quit:                                make(chan struct{}),
+		ctx:                                 ctx,
+		cancel:                              cancel,
 		createFinalityProviderRequestChan:   make(chan *createFinalityProviderRequest),
 		registerFinalityProviderRequestChan: make(chan *registerFinalityProviderRequest),
 		finalityProviderRegisteredEventChan: make(chan *finalityProviderRegisteredEvent),
 	}, nil
 }
 
+// GetConfig returns the current config, which must not be modified as a
+// new one is published upon each config reload
 func (app *FinalityProviderApp) GetConfig() *fpcfg.Config {
-	return app.config
+	return app.config()
+}
+
+func (app *FinalityProviderApp) config() *fpcfg.Config {
+	return app.cfgSnapshot.Load()
 }
 
 func (app *FinalityProviderApp) GetFinalityProviderStore() *store.FinalityProviderStore {
@@ -139,6 +246,22 @@ func (app *FinalityProviderApp) GetPubRandProofStore() *store.PubRandProofStore
 	return app.pubRandStore
 }
 
+func (app *FinalityProviderApp) GetSignRecordStore() *store.SignRecordStore {
+	return app.signRecordStore
+}
+
+func (app *FinalityProviderApp) GetVoteRetryStore() *store.VoteRetryStore {
+	return app.voteRetryStore
+}
+
+func (app *FinalityProviderApp) GetOutboxStore() *store.OutboxStore {
+	return app.outboxStore
+}
+
+func (app *FinalityProviderApp) GetSubmissionHistoryStore() *store.SubmissionHistoryStore {
+	return app.historyStore
+}
+
 func (app *FinalityProviderApp) GetKeyring() keyring.Keyring {
 	return app.kr
 }
@@ -156,19 +279,70 @@ func (app *FinalityProviderApp) ListAllFinalityProvidersInfo() ([]*proto.Finalit
 	return app.fpManager.AllFinalityProviders()
 }
 
+// QueryFinalityProvidersInfo returns the information of the stored finality providers
+// matching the given query and the key of the next page
+func (app *FinalityProviderApp) QueryFinalityProvidersInfo(q *store.FinalityProviderQuery) ([]*proto.FinalityProviderInfo, []byte, error) {
+	return app.fpManager.QueryFinalityProviders(q)
+}
+
 func (app *FinalityProviderApp) GetFinalityProviderInfo(fpPk *bbntypes.BIP340PubKey) (*proto.FinalityProviderInfo, error) {
 	return app.fpManager.FinalityProviderInfo(fpPk)
 }
 
-// GetFinalityProviderInstance returns the finality-provider instance with the given Babylon public key
-func (app *FinalityProviderApp) GetFinalityProviderInstance() (*FinalityProviderInstance, error) {
-	return app.fpManager.GetFinalityProviderInstance()
+// SubscribeEvents returns a channel receiving the events of the finality
+// providers published from now on and a function to unsubscribe
+func (app *FinalityProviderApp) SubscribeEvents(name string) (<-chan *eventbus.Event, func()) {
+	return app.fpManager.SubscribeEvents(name)
 }
 
-func (app *FinalityProviderApp) RegisterFinalityProvider(fpPkStr string) (*RegisterFinalityProviderResponse, error) {
+// ParticipationReport compares the on-chain votes of the given finality
+// provider within the given heights with the local records and reports the
+// missed votes with their probable reasons
+func (app *FinalityProviderApp) ParticipationReport(fpPk *bbntypes.BIP340PubKey, startHeight, endHeight uint64) (*proto.ParticipationReport, error) {
+	return app.fpManager.ParticipationReport(fpPk, startHeight, endHeight)
+}
+
+// QueryVotes returns the locally recorded votes of the given finality
+// provider between the given heights inclusive
+func (app *FinalityProviderApp) QueryVotes(fpPk *bbntypes.BIP340PubKey, startHeight, endHeight uint64) ([]*proto.VoteRecord, error) {
+	return app.fpManager.QueryVotes(fpPk, startHeight, endHeight)
+}
+
+// QueryPubRandCommits returns the locally recorded public randomness commits
+// of the given finality provider
+func (app *FinalityProviderApp) QueryPubRandCommits(fpPk *bbntypes.BIP340PubKey) ([]*proto.PubRandCommitRecord, error) {
+	return app.fpManager.QueryPubRandCommits(fpPk)
+}
+
+// GetFinalityProviderInstance returns the finality-provider instance with the given BTC public key
+func (app *FinalityProviderApp) GetFinalityProviderInstance(fpPk *bbntypes.BIP340PubKey) (*FinalityProviderInstance, error) {
+	return app.fpManager.GetFinalityProviderInstance(fpPk)
+}
+
+// GetSoleFinalityProviderInstance returns the finality-provider instance if exactly one is running
+//
+// Deprecated: use GetFinalityProviderInstance or ListRunningInstances instead
+func (app *FinalityProviderApp) GetSoleFinalityProviderInstance() (*FinalityProviderInstance, error) {
+	return app.fpManager.GetSoleFinalityProviderInstance()
+}
+
+// ListRunningInstances returns the running finality-provider instances
+func (app *FinalityProviderApp) ListRunningInstances() []*FinalityProviderInstance {
+	return app.fpManager.ListRunningInstances()
+}
+
+func (app *FinalityProviderApp) RegisterFinalityProvider(fpPkStr string) (res *RegisterFinalityProviderResponse, err error) {
+	defer func() {
+		details := map[string]string{"fp_btc_pk_hex": fpPkStr}
+		if res != nil {
+			details["tx_hash"] = res.TxHash
+		}
+		app.fpManager.audit.record(audit.OpRegister, details, err)
+	}()
+
 	fpPk, err := bbntypes.NewBIP340PubKeyFromHex(fpPkStr)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEotsPk, err)
 	}
 
 	fp, err := app.fps.GetFinalityProvider(fpPk.MustToBTCPK())
@@ -177,7 +351,7 @@ func (app *FinalityProviderApp) RegisterFinalityProvider(fpPkStr string) (*Regis
 	}
 
 	if fp.Status != proto.FinalityProviderStatus_CREATED {
-		return nil, fmt.Errorf("finality-provider is already registered")
+		return nil, ErrFinalityProviderAlreadyRegistered
 	}
 
 	btcSig, err := bbntypes.NewBIP340Signature(fp.Pop.BtcSig)
@@ -213,7 +387,7 @@ func (app *FinalityProviderApp) RegisterFinalityProvider(fpPkStr string) (*Regis
 	case successResponse := <-request.successResponse:
 		return successResponse, nil
 	case <-app.quit:
-		return nil, fmt.Errorf("finality-provider app is shutting down")
+		return nil, ErrAppShuttingDown
 	}
 }
 
@@ -223,6 +397,18 @@ func (app *FinalityProviderApp) StartHandlingFinalityProvider(fpPk *bbntypes.BIP
 	return app.fpManager.StartFinalityProvider(fpPk, passphrase)
 }
 
+// SetPassphrase sets the passphrase of the keys of the finality providers
+// started by the status sync, it should be called before the app starts
+func (app *FinalityProviderApp) SetPassphrase(passphrase string) {
+	app.passphrase = passphrase
+}
+
+// StopFinalityProvider stops the instance of the finality provider with the given EOTS public key,
+// leaving the daemon and the other instances running
+func (app *FinalityProviderApp) StopFinalityProvider(fpPk *bbntypes.BIP340PubKey) error {
+	return app.fpManager.StopFinalityProvider(fpPk)
+}
+
 // NOTE: this is not safe in production, so only used for testing purpose
 func (app *FinalityProviderApp) getFpPrivKey(fpPk []byte) (*btcec.PrivateKey, error) {
 	record, err := app.eotsManager.KeyRecord(fpPk, "")
@@ -233,37 +419,49 @@ func (app *FinalityProviderApp) getFpPrivKey(fpPk []byte) (*btcec.PrivateKey, er
 	return record.PrivKey, nil
 }
 
-// SyncFinalityProviderStatus syncs the status of the finality-providers with the chain.
-func (app *FinalityProviderApp) SyncFinalityProviderStatus() (bool, error) {
-	var fpInstanceRunning bool
+// SyncFinalityProviderStatus reconciles the stored finality providers with
+// the chain. The status of each finality provider which is not running is
+// updated from its voting power, and its instance is (re)started if the
+// status allows it, unless it has been stopped by the operator. A failure
+// of a finality provider does not prevent the others from being
+// reconciled. It returns the number of started instances
+func (app *FinalityProviderApp) SyncFinalityProviderStatus() (int, error) {
 	latestBlock, err := app.cc.QueryBestBlock()
 	if err != nil {
-		return false, err
+		return 0, err
 	}
 
 	fps, err := app.fps.GetAllStoredFinalityProviders()
 	if err != nil {
-		return false, err
+		return 0, err
 	}
 
+	var (
+		started int
+		errs    []error
+	)
 	for _, fp := range fps {
-		vp, err := app.cc.QueryFinalityProviderVotingPower(fp.BtcPk, latestBlock.Height)
-		if err != nil {
+		bip340PubKey := fp.GetBIP340BTCPK()
+		if app.fpManager.IsFinalityProviderRunning(bip340PubKey) {
+			// the status of a running instance is updated by the manager
+			continue
+		}
+		if app.fpManager.isStoppedByOperator(bip340PubKey) {
 			continue
 		}
 
-		bip340PubKey := fp.GetBIP340BTCPK()
-		if app.fpManager.IsFinalityProviderRunning(bip340PubKey) {
-			// there is a instance running, no need to keep syncing
-			fpInstanceRunning = true
-			// if it is already running, no need to update status
+		vp, err := app.cc.QueryFinalityProviderVotingPower(fp.BtcPk, latestBlock.Height)
+		if err != nil {
+			app.logger.Debug("failed to query the voting power",
+				zap.String("pk", bip340PubKey.MarshalHex()), zap.Error(err))
 			continue
 		}
 
 		oldStatus := fp.Status
 		newStatus, err := app.fps.UpdateFpStatusFromVotingPower(vp, fp)
 		if err != nil {
-			return false, err
+			errs = append(errs, fmt.Errorf("failed to update the status of %s: %w", bip340PubKey.MarshalHex(), err))
+			continue
 		}
 
 		if oldStatus != newStatus {
@@ -280,13 +478,14 @@ func (app *FinalityProviderApp) SyncFinalityProviderStatus() (bool, error) {
 			continue
 		}
 
-		if err := app.fpManager.StartFinalityProvider(bip340PubKey, ""); err != nil {
-			return false, err
+		if err := app.fpManager.StartFinalityProvider(bip340PubKey, app.passphrase); err != nil {
+			errs = append(errs, fmt.Errorf("failed to start %s: %w", bip340PubKey.MarshalHex(), err))
+			continue
 		}
-		fpInstanceRunning = true
+		started++
 	}
 
-	return fpInstanceRunning, nil
+	return started, errors.Join(errs...)
 }
 
 // Start starts only the finality-provider daemon without any finality-provider instances
@@ -300,6 +499,26 @@ func (app *FinalityProviderApp) Start() error {
 		go app.eventLoop()
 		go app.registrationLoop()
 		go app.metricsUpdateLoop()
+
+		if app.pubRandArchive != nil {
+			app.wg.Add(1)
+			go app.pubRandArchivalLoop()
+		}
+
+		if app.config().RewardWithdrawalConfig != nil && app.config().RewardWithdrawalConfig.Enabled {
+			app.wg.Add(1)
+			go app.rewardWithdrawalLoop()
+		}
+
+		if app.clockSkewEnabled() {
+			app.wg.Add(1)
+			go app.clockSkewMonitorLoop()
+		}
+
+		if app.nodeHealthEnabled() {
+			app.wg.Add(1)
+			go app.nodeHealthMonitorLoop()
+		}
 	})
 
 	return startErr
@@ -310,19 +529,52 @@ func (app *FinalityProviderApp) Stop() error {
 	app.stopOnce.Do(func() {
 		app.logger.Info("Stopping FinalityProviderApp")
 
+		defer app.cancel()
+
 		// Always stop the submission loop first to not generate additional events and actions
 		app.logger.Debug("Stopping submission loop")
 		close(app.quit)
-		app.wg.Wait()
 
-		app.logger.Debug("Stopping finality providers")
-		if err := app.fpManager.Stop(); err != nil {
-			stopErr = err
+		stopped := make(chan error, 1)
+		go func() {
+			app.wg.Wait()
+
+			app.logger.Debug("Stopping finality providers")
+			stopped <- app.fpManager.Stop()
+		}()
+
+		eotsManagerClosed := false
+		select {
+		case stopErr = <-stopped:
+		case <-time.After(app.config().ShutdownGracePeriod):
+			// the in-flight calls did not complete in time, so cancel them
+			// to not block the shutdown indefinitely
+			app.logger.Warn("the shutdown grace period elapsed, cancelling the outstanding calls",
+				zap.Duration("grace_period", app.config().ShutdownGracePeriod))
+			app.cancel()
+			if err := app.cc.Close(); err != nil {
+				app.logger.Error("failed to close the consumer chain client", zap.Error(err))
+			}
+			if err := app.eotsManager.Close(); err != nil {
+				app.logger.Error("failed to close the EOTS manager", zap.Error(err))
+			}
+			eotsManagerClosed = true
+			stopErr = <-stopped
+		}
+		if stopErr != nil {
 			return
 		}
 
-		app.logger.Debug("Stopping EOTS manager")
-		if err := app.eotsManager.Close(); err != nil {
+		if !eotsManagerClosed {
+			app.logger.Debug("Stopping EOTS manager")
+			if err := app.eotsManager.Close(); err != nil {
+				stopErr = err
+				return
+			}
+		}
+
+		app.logger.Debug("Closing the sign record db")
+		if err := app.signRecordDB.Close(); err != nil {
 			stopErr = err
 			return
 		}
@@ -337,7 +589,15 @@ func (app *FinalityProviderApp) CreateFinalityProvider(
 	eotsPk *bbntypes.BIP340PubKey,
 	description *stakingtypes.Description,
 	commission *sdkmath.LegacyDec,
-) (*CreateFinalityProviderResult, error) {
+) (res *CreateFinalityProviderResult, err error) {
+	defer func() {
+		app.fpManager.audit.record(audit.OpCreateFinalityProvider, map[string]string{
+			"fp_btc_pk_hex": eotsPk.MarshalHex(),
+			"key_name":      keyName,
+			"chain_id":      chainID,
+		}, err)
+	}()
+
 	req := &createFinalityProviderRequest{
 		keyName:         keyName,
 		chainID:         chainID,
@@ -360,10 +620,43 @@ func (app *FinalityProviderApp) CreateFinalityProvider(
 			FpInfo: successResponse.FpInfo,
 		}, nil
 	case <-app.quit:
-		return nil, fmt.Errorf("finality-provider app is shutting down")
+		return nil, ErrAppShuttingDown
 	}
 }
 
+// RegisterSubmissionHook registers the hook intercepting the signing and the
+// submission of the finality providers, so that the daemon can be extended
+// without forking it. The hooks are called in order of registration
+func (app *FinalityProviderApp) RegisterSubmissionHook(hook SubmissionHook) {
+	app.fpManager.RegisterSubmissionHook(hook)
+}
+
+// RegisterNotifier registers the notifier to which the alerts, e.g., the
+// jailing, the missed votes or the critical errors, are sent along with the
+// configured Slack, Telegram, PagerDuty and webhook notifiers
+func (app *FinalityProviderApp) RegisterNotifier(n notifier.Notifier) {
+	app.fpManager.RegisterNotifier(n)
+}
+
+// RegisterErrorReporter registers the reporter to which the panics and the
+// critical errors are shipped, scrubbed of the secrets, along with the one
+// configured in the error reporter config
+func (app *FinalityProviderApp) RegisterErrorReporter(reporter errreport.Reporter) {
+	app.fpManager.RegisterErrorReporter(reporter)
+}
+
+// PauseFinalityProvider stops the finality provider with the given EOTS public
+// key from voting and committing randomness while keeping its instance running
+func (app *FinalityProviderApp) PauseFinalityProvider(fpPk *bbntypes.BIP340PubKey) error {
+	return app.fpManager.PauseFinalityProvider(fpPk)
+}
+
+// ResumeFinalityProvider resumes the voting of the paused finality provider
+// with the given EOTS public key
+func (app *FinalityProviderApp) ResumeFinalityProvider(fpPk *bbntypes.BIP340PubKey) error {
+	return app.fpManager.ResumeFinalityProvider(fpPk)
+}
+
 // UnjailFinalityProvider sends a transaction to unjail a finality-provider
 func (app *FinalityProviderApp) UnjailFinalityProvider(fpPk *bbntypes.BIP340PubKey) (string, error) {
 	_, err := app.fps.GetFinalityProvider(fpPk.MustToBTCPK())
@@ -371,24 +664,102 @@ func (app *FinalityProviderApp) UnjailFinalityProvider(fpPk *bbntypes.BIP340PubK
 		return "", fmt.Errorf("failed to get finality provider from db: %w", err)
 	}
 
-	// Send unjail transaction
-	res, err := app.cc.UnjailFinalityProvider(fpPk.MustToBTCPK())
+	txHash, err := app.fpManager.unjailFinalityProvider(fpPk)
 	if err != nil {
-		return "", fmt.Errorf("failed to send unjail transaction: %w", err)
+		return "", err
 	}
 
-	// Update finality-provider status in the local store
-	// set it to INACTIVE for now and it will be updated to
-	// ACTIVE if the fp has voting power
-	err = app.fps.SetFpStatus(fpPk.MustToBTCPK(), proto.FinalityProviderStatus_INACTIVE)
+	app.logger.Info("successfully unjailed finality-provider",
+		zap.String("btc_pk", fpPk.MarshalHex()),
+		zap.String("txHash", txHash),
+	)
+
+	return txHash, nil
+}
+
+// EditFinalityProvider sends a transaction to update the description and the
+// commission rate of a finality-provider and reconciles the stored ones with
+// the updated values once it is included. The empty fields of the description
+// and a nil commission are left unchanged
+func (app *FinalityProviderApp) EditFinalityProvider(
+	fpPk *bbntypes.BIP340PubKey,
+	desc *proto.Description,
+	commission *sdkmath.LegacyDec,
+) (err error) {
+	defer func() {
+		details := map[string]string{"fp_btc_pk_hex": fpPk.MarshalHex()}
+		if desc != nil && desc.Moniker != "" {
+			details["moniker"] = desc.Moniker
+		}
+		if commission != nil {
+			details["commission"] = commission.String()
+		}
+		app.fpManager.audit.record(audit.OpEditFinalityProvider, details, err)
+	}()
+
+	if _, err := app.fps.GetFinalityProvider(fpPk.MustToBTCPK()); err != nil {
+		return fmt.Errorf("failed to get finality provider from db: %w", err)
+	}
+
+	if desc == nil {
+		desc = &proto.Description{}
+	}
+	// check the lengths before sending the transaction, empty fields are ignored
+	if _, err := stakingtypes.NewDescription(desc.Moniker, desc.Identity, desc.Website,
+		desc.SecurityContact, desc.Details).EnsureLength(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidDescription, err)
+	}
+	descBytes, err := protobuf.Marshal(desc)
 	if err != nil {
-		return "", fmt.Errorf("failed to update finality-provider status after unjailing: %w", err)
+		return err
 	}
 
-	app.fpManager.metrics.RecordFpStatus(fpPk.MarshalHex(), proto.FinalityProviderStatus_INACTIVE)
+	updatedMsg, err := app.cc.EditFinalityProvider(fpPk.MustToBTCPK(), commission, descBytes)
+	if err != nil {
+		return fmt.Errorf("failed to edit the finality provider: %w", err)
+	}
 
-	app.logger.Info("successfully unjailed finality-provider",
+	if err := app.fps.SetFpDescription(fpPk.MustToBTCPK(), updatedMsg.Description, updatedMsg.Commission); err != nil {
+		return fmt.Errorf("failed to update the stored description: %w", err)
+	}
+
+	app.logger.Info("successfully edited finality-provider",
+		zap.String("btc_pk", fpPk.MarshalHex()),
+		zap.String("moniker", updatedMsg.Description.Moniker),
+		zap.String("commission", updatedMsg.Commission.String()),
+	)
+
+	return nil
+}
+
+// UpdateFinalityProviderCommission sends a transaction to update the commission
+// rate of a finality-provider and updates the stored one once it is included
+func (app *FinalityProviderApp) UpdateFinalityProviderCommission(fpPk *bbntypes.BIP340PubKey, rate sdkmath.LegacyDec) (txHash string, err error) {
+	defer func() {
+		app.fpManager.audit.record(audit.OpUpdateCommission, map[string]string{
+			"fp_btc_pk_hex": fpPk.MarshalHex(),
+			"commission":    rate.String(),
+			"tx_hash":       txHash,
+		}, err)
+	}()
+
+	_, err = app.fps.GetFinalityProvider(fpPk.MustToBTCPK())
+	if err != nil {
+		return "", fmt.Errorf("failed to get finality provider from db: %w", err)
+	}
+
+	res, err := app.cc.UpdateFinalityProviderCommission(fpPk.MustToBTCPK(), rate)
+	if err != nil {
+		return "", fmt.Errorf("failed to send the commission update transaction: %w", err)
+	}
+
+	if err := app.fps.SetFpCommission(fpPk.MustToBTCPK(), rate); err != nil {
+		return "", fmt.Errorf("failed to update the stored commission after the transaction %s: %w", res.TxHash, err)
+	}
+
+	app.logger.Info("successfully updated the commission rate of finality-provider",
 		zap.String("btc_pk", fpPk.MarshalHex()),
+		zap.String("commission", rate.String()),
 		zap.String("txHash", res.TxHash),
 	)
 
@@ -396,7 +767,12 @@ func (app *FinalityProviderApp) UnjailFinalityProvider(fpPk *bbntypes.BIP340PubK
 }
 
 func (app *FinalityProviderApp) handleCreateFinalityProviderRequest(req *createFinalityProviderRequest) (*createFinalityProviderResponse, error) {
-	// 1. check if the chain key exists
+	// 1. check the commission and the description against the chain parameters
+	if err := app.validateFinalityProviderMetadata(req.description, req.commission); err != nil {
+		return nil, err
+	}
+
+	// 2. check if the chain key exists
 	kr, err := fpkr.NewChainKeyringControllerWithKeyring(app.kr, req.keyName, app.input)
 	if err != nil {
 		return nil, err
@@ -405,12 +781,12 @@ func (app *FinalityProviderApp) handleCreateFinalityProviderRequest(req *createF
 	fpAddr, err := kr.Address(req.passPhrase)
 	if err != nil {
 		// the chain key does not exist, should create the chain key first
-		return nil, fmt.Errorf("the keyname %s does not exist, add the key first: %w", req.keyName, err)
+		return nil, fmt.Errorf("%w: the keyname %s does not exist, add the key first: %w", ErrChainKeyNotFound, req.keyName, err)
 	}
 
-	// 2. create proof-of-possession
+	// 3. create proof-of-possession
 	if req.eotsPk == nil {
-		return nil, fmt.Errorf("eots pk cannot be nil")
+		return nil, fmt.Errorf("%w: eots pk cannot be nil", ErrInvalidEotsPk)
 	}
 	pop, err := app.CreatePop(fpAddr, req.eotsPk, req.passPhrase)
 	if err != nil {
@@ -422,26 +798,89 @@ func (app *FinalityProviderApp) handleCreateFinalityProviderRequest(req *createF
 		return nil, fmt.Errorf("failed to save finality-provider: %w", err)
 	}
 
+	storedFp, err := app.fps.GetFinalityProvider(btcPk)
+	if err != nil {
+		return nil, err
+	}
+
+	// the finality provider might already exist with the same metadata,
+	// so record the stored status instead of assuming it is created
 	pkHex := req.eotsPk.MarshalHex()
-	app.fpManager.metrics.RecordFpStatus(pkHex, proto.FinalityProviderStatus_CREATED)
+	app.fpManager.metrics.RecordFpStatus(pkHex, storedFp.Status)
+
+	app.fpManager.events.Publish(&eventbus.Event{
+		Type:      eventbus.EventFpCreated,
+		FpBtcPk:   pkHex,
+		NewStatus: storedFp.Status.String(),
+	})
 
 	app.logger.Info("successfully created a finality-provider",
 		zap.String("eots_pk", pkHex),
 		zap.String("addr", fpAddr.String()),
 		zap.String("key_name", req.keyName),
+		zap.String("status", storedFp.Status.String()),
 	)
 
-	storedFp, err := app.fps.GetFinalityProvider(btcPk)
+	return &createFinalityProviderResponse{
+		FpInfo: storedFp.ToFinalityProviderInfo(),
+	}, nil
+}
+
+// validateFinalityProviderMetadata checks the commission and the description
+// of a finality provider against the btcstaking module of the chain so that
+// it is not created with values which would fail its registration
+func (app *FinalityProviderApp) validateFinalityProviderMetadata(description *stakingtypes.Description, commission *sdkmath.LegacyDec) error {
+	if description == nil || description.Moniker == "" {
+		return fmt.Errorf("%w: the moniker cannot be empty", ErrInvalidDescription)
+	}
+	if _, err := description.EnsureLength(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidDescription, err)
+	}
+
+	if commission == nil || commission.IsNil() {
+		return fmt.Errorf("%w: the commission rate cannot be empty", ErrInvalidCommission)
+	}
+	if commission.GT(sdkmath.LegacyOneDec()) {
+		return fmt.Errorf("%w: the commission rate %s cannot be greater than 1", ErrInvalidCommission, commission.String())
+	}
+
+	minRate, err := app.cc.QueryMinCommissionRate()
+	if err != nil {
+		return fmt.Errorf("failed to query the minimum commission rate: %w", err)
+	}
+	if commission.LT(minRate) {
+		return fmt.Errorf("%w: the commission rate %s is lower than the minimum commission rate %s of the chain",
+			ErrInvalidCommission, commission.String(), minRate.String())
+	}
+
+	return nil
+}
+
+func (app *FinalityProviderApp) CreatePop(fpAddress sdk.AccAddress, fpPk *bbntypes.BIP340PubKey, passphrase string) (*bstypes.ProofOfPossessionBTC, error) {
+	return CreatePopWithEOTSManager(app.eotsManager, fpAddress, fpPk, passphrase)
+}
+
+// GetPop returns the proof-of-possession of the stored finality provider
+// with the given EOTS public key, as included in its registration message
+func (app *FinalityProviderApp) GetPop(fpPk *bbntypes.BIP340PubKey) (*bstypes.ProofOfPossessionBTC, error) {
+	fp, err := app.fps.GetFinalityProvider(fpPk.MustToBTCPK())
 	if err != nil {
 		return nil, err
 	}
+	if fp.Pop == nil || len(fp.Pop.BtcSig) == 0 {
+		return nil, fmt.Errorf("the proof-of-possession of %s is not stored", fpPk.MarshalHex())
+	}
 
-	return &createFinalityProviderResponse{
-		FpInfo: storedFp.ToFinalityProviderInfo(),
+	return &bstypes.ProofOfPossessionBTC{
+		BtcSig:     fp.Pop.BtcSig,
+		BtcSigType: bstypes.BTCSigType_BIP340,
 	}, nil
 }
 
-func (app *FinalityProviderApp) CreatePop(fpAddress sdk.AccAddress, fpPk *bbntypes.BIP340PubKey, passphrase string) (*bstypes.ProofOfPossessionBTC, error) {
+// CreatePopWithEOTSManager creates the proof-of-possession binding the EOTS key
+// managed by the given EOTS manager to the finality provider address, it is
+// used without the app to regenerate the proof-of-possession offline
+func CreatePopWithEOTSManager(em eotsmanager.EOTSManager, fpAddress sdk.AccAddress, fpPk *bbntypes.BIP340PubKey, passphrase string) (*bstypes.ProofOfPossessionBTC, error) {
 	pop := &bstypes.ProofOfPossessionBTC{
 		BtcSigType: bstypes.BTCSigType_BIP340, // by default, we use BIP-340 encoding for BTC signature
 	}
@@ -451,7 +890,7 @@ func (app *FinalityProviderApp) CreatePop(fpAddress sdk.AccAddress, fpPk *bbntyp
 	// So we have to hash the address before signing
 	hash := tmhash.Sum(fpAddress.Bytes())
 
-	sig, err := app.eotsManager.SignSchnorrSig(fpPk.MustMarshal(), hash, passphrase)
+	sig, err := em.SignSchnorrSig(fpPk.MustMarshal(), hash, passphrase)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get schnorr signature from the EOTS manager: %w", err)
 	}
@@ -486,6 +925,7 @@ func (app *FinalityProviderApp) loadChainKeyring(
 	if err != nil {
 		// the chain key does not exist, should create the chain key first
 		keyInfo, err := kr.CreateChainKey(passPhrase, hdPath, "")
+		app.fpManager.audit.record(audit.OpCreateKey, createKeyAuditDetails(keyName, keyInfo), err)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to create chain key %s: %w", keyName, err)
 		}
@@ -547,6 +987,11 @@ func (app *FinalityProviderApp) eventLoop() {
 				)
 			}
 			app.fpManager.metrics.RecordFpStatus(ev.btcPubKey.MarshalHex(), proto.FinalityProviderStatus_REGISTERED)
+			app.fpManager.events.Publish(&eventbus.Event{
+				Type:    eventbus.EventFpRegistered,
+				FpBtcPk: ev.btcPubKey.MarshalHex(),
+				TxHash:  ev.txHash,
+			})
 
 			// return to the caller
 			ev.successResponse <- &RegisterFinalityProviderResponse{
@@ -623,7 +1068,7 @@ func (app *FinalityProviderApp) registrationLoop() {
 func (app *FinalityProviderApp) metricsUpdateLoop() {
 	defer app.wg.Done()
 
-	interval := app.config.Metrics.UpdateInterval
+	interval := app.config().Metrics.UpdateInterval
 	app.logger.Info("starting metrics update loop",
 		zap.Float64("interval seconds", interval.Seconds()))
 	updateTicker := time.NewTicker(interval)
@@ -631,12 +1076,9 @@ func (app *FinalityProviderApp) metricsUpdateLoop() {
 	for {
 		select {
 		case <-updateTicker.C:
-			fps, err := app.fps.GetAllStoredFinalityProviders()
-			if err != nil {
-				app.logger.Error("failed to get finality-providers from the store", zap.Error(err))
-				continue
-			}
-			app.metrics.UpdateFpMetrics(fps)
+			// the interval might have been changed by a config reload
+			updateTicker.Reset(app.config().Metrics.UpdateInterval)
+			app.updateFpMetrics()
 		case <-app.quit:
 			updateTicker.Stop()
 			app.logger.Info("exiting metrics update loop")
@@ -645,16 +1087,36 @@ func (app *FinalityProviderApp) metricsUpdateLoop() {
 	}
 }
 
+// updateFpMetrics updates the metrics of the stored finality providers
+// page by page to avoid loading all of them at once
+func (app *FinalityProviderApp) updateFpMetrics() {
+	q := &store.FinalityProviderQuery{Limit: metricsUpdatePageSize}
+	for {
+		fps, nextKey, err := app.fps.QueryFinalityProviders(q)
+		if err != nil {
+			app.logger.Error("failed to get finality-providers from the store", zap.Error(err))
+			return
+		}
+		app.metrics.UpdateFpMetrics(fps)
+
+		if nextKey == nil {
+			return
+		}
+		q.StartKey = nextKey
+	}
+}
+
 // syncChainFpStatusLoop keeps querying the chain for the finality
 // provider voting power and update the FP status accordingly.
 // If there is some voting power it sets to active, for zero voting power
 // it goes from: CREATED -> REGISTERED or ACTIVE -> INACTIVE.
-// if there is any node running or a new finality provider instance
-// is started, the loop stops.
+// The loop keeps running until the app stops so that the finality
+// providers added later and the instances which have stopped are
+// picked up again.
 func (app *FinalityProviderApp) syncChainFpStatusLoop() {
 	defer app.wg.Done()
 
-	interval := app.config.SyncFpStatusInterval
+	interval := app.config().SyncFpStatusInterval
 	app.logger.Info(
 		"starting sync FP status loop",
 		zap.Float64("interval seconds", interval.Seconds()),
@@ -665,12 +1127,29 @@ func (app *FinalityProviderApp) syncChainFpStatusLoop() {
 	for {
 		select {
 		case <-syncFpStatusTicker.C:
-			fpInstanceStarted, err := app.SyncFinalityProviderStatus()
+			// the ticker might fire along with the quit signal while a
+			// previous sync was blocked, so do not sync again upon stop
+			select {
+			case <-app.quit:
+				app.logger.Info("exiting sync FP status loop")
+				return
+			default:
+			}
+			// the interval might have been changed by a config reload
+			syncFpStatusTicker.Reset(app.config().SyncFpStatusInterval)
+			var (
+				started int
+				err     error
+			)
+			if !app.fpManager.maintenance.do(func() { started, err = app.SyncFinalityProviderStatus() }) {
+				app.logger.Debug("the daemon is in the maintenance mode, skip syncing finality-provider status")
+				continue
+			}
 			if err != nil {
 				app.Logger().Error("failed to sync finality-provider status", zap.Error(err))
 			}
-			if fpInstanceStarted {
-				return
+			if started > 0 {
+				app.logger.Info("started finality provider instances upon status sync", zap.Int("started", started))
 			}
 
 		case <-app.quit:
@@ -1,8 +1,10 @@
 package service_test
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"math/rand"
 	"os"
 	"path/filepath"
@@ -10,20 +12,34 @@ import (
 	"testing"
 	"time"
 
+	errorsmod "cosmossdk.io/errors"
+	sdkmath "cosmossdk.io/math"
 	"github.com/babylonlabs-io/babylon/testutil/datagen"
 	bbntypes "github.com/babylonlabs-io/babylon/types"
 	bstypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
 	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/babylonlabs-io/finality-provider/eotsmanager"
 	eotscfg "github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/audit"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	fpkr "github.com/babylonlabs-io/finality-provider/keyring"
+	"github.com/babylonlabs-io/finality-provider/log"
 	"github.com/babylonlabs-io/finality-provider/testutil"
+	"github.com/babylonlabs-io/finality-provider/testutil/mocks"
 	"github.com/babylonlabs-io/finality-provider/types"
 )
 
@@ -103,6 +119,10 @@ func FuzzRegisterFinalityProvider(f *testing.F) {
 		}
 		popBytes, err := pop.Marshal()
 		require.NoError(t, err)
+		// the stored proof-of-possession is the one of the registration
+		storedPop, err := app.GetPop(fp.GetBIP340BTCPK())
+		require.NoError(t, err)
+		require.Equal(t, pop, storedPop)
 		fpInfo, err := app.GetFinalityProviderInfo(fp.GetBIP340BTCPK())
 		require.NoError(t, err)
 		require.Equal(t, proto.FinalityProviderStatus_name[0], fpInfo.Status)
@@ -128,7 +148,7 @@ func FuzzRegisterFinalityProvider(f *testing.F) {
 		err = app.StartHandlingFinalityProvider(fp.GetBIP340BTCPK(), passphrase)
 		require.NoError(t, err)
 
-		fpAfterReg, err := app.GetFinalityProviderInstance()
+		fpAfterReg, err := app.GetFinalityProviderInstance(fp.GetBIP340BTCPK())
 		require.NoError(t, err)
 		require.Equal(t, proto.FinalityProviderStatus_REGISTERED, fpAfterReg.GetStoreFinalityProvider().Status)
 
@@ -136,6 +156,14 @@ func FuzzRegisterFinalityProvider(f *testing.F) {
 		require.NoError(t, err)
 		require.Equal(t, proto.FinalityProviderStatus_name[1], fpInfo.Status)
 		require.Equal(t, true, fpInfo.IsRunning)
+
+		// the live status of the instance comes with the tip of the chain
+		instanceStatus, err := app.InstanceStatus(fp.GetBIP340BTCPK())
+		require.NoError(t, err)
+		require.Equal(t, proto.FinalityProviderStatus_name[1], instanceStatus.Status)
+		require.Equal(t, currentHeight, instanceStatus.ChainTip)
+		require.Zero(t, instanceStatus.LastCommittedPubRandHeight)
+		require.False(t, instanceStatus.Jailed)
 	})
 }
 
@@ -192,6 +220,13 @@ func FuzzSyncFinalityProviderStatus(f *testing.F) {
 
 		err = app.Start()
 		require.NoError(t, err)
+		// the app is stopped so that its loops do not call the mocks after
+		// the test
+		defer func() {
+			require.NoError(t, app.Stop())
+			require.NoError(t, fpdb.Close())
+			require.NoError(t, dbBackend.Close())
+		}()
 
 		eotsKeyName := testutil.GenRandomHexStr(r, 4)
 		require.NoError(t, err)
@@ -212,7 +247,7 @@ func FuzzSyncFinalityProviderStatus(f *testing.F) {
 			if noVotingPowerTable {
 				expectedStatus = proto.FinalityProviderStatus_REGISTERED
 			}
-			fpInstance, err := app.GetFinalityProviderInstance()
+			fpInstance, err := app.GetFinalityProviderInstance(fpPk)
 			if err != nil {
 				return false
 			}
@@ -225,6 +260,86 @@ func FuzzSyncFinalityProviderStatus(f *testing.F) {
 	})
 }
 
+func TestSyncFinalityProviderStatusReconciles(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	logger := zap.NewNop()
+
+	eotsHomeDir := filepath.Join(t.TempDir(), "eots-home")
+	eotsCfg := eotscfg.DefaultConfigWithHomePath(eotsHomeDir)
+	eotsdb, err := eotsCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	em, err := eotsmanager.NewLocalEOTSManager(eotsHomeDir, eotsCfg.KeyringBackend, eotsdb, logger)
+	require.NoError(t, err)
+
+	fpCfg := config.DefaultConfigWithHome(filepath.Join(t.TempDir(), "fp-home"))
+	// the status is synced manually
+	fpCfg.SyncFpStatusInterval = time.Hour
+	fpCfg.StatusUpdateInterval = time.Hour
+	fpdb, err := fpCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+
+	currentHeight := uint64(r.Int63n(100) + 1)
+	mockClientController := testutil.PrepareMockedClientController(t, r, currentHeight, currentHeight, 0)
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), uint64(1)).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryBlock(gomock.Any()).Return(nil, errors.New("chain not online")).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+
+	app, err := service.NewFinalityProviderApp(&fpCfg, mockClientController, em, fpdb, logger)
+	require.NoError(t, err)
+	require.NoError(t, app.Start())
+	t.Cleanup(func() {
+		require.NoError(t, app.Stop())
+		require.NoError(t, fpdb.Close())
+		require.NoError(t, eotsdb.Close())
+	})
+
+	createFp := func() *bbntypes.BIP340PubKey {
+		eotsPkBz, err := em.CreateKey(testutil.GenRandomHexStr(r, 4), passphrase, hdPath)
+		require.NoError(t, err)
+		eotsPk, err := bbntypes.NewBIP340PubKey(eotsPkBz)
+		require.NoError(t, err)
+		testutil.GenStoredFinalityProvider(r, t, app, "", hdPath, eotsPk)
+		return eotsPk
+	}
+	isRunning := func(fpPk *bbntypes.BIP340PubKey) bool {
+		fpInfo, err := app.GetFinalityProviderInfo(fpPk)
+		require.NoError(t, err)
+		return fpInfo.IsRunning
+	}
+
+	fpPk1 := createFp()
+	started, err := app.SyncFinalityProviderStatus()
+	require.NoError(t, err)
+	require.Equal(t, 1, started)
+	require.True(t, isRunning(fpPk1))
+
+	// the running instance is left as is
+	started, err = app.SyncFinalityProviderStatus()
+	require.NoError(t, err)
+	require.Zero(t, started)
+
+	// the instance stopped by the operator is not restarted
+	require.NoError(t, app.StopFinalityProvider(fpPk1))
+	started, err = app.SyncFinalityProviderStatus()
+	require.NoError(t, err)
+	require.Zero(t, started)
+	require.False(t, isRunning(fpPk1))
+
+	// the finality provider added later is picked up
+	fpPk2 := createFp()
+	started, err = app.SyncFinalityProviderStatus()
+	require.NoError(t, err)
+	require.Equal(t, 1, started)
+	require.True(t, isRunning(fpPk2))
+	require.False(t, isRunning(fpPk1))
+
+	// the instance started again by the operator is reconciled again
+	require.NoError(t, app.StartHandlingFinalityProvider(fpPk1, ""))
+	require.True(t, isRunning(fpPk1))
+}
+
 func FuzzUnjailFinalityProvider(f *testing.F) {
 	testutil.AddRandomSeedsToFuzzer(f, 10)
 	f.Fuzz(func(t *testing.T, seed int64) {
@@ -297,3 +412,1039 @@ func FuzzUnjailFinalityProvider(f *testing.F) {
 		require.Equal(t, proto.FinalityProviderStatus_INACTIVE.String(), fpInfo.GetStatus())
 	})
 }
+
+func TestEditFinalityProvider(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	logger := zap.NewNop()
+
+	eotsHomeDir := filepath.Join(t.TempDir(), "eots-home")
+	eotsCfg := eotscfg.DefaultConfigWithHomePath(eotsHomeDir)
+	dbBackend, err := eotsCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	em, err := eotsmanager.NewLocalEOTSManager(eotsHomeDir, eotsCfg.KeyringBackend, dbBackend, logger)
+	require.NoError(t, err)
+
+	fpHomeDir := filepath.Join(t.TempDir(), "fp-home")
+	fpCfg := config.DefaultConfigWithHome(fpHomeDir)
+	fpdb, err := fpCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+
+	randomStartingHeight := uint64(r.Int63n(100) + 1)
+	mockClientController := testutil.PrepareMockedClientController(t, r, randomStartingHeight, randomStartingHeight, 0)
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any()).Return(false, false, nil).AnyTimes()
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
+
+	app, err := service.NewFinalityProviderApp(&fpCfg, mockClientController, em, fpdb, logger)
+	require.NoError(t, err)
+	err = app.Start()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, app.Stop())
+	}()
+
+	eotsPkBz, err := em.CreateKey(testutil.GenRandomHexStr(r, 4), passphrase, hdPath)
+	require.NoError(t, err)
+	eotsPk, err := bbntypes.NewBIP340PubKey(eotsPkBz)
+	require.NoError(t, err)
+	fp := testutil.GenStoredFinalityProvider(r, t, app, "", hdPath, eotsPk)
+
+	// an invalid description is rejected before sending the transaction
+	err = app.EditFinalityProvider(fp.GetBIP340BTCPK(), &proto.Description{Moniker: strings.Repeat("a", 100)}, nil)
+	require.ErrorContains(t, err, "invalid description")
+
+	// the stored description is reconciled with the values included on chain
+	updatedDesc := testutil.RandomDescription(r)
+	updatedCommission := fp.Commission.Add(sdkmath.LegacyNewDecWithPrec(1, 2))
+	mockClientController.EXPECT().EditFinalityProvider(fp.BtcPk, nil, gomock.Any()).
+		Return(&bstypes.MsgEditFinalityProvider{Description: updatedDesc, Commission: &updatedCommission}, nil).Times(1)
+	err = app.EditFinalityProvider(fp.GetBIP340BTCPK(), &proto.Description{Moniker: updatedDesc.Moniker}, nil)
+	require.NoError(t, err)
+
+	storedFp, err := app.GetFinalityProviderStore().GetFinalityProvider(fp.BtcPk)
+	require.NoError(t, err)
+	require.Equal(t, updatedDesc.Moniker, storedFp.Description.Moniker)
+	require.True(t, updatedCommission.Equal(*storedFp.Commission))
+}
+
+// minCommissionRateController overrides the minimum commission rate of the
+// mocked client controller
+type minCommissionRateController struct {
+	*mocks.MockClientController
+	minRate sdkmath.LegacyDec
+}
+
+func (c *minCommissionRateController) QueryMinCommissionRate() (sdkmath.LegacyDec, error) {
+	return c.minRate, nil
+}
+
+func TestCreateFinalityProviderValidation(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	logger := zap.NewNop()
+
+	eotsHomeDir := filepath.Join(t.TempDir(), "eots-home")
+	eotsCfg := eotscfg.DefaultConfigWithHomePath(eotsHomeDir)
+	dbBackend, err := eotsCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	em, err := eotsmanager.NewLocalEOTSManager(eotsHomeDir, eotsCfg.KeyringBackend, dbBackend, logger)
+	require.NoError(t, err)
+
+	fpHomeDir := filepath.Join(t.TempDir(), "fp-home")
+	fpCfg := config.DefaultConfigWithHome(fpHomeDir)
+	fpdb, err := fpCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+
+	randomStartingHeight := uint64(r.Int63n(100) + 1)
+	mockClientController := testutil.PrepareMockedClientController(t, r, randomStartingHeight, randomStartingHeight, 0)
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
+	cc := &minCommissionRateController{
+		MockClientController: mockClientController,
+		minRate:              sdkmath.LegacyNewDecWithPrec(5, 2),
+	}
+
+	app, err := service.NewFinalityProviderApp(&fpCfg, cc, em, fpdb, logger)
+	require.NoError(t, err)
+	err = app.Start()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, app.Stop())
+	}()
+
+	eotsPkBz, err := em.CreateKey(testutil.GenRandomHexStr(r, 4), passphrase, hdPath)
+	require.NoError(t, err)
+	eotsPk, err := bbntypes.NewBIP340PubKey(eotsPkBz)
+	require.NoError(t, err)
+	keyName := testutil.GenRandomHexStr(r, 4)
+	_, err = service.CreateChainKey(fpCfg.BabylonConfig.KeyDirectory, fpCfg.BabylonConfig.ChainID, keyName, fpCfg.BabylonConfig.KeyringBackend, passphrase, hdPath, "")
+	require.NoError(t, err)
+
+	validCommission := sdkmath.LegacyNewDecWithPrec(1, 1)
+	lowCommission := sdkmath.LegacyNewDecWithPrec(1, 2)
+	highCommission := sdkmath.LegacyNewDecWithPrec(15, 1)
+	emptyDesc := stakingtypes.Description{}
+	longDesc := stakingtypes.Description{Moniker: strings.Repeat("a", 100)}
+
+	testCases := []struct {
+		name        string
+		description *stakingtypes.Description
+		commission  *sdkmath.LegacyDec
+		expectedErr string
+		errType     error
+	}{
+		{"empty moniker", &emptyDesc, &validCommission, "the moniker cannot be empty", service.ErrInvalidDescription},
+		{"too long moniker", &longDesc, &validCommission, "invalid description", service.ErrInvalidDescription},
+		{"empty commission", testutil.RandomDescription(r), nil, "the commission rate cannot be empty", service.ErrInvalidCommission},
+		{"commission above 1", testutil.RandomDescription(r), &highCommission, "cannot be greater than 1", service.ErrInvalidCommission},
+		{"commission below the minimum", testutil.RandomDescription(r), &lowCommission, "lower than the minimum commission rate", service.ErrInvalidCommission},
+	}
+	for _, tc := range testCases {
+		_, err := app.CreateFinalityProvider(keyName, fpCfg.BabylonConfig.ChainID, passphrase, hdPath, eotsPk, tc.description, tc.commission)
+		require.ErrorContains(t, err, tc.expectedErr, tc.name)
+		require.ErrorIs(t, err, tc.errType, tc.name)
+		require.Equal(t, codes.InvalidArgument, status.Code(service.ToGRPCError(err)), tc.name)
+	}
+
+	// nothing is stored for the rejected requests
+	fps, err := app.ListAllFinalityProvidersInfo()
+	require.NoError(t, err)
+	require.Empty(t, fps)
+
+	res, err := app.CreateFinalityProvider(keyName, fpCfg.BabylonConfig.ChainID, passphrase, hdPath, eotsPk, testutil.RandomDescription(r), &validCommission)
+	require.NoError(t, err)
+	require.Equal(t, eotsPk.MarshalHex(), res.FpInfo.BtcPkHex)
+}
+
+func TestReloadConfig(t *testing.T) {
+	t.Parallel()
+
+	fpHomeDir := filepath.Join(t.TempDir(), "fp-home")
+	fpCfg := config.DefaultConfigWithHome(fpHomeDir)
+	fpdb, err := fpCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, fpdb.Close())
+	})
+
+	ctl := gomock.NewController(t)
+	app, err := service.NewFinalityProviderApp(&fpCfg, mocks.NewMockClientController(ctl), nil, fpdb, zap.NewNop())
+	require.NoError(t, err)
+
+	// the reload is disabled by default
+	_, err = app.ReloadConfig()
+	require.Error(t, err)
+
+	newCfg := config.DefaultConfigWithHome(fpHomeDir)
+	newCfg.LogLevel = "debug"
+	newCfg.NumPubRand = fpCfg.NumPubRand + 1
+	newCfg.SignatureSubmissionInterval = 5 * time.Second
+	newCfg.RPCListener = "127.0.0.1:1234"
+	newLogCfg := config.DefaultLogConfig()
+	newLogCfg.ModuleLevels = []string{log.ModuleClientController + "=warn"}
+	newCfg.LogConfig = &newLogCfg
+	logLevels := log.NewLevels(zap.InfoLevel)
+	app.EnableConfigReload(func() (*config.Config, error) {
+		cfg := newCfg
+		return &cfg, nil
+	}, logLevels)
+
+	oldCfg := app.GetConfig()
+	res, err := app.ReloadConfig()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"loglevel", "logconfig.modulelevel", "numpubrand", "signaturesubmissioninterval"}, res.Updated)
+	require.Equal(t, []string{"rpclistener"}, res.RestartRequired)
+	require.Equal(t, zap.DebugLevel, logLevels.Root())
+	require.Equal(t, zap.WarnLevel, logLevels.LevelOf(log.ModuleClientController))
+	require.Equal(t, newCfg.NumPubRand, app.GetConfig().NumPubRand)
+	require.Equal(t, newCfg.SignatureSubmissionInterval, app.GetConfig().SignatureSubmissionInterval)
+	require.Equal(t, config.DefaultRPCListener, app.GetConfig().RPCListener)
+	// the config read by the running loops is replaced rather than modified
+	require.NotSame(t, oldCfg, app.GetConfig())
+	require.Equal(t, fpCfg.NumPubRand, oldCfg.NumPubRand)
+	require.Equal(t, fpCfg.SignatureSubmissionInterval, oldCfg.SignatureSubmissionInterval)
+
+	// invalid configs are not applied
+	validCfg := newCfg
+	for _, invalidate := range []func(cfg *config.Config){
+		func(cfg *config.Config) { cfg.RandomnessCommitInterval = 0 },
+		func(cfg *config.Config) { cfg.SignatureSubmissionInterval = 0 },
+		func(cfg *config.Config) { cfg.SubmissionRetryInterval = 0 },
+		func(cfg *config.Config) { cfg.NumPubRand = 0 },
+		func(cfg *config.Config) { cfg.NumPubRand = cfg.NumPubRandMax + 1 },
+	} {
+		newCfg = validCfg
+		invalidate(&newCfg)
+		_, err = app.ReloadConfig()
+		require.Error(t, err)
+	}
+	require.Equal(t, validCfg.NumPubRand, app.GetConfig().NumPubRand)
+	require.Equal(t, validCfg.SignatureSubmissionInterval, app.GetConfig().SignatureSubmissionInterval)
+	require.NotZero(t, app.GetConfig().RandomnessCommitInterval)
+	require.NotZero(t, app.GetConfig().SubmissionRetryInterval)
+
+	// the reloads are recorded in the audit log, including the failed ones
+	entries, err := audit.ReadEntries(fpCfg.AuditConfig.Path)
+	require.NoError(t, err)
+	require.Len(t, entries, 7)
+	for _, e := range entries {
+		require.Equal(t, audit.OpReloadConfig, e.Operation)
+	}
+	require.NotEmpty(t, entries[0].Error)
+	require.Equal(t, "rpclistener", entries[1].Details["restart_required"])
+	require.Empty(t, entries[1].Error)
+	for _, e := range entries[2:] {
+		require.NotEmpty(t, e.Error)
+	}
+	require.NoError(t, audit.Verify(entries))
+}
+
+func TestSetLogLevel(t *testing.T) {
+	t.Parallel()
+	fpHomeDir := filepath.Join(t.TempDir(), "fp-home")
+	fpCfg := config.DefaultConfigWithHome(fpHomeDir)
+	fpdb, err := fpCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, fpdb.Close())
+	})
+
+	ctl := gomock.NewController(t)
+	app, err := service.NewFinalityProviderApp(&fpCfg, mocks.NewMockClientController(ctl), nil, fpdb, zap.NewNop())
+	require.NoError(t, err)
+
+	// the log levels cannot be changed without the reload
+	_, err = app.SetLogLevel("debug", "")
+	require.Error(t, err)
+
+	logLevels := log.NewLevels(zap.InfoLevel)
+	app.EnableConfigReload(func() (*config.Config, error) {
+		cfg := fpCfg
+		return &cfg, nil
+	}, logLevels)
+
+	levels, err := app.SetLogLevel("debug", log.ModuleFpInstance)
+	require.NoError(t, err)
+	require.Equal(t, "info", levels.Root)
+	require.Equal(t, map[string]string{log.ModuleFpInstance: "debug"}, levels.Modules)
+	// the level of a module applies to its sub-modules
+	require.Equal(t, zap.DebugLevel, logLevels.LevelOf(log.ModuleFpInstance+"."+log.ModuleChainPoller))
+	require.Equal(t, zap.InfoLevel, logLevels.LevelOf(log.ModuleRPC))
+
+	levels, err = app.SetLogLevel("warn", "")
+	require.NoError(t, err)
+	require.Equal(t, "warn", levels.Root)
+
+	levels, err = app.SetLogLevel("", log.ModuleFpInstance)
+	require.NoError(t, err)
+	require.Empty(t, levels.Modules)
+	require.Equal(t, zap.WarnLevel, logLevels.LevelOf(log.ModuleFpInstance))
+
+	_, err = app.SetLogLevel("verbose", "")
+	require.ErrorIs(t, err, service.ErrInvalidLogLevel)
+	_, err = app.SetLogLevel("debug", "unknown")
+	require.ErrorIs(t, err, service.ErrInvalidLogLevel)
+	_, err = app.SetLogLevel("", "")
+	require.ErrorIs(t, err, service.ErrInvalidLogLevel)
+}
+
+func TestWithdrawRewards(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	fpHomeDir := filepath.Join(t.TempDir(), "fp-home")
+	fpCfg := config.DefaultConfigWithHome(fpHomeDir)
+	fpCfg.RewardWithdrawalConfig.Recipient = sdk.AccAddress(datagen.GenRandomByteArray(r, 20)).String()
+	fpdb, err := fpCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, fpdb.Close())
+	})
+
+	ctl := gomock.NewController(t)
+	mockClientController := mocks.NewMockClientController(ctl)
+	app, err := service.NewFinalityProviderApp(&fpCfg, mockClientController, nil, fpdb, zap.NewNop())
+	require.NoError(t, err)
+
+	// nothing is withdrawn without rewards
+	mockClientController.EXPECT().QueryFinalityProviderRewards().Return(sdk.NewCoins(), nil).Times(1)
+	_, _, err = app.WithdrawRewards("")
+	require.ErrorIs(t, err, service.ErrNoWithdrawableRewards)
+
+	// the rewards are sent to the configured recipient by default
+	rewards := sdk.NewCoins(sdk.NewInt64Coin("ubbn", r.Int63n(1000000)+1))
+	expectedTxHash := testutil.GenRandomHexStr(r, 32)
+	mockClientController.EXPECT().QueryFinalityProviderRewards().Return(rewards, nil).Times(2)
+	mockClientController.EXPECT().WithdrawFinalityProviderRewards(rewards, fpCfg.RewardWithdrawalConfig.Recipient).
+		Return(&types.TxResponse{TxHash: expectedTxHash}, nil).Times(1)
+	txHash, amount, err := app.WithdrawRewards("")
+	require.NoError(t, err)
+	require.Equal(t, expectedTxHash, txHash)
+	require.Equal(t, rewards, amount)
+
+	// or to the given recipient
+	recipient := sdk.AccAddress(datagen.GenRandomByteArray(r, 20)).String()
+	mockClientController.EXPECT().WithdrawFinalityProviderRewards(rewards, recipient).
+		Return(&types.TxResponse{TxHash: expectedTxHash}, nil).Times(1)
+	_, _, err = app.WithdrawRewards(recipient)
+	require.NoError(t, err)
+}
+
+func TestWithdrawFinalityProviderRewards(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	fpHomeDir := filepath.Join(t.TempDir(), "fp-home")
+	fpCfg := config.DefaultConfigWithHome(fpHomeDir)
+	fpCfg.RewardWithdrawalConfig.Recipient = sdk.AccAddress(datagen.GenRandomByteArray(r, 20)).String()
+	fpdb, err := fpCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, fpdb.Close())
+	})
+
+	ctl := gomock.NewController(t)
+	mockClientController := mocks.NewMockClientController(ctl)
+	app, err := service.NewFinalityProviderApp(&fpCfg, mockClientController, nil, fpdb, zap.NewNop())
+	require.NoError(t, err)
+
+	fp := testutil.GenRandomFinalityProvider(r, t)
+	fpAddr, err := sdk.AccAddressFromBech32(fp.FPAddr)
+	require.NoError(t, err)
+	err = app.GetFinalityProviderStore().CreateFinalityProvider(fpAddr, fp.BtcPk, fp.Description, fp.Commission,
+		fp.KeyName, fp.ChainID, fp.Pop.BtcSig)
+	require.NoError(t, err)
+
+	// the finality providers unknown to the daemon are rejected
+	unknownFp := testutil.GenRandomFinalityProvider(r, t)
+	_, _, err = app.QueryRewards(unknownFp.GetBIP340BTCPK())
+	require.ErrorIs(t, err, store.ErrFinalityProviderNotFound)
+
+	// nothing is withdrawn without rewards
+	mockClientController.EXPECT().QueryRewardsOfFinalityProvider(fp.BtcPk).Return(sdk.NewCoins(), nil).Times(1)
+	_, _, err = app.WithdrawFinalityProviderRewards(fp.GetBIP340BTCPK(), "")
+	require.ErrorIs(t, err, service.ErrNoWithdrawableRewards)
+
+	// the rewards are queried along with the address they accrue to
+	rewards := sdk.NewCoins(sdk.NewInt64Coin("ubbn", r.Int63n(1000000)+1))
+	mockClientController.EXPECT().QueryRewardsOfFinalityProvider(fp.BtcPk).Return(rewards, nil).Times(2)
+	addr, queried, err := app.QueryRewards(fp.GetBIP340BTCPK())
+	require.NoError(t, err)
+	require.Equal(t, fp.FPAddr, addr)
+	require.Equal(t, rewards, queried)
+
+	// the rewards are sent to the configured recipient by default
+	expectedTxHash := testutil.GenRandomHexStr(r, 32)
+	mockClientController.EXPECT().WithdrawRewardsOfFinalityProvider(fp.BtcPk, rewards, fpCfg.RewardWithdrawalConfig.Recipient).
+		Return(&types.TxResponse{TxHash: expectedTxHash}, nil).Times(1)
+	txHash, amount, err := app.WithdrawFinalityProviderRewards(fp.GetBIP340BTCPK(), "")
+	require.NoError(t, err)
+	require.Equal(t, expectedTxHash, txHash)
+	require.Equal(t, rewards, amount)
+}
+
+func TestGetInfo(t *testing.T) {
+	t.Parallel()
+
+	fpHomeDir := filepath.Join(t.TempDir(), "fp-home")
+	fpCfg := config.DefaultConfigWithHome(fpHomeDir)
+	fpdb, err := fpCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, fpdb.Close())
+	})
+
+	ctl := gomock.NewController(t)
+	mockClientController := mocks.NewMockClientController(ctl)
+	app, err := service.NewFinalityProviderApp(&fpCfg, mockClientController, nil, fpdb, zap.NewNop())
+	require.NoError(t, err)
+
+	mockClientController.EXPECT().QueryBestBlock().Return(&types.BlockInfo{Height: 100}, nil).Times(1)
+	mockClientController.EXPECT().QuerySignerBalance().Return(sdk.NewCoins(sdk.NewInt64Coin("ubbn", 1000)), nil).Times(1)
+	info := app.GetInfo()
+	require.Equal(t, fpCfg.BabylonConfig.ChainID, info.ChainId)
+	require.NotEmpty(t, info.Version)
+	require.NotEmpty(t, info.Commit)
+	require.Equal(t, uint64(100), info.NodeHeight)
+	require.Zero(t, info.ProcessedHeight)
+	require.Zero(t, info.NumRunningFps)
+	require.False(t, info.EotsdConnected)
+	require.False(t, info.InMaintenance)
+	require.Equal(t, "1000ubbn", info.SignerBalance)
+
+	// an unreachable node is reported with the zero height
+	mockClientController.EXPECT().QueryBestBlock().Return(nil, errors.New("connection refused")).Times(1)
+	mockClientController.EXPECT().QuerySignerBalance().Return(nil, errors.New("connection refused")).Times(1)
+	info = app.GetInfo()
+	require.Zero(t, info.NodeHeight)
+	require.Empty(t, info.SignerBalance)
+
+	// only the running instances have a status
+	_, btcPk, err := datagen.GenRandomBTCKeyPair(rand.New(rand.NewSource(time.Now().UnixNano())))
+	require.NoError(t, err)
+	_, err = app.InstanceStatus(bbntypes.NewBIP340PubKeyFromBTCPK(btcPk))
+	require.ErrorIs(t, err, service.ErrFinalityProviderNotRunning)
+}
+
+// TestDryRunSignRecords tests that the dry-run mode refuses to sign at the
+// heights recorded in the sign records of the daemon, without recording its
+// own signatures in them
+func TestDryRunSignRecords(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	fpHomeDir := filepath.Join(t.TempDir(), "fp-home")
+	fpCfg := config.DefaultConfigWithHome(fpHomeDir)
+	err := os.MkdirAll(fpCfg.DatabaseConfig.DBPath, 0o700)
+	require.NoError(t, err)
+
+	// the daemon has signed at height 10
+	chainID, pk := []byte(fpCfg.BabylonConfig.ChainID), datagen.GenRandomByteArray(r, 32)
+	signedMsg := datagen.GenRandomByteArray(r, 40)
+	signRecordDB, err := fpCfg.DatabaseConfig.GetSignRecordDBBackend()
+	require.NoError(t, err)
+	signRecords, err := store.NewSignRecordStore(signRecordDB)
+	require.NoError(t, err)
+	err = signRecords.SaveSignRecord(chainID, pk, 10, signedMsg)
+	require.NoError(t, err)
+	require.NoError(t, signRecordDB.Close())
+
+	fpCfg.DryRun = true
+	fpdb, err := store.OpenInMemoryCopy(fpCfg.DatabaseConfig.GetDBBackend)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, fpdb.Close())
+	})
+	ctl := gomock.NewController(t)
+	mockClientController := mocks.NewMockClientController(ctl)
+	app, err := service.NewFinalityProviderApp(&fpCfg, mockClientController, nil, fpdb, zap.NewNop())
+	require.NoError(t, err)
+
+	// the rehearsal refuses to sign a different message at the signed height
+	dryRunRecords := app.GetSignRecordStore()
+	err = dryRunRecords.SaveSignRecord(chainID, pk, 10, datagen.GenRandomByteArray(r, 40))
+	require.ErrorIs(t, err, store.ErrDoubleSign)
+	err = dryRunRecords.SaveSignRecord(chainID, pk, 11, signedMsg)
+	require.NoError(t, err)
+
+	// the signature of the rehearsal is not recorded by the daemon
+	signRecordDB, err = fpCfg.DatabaseConfig.GetSignRecordDBBackend()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, signRecordDB.Close())
+	}()
+	signRecords, err = store.NewSignRecordStore(signRecordDB)
+	require.NoError(t, err)
+	_, err = signRecords.GetSignRecord(chainID, pk, 11)
+	require.ErrorIs(t, err, store.ErrSignRecordNotFound)
+}
+
+// TestPruneDataOfConfiguredChain tests that only the data of the finality
+// providers of the configured chain is pruned, as the heights of the other
+// chains are not checked against their last finalized height
+func TestPruneDataOfConfiguredChain(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	fpHomeDir := filepath.Join(t.TempDir(), "fp-home")
+	fpCfg := config.DefaultConfigWithHome(fpHomeDir)
+	fpdb, err := fpCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, fpdb.Close())
+	})
+
+	ctl := gomock.NewController(t)
+	mockClientController := mocks.NewMockClientController(ctl)
+	app, err := service.NewFinalityProviderApp(&fpCfg, mockClientController, nil, fpdb, zap.NewNop())
+	require.NoError(t, err)
+
+	// a finality provider of the configured chain and one of another chain
+	// have proofs for the heights from 1 to 30
+	numPubRand := uint64(30)
+	var pks [][]byte
+	for _, chainID := range []string{fpCfg.BabylonConfig.ChainID, "other-chain"} {
+		fp := testutil.GenRandomFinalityProvider(r, t)
+		fpAddr, err := sdk.AccAddressFromBech32(fp.FPAddr)
+		require.NoError(t, err)
+		err = app.GetFinalityProviderStore().CreateFinalityProvider(fpAddr, fp.BtcPk, fp.Description, fp.Commission, fp.KeyName, chainID, fp.Pop.BtcSig)
+		require.NoError(t, err)
+
+		pubRandList := make([]*btcec.FieldVal, 0, numPubRand)
+		for i := uint64(0); i < numPubRand; i++ {
+			pubRandList = append(pubRandList, testutil.GenPublicRand(r, t).ToFieldVal())
+		}
+		_, proofList := types.GetPubRandCommitAndProofs(pubRandList)
+		pk := fp.GetBIP340BTCPK().MustMarshal()
+		err = app.GetPubRandProofStore().AddPubRandProofList([]byte(chainID), pk, 1, numPubRand, proofList)
+		require.NoError(t, err)
+		pks = append(pks, pk)
+	}
+
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(uint64(1)).
+		Return([]*types.BlockInfo{{Height: 20}}, nil).Times(1)
+	res, err := app.PruneData(11)
+	require.NoError(t, err)
+	require.Len(t, res.FinalityProviders, 1)
+	require.Equal(t, 10, res.FinalityProviders[0].NumPubRandProofs)
+
+	_, err = app.GetPubRandProofStore().GetPubRandProof([]byte(fpCfg.BabylonConfig.ChainID), pks[0], 10)
+	require.ErrorIs(t, err, store.ErrPubRandProofNotFound)
+	_, err = app.GetPubRandProofStore().GetPubRandProof([]byte("other-chain"), pks[1], 10)
+	require.NoError(t, err)
+}
+
+func TestExportState(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	fpHomeDir := filepath.Join(t.TempDir(), "fp-home")
+	fpCfg := config.DefaultConfigWithHome(fpHomeDir)
+	fpdb, err := fpCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, fpdb.Close())
+	})
+
+	ctl := gomock.NewController(t)
+	mockClientController := mocks.NewMockClientController(ctl)
+	app, err := service.NewFinalityProviderApp(&fpCfg, mockClientController, nil, fpdb, zap.NewNop())
+	require.NoError(t, err)
+
+	// a weak passphrase is rejected
+	err = app.ExportState(io.Discard, "short")
+	require.ErrorIs(t, err, store.ErrInvalidBackupPassphrase)
+	require.Equal(t, codes.InvalidArgument, service.GRPCCode(err))
+
+	// the exported bundle is decrypted with the passphrase
+	backupPassphrase := testutil.GenRandomHexStr(r, 8)
+	var bundle bytes.Buffer
+	err = app.ExportState(&bundle, backupPassphrase)
+	require.NoError(t, err)
+
+	backend, copyReader, err := store.ReadBackupBundle(&bundle, []byte(backupPassphrase))
+	require.NoError(t, err)
+	require.Equal(t, fpCfg.DatabaseConfig.Backend, backend)
+	dbCopy, err := io.ReadAll(copyReader)
+	require.NoError(t, err)
+	require.NotEmpty(t, dbCopy)
+}
+
+func TestStopCancelsOutstandingCalls(t *testing.T) {
+	t.Parallel()
+
+	logger := zap.NewNop()
+	eotsHomeDir := filepath.Join(t.TempDir(), "eots-home")
+	eotsCfg := eotscfg.DefaultConfigWithHomePath(eotsHomeDir)
+	eotsdb, err := eotsCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	em, err := eotsmanager.NewLocalEOTSManager(eotsHomeDir, eotsCfg.KeyringBackend, eotsdb, logger)
+	require.NoError(t, err)
+
+	fpCfg := config.DefaultConfigWithHome(filepath.Join(t.TempDir(), "fp-home"))
+	fpCfg.SyncFpStatusInterval = 10 * time.Millisecond
+	fpCfg.ShutdownGracePeriod = 100 * time.Millisecond
+	fpdb, err := fpCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, fpdb.Close())
+		require.NoError(t, eotsdb.Close())
+	})
+
+	// the query of the sync loop hangs until the client is closed
+	queried := make(chan struct{})
+	closed := make(chan struct{})
+	ctl := gomock.NewController(t)
+	mockClientController := mocks.NewMockClientController(ctl)
+	mockClientController.EXPECT().QueryBestBlock().DoAndReturn(func() (*types.BlockInfo, error) {
+		close(queried)
+		<-closed
+		return nil, errors.New("client closed")
+	}).Times(1)
+	mockClientController.EXPECT().Close().DoAndReturn(func() error {
+		close(closed)
+		return nil
+	}).Times(1)
+
+	app, err := service.NewFinalityProviderApp(&fpCfg, mockClientController, em, fpdb, logger)
+	require.NoError(t, err)
+	require.NoError(t, app.Start())
+	<-queried
+
+	start := time.Now()
+	require.NoError(t, app.Stop())
+	require.GreaterOrEqual(t, time.Since(start), fpCfg.ShutdownGracePeriod)
+}
+
+func TestHealthChecks(t *testing.T) {
+	t.Parallel()
+
+	fpCfg := config.DefaultConfigWithHome(filepath.Join(t.TempDir(), "fp-home"))
+	fpCfg.HealthConfig.CheckTimeout = 100 * time.Millisecond
+	fpdb, err := fpCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, fpdb.Close())
+	})
+
+	ctl := gomock.NewController(t)
+	mockClientController := mocks.NewMockClientController(ctl)
+	app, err := service.NewFinalityProviderApp(&fpCfg, mockClientController, nil, fpdb, zap.NewNop())
+	require.NoError(t, err)
+
+	// no instance is running
+	require.True(t, app.Liveness().Healthy())
+
+	checkStatuses := func(report *service.HealthReport, expected map[string]service.HealthStatus) {
+		require.Len(t, report.Checks, len(expected))
+		for _, c := range report.Checks {
+			require.Equal(t, expected[c.Name], c.Status, c.Name)
+		}
+	}
+
+	mockClientController.EXPECT().QueryBestBlock().Return(&types.BlockInfo{Height: 100}, nil).Times(1)
+	report := app.Readiness()
+	require.True(t, report.Healthy())
+	checkStatuses(report, map[string]service.HealthStatus{
+		"babylon":   service.HealthStatusOK,
+		"db":        service.HealthStatusOK,
+		"heartbeat": service.HealthStatusOK,
+		"block_lag": service.HealthStatusOK,
+	})
+
+	// the block lag cannot be checked without the tip
+	mockClientController.EXPECT().QueryBestBlock().Return(nil, errors.New("connection refused")).Times(1)
+	report = app.Readiness()
+	require.False(t, report.Healthy())
+	checkStatuses(report, map[string]service.HealthStatus{
+		"babylon":   service.HealthStatusFailing,
+		"db":        service.HealthStatusOK,
+		"heartbeat": service.HealthStatusOK,
+		"block_lag": service.HealthStatusFailing,
+	})
+
+	// a hanging query fails the check after the timeout
+	release := make(chan struct{})
+	defer close(release)
+	mockClientController.EXPECT().QueryBestBlock().DoAndReturn(func() (*types.BlockInfo, error) {
+		<-release
+		return &types.BlockInfo{Height: 100}, nil
+	}).Times(1)
+	report = app.Readiness()
+	require.False(t, report.Healthy())
+	require.Contains(t, report.Checks[0].Error, "timed out")
+}
+
+func TestPreflightChecks(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	logger := zap.NewNop()
+
+	eotsHomeDir := filepath.Join(t.TempDir(), "eots-home")
+	eotsCfg := eotscfg.DefaultConfigWithHomePath(eotsHomeDir)
+	eotsdb, err := eotsCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	em, err := eotsmanager.NewLocalEOTSManager(eotsHomeDir, eotsCfg.KeyringBackend, eotsdb, logger)
+	require.NoError(t, err)
+
+	fpCfg := config.DefaultConfigWithHome(filepath.Join(t.TempDir(), "fp-home"))
+	fpCfg.PreflightConfig.MinBalance = "1000ubbn"
+	fpdb, err := fpCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, fpdb.Close())
+		require.NoError(t, eotsdb.Close())
+	})
+
+	ctl := gomock.NewController(t)
+	mockClientController := mocks.NewMockClientController(ctl)
+	app, err := service.NewFinalityProviderApp(&fpCfg, mockClientController, em, fpdb, logger)
+	require.NoError(t, err)
+
+	eotsPkBz, err := em.CreateKey(testutil.GenRandomHexStr(r, 4), passphrase, hdPath)
+	require.NoError(t, err)
+	fpPk, err := bbntypes.NewBIP340PubKey(eotsPkBz)
+	require.NoError(t, err)
+
+	tipHeight := uint64(100)
+	mockClientController.EXPECT().QueryBestBlock().Return(&types.BlockInfo{Height: tipHeight}, nil).AnyTimes()
+	checkStatuses := func(report *service.HealthReport, expected map[string]service.HealthStatus) {
+		require.Len(t, report.Checks, len(expected))
+		for _, c := range report.Checks {
+			require.Equal(t, expected[c.Name], c.Status, c.Name)
+			// the checks which do not pass come with a remediation hint
+			require.Equal(t, c.Status != service.HealthStatusOK, c.Hint != "", c.Name)
+		}
+	}
+
+	mockClientController.EXPECT().QueryNodeChainID().Return(fpCfg.BabylonConfig.ChainID, nil).Times(1)
+	mockClientController.EXPECT().QuerySignerBalance().Return(sdk.NewCoins(sdk.NewInt64Coin("ubbn", 1000)), nil).Times(1)
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), uint64(1)).
+		Return(map[uint64]*finalitytypes.PubRandCommitResponse{1: {NumPubRand: tipHeight}}, nil).Times(1)
+	mockClientController.EXPECT().QueryLatestBlockTime().Return(time.Now(), nil).Times(1)
+	report := app.Preflight([]*bbntypes.BIP340PubKey{fpPk}, passphrase)
+	require.NoError(t, report.Err())
+	checkStatuses(report, map[string]service.HealthStatus{
+		"chain_id":   service.HealthStatusOK,
+		"eots_keys":  service.HealthStatusOK,
+		"balance":    service.HealthStatusOK,
+		"db":         service.HealthStatusOK,
+		"pub_rand":   service.HealthStatusOK,
+		"clock_skew": service.HealthStatusOK,
+	})
+
+	// a clock skew below the max skew only warns
+	mockClientController.EXPECT().QueryNodeChainID().Return(fpCfg.BabylonConfig.ChainID, nil).Times(1)
+	mockClientController.EXPECT().QuerySignerBalance().Return(sdk.NewCoins(sdk.NewInt64Coin("ubbn", 1000)), nil).Times(1)
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), uint64(1)).
+		Return(map[uint64]*finalitytypes.PubRandCommitResponse{1: {NumPubRand: tipHeight}}, nil).Times(1)
+	mockClientController.EXPECT().QueryLatestBlockTime().Return(time.Now().Add(-time.Minute), nil).Times(1)
+	report = app.Preflight([]*bbntypes.BIP340PubKey{fpPk}, passphrase)
+	require.NoError(t, report.Err())
+	checkStatuses(report, map[string]service.HealthStatus{
+		"chain_id":   service.HealthStatusOK,
+		"eots_keys":  service.HealthStatusOK,
+		"balance":    service.HealthStatusOK,
+		"db":         service.HealthStatusOK,
+		"pub_rand":   service.HealthStatusOK,
+		"clock_skew": service.HealthStatusWarning,
+	})
+
+	// the failing checks are consolidated, the missing public randomness
+	// only warns
+	_, unknownBtcPk, err := datagen.GenRandomBTCKeyPair(r)
+	require.NoError(t, err)
+	unknownPk := bbntypes.NewBIP340PubKeyFromBTCPK(unknownBtcPk)
+	mockClientController.EXPECT().QueryNodeChainID().Return("other-chain", nil).Times(1)
+	mockClientController.EXPECT().QuerySignerBalance().Return(sdk.NewCoins(sdk.NewInt64Coin("ubbn", 999)), nil).Times(1)
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), uint64(1)).Return(nil, nil).Times(2)
+	mockClientController.EXPECT().QueryLatestBlockTime().Return(time.Now().Add(-time.Hour), nil).Times(1)
+	report = app.Preflight([]*bbntypes.BIP340PubKey{fpPk, unknownPk}, passphrase)
+	require.False(t, report.Healthy())
+	checkStatuses(report, map[string]service.HealthStatus{
+		"chain_id":   service.HealthStatusFailing,
+		"eots_keys":  service.HealthStatusFailing,
+		"balance":    service.HealthStatusFailing,
+		"db":         service.HealthStatusOK,
+		"pub_rand":   service.HealthStatusWarning,
+		"clock_skew": service.HealthStatusFailing,
+	})
+	err = report.Err()
+	require.ErrorContains(t, err, "4 check(s) failing")
+	require.ErrorContains(t, err, unknownPk.MarshalHex())
+
+	// the doctor falls back to the passphrase of the daemon
+	app.SetPassphrase(passphrase)
+	mockClientController.EXPECT().QueryNodeChainID().Return(fpCfg.BabylonConfig.ChainID, nil).Times(1)
+	mockClientController.EXPECT().QuerySignerBalance().Return(sdk.NewCoins(sdk.NewInt64Coin("ubbn", 1000)), nil).Times(1)
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), uint64(1)).
+		Return(map[uint64]*finalitytypes.PubRandCommitResponse{1: {NumPubRand: tipHeight}}, nil).Times(1)
+	mockClientController.EXPECT().QueryLatestBlockTime().Return(time.Now(), nil).Times(1)
+	report = app.Doctor([]*bbntypes.BIP340PubKey{fpPk}, "")
+	require.NoError(t, report.Err())
+	checkStatuses(report, map[string]service.HealthStatus{
+		"chain_id":   service.HealthStatusOK,
+		"eots_keys":  service.HealthStatusOK,
+		"balance":    service.HealthStatusOK,
+		"db":         service.HealthStatusOK,
+		"pub_rand":   service.HealthStatusOK,
+		"clock_skew": service.HealthStatusOK,
+	})
+}
+
+func TestRecoverFinalityProvider(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	logger := zap.NewNop()
+
+	eotsHomeDir := filepath.Join(t.TempDir(), "eots-home")
+	eotsCfg := eotscfg.DefaultConfigWithHomePath(eotsHomeDir)
+	eotsdb, err := eotsCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	em, err := eotsmanager.NewLocalEOTSManager(eotsHomeDir, eotsCfg.KeyringBackend, eotsdb, logger)
+	require.NoError(t, err)
+
+	fpCfg := config.DefaultConfigWithHome(filepath.Join(t.TempDir(), "fp-home"))
+	fpdb, err := fpCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, fpdb.Close())
+		require.NoError(t, eotsdb.Close())
+	})
+
+	ctl := gomock.NewController(t)
+	mockClientController := mocks.NewMockClientController(ctl)
+	app, err := service.NewFinalityProviderApp(&fpCfg, mockClientController, em, fpdb, logger)
+	require.NoError(t, err)
+
+	keyName := testutil.GenRandomHexStr(r, 4)
+	kc, err := fpkr.NewChainKeyringControllerWithKeyring(app.GetKeyring(), keyName, app.GetInput())
+	require.NoError(t, err)
+	keyInfo, err := kc.CreateChainKey(passphrase, hdPath, "")
+	require.NoError(t, err)
+
+	randomFp := testutil.GenRandomFinalityProvider(r, t)
+	fpPk := randomFp.GetBIP340BTCPK()
+	chainFp := &bstypes.FinalityProviderResponse{
+		Description: randomFp.Description,
+		Commission:  randomFp.Commission,
+		Addr:        keyInfo.AccAddress.String(),
+		BtcPk:       fpPk,
+		Pop:         &bstypes.ProofOfPossessionBTC{BtcSig: randomFp.Pop.BtcSig},
+	}
+	mockClientController.EXPECT().QueryFinalityProvider(gomock.Any()).
+		Return(&bstypes.QueryFinalityProviderResponse{FinalityProvider: chainFp}, nil).AnyTimes()
+	mockClientController.EXPECT().QueryNodeChainID().Return(fpCfg.BabylonConfig.ChainID, nil).AnyTimes()
+
+	tipHeight := uint64(100)
+	lastVotedHeight := uint64(95)
+	mockClientController.EXPECT().QueryBestBlock().Return(&types.BlockInfo{Height: tipHeight}, nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), tipHeight).Return(uint64(1), nil).AnyTimes()
+	mockClientController.EXPECT().QueryVotesAtHeight(gomock.Any()).DoAndReturn(func(height uint64) ([]bbntypes.BIP340PubKey, error) {
+		if height == lastVotedHeight {
+			return []bbntypes.BIP340PubKey{*fpPk}, nil
+		}
+		return nil, nil
+	}).AnyTimes()
+
+	// the key must match the finality provider address
+	_, err = app.RecoverFinalityProvider(fpPk, "unknown-key", 10)
+	require.Error(t, err)
+
+	info, err := app.RecoverFinalityProvider(fpPk, keyName, 10)
+	require.NoError(t, err)
+	require.Equal(t, proto.FinalityProviderStatus_ACTIVE.String(), info.Status)
+	require.Equal(t, lastVotedHeight, info.LastVotedHeight)
+
+	storedFp, err := app.GetFinalityProviderStore().GetFinalityProvider(fpPk.MustToBTCPK())
+	require.NoError(t, err)
+	require.Equal(t, chainFp.Addr, storedFp.FPAddr)
+	require.Equal(t, keyName, storedFp.KeyName)
+	require.Equal(t, fpCfg.BabylonConfig.ChainID, storedFp.ChainID)
+	require.Equal(t, lastVotedHeight, storedFp.LastProcessedHeight)
+	require.True(t, chainFp.Commission.Equal(*storedFp.Commission))
+	require.Equal(t, chainFp.Description.Moniker, storedFp.Description.Moniker)
+
+	// the stored finality provider is never overwritten
+	_, err = app.RecoverFinalityProvider(fpPk, keyName, 10)
+	require.ErrorIs(t, err, store.ErrFinalityProviderExists)
+}
+
+func TestDiffFinalityProvider(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	logger := zap.NewNop()
+
+	eotsHomeDir := filepath.Join(t.TempDir(), "eots-home")
+	eotsCfg := eotscfg.DefaultConfigWithHomePath(eotsHomeDir)
+	eotsdb, err := eotsCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	em, err := eotsmanager.NewLocalEOTSManager(eotsHomeDir, eotsCfg.KeyringBackend, eotsdb, logger)
+	require.NoError(t, err)
+
+	fpCfg := config.DefaultConfigWithHome(filepath.Join(t.TempDir(), "fp-home"))
+	fpdb, err := fpCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, fpdb.Close())
+		require.NoError(t, eotsdb.Close())
+	})
+
+	ctl := gomock.NewController(t)
+	mockClientController := mocks.NewMockClientController(ctl)
+	app, err := service.NewFinalityProviderApp(&fpCfg, mockClientController, em, fpdb, logger)
+	require.NoError(t, err)
+
+	keyName := testutil.GenRandomHexStr(r, 4)
+	kc, err := fpkr.NewChainKeyringControllerWithKeyring(app.GetKeyring(), keyName, app.GetInput())
+	require.NoError(t, err)
+	keyInfo, err := kc.CreateChainKey(passphrase, hdPath, "")
+	require.NoError(t, err)
+
+	randomFp := testutil.GenRandomFinalityProvider(r, t)
+	fpPk := randomFp.GetBIP340BTCPK()
+	chainFp := &bstypes.FinalityProviderResponse{
+		Description: randomFp.Description,
+		Commission:  randomFp.Commission,
+		Addr:        keyInfo.AccAddress.String(),
+		BtcPk:       fpPk,
+		Pop:         &bstypes.ProofOfPossessionBTC{BtcSig: randomFp.Pop.BtcSig},
+	}
+	mockClientController.EXPECT().QueryFinalityProvider(gomock.Any()).
+		Return(&bstypes.QueryFinalityProviderResponse{FinalityProvider: chainFp}, nil).AnyTimes()
+	mockClientController.EXPECT().QueryNodeChainID().Return(fpCfg.BabylonConfig.ChainID, nil).AnyTimes()
+
+	tipHeight := uint64(100)
+	lastVotedHeight := uint64(95)
+	mockClientController.EXPECT().QueryBestBlock().Return(&types.BlockInfo{Height: tipHeight}, nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), tipHeight).Return(uint64(1), nil).AnyTimes()
+	mockClientController.EXPECT().QueryVotesAtHeight(gomock.Any()).DoAndReturn(func(height uint64) ([]bbntypes.BIP340PubKey, error) {
+		if height == lastVotedHeight {
+			return []bbntypes.BIP340PubKey{*fpPk}, nil
+		}
+		return nil, nil
+	}).AnyTimes()
+
+	_, err = app.RecoverFinalityProvider(fpPk, keyName, 10)
+	require.NoError(t, err)
+
+	// the recovered finality provider matches the chain
+	diff, err := app.DiffFinalityProvider(fpPk, 10, false)
+	require.NoError(t, err)
+	require.Empty(t, diff.Diffs)
+
+	// the stored status is stuck while the chain moves on
+	err = app.GetFinalityProviderStore().SetFpStatus(fpPk.MustToBTCPK(), proto.FinalityProviderStatus_INACTIVE)
+	require.NoError(t, err)
+	newCommission := randomFp.Commission.Add(sdkmath.LegacyNewDecWithPrec(1, 2))
+	chainFp.Commission = &newCommission
+	lastVotedHeight = 98
+
+	diff, err = app.DiffFinalityProvider(fpPk, 10, false)
+	require.NoError(t, err)
+	require.False(t, diff.Fixed)
+	fields := make([]string, 0, len(diff.Diffs))
+	for _, fd := range diff.Diffs {
+		fields = append(fields, fd.Field)
+	}
+	require.ElementsMatch(t, []string{
+		service.DiffFieldStatus, service.DiffFieldCommission, service.DiffFieldLastVotedHeight,
+	}, fields)
+
+	diff, err = app.DiffFinalityProvider(fpPk, 10, true)
+	require.NoError(t, err)
+	require.True(t, diff.Fixed)
+
+	storedFp, err := app.GetFinalityProviderStore().GetFinalityProvider(fpPk.MustToBTCPK())
+	require.NoError(t, err)
+	require.Equal(t, proto.FinalityProviderStatus_ACTIVE, storedFp.Status)
+	require.Equal(t, lastVotedHeight, storedFp.LastVotedHeight)
+	require.True(t, newCommission.Equal(*storedFp.Commission))
+
+	diff, err = app.DiffFinalityProvider(fpPk, 10, false)
+	require.NoError(t, err)
+	require.Empty(t, diff.Diffs)
+}
+
+func TestGRPCCode(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, codes.OK, service.GRPCCode(nil))
+	require.Equal(t, codes.AlreadyExists, service.GRPCCode(service.ErrFinalityProviderAlreadyRegistered))
+	require.Equal(t, codes.Unavailable, service.GRPCCode(service.ErrAppShuttingDown))
+	require.Equal(t, codes.FailedPrecondition, service.GRPCCode(service.ErrInMaintenance))
+	require.Equal(t, codes.ResourceExhausted, service.GRPCCode(service.ErrSubmissionRateLimited))
+
+	// the wrapped errors are matched by their cause
+	err := fmt.Errorf("failed to get finality provider from db: %w", store.ErrFinalityProviderNotFound)
+	require.Equal(t, codes.NotFound, service.GRPCCode(err))
+	grpcErr := service.ToGRPCError(err)
+	require.Equal(t, codes.NotFound, status.Code(grpcErr))
+	require.Equal(t, err.Error(), status.Convert(grpcErr).Message())
+
+	// the status errors are kept as they are
+	require.Equal(t, codes.PermissionDenied, status.Code(service.ToGRPCError(status.Error(codes.PermissionDenied, "denied"))))
+
+	// the typed causes take precedence over the wrapped Cosmos SDK errors,
+	// which implement GRPCStatus
+	sdkErr := fmt.Errorf("%w: %w", service.ErrInvalidDescription, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "moniker too long"))
+	require.Equal(t, codes.InvalidArgument, service.GRPCCode(sdkErr))
+	require.Equal(t, codes.InvalidArgument, status.Code(service.ToGRPCError(sdkErr)))
+	require.Equal(t, codes.Unknown, status.Code(service.ToGRPCError(errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "untyped"))))
+	require.Equal(t, codes.Unknown, service.GRPCCode(errors.New("untyped error")))
+
+	// the unavailable errors advise the clients to retry later
+	st := status.Convert(service.ToGRPCError(fmt.Errorf("the RPC server is draining: %w", service.ErrAppShuttingDown)))
+	require.Equal(t, codes.Unavailable, st.Code())
+	require.Len(t, st.Details(), 1)
+	retryInfo, ok := st.Details()[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	require.Positive(t, retryInfo.RetryDelay.AsDuration())
+}
+
+func TestParticipationReport(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+
+	randomStartingHeight := uint64(r.Int63n(100) + 1)
+	mockClientController := testutil.PrepareMockedClientController(t, r, randomStartingHeight, randomStartingHeight, 0)
+	app, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, randomStartingHeight)
+	defer cleanUp()
+
+	// the finality provider has voting power for the heights of the window
+	// but the last one, and only votes at the first one
+	fpPk := fpIns.GetBtcPkBIP340()
+	votedHeight := randomStartingHeight
+	pendingHeight, signedHeight := votedHeight+1, votedHeight+2
+	unsignedHeight, unprocessedHeight := votedHeight+3, votedHeight+4
+	noPowerHeight := votedHeight + 5
+	mockClientController.EXPECT().QueryVotesAtHeight(gomock.Any()).DoAndReturn(func(height uint64) ([]bbntypes.BIP340PubKey, error) {
+		if height == votedHeight {
+			return []bbntypes.BIP340PubKey{*fpPk}, nil
+		}
+		return nil, nil
+	}).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), noPowerHeight).Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).Return(uint64(1), nil).AnyTimes()
+
+	chainID, pk := fpIns.GetChainID(), fpPk.MustMarshal()
+	err := app.GetOutboxStore().AddPendingSubmissions(chainID, pk, []*store.PendingSubmission{
+		{Kind: store.SubmissionVote, Height: pendingHeight, BlockHash: testutil.GenRandomByteArray(r, 32)},
+	})
+	require.NoError(t, err)
+	err = app.GetSignRecordStore().SaveSignRecord(chainID, pk, signedHeight, testutil.GenRandomByteArray(r, 32))
+	require.NoError(t, err)
+	err = app.GetFinalityProviderStore().SetFpLastVotedHeight(fpPk.MustToBTCPK(), unsignedHeight)
+	require.NoError(t, err)
+
+	_, err = app.ParticipationReport(fpPk, noPowerHeight, votedHeight)
+	require.Error(t, err)
+
+	report, err := app.ParticipationReport(fpPk, votedHeight, noPowerHeight)
+	require.NoError(t, err)
+	require.Equal(t, fpIns.GetBtcPkHex(), report.BtcPkHex)
+	require.Equal(t, uint64(5), report.NumExpected)
+	require.Equal(t, uint64(1), report.NumVoted)
+	require.Equal(t, []*proto.MissedVote{
+		{Height: pendingHeight, Reason: "pending_broadcast"},
+		{Height: signedHeight, Reason: "signed_not_included"},
+		{Height: unsignedHeight, Reason: "not_signed"},
+		{Height: unprocessedHeight, Reason: "not_processed"},
+	}, report.MissedVotes)
+}
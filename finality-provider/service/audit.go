@@ -0,0 +1,59 @@
+package service
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/audit"
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// auditRecorder records the privileged operations in the audit log, a nil
+// recorder being a disabled audit log
+type auditRecorder struct {
+	log    *audit.Log
+	logger *zap.Logger
+}
+
+func newAuditRecorder(cfg *fpcfg.AuditConfig, logger *zap.Logger) (*auditRecorder, error) {
+	if cfg == nil || !cfg.Enabled {
+		return nil, nil
+	}
+
+	l, err := audit.NewLog(cfg.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the audit log %s: %w", cfg.Path, err)
+	}
+
+	return &auditRecorder{log: l, logger: logger}, nil
+}
+
+// record appends the given operation to the audit log. The operation has
+// already been performed, so the failure to record it is logged rather than
+// returned
+func (r *auditRecorder) record(op audit.Operation, details map[string]string, opErr error) {
+	if r == nil {
+		return
+	}
+
+	if _, err := r.log.Append(op, details, opErr); err != nil {
+		r.logger.Error("failed to record the operation in the audit log",
+			zap.String("operation", string(op)),
+			zap.String("path", r.log.Path()),
+			zap.Error(err),
+		)
+	}
+}
+
+// createKeyAuditDetails returns the details of the creation of the chain key
+// with the given name, the key info being nil if the creation has failed
+func createKeyAuditDetails(keyName string, keyInfo *types.ChainKeyInfo) map[string]string {
+	details := map[string]string{"key_name": keyName}
+	if keyInfo != nil {
+		details["address"] = keyInfo.AccAddress.String()
+	}
+
+	return details
+}
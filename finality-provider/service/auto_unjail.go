@@ -0,0 +1,172 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	bstypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/audit"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+)
+
+// autoUnjailEnabled returns whether the jailed finality providers are
+// unjailed automatically once their jailing period has elapsed
+func (fpm *FinalityProviderManager) autoUnjailEnabled() bool {
+	return fpm.config().AutoUnjailConfig != nil && fpm.config().AutoUnjailConfig.Enabled
+}
+
+// autoUnjail waits until the jailing period of the given finality provider
+// has elapsed, sends the unjail transaction and restarts its instance.
+// Failed attempts are retried with an exponential backoff until the max
+// attempts are reached, after which the finality provider has to be
+// unjailed manually
+func (fpm *FinalityProviderManager) autoUnjail(fpPk *bbntypes.BIP340PubKey, passphrase string) {
+	defer fpm.wg.Done()
+
+	cfg := fpm.config().AutoUnjailConfig
+	pkHex := fpPk.MarshalHex()
+
+	jailedUntil, err := fpm.getJailedUntilWithRetry(fpPk)
+	if err != nil {
+		fpm.logger.Error("failed to get the end of the jailing period, the finality provider has to be unjailed manually",
+			zap.String("pk", pkHex), zap.Error(err))
+		return
+	}
+
+	// a zero jailing end means that the finality provider is not jailed due
+	// to downtime, which cannot be recovered by unjailing
+	if jailedUntil.IsZero() {
+		fpm.logger.Warn("the finality provider is not jailed due to downtime, skip auto unjailing",
+			zap.String("pk", pkHex))
+		return
+	}
+
+	fpm.logger.Info("the finality provider will be unjailed automatically once the jailing period has elapsed",
+		zap.String("pk", pkHex), zap.Time("jailed_until", jailedUntil))
+
+	// the unjail transaction is rejected until the jailing period has elapsed
+	wait := time.Until(jailedUntil)
+	backoff := cfg.InitialBackoff
+	for attempt := uint32(1); attempt <= cfg.MaxAttempts; attempt++ {
+		if !fpm.sleepOrQuit(wait) {
+			return
+		}
+
+		var txHash string
+		if !fpm.maintenance.do(func() { txHash, err = fpm.unjailFinalityProvider(fpPk) }) {
+			err = ErrInMaintenance
+		}
+		switch {
+		case err == nil:
+			fpm.logger.Info("successfully unjailed the finality provider automatically",
+				zap.String("pk", pkHex), zap.String("tx_hash", txHash))
+		case strings.Contains(err.Error(), bstypes.ErrFpNotJailed.Error()):
+			// the finality provider might have been unjailed manually
+			fpm.logger.Info("the finality provider is no longer jailed", zap.String("pk", pkHex))
+		case strings.Contains(err.Error(), bstypes.ErrFpAlreadySlashed.Error()):
+			fpm.logger.Warn("the finality provider is slashed, stop auto unjailing", zap.String("pk", pkHex))
+			return
+		default:
+			fpm.logger.Warn("failed to unjail the finality provider",
+				zap.String("pk", pkHex),
+				zap.Uint32("attempt", attempt),
+				zap.Uint32("max_attempts", cfg.MaxAttempts),
+				zap.Duration("backoff", backoff),
+				zap.Error(err),
+			)
+			wait = backoff
+			backoff = min(2*backoff, cfg.MaxBackoff)
+
+			continue
+		}
+
+		if fpm.IsFinalityProviderRunning(fpPk) {
+			return
+		}
+		if err := fpm.startFinalityProviderInstance(fpPk, passphrase); err != nil {
+			fpm.logger.Error("failed to restart the finality provider instance after unjailing",
+				zap.String("pk", pkHex), zap.Error(err))
+		}
+
+		return
+	}
+
+	fpm.logger.Error("failed to unjail the finality provider after max attempts, it has to be unjailed manually",
+		zap.String("pk", pkHex), zap.Uint32("max_attempts", cfg.MaxAttempts))
+}
+
+// unjailFinalityProvider sends the unjail transaction of the given finality
+// provider and sets its status to INACTIVE, which is updated to ACTIVE by
+// the status update loop once it has voting power
+func (fpm *FinalityProviderManager) unjailFinalityProvider(fpPk *bbntypes.BIP340PubKey) (txHash string, err error) {
+	defer func() {
+		fpm.audit.record(audit.OpUnjail, map[string]string{"fp_btc_pk_hex": fpPk.MarshalHex(), "tx_hash": txHash}, err)
+	}()
+
+	if err := fpm.getSubmissionLimiter(fpPk.MarshalHex()).allow(submissionUnjail); err != nil {
+		return "", err
+	}
+
+	res, err := fpm.cc.UnjailFinalityProvider(fpPk.MustToBTCPK())
+	if err != nil {
+		return "", fmt.Errorf("failed to send unjail transaction: %w", err)
+	}
+
+	if err := fpm.fps.SetFpStatus(fpPk.MustToBTCPK(), proto.FinalityProviderStatus_INACTIVE); err != nil {
+		return "", fmt.Errorf("failed to update finality-provider status after unjailing: %w", err)
+	}
+
+	fpm.metrics.RecordFpStatus(fpPk.MarshalHex(), proto.FinalityProviderStatus_INACTIVE)
+
+	return res.TxHash, nil
+}
+
+// sleepOrQuit waits for the given duration and returns false if the manager
+// is stopped in the meantime
+func (fpm *FinalityProviderManager) sleepOrQuit(d time.Duration) bool {
+	if d <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-fpm.quit:
+		return false
+	}
+}
+
+func (fpm *FinalityProviderManager) getJailedUntilWithRetry(fpPk *bbntypes.BIP340PubKey) (time.Time, error) {
+	var (
+		jailedUntil time.Time
+		err         error
+	)
+
+	if err := retry.Do(func() error {
+		jailedUntil, err = fpm.cc.QueryFinalityProviderJailedUntil(fpPk.MustToBTCPK())
+		if err != nil {
+			return err
+		}
+		return nil
+	}, retry.Context(fpm.ctx), RtyAtt, RtyDel, RtyErr, retry.OnRetry(func(n uint, err error) {
+		fpm.logger.Debug(
+			"failed to query the end of the jailing period",
+			zap.String("pk", fpPk.MarshalHex()),
+			zap.Uint("attempt", n+1),
+			zap.Uint("max_attempts", RtyAttNum),
+			zap.Error(err),
+		)
+	})); err != nil {
+		return time.Time{}, err
+	}
+
+	return jailedUntil, nil
+}
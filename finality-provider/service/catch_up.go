@@ -0,0 +1,178 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// catchUp processes the blocks missed during a downtime if the instance is
+// at least CatchUpThreshold blocks behind the tip upon start. Instead of
+// waiting for the poller to retrieve the blocks one by one, the blocks up to
+// the tip are fetched, signed and submitted in batches of BatchSubmissionSize
+// and the heights finalized in the meantime are skipped. The poller then
+// continues from the height after the last caught up block
+func (fp *FinalityProviderInstance) catchUp() {
+	if fp.cfg().CatchUpThreshold == 0 {
+		return
+	}
+
+	startHeight := fp.startHeight.Load()
+	tip, err := fp.getLatestBlockWithRetry()
+	if err != nil {
+		fp.logger.Warn("failed to get the latest block, skip catching up",
+			zap.String("pk", fp.GetBtcPkHex()), zap.Error(err))
+		return
+	}
+	if tip.Height < startHeight+fp.cfg().CatchUpThreshold {
+		return
+	}
+
+	fp.logger.Info("the finality provider is far behind the tip, start catching up",
+		zap.String("pk", fp.GetBtcPkHex()),
+		zap.Uint64("start_height", startHeight),
+		zap.Uint64("tip_height", tip.Height),
+	)
+
+	nextHeight := startHeight
+	for nextHeight <= tip.Height {
+		select {
+		case <-fp.quit:
+			return
+		default:
+		}
+
+		if !fp.IsLeader() {
+			fp.logger.Info("the finality provider is a standby, stop catching up",
+				zap.String("pk", fp.GetBtcPkHex()))
+			break
+		}
+		if fp.maintenance.isActive() {
+			// the remaining blocks are polled after the maintenance
+			fp.logger.Info("the daemon is in the maintenance mode, stop catching up",
+				zap.String("pk", fp.GetBtcPkHex()))
+			break
+		}
+
+		// skip the heights finalized in the meantime
+		finalizedBlocks, err := fp.latestFinalizedBlocksWithRetry(1)
+		if err != nil {
+			fp.logger.Warn("failed to get the latest finalized block, stop catching up",
+				zap.String("pk", fp.GetBtcPkHex()), zap.Error(err))
+			break
+		}
+		if len(finalizedBlocks) > 0 && finalizedBlocks[0].Height >= nextHeight {
+			nextHeight = finalizedBlocks[0].Height + 1
+			if nextHeight > tip.Height {
+				break
+			}
+		}
+
+		endHeight := min(nextHeight+uint64(fp.cfg().BatchSubmissionSize)-1, tip.Height)
+		blocks, err := fp.queryBlocksWithRetry(nextHeight, endHeight)
+		if err != nil || len(blocks) == 0 {
+			fp.logger.Warn("failed to get the blocks, stop catching up",
+				zap.String("pk", fp.GetBtcPkHex()),
+				zap.Uint64("start_height", nextHeight),
+				zap.Uint64("end_height", endHeight),
+				zap.Error(err),
+			)
+			break
+		}
+
+		targetBlocks := make([]*types.BlockInfo, 0, len(blocks))
+		for _, b := range blocks {
+			shouldProcess, err := fp.shouldProcessBlock(b)
+			if err != nil {
+				if !errors.Is(err, ErrFinalityProviderShutDown) {
+					fp.reportCriticalErr(err)
+				}
+				return
+			}
+			if shouldProcess {
+				targetBlocks = append(targetBlocks, b)
+			}
+		}
+
+		if len(targetBlocks) > 0 {
+			res, err := fp.retrySubmitSigsUntilFinalized(targetBlocks)
+			if err != nil {
+				fp.metrics.IncrementFpTotalFailedVotes(fp.GetBtcPkHex())
+				if !errors.Is(err, ErrFinalityProviderShutDown) && !errors.Is(err, ErrFinalityProviderStandby) {
+					fp.alerts.checkMissedVotes(fp.streaks.addMissedHeights(len(targetBlocks)))
+					fp.reportCriticalErr(err)
+				}
+				return
+			}
+			if res != nil {
+				fp.logger.Info("successfully submitted the finality signatures while catching up",
+					zap.String("pk", fp.GetBtcPkHex()),
+					zap.Uint64("start_height", targetBlocks[0].Height),
+					zap.Uint64("end_height", targetBlocks[len(targetBlocks)-1].Height),
+					zap.String("tx_hash", res.TxHash),
+				)
+			}
+		}
+
+		nextHeight = blocks[len(blocks)-1].Height + 1
+		fp.lastHeartbeat.Store(time.Now())
+		fp.lastReceivedHeight.Store(nextHeight - 1)
+		fp.MustUpdateLastProcessedHeight(nextHeight - 1)
+		fp.logger.Info("catching up",
+			zap.String("pk", fp.GetBtcPkHex()),
+			zap.Uint64("processed_height", nextHeight-1),
+			zap.Uint64("tip_height", tip.Height),
+			zap.Uint64("remaining_blocks", tip.Height-(nextHeight-1)),
+		)
+	}
+
+	if nextHeight == startHeight {
+		return
+	}
+
+	// the blocks buffered by the poller in the meantime have been processed
+	if err := fp.poller.SkipToHeight(nextHeight); err != nil {
+		fp.logger.Debug("the poller is not skipped to the caught up height",
+			zap.String("pk", fp.GetBtcPkHex()),
+			zap.Uint64("height", nextHeight),
+			zap.Error(err),
+		)
+	}
+
+	fp.logger.Info("the finality provider has caught up",
+		zap.String("pk", fp.GetBtcPkHex()),
+		zap.Uint64("next_height", nextHeight),
+	)
+}
+
+func (fp *FinalityProviderInstance) queryBlocksWithRetry(startHeight, endHeight uint64) ([]*types.BlockInfo, error) {
+	var (
+		blocks []*types.BlockInfo
+		err    error
+	)
+
+	if err := retry.Do(func() error {
+		blocks, err = fp.cc.QueryBlocks(startHeight, endHeight, fp.cfg().BatchSubmissionSize)
+		if err != nil {
+			return err
+		}
+		return nil
+	}, retry.Context(fp.ctx), RtyAtt, RtyDel, RtyErr, retry.OnRetry(func(n uint, err error) {
+		fp.logger.Debug(
+			"failed to query the consumer chain for the blocks",
+			zap.Uint64("start_height", startHeight),
+			zap.Uint64("end_height", endHeight),
+			zap.Uint("attempt", n+1),
+			zap.Uint("max_attempts", RtyAttNum),
+			zap.Error(err),
+		)
+	})); err != nil {
+		return nil, err
+	}
+
+	return blocks, nil
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"fmt"
+	"math"
 	"sync"
 	"time"
 
@@ -30,6 +31,8 @@ const (
 
 type skipHeightRequest struct {
 	height uint64
+	// rewind allows the height to be lower than the next height to retrieve
+	rewind bool
 	resp   chan *skipHeightResponse
 }
 
@@ -37,15 +40,31 @@ type skipHeightResponse struct {
 	err error
 }
 
+// ChainPoller prefetches the blocks of the consumer chain into a bounded
+// buffer from which the finality provider instance pulls them. The poller
+// stops polling while the buffer is full, so that the memory is bounded
+// while catching up
 type ChainPoller struct {
 	isStarted *atomic.Bool
 	wg        sync.WaitGroup
 	quit      chan struct{}
 
-	cc             clientcontroller.ClientController
-	cfg            *cfg.ChainPollerConfig
-	metrics        *metrics.FpMetrics
-	blockInfoChan  chan *types.BlockInfo
+	cc      clientcontroller.ClientController
+	cfg     *cfg.ChainPollerConfig
+	metrics *metrics.FpMetrics
+	// pollInterval returns the current poll interval, the instance replaces
+	// it with the one following the config reloads
+	pollInterval func() time.Duration
+
+	// bufferMu protects buffer
+	bufferMu sync.Mutex
+	// buffer holds the prefetched blocks in ascending order of height, at
+	// most BufferSize of them
+	buffer []*types.BlockInfo
+	// demandChan wakes up the poller once blocks are pulled so that the
+	// buffer is refilled without waiting for the next poll
+	demandChan chan struct{}
+
 	skipHeightChan chan *skipHeightRequest
 	nextHeight     uint64
 	logger         *zap.Logger
@@ -61,9 +80,11 @@ func NewChainPoller(
 		isStarted:      atomic.NewBool(false),
 		logger:         logger,
 		cfg:            cfg,
+		pollInterval:   func() time.Duration { return cfg.PollInterval },
 		cc:             cc,
 		metrics:        metrics,
-		blockInfoChan:  make(chan *types.BlockInfo, cfg.BufferSize),
+		buffer:         make([]*types.BlockInfo, 0, cfg.BufferSize),
+		demandChan:     make(chan struct{}, 1),
 		skipHeightChan: make(chan *skipHeightRequest),
 		quit:           make(chan struct{}),
 	}
@@ -110,11 +131,57 @@ func (cp *ChainPoller) IsRunning() bool {
 	return cp.isStarted.Load()
 }
 
-// Return read only channel for incoming blocks
-// TODO: Handle the case when there is more than one consumer. Currently with more than
-// one consumer blocks most probably will be received out of order to those consumers.
-func (cp *ChainPoller) GetBlockInfoChan() <-chan *types.BlockInfo {
-	return cp.blockInfoChan
+// NextBlocks pulls at most limit prefetched blocks in ascending order of
+// height, it returns nil if no block is prefetched yet. The poller is
+// notified to refill the buffer
+// NOTE: the blocks are expected to be pulled by a single consumer
+func (cp *ChainPoller) NextBlocks(limit uint32) []*types.BlockInfo {
+	cp.bufferMu.Lock()
+	n := min(int(limit), len(cp.buffer))
+	if n == 0 {
+		cp.bufferMu.Unlock()
+		return nil
+	}
+	blocks := make([]*types.BlockInfo, n)
+	copy(blocks, cp.buffer)
+	// the remaining blocks are moved to the front so that the underlying
+	// array is not grown
+	cp.buffer = append(cp.buffer[:0], cp.buffer[n:]...)
+	occupancy := len(cp.buffer)
+	cp.bufferMu.Unlock()
+
+	cp.metrics.RecordPollerBufferOccupancy(occupancy)
+
+	select {
+	case cp.demandChan <- struct{}{}:
+	default:
+	}
+
+	return blocks
+}
+
+// BufferedBlocks returns the number of the prefetched blocks which are not
+// pulled yet
+func (cp *ChainPoller) BufferedBlocks() int {
+	cp.bufferMu.Lock()
+	defer cp.bufferMu.Unlock()
+
+	return len(cp.buffer)
+}
+
+// freeBufferSlots returns the number of the blocks that can be prefetched
+// before the buffer is full
+func (cp *ChainPoller) freeBufferSlots() int {
+	return int(cp.cfg.BufferSize) - cp.BufferedBlocks()
+}
+
+func (cp *ChainPoller) pushBlock(block *types.BlockInfo) {
+	cp.bufferMu.Lock()
+	cp.buffer = append(cp.buffer, block)
+	occupancy := len(cp.buffer)
+	cp.bufferMu.Unlock()
+
+	cp.metrics.RecordPollerBufferOccupancy(occupancy)
 }
 
 func (cp *ChainPoller) blockWithRetry(height uint64) (*types.BlockInfo, error) {
@@ -148,7 +215,7 @@ func (cp *ChainPoller) waitForActivation() {
 	// ensure that the startHeight is no lower than the activated height
 	for {
 		select {
-		case <-time.After(cp.cfg.PollInterval):
+		case <-time.After(cp.pollInterval()):
 			activatedHeight, err := cp.cc.QueryActivatedHeight()
 			if err != nil {
 				cp.logger.Debug("failed to query the consumer chain for the activated height", zap.Error(err))
@@ -174,81 +241,146 @@ func (cp *ChainPoller) pollChain() {
 
 	for {
 		select {
-		case <-time.After(cp.cfg.PollInterval):
-			// TODO: Handlig of request cancellation, as otherwise shutdown will be blocked
-			// until request is finished
-			blockToRetrieve := cp.nextHeight
-			block, err := cp.blockWithRetry(blockToRetrieve)
-			if err != nil {
-				failedCycles++
-				cp.logger.Debug(
-					"failed to query the consumer chain for the block",
-					zap.Uint32("current_failures", failedCycles),
-					zap.Uint64("block_to_retrieve", blockToRetrieve),
-					zap.Error(err),
-				)
-			} else {
-				// no error and we got the header we wanted to get, bump the state and push
-				// notification about data
-				cp.nextHeight = blockToRetrieve + 1
-				failedCycles = 0
-				cp.metrics.RecordLastPolledHeight(block.Height)
-
-				cp.logger.Info("the poller retrieved the block from the consumer chain",
-					zap.Uint64("height", block.Height))
-
-				// push the data to the channel
-				// Note: if the consumer is too slow -- the buffer is full
-				// the channel will block, and we will stop retrieving data from the node
-				cp.blockInfoChan <- block
-			}
+		case <-time.After(cp.pollInterval()):
+		case <-cp.demandChan:
+		case req := <-cp.skipHeightChan:
+			cp.skipToHeight(req)
+			continue
+		case <-cp.quit:
+			return
+		}
+
+		// the finality provider is behind, the poller waits until blocks
+		// are pulled instead of growing the buffer
+		freeSlots := cp.freeBufferSlots()
+		if freeSlots <= 0 {
+			cp.metrics.IncrementPollerTotalBackpressureStalls()
+			cp.logger.Debug("the poller buffer is full, waiting for the blocks to be pulled",
+				zap.Uint32("buffer_size", cp.cfg.BufferSize),
+				zap.Uint64("next_height", cp.nextHeight),
+			)
+			continue
+		}
 
-			if failedCycles > maxFailedCycles {
-				cp.logger.Fatal("the poller has reached the max failed cycles, exiting")
+		// TODO: Handlig of request cancellation, as otherwise shutdown will be blocked
+		// until request is finished
+		if err := cp.prefetchBlocks(min(freeSlots, int(cp.cfg.PrefetchSize))); err != nil {
+			failedCycles++
+			cp.logger.Debug(
+				"failed to query the consumer chain for the block",
+				zap.Uint32("current_failures", failedCycles),
+				zap.Uint64("block_to_retrieve", cp.nextHeight),
+				zap.Error(err),
+			)
+		} else {
+			failedCycles = 0
+		}
+
+		if failedCycles > maxFailedCycles {
+			cp.logger.Fatal("the poller has reached the max failed cycles, exiting")
+		}
+	}
+}
+
+// prefetchBlocks retrieves at most the given number of blocks from the next
+// height into the buffer. Only the first block is queried with retries, the
+// prefetching stops at the first block which is not produced yet so that
+// the tip is not polled repeatedly. An error is returned if the first block
+// cannot be retrieved
+func (cp *ChainPoller) prefetchBlocks(maxBlocks int) error {
+	for i := 0; i < maxBlocks; i++ {
+		blockToRetrieve := cp.nextHeight
+
+		var (
+			block *types.BlockInfo
+			err   error
+		)
+		if i == 0 {
+			block, err = cp.blockWithRetry(blockToRetrieve)
+			if err != nil {
+				return err
 			}
-		case req := <-cp.skipHeightChan:
-			// no need to skip heights if the target height is not higher
-			// than the next height to retrieve
-			targetHeight := req.height
-			if targetHeight <= cp.nextHeight {
-				resp := &skipHeightResponse{
-					err: fmt.Errorf(
-						"the target height %d is not higher than the next height %d to retrieve",
-						targetHeight, cp.nextHeight)}
-				req.resp <- resp
-				continue
+		} else {
+			block, err = cp.cc.QueryBlock(blockToRetrieve)
+			if err != nil {
+				return nil
 			}
+		}
 
-			// drain blocks that can be skipped from blockInfoChan
-			cp.clearChanBufferUpToHeight(targetHeight)
-
-			// set the next height to the skip height
-			cp.nextHeight = targetHeight
+		// no error and we got the header we wanted to get, bump the state
+		// and buffer the block
+		cp.nextHeight = blockToRetrieve + 1
+		cp.metrics.RecordLastPolledHeight(block.Height)
 
-			cp.logger.Debug("the poller has skipped height(s)",
-				zap.Uint64("next_height", req.height))
+		cp.logger.Info("the poller retrieved the block from the consumer chain",
+			zap.Uint64("height", block.Height))
 
-			req.resp <- &skipHeightResponse{}
+		cp.pushBlock(block)
 
+		select {
 		case <-cp.quit:
-			return
+			return nil
+		default:
 		}
 	}
+
+	return nil
+}
+
+func (cp *ChainPoller) skipToHeight(req *skipHeightRequest) {
+	// no need to skip heights if the target height is not higher
+	// than the next height to retrieve
+	targetHeight := req.height
+	if !req.rewind && targetHeight <= cp.nextHeight {
+		req.resp <- &skipHeightResponse{
+			err: fmt.Errorf(
+				"the target height %d is not higher than the next height %d to retrieve",
+				targetHeight, cp.nextHeight)}
+		return
+	}
+
+	if req.rewind {
+		// the blocks are retrieved again from the target height
+		cp.clearBufferUpToHeight(math.MaxUint64)
+	} else {
+		// drop the buffered blocks that can be skipped
+		cp.clearBufferUpToHeight(targetHeight)
+	}
+
+	// set the next height to the skip height
+	cp.nextHeight = targetHeight
+
+	cp.logger.Debug("the poller has skipped height(s)",
+		zap.Uint64("next_height", req.height))
+
+	req.resp <- &skipHeightResponse{}
 }
 
 func (cp *ChainPoller) SkipToHeight(height uint64) error {
+	return cp.sendSkipHeightRequest(&skipHeightRequest{height: height})
+}
+
+// RewindToHeight makes the poller retrieve the blocks again from the given
+// height, which might be lower than the next height to retrieve, dropping
+// the buffered blocks
+func (cp *ChainPoller) RewindToHeight(height uint64) error {
+	return cp.sendSkipHeightRequest(&skipHeightRequest{height: height, rewind: true})
+}
+
+func (cp *ChainPoller) sendSkipHeightRequest(req *skipHeightRequest) error {
 	if !cp.IsRunning() {
 		return fmt.Errorf("the chain poller is stopped")
 	}
 
 	respChan := make(chan *skipHeightResponse, 1)
+	req.resp = respChan
 
 	// this handles the case when the poller is stopped before the
 	// skip height request is sent
 	select {
 	case <-cp.quit:
 		return fmt.Errorf("the chain poller is stopped")
-	case cp.skipHeightChan <- &skipHeightRequest{height: height, resp: respChan}:
+	case cp.skipHeightChan <- req:
 	}
 
 	// this handles the case when the poller is stopped before
@@ -265,11 +397,16 @@ func (cp *ChainPoller) NextHeight() uint64 {
 	return cp.nextHeight
 }
 
-func (cp *ChainPoller) clearChanBufferUpToHeight(upToHeight uint64) {
-	for len(cp.blockInfoChan) > 0 {
-		block := <-cp.blockInfoChan
-		if block.Height+1 >= upToHeight {
-			break
-		}
+// clearBufferUpToHeight drops the buffered blocks below the given height
+func (cp *ChainPoller) clearBufferUpToHeight(upToHeight uint64) {
+	cp.bufferMu.Lock()
+	n := 0
+	for n < len(cp.buffer) && cp.buffer[n].Height < upToHeight {
+		n++
 	}
+	cp.buffer = append(cp.buffer[:0], cp.buffer[n:]...)
+	occupancy := len(cp.buffer)
+	cp.bufferMu.Unlock()
+
+	cp.metrics.RecordPollerBufferOccupancy(occupancy)
 }
@@ -1,6 +1,7 @@
 package service_test
 
 import (
+	"errors"
 	"math/rand"
 	"sync"
 	"testing"
@@ -45,6 +46,8 @@ func FuzzChainPoller_Start(f *testing.F) {
 			}
 			mockClientController.EXPECT().QueryBlock(i).Return(resBlock, nil).AnyTimes()
 		}
+		// the blocks above are not produced yet
+		mockClientController.EXPECT().QueryBlock(gomock.Any()).Return(nil, errors.New("block not found")).AnyTimes()
 
 		// TODO: use mock metrics
 		m := metrics.NewFpMetrics()
@@ -59,12 +62,8 @@ func FuzzChainPoller_Start(f *testing.F) {
 		}()
 
 		for i := startHeight; i <= endHeight; i++ {
-			select {
-			case info := <-poller.GetBlockInfoChan():
-				require.Equal(t, i, info.Height)
-			case <-time.After(10 * time.Second):
-				t.Fatalf("Failed to get block info")
-			}
+			info := pullNextBlock(t, poller)
+			require.Equal(t, i, info.Height)
 		}
 	})
 }
@@ -97,6 +96,8 @@ func FuzzChainPoller_SkipHeight(f *testing.F) {
 			}
 			mockClientController.EXPECT().QueryBlock(i).Return(resBlock, nil).AnyTimes()
 		}
+		// the blocks above are not produced yet
+		mockClientController.EXPECT().QueryBlock(gomock.Any()).Return(nil, errors.New("block not found")).AnyTimes()
 
 		// TODO: use mock metrics
 		m := metrics.NewFpMetrics()
@@ -135,15 +136,11 @@ func FuzzChainPoller_SkipHeight(f *testing.F) {
 			if skipped {
 				break
 			}
-			select {
-			case info := <-poller.GetBlockInfoChan():
-				if info.Height == skipHeight {
-					skipped = true
-				} else {
-					require.Equal(t, i, info.Height)
-				}
-			case <-time.After(10 * time.Second):
-				t.Fatalf("Failed to get block info")
+			info := pullNextBlock(t, poller)
+			if info.Height == skipHeight {
+				skipped = true
+			} else {
+				require.Equal(t, i, info.Height)
 			}
 		}
 
@@ -152,3 +149,107 @@ func FuzzChainPoller_SkipHeight(f *testing.F) {
 		require.Equal(t, skipHeight+1, poller.NextHeight())
 	})
 }
+
+// TestChainPoller_RewindToHeight tests that the poller retrieves the blocks
+// again from a height lower than the next height to retrieve
+func TestChainPoller_RewindToHeight(t *testing.T) {
+	startHeight := uint64(10)
+	endHeight := uint64(20)
+	rewindHeight := uint64(5)
+
+	ctl := gomock.NewController(t)
+	mockClientController := mocks.NewMockClientController(ctl)
+	mockClientController.EXPECT().Close().Return(nil).AnyTimes()
+	mockClientController.EXPECT().QueryActivatedHeight().Return(uint64(1), nil).AnyTimes()
+	for i := rewindHeight; i <= endHeight; i++ {
+		mockClientController.EXPECT().QueryBlock(i).Return(&types.BlockInfo{Height: i}, nil).AnyTimes()
+	}
+	// the blocks above are not produced yet
+	mockClientController.EXPECT().QueryBlock(gomock.Any()).Return(nil, errors.New("block not found")).AnyTimes()
+
+	// TODO: use mock metrics
+	m := metrics.NewFpMetrics()
+	pollerCfg := fpcfg.DefaultChainPollerConfig()
+	pollerCfg.PollInterval = 10 * time.Millisecond
+	poller := service.NewChainPoller(zap.NewNop(), &pollerCfg, mockClientController, m)
+	// should expect error if the poller is not started
+	require.Error(t, poller.RewindToHeight(rewindHeight))
+	err := poller.Start(startHeight)
+	require.NoError(t, err)
+	defer func() {
+		err := poller.Stop()
+		require.NoError(t, err)
+	}()
+
+	for i := startHeight; i < startHeight+3; i++ {
+		require.Equal(t, i, pullNextBlock(t, poller).Height)
+	}
+
+	require.NoError(t, poller.RewindToHeight(rewindHeight))
+
+	// the buffered blocks are dropped and retrieved again
+	for i := rewindHeight; i <= endHeight; i++ {
+		require.Equal(t, i, pullNextBlock(t, poller).Height)
+	}
+}
+
+// TestChainPoller_Backpressure tests that the poller stops prefetching
+// blocks while its buffer is full and resumes once the blocks are pulled
+func TestChainPoller_Backpressure(t *testing.T) {
+	startHeight := uint64(1)
+	endHeight := uint64(100)
+
+	ctl := gomock.NewController(t)
+	mockClientController := mocks.NewMockClientController(ctl)
+	mockClientController.EXPECT().Close().Return(nil).AnyTimes()
+	mockClientController.EXPECT().QueryActivatedHeight().Return(uint64(1), nil).AnyTimes()
+	for i := startHeight; i <= endHeight; i++ {
+		mockClientController.EXPECT().QueryBlock(i).Return(&types.BlockInfo{Height: i}, nil).AnyTimes()
+	}
+
+	// TODO: use mock metrics
+	m := metrics.NewFpMetrics()
+	pollerCfg := fpcfg.DefaultChainPollerConfig()
+	pollerCfg.PollInterval = 10 * time.Millisecond
+	pollerCfg.BufferSize = 4
+	pollerCfg.PrefetchSize = 2
+	poller := service.NewChainPoller(zap.NewNop(), &pollerCfg, mockClientController, m)
+	err := poller.Start(startHeight)
+	require.NoError(t, err)
+	defer func() {
+		err := poller.Stop()
+		require.NoError(t, err)
+	}()
+
+	// the poller fills the buffer and stops
+	require.Eventually(t, func() bool {
+		return poller.BufferedBlocks() == int(pollerCfg.BufferSize)
+	}, 10*time.Second, 10*time.Millisecond)
+	time.Sleep(10 * pollerCfg.PollInterval)
+	require.Equal(t, int(pollerCfg.BufferSize), poller.BufferedBlocks())
+	require.Equal(t, startHeight+uint64(pollerCfg.BufferSize), poller.NextHeight())
+
+	// the pulled blocks are in order and refilled
+	blocks := poller.NextBlocks(3)
+	require.Len(t, blocks, 3)
+	for i, b := range blocks {
+		require.Equal(t, startHeight+uint64(i), b.Height)
+	}
+	require.Eventually(t, func() bool {
+		return poller.BufferedBlocks() == int(pollerCfg.BufferSize)
+	}, 10*time.Second, 10*time.Millisecond)
+	blocks = poller.NextBlocks(pollerCfg.BufferSize)
+	require.Len(t, blocks, int(pollerCfg.BufferSize))
+	require.Equal(t, startHeight+3, blocks[0].Height)
+}
+
+// pullNextBlock waits for the next block prefetched by the poller
+func pullNextBlock(t *testing.T, poller *service.ChainPoller) *types.BlockInfo {
+	var blocks []*types.BlockInfo
+	require.Eventually(t, func() bool {
+		blocks = poller.NextBlocks(1)
+		return len(blocks) == 1
+	}, 10*time.Second, 10*time.Millisecond, "Failed to get block info")
+
+	return blocks[0]
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// WithTLS returns the dial option to connect to the daemon over TLS,
+// trusting the certificate at certPath and presenting the client
+// certificate at clientCertPath if it is not empty
+func WithTLS(certPath, clientCertPath, clientKeyPath string) (grpc.DialOption, error) {
+	certPEM, err := os.ReadFile(certPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the TLS certificate of the daemon: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(certPEM) {
+		return nil, fmt.Errorf("no valid certificate in %s", certPath)
+	}
+
+	tlsCfg := &tls.Config{
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if clientCertPath != "" {
+		clientCert, err := tls.LoadX509KeyPair(clientCertPath, clientKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{clientCert}
+	}
+
+	return grpc.WithTransportCredentials(credentials.NewTLS(tlsCfg)), nil
+}
+
+// WithAuthToken returns the dial option attaching the given auth token to
+// each request to the daemon
+func WithAuthToken(token string) grpc.DialOption {
+	return grpc.WithPerRPCCredentials(tokenCredentials(token))
+}
+
+// ReadAuthToken reads the auth token from the given file
+func ReadAuthToken(path string) (string, error) {
+	tokenBytes, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the auth token: %w", err)
+	}
+
+	token := strings.TrimSpace(string(tokenBytes))
+	if token == "" {
+		return "", fmt.Errorf("the auth token file %s is empty", path)
+	}
+
+	return token, nil
+}
+
+// tokenCredentials sets the auth token in the authorization metadata of the
+// requests
+type tokenCredentials string
+
+func (t tokenCredentials) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+// RequireTransportSecurity allows the token to be sent in plaintext, which
+// is meant for daemons listening on a loopback address only
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
@@ -2,7 +2,9 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 
 	sdkmath "cosmossdk.io/math"
 	bbntypes "github.com/babylonlabs-io/babylon/types"
@@ -14,12 +16,15 @@ import (
 )
 
 type FinalityProviderServiceGRpcClient struct {
-	client proto.FinalityProvidersClient
+	client      proto.FinalityProvidersClient
+	adminClient proto.FinalityProvidersAdminClient
 }
 
 // NewFinalityProviderServiceGRpcClient creates a new GRPC connection with finality provider daemon.
-func NewFinalityProviderServiceGRpcClient(remoteAddr string) (*FinalityProviderServiceGRpcClient, func() error, error) {
-	conn, err := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// The connection is in plaintext unless the given options set other transport credentials.
+func NewFinalityProviderServiceGRpcClient(remoteAddr string, opts ...grpc.DialOption) (*FinalityProviderServiceGRpcClient, func() error, error) {
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+	conn, err := grpc.NewClient(remoteAddr, opts...)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to build gRPC connection to %s: %w", remoteAddr, err)
 	}
@@ -32,7 +37,8 @@ func NewFinalityProviderServiceGRpcClient(remoteAddr string) (*FinalityProviderS
 	}
 
 	return &FinalityProviderServiceGRpcClient{
-		client: proto.NewFinalityProvidersClient(conn),
+		client:      proto.NewFinalityProvidersClient(conn),
+		adminClient: proto.NewFinalityProvidersAdminClient(conn),
 	}, cleanUp, nil
 }
 
@@ -52,7 +58,7 @@ func (c *FinalityProviderServiceGRpcClient) RegisterFinalityProvider(
 	passphrase string,
 ) (*proto.RegisterFinalityProviderResponse, error) {
 	req := &proto.RegisterFinalityProviderRequest{BtcPk: fpPk.MarshalHex(), Passphrase: passphrase}
-	res, err := c.client.RegisterFinalityProvider(ctx, req)
+	res, err := c.adminClient.RegisterFinalityProvider(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -81,7 +87,7 @@ func (c *FinalityProviderServiceGRpcClient) CreateFinalityProvider(
 		EotsPkHex:   eotsPkHex,
 	}
 
-	res, err := c.client.CreateFinalityProvider(ctx, req)
+	res, err := c.adminClient.CreateFinalityProvider(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -96,7 +102,7 @@ func (c *FinalityProviderServiceGRpcClient) AddFinalitySignature(ctx context.Con
 		AppHash: appHash,
 	}
 
-	res, err := c.client.AddFinalitySignature(ctx, req)
+	res, err := c.adminClient.AddFinalitySignature(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -104,12 +110,17 @@ func (c *FinalityProviderServiceGRpcClient) AddFinalitySignature(ctx context.Con
 	return res, nil
 }
 
-func (c *FinalityProviderServiceGRpcClient) UnjailFinalityProvider(ctx context.Context, fpPk string) (*proto.UnjailFinalityProviderResponse, error) {
+func (c *FinalityProviderServiceGRpcClient) UnjailFinalityProvider(
+	ctx context.Context,
+	fpPk string,
+	confirm bool,
+) (*proto.UnjailFinalityProviderResponse, error) {
 	req := &proto.UnjailFinalityProviderRequest{
-		BtcPk: fpPk,
+		BtcPk:   fpPk,
+		Confirm: confirm,
 	}
 
-	res, err := c.client.UnjailFinalityProvider(ctx, req)
+	res, err := c.adminClient.UnjailFinalityProvider(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -117,8 +128,20 @@ func (c *FinalityProviderServiceGRpcClient) UnjailFinalityProvider(ctx context.C
 	return res, nil
 }
 
-func (c *FinalityProviderServiceGRpcClient) QueryFinalityProviderList(ctx context.Context) (*proto.QueryFinalityProviderListResponse, error) {
-	req := &proto.QueryFinalityProviderListRequest{}
+// QueryFinalityProviderList - gets the finality providers from local store matching the filters
+func (c *FinalityProviderServiceGRpcClient) QueryFinalityProviderList(
+	ctx context.Context,
+	statuses []proto.FinalityProviderStatus,
+	chainID string,
+	paginationKey []byte,
+	limit uint64,
+) (*proto.QueryFinalityProviderListResponse, error) {
+	req := &proto.QueryFinalityProviderListRequest{
+		Statuses:      statuses,
+		ChainId:       chainID,
+		PaginationKey: paginationKey,
+		Limit:         limit,
+	}
 	res, err := c.client.QueryFinalityProviderList(ctx, req)
 	if err != nil {
 		return nil, err
@@ -142,7 +165,7 @@ func (c *FinalityProviderServiceGRpcClient) QueryFinalityProviderInfo(ctx contex
 func (c *FinalityProviderServiceGRpcClient) EditFinalityProvider(
 	ctx context.Context, fpPk *bbntypes.BIP340PubKey, desc *proto.Description, rate string) error {
 	req := &proto.EditFinalityProviderRequest{BtcPk: fpPk.MarshalHex(), Description: desc, Commission: rate}
-	_, err := c.client.EditFinalityProvider(ctx, req)
+	_, err := c.adminClient.EditFinalityProvider(ctx, req)
 	if err != nil {
 		return err
 	}
@@ -150,6 +173,232 @@ func (c *FinalityProviderServiceGRpcClient) EditFinalityProvider(
 	return nil
 }
 
+// StartFinalityProvider - starts the instance of the finality provider in the daemon
+func (c *FinalityProviderServiceGRpcClient) StartFinalityProvider(
+	ctx context.Context, fpPk *bbntypes.BIP340PubKey, passphrase string) error {
+	req := &proto.StartFinalityProviderRequest{BtcPk: fpPk.MarshalHex(), Passphrase: passphrase}
+	_, err := c.adminClient.StartFinalityProvider(ctx, req)
+
+	return err
+}
+
+// StopFinalityProvider - stops the instance of the finality provider in the daemon
+func (c *FinalityProviderServiceGRpcClient) StopFinalityProvider(ctx context.Context, fpPk *bbntypes.BIP340PubKey) error {
+	req := &proto.StopFinalityProviderRequest{BtcPk: fpPk.MarshalHex()}
+	_, err := c.adminClient.StopFinalityProvider(ctx, req)
+
+	return err
+}
+
+// PauseFinalityProvider - pauses the voting of the finality provider in the daemon
+func (c *FinalityProviderServiceGRpcClient) PauseFinalityProvider(ctx context.Context, fpPk *bbntypes.BIP340PubKey) error {
+	req := &proto.PauseFinalityProviderRequest{BtcPk: fpPk.MarshalHex()}
+	_, err := c.adminClient.PauseFinalityProvider(ctx, req)
+
+	return err
+}
+
+// ResumeFinalityProvider - resumes the voting of the finality provider in the daemon
+func (c *FinalityProviderServiceGRpcClient) ResumeFinalityProvider(ctx context.Context, fpPk *bbntypes.BIP340PubKey) error {
+	req := &proto.ResumeFinalityProviderRequest{BtcPk: fpPk.MarshalHex()}
+	_, err := c.adminClient.ResumeFinalityProvider(ctx, req)
+
+	return err
+}
+
+// EnterMaintenance - freezes the chain submissions and the db writes of the daemon
+func (c *FinalityProviderServiceGRpcClient) EnterMaintenance(ctx context.Context) error {
+	_, err := c.adminClient.EnterMaintenance(ctx, &proto.EnterMaintenanceRequest{})
+
+	return err
+}
+
+// ExitMaintenance - resumes the chain submissions and the db writes of the daemon
+func (c *FinalityProviderServiceGRpcClient) ExitMaintenance(ctx context.Context) error {
+	_, err := c.adminClient.ExitMaintenance(ctx, &proto.ExitMaintenanceRequest{})
+
+	return err
+}
+
+// CommitPubRand - commits the public randomness of the finality provider until the target height
+func (c *FinalityProviderServiceGRpcClient) CommitPubRand(
+	ctx context.Context,
+	fpPk *bbntypes.BIP340PubKey,
+	targetHeight uint64,
+) (*proto.CommitPubRandResponse, error) {
+	req := &proto.CommitPubRandRequest{BtcPk: fpPk.MarshalHex(), TargetHeight: targetHeight}
+
+	return c.adminClient.CommitPubRand(ctx, req)
+}
+
+// ExportState - streams the encrypted backup bundle of the state of the daemon to w
+func (c *FinalityProviderServiceGRpcClient) ExportState(ctx context.Context, passphrase string, w io.Writer) error {
+	stream, err := c.adminClient.ExportState(ctx, &proto.ExportStateRequest{Passphrase: passphrase})
+	if err != nil {
+		return err
+	}
+
+	for {
+		res, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(res.Data); err != nil {
+			return err
+		}
+	}
+}
+
+// ExportPubRandProofs - streams the proofs of the public randomness of the finality provider
+// between the given heights inclusive, up to the last proof if toHeight is zero, to the callback
+func (c *FinalityProviderServiceGRpcClient) ExportPubRandProofs(
+	ctx context.Context,
+	fpPk string,
+	fromHeight, toHeight uint64,
+	callback func(*proto.PubRandProof) error,
+) error {
+	stream, err := c.client.ExportPubRandProofs(ctx, &proto.ExportPubRandProofsRequest{
+		BtcPk:      fpPk,
+		FromHeight: fromHeight,
+		ToHeight:   toHeight,
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		res, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := callback(res); err != nil {
+			return err
+		}
+	}
+}
+
+// ReloadConfig - reloads the config of the daemon
+func (c *FinalityProviderServiceGRpcClient) ReloadConfig(ctx context.Context) (*proto.ReloadConfigResponse, error) {
+	return c.adminClient.ReloadConfig(ctx, &proto.ReloadConfigRequest{})
+}
+
+// SetLogLevel - sets the log level of the daemon, or of the given module if not empty
+func (c *FinalityProviderServiceGRpcClient) SetLogLevel(
+	ctx context.Context,
+	level string,
+	module string,
+) (*proto.SetLogLevelResponse, error) {
+	return c.adminClient.SetLogLevel(ctx, &proto.SetLogLevelRequest{Level: level, Module: module})
+}
+
+// Doctor - runs the preflight checks of the daemon, against the keys of the given
+// finality providers or of the running instances if none is given
+func (c *FinalityProviderServiceGRpcClient) Doctor(
+	ctx context.Context,
+	fpPks []string,
+	passphrase string,
+) (*proto.DoctorResponse, error) {
+	return c.adminClient.Doctor(ctx, &proto.DoctorRequest{BtcPks: fpPks, Passphrase: passphrase})
+}
+
+// WithdrawRewards - withdraws the accumulated finality provider rewards to the recipient,
+// the rewards of the given finality provider if its pk is not empty
+func (c *FinalityProviderServiceGRpcClient) WithdrawRewards(
+	ctx context.Context,
+	fpPk string,
+	recipient string,
+) (*proto.WithdrawRewardsResponse, error) {
+	return c.adminClient.WithdrawRewards(ctx, &proto.WithdrawRewardsRequest{Recipient: recipient, BtcPk: fpPk})
+}
+
+// QueryRewards - queries the withdrawable rewards of the finality provider
+func (c *FinalityProviderServiceGRpcClient) QueryRewards(
+	ctx context.Context,
+	fpPk *bbntypes.BIP340PubKey,
+) (*proto.QueryRewardsResponse, error) {
+	return c.client.QueryRewards(ctx, &proto.QueryRewardsRequest{BtcPk: fpPk.MarshalHex()})
+}
+
+// QueryInstanceStatus - queries the live status of the running instance of the finality provider
+func (c *FinalityProviderServiceGRpcClient) QueryInstanceStatus(
+	ctx context.Context,
+	fpPkHex string,
+) (*proto.QueryInstanceStatusResponse, error) {
+	return c.client.QueryInstanceStatus(ctx, &proto.QueryInstanceStatusRequest{BtcPk: fpPkHex})
+}
+
+// UpdateCommission - updates the commission rate of the finality provider
+func (c *FinalityProviderServiceGRpcClient) UpdateCommission(
+	ctx context.Context, fpPk *bbntypes.BIP340PubKey, rate string) (*proto.UpdateCommissionResponse, error) {
+	req := &proto.UpdateCommissionRequest{BtcPk: fpPk.MarshalHex(), Commission: rate}
+	return c.adminClient.UpdateCommission(ctx, req)
+}
+
+// SubscribeEvents - streams the events of the given types of the given finality provider,
+// all the types and all the finality providers if empty, until the context is cancelled
+func (c *FinalityProviderServiceGRpcClient) SubscribeEvents(
+	ctx context.Context, eventTypes []string, fpPkHex string) (proto.FinalityProviders_SubscribeEventsClient, error) {
+	req := &proto.SubscribeEventsRequest{Types: eventTypes, BtcPk: fpPkHex}
+
+	return c.client.SubscribeEvents(ctx, req)
+}
+
+// QueryParticipationReport - reports the missed votes of the finality provider within the given heights
+func (c *FinalityProviderServiceGRpcClient) QueryParticipationReport(
+	ctx context.Context, fpPk *bbntypes.BIP340PubKey, startHeight, endHeight uint64) (*proto.ParticipationReport, error) {
+	req := &proto.QueryParticipationReportRequest{BtcPk: fpPk.MarshalHex(), StartHeight: startHeight, EndHeight: endHeight}
+	res, err := c.client.QueryParticipationReport(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Report, nil
+}
+
+func (c *FinalityProviderServiceGRpcClient) QueryVotes(
+	ctx context.Context, fpPk *bbntypes.BIP340PubKey, startHeight, endHeight uint64) ([]*proto.VoteRecord, error) {
+	req := &proto.QueryVotesRequest{BtcPk: fpPk.MarshalHex(), StartHeight: startHeight, EndHeight: endHeight}
+	res, err := c.client.QueryVotes(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Votes, nil
+}
+
+func (c *FinalityProviderServiceGRpcClient) QueryPubRandCommits(
+	ctx context.Context, fpPk *bbntypes.BIP340PubKey) ([]*proto.PubRandCommitRecord, error) {
+	req := &proto.QueryPubRandCommitsRequest{BtcPk: fpPk.MarshalHex()}
+	res, err := c.client.QueryPubRandCommits(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Commits, nil
+}
+
+// SignSchnorrMessage signs the message with the EOTS key of the given
+// finality provider
+func (c *FinalityProviderServiceGRpcClient) SignSchnorrMessage(
+	ctx context.Context,
+	fpPk string,
+	msg []byte,
+	passphrase string,
+) (*proto.SignSchnorrMessageResponse, error) {
+	req := &proto.SignSchnorrMessageRequest{
+		BtcPk:      fpPk,
+		Msg:        msg,
+		Passphrase: passphrase,
+	}
+	return c.adminClient.SignSchnorrMessage(ctx, req)
+}
+
 func (c *FinalityProviderServiceGRpcClient) SignMessageFromChainKey(
 	ctx context.Context,
 	keyName, passphrase, hdPath string,
@@ -161,5 +410,5 @@ func (c *FinalityProviderServiceGRpcClient) SignMessageFromChainKey(
 		Passphrase: passphrase,
 		HdPath:     hdPath,
 	}
-	return c.client.SignMessageFromChainKey(ctx, req)
+	return c.adminClient.SignMessageFromChainKey(ctx, req)
 }
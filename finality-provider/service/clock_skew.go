@@ -0,0 +1,76 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// clockSkewEnabled returns whether the local clock is compared to the
+// timestamps of the latest blocks
+func (app *FinalityProviderApp) clockSkewEnabled() bool {
+	return app.config().ClockSkewConfig != nil && app.config().ClockSkewConfig.Enabled
+}
+
+// measureClockSkew returns the difference between the local time and the
+// timestamp of the latest block, positive if the local clock is ahead. It
+// includes the time elapsed since the latest block was produced
+func (app *FinalityProviderApp) measureClockSkew() (time.Duration, error) {
+	blockTime, err := app.cc.QueryLatestBlockTime()
+	if err != nil {
+		return 0, fmt.Errorf("failed to query the latest block time: %w", err)
+	}
+
+	skew := time.Since(blockTime)
+	app.fpManager.metrics.RecordClockSkew(skew)
+
+	return skew, nil
+}
+
+// checkClockSkew measures the clock skew and returns an error wrapping
+// ErrClockSkewTooLarge if it exceeds MaxSkew, or an error if it exceeds
+// WarnThreshold
+func (app *FinalityProviderApp) checkClockSkew() error {
+	cfg := app.config().ClockSkewConfig
+
+	skew, err := app.measureClockSkew()
+	if err != nil {
+		return err
+	}
+
+	absSkew := skew.Abs()
+	if cfg.MaxSkew > 0 && absSkew > cfg.MaxSkew {
+		return fmt.Errorf("%w: %v relative to the latest block, max %v", ErrClockSkewTooLarge, skew, cfg.MaxSkew)
+	}
+	if absSkew > cfg.WarnThreshold {
+		return fmt.Errorf("the local clock is skewed by %v relative to the latest block, above %v", skew, cfg.WarnThreshold)
+	}
+
+	return nil
+}
+
+// clockSkewMonitorLoop periodically checks the clock skew and warns if it
+// exceeds the thresholds, as the timing based decisions, e.g., the
+// randomness runway and the vote timing, silently misbehave otherwise
+func (app *FinalityProviderApp) clockSkewMonitorLoop() {
+	defer app.wg.Done()
+
+	cfg := app.config().ClockSkewConfig
+	app.logger.Info("starting clock skew monitor loop",
+		zap.Float64("interval seconds", cfg.CheckInterval.Seconds()))
+	ticker := time.NewTicker(cfg.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := app.checkClockSkew(); err != nil {
+				app.logger.Warn("the clock skew check failed", zap.Error(err))
+			}
+		case <-app.quit:
+			app.logger.Info("exiting clock skew monitor loop")
+			return
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/audit"
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/log"
+)
+
+// ConfigLoader loads the latest version of the config, usually by
+// re-reading the config file
+type ConfigLoader func() (*fpcfg.Config, error)
+
+// ConfigReloadResult describes the outcome of a config reload
+type ConfigReloadResult struct {
+	// Updated contains the reloadable fields which have been changed
+	Updated []string
+	// RestartRequired contains the changed fields which cannot be
+	// applied at runtime and are ignored until the daemon is restarted
+	RestartRequired []string
+}
+
+// EnableConfigReload enables the reload of the config through the given
+// loader. The root log level and the levels of the modules are changed
+// through logLevels upon reload, which also enables SetLogLevel
+func (app *FinalityProviderApp) EnableConfigReload(loader ConfigLoader, logLevels *log.Levels) {
+	app.reloadMu.Lock()
+	defer app.reloadMu.Unlock()
+
+	app.configLoader = loader
+	app.logLevels = logLevels
+}
+
+// ReloadConfig re-reads the config and applies the changes of the
+// reloadable fields, i.e., the log levels, the intervals of the loops,
+// and the settings of the randomness commitment and signature
+// submission, without restarting the running finality provider instances.
+// The changes are applied to a copy of the config published as a whole, and
+// the running loops pick up the new values on their next iteration
+func (app *FinalityProviderApp) ReloadConfig() (res *ConfigReloadResult, err error) {
+	defer func() {
+		details := map[string]string{}
+		if res != nil {
+			details["updated"] = strings.Join(res.Updated, ",")
+			details["restart_required"] = strings.Join(res.RestartRequired, ",")
+		}
+		app.fpManager.audit.record(audit.OpReloadConfig, details, err)
+	}()
+
+	app.reloadMu.Lock()
+	defer app.reloadMu.Unlock()
+
+	if app.configLoader == nil {
+		return nil, errors.New("config reload is not enabled")
+	}
+
+	newCfg, err := app.configLoader()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if err := validateReloadableConfig(newCfg); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	cur := app.config()
+	res = &ConfigReloadResult{
+		RestartRequired: restartRequiredFields(cur, newCfg),
+	}
+	// the current config is read by the running loops without locking, so
+	// it is never modified
+	cfg := copyReloadableConfig(cur)
+
+	if cfg.LogLevel != newCfg.LogLevel && app.logLevels != nil {
+		// the level has been validated above
+		lvl, _ := log.ParseLevel(newCfg.LogLevel)
+		app.logLevels.SetLevel("", lvl)
+	}
+
+	reloadField(res, "loglevel", &cfg.LogLevel, newCfg.LogLevel)
+	if cfg.LogConfig != nil && newCfg.LogConfig != nil &&
+		!reflect.DeepEqual(cfg.LogConfig.ModuleLevels, newCfg.LogConfig.ModuleLevels) {
+		if app.logLevels != nil {
+			// the levels have been validated above
+			moduleLevels, _ := log.ParseModuleLevels(newCfg.LogConfig.ModuleLevels)
+			app.logLevels.SetModuleLevels(moduleLevels)
+		}
+		cfg.LogConfig.ModuleLevels = newCfg.LogConfig.ModuleLevels
+		res.Updated = append(res.Updated, "logconfig.modulelevel")
+	}
+	reloadField(res, "numpubrand", &cfg.NumPubRand, newCfg.NumPubRand)
+	reloadField(res, "numpubrandmax", &cfg.NumPubRandMax, newCfg.NumPubRandMax)
+	reloadField(res, "minrandheightgap", &cfg.MinRandHeightGap, newCfg.MinRandHeightGap)
+	reloadField(res, "pubrandrunway", &cfg.PubRandRunway, newCfg.PubRandRunway)
+	reloadField(res, "maxsubmissionretries", &cfg.MaxSubmissionRetries, newCfg.MaxSubmissionRetries)
+	reloadField(res, "batchsubmissionsize", &cfg.BatchSubmissionSize, newCfg.BatchSubmissionSize)
+	// a zero status update interval disables the status update loop
+	// which cannot be turned on or off at runtime
+	if (cfg.StatusUpdateInterval == 0) != (newCfg.StatusUpdateInterval == 0) {
+		res.RestartRequired = append(res.RestartRequired, "statusupdateinterval")
+	} else {
+		reloadField(res, "statusupdateinterval", &cfg.StatusUpdateInterval, newCfg.StatusUpdateInterval)
+	}
+	reloadField(res, "randomnesscommitinterval", &cfg.RandomnessCommitInterval, newCfg.RandomnessCommitInterval)
+	reloadField(res, "submissionretryinterval", &cfg.SubmissionRetryInterval, newCfg.SubmissionRetryInterval)
+	reloadField(res, "syncfpstatusinterval", &cfg.SyncFpStatusInterval, newCfg.SyncFpStatusInterval)
+	reloadField(res, "signaturesubmissioninterval", &cfg.SignatureSubmissionInterval, newCfg.SignatureSubmissionInterval)
+	reloadField(res, "chainpollerconfig.pollinterval", &cfg.PollerConfig.PollInterval, newCfg.PollerConfig.PollInterval)
+	reloadField(res, "shutdowngraceperiod", &cfg.ShutdownGracePeriod, newCfg.ShutdownGracePeriod)
+	reloadField(res, "rpcdraintimeout", &cfg.RPCDrainTimeout, newCfg.RPCDrainTimeout)
+	reloadField(res, "catchupthreshold", &cfg.CatchUpThreshold, newCfg.CatchUpThreshold)
+	reloadField(res, "inactivepubrandcommit", &cfg.InactivePubRandCommit, newCfg.InactivePubRandCommit)
+	reloadField(res, "metrics.updateinterval", &cfg.Metrics.UpdateInterval, newCfg.Metrics.UpdateInterval)
+	app.cfgSnapshot.Store(cfg)
+
+	app.logger.Info("reloaded the config",
+		zap.Strings("updated", res.Updated),
+		zap.Strings("restart_required", res.RestartRequired),
+	)
+
+	return res, nil
+}
+
+// validateReloadableConfig checks the values of the reloadable fields
+// that would break the running loops
+func validateReloadableConfig(cfg *fpcfg.Config) error {
+	if _, err := log.ParseLevel(cfg.LogLevel); err != nil {
+		return err
+	}
+	if cfg.LogConfig != nil {
+		if _, err := log.ParseModuleLevels(cfg.LogConfig.ModuleLevels); err != nil {
+			return err
+		}
+	}
+
+	intervals := map[string]time.Duration{
+		"randomnesscommitinterval":       cfg.RandomnessCommitInterval,
+		"syncfpstatusinterval":           cfg.SyncFpStatusInterval,
+		"signaturesubmissioninterval":    cfg.SignatureSubmissionInterval,
+		"submissionretryinterval":        cfg.SubmissionRetryInterval,
+		"metrics.updateinterval":         cfg.Metrics.UpdateInterval,
+		"chainpollerconfig.pollinterval": cfg.PollerConfig.PollInterval,
+	}
+	for name, interval := range intervals {
+		if interval <= 0 {
+			return fmt.Errorf("%s must be positive, got %v", name, interval)
+		}
+	}
+
+	if cfg.BatchSubmissionSize == 0 {
+		return errors.New("batchsubmissionsize must be positive")
+	}
+
+	if cfg.NumPubRand == 0 {
+		return errors.New("numpubrand must be positive")
+	}
+	if cfg.NumPubRandMax != 0 && cfg.NumPubRand > cfg.NumPubRandMax {
+		return fmt.Errorf("numpubrand %d exceeds numpubrandmax %d", cfg.NumPubRand, cfg.NumPubRandMax)
+	}
+
+	return nil
+}
+
+// copyReloadableConfig returns a copy of the config to which the reloaded
+// fields are applied, the nested configs holding reloadable fields are
+// copied as well
+func copyReloadableConfig(cfg *fpcfg.Config) *fpcfg.Config {
+	newCfg := *cfg
+	if cfg.PollerConfig != nil {
+		pollerCfg := *cfg.PollerConfig
+		newCfg.PollerConfig = &pollerCfg
+	}
+	if cfg.Metrics != nil {
+		metricsCfg := *cfg.Metrics
+		newCfg.Metrics = &metricsCfg
+	}
+	if cfg.LogConfig != nil {
+		logCfg := *cfg.LogConfig
+		newCfg.LogConfig = &logCfg
+	}
+
+	return &newCfg
+}
+
+// restartRequiredFields returns the changed fields which are not reloadable
+func restartRequiredFields(cfg, newCfg *fpcfg.Config) []string {
+	var fields []string
+	changed := func(name string, v1, v2 interface{}) {
+		if !reflect.DeepEqual(v1, v2) {
+			fields = append(fields, name)
+		}
+	}
+
+	changed("chaintype", cfg.ChainType, newCfg.ChainType)
+	changed("eotsmanageraddress", cfg.EOTSManagerAddress, newCfg.EOTSManagerAddress)
+	changed("bitcoinnetwork", cfg.BitcoinNetwork, newCfg.BitcoinNetwork)
+	changed("rpclistener", cfg.RPCListener, newCfg.RPCListener)
+	changed("adminrpclistener", cfg.AdminRPCListener, newCfg.AdminRPCListener)
+	changed("rpcunixsocket", cfg.RPCUnixSocket, newCfg.RPCUnixSocket)
+	changed("rpcunixsocketperm", cfg.RPCUnixSocketPerm, newCfg.RPCUnixSocketPerm)
+	changed("dryrun", cfg.DryRun, newCfg.DryRun)
+	changed("finalityprovider", cfg.FinalityProviders, newCfg.FinalityProviders)
+	changed("passphrasefile", cfg.PassphraseFile, newCfg.PassphraseFile)
+	changed("passphrasecommand", cfg.PassphraseCommand, newCfg.PassphraseCommand)
+	changed("dbconfig", cfg.DatabaseConfig, newCfg.DatabaseConfig)
+	changed("babylon", cfg.BabylonConfig, newCfg.BabylonConfig)
+	changed("archiveconfig", cfg.ArchiveConfig, newCfg.ArchiveConfig)
+	changed("haconfig", cfg.HAConfig, newCfg.HAConfig)
+	changed("autounjailconfig", cfg.AutoUnjailConfig, newCfg.AutoUnjailConfig)
+	changed("notifierconfig", cfg.NotifierConfig, newCfg.NotifierConfig)
+	changed("rewardwithdrawalconfig", cfg.RewardWithdrawalConfig, newCfg.RewardWithdrawalConfig)
+	changed("healthconfig", cfg.HealthConfig, newCfg.HealthConfig)
+	changed("restgatewayconfig", cfg.RestGatewayConfig, newCfg.RestGatewayConfig)
+	changed("rpcauthconfig", cfg.RPCAuthConfig, newCfg.RPCAuthConfig)
+	changed("eventbusconfig", cfg.EventBusConfig, newCfg.EventBusConfig)
+	changed("supervisorconfig", cfg.SupervisorConfig, newCfg.SupervisorConfig)
+	changed("equivocationwatcherconfig", cfg.EquivocationWatcherConfig, newCfg.EquivocationWatcherConfig)
+	changed("selfcompromiseconfig", cfg.SelfCompromiseConfig, newCfg.SelfCompromiseConfig)
+	changed("preflightconfig", cfg.PreflightConfig, newCfg.PreflightConfig)
+	changed("votetimingconfig", cfg.VoteTimingConfig, newCfg.VoteTimingConfig)
+	changed("criticalerrorconfig", cfg.CriticalErrorConfig, newCfg.CriticalErrorConfig)
+	changed("voteretryconfig", cfg.VoteRetryConfig, newCfg.VoteRetryConfig)
+	changed("clockskewconfig", cfg.ClockSkewConfig, newCfg.ClockSkewConfig)
+	changed("submissionlimitconfig", cfg.SubmissionLimitConfig, newCfg.SubmissionLimitConfig)
+	changed("participationreportconfig", cfg.ParticipationReportConfig, newCfg.ParticipationReportConfig)
+	changed("delegationmonitorconfig", cfg.DelegationMonitorConfig, newCfg.DelegationMonitorConfig)
+	changed("finalitylagconfig", cfg.FinalityLagConfig, newCfg.FinalityLagConfig)
+	changed("submissionhistoryconfig", cfg.SubmissionHistoryConfig, newCfg.SubmissionHistoryConfig)
+	changed("auditconfig", cfg.AuditConfig, newCfg.AuditConfig)
+	changed("nodehealthconfig", cfg.NodeHealthConfig, newCfg.NodeHealthConfig)
+	changed("errorreporterconfig", cfg.ErrorReporterConfig, newCfg.ErrorReporterConfig)
+	changed("alertrulesconfig", cfg.AlertRulesConfig, newCfg.AlertRulesConfig)
+	changed("feebalanceconfig", cfg.FeeBalanceConfig, newCfg.FeeBalanceConfig)
+	changed("participationrateconfig", cfg.ParticipationRateConfig, newCfg.ParticipationRateConfig)
+
+	// the other fields of the poller and the metrics are not reloadable
+	poller, newPoller := *cfg.PollerConfig, *newCfg.PollerConfig
+	poller.PollInterval, newPoller.PollInterval = 0, 0
+	changed("chainpollerconfig", poller, newPoller)
+	metricsCfg, newMetricsCfg := *cfg.Metrics, *newCfg.Metrics
+	metricsCfg.UpdateInterval, newMetricsCfg.UpdateInterval = 0, 0
+	changed("metrics", metricsCfg, newMetricsCfg)
+	// the log file is opened once, only the levels of the modules are
+	// reloadable
+	if cfg.LogConfig != nil && newCfg.LogConfig != nil {
+		logCfg, newLogCfg := *cfg.LogConfig, *newCfg.LogConfig
+		logCfg.ModuleLevels, newLogCfg.ModuleLevels = nil, nil
+		changed("logconfig", logCfg, newLogCfg)
+	}
+
+	return fields
+}
+
+// reloadField sets the field to the new value and records it in the result
+// if it has changed
+func reloadField[T comparable](res *ConfigReloadResult, name string, field *T, newValue T) {
+	if *field == newValue {
+		return
+	}
+	*field = newValue
+	res.Updated = append(res.Updated, name)
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	sdkErr "cosmossdk.io/errors"
+	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	"go.uber.org/zap"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/eventbus"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/notifier"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+)
+
+// the classes of the critical errors, each handled with its own policy
+const (
+	criticalErrClassSlashed    = "slashed"
+	criticalErrClassJailed     = "jailed"
+	criticalErrClassPubRand    = "pubrand"
+	criticalErrClassDoubleSign = "doublesign"
+	criticalErrClassPanic      = "panic"
+	criticalErrClassOther      = "other"
+)
+
+// pubRandErrs are the errors of Babylon upon a vote whose public randomness
+// is missing or expired
+var pubRandErrs = []*sdkErr.Error{
+	finalitytypes.ErrPubRandNotFound,
+	finalitytypes.ErrNoPubRandYet,
+	finalitytypes.ErrTooFewPubRand,
+	finalitytypes.ErrInvalidPubRand,
+}
+
+// classifyCriticalErr returns the class of the given critical error
+func classifyCriticalErr(err error) string {
+	switch {
+	case errors.Is(err, ErrFinalityProviderSlashed):
+		return criticalErrClassSlashed
+	case errors.Is(err, ErrFinalityProviderJailed):
+		return criticalErrClassJailed
+	case errors.Is(err, store.ErrDoubleSign):
+		return criticalErrClassDoubleSign
+	case errors.Is(err, ErrFinalityProviderPanicked):
+		return criticalErrClassPanic
+	}
+
+	// cannot use errors.Is as the errors of Babylon are not wrapped
+	for _, e := range pubRandErrs {
+		if strings.Contains(err.Error(), e.Error()) {
+			return criticalErrClassPubRand
+		}
+	}
+
+	return criticalErrClassOther
+}
+
+// criticalErrPolicy returns the configured policy of the given class of
+// critical errors
+func (fpm *FinalityProviderManager) criticalErrPolicy(class string) string {
+	cfg := fpm.config().CriticalErrorConfig
+	if cfg == nil {
+		defaultCfg := fpcfg.DefaultCriticalErrorConfig()
+		cfg = &defaultCfg
+	}
+
+	switch class {
+	case criticalErrClassSlashed:
+		return cfg.Slashed
+	case criticalErrClassJailed:
+		return cfg.Jailed
+	case criticalErrClassPubRand:
+		return cfg.PubRand
+	case criticalErrClassDoubleSign:
+		return cfg.DoubleSign
+	case criticalErrClassPanic:
+		return cfg.Panic
+	default:
+		return cfg.Other
+	}
+}
+
+// handleCriticalErr applies the policy of the class of the critical error
+// reported by the given instance
+func (fpm *FinalityProviderManager) handleCriticalErr(fpi *FinalityProviderInstance, criticalErr error) {
+	class := classifyCriticalErr(criticalErr)
+	policy := fpm.criticalErrPolicy(class)
+	pkHex := fpi.GetBtcPkHex()
+
+	fpm.metrics.IncrementFpTotalCriticalErrors(pkHex, class, policy)
+	fpm.events.Publish(&eventbus.Event{
+		Type:    eventbus.EventCriticalError,
+		FpBtcPk: pkHex,
+		Error:   criticalErr.Error(),
+	})
+
+	switch policy {
+	case fpcfg.CriticalErrorPolicyHalt:
+		// the status is updated before the daemon is terminated
+		fpm.updateStatusUponCriticalErr(fpi, class)
+		fpm.logger.Fatal("terminating the daemon due to critical error",
+			zap.String("pk", pkHex), zap.String("class", class), zap.Error(criticalErr))
+
+	case fpcfg.CriticalErrorPolicyStop:
+		if fpm.updateStatusUponCriticalErr(fpi, class) {
+			fpm.logger.Debug("the finality-provider instance is stopped",
+				zap.String("pk", pkHex), zap.String("class", class))
+			return
+		}
+		fpm.logger.Error(instanceTerminatingMsg,
+			zap.String("pk", pkHex), zap.String("class", class), zap.Error(criticalErr))
+		// the instance is not restarted by the status sync
+		if err := fpm.StopFinalityProvider(fpi.GetBtcPkBIP340()); err != nil {
+			fpm.logger.Debug("the finality-provider instance is already stopped",
+				zap.String("pk", pkHex), zap.Error(err))
+		}
+		fpm.notifyCriticalErr(fpi, criticalErr)
+
+	case fpcfg.CriticalErrorPolicyAlert:
+		fpm.logger.Error("the finality-provider instance reported a critical error, keep it running",
+			zap.String("pk", pkHex), zap.String("class", class), zap.Error(criticalErr))
+		fpm.notifyCriticalErr(fpi, criticalErr)
+
+	case fpcfg.CriticalErrorPolicyRecommit:
+		fpm.logger.Warn("the public randomness is missing or expired, committing it",
+			zap.String("pk", pkHex), zap.Error(criticalErr))
+		fpi.triggerPubRandCommit()
+
+	default:
+		fpm.handleInstanceCrash(fpi, criticalErr)
+	}
+}
+
+// updateStatusUponCriticalErr sets the status of the jailed or slashed
+// finality provider, which stops its instance. It returns false for the
+// other classes of errors
+func (fpm *FinalityProviderManager) updateStatusUponCriticalErr(fpi *FinalityProviderInstance, class string) bool {
+	switch class {
+	case criticalErrClassSlashed:
+		fpm.setFinalityProviderSlashed(fpi)
+	case criticalErrClassJailed:
+		fpm.setFinalityProviderJailed(fpi)
+	default:
+		return false
+	}
+
+	return true
+}
+
+// notifyCriticalErr alerts the configured notifiers of the critical error
+// of the given instance
+func (fpm *FinalityProviderManager) notifyCriticalErr(fpi *FinalityProviderInstance, criticalErr error) {
+	if !fpm.notifier.Enabled() {
+		return
+	}
+
+	fpm.sendNotification(&notifier.Event{
+		Type:            notifier.EventFailing,
+		FpBtcPk:         fpi.GetBtcPkHex(),
+		ChainID:         string(fpi.GetChainID()),
+		OldStatus:       fpi.GetStatus().String(),
+		LastVotedHeight: fpi.GetLastVotedHeight(),
+		ProbableCause:   criticalErr.Error(),
+		Time:            time.Now().UTC(),
+	})
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/version"
+)
+
+// GetInfo returns the version of the daemon, the sync status of the running
+// finality provider instances against the connected node, the balance of
+// the signer and the reachability of the EOTS manager. The node and the EOTS manager are
+// queried with the timeout of the health checks, so that an unreachable
+// one does not block the call
+func (app *FinalityProviderApp) GetInfo() *proto.GetInfoResponse {
+	return app.getInfo(nil)
+}
+
+// getInfo returns the info of the daemon restricted to the given scope, i.e.,
+// the running instances are those of the scope and the balance of the
+// signer, which is shared by all the finality providers, is left out
+func (app *FinalityProviderApp) getInfo(scope *rpcScope) *proto.GetInfoResponse {
+	timeout := app.config().HealthConfig.CheckTimeout
+	commit, commitTime := version.CommitInfo()
+
+	info := &proto.GetInfoResponse{
+		Version:       version.Version(),
+		InMaintenance: app.IsInMaintenance(),
+		Commit:        commit,
+		CommitTime:    commitTime,
+		ChainId:       app.config().BabylonConfig.ChainID,
+	}
+
+	tip, err := queryWithTimeout(timeout, app.cc.QueryBestBlock)
+	if err != nil {
+		app.logger.Debug("failed to query the best block", zap.Error(err))
+	} else {
+		info.NodeHeight = tip.Height
+	}
+
+	if scope == nil {
+		balance, err := queryWithTimeout(timeout, app.cc.QuerySignerBalance)
+		if err != nil {
+			app.logger.Debug("failed to query the balance of the signer", zap.Error(err))
+		} else {
+			info.SignerBalance = balance.String()
+		}
+	}
+
+	// the local EOTS manager is always reachable
+	info.EotsdConnected = app.eotsManager != nil
+	if pinger, ok := app.eotsManager.(eotsPinger); ok {
+		info.EotsdConnected = runWithTimeout(timeout, pinger.Ping) == nil
+	}
+
+	for _, fpi := range app.fpManager.ListRunningInstances() {
+		if !scope.allows(fpi.GetBtcPkHex()) {
+			continue
+		}
+		if height := fpi.GetLastProcessedHeight(); info.NumRunningFps == 0 || height < info.ProcessedHeight {
+			info.ProcessedHeight = height
+		}
+		info.NumRunningFps++
+	}
+
+	return info
+}
+
+// queryWithTimeout runs the query with the given timeout, the result being
+// sent through a channel as the query might outlive the call
+func queryWithTimeout[T any](timeout time.Duration, query func() (T, error)) (T, error) {
+	resChan := make(chan T, 1)
+	err := runWithTimeout(timeout, func() error {
+		res, err := query()
+		if err != nil {
+			return err
+		}
+		resChan <- res
+		return nil
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return <-resChan, nil
+}
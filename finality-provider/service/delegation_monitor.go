@@ -0,0 +1,94 @@
+package service
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+)
+
+// the statuses of the delegations exposed by the metrics
+const (
+	delegationStatusActive   = "active"
+	delegationStatusPending  = "pending"
+	delegationStatusUnbonded = "unbonded"
+)
+
+func (fpm *FinalityProviderManager) delegationMonitorEnabled() bool {
+	return fpm.config().DelegationMonitorConfig != nil && fpm.config().DelegationMonitorConfig.Enabled
+}
+
+// delegationMonitorLoop periodically queries the BTC delegations to the
+// running finality providers and exposes them through the metrics and the
+// finality provider info
+func (fpm *FinalityProviderManager) delegationMonitorLoop() {
+	defer fpm.wg.Done()
+
+	interval := fpm.config().DelegationMonitorConfig.Interval
+	fpm.logger.Info("starting delegation monitor loop",
+		zap.Float64("interval seconds", interval.Seconds()))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			fpm.updateDelegations()
+		case <-fpm.quit:
+			fpm.logger.Info("exiting delegation monitor loop")
+			return
+		}
+	}
+}
+
+// updateDelegations queries the delegations to each running finality
+// provider, the summaries of the finality providers which are no longer
+// running are dropped while the failed queries keep the previous ones
+func (fpm *FinalityProviderManager) updateDelegations() {
+	running := make(map[string]struct{})
+	for _, fpi := range fpm.ListRunningInstances() {
+		pkHex := fpi.GetBtcPkHex()
+		running[pkHex] = struct{}{}
+
+		summary, err := fpm.cc.QueryFinalityProviderDelegations(fpi.GetBtcPk())
+		if err != nil {
+			fpm.logger.Warn("failed to query the delegations", zap.String("pk", pkHex), zap.Error(err))
+			continue
+		}
+
+		fpm.metrics.RecordFpDelegations(pkHex, delegationStatusActive, summary.NumActive, summary.ActiveSat)
+		fpm.metrics.RecordFpDelegations(pkHex, delegationStatusPending, summary.NumPending, summary.PendingSat)
+		fpm.metrics.RecordFpDelegations(pkHex, delegationStatusUnbonded, summary.NumUnbonded, summary.UnbondedSat)
+
+		fpm.delegationsMu.Lock()
+		fpm.delegations[pkHex] = &proto.DelegationSummary{
+			NumActive:   summary.NumActive,
+			ActiveSat:   summary.ActiveSat,
+			NumPending:  summary.NumPending,
+			PendingSat:  summary.PendingSat,
+			NumUnbonded: summary.NumUnbonded,
+			UnbondedSat: summary.UnbondedSat,
+			UpdatedAt:   time.Now().Unix(),
+		}
+		fpm.delegationsMu.Unlock()
+	}
+
+	fpm.delegationsMu.Lock()
+	defer fpm.delegationsMu.Unlock()
+	for pkHex := range fpm.delegations {
+		if _, ok := running[pkHex]; !ok {
+			delete(fpm.delegations, pkHex)
+		}
+	}
+}
+
+// getDelegationSummary returns the last summary of the delegations to the
+// finality provider, nil if they have not been queried
+func (fpm *FinalityProviderManager) getDelegationSummary(pkHex string) *proto.DelegationSummary {
+	fpm.delegationsMu.RLock()
+	defer fpm.delegationsMu.RUnlock()
+
+	return fpm.delegations[pkHex]
+}
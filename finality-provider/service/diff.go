@@ -0,0 +1,173 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	btcstakingtypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+)
+
+const (
+	DiffFieldStatus          = "status"
+	DiffFieldCommission      = "commission"
+	DiffFieldDescription     = "description"
+	DiffFieldLastVotedHeight = "last_voted_height"
+	DiffFieldAddress         = "address"
+)
+
+// FieldDiff is a field of a finality provider whose stored value differs
+// from the one reported by the chain
+type FieldDiff struct {
+	Field string `json:"field"`
+	Local string `json:"local"`
+	Chain string `json:"chain"`
+	// Fixable tells whether the stored value can be reconciled with the
+	// one of the chain
+	Fixable bool `json:"fixable"`
+}
+
+// FinalityProviderDiff lists the discrepancies between the stored finality
+// provider and the chain
+type FinalityProviderDiff struct {
+	BtcPkHex string       `json:"btc_pk_hex"`
+	Diffs    []*FieldDiff `json:"diffs"`
+	// Fixed tells whether the fixable discrepancies have been reconciled
+	Fixed bool `json:"fixed"`
+}
+
+// DiffFinalityProvider compares the stored finality provider with the given
+// EOTS public key against the chain, i.e., its status, commission,
+// description and last voted height, the last vote being searched within the
+// last scanBlocks blocks. If fix is set, the stored finality provider is
+// reconciled with the chain, except for its address, which cannot change,
+// and its last voted height, which is never decreased. It should not be
+// running, e.g., the daemon should be stopped
+func (app *FinalityProviderApp) DiffFinalityProvider(
+	fpPk *bbntypes.BIP340PubKey,
+	scanBlocks uint64,
+	fix bool,
+) (*FinalityProviderDiff, error) {
+	btcPk := fpPk.MustToBTCPK()
+	sfp, err := app.fps.GetFinalityProvider(btcPk)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &FinalityProviderDiff{BtcPkHex: fpPk.MarshalHex(), Diffs: []*FieldDiff{}}
+
+	res, err := app.cc.QueryFinalityProvider(btcPk)
+	if err != nil {
+		if !strings.Contains(err.Error(), btcstakingtypes.ErrFpNotFound.Error()) {
+			return nil, err
+		}
+		// a finality provider which is not registered should be created
+		if sfp.Status != proto.FinalityProviderStatus_CREATED {
+			diff.Diffs = append(diff.Diffs, &FieldDiff{
+				Field: DiffFieldStatus,
+				Local: sfp.Status.String(),
+				Chain: "NOT_REGISTERED",
+			})
+		}
+
+		return diff, nil
+	}
+	fpRes := res.FinalityProvider
+
+	tip, err := app.cc.QueryBestBlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the best block: %w", err)
+	}
+
+	if fpRes.Addr != sfp.FPAddr {
+		diff.Diffs = append(diff.Diffs, &FieldDiff{Field: DiffFieldAddress, Local: sfp.FPAddr, Chain: fpRes.Addr})
+	}
+
+	chainStatus, err := app.queryRecoveredStatus(btcPk, fpRes.SlashedBabylonHeight > 0 || fpRes.SlashedBtcHeight > 0,
+		fpRes.Jailed, tip.Height)
+	if err != nil {
+		return nil, err
+	}
+	// an inactive finality provider has no voting power as a registered one
+	if sfp.Status != chainStatus &&
+		!(sfp.Status == proto.FinalityProviderStatus_INACTIVE && chainStatus == proto.FinalityProviderStatus_REGISTERED) {
+		diff.Diffs = append(diff.Diffs, &FieldDiff{
+			Field:   DiffFieldStatus,
+			Local:   sfp.Status.String(),
+			Chain:   chainStatus.String(),
+			Fixable: true,
+		})
+	}
+
+	if fpRes.Commission != nil && !sfp.Commission.Equal(*fpRes.Commission) {
+		diff.Diffs = append(diff.Diffs, &FieldDiff{
+			Field:   DiffFieldCommission,
+			Local:   sfp.Commission.String(),
+			Chain:   fpRes.Commission.String(),
+			Fixable: true,
+		})
+	}
+
+	if fpRes.Description != nil && !sfp.Description.Equal(fpRes.Description) {
+		diff.Diffs = append(diff.Diffs, &FieldDiff{
+			Field:   DiffFieldDescription,
+			Local:   sfp.Description.String(),
+			Chain:   fpRes.Description.String(),
+			Fixable: true,
+		})
+	}
+
+	// the stored last voted height might be ahead of the scanned blocks
+	chainLastVotedHeight, err := app.queryLastVotedHeight(fpPk, tip.Height, scanBlocks)
+	if err != nil {
+		return nil, err
+	}
+	if chainLastVotedHeight > sfp.LastVotedHeight {
+		diff.Diffs = append(diff.Diffs, &FieldDiff{
+			Field:   DiffFieldLastVotedHeight,
+			Local:   strconv.FormatUint(sfp.LastVotedHeight, 10),
+			Chain:   strconv.FormatUint(chainLastVotedHeight, 10),
+			Fixable: true,
+		})
+	}
+
+	if !fix || !diff.hasFixable() {
+		return diff, nil
+	}
+
+	if _, err := app.fps.UpdateFpState(btcPk, &store.FinalityProviderStateUpdate{
+		Status:              &chainStatus,
+		LastVotedHeight:     chainLastVotedHeight,
+		LastProcessedHeight: chainLastVotedHeight,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update the state of the finality provider: %w", err)
+	}
+	if fpRes.Description != nil && fpRes.Commission != nil {
+		if err := app.fps.SetFpDescription(btcPk, fpRes.Description, fpRes.Commission); err != nil {
+			return nil, fmt.Errorf("failed to update the description of the finality provider: %w", err)
+		}
+	}
+	diff.Fixed = true
+
+	app.logger.Info("reconciled the finality provider with the chain",
+		zap.String("pk", diff.BtcPkHex),
+		zap.Int("num_diffs", len(diff.Diffs)),
+	)
+
+	return diff, nil
+}
+
+func (d *FinalityProviderDiff) hasFixable() bool {
+	for _, fd := range d.Diffs {
+		if fd.Fixable {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+)
+
+// Doctor runs the preflight checks on demand against the running daemon,
+// i.e., the reachability and the chain id of the node, the reachability of
+// the EOTS manager and its keys, the balance of the key signing the
+// transactions, the health of the db, the runway of the public randomness
+// and the clock skew, each with a remediation hint if it does not pass.
+// The keys of the running instances are checked if no finality provider is
+// given, and the passphrase of the daemon is used if none is given
+func (app *FinalityProviderApp) Doctor(fpPks []*bbntypes.BIP340PubKey, passphrase string) *HealthReport {
+	if len(fpPks) == 0 {
+		for _, fpi := range app.fpManager.ListRunningInstances() {
+			fpPks = append(fpPks, fpi.GetBtcPkBIP340())
+		}
+	}
+	if passphrase == "" {
+		passphrase = app.passphrase
+	}
+
+	return app.Preflight(fpPks, passphrase)
+}
@@ -1,14 +1,21 @@
 package service
 
 import (
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"time"
 
 	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
 	"github.com/babylonlabs-io/finality-provider/types"
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/cometbft/cometbft/crypto/tmhash"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func (fp *FinalityProviderInstance) getPubRandList(startHeight uint64, numPubRand uint32) ([]*btcec.FieldVal, error) {
@@ -48,7 +55,11 @@ func (fp *FinalityProviderInstance) signPubRandCommit(startHeight uint64, numPub
 	}
 
 	// sign the message hash using the finality-provider's BTC private key
-	return fp.em.SignSchnorrSig(fp.btcPk.MustMarshal(), hash, fp.passphrase)
+	start := time.Now()
+	sig, err := fp.em.SignSchnorrSig(fp.btcPk.MustMarshal(), hash, fp.passphrase)
+	fp.recordEOTSSign(eotsMethodSignSchnorrSig, start, err)
+
+	return sig, err
 }
 
 // TODO: have this function in Babylon side
@@ -56,13 +67,96 @@ func getMsgToSignForVote(blockHeight uint64, blockHash []byte) []byte {
 	return append(sdk.Uint64ToBigEndian(blockHeight), blockHash...)
 }
 
+// recordFinalityVote persists the vote over the given block before it is
+// signed. It refuses to sign if a vote over a different block has been
+// signed at the same height, e.g., due to a fork or a rolled back state,
+// as the EOTS private key would be leaked by the two signatures
+func (fp *FinalityProviderInstance) recordFinalityVote(b *types.BlockInfo) error {
+	msgToSign := getMsgToSignForVote(b.Height, b.Hash)
+	err := fp.signRecords.SaveSignRecord(fp.GetChainID(), fp.btcPk.MustMarshal(), b.Height, msgToSign)
+	if errors.Is(err, store.ErrDoubleSign) {
+		fp.metrics.IncrementFpTotalDoubleSignRefusals(fp.GetBtcPkHex())
+		fp.logger.Error("refused to sign a conflicting finality vote, the finality provider would be slashed",
+			zap.String("pk", fp.GetBtcPkHex()),
+			zap.Uint64("height", b.Height),
+			zap.String("block_hash", hex.EncodeToString(b.Hash)),
+		)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record the finality vote: %w", err)
+	}
+
+	return nil
+}
+
 func (fp *FinalityProviderInstance) signFinalitySig(b *types.BlockInfo) (*bbntypes.SchnorrEOTSSig, error) {
 	// build proper finality signature request
 	msgToSign := getMsgToSignForVote(b.Height, b.Hash)
+	start := time.Now()
 	sig, err := fp.em.SignEOTS(fp.btcPk.MustMarshal(), fp.GetChainID(), msgToSign, b.Height, fp.passphrase)
+	fp.recordEOTSSign(eotsMethodSignEOTS, start, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign EOTS: %w", err)
 	}
 
 	return bbntypes.NewSchnorrEOTSSigFromModNScalar(sig), nil
 }
+
+// signFinalitySigs signs the finality signatures over the given blocks in
+// one request to the EOTS manager
+func (fp *FinalityProviderInstance) signFinalitySigs(blocks []*types.BlockInfo) ([]*btcec.ModNScalar, error) {
+	msgs := make([][]byte, 0, len(blocks))
+	heights := make([]uint64, 0, len(blocks))
+	for _, b := range blocks {
+		msgs = append(msgs, getMsgToSignForVote(b.Height, b.Hash))
+		heights = append(heights, b.Height)
+	}
+
+	start := time.Now()
+	sigs, err := fp.em.SignEOTSBatch(fp.btcPk.MustMarshal(), fp.GetChainID(), msgs, heights, fp.passphrase)
+	fp.recordEOTSSign(eotsMethodSignEOTSBatch, start, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign EOTS: %w", err)
+	}
+
+	return sigs, nil
+}
+
+// the methods of the EOTS manager labelling the signing metrics
+const (
+	eotsMethodSignEOTS       = "SignEOTS"
+	eotsMethodSignEOTSBatch  = "SignEOTSBatch"
+	eotsMethodSignSchnorrSig = "SignSchnorrSig"
+)
+
+// recordEOTSSign records the round-trip time of a signing request to the
+// EOTS manager started at the given time, and its error if any
+func (fp *FinalityProviderInstance) recordEOTSSign(method string, start time.Time, err error) {
+	fp.metrics.RecordFpEOTSSignDuration(fp.GetBtcPkHex(), method, time.Since(start))
+	if err != nil {
+		fp.metrics.IncrementFpTotalEOTSSignErrors(fp.GetBtcPkHex(), method, eotsSignErrorType(err))
+	}
+}
+
+// eotsSignErrorType classifies the error of a signing request by its gRPC
+// code, the errors of a local EOTS manager being unknown
+func eotsSignErrorType(err error) string {
+	switch status.Code(err) {
+	case codes.Unavailable:
+		return "unavailable"
+	case codes.DeadlineExceeded:
+		return "deadline_exceeded"
+	case codes.Canceled:
+		return "canceled"
+	case codes.Unauthenticated, codes.PermissionDenied:
+		return "unauthorized"
+	case codes.ResourceExhausted:
+		return "resource_exhausted"
+	case codes.InvalidArgument, codes.NotFound, codes.FailedPrecondition:
+		return "rejected"
+	case codes.Internal:
+		return "internal"
+	default:
+		return "unknown"
+	}
+}
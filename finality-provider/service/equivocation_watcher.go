@@ -0,0 +1,180 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/eventbus"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/notifier"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+)
+
+func (fpm *FinalityProviderManager) equivocationWatcherEnabled() bool {
+	return fpm.config().EquivocationWatcherConfig != nil && fpm.config().EquivocationWatcherConfig.Enabled
+}
+
+// equivocationWatchLoop periodically scans the equivocation evidences
+// recorded on-chain since LookbackBlocks before the tip upon start. Each new
+// evidence is reported once, and the slashing evidence of the equivocating
+// finality providers other than the managed ones is submitted until they are
+// slashed if enabled
+func (fpm *FinalityProviderManager) equivocationWatchLoop() {
+	defer fpm.wg.Done()
+
+	cfg := fpm.config().EquivocationWatcherConfig
+
+	var startHeight uint64
+	tip, err := fpm.getLatestBlockWithRetry()
+	if err != nil {
+		fpm.logger.Warn("failed to get the tip, scanning the equivocation evidences from genesis", zap.Error(err))
+	} else if tip.Height > cfg.LookbackBlocks {
+		startHeight = tip.Height - cfg.LookbackBlocks
+	}
+
+	fpm.logger.Info("starting equivocation watcher",
+		zap.Uint64("start_height", startHeight),
+		zap.Float64("interval seconds", cfg.PollInterval.Seconds()),
+	)
+
+	// reported and settled contain the EOTS public key hex of the finality
+	// providers whose evidence has been reported and, respectively, which
+	// do not need to be slashed anymore
+	reported := make(map[string]struct{})
+	settled := make(map[string]struct{})
+
+	ticker := time.NewTicker(cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		evidences, err := fpm.cc.QueryEvidences(startHeight)
+		if err != nil {
+			fpm.logger.Warn("failed to query the equivocation evidences", zap.Error(err))
+		}
+
+		for _, evidence := range evidences {
+			pkHex := evidence.FpBtcPk.MarshalHex()
+			own := fpm.isManagedFinalityProvider(evidence)
+			if own && cfg.IgnoreOwnKeys {
+				continue
+			}
+
+			if _, ok := reported[pkHex]; !ok {
+				reported[pkHex] = struct{}{}
+				fpm.reportEquivocation(evidence, own)
+			}
+
+			// the managed finality providers are not slashed by the daemon
+			if own || !cfg.SubmitSlashingEvidence {
+				continue
+			}
+			if _, ok := settled[pkHex]; !ok && fpm.submitSlashingEvidence(evidence) {
+				settled[pkHex] = struct{}{}
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-fpm.quit:
+			fpm.logger.Info("exiting equivocation watcher")
+			return
+		}
+	}
+}
+
+// isManagedFinalityProvider returns whether the evidence is against one of
+// the finality providers stored in the db. It returns true if the db cannot
+// be read so that the daemon never submits evidence against its own keys
+func (fpm *FinalityProviderManager) isManagedFinalityProvider(evidence *finalitytypes.Evidence) bool {
+	_, err := fpm.fps.GetFinalityProvider(evidence.FpBtcPk.MustToBTCPK())
+	if errors.Is(err, store.ErrFinalityProviderNotFound) {
+		return false
+	}
+	if err != nil {
+		fpm.logger.Warn("failed to get the finality provider of the evidence",
+			zap.String("pk", evidence.FpBtcPk.MarshalHex()), zap.Error(err))
+	}
+
+	return true
+}
+
+// reportEquivocation surfaces the evidence through the logs, the metrics,
+// the event bus and the notifiers
+func (fpm *FinalityProviderManager) reportEquivocation(evidence *finalitytypes.Evidence, own bool) {
+	pkHex := evidence.FpBtcPk.MarshalHex()
+
+	logFields := []zap.Field{
+		zap.String("pk", pkHex),
+		zap.Uint64("height", evidence.BlockHeight),
+		zap.Bool("own", own),
+	}
+	if own {
+		fpm.logger.Error("found an equivocation evidence against a managed finality provider", logFields...)
+	} else {
+		fpm.logger.Warn("found an equivocation evidence against a finality provider", logFields...)
+	}
+
+	fpm.metrics.IncrementFpTotalEquivocationEvidences(pkHex, own)
+
+	fpm.events.Publish(&eventbus.Event{
+		Type:    eventbus.EventEquivocationDetected,
+		FpBtcPk: pkHex,
+		Height:  evidence.BlockHeight,
+	})
+
+	if !fpm.notifier.Enabled() {
+		return
+	}
+
+	fpm.sendNotification(&notifier.Event{
+		Type:          notifier.EventEquivocated,
+		FpBtcPk:       pkHex,
+		ChainID:       fpm.config().BabylonConfig.ChainID,
+		Height:        evidence.BlockHeight,
+		ProbableCause: probableCauses[notifier.EventEquivocated],
+		Time:          time.Now().UTC(),
+	})
+}
+
+// submitSlashingEvidence extracts the key of the equivocating finality
+// provider from the evidence and submits it to slash the finality provider.
+// It returns true if the finality provider does not need to be slashed
+// anymore, false if the submission should be retried
+func (fpm *FinalityProviderManager) submitSlashingEvidence(evidence *finalitytypes.Evidence) bool {
+	fpPk := evidence.FpBtcPk.MustToBTCPK()
+	pkHex := evidence.FpBtcPk.MarshalHex()
+
+	slashed, _, err := fpm.cc.QueryFinalityProviderSlashedOrJailed(fpPk)
+	if err != nil {
+		fpm.logger.Warn("failed to query whether the equivocating finality provider is slashed",
+			zap.String("pk", pkHex), zap.Error(err))
+		return false
+	}
+	if slashed {
+		fpm.logger.Debug("the equivocating finality provider is already slashed", zap.String("pk", pkHex))
+		return true
+	}
+
+	sk, err := evidence.ExtractBTCSK()
+	if err != nil {
+		fpm.logger.Warn("failed to extract the key from the evidence, skip slashing the finality provider",
+			zap.String("pk", pkHex), zap.Error(err))
+		return true
+	}
+
+	res, err := fpm.cc.SubmitSelectiveSlashingEvidence(sk)
+	if err != nil {
+		fpm.logger.Error("failed to submit the slashing evidence",
+			zap.String("pk", pkHex), zap.Error(err))
+		// e.g., the finality provider is slashed in the meantime
+		return clientcontroller.IsUnrecoverable(err)
+	}
+
+	fpm.logger.Info("successfully submitted the slashing evidence of the equivocating finality provider",
+		zap.String("pk", pkHex), zap.String("tx_hash", res.TxHash))
+
+	return true
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/errreport"
+)
+
+const (
+	errorReportComponentFp  = "finality_provider"
+	errorReportComponentRPC = "rpc"
+)
+
+// errorReporters ships the panics and the critical errors to the configured
+// and the registered error reporters. The reports are scrubbed of the secrets
+// before being shipped
+type errorReporters struct {
+	chainID string
+	timeout time.Duration
+	logger  *zap.Logger
+
+	mu        sync.RWMutex
+	reporters errreport.MultiReporter
+}
+
+func newErrorReporters(cfg *fpcfg.Config, logger *zap.Logger) (*errorReporters, error) {
+	reporterCfg := cfg.ErrorReporterConfig
+	if reporterCfg == nil {
+		defaultCfg := fpcfg.DefaultErrorReporterConfig()
+		reporterCfg = &defaultCfg
+	}
+
+	r := &errorReporters{
+		chainID: cfg.BabylonConfig.ChainID,
+		timeout: reporterCfg.Timeout,
+		logger:  logger,
+	}
+
+	reporter, err := errreport.New(reporterCfg)
+	if err != nil {
+		return nil, err
+	}
+	if reporter != nil {
+		r.reporters = append(r.reporters, reporter)
+	}
+
+	return r, nil
+}
+
+func (r *errorReporters) register(reporter errreport.Reporter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reporters = append(r.reporters, reporter)
+}
+
+// report ships the report to the reporters. It blocks until the reporters
+// are done or the timeout expires, so that the report is not lost if the
+// daemon is terminated right after, and a failure is logged rather than
+// returned
+func (r *errorReporters) report(ctx context.Context, report *errreport.Report) {
+	r.mu.RLock()
+	reporters := r.reporters
+	r.mu.RUnlock()
+
+	if len(reporters) == 0 {
+		return
+	}
+
+	if report.ChainID == "" {
+		report.ChainID = r.chainID
+	}
+	scrubbed := errreport.Scrub(report)
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	if err := reporters.Report(ctx, scrubbed); err != nil {
+		r.logger.Error("failed to report the error",
+			zap.String("kind", string(report.Kind)),
+			zap.String("component", report.Component),
+			zap.Error(err),
+		)
+	}
+}
+
+// RegisterErrorReporter registers the reporter to which the panics and the
+// critical errors are shipped along with the configured one, so that the
+// daemon can be integrated with other error tracking services
+func (fpm *FinalityProviderManager) RegisterErrorReporter(reporter errreport.Reporter) {
+	fpm.errReporters.register(reporter)
+}
+
+// captureCriticalErr reports the critical error of the given instance along
+// with its context
+func (fpm *FinalityProviderManager) captureCriticalErr(fpi *FinalityProviderInstance, criticalErr *CriticalError) {
+	kind := errreport.KindCriticalError
+	if errors.Is(criticalErr.err, ErrFinalityProviderPanicked) {
+		kind = errreport.KindPanic
+	}
+
+	fpm.errReporters.report(fpm.ctx, &errreport.Report{
+		Kind:      kind,
+		Component: errorReportComponentFp,
+		Message:   criticalErr.err.Error(),
+		Class:     classifyCriticalErr(criticalErr.err),
+		FpBtcPk:   fpi.GetBtcPkHex(),
+		ChainID:   string(fpi.GetChainID()),
+		Height:    fpi.GetLastProcessedHeight(),
+		Stack:     string(criticalErr.stack),
+		Time:      time.Now().UTC(),
+	})
+}
@@ -6,4 +6,60 @@ var (
 	ErrFinalityProviderShutDown = errors.New("the finality provider instance is shutting down")
 	ErrFinalityProviderJailed   = errors.New("the finality provider instance is jailed")
 	ErrFinalityProviderSlashed  = errors.New("the finality provider instance is slashed")
+	ErrFinalityProviderStandby  = errors.New("the finality provider instance is a standby")
+	ErrFinalityProviderPanicked = errors.New("the finality provider instance panicked")
+	// ErrMaxFailedCycles is returned if the submission still fails after
+	// the max retries
+	ErrMaxFailedCycles = errors.New("reached max failed cycles")
+	// ErrPubRandCommitmentMismatch is returned if the public randomness re-derived
+	// from the EOTS manager does not match the on-chain commitment
+	ErrPubRandCommitmentMismatch = errors.New("the regenerated public randomness does not match the on-chain commitment")
+	// ErrClockSkewTooLarge is returned if the local clock is skewed by more
+	// than the max skew relative to the latest block
+	ErrClockSkewTooLarge = errors.New("the local clock is skewed beyond the max skew")
+	// ErrInMaintenance is returned if a write is requested while the daemon
+	// is in the maintenance mode
+	ErrInMaintenance = errors.New("the daemon is in the maintenance mode")
+	// ErrNotInMaintenance is returned if the maintenance mode is exited
+	// while it is not on
+	ErrNotInMaintenance = errors.New("the daemon is not in the maintenance mode")
+	// ErrSubmissionRateLimited is returned if a submission is rejected as the
+	// finality provider reached the max submissions per minute
+	ErrSubmissionRateLimited = errors.New("the submissions of the finality provider are rate limited")
+	// ErrAppShuttingDown is returned if a request is made while the app is
+	// shutting down
+	ErrAppShuttingDown = errors.New("finality-provider app is shutting down")
+	// ErrFinalityProviderAlreadyRegistered is returned if the registration of
+	// a finality provider which is already registered is requested
+	ErrFinalityProviderAlreadyRegistered = errors.New("finality-provider is already registered")
+	// ErrFinalityProviderNotRunning is returned if the instance of the
+	// requested finality provider is not run by the daemon
+	ErrFinalityProviderNotRunning = errors.New("the finality provider instance does not exist")
+	// ErrInvalidDescription is returned if the description of a finality
+	// provider is rejected
+	ErrInvalidDescription = errors.New("invalid description")
+	// ErrInvalidCommission is returned if the commission rate of a finality
+	// provider is rejected
+	ErrInvalidCommission = errors.New("invalid commission")
+	// ErrInvalidEotsPk is returned if the EOTS public key of a request is
+	// missing or malformed
+	ErrInvalidEotsPk = errors.New("invalid EOTS public key")
+	// ErrChainKeyNotFound is returned if the chain key of a finality provider
+	// is not in the keyring
+	ErrChainKeyNotFound = errors.New("the chain key does not exist")
+	// ErrRejectedByHook is returned if a vote or a public randomness commit
+	// is aborted by a registered submission hook
+	ErrRejectedByHook = errors.New("the submission is rejected by a hook")
+	// ErrInvalidHeightRange is returned if the height range of a query is
+	// empty or too large
+	ErrInvalidHeightRange = errors.New("invalid height range")
+	// ErrUnjailNotConfirmed is returned if the unjailing of a finality
+	// provider is requested without the confirmation
+	ErrUnjailNotConfirmed = errors.New("the unjailing is not confirmed")
+	// ErrMessageNotSignable is returned if an arbitrary message is refused
+	// by the signing policy
+	ErrMessageNotSignable = errors.New("the message is not signable")
+	// ErrInvalidLogLevel is returned if the log level or the module of a
+	// request is unknown
+	ErrInvalidLogLevel = errors.New("invalid log level")
 )
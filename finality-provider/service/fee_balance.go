@@ -0,0 +1,206 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/notifier"
+)
+
+func (fpm *FinalityProviderManager) feeBalanceEnabled() bool {
+	return fpm.config().FeeBalanceConfig != nil && fpm.config().FeeBalanceConfig.Enabled
+}
+
+// feeBalanceSample is the balance of a denom of an account at a given time
+type feeBalanceSample struct {
+	time   time.Time
+	amount float64
+}
+
+// feeSpendTracker measures the spend rate of each denom of each account from
+// its balances within the spend window, the top-ups being ignored
+type feeSpendTracker struct {
+	window time.Duration
+	// samples are keyed by the address and the denom, oldest first
+	samples map[string][]feeBalanceSample
+}
+
+func newFeeSpendTracker(window time.Duration) *feeSpendTracker {
+	return &feeSpendTracker{
+		window:  window,
+		samples: make(map[string][]feeBalanceSample),
+	}
+}
+
+// add records the balance and drops the samples older than the window
+func (t *feeSpendTracker) add(key string, now time.Time, amount float64) {
+	samples := append(t.samples[key], feeBalanceSample{time: now, amount: amount})
+	i := 0
+	for i < len(samples)-1 && now.Sub(samples[i].time) > t.window {
+		i++
+	}
+	t.samples[key] = samples[i:]
+}
+
+// hourlyRate returns the amount spent per hour over the samples, or false if
+// they do not span half of the window yet, as a shorter span is too noisy
+// to be projected
+func (t *feeSpendTracker) hourlyRate(key string) (float64, bool) {
+	samples := t.samples[key]
+	if len(samples) < 2 {
+		return 0, false
+	}
+	span := samples[len(samples)-1].time.Sub(samples[0].time)
+	if span < t.window/2 {
+		return 0, false
+	}
+
+	var spent float64
+	for i := 1; i < len(samples); i++ {
+		if d := samples[i-1].amount - samples[i].amount; d > 0 {
+			spent += d
+		}
+	}
+
+	return spent / span.Hours(), true
+}
+
+// feeBalanceLoop periodically queries the balances of the accounts paying
+// the fees and warns once one falls below the min or the projected spend
+func (fpm *FinalityProviderManager) feeBalanceLoop() {
+	defer fpm.wg.Done()
+
+	cfg := fpm.config().FeeBalanceConfig
+	fpm.logger.Info("starting fee balance monitor loop",
+		zap.Float64("interval seconds", cfg.PollInterval.Seconds()))
+
+	ticker := time.NewTicker(cfg.PollInterval)
+	defer ticker.Stop()
+
+	// the tracker and the low accounts are only accessed by this loop
+	tracker := newFeeSpendTracker(cfg.SpendWindow)
+	lowAccounts := make(map[string]struct{})
+
+	for {
+		select {
+		case <-ticker.C:
+			fpm.checkFeeBalances(tracker, lowAccounts)
+		case <-fpm.quit:
+			fpm.logger.Info("exiting fee balance monitor loop")
+			return
+		}
+	}
+}
+
+func (fpm *FinalityProviderManager) checkFeeBalances(tracker *feeSpendTracker, lowAccounts map[string]struct{}) {
+	cfg := fpm.config().FeeBalanceConfig
+	now := time.Now()
+
+	balances, err := fpm.cc.QueryFeeAccountBalances()
+	if err != nil {
+		fpm.logger.Warn("failed to query the balances of the fee accounts", zap.Error(err))
+		return
+	}
+
+	minBalance := cfg.MinBalanceCoins()
+	for addr, coins := range balances {
+		var reasons []string
+		for _, denom := range feeBalanceDenoms(coins, minBalance) {
+			amount := coins.AmountOf(denom)
+			amountF, err := amount.ToLegacyDec().Float64()
+			if err != nil {
+				amountF = math.MaxFloat64
+			}
+			fpm.metrics.RecordFeeAccountBalance(addr, denom, amountF)
+
+			if minAmount := minBalance.AmountOf(denom); minAmount.IsPositive() && amount.LT(minAmount) {
+				reasons = append(reasons, fmt.Sprintf("the balance %s%s is below the min %s%s",
+					amount, denom, minAmount, denom))
+			}
+
+			if cfg.RunwayWindow == 0 {
+				continue
+			}
+			key := addr + "/" + denom
+			tracker.add(key, now, amountF)
+			rate, ok := tracker.hourlyRate(key)
+			if !ok {
+				continue
+			}
+			if rate == 0 {
+				fpm.metrics.RecordFeeAccountRunway(addr, denom, math.Inf(1))
+				continue
+			}
+			runwayHours := amountF / rate
+			fpm.metrics.RecordFeeAccountRunway(addr, denom, runwayHours)
+			if runwayHours < cfg.RunwayWindow.Hours() {
+				reasons = append(reasons, fmt.Sprintf("the balance %s%s lasts %.1f hours at the spend rate of %.0f%s per hour, below %s",
+					amount, denom, runwayHours, rate, denom, cfg.RunwayWindow))
+			}
+		}
+
+		_, wasLow := lowAccounts[addr]
+		switch {
+		case len(reasons) > 0 && !wasLow:
+			lowAccounts[addr] = struct{}{}
+			fpm.logger.Warn("the balance of the fee account is low",
+				zap.String("address", addr),
+				zap.String("balance", coins.String()),
+				zap.Strings("reasons", reasons),
+			)
+			fpm.notifyLowBalance(addr, reasons)
+		case len(reasons) == 0 && wasLow:
+			delete(lowAccounts, addr)
+			fpm.logger.Info("the balance of the fee account is no longer low",
+				zap.String("address", addr),
+				zap.String("balance", coins.String()),
+			)
+		}
+	}
+}
+
+// feeBalanceDenoms returns the sorted denoms of the balance and of the min
+// balance, so that an account without any of the min denoms is checked
+func feeBalanceDenoms(balance, minBalance sdk.Coins) []string {
+	seen := make(map[string]struct{}, len(balance)+len(minBalance))
+	for _, coin := range balance {
+		seen[coin.Denom] = struct{}{}
+	}
+	for _, coin := range minBalance {
+		seen[coin.Denom] = struct{}{}
+	}
+	denoms := make([]string, 0, len(seen))
+	for denom := range seen {
+		denoms = append(denoms, denom)
+	}
+	sort.Strings(denoms)
+
+	return denoms
+}
+
+// notifyLowBalance alerts the configured notifiers on behalf of each running
+// finality provider that the balance of the given fee account is low
+func (fpm *FinalityProviderManager) notifyLowBalance(addr string, reasons []string) {
+	if !fpm.notifier.Enabled() {
+		return
+	}
+
+	cause := fmt.Sprintf("fee account %s: %s; %s", addr, strings.Join(reasons, ", "), probableCauses[notifier.EventLowBalance])
+	for _, fpi := range fpm.ListRunningInstances() {
+		fpm.sendNotification(&notifier.Event{
+			Type:            notifier.EventLowBalance,
+			FpBtcPk:         fpi.GetBtcPkHex(),
+			ChainID:         string(fpi.GetChainID()),
+			OldStatus:       fpi.GetStatus().String(),
+			LastVotedHeight: fpi.GetLastVotedHeight(),
+			ProbableCause:   cause,
+			Time:            time.Now().UTC(),
+		})
+	}
+}
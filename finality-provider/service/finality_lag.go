@@ -0,0 +1,160 @@
+package service
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/notifier"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+)
+
+// lagAlert tracks how long a gap has stayed above its threshold so that the
+// notifiers are alerted once per lagging period
+type lagAlert struct {
+	since    time.Time
+	notified bool
+}
+
+// update returns true if the gap has been above its threshold for at least
+// sustainedFor and the notifiers have not been alerted yet
+func (a *lagAlert) update(lagging bool, now time.Time, sustainedFor time.Duration) bool {
+	if !lagging {
+		*a = lagAlert{}
+		return false
+	}
+	if a.since.IsZero() {
+		a.since = now
+	}
+	if a.notified || now.Sub(a.since) < sustainedFor {
+		return false
+	}
+	a.notified = true
+
+	return true
+}
+
+func (fpm *FinalityProviderManager) finalityLagEnabled() bool {
+	return fpm.config().FinalityLagConfig != nil && fpm.config().FinalityLagConfig.Enabled
+}
+
+// finalityLagLoop periodically measures the gap between the tip and both the
+// last voted height of the running finality providers and the last finalized
+// height, and alerts the notifiers if a gap stays above its threshold
+func (fpm *FinalityProviderManager) finalityLagLoop() {
+	defer fpm.wg.Done()
+
+	cfg := fpm.config().FinalityLagConfig
+	fpm.logger.Info("starting finality lag monitor loop",
+		zap.Float64("interval seconds", cfg.CheckInterval.Seconds()))
+
+	ticker := time.NewTicker(cfg.CheckInterval)
+	defer ticker.Stop()
+
+	// the alerts are only accessed by this loop
+	var finalizationLag lagAlert
+	voteLags := make(map[string]*lagAlert)
+
+	for {
+		select {
+		case <-ticker.C:
+			fpm.checkFinalityLag(&finalizationLag, voteLags)
+		case <-fpm.quit:
+			fpm.logger.Info("exiting finality lag monitor loop")
+			return
+		}
+	}
+}
+
+func (fpm *FinalityProviderManager) checkFinalityLag(finalizationLag *lagAlert, voteLags map[string]*lagAlert) {
+	cfg := fpm.config().FinalityLagConfig
+	now := time.Now()
+
+	tip, err := fpm.cc.QueryBestBlock()
+	if err != nil {
+		fpm.logger.Warn("failed to query the tip to measure the finality lag", zap.Error(err))
+		return
+	}
+
+	running := fpm.ListRunningInstances()
+
+	// no block is finalized before the finality activation
+	finalized, err := fpm.cc.QueryLatestFinalizedBlocks(1)
+	if err != nil {
+		fpm.logger.Warn("failed to query the last finalized block", zap.Error(err))
+	} else if len(finalized) > 0 {
+		lag := lagBehind(tip.Height, finalized[0].Height)
+		fpm.metrics.RecordFinalizationLag(lag)
+		lagging := cfg.MaxFinalizationLag > 0 && lag > cfg.MaxFinalizationLag
+		if finalizationLag.update(lagging, now, cfg.SustainedFor) {
+			fpm.logger.Warn("the last finalized height is lagging behind the tip",
+				zap.Uint64("tip_height", tip.Height),
+				zap.Uint64("finalized_height", finalized[0].Height),
+				zap.Uint64("max_lag", cfg.MaxFinalizationLag),
+			)
+			for _, fpi := range running {
+				fpm.notifyLagging(fpi, notifier.EventFinalizationLagging, tip.Height)
+			}
+		}
+	}
+
+	alive := make(map[string]struct{}, len(running))
+	for _, fpi := range running {
+		pkHex := fpi.GetBtcPkHex()
+		alive[pkHex] = struct{}{}
+
+		lastVotedHeight := fpi.GetLastVotedHeight()
+		lag := lagBehind(tip.Height, lastVotedHeight)
+		fpm.metrics.RecordFpVoteLag(pkHex, lag)
+
+		alert, ok := voteLags[pkHex]
+		if !ok {
+			alert = &lagAlert{}
+			voteLags[pkHex] = alert
+		}
+		// only the active finality providers are expected to vote
+		lagging := cfg.MaxVoteLag > 0 && fpi.GetStatus() == proto.FinalityProviderStatus_ACTIVE && lag > cfg.MaxVoteLag
+		if alert.update(lagging, now, cfg.SustainedFor) {
+			fpm.logger.Warn("the last voted height of the finality provider is lagging behind the tip",
+				zap.String("pk", pkHex),
+				zap.Uint64("tip_height", tip.Height),
+				zap.Uint64("last_voted_height", lastVotedHeight),
+				zap.Uint64("max_lag", cfg.MaxVoteLag),
+			)
+			fpm.notifyLagging(fpi, notifier.EventVoteLagging, tip.Height)
+		}
+	}
+	for pkHex := range voteLags {
+		if _, ok := alive[pkHex]; !ok {
+			delete(voteLags, pkHex)
+		}
+	}
+}
+
+// notifyLagging alerts the configured notifiers that the last voted or the
+// last finalized height stays too far behind the tip
+func (fpm *FinalityProviderManager) notifyLagging(fpi *FinalityProviderInstance, eventType notifier.EventType, tipHeight uint64) {
+	if !fpm.notifier.Enabled() {
+		return
+	}
+
+	fpm.sendNotification(&notifier.Event{
+		Type:            eventType,
+		FpBtcPk:         fpi.GetBtcPkHex(),
+		ChainID:         string(fpi.GetChainID()),
+		Height:          tipHeight,
+		LastVotedHeight: fpi.GetLastVotedHeight(),
+		ProbableCause:   probableCauses[eventType],
+		Time:            time.Now().UTC(),
+	})
+}
+
+// lagBehind returns the number of blocks between the tip and the given
+// height, zero if the height is not behind the tip
+func lagBehind(tipHeight, height uint64) uint64 {
+	if height >= tipHeight {
+		return 0
+	}
+
+	return tipHeight - height
+}
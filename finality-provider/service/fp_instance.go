@@ -1,9 +1,11 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math"
+	"runtime/debug"
 	"strings"
 	"sync"
 	"time"
@@ -19,8 +21,10 @@ import (
 	"github.com/babylonlabs-io/finality-provider/clientcontroller"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager"
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/eventbus"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/log"
 	"github.com/babylonlabs-io/finality-provider/metrics"
 	"github.com/babylonlabs-io/finality-provider/types"
 )
@@ -30,22 +34,90 @@ type FinalityProviderInstance struct {
 
 	fpState      *fpState
 	pubRandState *pubRandState
-	cfg          *fpcfg.Config
+	signRecords  *store.SignRecordStore
+	// voteRetries is nil if the failed votes are not retried
+	voteRetries *store.VoteRetryStore
+	// cfgSnapshot holds the current config, the manager replaces it with
+	// the one shared with the app so that the config reloads are applied
+	cfgSnapshot *atomic.Pointer[fpcfg.Config]
 
 	logger  *zap.Logger
 	em      eotsmanager.EOTSManager
 	cc      clientcontroller.ClientController
 	poller  *ChainPoller
 	metrics *metrics.FpMetrics
+	// events is nil if the event bus is disabled
+	events *eventbus.Bus
 
 	// passphrase is used to unlock private keys
 	passphrase string
 
 	criticalErrChan chan<- *CriticalError
 
+	// ctx is cancelled to abort the outstanding calls upon shutdown
+	ctx context.Context
+
 	isStarted *atomic.Bool
 	inSync    *atomic.Bool
 	isLagging *atomic.Bool
+	// isSubmittingSigs is true while finality signatures are being
+	// submitted, the public randomness commitment is deferred meanwhile
+	isSubmittingSigs *atomic.Bool
+	// isPaused is true while the voting and the randomness commitment are
+	// paused by the operator, the instance keeps running otherwise
+	isPaused *atomic.Bool
+	// isLeader is always true if high availability is disabled
+	isLeader *atomic.Bool
+	// promoted is set once the instance becomes the leader, for the
+	// signature submission loop to rewind the poller to the blocks dropped
+	// as a standby
+	promoted *atomic.Bool
+	// lastHeartbeat is the time of the last iteration of the signature
+	// submission loop, which is reported by the liveness check
+	lastHeartbeat *atomic.Time
+	// lastReceivedHeight is the height of the last block received from
+	// the poller, which is used to check the lag behind the tip
+	lastReceivedHeight *atomic.Uint64
+	// startHeight is the height from which the poller is started
+	startHeight *atomic.Uint64
+
+	// maintenance is shared by the instances of the manager to freeze the
+	// chain submissions and the db writes, nil if not managed
+	maintenance *maintenanceGate
+	// submissionLimiter caps the transactions submitted per minute, nil if
+	// the limit is disabled
+	submissionLimiter *submissionLimiter
+	// outbox records the submissions until they are confirmed, nil if the
+	// instance is not managed
+	outbox *store.OutboxStore
+	// history records the results of the submissions, nil if the instance
+	// is not managed or the history is disabled
+	history *store.SubmissionHistoryStore
+	// hooks intercept the signing and the submission, nil if the instance is
+	// not managed
+	hooks *submissionHooks
+
+	// pubRandCommitTrigger triggers a public randomness commitment
+	// without waiting for the next tick
+	pubRandCommitTrigger chan struct{}
+	// pubRandCommitMu serializes the scheduled commitments and the manual
+	// ones, so that they do not commit the same heights
+	pubRandCommitMu sync.Mutex
+
+	voteTiming voteTimingStrategy
+	// pendingBlocks are the received blocks waiting to be voted according
+	// to the vote timing strategy, only accessed by the signature
+	// submission loop
+	pendingBlocks []*types.BlockInfo
+	// votePipeline records the time spent in each stage of the votes
+	votePipeline *votePipelineRecorder
+	// streaks track the consecutive failures exposed for alerting
+	streaks *alertStreaks
+	// participation tracks the voted heights for the rolling participation
+	// rate, nil if the instance is not managed or the rate is disabled
+	participation *participationTracker
+	// alerts is set by the manager, nil if the alerts are not configured
+	alerts *instanceAlerts
 
 	wg   sync.WaitGroup
 	quit chan struct{}
@@ -54,13 +126,17 @@ type FinalityProviderInstance struct {
 // NewFinalityProviderInstance returns a FinalityProviderInstance instance with the given Babylon public key
 // the finality-provider should be registered before
 func NewFinalityProviderInstance(
+	ctx context.Context,
 	fpPk *bbntypes.BIP340PubKey,
 	cfg *fpcfg.Config,
 	s *store.FinalityProviderStore,
 	prStore *store.PubRandProofStore,
+	srStore *store.SignRecordStore,
+	vrStore *store.VoteRetryStore,
 	cc clientcontroller.ClientController,
 	em eotsmanager.EOTSManager,
 	metrics *metrics.FpMetrics,
+	events *eventbus.Bus,
 	passphrase string,
 	errChan chan<- *CriticalError,
 	logger *zap.Logger,
@@ -74,43 +150,81 @@ func NewFinalityProviderInstance(
 		return nil, fmt.Errorf("the finality provider instance cannot be initiated with status %s", sfp.Status.String())
 	}
 
-	return newFinalityProviderInstanceFromStore(sfp, cfg, s, prStore, cc, em, metrics, passphrase, errChan, logger)
+	return newFinalityProviderInstanceFromStore(ctx, sfp, cfg, s, prStore, srStore, vrStore, cc, em, metrics, events, passphrase, errChan, logger)
 }
 
 // Helper function to create FinalityProviderInstance from store data
 func newFinalityProviderInstanceFromStore(
+	ctx context.Context,
 	sfp *store.StoredFinalityProvider,
 	cfg *fpcfg.Config,
 	s *store.FinalityProviderStore,
 	prStore *store.PubRandProofStore,
+	srStore *store.SignRecordStore,
+	vrStore *store.VoteRetryStore,
 	cc clientcontroller.ClientController,
 	em eotsmanager.EOTSManager,
 	metrics *metrics.FpMetrics,
+	events *eventbus.Bus,
 	passphrase string,
 	errChan chan<- *CriticalError,
 	logger *zap.Logger,
 ) (*FinalityProviderInstance, error) {
-	return &FinalityProviderInstance{
-		btcPk:           bbntypes.NewBIP340PubKeyFromBTCPK(sfp.BtcPk),
-		fpState:         newFpState(sfp, s),
-		pubRandState:    newPubRandState(prStore),
-		cfg:             cfg,
-		logger:          logger,
-		isStarted:       atomic.NewBool(false),
-		inSync:          atomic.NewBool(false),
-		isLagging:       atomic.NewBool(false),
-		criticalErrChan: errChan,
-		passphrase:      passphrase,
-		em:              em,
-		cc:              cc,
-		metrics:         metrics,
-	}, nil
-}
-
-func (fp *FinalityProviderInstance) Start() error {
+	fp := &FinalityProviderInstance{
+		btcPk:              bbntypes.NewBIP340PubKeyFromBTCPK(sfp.BtcPk),
+		fpState:            newFpState(sfp, s),
+		pubRandState:       newPubRandState(prStore),
+		signRecords:        srStore,
+		voteRetries:        vrStore,
+		cfgSnapshot:        atomic.NewPointer(cfg),
+		logger:             logger,
+		isStarted:          atomic.NewBool(false),
+		inSync:             atomic.NewBool(false),
+		isLagging:          atomic.NewBool(false),
+		isSubmittingSigs:   atomic.NewBool(false),
+		isPaused:           atomic.NewBool(false),
+		isLeader:           atomic.NewBool(cfg.HAConfig == nil || !cfg.HAConfig.Enabled),
+		promoted:           atomic.NewBool(false),
+		lastHeartbeat:      atomic.NewTime(time.Time{}),
+		lastReceivedHeight: atomic.NewUint64(0),
+		startHeight:        atomic.NewUint64(0),
+		criticalErrChan:    errChan,
+		ctx:                ctx,
+		passphrase:         passphrase,
+		em:                 em,
+		cc:                 cc,
+		metrics:            metrics,
+		events:             events,
+	}
+	fp.voteTiming = newVoteTimingStrategy(cfg.VoteTimingConfig, fp)
+	fp.votePipeline = newVotePipelineRecorder(fp.GetBtcPkHex(), metrics, logger)
+	fp.streaks = newAlertStreaks(fp.GetBtcPkHex(), metrics)
+	// the manager replaces the limiter with the one kept across the restarts
+	if cfg.SubmissionLimitConfig.Enabled() {
+		fp.submissionLimiter = newSubmissionLimiter(fp.GetBtcPkHex(), cfg.SubmissionLimitConfig.MaxSubmissionsPerMinute, metrics, logger, nil)
+	}
+	// buffered so that the triggers are coalesced
+	fp.pubRandCommitTrigger = make(chan struct{}, 1)
+
+	return fp, nil
+}
+
+// cfg returns the current config, which must not be modified
+func (fp *FinalityProviderInstance) cfg() *fpcfg.Config {
+	return fp.cfgSnapshot.Load()
+}
+
+// Start starts the poller and the loops of the instance, which is left
+// stopped if any step fails, so that it can be started again
+func (fp *FinalityProviderInstance) Start() (err error) {
 	if fp.isStarted.Swap(true) {
 		return fmt.Errorf("the finality-provider instance %s is already started", fp.GetBtcPkHex())
 	}
+	defer func() {
+		if err != nil {
+			fp.isStarted.Store(false)
+		}
+	}()
 
 	if fp.IsJailed() {
 		return fmt.Errorf("%w: %s", ErrFinalityProviderJailed, fp.GetBtcPkHex())
@@ -118,26 +232,56 @@ func (fp *FinalityProviderInstance) Start() error {
 
 	fp.logger.Info("Starting finality-provider instance", zap.String("pk", fp.GetBtcPkHex()))
 
+	var leaser eotsmanager.Leaser
+	if fp.haEnabled() {
+		if leaser, err = fp.leaser(); err != nil {
+			return err
+		}
+	}
+
+	// restore the height metrics from the stored state
+	fp.recordHeightMetrics()
+
+	if err := fp.migrateLegacyPubRandProofs(); err != nil {
+		return fmt.Errorf("failed to migrate legacy public randomness proofs: %w", err)
+	}
+
+	if err := fp.reconcileOutbox(); err != nil {
+		return fmt.Errorf("failed to reconcile the outbox: %w", err)
+	}
+
 	startHeight, err := fp.getPollerStartingHeight()
 	if err != nil {
 		return fmt.Errorf("failed to get the start height: %w", err)
 	}
+	startHeight, err = fp.skipBeforeActivation(startHeight)
+	if err != nil {
+		return fmt.Errorf("failed to skip the heights before activation: %w", err)
+	}
+	fp.startHeight.Store(startHeight)
 
 	fp.logger.Info("starting the finality provider",
 		zap.String("pk", fp.GetBtcPkHex()), zap.Uint64("height", startHeight))
 
-	poller := NewChainPoller(fp.logger, fp.cfg.PollerConfig, fp.cc, fp.metrics)
+	poller := NewChainPoller(fp.logger.Named(log.ModuleChainPoller), fp.cfg().PollerConfig, fp.cc, fp.metrics)
+	poller.pollInterval = func() time.Duration { return fp.cfg().PollerConfig.PollInterval }
 
 	if err := poller.Start(startHeight); err != nil {
 		return fmt.Errorf("failed to start the poller with start height %d: %w", startHeight, err)
 	}
 
 	fp.poller = poller
+	fp.pendingBlocks = nil
 	fp.quit = make(chan struct{})
+	fp.lastHeartbeat.Store(time.Now())
 	fp.wg.Add(1)
-	go fp.finalitySigSubmissionLoop()
+	go fp.recoverToCriticalErr(fp.finalitySigSubmissionLoop)
 	fp.wg.Add(1)
-	go fp.randomnessCommitmentLoop()
+	go fp.recoverToCriticalErr(fp.randomnessCommitmentLoop)
+	if leaser != nil {
+		fp.wg.Add(1)
+		go fp.recoverToCriticalErr(func() { fp.leaderElectionLoop(leaser) })
+	}
 
 	return nil
 }
@@ -161,10 +305,115 @@ func (fp *FinalityProviderInstance) Stop() error {
 	return nil
 }
 
+// migrateLegacyPubRandProofs moves the public randomness proofs stored with
+// the legacy layout, which is keyed by public randomness only, to the layout
+// namespaced by chain id. Only the proofs of the heights that are committed
+// but not voted yet are migrated as the others will never be used
+func (fp *FinalityProviderInstance) migrateLegacyPubRandProofs() error {
+	hasLegacy, err := fp.pubRandState.hasLegacyPubRandProofs()
+	if err != nil {
+		return err
+	}
+	if !hasLegacy {
+		return nil
+	}
+
+	lastCommittedHeight, err := fp.GetLastCommittedHeight()
+	if err != nil {
+		return err
+	}
+
+	activationBlkHeight, err := fp.cc.QueryFinalityActivationBlockHeight()
+	if err != nil {
+		return err
+	}
+
+	startHeight := max(fp.GetLastVotedHeight()+1, activationBlkHeight)
+	if lastCommittedHeight < startHeight {
+		return nil
+	}
+
+	numPubRand := lastCommittedHeight - startHeight + 1
+	if numPubRand > math.MaxUint32 {
+		return fmt.Errorf("too many public randomness to migrate: %d", numPubRand)
+	}
+
+	// #nosec G115 -- performed the conversion check above
+	pubRandList, err := fp.getPubRandList(startHeight, uint32(numPubRand))
+	if err != nil {
+		return err
+	}
+
+	numMigrated, err := fp.pubRandState.migrateLegacyPubRandProofs(
+		fp.GetChainID(),
+		fp.btcPk.MustMarshal(),
+		startHeight,
+		pubRandList,
+	)
+	if err != nil {
+		return err
+	}
+
+	fp.logger.Info("migrated legacy public randomness proofs",
+		zap.String("pk", fp.GetBtcPkHex()),
+		zap.Uint64("start_height", startHeight),
+		zap.Uint64("last_committed_height", lastCommittedHeight),
+		zap.Int("num_migrated", numMigrated),
+	)
+
+	return nil
+}
+
 func (fp *FinalityProviderInstance) IsRunning() bool {
 	return fp.isStarted.Load()
 }
 
+// Pause stops the finality provider from voting and committing randomness
+// while the instance keeps running, e.g., during the maintenance of the
+// EOTS manager. The status sync and the metrics are not affected
+func (fp *FinalityProviderInstance) Pause() error {
+	if !fp.IsRunning() {
+		return fmt.Errorf("the finality-provider instance %s is not running", fp.GetBtcPkHex())
+	}
+	if fp.isPaused.Swap(true) {
+		return fmt.Errorf("the finality-provider instance %s is already paused", fp.GetBtcPkHex())
+	}
+
+	fp.metrics.RecordFpPaused(fp.GetBtcPkHex(), true)
+	fp.logger.Info("the finality-provider is paused", zap.String("pk", fp.GetBtcPkHex()))
+
+	return nil
+}
+
+// Resume makes a paused finality provider vote again from the next block
+// received, the blocks received while paused are not voted
+func (fp *FinalityProviderInstance) Resume() error {
+	if !fp.isPaused.Swap(false) {
+		return fmt.Errorf("the finality-provider instance %s is not paused", fp.GetBtcPkHex())
+	}
+
+	fp.metrics.RecordFpPaused(fp.GetBtcPkHex(), false)
+	fp.logger.Info("the finality-provider is resumed", zap.String("pk", fp.GetBtcPkHex()))
+
+	return nil
+}
+
+func (fp *FinalityProviderInstance) IsPaused() bool {
+	return fp.isPaused.Load()
+}
+
+// LastHeartbeat returns the time of the last iteration of the signature
+// submission loop
+func (fp *FinalityProviderInstance) LastHeartbeat() time.Time {
+	return fp.lastHeartbeat.Load()
+}
+
+// LastReceivedHeight returns the height of the last block received from
+// the poller or processed while catching up, 0 if none yet
+func (fp *FinalityProviderInstance) LastReceivedHeight() uint64 {
+	return fp.lastReceivedHeight.Load()
+}
+
 func (fp *FinalityProviderInstance) IsJailed() bool {
 	return fp.GetStatus() == proto.FinalityProviderStatus_JAILED
 }
@@ -172,41 +421,31 @@ func (fp *FinalityProviderInstance) IsJailed() bool {
 func (fp *FinalityProviderInstance) finalitySigSubmissionLoop() {
 	defer fp.wg.Done()
 
+	fp.catchUp()
+
+	// the retry case is never selected if the retries are disabled
+	var voteRetryTicker <-chan time.Time
+	if fp.voteRetryEnabled() {
+		ticker := time.NewTicker(fp.cfg().VoteRetryConfig.RetryInterval)
+		defer ticker.Stop()
+		voteRetryTicker = ticker.C
+	}
+
 	for {
 		select {
-		case <-time.After(fp.cfg.SignatureSubmissionInterval):
-			pollerBlocks := fp.getAllBlocksFromChan()
-			if len(pollerBlocks) == 0 {
+		case <-time.After(fp.cfg().SignatureSubmissionInterval):
+			fp.lastHeartbeat.Store(time.Now())
+			// the blocks are kept in the poller buffer during the
+			// maintenance and processed afterwards
+			fp.maintenance.do(fp.processNextBlocks)
+
+		case <-voteRetryTicker:
+			// the queued votes are retried along with the new votes so
+			// that the submissions are not concurrent
+			if fp.IsPaused() || !fp.IsLeader() {
 				continue
 			}
-			targetHeight := pollerBlocks[len(pollerBlocks)-1].Height
-			fp.logger.Debug("the finality-provider received new block(s), start processing",
-				zap.String("pk", fp.GetBtcPkHex()),
-				zap.Uint64("start_height", pollerBlocks[0].Height),
-				zap.Uint64("end_height", targetHeight),
-			)
-			res, err := fp.retrySubmitSigsUntilFinalized(pollerBlocks)
-			if err != nil {
-				fp.metrics.IncrementFpTotalFailedVotes(fp.GetBtcPkHex())
-				if !errors.Is(err, ErrFinalityProviderShutDown) {
-					fp.reportCriticalErr(err)
-				}
-				continue
-			}
-			if res == nil {
-				// this can happen when a finality signature is not needed
-				// either if the block is already submitted or the signature
-				// is already submitted
-				continue
-			}
-			fp.logger.Info(
-				"successfully submitted the finality signature to the consumer chain",
-				zap.String("consumer_id", string(fp.GetChainID())),
-				zap.String("pk", fp.GetBtcPkHex()),
-				zap.Uint64("start_height", pollerBlocks[0].Height),
-				zap.Uint64("end_height", targetHeight),
-				zap.String("tx_hash", res.TxHash),
-			)
+			fp.maintenance.do(fp.retryFailedVotes)
 
 		case <-fp.quit:
 			fp.logger.Info("the finality signature submission loop is closing")
@@ -215,29 +454,123 @@ func (fp *FinalityProviderInstance) finalitySigSubmissionLoop() {
 	}
 }
 
-func (fp *FinalityProviderInstance) getAllBlocksFromChan() []*types.BlockInfo {
+// processNextBlocks votes for the next blocks pulled from the poller, if
+// any, and updates the last processed height
+func (fp *FinalityProviderInstance) processNextBlocks() {
+	if fp.promoted.Swap(false) {
+		fp.rewindPollerOnPromotion()
+	}
+	pollerBlocks := fp.nextBlocksToVote(fp.pullBlocksFromPoller())
+	if len(pollerBlocks) == 0 {
+		// the received blocks, if any, do not need to be voted
+		// or are waiting to be voted
+		fp.MustUpdateLastProcessedHeight(fp.processedHeight())
+		return
+	}
+	if fp.IsPaused() {
+		fp.logger.Debug("the finality-provider is paused, skip the received block(s)",
+			zap.String("pk", fp.GetBtcPkHex()),
+			zap.Uint64("start_height", pollerBlocks[0].Height),
+			zap.Uint64("end_height", pollerBlocks[len(pollerBlocks)-1].Height),
+		)
+		fp.streaks.addMissedHeights(len(pollerBlocks))
+		fp.participation.heightsEligible(pollerBlocks)
+		fp.recordUnvotedHeights(unvotedReasonPaused, pollerBlocks)
+		fp.MustUpdateLastProcessedHeight(fp.processedHeight())
+		return
+	}
+	if !fp.IsLeader() {
+		// the leader votes for the blocks
+		fp.logger.Debug("the finality-provider is a standby, skip the received block(s)",
+			zap.String("pk", fp.GetBtcPkHex()),
+			zap.Uint64("start_height", pollerBlocks[0].Height),
+			zap.Uint64("end_height", pollerBlocks[len(pollerBlocks)-1].Height),
+		)
+		fp.MustUpdateLastProcessedHeight(fp.processedHeight())
+		return
+	}
+	targetHeight := pollerBlocks[len(pollerBlocks)-1].Height
+	fp.logger.Debug("the finality-provider received new block(s), start processing",
+		zap.String("pk", fp.GetBtcPkHex()),
+		zap.Uint64("start_height", pollerBlocks[0].Height),
+		zap.Uint64("end_height", targetHeight),
+	)
+	fp.participation.heightsEligible(pollerBlocks)
+	res, err := fp.retrySubmitSigsUntilFinalized(pollerBlocks)
+	if err != nil {
+		fp.metrics.IncrementFpTotalFailedVotes(fp.GetBtcPkHex())
+		if !errors.Is(err, ErrFinalityProviderShutDown) && !errors.Is(err, ErrFinalityProviderStandby) {
+			fp.alerts.checkMissedVotes(fp.streaks.addMissedHeights(len(pollerBlocks)))
+		}
+		if errors.Is(err, ErrMaxFailedCycles) && fp.voteRetryEnabled() {
+			// the votes are retried later instead of being dropped, so
+			// they are only counted as unvoted once abandoned
+			if err := fp.enqueueFailedVotes(pollerBlocks); err != nil {
+				fp.reportCriticalErr(err)
+				return
+			}
+			fp.MustUpdateLastProcessedHeight(fp.processedHeight())
+			return
+		}
+		if !errors.Is(err, ErrFinalityProviderShutDown) && !errors.Is(err, ErrFinalityProviderStandby) {
+			fp.recordUnvotedHeights(unvotedReasonOf(err), pollerBlocks)
+			fp.reportCriticalErr(err)
+		}
+		return
+	}
+	// the blocks are either voted or finalized in the meantime
+	fp.MustUpdateLastProcessedHeight(fp.processedHeight())
+	if res == nil {
+		// this can happen when a finality signature is not needed
+		// either if the block is already submitted or the signature
+		// is already submitted
+		return
+	}
+	fp.logger.Info(
+		"successfully submitted the finality signature to the consumer chain",
+		zap.String("consumer_id", string(fp.GetChainID())),
+		zap.String("pk", fp.GetBtcPkHex()),
+		zap.Uint64("start_height", pollerBlocks[0].Height),
+		zap.Uint64("end_height", targetHeight),
+		zap.String("tx_hash", res.TxHash),
+	)
+}
+
+// pullBlocksFromPoller pulls the prefetched blocks from the poller until
+// BatchSubmissionSize blocks to process are collected or no block is left
+func (fp *FinalityProviderInstance) pullBlocksFromPoller() []*types.BlockInfo {
+	// the batch size is read once as a config reload might lower it while
+	// the blocks are being pulled
+	batchSize := int(fp.cfg().BatchSubmissionSize)
 	var pollerBlocks []*types.BlockInfo
 	for {
 		select {
-		case b := <-fp.poller.GetBlockInfoChan():
+		case <-fp.quit:
+			fp.logger.Info("the pull blocks loop is closing")
+			return nil
+		default:
+		}
+
+		blocks := fp.poller.NextBlocks(uint32(batchSize - len(pollerBlocks)))
+		if len(blocks) == 0 {
+			return pollerBlocks
+		}
+		for _, b := range blocks {
+			fp.lastReceivedHeight.Store(b.Height)
 			// TODO: in cases of catching up, this could issue frequent RPC calls
 			shouldProcess, err := fp.shouldProcessBlock(b)
 			if err != nil {
 				if !errors.Is(err, ErrFinalityProviderShutDown) {
 					fp.reportCriticalErr(err)
 				}
-				break
+				continue
 			}
 			if shouldProcess {
+				fp.votePipeline.blockReceived(b.Height)
 				pollerBlocks = append(pollerBlocks, b)
 			}
-			if len(pollerBlocks) == int(fp.cfg.BatchSubmissionSize) {
-				return pollerBlocks
-			}
-		case <-fp.quit:
-			fp.logger.Info("the get all blocks loop is closing")
-			return nil
-		default:
+		}
+		if len(pollerBlocks) >= batchSize {
 			return pollerBlocks
 		}
 	}
@@ -263,6 +596,7 @@ func (fp *FinalityProviderInstance) shouldProcessBlock(b *types.BlockInfo) (bool
 		// the finality provider does not have voting power
 		// and it will never will at this block
 		fp.metrics.IncrementFpTotalBlocksWithoutVotingPower(fp.GetBtcPkHex())
+		fp.recordUnvotedHeights(unvotedReasonNoVotingPower, []*types.BlockInfo{b})
 		return false, nil
 	}
 
@@ -272,36 +606,69 @@ func (fp *FinalityProviderInstance) shouldProcessBlock(b *types.BlockInfo) (bool
 func (fp *FinalityProviderInstance) randomnessCommitmentLoop() {
 	defer fp.wg.Done()
 
-	commitRandTicker := time.NewTicker(fp.cfg.RandomnessCommitInterval)
+	commitRandTicker := time.NewTicker(fp.cfg().RandomnessCommitInterval)
 	defer commitRandTicker.Stop()
 
 	for {
 		select {
 		case <-commitRandTicker.C:
-			tipBlock, err := fp.getLatestBlockWithRetry()
-			if err != nil {
-				fp.reportCriticalErr(err)
-				continue
-			}
-			txRes, err := fp.retryCommitPubRandUntilBlockFinalized(tipBlock)
-			if err != nil {
-				fp.metrics.IncrementFpTotalFailedRandomness(fp.GetBtcPkHex())
-				fp.reportCriticalErr(err)
-				continue
-			}
-			// txRes could be nil if no need to commit more randomness
-			if txRes != nil {
-				fp.logger.Info(
-					"successfully committed public randomness to the consumer chain",
-					zap.String("pk", fp.GetBtcPkHex()),
-					zap.String("tx_hash", txRes.TxHash),
-				)
-			}
-
+			// the interval might have been changed by a config reload
+			commitRandTicker.Reset(fp.cfg().RandomnessCommitInterval)
+		case <-fp.pubRandCommitTrigger:
 		case <-fp.quit:
 			fp.logger.Info("the randomness commitment loop is closing")
 			return
 		}
+
+		if !fp.IsLeader() || fp.IsPaused() {
+			// the leader commits the randomness unless it is paused
+			continue
+		}
+		// the randomness is committed on the next tick after the
+		// maintenance
+		fp.maintenance.do(fp.commitPubRandOnSchedule)
+	}
+}
+
+// commitPubRandOnSchedule commits public randomness if the remaining
+// committed heights fall below the runway
+func (fp *FinalityProviderInstance) commitPubRandOnSchedule() {
+	if !fp.shouldCommitPubRand() {
+		return
+	}
+	tipBlock, err := fp.getLatestBlockWithRetry()
+	if err != nil {
+		fp.reportCriticalErr(err)
+		return
+	}
+	if fp.shouldDeferPubRandCommit(tipBlock.Height) {
+		return
+	}
+
+	fp.pubRandCommitMu.Lock()
+	defer fp.pubRandCommitMu.Unlock()
+	txRes, err := fp.retryCommitPubRandUntilBlockFinalized(tipBlock)
+	if err != nil {
+		fp.metrics.IncrementFpTotalFailedRandomness(fp.GetBtcPkHex())
+		fp.reportCriticalErr(err)
+		return
+	}
+	// txRes could be nil if no need to commit more randomness
+	if txRes != nil {
+		fp.logger.Info(
+			"successfully committed public randomness to the consumer chain",
+			zap.String("pk", fp.GetBtcPkHex()),
+			zap.String("tx_hash", txRes.TxHash),
+		)
+	}
+}
+
+// triggerPubRandCommit makes the randomness commitment loop commit public
+// randomness, if needed, without waiting for the next tick
+func (fp *FinalityProviderInstance) triggerPubRandCommit() {
+	select {
+	case fp.pubRandCommitTrigger <- struct{}{}:
+	default:
 	}
 }
 
@@ -323,13 +690,43 @@ func (fp *FinalityProviderInstance) hasVotingPower(b *types.BlockInfo) (bool, er
 	return true, nil
 }
 
+// reportCriticalErr sends the error to the manager, it is dropped if the
+// instance is stopped in the meantime
 func (fp *FinalityProviderInstance) reportCriticalErr(err error) {
-	fp.criticalErrChan <- &CriticalError{
+	fp.sendCriticalErr(&CriticalError{
 		err:     err,
 		fpBtcPk: fp.GetBtcPkBIP340(),
+	})
+}
+
+func (fp *FinalityProviderInstance) sendCriticalErr(criticalErr *CriticalError) {
+	select {
+	case fp.criticalErrChan <- criticalErr:
+	case <-fp.quit:
 	}
 }
 
+// recoverToCriticalErr runs the given loop and reports a panic of the loop
+// as a critical error so that the manager can restart the instance
+func (fp *FinalityProviderInstance) recoverToCriticalErr(loop func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			fp.logger.Error("the finality-provider instance panicked",
+				zap.String("pk", fp.GetBtcPkHex()),
+				zap.Any("panic", r),
+				zap.Stack("stack"),
+			)
+			fp.sendCriticalErr(&CriticalError{
+				err:     fmt.Errorf("%w: %v", ErrFinalityProviderPanicked, r),
+				fpBtcPk: fp.GetBtcPkBIP340(),
+				stack:   debug.Stack(),
+			})
+		}
+	}()
+
+	loop()
+}
+
 // retrySubmitSigsUntilFinalized periodically tries to submit finality signature until success or the block is finalized
 // error will be returned if maximum retries have been reached or the query to the consumer chain fails
 func (fp *FinalityProviderInstance) retrySubmitSigsUntilFinalized(targetBlocks []*types.BlockInfo) (*types.TxResponse, error) {
@@ -337,6 +734,9 @@ func (fp *FinalityProviderInstance) retrySubmitSigsUntilFinalized(targetBlocks [
 		return nil, fmt.Errorf("cannot send signatures for empty blocks")
 	}
 
+	fp.isSubmittingSigs.Store(true)
+	defer fp.isSubmittingSigs.Store(false)
+
 	var failedCycles uint32
 	targetHeight := targetBlocks[len(targetBlocks)-1].Height
 
@@ -344,7 +744,7 @@ func (fp *FinalityProviderInstance) retrySubmitSigsUntilFinalized(targetBlocks [
 	// error will be returned if maximum retries have been reached or the query to the consumer chain fails
 	for {
 		select {
-		case <-time.After(fp.cfg.SubmissionRetryInterval):
+		case <-time.After(fp.cfg().SubmissionRetryInterval):
 			// error will be returned if max retries have been reached
 			var res *types.TxResponse
 			var err error
@@ -359,7 +759,7 @@ func (fp *FinalityProviderInstance) retrySubmitSigsUntilFinalized(targetBlocks [
 					zap.Error(err),
 				)
 
-				if clientcontroller.IsUnrecoverable(err) {
+				if clientcontroller.IsUnrecoverable(err) || errors.Is(err, store.ErrDoubleSign) {
 					return nil, err
 				}
 
@@ -367,9 +767,16 @@ func (fp *FinalityProviderInstance) retrySubmitSigsUntilFinalized(targetBlocks [
 					return nil, nil
 				}
 
+				// the lease was lost, the EOTS manager refuses to sign
+				// so that the new leader is the only one voting
+				if isNotLeaderErr(err) {
+					fp.isLeader.Store(false)
+					return nil, ErrFinalityProviderStandby
+				}
+
 				failedCycles++
-				if failedCycles > fp.cfg.MaxSubmissionRetries {
-					return nil, fmt.Errorf("reached max failed cycles with err: %w", err)
+				if failedCycles > fp.cfg().MaxSubmissionRetries {
+					return nil, fmt.Errorf("%w with err: %w", ErrMaxFailedCycles, err)
 				}
 			} else {
 				// the signature has been successfully submitted
@@ -387,6 +794,7 @@ func (fp *FinalityProviderInstance) retrySubmitSigsUntilFinalized(targetBlocks [
 					zap.String("pk", fp.GetBtcPkHex()),
 					zap.Uint64("target_height", targetHeight),
 				)
+				fp.recordUnvotedHeights(unvotedReasonFinalized, targetBlocks)
 				// TODO: returning nil here is to safely break the loop
 				//  the error still exists
 				return nil, nil
@@ -408,6 +816,54 @@ func (fp *FinalityProviderInstance) checkBlockFinalization(height uint64) (bool,
 	return b.Finalized, nil
 }
 
+// shouldCommitPubRand returns whether the finality provider commits public
+// randomness given its status. The registered or inactive finality provider
+// keeps committing it unless disabled by the config, so that the committed
+// randomness is already timestamped once it gains voting power
+func (fp *FinalityProviderInstance) shouldCommitPubRand() bool {
+	if fp.cfg().InactivePubRandCommit {
+		return true
+	}
+
+	status := fp.GetStatus()
+	if status == proto.FinalityProviderStatus_REGISTERED || status == proto.FinalityProviderStatus_INACTIVE {
+		fp.logger.Debug("the finality-provider is not active, skip committing public randomness",
+			zap.String("pk", fp.GetBtcPkHex()),
+			zap.String("status", status.String()),
+		)
+		return false
+	}
+
+	return true
+}
+
+// shouldDeferPubRandCommit returns true if the public randomness commitment
+// should be deferred to the next tick as finality signatures are being
+// submitted, unless less than half of the runway remains
+func (fp *FinalityProviderInstance) shouldDeferPubRandCommit(tipHeight uint64) bool {
+	if !fp.isSubmittingSigs.Load() {
+		return false
+	}
+
+	lastCommittedHeight, err := fp.GetLastCommittedHeight()
+	if err != nil {
+		return false
+	}
+
+	// (should not use subtraction because they are in the type of uint64)
+	if lastCommittedHeight < tipHeight+fp.cfg().PubRandRunwayBlocks()/2 {
+		return false
+	}
+
+	fp.logger.Debug("the finality-provider is submitting finality signatures, defer committing public randomness",
+		zap.String("pk", fp.GetBtcPkHex()),
+		zap.Uint64("tip_height", tipHeight),
+		zap.Uint64("last_committed_height", lastCommittedHeight),
+	)
+
+	return true
+}
+
 // retryCommitPubRandUntilBlockFinalized periodically tries to commit public rand until success or the block is finalized
 // error will be returned if maximum retries have been reached or the query to the consumer chain fails
 func (fp *FinalityProviderInstance) retryCommitPubRandUntilBlockFinalized(targetBlock *types.BlockInfo) (*types.TxResponse, error) {
@@ -437,7 +893,7 @@ func (fp *FinalityProviderInstance) retryCommitPubRandUntilBlockFinalized(target
 			)
 
 			failedCycles++
-			if failedCycles > fp.cfg.MaxSubmissionRetries {
+			if failedCycles > fp.cfg().MaxSubmissionRetries {
 				return nil, fmt.Errorf("reached max failed cycles with err: %w", err)
 			}
 		} else {
@@ -445,7 +901,7 @@ func (fp *FinalityProviderInstance) retryCommitPubRandUntilBlockFinalized(target
 			return res, nil
 		}
 		select {
-		case <-time.After(fp.cfg.SubmissionRetryInterval):
+		case <-time.After(fp.cfg().SubmissionRetryInterval):
 			// periodically query the index block to be later checked whether it is Finalized
 			finalized, err := fp.checkBlockFinalization(targetBlock.Height)
 			if err != nil {
@@ -480,15 +936,24 @@ func (fp *FinalityProviderInstance) CommitPubRand(tipHeight uint64) (*types.TxRe
 	if err != nil {
 		return nil, err
 	}
+	var runway uint64
+	if lastCommittedHeight > tipHeight {
+		runway = lastCommittedHeight - tipHeight
+	}
+	fp.metrics.RecordFpPubRandRunway(fp.GetBtcPkHex(), runway)
+	// the runway of a finality provider which has never committed is not low
+	if lastCommittedHeight > 0 {
+		fp.alerts.checkPubRandRunway(runway, tipHeight)
+	}
 
 	var startHeight uint64
 	switch {
 	case lastCommittedHeight == uint64(0):
 		// the finality-provider has never submitted public rand before
 		startHeight = tipHeight + 1
-	case lastCommittedHeight < uint64(fp.cfg.MinRandHeightGap)+tipHeight:
+	case lastCommittedHeight < fp.cfg().PubRandRunwayBlocks()+tipHeight:
 		// (should not use subtraction because they are in the type of uint64)
-		// we are running out of the randomness
+		// the remaining committed heights fall below the runway
 		startHeight = lastCommittedHeight + 1
 	default:
 		fp.logger.Debug(
@@ -505,6 +970,10 @@ func (fp *FinalityProviderInstance) CommitPubRand(tipHeight uint64) (*types.TxRe
 
 // it will commit fp.cfg.NumPubRand pairs of public randomness starting from startHeight
 func (fp *FinalityProviderInstance) commitPubRandPairs(startHeight uint64) (*types.TxResponse, error) {
+	if err := fp.submissionLimiter.allow(submissionPubRandCommit); err != nil {
+		return nil, err
+	}
+
 	activationBlkHeight, err := fp.cc.QueryFinalityActivationBlockHeight()
 	if err != nil {
 		return nil, err
@@ -517,7 +986,7 @@ func (fp *FinalityProviderInstance) commitPubRandPairs(startHeight uint64) (*typ
 	// NOTE: currently, calling this will create and save a list of randomness
 	// in case of failure, randomness that has been created will be overwritten
 	// for safety reason as the same randomness must not be used twice
-	pubRandList, err := fp.getPubRandList(startHeight, fp.cfg.NumPubRand)
+	pubRandList, err := fp.getPubRandList(startHeight, fp.cfg().NumPubRand)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate randomness: %w", err)
 	}
@@ -527,7 +996,7 @@ func (fp *FinalityProviderInstance) commitPubRandPairs(startHeight uint64) (*typ
 	commitment, proofList := types.GetPubRandCommitAndProofs(pubRandList)
 
 	// store them to database
-	if err := fp.pubRandState.addPubRandProofList(pubRandList, proofList); err != nil {
+	if err := fp.pubRandState.addPubRandProofList(fp.GetChainID(), fp.btcPk.MustMarshal(), startHeight, numPubRand, proofList); err != nil {
 		return nil, fmt.Errorf("failed to save public randomness to DB: %w", err)
 	}
 
@@ -537,15 +1006,41 @@ func (fp *FinalityProviderInstance) commitPubRandPairs(startHeight uint64) (*typ
 		return nil, fmt.Errorf("failed to sign the Schnorr signature: %w", err)
 	}
 
+	sub := &Submission{
+		Kind:        SubmissionKindPubRandCommit,
+		FpBtcPk:     fp.btcPk,
+		StartHeight: startHeight,
+		NumPubRand:  numPubRand,
+	}
+	if err := fp.hooks.beforeBroadcast(fp.ctx, sub); err != nil {
+		return nil, err
+	}
+	if err := fp.recordPendingPubRandCommit(startHeight, numPubRand, commitment); err != nil {
+		return nil, err
+	}
 	res, err := fp.cc.CommitPubRandList(fp.GetBtcPk(), startHeight, numPubRand, commitment, schnorrSig)
+	fp.hooks.afterBroadcast(fp.ctx, sub, res, err)
+	fp.recordPubRandCommitResult(startHeight, numPubRand, res, err)
+	fp.streaks.recordBroadcast(submissionPubRandCommit, err)
 	if err != nil {
+		fp.metrics.IncrementFpTotalFailedPubRandCommitTxs(fp.GetBtcPkHex())
 		return nil, fmt.Errorf("failed to commit public randomness to the consumer chain: %w", err)
 	}
+	fp.completeSubmissions(store.SubmissionPubRandCommit, []uint64{startHeight})
 
 	// Update metrics
 	fp.metrics.RecordFpRandomnessTime(fp.GetBtcPkHex())
 	fp.metrics.RecordFpLastCommittedRandomnessHeight(fp.GetBtcPkHex(), startHeight+numPubRand-1)
 	fp.metrics.AddToFpTotalCommittedRandomness(fp.GetBtcPkHex(), float64(len(pubRandList)))
+	fp.metrics.RecordFpPubRandCommitment(fp.GetBtcPkHex(), numPubRand)
+
+	fp.events.Publish(&eventbus.Event{
+		Type:        eventbus.EventPubRandCommitted,
+		FpBtcPk:     fp.GetBtcPkHex(),
+		TxHash:      txHashOf(res),
+		StartHeight: startHeight,
+		EndHeight:   startHeight + numPubRand - 1,
+	})
 
 	return res, nil
 }
@@ -615,7 +1110,7 @@ func (fp *FinalityProviderInstance) TestCommitPubRandWithStartHeight(startHeight
 		if err != nil {
 			return err
 		}
-		lastCommittedHeight = startHeight + uint64(fp.cfg.NumPubRand) - 1
+		lastCommittedHeight = startHeight + uint64(fp.cfg().NumPubRand) - 1
 		startHeight = lastCommittedHeight + 1
 		fp.logger.Info("Committed pubrand to block height", zap.Uint64("height", lastCommittedHeight))
 	}
@@ -636,48 +1131,108 @@ func (fp *FinalityProviderInstance) SubmitBatchFinalitySignatures(blocks []*type
 		return nil, fmt.Errorf("should not submit batch finality signature with zero block")
 	}
 
-	if len(blocks) > math.MaxUint32 {
+	for i := 1; i < len(blocks); i++ {
+		if blocks[i].Height <= blocks[i-1].Height {
+			return nil, fmt.Errorf("the blocks should be in strictly ascending order of height")
+		}
+	}
+
+	startHeight := blocks[0].Height
+	numHeights := blocks[len(blocks)-1].Height - startHeight + 1
+	if numHeights > math.MaxUint32 {
 		return nil, fmt.Errorf("should not submit batch finality signature with too many blocks")
 	}
 
-	// get public randomness list
+	if err := fp.submissionLimiter.allow(submissionVote); err != nil {
+		return nil, err
+	}
+
+	attempt := &voteAttemptTiming{start: time.Now()}
+
+	// get public randomness list of the whole height range
+	// as the blocks might not be contiguous
 	// #nosec G115 -- performed the conversion check above
-	prList, err := fp.getPubRandList(blocks[0].Height, uint32(len(blocks)))
+	rangePrList, err := fp.getPubRandList(startHeight, uint32(numHeights))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get public randomness list: %w", err)
+		return nil, withUnvotedReason(unvotedReasonRandomnessMissing, fmt.Errorf("failed to get public randomness list: %w", err))
+	}
+	heights := make([]uint64, 0, len(blocks))
+	prList := make([]*btcec.FieldVal, 0, len(blocks))
+	for _, b := range blocks {
+		heights = append(heights, b.Height)
+		prList = append(prList, rangePrList[b.Height-startHeight])
 	}
-	// get proof list
-	// TODO: how to recover upon having an error in getPubRandProofList?
-	proofBytesList, err := fp.pubRandState.getPubRandProofList(prList)
+
+	// get proof list within one db transaction, the missing proofs are
+	// regenerated from the EOTS manager
+	proofBytesList, err := fp.getPubRandProofsByHeights(heights)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get public randomness inclusion proof list: %w", err)
+		return nil, withUnvotedReason(unvotedReasonRandomnessMissing, fmt.Errorf("failed to get public randomness inclusion proof list: %w", err))
 	}
+	attempt.randomness = time.Since(attempt.start)
 
 	// sign blocks
-	sigList := make([]*btcec.ModNScalar, 0, len(blocks))
+	if err := fp.hooks.beforeSign(fp.ctx, fp.btcPk, blocks); err != nil {
+		return nil, err
+	}
 	for _, b := range blocks {
-		eotsSig, err := fp.signFinalitySig(b)
-		if err != nil {
-			return nil, err
+		if err := fp.recordFinalityVote(b); err != nil {
+			return nil, withUnvotedReason(unvotedReasonSignerError, err)
 		}
-		sigList = append(sigList, eotsSig.ToModNScalar())
 	}
+	sigList, err := fp.signFinalitySigs(blocks)
+	if err != nil {
+		return nil, withUnvotedReason(unvotedReasonSignerError, err)
+	}
+	if err := fp.hooks.afterSign(fp.ctx, fp.btcPk, blocks, sigList); err != nil {
+		return nil, err
+	}
+	attempt.signing = time.Since(attempt.start) - attempt.randomness
 
 	// send finality signature to the consumer chain
+	sub := &Submission{
+		Kind:    SubmissionKindVote,
+		FpBtcPk: fp.btcPk,
+		Blocks:  blocks,
+	}
+	if err := fp.hooks.beforeBroadcast(fp.ctx, sub); err != nil {
+		return nil, err
+	}
+	if err := fp.recordPendingVotes(blocks); err != nil {
+		return nil, err
+	}
+	broadcastStart := time.Now()
 	res, err := fp.cc.SubmitBatchFinalitySigs(fp.GetBtcPk(), blocks, prList, proofBytesList, sigList)
+	attempt.broadcast = time.Since(broadcastStart)
+	fp.hooks.afterBroadcast(fp.ctx, sub, res, err)
+	fp.recordVoteResults(blocks, res, err)
+	fp.streaks.recordBroadcast(submissionVote, err)
 	if err != nil {
 		if strings.Contains(err.Error(), "jailed") {
-			return nil, ErrFinalityProviderJailed
+			return nil, withUnvotedReason(unvotedReasonBroadcastFailure, ErrFinalityProviderJailed)
 		}
 		if strings.Contains(err.Error(), "slashed") {
-			return nil, ErrFinalityProviderSlashed
+			return nil, withUnvotedReason(unvotedReasonBroadcastFailure, ErrFinalityProviderSlashed)
 		}
-		return nil, err
+		return nil, withUnvotedReason(unvotedReasonBroadcastFailure, err)
 	}
 
 	// update DB
 	highBlock := blocks[len(blocks)-1]
 	fp.MustUpdateStateAfterFinalitySigSubmission(highBlock.Height)
+	fp.completeSubmissions(store.SubmissionVote, heights)
+	fp.votePipeline.votesConfirmed(blocks, attempt)
+	fp.participation.heightsVoted(blocks)
+	fp.metrics.RecordFpVoteTime(fp.GetBtcPkHex())
+	fp.metrics.AddToFpTotalVotedBlocks(fp.GetBtcPkHex(), float64(len(blocks)))
+
+	fp.events.Publish(&eventbus.Event{
+		Type:        eventbus.EventVoteSubmitted,
+		FpBtcPk:     fp.GetBtcPkHex(),
+		TxHash:      txHashOf(res),
+		StartHeight: startHeight,
+		EndHeight:   highBlock.Height,
+	})
 
 	return res, nil
 }
@@ -694,7 +1249,7 @@ func (fp *FinalityProviderInstance) TestSubmitFinalitySignatureAndExtractPrivKey
 	pubRand := prList[0]
 
 	// get proof
-	proofBytes, err := fp.pubRandState.getPubRandProof(pubRand)
+	proofBytes, err := fp.pubRandState.getPubRandProof(fp.GetChainID(), fp.btcPk.MustMarshal(), b.Height)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get public randomness inclusion proof: %w", err)
 	}
@@ -736,20 +1291,23 @@ func (fp *FinalityProviderInstance) TestSubmitFinalitySignatureAndExtractPrivKey
 	return res, privKey, nil
 }
 
-// getPollerStartingHeight gets the starting height of the poller with
+// getPollerStartingHeight gets the starting height of the poller. If the fp
+// has processed blocks before, it resumes right after the last processed
+// height, which is persisted once the received blocks are handled, so that
+// no block is processed twice or skipped after a crash or restart.
+// Otherwise, the starting height is
 // max(lastVotedHeight+1, lastFinalizedHeight+1, params.FinalityActivationHeight)
 // this ensures that:
 // (1) the fp will not vote for a height lower than params.FinalityActivationHeight
 // (2) the fp will not miss for any non-finalized blocks
 // (3) the fp will not process any blocks that have been already voted
-// Note: if the fp starting from the last finalized height with a gap to the last
-// processed height, the fp might miss some rewards due to not sending the votes
-// depending on the consumer chain's reward distribution mechanism
-// TODO: provide an option to start from the last processed height in case
-// the consumer chain distributes rewards for late voters
 func (fp *FinalityProviderInstance) getPollerStartingHeight() (uint64, error) {
-	if !fp.cfg.PollerConfig.AutoChainScanningMode {
-		return fp.cfg.PollerConfig.StaticChainScanningStartHeight, nil
+	if lastProcessedHeight := fp.GetLastProcessedHeight(); lastProcessedHeight > 0 {
+		return max(lastProcessedHeight, fp.GetLastVotedHeight()) + 1, nil
+	}
+
+	if !fp.cfg().PollerConfig.AutoChainScanningMode {
+		return fp.cfg().PollerConfig.StaticChainScanningStartHeight, nil
 	}
 
 	// TODO: query last voted height and update local height
@@ -808,7 +1366,7 @@ func (fp *FinalityProviderInstance) lastCommittedPublicRandWithRetry(count uint6
 		}
 		response = resp
 		return nil
-	}, RtyAtt, RtyDel, RtyErr, retry.OnRetry(func(n uint, err error) {
+	}, retry.Context(fp.ctx), RtyAtt, RtyDel, RtyErr, retry.OnRetry(func(n uint, err error) {
 		fp.logger.Debug(
 			"failed to query babylon for the last committed public randomness",
 			zap.Uint("attempt", n+1),
@@ -830,7 +1388,7 @@ func (fp *FinalityProviderInstance) latestFinalizedBlocksWithRetry(count uint64)
 		}
 		response = latestFinalisedBlock
 		return nil
-	}, RtyAtt, RtyDel, RtyErr, retry.OnRetry(func(n uint, err error) {
+	}, retry.Context(fp.ctx), RtyAtt, RtyDel, RtyErr, retry.OnRetry(func(n uint, err error) {
 		fp.logger.Debug(
 			"failed to query babylon for the latest finalised blocks",
 			zap.Uint("attempt", n+1),
@@ -852,7 +1410,7 @@ func (fp *FinalityProviderInstance) getFinalityActivationHeightWithRetry() (uint
 		}
 		response = finalityActivationHeight
 		return nil
-	}, RtyAtt, RtyDel, RtyErr, retry.OnRetry(func(n uint, err error) {
+	}, retry.Context(fp.ctx), RtyAtt, RtyDel, RtyErr, retry.OnRetry(func(n uint, err error) {
 		fp.logger.Debug(
 			"failed to query babylon for the finality activation height",
 			zap.Uint("attempt", n+1),
@@ -877,7 +1435,7 @@ func (fp *FinalityProviderInstance) getLatestBlockWithRetry() (*types.BlockInfo,
 			return err
 		}
 		return nil
-	}, RtyAtt, RtyDel, RtyErr, retry.OnRetry(func(n uint, err error) {
+	}, retry.Context(fp.ctx), RtyAtt, RtyDel, RtyErr, retry.OnRetry(func(n uint, err error) {
 		fp.logger.Debug(
 			"failed to query the consumer chain for the latest block",
 			zap.Uint("attempt", n+1),
@@ -904,7 +1462,7 @@ func (fp *FinalityProviderInstance) GetVotingPowerWithRetry(height uint64) (uint
 			return err
 		}
 		return nil
-	}, RtyAtt, RtyDel, RtyErr, retry.OnRetry(func(n uint, err error) {
+	}, retry.Context(fp.ctx), RtyAtt, RtyDel, RtyErr, retry.OnRetry(func(n uint, err error) {
 		fp.logger.Debug(
 			"failed to query the voting power",
 			zap.Uint("attempt", n+1),
@@ -931,7 +1489,7 @@ func (fp *FinalityProviderInstance) GetFinalityProviderSlashedOrJailedWithRetry(
 			return err
 		}
 		return nil
-	}, RtyAtt, RtyDel, RtyErr, retry.OnRetry(func(n uint, err error) {
+	}, retry.Context(fp.ctx), RtyAtt, RtyDel, RtyErr, retry.OnRetry(func(n uint, err error) {
 		fp.logger.Debug(
 			"failed to query the finality-provider",
 			zap.Uint("attempt", n+1),
@@ -944,3 +1502,14 @@ func (fp *FinalityProviderInstance) GetFinalityProviderSlashedOrJailedWithRetry(
 
 	return slashed, jailed, nil
 }
+
+// txHashOf returns the hash of the transaction of the given response, which
+// is nil if the chain returns no response, e.g., with a mocked or a dry-run
+// controller
+func txHashOf(res *types.TxResponse) string {
+	if res == nil {
+		return ""
+	}
+
+	return res.TxHash
+}
@@ -1,12 +1,20 @@
 package service_test
 
 import (
+	"context"
+	"encoding/hex"
+	"fmt"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
 	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -18,8 +26,10 @@ import (
 	"github.com/babylonlabs-io/finality-provider/eotsmanager"
 	eotscfg "github.com/babylonlabs-io/finality-provider/eotsmanager/config"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/eventbus"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
 	"github.com/babylonlabs-io/finality-provider/metrics"
 	"github.com/babylonlabs-io/finality-provider/testutil"
 	"github.com/babylonlabs-io/finality-provider/types"
@@ -96,10 +106,778 @@ func FuzzSubmitFinalitySigs(f *testing.F) {
 
 		// check the last_voted_height
 		require.Equal(t, nextBlock.Height, fpIns.GetLastVotedHeight())
+
+		// the timing of the vote is recorded
+		voteTimings := fpIns.RecentVoteTimings()
+		require.Len(t, voteTimings, 1)
+		require.Equal(t, nextBlock.Height, voteTimings[0].Height)
+
+		// the same block can be voted again but not a conflicting block
+		_, err = fpIns.SubmitBatchFinalitySignatures([]*types.BlockInfo{nextBlock})
+		require.NoError(t, err)
+		conflictingBlock := &types.BlockInfo{
+			Height: nextBlock.Height,
+			Hash:   testutil.GenRandomByteArray(r, 32),
+		}
+		_, err = fpIns.SubmitBatchFinalitySignatures([]*types.BlockInfo{conflictingBlock})
+		require.ErrorIs(t, err, store.ErrDoubleSign)
 	})
 }
 
-func startFinalityProviderAppWithRegisteredFp(t *testing.T, r *rand.Rand, cc clientcontroller.ClientController, startingHeight uint64) (*service.FinalityProviderApp, *service.FinalityProviderInstance, func()) {
+func TestSubmissionRateLimit(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+
+	randomStartingHeight := uint64(r.Int63n(100) + 1)
+	currentHeight := randomStartingHeight + uint64(r.Int63n(10)+1)
+	mockClientController := testutil.PrepareMockedClientController(t, r, randomStartingHeight, currentHeight, 0)
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
+	_, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, randomStartingHeight, func(cfg *config.Config) {
+		cfg.SubmissionLimitConfig.MaxSubmissionsPerMinute = 1
+	})
+	defer cleanUp()
+
+	// the first submission within the minute is sent
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), uint64(1)).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&types.TxResponse{TxHash: testutil.GenRandomHexStr(r, 32)}, nil).Times(1)
+	_, err := fpIns.CommitPubRand(randomStartingHeight)
+	require.NoError(t, err)
+
+	// the next ones are rejected without reaching the chain
+	_, err = fpIns.CommitPubRand(randomStartingHeight)
+	require.ErrorIs(t, err, service.ErrSubmissionRateLimited)
+	nextBlock := &types.BlockInfo{
+		Height: randomStartingHeight + 1,
+		Hash:   testutil.GenRandomByteArray(r, 32),
+	}
+	_, err = fpIns.SubmitBatchFinalitySignatures([]*types.BlockInfo{nextBlock})
+	require.ErrorIs(t, err, service.ErrSubmissionRateLimited)
+}
+
+func TestReconcileOutbox(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+
+	randomStartingHeight := uint64(r.Int63n(100) + 10)
+	mockClientController := testutil.PrepareMockedClientController(t, r, randomStartingHeight, randomStartingHeight, 0)
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryBlock(gomock.Any()).Return(&types.BlockInfo{Height: randomStartingHeight}, nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any()).Return(false, false, nil).AnyTimes()
+	mockClientController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&types.TxResponse{TxHash: ""}, nil).AnyTimes()
+	app, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, randomStartingHeight)
+	defer cleanUp()
+
+	// the submissions left by a crash, only the first vote and the first
+	// public randomness commit are included
+	chainID, pk := fpIns.GetChainID(), fpIns.GetBtcPkBIP340().MustMarshal()
+	votedHeight, unvotedHeight := randomStartingHeight-2, randomStartingHeight-1
+	committed := &store.PendingSubmission{
+		Kind:       store.SubmissionPubRandCommit,
+		Height:     randomStartingHeight,
+		NumPubRand: testutil.TestPubRandNum,
+		Commitment: testutil.GenRandomByteArray(r, 32),
+	}
+	err := app.GetOutboxStore().AddPendingSubmissions(chainID, pk, []*store.PendingSubmission{
+		{Kind: store.SubmissionVote, Height: votedHeight, BlockHash: testutil.GenRandomByteArray(r, 32)},
+		{Kind: store.SubmissionVote, Height: unvotedHeight, BlockHash: testutil.GenRandomByteArray(r, 32)},
+		committed,
+		{
+			Kind:       store.SubmissionPubRandCommit,
+			Height:     randomStartingHeight + testutil.TestPubRandNum,
+			NumPubRand: testutil.TestPubRandNum,
+			Commitment: testutil.GenRandomByteArray(r, 32),
+		},
+	})
+	require.NoError(t, err)
+
+	mockClientController.EXPECT().QueryVotesAtHeight(gomock.Any()).DoAndReturn(func(height uint64) ([]bbntypes.BIP340PubKey, error) {
+		if height == votedHeight {
+			return []bbntypes.BIP340PubKey{*fpIns.GetBtcPkBIP340()}, nil
+		}
+		return nil, nil
+	}).AnyTimes()
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any()).Return(map[uint64]*ftypes.PubRandCommitResponse{
+		committed.Height: {NumPubRand: committed.NumPubRand, Commitment: committed.Commitment},
+	}, nil).AnyTimes()
+
+	err = app.StartHandlingFinalityProvider(fpIns.GetBtcPkBIP340(), passphrase)
+	require.NoError(t, err)
+
+	// the included vote is recorded and the other one is queued to be retried
+	managedIns, err := app.GetFinalityProviderInstance(fpIns.GetBtcPkBIP340())
+	require.NoError(t, err)
+	require.Equal(t, votedHeight, managedIns.GetLastVotedHeight())
+	failedVotes, err := app.GetVoteRetryStore().ListFailedVotes(chainID, pk)
+	require.NoError(t, err)
+	require.Len(t, failedVotes, 1)
+	require.Equal(t, unvotedHeight, failedVotes[0].Height)
+
+	// the reconciled submissions are removed from the outbox
+	require.Eventually(t, func() bool {
+		subs, err := app.GetOutboxStore().ListPendingSubmissions(chainID, pk)
+		return err == nil && len(subs) == 0
+	}, eventuallyWaitTimeOut, eventuallyPollTime)
+}
+
+// rejectingHook rejects the broadcast of the submissions of the given kind
+type rejectingHook struct {
+	service.NoopSubmissionHook
+	kind service.SubmissionKind
+
+	mu       sync.Mutex
+	rejected []*service.Submission
+}
+
+func (h *rejectingHook) BeforeBroadcast(_ context.Context, sub *service.Submission) error {
+	if sub.Kind != h.kind {
+		return nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.rejected = append(h.rejected, sub)
+
+	return fmt.Errorf("the %s is not approved", sub.Kind)
+}
+
+func TestSubmissionHook(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+
+	randomStartingHeight := uint64(r.Int63n(100) + 1)
+	mockClientController := testutil.PrepareMockedClientController(t, r, randomStartingHeight, randomStartingHeight, 0)
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryBlock(gomock.Any()).Return(&types.BlockInfo{Height: randomStartingHeight}, nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any()).Return(false, false, nil).AnyTimes()
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	app, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, randomStartingHeight)
+	defer cleanUp()
+
+	hook := &rejectingHook{kind: service.SubmissionKindPubRandCommit}
+	app.RegisterSubmissionHook(hook)
+	err := app.StartHandlingFinalityProvider(fpIns.GetBtcPkBIP340(), passphrase)
+	require.NoError(t, err)
+	managedIns, err := app.GetFinalityProviderInstance(fpIns.GetBtcPkBIP340())
+	require.NoError(t, err)
+
+	// the rejected commit does not reach the chain as CommitPubRandList is
+	// not expected by the mock
+	_, err = managedIns.CommitPubRand(randomStartingHeight)
+	require.ErrorIs(t, err, service.ErrRejectedByHook)
+
+	hook.mu.Lock()
+	defer hook.mu.Unlock()
+	require.NotEmpty(t, hook.rejected)
+	require.Equal(t, fpIns.GetBtcPkHex(), hook.rejected[0].FpBtcPk.MarshalHex())
+	require.Equal(t, uint64(testutil.TestPubRandNum), hook.rejected[0].NumPubRand)
+}
+
+func TestSubscribeEvents(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+
+	randomStartingHeight := uint64(r.Int63n(100) + 1)
+	mockClientController := testutil.PrepareMockedClientController(t, r, randomStartingHeight, randomStartingHeight, 0)
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryBlock(gomock.Any()).Return(&types.BlockInfo{Height: randomStartingHeight}, nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any()).Return(false, false, nil).AnyTimes()
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	txHash := testutil.GenRandomHexStr(r, 32)
+	mockClientController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&types.TxResponse{TxHash: txHash}, nil).AnyTimes()
+	app, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, randomStartingHeight)
+	defer cleanUp()
+
+	events, unsubscribe := app.SubscribeEvents("test")
+	defer unsubscribe()
+
+	err := app.StartHandlingFinalityProvider(fpIns.GetBtcPkBIP340(), passphrase)
+	require.NoError(t, err)
+	managedIns, err := app.GetFinalityProviderInstance(fpIns.GetBtcPkBIP340())
+	require.NoError(t, err)
+	_, err = managedIns.CommitPubRand(randomStartingHeight)
+	require.NoError(t, err)
+
+	// the commit is streamed to the subscriber
+	timeout := time.After(eventuallyWaitTimeOut)
+	for {
+		select {
+		case ev := <-events:
+			if ev.Type != eventbus.EventPubRandCommitted {
+				continue
+			}
+			require.Equal(t, fpIns.GetBtcPkHex(), ev.FpBtcPk)
+			require.Equal(t, txHash, ev.TxHash)
+			require.False(t, ev.Time.IsZero())
+			return
+		case <-timeout:
+			t.Fatal("the public randomness commit was not streamed")
+		}
+	}
+}
+
+func TestSubmissionHistory(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+
+	randomStartingHeight := uint64(r.Int63n(100) + 1)
+	mockClientController := testutil.PrepareMockedClientController(t, r, randomStartingHeight, randomStartingHeight, 0)
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryBlock(gomock.Any()).Return(&types.BlockInfo{Height: randomStartingHeight}, nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any()).Return(false, false, nil).AnyTimes()
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	txHash := testutil.GenRandomHexStr(r, 32)
+	mockClientController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&types.TxResponse{TxHash: txHash}, nil).AnyTimes()
+	app, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, randomStartingHeight)
+	defer cleanUp()
+
+	err := app.StartHandlingFinalityProvider(fpIns.GetBtcPkBIP340(), passphrase)
+	require.NoError(t, err)
+	managedIns, err := app.GetFinalityProviderInstance(fpIns.GetBtcPkBIP340())
+	require.NoError(t, err)
+	_, err = managedIns.CommitPubRand(randomStartingHeight)
+	require.NoError(t, err)
+
+	// the commit is recorded along with its tx hash
+	commits, err := app.QueryPubRandCommits(fpIns.GetBtcPkBIP340())
+	require.NoError(t, err)
+	require.NotEmpty(t, commits)
+	require.Equal(t, "submitted", commits[0].Result)
+	require.Equal(t, txHash, commits[0].TxHash)
+	require.Equal(t, uint64(testutil.TestPubRandNum), commits[0].NumPubRand)
+
+	// no vote has been recorded
+	votes, err := app.QueryVotes(fpIns.GetBtcPkBIP340(), randomStartingHeight, 0)
+	require.NoError(t, err)
+	require.Empty(t, votes)
+
+	_, err = app.QueryVotes(fpIns.GetBtcPkBIP340(), randomStartingHeight+1, randomStartingHeight)
+	require.ErrorIs(t, err, service.ErrInvalidHeightRange)
+}
+
+func TestManualPubRandCommit(t *testing.T) {
+	r := rand.New(rand.NewSource(11))
+
+	randomStartingHeight := uint64(r.Int63n(100) + 1)
+	mockClientController := testutil.PrepareMockedClientController(t, r, randomStartingHeight, randomStartingHeight, 0)
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryBlock(gomock.Any()).Return(&types.BlockInfo{Height: randomStartingHeight}, nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any()).Return(false, false, nil).AnyTimes()
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	var startHeights []uint64
+	mockClientController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ *btcec.PublicKey, startHeight uint64, _ uint64, _ []byte, _ *schnorr.Signature) (*types.TxResponse, error) {
+			startHeights = append(startHeights, startHeight)
+			return &types.TxResponse{TxHash: testutil.GenRandomHexStr(r, 32)}, nil
+		}).AnyTimes()
+	app, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, randomStartingHeight)
+	defer cleanUp()
+
+	// the randomness is committed from the tip in batches until the
+	// target height
+	targetHeight := randomStartingHeight + 2*testutil.TestPubRandNum
+	txHashes, lastCommittedHeight, err := fpIns.CommitPubRandUntil(targetHeight)
+	require.NoError(t, err)
+	require.Len(t, txHashes, 2)
+	require.Equal(t, []uint64{randomStartingHeight + 1, randomStartingHeight + 1 + testutil.TestPubRandNum}, startHeights)
+	require.Equal(t, targetHeight, lastCommittedHeight)
+
+	// only the running finality providers are committed by the daemon
+	_, _, err = app.CommitPubRand(fpIns.GetBtcPkBIP340(), 0)
+	require.ErrorIs(t, err, service.ErrFinalityProviderNotRunning)
+
+	// a target height requiring too many commits is rejected
+	err = app.StartHandlingFinalityProvider(fpIns.GetBtcPkBIP340(), passphrase)
+	require.NoError(t, err)
+	_, _, err = app.CommitPubRand(fpIns.GetBtcPkBIP340(), randomStartingHeight+100*testutil.TestPubRandNum)
+	require.ErrorIs(t, err, service.ErrInvalidHeightRange)
+}
+
+func TestExportPubRandProofs(t *testing.T) {
+	r := rand.New(rand.NewSource(13))
+
+	randomStartingHeight := uint64(r.Int63n(100) + 1)
+	mockClientController := testutil.PrepareMockedClientController(t, r, randomStartingHeight, randomStartingHeight, 0)
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryBlock(gomock.Any()).Return(&types.BlockInfo{Height: randomStartingHeight}, nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any()).Return(false, false, nil).AnyTimes()
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&types.TxResponse{TxHash: testutil.GenRandomHexStr(r, 32)}, nil).AnyTimes()
+	app, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, randomStartingHeight)
+	defer cleanUp()
+
+	targetHeight := randomStartingHeight + 2*testutil.TestPubRandNum
+	_, _, err := fpIns.CommitPubRandUntil(targetHeight)
+	require.NoError(t, err)
+
+	export := func(fromHeight, toHeight uint64) []uint64 {
+		var heights []uint64
+		err := app.ExportPubRandProofs(fpIns.GetBtcPkBIP340(), fromHeight, toHeight, func(p *store.PubRandProofRecord) error {
+			require.NotEmpty(t, p.Proof)
+			heights = append(heights, p.Height)
+			return nil
+		})
+		require.NoError(t, err)
+		return heights
+	}
+
+	// all the proofs are exported without an end height
+	heights := export(0, 0)
+	require.Len(t, heights, int(2*testutil.TestPubRandNum))
+	require.Equal(t, randomStartingHeight+1, heights[0])
+	require.Equal(t, targetHeight, heights[len(heights)-1])
+
+	heights = export(randomStartingHeight+2, randomStartingHeight+3)
+	require.Equal(t, []uint64{randomStartingHeight + 2, randomStartingHeight + 3}, heights)
+
+	err = app.ExportPubRandProofs(fpIns.GetBtcPkBIP340(), 10, 9, func(*store.PubRandProofRecord) error { return nil })
+	require.ErrorIs(t, err, service.ErrInvalidHeightRange)
+
+	unknownPk, err := datagen.GenRandomBIP340PubKey(r)
+	require.NoError(t, err)
+	err = app.ExportPubRandProofs(unknownPk, 0, 0, func(*store.PubRandProofRecord) error { return nil })
+	require.ErrorIs(t, err, store.ErrFinalityProviderNotFound)
+}
+
+func TestSignSchnorrMessage(t *testing.T) {
+	r := rand.New(rand.NewSource(12))
+
+	randomStartingHeight := uint64(r.Int63n(100) + 1)
+	mockClientController := testutil.PrepareMockedClientController(t, r, randomStartingHeight, randomStartingHeight, 0)
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryBlock(gomock.Any()).Return(&types.BlockInfo{Height: randomStartingHeight}, nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any()).Return(false, false, nil).AnyTimes()
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&types.TxResponse{TxHash: testutil.GenRandomHexStr(r, 32)}, nil).AnyTimes()
+	app, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, randomStartingHeight)
+	defer cleanUp()
+
+	// the signature is over the hash of the prefixed message
+	msg := []byte("I am the operator of this finality provider")
+	hash, sig, err := app.SignSchnorrMessage(fpIns.GetBtcPkBIP340(), msg, passphrase)
+	require.NoError(t, err)
+	require.Equal(t, service.SignedMessageHash(msg), hash)
+	require.True(t, sig.Verify(hash, fpIns.GetBtcPkBIP340().MustToBTCPK()))
+	require.False(t, sig.Verify(msg, fpIns.GetBtcPkBIP340().MustToBTCPK()))
+
+	// the messages shaped like the payloads signed on-chain or their hex
+	// encodings are refused
+	blockHash := datagen.GenRandomByteArray(r, 32)
+	for _, msg := range [][]byte{
+		nil,
+		blockHash,
+		append(sdk.Uint64ToBigEndian(randomStartingHeight), blockHash...),
+		[]byte(hex.EncodeToString(blockHash)),
+		[]byte("0x" + hex.EncodeToString(blockHash)),
+		[]byte(hex.EncodeToString(datagen.GenRandomByteArray(r, 40))),
+		datagen.GenRandomByteArray(r, 1025),
+	} {
+		_, _, err = app.SignSchnorrMessage(fpIns.GetBtcPkBIP340(), msg, passphrase)
+		require.ErrorIs(t, err, service.ErrMessageNotSignable)
+	}
+
+	// only the finality providers managed by the daemon sign
+	unknownPk, err := datagen.GenRandomBIP340PubKey(r)
+	require.NoError(t, err)
+	_, _, err = app.SignSchnorrMessage(unknownPk, msg, passphrase)
+	require.ErrorIs(t, err, store.ErrFinalityProviderNotFound)
+}
+
+func TestCatchUp(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+
+	randomStartingHeight := uint64(r.Int63n(100) + 2)
+	// the public randomness is committed for the heights up to the tip
+	tipHeight := randomStartingHeight + testutil.TestPubRandNum - 1
+	mockClientController := testutil.PrepareMockedClientController(t, r, randomStartingHeight, tipHeight, 0)
+	mockClientController.EXPECT().QueryBlock(gomock.Any()).DoAndReturn(func(height uint64) (*types.BlockInfo, error) {
+		return &types.BlockInfo{Height: height, Hash: genBlockHash(height)}, nil
+	}).AnyTimes()
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).Return(uint64(1), nil).AnyTimes()
+	_, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, randomStartingHeight, func(cfg *config.Config) {
+		cfg.CatchUpThreshold = 10
+		cfg.BatchSubmissionSize = 10
+	})
+	defer cleanUp()
+
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), uint64(1)).Return(nil, nil).Times(1)
+	mockClientController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+	_, err := fpIns.CommitPubRand(randomStartingHeight - 1)
+	require.NoError(t, err)
+
+	// the missed blocks are fetched and submitted in batches
+	mockClientController.EXPECT().QueryBlocks(gomock.Any(), gomock.Any(), uint32(10)).
+		DoAndReturn(func(startHeight, endHeight uint64, _ uint32) ([]*types.BlockInfo, error) {
+			blocks := make([]*types.BlockInfo, 0, endHeight-startHeight+1)
+			for h := startHeight; h <= endHeight; h++ {
+				blocks = append(blocks, &types.BlockInfo{Height: h, Hash: genBlockHash(h)})
+			}
+			return blocks, nil
+		}).Times(3)
+	mockClientController.EXPECT().SubmitBatchFinalitySigs(fpIns.GetBtcPk(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&types.TxResponse{TxHash: testutil.GenRandomHexStr(r, 32)}, nil).Times(3)
+
+	err = fpIns.Start()
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return fpIns.GetLastVotedHeight() == tipHeight
+	}, eventuallyWaitTimeOut, eventuallyPollTime)
+	err = fpIns.Stop()
+	require.NoError(t, err)
+}
+
+func TestCommitPubRandRunway(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+
+	randomStartingHeight := uint64(r.Int63n(100) + 1)
+	mockClientController := testutil.PrepareMockedClientController(t, r, randomStartingHeight, randomStartingHeight, 0)
+	_, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, randomStartingHeight, func(cfg *config.Config) {
+		cfg.NumPubRand = 100
+		cfg.PubRandRunway = "20%"
+	})
+	defer cleanUp()
+
+	// the heights [randomStartingHeight, randomStartingHeight+99] are committed
+	lastCommittedHeight := randomStartingHeight + 99
+	lastCommittedPubRandMap := make(map[uint64]*ftypes.PubRandCommitResponse)
+	lastCommittedPubRandMap[randomStartingHeight] = &ftypes.PubRandCommitResponse{
+		NumPubRand: 100,
+		Commitment: datagen.GenRandomByteArray(r, 32),
+	}
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), uint64(1)).Return(lastCommittedPubRandMap, nil).AnyTimes()
+
+	// the remaining committed heights are above the runway of 20 blocks
+	res, err := fpIns.CommitPubRand(lastCommittedHeight - 20)
+	require.NoError(t, err)
+	require.Nil(t, res)
+
+	// the remaining committed heights fall below the runway
+	expectedTxHash := testutil.GenRandomHexStr(r, 32)
+	mockClientController.EXPECT().
+		CommitPubRandList(fpIns.GetBtcPk(), lastCommittedHeight+1, uint64(100), gomock.Any(), gomock.Any()).
+		Return(&types.TxResponse{TxHash: expectedTxHash}, nil).Times(1)
+	res, err = fpIns.CommitPubRand(lastCommittedHeight - 19)
+	require.NoError(t, err)
+	require.Equal(t, expectedTxHash, res.TxHash)
+}
+
+func TestPauseFinalityProvider(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+
+	randomStartingHeight := uint64(r.Int63n(100) + 1)
+	mockClientController := testutil.PrepareMockedClientController(t, r, randomStartingHeight, randomStartingHeight+1, 0)
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), uint64(1)).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any()).Return(false, false, nil).AnyTimes()
+	_, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, randomStartingHeight)
+	defer cleanUp()
+
+	// only a running instance can be paused
+	require.Error(t, fpIns.Pause())
+
+	err := fpIns.Start()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, fpIns.Stop())
+	}()
+
+	require.NoError(t, fpIns.Pause())
+	require.True(t, fpIns.IsPaused())
+	require.True(t, fpIns.IsRunning())
+	require.Error(t, fpIns.Pause())
+
+	require.NoError(t, fpIns.Resume())
+	require.False(t, fpIns.IsPaused())
+	require.Error(t, fpIns.Resume())
+}
+
+func TestResumeFromLastProcessedHeight(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+
+	randomStartingHeight := uint64(r.Int63n(100) + 1)
+	mockClientController := testutil.PrepareMockedClientController(t, r, randomStartingHeight, randomStartingHeight, 0)
+	var (
+		mu             sync.Mutex
+		queriedHeights []uint64
+	)
+	mockClientController.EXPECT().QueryBlock(gomock.Any()).DoAndReturn(func(height uint64) (*types.BlockInfo, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		queriedHeights = append(queriedHeights, height)
+		return &types.BlockInfo{Height: height, Hash: genBlockHash(height)}, nil
+	}).AnyTimes()
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), uint64(1)).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any()).Return(false, false, nil).AnyTimes()
+	app, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, randomStartingHeight, func(cfg *config.Config) {
+		cfg.PollerConfig.PollInterval = 10 * time.Millisecond
+		cfg.SignatureSubmissionInterval = 10 * time.Millisecond
+	})
+	defer cleanUp()
+
+	// the blocks without voting power are processed without being voted
+	err := fpIns.Start()
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return fpIns.GetLastProcessedHeight() >= randomStartingHeight+5
+	}, eventuallyWaitTimeOut, eventuallyPollTime)
+	err = fpIns.Stop()
+	require.NoError(t, err)
+	require.Zero(t, fpIns.GetLastVotedHeight())
+
+	// the last processed height is persisted
+	lastProcessedHeight := fpIns.GetLastProcessedHeight()
+	storedFp, err := app.GetFinalityProviderStore().GetFinalityProvider(fpIns.GetBtcPk())
+	require.NoError(t, err)
+	require.Equal(t, lastProcessedHeight, storedFp.LastProcessedHeight)
+
+	// the restarted instance resumes right after the last processed height
+	// instead of the static start height
+	mu.Lock()
+	queriedHeights = nil
+	mu.Unlock()
+	err = fpIns.Start()
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(queriedHeights) > 0
+	}, eventuallyWaitTimeOut, eventuallyPollTime)
+	err = fpIns.Stop()
+	require.NoError(t, err)
+	mu.Lock()
+	require.Equal(t, lastProcessedHeight+1, queriedHeights[0])
+	mu.Unlock()
+}
+
+func TestSkipBeforeActivation(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+
+	randomStartingHeight := uint64(r.Int63n(100) + 1)
+	finalityActivationHeight := randomStartingHeight + 5
+	fpActivationHeight := randomStartingHeight + 12
+	tipHeight := randomStartingHeight + 20
+	mockClientController := testutil.PrepareMockedClientController(t, r, randomStartingHeight, tipHeight, finalityActivationHeight)
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), uint64(1)).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any()).Return(false, false, nil).AnyTimes()
+	// the finality provider has voting power from its activation height
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ *btcec.PublicKey, height uint64) (uint64, error) {
+			if height < fpActivationHeight {
+				return 0, nil
+			}
+			return 1, nil
+		}).AnyTimes()
+	_, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, randomStartingHeight, func(cfg *config.Config) {
+		cfg.CatchUpThreshold = 0
+	})
+	defer cleanUp()
+
+	// the static start height is fast-forwarded to the activation height
+	// of the finality provider, which is above the finality activation height
+	require.Zero(t, fpIns.GetStartHeight())
+	err := fpIns.Start()
+	require.NoError(t, err)
+	require.Equal(t, fpActivationHeight, fpIns.GetStartHeight())
+	err = fpIns.Stop()
+	require.NoError(t, err)
+}
+
+func TestInactivePubRandCommit(t *testing.T) {
+	testCases := []struct {
+		name    string
+		enabled bool
+	}{
+		{name: "enabled", enabled: true},
+		{name: "disabled", enabled: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(10))
+
+			randomStartingHeight := uint64(r.Int63n(100) + 1)
+			mockClientController := testutil.PrepareMockedClientController(t, r, randomStartingHeight, randomStartingHeight, 0)
+			mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
+			mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), uint64(1)).Return(nil, nil).AnyTimes()
+			mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any()).Return(false, false, nil).AnyTimes()
+			// the registered finality provider has no voting power
+			mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+			committed := make(chan struct{})
+			if tc.enabled {
+				var once sync.Once
+				mockClientController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *btcec.PublicKey, _ uint64, _ uint64, _ []byte, _ *schnorr.Signature) (*types.TxResponse, error) {
+						once.Do(func() { close(committed) })
+						return &types.TxResponse{TxHash: "hash"}, nil
+					}).AnyTimes()
+			}
+			_, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, randomStartingHeight, func(cfg *config.Config) {
+				cfg.RandomnessCommitInterval = 10 * time.Millisecond
+				cfg.InactivePubRandCommit = tc.enabled
+			})
+			defer cleanUp()
+
+			err := fpIns.Start()
+			require.NoError(t, err)
+			defer func() {
+				require.NoError(t, fpIns.Stop())
+			}()
+
+			select {
+			case <-committed:
+				require.True(t, tc.enabled)
+			case <-time.After(200 * time.Millisecond):
+				require.False(t, tc.enabled)
+			}
+		})
+	}
+}
+
+func TestDelayedVoteTiming(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+
+	randomStartingHeight := uint64(r.Int63n(100) + 2)
+	// the blocks up to the last height are produced
+	lastHeight := randomStartingHeight + 6
+	delayBlocks := uint64(2)
+	mockClientController := testutil.PrepareMockedClientController(t, r, randomStartingHeight, randomStartingHeight, 0)
+	mockClientController.EXPECT().QueryBlock(gomock.Any()).DoAndReturn(func(height uint64) (*types.BlockInfo, error) {
+		if height > lastHeight {
+			return nil, fmt.Errorf("block %d not found", height)
+		}
+		return &types.BlockInfo{Height: height, Hash: genBlockHash(height)}, nil
+	}).AnyTimes()
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).Return(uint64(1), nil).AnyTimes()
+	_, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, randomStartingHeight, func(cfg *config.Config) {
+		cfg.PollerConfig.PollInterval = 10 * time.Millisecond
+		cfg.SignatureSubmissionInterval = 10 * time.Millisecond
+		cfg.VoteTimingConfig.Strategy = config.VoteTimingDelayed
+		cfg.VoteTimingConfig.DelayBlocks = delayBlocks
+	})
+	defer cleanUp()
+
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), uint64(1)).Return(nil, nil).Times(1)
+	mockClientController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+	_, err := fpIns.CommitPubRand(randomStartingHeight - 1)
+	require.NoError(t, err)
+
+	mockClientController.EXPECT().SubmitBatchFinalitySigs(fpIns.GetBtcPk(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&types.TxResponse{TxHash: testutil.GenRandomHexStr(r, 32)}, nil).AnyTimes()
+
+	// the last blocks are waiting for the subsequent blocks to be voted
+	err = fpIns.Start()
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return fpIns.GetLastVotedHeight() == lastHeight-delayBlocks
+	}, eventuallyWaitTimeOut, eventuallyPollTime)
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, lastHeight-delayBlocks, fpIns.GetLastVotedHeight())
+	require.Equal(t, lastHeight-delayBlocks, fpIns.GetLastProcessedHeight())
+	err = fpIns.Stop()
+	require.NoError(t, err)
+}
+
+// genBlockHash generates the hash of the block at the given height, which
+// is safe to be called by the concurrent loops of the instance
+func genBlockHash(height uint64) []byte {
+	// #nosec G115 -- the heights are small in tests
+	return testutil.GenRandomByteArray(rand.New(rand.NewSource(int64(height))), 32)
+}
+
+func TestRegenerateMissingPubRandProofs(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	logger := zap.NewNop()
+
+	startingHeight := uint64(r.Int63n(100) + 1)
+	mockClientController := testutil.PrepareMockedClientController(t, r, startingHeight, startingHeight, 0)
+
+	eotsHomeDir := filepath.Join(t.TempDir(), "eots-home")
+	eotsCfg := eotscfg.DefaultConfigWithHomePath(eotsHomeDir)
+	eotsdb, err := eotsCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	em, err := eotsmanager.NewLocalEOTSManager(eotsHomeDir, eotsCfg.KeyringBackend, eotsdb, logger)
+	require.NoError(t, err)
+
+	fpCfg := config.DefaultConfigWithHome(filepath.Join(t.TempDir(), "fp-home"))
+	fpCfg.NumPubRand = testutil.TestPubRandNum
+	db, err := fpCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	app, err := service.NewFinalityProviderApp(&fpCfg, mockClientController, em, db, logger)
+	require.NoError(t, err)
+	require.NoError(t, app.Start())
+	t.Cleanup(func() {
+		require.NoError(t, app.Stop())
+		require.NoError(t, eotsdb.Close())
+		require.NoError(t, db.Close())
+	})
+
+	eotsPkBz, err := em.CreateKey(testutil.GenRandomHexStr(r, 4), passphrase, hdPath)
+	require.NoError(t, err)
+	eotsPk, err := bbntypes.NewBIP340PubKey(eotsPkBz)
+	require.NoError(t, err)
+	fp := testutil.GenStoredFinalityProvider(r, t, app, passphrase, hdPath, eotsPk)
+	fpStore := app.GetFinalityProviderStore()
+	require.NoError(t, fpStore.SetFpStatus(fp.BtcPk, proto.FinalityProviderStatus_REGISTERED))
+
+	// the public randomness is committed with the proofs saved to a proof
+	// store which is then lost
+	onChainCommits := make(map[uint64]*ftypes.PubRandCommitResponse)
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any()).Return(onChainCommits, nil).AnyTimes()
+	mockClientController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, startHeight, numPubRand uint64, commitment []byte, _ any) (*types.TxResponse, error) {
+			onChainCommits[startHeight] = &ftypes.PubRandCommitResponse{NumPubRand: numPubRand, Commitment: commitment}
+			return &types.TxResponse{}, nil
+		}).Times(1)
+	fpIns, err := service.NewFinalityProviderInstance(context.Background(), fp.GetBIP340BTCPK(), &fpCfg, fpStore,
+		app.GetPubRandProofStore(), app.GetSignRecordStore(), app.GetVoteRetryStore(), mockClientController, em, metrics.NewFpMetrics(), nil,
+		passphrase, make(chan *service.CriticalError), logger)
+	require.NoError(t, err)
+	_, err = fpIns.CommitPubRand(startingHeight)
+	require.NoError(t, err)
+
+	newInstanceWithEmptyProofStore := func() *service.FinalityProviderInstance {
+		proofDB, err := config.DefaultDBConfigWithHomePath(t.TempDir()).GetDBBackend()
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, proofDB.Close())
+		})
+		proofStore, err := store.NewPubRandProofStore(proofDB)
+		require.NoError(t, err)
+		fpIns, err := service.NewFinalityProviderInstance(context.Background(), fp.GetBIP340BTCPK(), &fpCfg, fpStore,
+			proofStore, app.GetSignRecordStore(), app.GetVoteRetryStore(), mockClientController, em, metrics.NewFpMetrics(), nil,
+			passphrase, make(chan *service.CriticalError), logger)
+		require.NoError(t, err)
+		return fpIns
+	}
+
+	// the missing proofs are regenerated to vote
+	mockClientController.EXPECT().SubmitBatchFinalitySigs(fp.BtcPk, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&types.TxResponse{TxHash: testutil.GenRandomHexStr(r, 32)}, nil).Times(1)
+	block := &types.BlockInfo{Height: startingHeight + 1, Hash: genBlockHash(startingHeight + 1)}
+	_, err = newInstanceWithEmptyProofStore().SubmitBatchFinalitySignatures([]*types.BlockInfo{block})
+	require.NoError(t, err)
+
+	// the proofs are not regenerated if the re-derived randomness does not
+	// match the on-chain commitment
+	onChainCommits[startingHeight+1].Commitment = datagen.GenRandomByteArray(r, 32)
+	block = &types.BlockInfo{Height: startingHeight + 2, Hash: genBlockHash(startingHeight + 2)}
+	_, err = newInstanceWithEmptyProofStore().SubmitBatchFinalitySignatures([]*types.BlockInfo{block})
+	require.ErrorIs(t, err, service.ErrPubRandCommitmentMismatch)
+}
+
+func startFinalityProviderAppWithRegisteredFp(t *testing.T, r *rand.Rand, cc clientcontroller.ClientController, startingHeight uint64, cfgOpts ...func(cfg *config.Config)) (*service.FinalityProviderApp, *service.FinalityProviderInstance, func()) {
 	logger := zap.NewNop()
 	// create an EOTS manager
 	eotsHomeDir := filepath.Join(t.TempDir(), "eots-home")
@@ -115,6 +893,9 @@ func startFinalityProviderAppWithRegisteredFp(t *testing.T, r *rand.Rand, cc cli
 	fpCfg.NumPubRand = testutil.TestPubRandNum
 	fpCfg.PollerConfig.AutoChainScanningMode = false
 	fpCfg.PollerConfig.StaticChainScanningStartHeight = startingHeight
+	for _, opt := range cfgOpts {
+		opt(&fpCfg)
+	}
 	db, err := fpCfg.DatabaseConfig.GetDBBackend()
 	require.NoError(t, err)
 	app, err := service.NewFinalityProviderApp(&fpCfg, cc, em, db, logger)
@@ -131,12 +912,13 @@ func startFinalityProviderAppWithRegisteredFp(t *testing.T, r *rand.Rand, cc cli
 	require.NoError(t, err)
 	fp := testutil.GenStoredFinalityProvider(r, t, app, passphrase, hdPath, eotsPk)
 	pubRandProofStore := app.GetPubRandProofStore()
+	signRecordStore := app.GetSignRecordStore()
 	fpStore := app.GetFinalityProviderStore()
 	err = fpStore.SetFpStatus(fp.BtcPk, proto.FinalityProviderStatus_REGISTERED)
 	require.NoError(t, err)
 	// TODO: use mock metrics
 	m := metrics.NewFpMetrics()
-	fpIns, err := service.NewFinalityProviderInstance(fp.GetBIP340BTCPK(), &fpCfg, fpStore, pubRandProofStore, cc, em, m, passphrase, make(chan *service.CriticalError), logger)
+	fpIns, err := service.NewFinalityProviderInstance(context.Background(), fp.GetBIP340BTCPK(), &fpCfg, fpStore, pubRandProofStore, signRecordStore, app.GetVoteRetryStore(), cc, em, m, nil, passphrase, make(chan *service.CriticalError), logger)
 	require.NoError(t, err)
 
 	cleanUp := func() {
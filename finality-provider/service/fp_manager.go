@@ -1,20 +1,26 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/avast/retry-go/v4"
 	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 
 	"github.com/babylonlabs-io/finality-provider/clientcontroller"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager"
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/eventbus"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/notifier"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/log"
 	"github.com/babylonlabs-io/finality-provider/metrics"
 	"github.com/babylonlabs-io/finality-provider/types"
 )
@@ -24,63 +30,182 @@ const instanceTerminatingMsg = "terminating the finality-provider instance due t
 type CriticalError struct {
 	err     error
 	fpBtcPk *bbntypes.BIP340PubKey
+	// stack is the stack trace of the panic which has caused the error, nil
+	// for the other errors
+	stack []byte
 }
 
 func (ce *CriticalError) Error() string {
 	return fmt.Sprintf("critical err on finality-provider %s: %s", ce.fpBtcPk.MarshalHex(), ce.err.Error())
 }
 
-// FinalityProviderManager is responsible to initiate and start the given finality
-// provider instance, monitor its running status
+// FinalityProviderManager is responsible to initiate and start the finality
+// provider instances, one per EOTS key, and monitor their running status
 type FinalityProviderManager struct {
 	startOnce sync.Once
 	stopOnce  sync.Once
 
 	wg sync.WaitGroup
 
-	fpIns *FinalityProviderInstance
+	// ctx is the root context of the instances, it is cancelled to abort
+	// their outstanding calls when the shutdown grace period elapses
+	ctx context.Context
+
+	// fpInsMu protects fpInstances
+	fpInsMu sync.RWMutex
+	// fpInstances maps the EOTS public key hex to the instance of each
+	// finality provider run by the daemon
+	fpInstances map[string]*FinalityProviderInstance
+	// stoppedFps contains the EOTS public key hex of the finality providers
+	// stopped by the operator, which are not restarted by the status sync
+	stoppedFps map[string]struct{}
 
 	// needed for initiating finality-provider instances
 	fps          *store.FinalityProviderStore
 	pubRandStore *store.PubRandProofStore
-	config       *fpcfg.Config
-	cc           clientcontroller.ClientController
-	em           eotsmanager.EOTSManager
-	logger       *zap.Logger
+	signRecords  *store.SignRecordStore
+	voteRetries  *store.VoteRetryStore
+	outbox       *store.OutboxStore
+	history      *store.SubmissionHistoryStore
+	// cfgSnapshot holds the current config, shared with the app and the
+	// instances, and replaced as a whole upon a config reload
+	cfgSnapshot *atomic.Pointer[fpcfg.Config]
+	cc          clientcontroller.ClientController
+	em          eotsmanager.EOTSManager
+	logger      *zap.Logger
 
 	metrics *metrics.FpMetrics
 
+	// notifier sends the alerts to the configured and the registered
+	// notifiers
+	notifier *notifier.Dispatcher
+
+	// audit is nil if the audit log is disabled
+	audit *auditRecorder
+
+	// errReporters ships the panics and the critical errors to the
+	// configured and the registered error reporters
+	errReporters *errorReporters
+
+	// events dispatches the events to the configured sinks and to the gRPC
+	// subscribers
+	events *eventbus.Bus
+
 	criticalErrChan chan *CriticalError
 
+	// crashesMu protects crashes
+	crashesMu sync.Mutex
+	// crashes maps the EOTS public key hex to the crash record of the
+	// instance of each finality provider, which is used by the supervisor
+	crashes map[string]*crashRecord
+
+	// maintenance freezes the chain submissions and the db writes during
+	// the maintenance mode
+	maintenance *maintenanceGate
+
+	// submissionLimitersMu protects submissionLimiters
+	submissionLimitersMu sync.Mutex
+	// submissionLimiters maps the EOTS public key hex to the submission
+	// rate limiter of each finality provider, empty if the limit is disabled
+	submissionLimiters map[string]*submissionLimiter
+
+	// participationTrackersMu protects participationTrackers
+	participationTrackersMu sync.Mutex
+	// participationTrackers maps the EOTS public key hex to the tracker of
+	// the rolling participation rate of each finality provider, empty if the
+	// rate is disabled
+	participationTrackers map[string]*participationTracker
+
+	// hooks are shared by the instances to intercept their signing and
+	// submission
+	hooks *submissionHooks
+
+	// delegationsMu protects delegations
+	delegationsMu sync.RWMutex
+	// delegations maps the EOTS public key hex to the summary of the BTC
+	// delegations to each running finality provider as of the last query
+	delegations map[string]*proto.DelegationSummary
+
 	quit chan struct{}
 }
 
 func NewFinalityProviderManager(
+	ctx context.Context,
 	fps *store.FinalityProviderStore,
 	pubRandStore *store.PubRandProofStore,
+	signRecords *store.SignRecordStore,
+	voteRetries *store.VoteRetryStore,
+	outbox *store.OutboxStore,
+	history *store.SubmissionHistoryStore,
 	config *fpcfg.Config,
 	cc clientcontroller.ClientController,
 	em eotsmanager.EOTSManager,
 	metrics *metrics.FpMetrics,
 	logger *zap.Logger,
 ) (*FinalityProviderManager, error) {
+	events, err := eventbus.New(config.EventBusConfig, logger.Named(log.ModuleEventBus))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the event bus: %w", err)
+	}
+	if events == nil {
+		// the bus without sinks still streams the events to the gRPC
+		// subscribers
+		bufferSize := fpcfg.DefaultEventBusConfig().BufferSize
+		if config.EventBusConfig != nil && config.EventBusConfig.BufferSize > 0 {
+			bufferSize = config.EventBusConfig.BufferSize
+		}
+		events = eventbus.NewBus(int(bufferSize), logger.Named(log.ModuleEventBus))
+	}
+
+	recorder, err := newAuditRecorder(config.AuditConfig, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	reporters, err := newErrorReporters(config, logger)
+	if err != nil {
+		return nil, err
+	}
+
 	return &FinalityProviderManager{
-		criticalErrChan: make(chan *CriticalError),
-		fps:             fps,
-		pubRandStore:    pubRandStore,
-		config:          config,
-		cc:              cc,
-		em:              em,
-		metrics:         metrics,
-		logger:          logger,
-		quit:            make(chan struct{}),
+		ctx:                   ctx,
+		criticalErrChan:       make(chan *CriticalError),
+		fpInstances:           make(map[string]*FinalityProviderInstance),
+		stoppedFps:            make(map[string]struct{}),
+		crashes:               make(map[string]*crashRecord),
+		maintenance:           newMaintenanceGate(),
+		submissionLimiters:    make(map[string]*submissionLimiter),
+		participationTrackers: make(map[string]*participationTracker),
+		delegations:           make(map[string]*proto.DelegationSummary),
+		hooks:                 newSubmissionHooks(),
+		fps:                   fps,
+		pubRandStore:          pubRandStore,
+		signRecords:           signRecords,
+		voteRetries:           voteRetries,
+		outbox:                outbox,
+		history:               history,
+		cfgSnapshot:           atomic.NewPointer(config),
+		cc:                    cc,
+		em:                    em,
+		metrics:               metrics,
+		notifier:              notifier.NewDispatcher(config.NotifierConfig),
+		audit:                 recorder,
+		errReporters:          reporters,
+		events:                events,
+		logger:                logger,
+		quit:                  make(chan struct{}),
 	}, nil
 }
 
+// config returns the current config, which must not be modified
+func (fpm *FinalityProviderManager) config() *fpcfg.Config {
+	return fpm.cfgSnapshot.Load()
+}
+
 // monitorCriticalErr takes actions when it receives critical errors from a finality-provider instance
-// if the finality-provider is slashed, it will be terminated and the program keeps running in case
+// if the finality-provider is slashed or jailed, it will be terminated and the program keeps running in case
 // new finality providers join
-// otherwise, the program will panic
+// otherwise, the instance is restarted or the program is terminated according to the restart policy
 func (fpm *FinalityProviderManager) monitorCriticalErr() {
 	defer fpm.wg.Done()
 
@@ -89,29 +214,15 @@ func (fpm *FinalityProviderManager) monitorCriticalErr() {
 	for {
 		select {
 		case criticalErr = <-fpm.criticalErrChan:
-			fpi, err := fpm.GetFinalityProviderInstance()
+			fpi, err := fpm.GetFinalityProviderInstance(criticalErr.fpBtcPk)
 			if err != nil {
 				fpm.logger.Debug("the finality-provider instance is already shutdown",
 					zap.String("pk", criticalErr.fpBtcPk.MarshalHex()))
 
 				continue
 			}
-			if errors.Is(criticalErr.err, ErrFinalityProviderSlashed) {
-				fpm.setFinalityProviderSlashed(fpi)
-				fpm.logger.Debug("the finality-provider has been slashed",
-					zap.String("pk", criticalErr.fpBtcPk.MarshalHex()))
-
-				continue
-			}
-			if errors.Is(criticalErr.err, ErrFinalityProviderJailed) {
-				fpm.setFinalityProviderJailed(fpi)
-				fpm.logger.Debug("the finality-provider has been jailed",
-					zap.String("pk", criticalErr.fpBtcPk.MarshalHex()))
-
-				continue
-			}
-			fpm.logger.Fatal(instanceTerminatingMsg,
-				zap.String("pk", criticalErr.fpBtcPk.MarshalHex()), zap.Error(criticalErr.err))
+			fpm.captureCriticalErr(fpi, criticalErr)
+			fpm.handleCriticalErr(fpi, criticalErr.err)
 		case <-fpm.quit:
 			return
 		}
@@ -128,19 +239,21 @@ func (fpm *FinalityProviderManager) monitorCriticalErr() {
 func (fpm *FinalityProviderManager) monitorStatusUpdate() {
 	defer fpm.wg.Done()
 
-	if fpm.config.StatusUpdateInterval == 0 {
+	if fpm.config().StatusUpdateInterval == 0 {
 		fpm.logger.Info("the status update is disabled")
 		return
 	}
 
-	statusUpdateTicker := time.NewTicker(fpm.config.StatusUpdateInterval)
+	statusUpdateTicker := time.NewTicker(fpm.config().StatusUpdateInterval)
 	defer statusUpdateTicker.Stop()
 
 	for {
 		select {
 		case <-statusUpdateTicker.C:
-			fpi := fpm.fpIns
-			if fpi == nil {
+			// the interval might have been changed by a config reload
+			statusUpdateTicker.Reset(fpm.config().StatusUpdateInterval)
+			fpInstances := fpm.listFinalityProviderInstances()
+			if len(fpInstances) == 0 {
 				continue
 			}
 
@@ -149,86 +262,113 @@ func (fpm *FinalityProviderManager) monitorStatusUpdate() {
 				fpm.logger.Debug("failed to get the latest block", zap.Error(err))
 				continue
 			}
-			oldStatus := fpi.GetStatus()
-			power, err := fpi.GetVotingPowerWithRetry(latestBlock.Height)
-			if err != nil {
-				fpm.logger.Debug(
-					"failed to get the voting power",
-					zap.String("fp_btc_pk", fpi.GetBtcPkHex()),
-					zap.Uint64("height", latestBlock.Height),
-					zap.Error(err),
-				)
-				continue
-			}
-			// power > 0 (slashed_height must > 0), set status to ACTIVE
-			if power > 0 {
-				if oldStatus != proto.FinalityProviderStatus_ACTIVE {
-					fpi.MustSetStatus(proto.FinalityProviderStatus_ACTIVE)
-					fpm.logger.Debug(
-						"the finality-provider status is changed to ACTIVE",
-						zap.String("fp_btc_pk", fpi.GetBtcPkHex()),
-						zap.String("old_status", oldStatus.String()),
-						zap.Uint64("power", power),
-					)
+			// the status is stored in the db
+			fpm.maintenance.do(func() {
+				for _, fpi := range fpInstances {
+					fpm.updateStatus(fpi, latestBlock.Height)
 				}
-				continue
-			}
-			slashed, jailed, err := fpi.GetFinalityProviderSlashedOrJailedWithRetry()
-			if err != nil {
-				fpm.logger.Debug(
-					"failed to get the slashed or jailed status",
-					zap.String("fp_btc_pk", fpi.GetBtcPkHex()),
-					zap.Error(err),
-				)
-				continue
-			}
-			// power == 0 and slashed == true, set status to SLASHED, stop, and remove the finality-provider instance
-			if slashed {
-				fpm.setFinalityProviderSlashed(fpi)
-				fpm.logger.Warn(
-					"the finality-provider is slashed",
-					zap.String("fp_btc_pk", fpi.GetBtcPkHex()),
-					zap.String("old_status", oldStatus.String()),
-				)
-				continue
-			}
-			// power == 0 and jailed == true, set status to JAILED, stop, and remove the finality-provider instance
-			if jailed {
-				fpm.setFinalityProviderJailed(fpi)
-				fpm.logger.Warn(
-					"the finality-provider is jailed",
-					zap.String("fp_btc_pk", fpi.GetBtcPkHex()),
-					zap.String("old_status", oldStatus.String()),
-				)
-				continue
-			}
-			// power == 0 and slashed_height == 0, change to INACTIVE if the current status is ACTIVE
-			if oldStatus == proto.FinalityProviderStatus_ACTIVE {
-				fpi.MustSetStatus(proto.FinalityProviderStatus_INACTIVE)
-				fpm.logger.Debug(
-					"the finality-provider status is changed to INACTIVE",
-					zap.String("fp_btc_pk", fpi.GetBtcPkHex()),
-					zap.String("old_status", oldStatus.String()),
-				)
-			}
+			})
 		case <-fpm.quit:
 			return
 		}
 	}
 }
 
+// updateStatus updates the status of the finality provider instance
+// based on its voting power at the given height
+func (fpm *FinalityProviderManager) updateStatus(fpi *FinalityProviderInstance, height uint64) {
+	oldStatus := fpi.GetStatus()
+	power, err := fpi.GetVotingPowerWithRetry(height)
+	if err != nil {
+		fpm.logger.Debug(
+			"failed to get the voting power",
+			zap.String("fp_btc_pk", fpi.GetBtcPkHex()),
+			zap.Uint64("height", height),
+			zap.Error(err),
+		)
+		return
+	}
+	// power > 0 (slashed_height must > 0), set status to ACTIVE
+	if power > 0 {
+		if oldStatus != proto.FinalityProviderStatus_ACTIVE {
+			fpi.MustSetStatus(proto.FinalityProviderStatus_ACTIVE)
+			fpm.logger.Debug(
+				"the finality-provider status is changed to ACTIVE",
+				zap.String("fp_btc_pk", fpi.GetBtcPkHex()),
+				zap.String("old_status", oldStatus.String()),
+				zap.Uint64("power", power),
+			)
+			// top up the runway of the public randomness right away
+			// instead of waiting for the next commitment
+			fpi.triggerPubRandCommit()
+		}
+		return
+	}
+	slashed, jailed, err := fpi.GetFinalityProviderSlashedOrJailedWithRetry()
+	if err != nil {
+		fpm.logger.Debug(
+			"failed to get the slashed or jailed status",
+			zap.String("fp_btc_pk", fpi.GetBtcPkHex()),
+			zap.Error(err),
+		)
+		return
+	}
+	// power == 0 and slashed == true, set status to SLASHED, stop, and remove the finality-provider instance
+	if slashed {
+		fpm.setFinalityProviderSlashed(fpi)
+		fpm.logger.Warn(
+			"the finality-provider is slashed",
+			zap.String("fp_btc_pk", fpi.GetBtcPkHex()),
+			zap.String("old_status", oldStatus.String()),
+		)
+		return
+	}
+	// power == 0 and jailed == true, set status to JAILED, stop, and remove the finality-provider instance
+	if jailed {
+		fpm.setFinalityProviderJailed(fpi)
+		fpm.logger.Warn(
+			"the finality-provider is jailed",
+			zap.String("fp_btc_pk", fpi.GetBtcPkHex()),
+			zap.String("old_status", oldStatus.String()),
+		)
+		return
+	}
+	// power == 0 and slashed_height == 0, change to INACTIVE if the current status is ACTIVE
+	if oldStatus == proto.FinalityProviderStatus_ACTIVE {
+		fpi.MustSetStatus(proto.FinalityProviderStatus_INACTIVE)
+		fpm.logger.Debug(
+			"the finality-provider status is changed to INACTIVE",
+			zap.String("fp_btc_pk", fpi.GetBtcPkHex()),
+			zap.String("old_status", oldStatus.String()),
+		)
+	}
+}
+
 func (fpm *FinalityProviderManager) setFinalityProviderSlashed(fpi *FinalityProviderInstance) {
+	oldStatus := fpi.GetStatus()
 	fpi.MustSetStatus(proto.FinalityProviderStatus_SLASHED)
-	if err := fpm.removeFinalityProviderInstance(); err != nil {
+	if oldStatus != proto.FinalityProviderStatus_SLASHED {
+		fpm.notifyStatusChange(fpi, notifier.EventSlashed, oldStatus)
+	}
+	if err := fpm.removeFinalityProviderInstance(fpi.GetBtcPkBIP340()); err != nil {
 		panic(fmt.Errorf("failed to terminate a slashed finality-provider %s: %w", fpi.GetBtcPkHex(), err))
 	}
 }
 
 func (fpm *FinalityProviderManager) setFinalityProviderJailed(fpi *FinalityProviderInstance) {
+	oldStatus := fpi.GetStatus()
 	fpi.MustSetStatus(proto.FinalityProviderStatus_JAILED)
-	if err := fpm.removeFinalityProviderInstance(); err != nil {
+	if oldStatus != proto.FinalityProviderStatus_JAILED {
+		fpm.notifyStatusChange(fpi, notifier.EventJailed, oldStatus)
+	}
+	if err := fpm.removeFinalityProviderInstance(fpi.GetBtcPkBIP340()); err != nil {
 		panic(fmt.Errorf("failed to terminate a jailed finality-provider %s: %w", fpi.GetBtcPkHex(), err))
 	}
+
+	if fpm.autoUnjailEnabled() {
+		fpm.wg.Add(1)
+		go fpm.autoUnjail(fpi.GetBtcPkBIP340(), fpi.passphrase)
+	}
 }
 
 func (fpm *FinalityProviderManager) StartFinalityProvider(fpPk *bbntypes.BIP340PubKey, passphrase string) error {
@@ -236,10 +376,54 @@ func (fpm *FinalityProviderManager) StartFinalityProvider(fpPk *bbntypes.BIP340P
 		fpm.wg.Add(2)
 		go fpm.monitorCriticalErr()
 		go fpm.monitorStatusUpdate()
+
+		if fpm.equivocationWatcherEnabled() {
+			fpm.wg.Add(1)
+			go fpm.equivocationWatchLoop()
+		}
+
+		if fpm.selfCompromiseCheckEnabled() {
+			fpm.wg.Add(1)
+			go fpm.selfCompromiseCheckLoop()
+		}
+
+		if fpm.participationReportEnabled() {
+			fpm.wg.Add(1)
+			go fpm.participationReportLoop()
+		}
+
+		if fpm.alertRulesEnabled() {
+			fpm.wg.Add(1)
+			go fpm.alertRulesLoop()
+		}
+
+		if fpm.delegationMonitorEnabled() {
+			fpm.wg.Add(1)
+			go fpm.delegationMonitorLoop()
+		}
+
+		if fpm.finalityLagEnabled() {
+			fpm.wg.Add(1)
+			go fpm.finalityLagLoop()
+		}
+
+		if fpm.feeBalanceEnabled() {
+			fpm.wg.Add(1)
+			go fpm.feeBalanceLoop()
+		}
+
+		if fpm.participationRateEnabled() {
+			fpm.wg.Add(1)
+			go fpm.participationRateLoop()
+		}
 	})
 
 	fpm.logger.Info("starting finality provider", zap.String("pk", fpPk.MarshalHex()))
 
+	fpm.fpInsMu.Lock()
+	delete(fpm.stoppedFps, fpPk.MarshalHex())
+	fpm.fpInsMu.Unlock()
+
 	if err := fpm.startFinalityProviderInstance(fpPk, passphrase); err != nil {
 		return err
 	}
@@ -249,60 +433,152 @@ func (fpm *FinalityProviderManager) StartFinalityProvider(fpPk *bbntypes.BIP340P
 	return nil
 }
 
+// StopFinalityProvider stops the instance of the given finality provider
+// and removes it from the manager, leaving the other instances running
+func (fpm *FinalityProviderManager) StopFinalityProvider(fpPk *bbntypes.BIP340PubKey) error {
+	fpm.logger.Info("stopping finality provider", zap.String("pk", fpPk.MarshalHex()))
+
+	if err := fpm.removeFinalityProviderInstance(fpPk); err != nil {
+		return err
+	}
+
+	fpm.fpInsMu.Lock()
+	fpm.stoppedFps[fpPk.MarshalHex()] = struct{}{}
+	fpm.fpInsMu.Unlock()
+
+	fpm.logger.Info("finality provider is stopped", zap.String("pk", fpPk.MarshalHex()))
+
+	return nil
+}
+
+// isStoppedByOperator returns whether the given finality provider has been
+// stopped through StopFinalityProvider and not started again since
+func (fpm *FinalityProviderManager) isStoppedByOperator(fpPk *bbntypes.BIP340PubKey) bool {
+	fpm.fpInsMu.RLock()
+	defer fpm.fpInsMu.RUnlock()
+
+	_, stopped := fpm.stoppedFps[fpPk.MarshalHex()]
+	return stopped
+}
+
 func (fpm *FinalityProviderManager) Stop() error {
 	var stopErr error
 	fpm.stopOnce.Do(func() {
 		close(fpm.quit)
 		fpm.wg.Wait()
 
-		if fpm.fpIns == nil {
-			return
-		}
+		for _, fpi := range fpm.listFinalityProviderInstances() {
+			if !fpi.IsRunning() {
+				continue
+			}
 
-		if !fpm.fpIns.IsRunning() {
-			return
-		}
+			pkHex := fpi.GetBtcPkHex()
+			fpm.logger.Info("stopping finality provider", zap.String("pk", pkHex))
 
-		pkHex := fpm.fpIns.GetBtcPkHex()
-		fpm.logger.Info("stopping finality provider", zap.String("pk", pkHex))
+			if err := fpi.Stop(); err != nil {
+				stopErr = errors.Join(stopErr, fmt.Errorf("failed to stop finality provider %s: %w", pkHex, err))
+				continue
+			}
 
-		if err := fpm.fpIns.Stop(); err != nil {
-			stopErr = err
-			return
+			fpm.logger.Info("finality provider is stopped", zap.String("pk", pkHex))
 		}
 
-		fpm.logger.Info("finality provider is stopped", zap.String("pk", pkHex))
+		// the instances no longer publish events
+		fpm.events.Close()
 	})
 
 	return stopErr
 }
 
-func (fpm *FinalityProviderManager) GetFinalityProviderInstance() (*FinalityProviderInstance, error) {
-	if fpm.fpIns == nil {
-		return nil, fmt.Errorf("finality provider does not exist")
+// GetSoleFinalityProviderInstance returns the finality provider instance if
+// exactly one is run by the manager
+//
+// Deprecated: use GetFinalityProviderInstance or ListRunningInstances instead,
+// which address the instances of a manager running multiple finality providers
+func (fpm *FinalityProviderManager) GetSoleFinalityProviderInstance() (*FinalityProviderInstance, error) {
+	fpInstances := fpm.listFinalityProviderInstances()
+	switch len(fpInstances) {
+	case 0:
+		return nil, ErrFinalityProviderNotRunning
+	case 1:
+		return fpInstances[0], nil
+	default:
+		return nil, fmt.Errorf("%d finality provider instances are running", len(fpInstances))
 	}
+}
 
-	return fpm.fpIns, nil
+// GetFinalityProviderInstance returns the instance of the given finality provider
+func (fpm *FinalityProviderManager) GetFinalityProviderInstance(fpPk *bbntypes.BIP340PubKey) (*FinalityProviderInstance, error) {
+	fpm.fpInsMu.RLock()
+	defer fpm.fpInsMu.RUnlock()
+
+	fpi, exists := fpm.fpInstances[fpPk.MarshalHex()]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrFinalityProviderNotRunning, fpPk.MarshalHex())
+	}
+
+	return fpi, nil
+}
+
+// ListRunningInstances returns the running instances sorted by the BTC public
+// keys of their finality providers
+func (fpm *FinalityProviderManager) ListRunningInstances() []*FinalityProviderInstance {
+	fpInstances := make([]*FinalityProviderInstance, 0)
+	for _, fpi := range fpm.listFinalityProviderInstances() {
+		if fpi.IsRunning() {
+			fpInstances = append(fpInstances, fpi)
+		}
+	}
+
+	sort.Slice(fpInstances, func(i, j int) bool {
+		return fpInstances[i].GetBtcPkHex() < fpInstances[j].GetBtcPkHex()
+	})
+
+	return fpInstances
+}
+
+// listFinalityProviderInstances returns a snapshot of the instances
+// run by the manager
+func (fpm *FinalityProviderManager) listFinalityProviderInstances() []*FinalityProviderInstance {
+	fpm.fpInsMu.RLock()
+	defer fpm.fpInsMu.RUnlock()
+
+	fpInstances := make([]*FinalityProviderInstance, 0, len(fpm.fpInstances))
+	for _, fpi := range fpm.fpInstances {
+		fpInstances = append(fpInstances, fpi)
+	}
+
+	return fpInstances
 }
 
 func (fpm *FinalityProviderManager) AllFinalityProviders() ([]*proto.FinalityProviderInfo, error) {
-	storedFps, err := fpm.fps.GetAllStoredFinalityProviders()
+	fpsInfo, _, err := fpm.QueryFinalityProviders(&store.FinalityProviderQuery{})
+
+	return fpsInfo, err
+}
+
+// QueryFinalityProviders returns the information of the stored finality providers
+// matching the given query and the key of the next page
+func (fpm *FinalityProviderManager) QueryFinalityProviders(q *store.FinalityProviderQuery) ([]*proto.FinalityProviderInfo, []byte, error) {
+	storedFps, nextKey, err := fpm.fps.QueryFinalityProviders(q)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	fpsInfo := make([]*proto.FinalityProviderInfo, 0, len(storedFps))
 	for _, fp := range storedFps {
 		fpInfo := fp.ToFinalityProviderInfo()
 
-		if fpm.IsFinalityProviderRunning(fp.GetBIP340BTCPK()) {
-			fpInfo.IsRunning = true
+		if fpi, err := fpm.GetFinalityProviderInstance(fp.GetBIP340BTCPK()); err == nil {
+			fpInfo.IsRunning = fpi.IsRunning()
+			fpInfo.IsPaused = fpi.IsPaused()
+			fpInfo.StartHeight = fpi.GetStartHeight()
 		}
 
 		fpsInfo = append(fpsInfo, fpInfo)
 	}
 
-	return fpsInfo, nil
+	return fpsInfo, nextKey, nil
 }
 
 func (fpm *FinalityProviderManager) FinalityProviderInfo(fpPk *bbntypes.BIP340PubKey) (*proto.FinalityProviderInfo, error) {
@@ -313,38 +589,77 @@ func (fpm *FinalityProviderManager) FinalityProviderInfo(fpPk *bbntypes.BIP340Pu
 
 	fpInfo := storedFp.ToFinalityProviderInfo()
 
-	if fpm.IsFinalityProviderRunning(fpPk) {
-		fpInfo.IsRunning = true
+	if fpi, err := fpm.GetFinalityProviderInstance(fpPk); err == nil {
+		fpInfo.IsRunning = fpi.IsRunning()
+		fpInfo.IsPaused = fpi.IsPaused()
+		fpInfo.StartHeight = fpi.GetStartHeight()
 	}
+	fpInfo.Delegations = fpm.getDelegationSummary(fpPk.MarshalHex())
 
 	return fpInfo, nil
 }
 
-func (fpm *FinalityProviderManager) IsFinalityProviderRunning(fpPk *bbntypes.BIP340PubKey) bool {
-	if fpm.fpIns == nil {
-		return false
+// SubscribeEvents returns a channel receiving the events of the finality
+// providers published from now on and a function to unsubscribe. The
+// channel is closed once the manager stops
+func (fpm *FinalityProviderManager) SubscribeEvents(name string) (<-chan *eventbus.Event, func()) {
+	return fpm.events.Subscribe(name)
+}
+
+// RegisterSubmissionHook registers the hook intercepting the signing and the
+// submission of all the finality providers, including the running ones
+func (fpm *FinalityProviderManager) RegisterSubmissionHook(hook SubmissionHook) {
+	fpm.hooks.register(hook)
+}
+
+// PauseFinalityProvider pauses the voting of the running finality provider
+func (fpm *FinalityProviderManager) PauseFinalityProvider(fpPk *bbntypes.BIP340PubKey) error {
+	fpi, err := fpm.GetFinalityProviderInstance(fpPk)
+	if err != nil {
+		return err
+	}
+
+	return fpi.Pause()
+}
+
+// ResumeFinalityProvider resumes the voting of the paused finality provider
+func (fpm *FinalityProviderManager) ResumeFinalityProvider(fpPk *bbntypes.BIP340PubKey) error {
+	fpi, err := fpm.GetFinalityProviderInstance(fpPk)
+	if err != nil {
+		return err
 	}
 
-	if fpm.fpIns.GetBtcPkHex() != fpPk.MarshalHex() {
+	return fpi.Resume()
+}
+
+func (fpm *FinalityProviderManager) IsFinalityProviderRunning(fpPk *bbntypes.BIP340PubKey) bool {
+	fpi, err := fpm.GetFinalityProviderInstance(fpPk)
+	if err != nil {
 		return false
 	}
 
-	return fpm.fpIns.IsRunning()
+	return fpi.IsRunning()
 }
 
-func (fpm *FinalityProviderManager) removeFinalityProviderInstance() error {
-	fpi := fpm.fpIns
-	if fpi == nil {
-		return fmt.Errorf("the finality provider instance does not exist")
+func (fpm *FinalityProviderManager) removeFinalityProviderInstance(fpPk *bbntypes.BIP340PubKey) error {
+	pkHex := fpPk.MarshalHex()
+
+	// the instance is stopped without holding the lock as stopping
+	// it waits for its loops, which might be reporting critical errors
+	fpm.fpInsMu.Lock()
+	fpi, exists := fpm.fpInstances[pkHex]
+	delete(fpm.fpInstances, pkHex)
+	fpm.fpInsMu.Unlock()
+
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrFinalityProviderNotRunning, pkHex)
 	}
 	if fpi.IsRunning() {
 		if err := fpi.Stop(); err != nil {
-			return fmt.Errorf("failed to stop the finality provider instance %s", fpi.GetBtcPkHex())
+			return fmt.Errorf("failed to stop the finality provider instance %s", pkHex)
 		}
 	}
 
-	fpm.fpIns = nil
-
 	return nil
 }
 
@@ -353,20 +668,75 @@ func (fpm *FinalityProviderManager) startFinalityProviderInstance(
 	pk *bbntypes.BIP340PubKey,
 	passphrase string,
 ) error {
-	pkHex := pk.MarshalHex()
-	if fpm.fpIns == nil {
-		fpIns, err := NewFinalityProviderInstance(
-			pk, fpm.config, fpm.fps, fpm.pubRandStore, fpm.cc, fpm.em,
-			fpm.metrics, passphrase, fpm.criticalErrChan, fpm.logger,
-		)
-		if err != nil {
-			return fmt.Errorf("failed to create finality provider instance %s: %w", pkHex, err)
+	// starting an instance writes to the db
+	if fpm.IsInMaintenance() {
+		return ErrInMaintenance
+	}
+
+	fpIns, err := fpm.getOrCreateFinalityProviderInstance(pk, passphrase)
+	if err != nil {
+		return err
+	}
+
+	if err := fpIns.Start(); err != nil {
+		// the instance failing to start is dropped, so that it is not
+		// taken for a running one and is created anew by the next start
+		if !fpIns.IsRunning() {
+			fpm.dropFinalityProviderInstance(fpIns)
 		}
+		return err
+	}
+
+	return nil
+}
+
+// dropFinalityProviderInstance removes the given instance from the manager
+// unless it has been replaced in the meantime
+func (fpm *FinalityProviderManager) dropFinalityProviderInstance(fpIns *FinalityProviderInstance) {
+	fpm.fpInsMu.Lock()
+	defer fpm.fpInsMu.Unlock()
+
+	pkHex := fpIns.GetBtcPkHex()
+	if fpm.fpInstances[pkHex] == fpIns {
+		delete(fpm.fpInstances, pkHex)
+	}
+}
 
-		fpm.fpIns = fpIns
+func (fpm *FinalityProviderManager) getOrCreateFinalityProviderInstance(
+	pk *bbntypes.BIP340PubKey,
+	passphrase string,
+) (*FinalityProviderInstance, error) {
+	fpm.fpInsMu.Lock()
+	defer fpm.fpInsMu.Unlock()
+
+	pkHex := pk.MarshalHex()
+	if fpIns, exists := fpm.fpInstances[pkHex]; exists {
+		return fpIns, nil
 	}
 
-	return fpm.fpIns.Start()
+	fpIns, err := NewFinalityProviderInstance(
+		fpm.ctx, pk, fpm.config(), fpm.fps, fpm.pubRandStore, fpm.signRecords, fpm.voteRetries, fpm.cc, fpm.em,
+		fpm.metrics, fpm.events, passphrase, fpm.criticalErrChan, fpm.logger.Named(log.ModuleFpInstance),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create finality provider instance %s: %w", pkHex, err)
+	}
+	fpIns.cfgSnapshot = fpm.cfgSnapshot
+	fpIns.maintenance = fpm.maintenance
+	fpIns.submissionLimiter = fpm.getSubmissionLimiter(pkHex)
+	fpIns.participation = fpm.getParticipationTracker(pkHex)
+	fpIns.outbox = fpm.outbox
+	if cfg := fpm.config().SubmissionHistoryConfig; cfg != nil && cfg.Enabled {
+		fpIns.history = fpm.history
+	}
+	fpIns.hooks = fpm.hooks
+	fpIns.alerts = newInstanceAlerts(fpm.config().NotifierConfig, func(eventType notifier.EventType, height uint64) {
+		fpm.notifyInstanceAlert(fpIns, eventType, height)
+	})
+
+	fpm.fpInstances[pkHex] = fpIns
+
+	return fpIns, nil
 }
 
 func (fpm *FinalityProviderManager) getLatestBlockWithRetry() (*types.BlockInfo, error) {
@@ -381,7 +751,7 @@ func (fpm *FinalityProviderManager) getLatestBlockWithRetry() (*types.BlockInfo,
 			return err
 		}
 		return nil
-	}, RtyAtt, RtyDel, RtyErr, retry.OnRetry(func(n uint, err error) {
+	}, retry.Context(fpm.ctx), RtyAtt, RtyDel, RtyErr, retry.OnRetry(func(n uint, err error) {
 		fpm.logger.Debug(
 			"failed to query the consumer chain for the latest block",
 			zap.Uint("attempt", n+1),
@@ -1,15 +1,25 @@
 package service_test
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"math/rand"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/babylonlabs-io/babylon/testutil/datagen"
 	bbntypes "github.com/babylonlabs-io/babylon/types"
+	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -18,6 +28,7 @@ import (
 	"github.com/babylonlabs-io/finality-provider/eotsmanager"
 	eotscfg "github.com/babylonlabs-io/finality-provider/eotsmanager/config"
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/notifier"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
 	fpstore "github.com/babylonlabs-io/finality-provider/finality-provider/store"
@@ -61,6 +72,8 @@ func FuzzStatusUpdate(f *testing.F) {
 
 		votingPower := uint64(r.Intn(2))
 		mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), currentHeight).Return(votingPower, nil).AnyTimes()
+		// the finality provider is activated at the current height, if ever
+		mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
 		mockClientController.EXPECT().SubmitFinalitySig(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&types.TxResponse{TxHash: ""}, nil).AnyTimes()
 		var isSlashedOrJailed int
 		if votingPower == 0 {
@@ -78,7 +91,7 @@ func FuzzStatusUpdate(f *testing.F) {
 
 		err := vm.StartFinalityProvider(fpPk, passphrase)
 		require.NoError(t, err)
-		fpIns, err := vm.GetFinalityProviderInstance()
+		fpIns, err := vm.GetFinalityProviderInstance(fpPk)
 		require.NoError(t, err)
 		// stop the finality-provider as we are testing static functionalities
 		err = fpIns.Stop()
@@ -106,7 +119,646 @@ func waitForStatus(t *testing.T, fpIns *service.FinalityProviderInstance, s prot
 		}, eventuallyWaitTimeOut, eventuallyPollTime)
 }
 
-func newFinalityProviderManagerWithRegisteredFp(t *testing.T, r *rand.Rand, cc clientcontroller.ClientController) (*service.FinalityProviderManager, *bbntypes.BIP340PubKey, func()) {
+func TestStartStopMultipleFinalityProviders(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+
+	ctl := gomock.NewController(t)
+	mockClientController := mocks.NewMockClientController(ctl)
+	vm, fpPks, cleanUp := newFinalityProviderManagerWithRegisteredFps(t, r, mockClientController, 2)
+	defer cleanUp()
+
+	currentBlockRes := &types.BlockInfo{
+		Height: uint64(r.Int63n(100) + 1),
+		Hash:   datagen.GenRandomByteArray(r, 32),
+	}
+	mockClientController.EXPECT().QueryBestBlock().Return(currentBlockRes, nil).AnyTimes()
+	mockClientController.EXPECT().Close().Return(nil).AnyTimes()
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryActivatedHeight().Return(uint64(1), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityActivationBlockHeight().Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryBlock(gomock.Any()).Return(currentBlockRes, nil).AnyTimes()
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&types.TxResponse{TxHash: ""}, nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).Return(uint64(1), nil).AnyTimes()
+	mockClientController.EXPECT().SubmitBatchFinalitySigs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&types.TxResponse{TxHash: ""}, nil).AnyTimes()
+
+	// each finality provider runs in its own instance
+	for _, fpPk := range fpPks {
+		err := vm.StartFinalityProvider(fpPk, passphrase)
+		require.NoError(t, err)
+		require.True(t, vm.IsFinalityProviderRunning(fpPk))
+	}
+	require.Len(t, vm.ListRunningInstances(), 2)
+
+	// stopping one instance leaves the other running
+	err := vm.StopFinalityProvider(fpPks[0])
+	require.NoError(t, err)
+	require.False(t, vm.IsFinalityProviderRunning(fpPks[0]))
+	require.True(t, vm.IsFinalityProviderRunning(fpPks[1]))
+	err = vm.StopFinalityProvider(fpPks[0])
+	require.Error(t, err)
+
+	runningInstances := vm.ListRunningInstances()
+	require.Len(t, runningInstances, 1)
+	require.Equal(t, fpPks[1].MarshalHex(), runningInstances[0].GetBtcPkHex())
+	_, err = vm.GetFinalityProviderInstance(fpPks[0])
+	require.Error(t, err)
+	fpIns, err := vm.GetFinalityProviderInstance(fpPks[1])
+	require.NoError(t, err)
+	require.Same(t, runningInstances[0], fpIns)
+
+	// the stopped instance can be started again
+	err = vm.StartFinalityProvider(fpPks[0], passphrase)
+	require.NoError(t, err)
+	require.True(t, vm.IsFinalityProviderRunning(fpPks[0]))
+}
+
+func TestMaintenanceMode(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+
+	ctl := gomock.NewController(t)
+	mockClientController := mocks.NewMockClientController(ctl)
+	vm, fpPks, cleanUp := newFinalityProviderManagerWithRegisteredFps(t, r, mockClientController, 2, func(cfg *fpcfg.Config) {
+		cfg.RandomnessCommitInterval = 10 * time.Millisecond
+		cfg.NumPubRand = testutil.TestPubRandNum
+		cfg.SignatureSubmissionInterval = 10 * time.Millisecond
+	})
+	defer cleanUp()
+
+	currentBlockRes := &types.BlockInfo{
+		Height: uint64(r.Int63n(100) + 1),
+		Hash:   datagen.GenRandomByteArray(r, 32),
+	}
+	var submissions atomic.Int32
+	mockClientController.EXPECT().QueryBestBlock().Return(currentBlockRes, nil).AnyTimes()
+	mockClientController.EXPECT().Close().Return(nil).AnyTimes()
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryActivatedHeight().Return(uint64(1), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityActivationBlockHeight().Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryBlock(gomock.Any()).Return(currentBlockRes, nil).AnyTimes()
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_, _, _, _, _ interface{}) (*types.TxResponse, error) {
+			submissions.Add(1)
+			return &types.TxResponse{TxHash: "hash"}, nil
+		}).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any()).Return(false, false, nil).AnyTimes()
+
+	err := vm.StartFinalityProvider(fpPks[0], passphrase)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return submissions.Load() > 0
+	}, eventuallyWaitTimeOut, eventuallyPollTime)
+
+	require.NoError(t, vm.EnterMaintenance())
+	require.True(t, vm.IsInMaintenance())
+	require.ErrorIs(t, vm.EnterMaintenance(), service.ErrInMaintenance)
+
+	// nothing is submitted and no instance is started in the maintenance
+	// mode, while the running instance keeps running
+	numSubmissions := submissions.Load()
+	require.Never(t, func() bool {
+		return submissions.Load() != numSubmissions
+	}, 200*time.Millisecond, eventuallyPollTime)
+	require.True(t, vm.IsFinalityProviderRunning(fpPks[0]))
+	require.ErrorIs(t, vm.StartFinalityProvider(fpPks[1], passphrase), service.ErrInMaintenance)
+
+	require.NoError(t, vm.ExitMaintenance())
+	require.False(t, vm.IsInMaintenance())
+	require.ErrorIs(t, vm.ExitMaintenance(), service.ErrNotInMaintenance)
+	require.NoError(t, vm.StartFinalityProvider(fpPks[1], passphrase))
+}
+
+func TestAutoUnjail(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+
+	ctl := gomock.NewController(t)
+	mockClientController := mocks.NewMockClientController(ctl)
+	vm, fpPk, cleanUp := newFinalityProviderManagerWithRegisteredFp(t, r, mockClientController, func(cfg *fpcfg.Config) {
+		cfg.AutoUnjailConfig.Enabled = true
+		cfg.AutoUnjailConfig.InitialBackoff = 10 * time.Millisecond
+		cfg.AutoUnjailConfig.MaxBackoff = 10 * time.Millisecond
+	})
+	defer cleanUp()
+
+	currentBlockRes := &types.BlockInfo{
+		Height: uint64(r.Int63n(100) + 1),
+		Hash:   datagen.GenRandomByteArray(r, 32),
+	}
+	mockClientController.EXPECT().QueryBestBlock().Return(currentBlockRes, nil).AnyTimes()
+	mockClientController.EXPECT().Close().Return(nil).AnyTimes()
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryActivatedHeight().Return(uint64(1), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityActivationBlockHeight().Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryBlock(gomock.Any()).Return(currentBlockRes, nil).AnyTimes()
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&types.TxResponse{TxHash: ""}, nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+
+	// the finality provider is jailed once and its jailing period has elapsed
+	gomock.InOrder(
+		mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any()).Return(false, true, nil).Times(1),
+		mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any()).Return(false, false, nil).AnyTimes(),
+	)
+	mockClientController.EXPECT().QueryFinalityProviderJailedUntil(gomock.Any()).Return(time.Now().Add(-time.Minute), nil).Times(1)
+	// the first attempt fails and is retried after the backoff
+	unjailed := make(chan struct{})
+	gomock.InOrder(
+		mockClientController.EXPECT().UnjailFinalityProvider(gomock.Any()).Return(nil, errors.New("transient error")).Times(1),
+		mockClientController.EXPECT().UnjailFinalityProvider(gomock.Any()).DoAndReturn(func(_ interface{}) (*types.TxResponse, error) {
+			close(unjailed)
+			return &types.TxResponse{TxHash: "hash"}, nil
+		}).Times(1),
+	)
+
+	err := vm.StartFinalityProvider(fpPk, passphrase)
+	require.NoError(t, err)
+
+	select {
+	case <-unjailed:
+	case <-time.After(eventuallyWaitTimeOut):
+		t.Fatal("the finality provider is not unjailed")
+	}
+
+	// the instance is restarted after unjailing
+	require.Eventually(t, func() bool {
+		return vm.IsFinalityProviderRunning(fpPk)
+	}, eventuallyWaitTimeOut, eventuallyPollTime)
+	fpIns, err := vm.GetFinalityProviderInstance(fpPk)
+	require.NoError(t, err)
+	require.Equal(t, proto.FinalityProviderStatus_INACTIVE, fpIns.GetStatus())
+}
+
+func TestRestartCrashedInstance(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+
+	ctl := gomock.NewController(t)
+	mockClientController := mocks.NewMockClientController(ctl)
+	vm, fpPk, cleanUp := newFinalityProviderManagerWithRegisteredFp(t, r, mockClientController, func(cfg *fpcfg.Config) {
+		cfg.RandomnessCommitInterval = 10 * time.Millisecond
+		cfg.NumPubRand = testutil.TestPubRandNum
+		cfg.SupervisorConfig.InitialBackoff = 10 * time.Millisecond
+		cfg.SupervisorConfig.MaxBackoff = 10 * time.Millisecond
+	})
+	defer cleanUp()
+
+	currentBlockRes := &types.BlockInfo{
+		Height: uint64(r.Int63n(100) + 1),
+		Hash:   datagen.GenRandomByteArray(r, 32),
+	}
+	mockClientController.EXPECT().QueryBestBlock().Return(currentBlockRes, nil).AnyTimes()
+	mockClientController.EXPECT().Close().Return(nil).AnyTimes()
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryActivatedHeight().Return(uint64(1), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityActivationBlockHeight().Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryBlock(gomock.Any()).Return(currentBlockRes, nil).AnyTimes()
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any()).Return(false, false, nil).AnyTimes()
+
+	// the first commitment panics, the instance is restarted and commits again
+	recommitted := make(chan struct{})
+	gomock.InOrder(
+		mockClientController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_, _, _, _, _ interface{}) (*types.TxResponse, error) {
+				panic("unexpected error")
+			}).Times(1),
+		mockClientController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_, _, _, _, _ interface{}) (*types.TxResponse, error) {
+				close(recommitted)
+				return &types.TxResponse{TxHash: "hash"}, nil
+			}).Times(1),
+		mockClientController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(&types.TxResponse{TxHash: "hash"}, nil).AnyTimes(),
+	)
+
+	err := vm.StartFinalityProvider(fpPk, passphrase)
+	require.NoError(t, err)
+	crashedIns, err := vm.GetFinalityProviderInstance(fpPk)
+	require.NoError(t, err)
+
+	select {
+	case <-recommitted:
+	case <-time.After(eventuallyWaitTimeOut):
+		t.Fatal("the crashed finality provider instance is not restarted")
+	}
+
+	// the crashed instance is replaced by a new running one
+	require.False(t, crashedIns.IsRunning())
+	require.Eventually(t, func() bool {
+		return vm.IsFinalityProviderRunning(fpPk)
+	}, eventuallyWaitTimeOut, eventuallyPollTime)
+	fpIns, err := vm.GetFinalityProviderInstance(fpPk)
+	require.NoError(t, err)
+	require.NotSame(t, crashedIns, fpIns)
+}
+
+// TestRestartFailingInstance tests that an instance failing to be restarted
+// is not left as a running one, so that the manager can be stopped
+func TestRestartFailingInstance(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+
+	ctl := gomock.NewController(t)
+	mockClientController := mocks.NewMockClientController(ctl)
+	vm, fpPk, cleanUp := newFinalityProviderManagerWithRegisteredFp(t, r, mockClientController, func(cfg *fpcfg.Config) {
+		cfg.RandomnessCommitInterval = 10 * time.Millisecond
+		cfg.NumPubRand = testutil.TestPubRandNum
+		cfg.SupervisorConfig.InitialBackoff = 10 * time.Millisecond
+		cfg.SupervisorConfig.MaxBackoff = 10 * time.Millisecond
+	})
+	defer cleanUp()
+
+	currentBlockRes := &types.BlockInfo{
+		Height: uint64(r.Int63n(100) + 1),
+		Hash:   datagen.GenRandomByteArray(r, 32),
+	}
+	// the node becomes unreachable once the instance crashes, so that the
+	// restarted instance fails to start
+	var (
+		crashed       atomic.Bool
+		failedQueries atomic.Int32
+	)
+	mockClientController.EXPECT().QueryFinalityActivationBlockHeight().DoAndReturn(func() (uint64, error) {
+		if crashed.Load() {
+			failedQueries.Add(1)
+			return 0, errors.New("connection refused")
+		}
+		return 0, nil
+	}).AnyTimes()
+	mockClientController.EXPECT().QueryBestBlock().Return(currentBlockRes, nil).AnyTimes()
+	mockClientController.EXPECT().Close().Return(nil).AnyTimes()
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryActivatedHeight().Return(uint64(1), nil).AnyTimes()
+	mockClientController.EXPECT().QueryBlock(gomock.Any()).Return(currentBlockRes, nil).AnyTimes()
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any()).Return(false, false, nil).AnyTimes()
+	mockClientController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_, _, _, _, _ interface{}) (*types.TxResponse, error) {
+			crashed.Store(true)
+			panic("unexpected error")
+		}).Times(1)
+
+	err := vm.StartFinalityProvider(fpPk, passphrase)
+	require.NoError(t, err)
+
+	// the instance failing to restart is dropped once the queries of the
+	// activation height are given up
+	require.Eventually(t, func() bool {
+		return failedQueries.Load() >= int32(service.RtyAttNum)
+	}, 30*time.Second, eventuallyPollTime)
+	require.Eventually(t, func() bool {
+		_, err := vm.GetFinalityProviderInstance(fpPk)
+		return errors.Is(err, service.ErrFinalityProviderNotRunning)
+	}, eventuallyWaitTimeOut, eventuallyPollTime)
+	require.False(t, vm.IsFinalityProviderRunning(fpPk))
+}
+
+func TestAlertCriticalErrorPolicy(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+
+	ctl := gomock.NewController(t)
+	mockClientController := mocks.NewMockClientController(ctl)
+	vm, fpPk, cleanUp := newFinalityProviderManagerWithRegisteredFp(t, r, mockClientController, func(cfg *fpcfg.Config) {
+		cfg.RandomnessCommitInterval = 10 * time.Millisecond
+		cfg.NumPubRand = testutil.TestPubRandNum
+		cfg.SubmissionRetryInterval = 10 * time.Millisecond
+		cfg.MaxSubmissionRetries = 0
+		cfg.CriticalErrorConfig.Other = fpcfg.CriticalErrorPolicyAlert
+	})
+	defer cleanUp()
+
+	currentBlockRes := &types.BlockInfo{
+		Height: uint64(r.Int63n(100) + 1),
+		Hash:   datagen.GenRandomByteArray(r, 32),
+	}
+	mockClientController.EXPECT().QueryBestBlock().Return(currentBlockRes, nil).AnyTimes()
+	mockClientController.EXPECT().Close().Return(nil).AnyTimes()
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryActivatedHeight().Return(uint64(1), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityActivationBlockHeight().Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryBlock(gomock.Any()).Return(currentBlockRes, nil).AnyTimes()
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any()).Return(false, false, nil).AnyTimes()
+
+	// each failed commitment is reported as a critical error
+	var failures atomic.Int32
+	mockClientController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_, _, _, _, _ interface{}) (*types.TxResponse, error) {
+			failures.Add(1)
+			return nil, errors.New("insufficient fees")
+		}).AnyTimes()
+
+	err := vm.StartFinalityProvider(fpPk, passphrase)
+	require.NoError(t, err)
+	fpIns, err := vm.GetFinalityProviderInstance(fpPk)
+	require.NoError(t, err)
+
+	// the instance keeps running and committing instead of being restarted
+	require.Eventually(t, func() bool {
+		return failures.Load() >= 3
+	}, eventuallyWaitTimeOut, eventuallyPollTime)
+	require.True(t, fpIns.IsRunning())
+	currentIns, err := vm.GetFinalityProviderInstance(fpPk)
+	require.NoError(t, err)
+	require.Same(t, fpIns, currentIns)
+}
+
+func TestEquivocationWatcher(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+
+	ctl := gomock.NewController(t)
+	mockClientController := mocks.NewMockClientController(ctl)
+	vm, fpPk, cleanUp := newFinalityProviderManagerWithRegisteredFp(t, r, mockClientController, func(cfg *fpcfg.Config) {
+		cfg.EquivocationWatcherConfig.Enabled = true
+		cfg.EquivocationWatcherConfig.PollInterval = 10 * time.Millisecond
+		cfg.EquivocationWatcherConfig.SubmitSlashingEvidence = true
+	})
+	defer cleanUp()
+
+	currentBlockRes := &types.BlockInfo{
+		Height: uint64(r.Int63n(100) + 1),
+		Hash:   datagen.GenRandomByteArray(r, 32),
+	}
+	mockClientController.EXPECT().QueryBestBlock().Return(currentBlockRes, nil).AnyTimes()
+	mockClientController.EXPECT().Close().Return(nil).AnyTimes()
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryActivatedHeight().Return(uint64(1), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityActivationBlockHeight().Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryBlock(gomock.Any()).Return(currentBlockRes, nil).AnyTimes()
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&types.TxResponse{TxHash: ""}, nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any()).Return(false, false, nil).AnyTimes()
+
+	// an evidence against another finality provider and one against the
+	// managed finality provider, whose key is not used by the test
+	otherSk, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	otherEvidence, err := datagen.GenRandomEvidence(r, otherSk, currentBlockRes.Height)
+	require.NoError(t, err)
+	ownEvidence := &finalitytypes.Evidence{FpBtcPk: fpPk, BlockHeight: currentBlockRes.Height}
+	mockClientController.EXPECT().QueryEvidences(gomock.Any()).
+		Return([]*finalitytypes.Evidence{otherEvidence, ownEvidence}, nil).AnyTimes()
+
+	// only the other finality provider is slashed, and only once
+	submitted := make(chan struct{})
+	mockClientController.EXPECT().SubmitSelectiveSlashingEvidence(gomock.Any()).
+		DoAndReturn(func(sk *btcec.PrivateKey) (*types.TxResponse, error) {
+			// the extracted key might be the negation of the other key,
+			// which has the same x-only public key
+			require.Equal(t, schnorr.SerializePubKey(otherSk.PubKey()), schnorr.SerializePubKey(sk.PubKey()))
+			close(submitted)
+			return &types.TxResponse{TxHash: "hash"}, nil
+		}).Times(1)
+
+	err = vm.StartFinalityProvider(fpPk, passphrase)
+	require.NoError(t, err)
+
+	select {
+	case <-submitted:
+	case <-time.After(eventuallyWaitTimeOut):
+		t.Fatal("the slashing evidence is not submitted")
+	}
+
+	// the next scans do not submit the evidence again
+	time.Sleep(100 * time.Millisecond)
+}
+
+func TestSelfCompromiseDetection(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+
+	ctl := gomock.NewController(t)
+	mockClientController := mocks.NewMockClientController(ctl)
+	vm, fpPk, cleanUp := newFinalityProviderManagerWithRegisteredFp(t, r, mockClientController, func(cfg *fpcfg.Config) {
+		cfg.SelfCompromiseConfig.Enabled = true
+		cfg.SelfCompromiseConfig.CheckInterval = 10 * time.Millisecond
+		cfg.SelfCompromiseConfig.HaltSigning = true
+	})
+	defer cleanUp()
+
+	currentBlockRes := &types.BlockInfo{
+		Height: uint64(r.Int63n(100) + 20),
+		Hash:   datagen.GenRandomByteArray(r, 32),
+	}
+	mockClientController.EXPECT().QueryBestBlock().Return(currentBlockRes, nil).AnyTimes()
+	mockClientController.EXPECT().Close().Return(nil).AnyTimes()
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryActivatedHeight().Return(uint64(1), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityActivationBlockHeight().Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryBlock(gomock.Any()).Return(currentBlockRes, nil).AnyTimes()
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&types.TxResponse{TxHash: ""}, nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any()).Return(false, false, nil).AnyTimes()
+
+	// the finality provider has a vote on-chain that it has not signed
+	unknownVoteHeight := currentBlockRes.Height - 10
+	mockClientController.EXPECT().QueryVotesAtHeight(gomock.Any()).DoAndReturn(func(height uint64) ([]bbntypes.BIP340PubKey, error) {
+		if height == unknownVoteHeight {
+			return []bbntypes.BIP340PubKey{*fpPk}, nil
+		}
+		return nil, nil
+	}).AnyTimes()
+
+	err := vm.StartFinalityProvider(fpPk, passphrase)
+	require.NoError(t, err)
+	fpIns, err := vm.GetFinalityProviderInstance(fpPk)
+	require.NoError(t, err)
+
+	// the voting of the compromised finality provider is halted
+	require.Eventually(t, fpIns.IsPaused, eventuallyWaitTimeOut, eventuallyPollTime)
+	require.True(t, fpIns.IsRunning())
+}
+
+func TestDelegationMonitor(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+
+	ctl := gomock.NewController(t)
+	mockClientController := mocks.NewMockClientController(ctl)
+	vm, fpPk, cleanUp := newFinalityProviderManagerWithRegisteredFp(t, r, mockClientController, func(cfg *fpcfg.Config) {
+		cfg.DelegationMonitorConfig.Enabled = true
+		cfg.DelegationMonitorConfig.Interval = 10 * time.Millisecond
+	})
+	defer cleanUp()
+
+	currentBlockRes := &types.BlockInfo{
+		Height: uint64(r.Int63n(100) + 1),
+		Hash:   datagen.GenRandomByteArray(r, 32),
+	}
+	mockClientController.EXPECT().QueryBestBlock().Return(currentBlockRes, nil).AnyTimes()
+	mockClientController.EXPECT().Close().Return(nil).AnyTimes()
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryActivatedHeight().Return(uint64(1), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityActivationBlockHeight().Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryBlock(gomock.Any()).Return(currentBlockRes, nil).AnyTimes()
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&types.TxResponse{TxHash: ""}, nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any()).Return(false, false, nil).AnyTimes()
+
+	summary := &types.DelegationSummary{
+		NumActive:   3,
+		ActiveSat:   uint64(r.Int63n(1e8) + 1),
+		NumPending:  1,
+		PendingSat:  uint64(r.Int63n(1e8) + 1),
+		NumUnbonded: 2,
+		UnbondedSat: uint64(r.Int63n(1e8) + 1),
+	}
+	mockClientController.EXPECT().QueryFinalityProviderDelegations(gomock.Any()).Return(summary, nil).AnyTimes()
+
+	// the delegations are not known until they are queried
+	info, err := vm.FinalityProviderInfo(fpPk)
+	require.NoError(t, err)
+	require.Nil(t, info.Delegations)
+
+	err = vm.StartFinalityProvider(fpPk, passphrase)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		info, err := vm.FinalityProviderInfo(fpPk)
+		return err == nil && info.Delegations != nil
+	}, eventuallyWaitTimeOut, eventuallyPollTime)
+
+	info, err = vm.FinalityProviderInfo(fpPk)
+	require.NoError(t, err)
+	require.Equal(t, summary.NumActive, info.Delegations.NumActive)
+	require.Equal(t, summary.ActiveSat, info.Delegations.ActiveSat)
+	require.Equal(t, summary.NumPending, info.Delegations.NumPending)
+	require.Equal(t, summary.PendingSat, info.Delegations.PendingSat)
+	require.Equal(t, summary.NumUnbonded, info.Delegations.NumUnbonded)
+	require.Equal(t, summary.UnbondedSat, info.Delegations.UnbondedSat)
+	require.NotZero(t, info.Delegations.UpdatedAt)
+}
+
+func TestFinalityLagAlert(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+
+	// the webhook records the types of the received events
+	var numLagging atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var event notifier.Event
+		if err := json.NewDecoder(req.Body).Decode(&event); err == nil && event.Type == notifier.EventFinalizationLagging {
+			numLagging.Add(1)
+		}
+	}))
+	defer srv.Close()
+
+	ctl := gomock.NewController(t)
+	mockClientController := mocks.NewMockClientController(ctl)
+	vm, fpPk, cleanUp := newFinalityProviderManagerWithRegisteredFp(t, r, mockClientController, func(cfg *fpcfg.Config) {
+		cfg.NotifierConfig.WebhookURL = srv.URL
+		cfg.FinalityLagConfig.CheckInterval = 10 * time.Millisecond
+		cfg.FinalityLagConfig.MaxFinalizationLag = 5
+		cfg.FinalityLagConfig.SustainedFor = 50 * time.Millisecond
+	})
+	defer cleanUp()
+
+	// the last finalized height stays far behind the tip
+	currentBlockRes := &types.BlockInfo{
+		Height: uint64(r.Int63n(100) + 20),
+		Hash:   datagen.GenRandomByteArray(r, 32),
+	}
+	finalizedBlockRes := &types.BlockInfo{Height: 1, Hash: datagen.GenRandomByteArray(r, 32), Finalized: true}
+	mockClientController.EXPECT().QueryBestBlock().Return(currentBlockRes, nil).AnyTimes()
+	mockClientController.EXPECT().Close().Return(nil).AnyTimes()
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return([]*types.BlockInfo{finalizedBlockRes}, nil).AnyTimes()
+	mockClientController.EXPECT().QueryActivatedHeight().Return(uint64(1), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityActivationBlockHeight().Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryBlock(gomock.Any()).Return(currentBlockRes, nil).AnyTimes()
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&types.TxResponse{TxHash: ""}, nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any()).Return(false, false, nil).AnyTimes()
+
+	err := vm.StartFinalityProvider(fpPk, passphrase)
+	require.NoError(t, err)
+
+	// the notifiers are alerted once per lagging period
+	require.Eventually(t, func() bool {
+		return numLagging.Load() > 0
+	}, eventuallyWaitTimeOut, eventuallyPollTime)
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, int32(1), numLagging.Load())
+}
+
+func TestFeeBalanceAlert(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+
+	// the webhook records the types of the received events
+	var numLowBalance atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var event notifier.Event
+		if err := json.NewDecoder(req.Body).Decode(&event); err == nil && event.Type == notifier.EventLowBalance {
+			numLowBalance.Add(1)
+		}
+	}))
+	defer srv.Close()
+
+	ctl := gomock.NewController(t)
+	mockClientController := mocks.NewMockClientController(ctl)
+	vm, fpPk, cleanUp := newFinalityProviderManagerWithRegisteredFp(t, r, mockClientController, func(cfg *fpcfg.Config) {
+		cfg.NotifierConfig.WebhookURL = srv.URL
+		cfg.FeeBalanceConfig.PollInterval = 10 * time.Millisecond
+		cfg.FeeBalanceConfig.MinBalance = "1000ubbn"
+	})
+	defer cleanUp()
+
+	currentBlockRes := &types.BlockInfo{
+		Height: uint64(r.Int63n(100) + 1),
+		Hash:   datagen.GenRandomByteArray(r, 32),
+	}
+	mockClientController.EXPECT().QueryBestBlock().Return(currentBlockRes, nil).AnyTimes()
+	mockClientController.EXPECT().Close().Return(nil).AnyTimes()
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryActivatedHeight().Return(uint64(1), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityActivationBlockHeight().Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryBlock(gomock.Any()).Return(currentBlockRes, nil).AnyTimes()
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&types.TxResponse{TxHash: ""}, nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any()).Return(false, false, nil).AnyTimes()
+
+	// the balance of the signer stays below the min
+	balances := map[string]sdk.Coins{
+		datagen.GenRandomAccount().Address: sdk.NewCoins(sdk.NewInt64Coin("ubbn", 10)),
+	}
+	mockClientController.EXPECT().QueryFeeAccountBalances().Return(balances, nil).AnyTimes()
+
+	err := vm.StartFinalityProvider(fpPk, passphrase)
+	require.NoError(t, err)
+
+	// the notifiers are alerted once until the balance recovers
+	require.Eventually(t, func() bool {
+		return numLowBalance.Load() > 0
+	}, eventuallyWaitTimeOut, eventuallyPollTime)
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, int32(1), numLowBalance.Load())
+}
+
+func newFinalityProviderManagerWithRegisteredFp(t *testing.T, r *rand.Rand, cc clientcontroller.ClientController, cfgOpts ...func(cfg *fpcfg.Config)) (*service.FinalityProviderManager, *bbntypes.BIP340PubKey, func()) {
+	vm, fpPks, cleanUp := newFinalityProviderManagerWithRegisteredFps(t, r, cc, 1, cfgOpts...)
+
+	return vm, fpPks[0], cleanUp
+}
+
+func newFinalityProviderManagerWithRegisteredFps(
+	t *testing.T,
+	r *rand.Rand,
+	cc clientcontroller.ClientController,
+	numFps int,
+	cfgOpts ...func(cfg *fpcfg.Config),
+) (*service.FinalityProviderManager, []*bbntypes.BIP340PubKey, func()) {
 	logger := zap.NewNop()
 	// create an EOTS manager
 	eotsHomeDir := filepath.Join(t.TempDir(), "eots-home")
@@ -120,6 +772,9 @@ func newFinalityProviderManagerWithRegisteredFp(t *testing.T, r *rand.Rand, cc c
 	fpHomeDir := filepath.Join(t.TempDir(), "fp-home")
 	fpCfg := fpcfg.DefaultConfigWithHome(fpHomeDir)
 	fpCfg.StatusUpdateInterval = 10 * time.Millisecond
+	for _, opt := range cfgOpts {
+		opt(&fpCfg)
+	}
 	input := strings.NewReader("")
 	kr, err := keyring.CreateKeyring(
 		fpCfg.BabylonConfig.KeyDirectory,
@@ -136,41 +791,54 @@ func newFinalityProviderManagerWithRegisteredFp(t *testing.T, r *rand.Rand, cc c
 	require.NoError(t, err)
 	pubRandStore, err := fpstore.NewPubRandProofStore(db)
 	require.NoError(t, err)
-
-	metricsCollectors := metrics.NewFpMetrics()
-	vm, err := service.NewFinalityProviderManager(fpStore, pubRandStore, &fpCfg, cc, em, metricsCollectors, logger)
-	require.NoError(t, err)
-
-	// create registered finality-provider
-	keyName := datagen.GenRandomHexStr(r, 10)
-	chainID := datagen.GenRandomHexStr(r, 10)
-	kc, err := keyring.NewChainKeyringControllerWithKeyring(kr, keyName, input)
-	require.NoError(t, err)
-	btcPkBytes, err := em.CreateKey(keyName, passphrase, hdPath)
-	require.NoError(t, err)
-	btcPk, err := bbntypes.NewBIP340PubKey(btcPkBytes)
+	signRecordStore, err := fpstore.NewSignRecordStore(db)
 	require.NoError(t, err)
-	keyInfo, err := kc.CreateChainKey(passphrase, hdPath, "")
+	voteRetryStore, err := fpstore.NewVoteRetryStore(db)
 	require.NoError(t, err)
-	fpAddr := keyInfo.AccAddress
-	fpRecord, err := em.KeyRecord(btcPk.MustMarshal(), passphrase)
+	outboxStore, err := fpstore.NewOutboxStore(db)
 	require.NoError(t, err)
-	pop, err := kc.CreatePop(fpAddr, fpRecord.PrivKey)
+	historyStore, err := fpstore.NewSubmissionHistoryStore(db)
 	require.NoError(t, err)
 
-	err = fpStore.CreateFinalityProvider(
-		fpAddr,
-		btcPk.MustToBTCPK(),
-		testutil.RandomDescription(r),
-		testutil.ZeroCommissionRate(),
-		keyName,
-		chainID,
-		pop.BtcSig,
-	)
-	require.NoError(t, err)
-	err = fpStore.SetFpStatus(btcPk.MustToBTCPK(), proto.FinalityProviderStatus_REGISTERED)
+	metricsCollectors := metrics.NewFpMetrics()
+	vm, err := service.NewFinalityProviderManager(context.Background(), fpStore, pubRandStore, signRecordStore, voteRetryStore, outboxStore, historyStore, &fpCfg, cc, em, metricsCollectors, logger)
 	require.NoError(t, err)
 
+	// create registered finality-providers
+	fpPks := make([]*bbntypes.BIP340PubKey, 0, numFps)
+	for i := 0; i < numFps; i++ {
+		keyName := datagen.GenRandomHexStr(r, 10)
+		chainID := datagen.GenRandomHexStr(r, 10)
+		kc, err := keyring.NewChainKeyringControllerWithKeyring(kr, keyName, input)
+		require.NoError(t, err)
+		btcPkBytes, err := em.CreateKey(keyName, passphrase, hdPath)
+		require.NoError(t, err)
+		btcPk, err := bbntypes.NewBIP340PubKey(btcPkBytes)
+		require.NoError(t, err)
+		keyInfo, err := kc.CreateChainKey(passphrase, hdPath, "")
+		require.NoError(t, err)
+		fpAddr := keyInfo.AccAddress
+		fpRecord, err := em.KeyRecord(btcPk.MustMarshal(), passphrase)
+		require.NoError(t, err)
+		pop, err := kc.CreatePop(fpAddr, fpRecord.PrivKey)
+		require.NoError(t, err)
+
+		err = fpStore.CreateFinalityProvider(
+			fpAddr,
+			btcPk.MustToBTCPK(),
+			testutil.RandomDescription(r),
+			testutil.ZeroCommissionRate(),
+			keyName,
+			chainID,
+			pop.BtcSig,
+		)
+		require.NoError(t, err)
+		err = fpStore.SetFpStatus(btcPk.MustToBTCPK(), proto.FinalityProviderStatus_REGISTERED)
+		require.NoError(t, err)
+
+		fpPks = append(fpPks, btcPk)
+	}
+
 	cleanUp := func() {
 		err = vm.Stop()
 		require.NoError(t, err)
@@ -184,5 +852,5 @@ func newFinalityProviderManagerWithRegisteredFp(t *testing.T, r *rand.Rand, cc c
 		require.NoError(t, err)
 	}
 
-	return vm, btcPk, cleanUp
+	return vm, fpPks, cleanUp
 }
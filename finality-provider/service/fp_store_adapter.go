@@ -11,6 +11,7 @@ import (
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"go.uber.org/zap"
 
+	"github.com/babylonlabs-io/finality-provider/finality-provider/eventbus"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
 )
@@ -87,11 +88,22 @@ func (fps *fpState) setStatus(s proto.FinalityProviderStatus) error {
 	return fps.s.SetFpStatus(fps.fp.BtcPk, s)
 }
 
-func (fps *fpState) setLastVotedHeight(height uint64) error {
+// updateState updates the state in the store within a single transaction
+// and mirrors the result in memory only if it succeeds
+func (fps *fpState) updateState(update *store.FinalityProviderStateUpdate) error {
 	fps.mu.Lock()
-	fps.fp.LastVotedHeight = height
-	fps.mu.Unlock()
-	return fps.s.SetFpLastVotedHeight(fps.fp.BtcPk, height)
+	defer fps.mu.Unlock()
+
+	updatedFp, err := fps.s.UpdateFpState(fps.fp.BtcPk, update)
+	if err != nil {
+		return err
+	}
+
+	fps.fp.Status = updatedFp.Status
+	fps.fp.LastVotedHeight = updatedFp.LastVotedHeight
+	fps.fp.LastProcessedHeight = updatedFp.LastProcessedHeight
+
+	return nil
 }
 
 func (fp *FinalityProviderInstance) GetStoreFinalityProvider() *store.StoredFinalityProvider {
@@ -118,12 +130,30 @@ func (fp *FinalityProviderInstance) GetLastVotedHeight() uint64 {
 	return fp.fpState.getStoreFinalityProvider().LastVotedHeight
 }
 
+func (fp *FinalityProviderInstance) GetLastProcessedHeight() uint64 {
+	return fp.fpState.getStoreFinalityProvider().LastProcessedHeight
+}
+
 func (fp *FinalityProviderInstance) GetChainID() []byte {
 	return []byte(fp.fpState.getStoreFinalityProvider().ChainID)
 }
 
 func (fp *FinalityProviderInstance) SetStatus(s proto.FinalityProviderStatus) error {
-	return fp.fpState.setStatus(s)
+	oldStatus := fp.GetStatus()
+	if err := fp.fpState.setStatus(s); err != nil {
+		return err
+	}
+
+	if oldStatus != s {
+		fp.events.Publish(&eventbus.Event{
+			Type:      eventbus.EventStatusChanged,
+			FpBtcPk:   fp.GetBtcPkHex(),
+			OldStatus: oldStatus.String(),
+			NewStatus: s.String(),
+		})
+	}
+
+	return nil
 }
 
 func (fp *FinalityProviderInstance) MustSetStatus(s proto.FinalityProviderStatus) {
@@ -134,7 +164,10 @@ func (fp *FinalityProviderInstance) MustSetStatus(s proto.FinalityProviderStatus
 }
 
 func (fp *FinalityProviderInstance) updateStateAfterFinalitySigSubmission(height uint64) error {
-	return fp.fpState.setLastVotedHeight(height)
+	return fp.fpState.updateState(&store.FinalityProviderStateUpdate{
+		LastVotedHeight:     height,
+		LastProcessedHeight: height,
+	})
 }
 
 func (fp *FinalityProviderInstance) MustUpdateStateAfterFinalitySigSubmission(height uint64) {
@@ -142,6 +175,30 @@ func (fp *FinalityProviderInstance) MustUpdateStateAfterFinalitySigSubmission(he
 		fp.logger.Fatal("failed to update state after finality signature submitted",
 			zap.String("pk", fp.GetBtcPkHex()), zap.Uint64("height", height))
 	}
-	fp.metrics.RecordFpLastVotedHeight(fp.GetBtcPkHex(), height)
-	fp.metrics.RecordFpLastProcessedHeight(fp.GetBtcPkHex(), height)
+	fp.recordHeightMetrics()
+}
+
+// MustUpdateLastProcessedHeight persists the given height as the last
+// processed height if it is higher than the stored one, from which the
+// poller resumes upon restart
+func (fp *FinalityProviderInstance) MustUpdateLastProcessedHeight(height uint64) {
+	if height <= fp.GetLastProcessedHeight() {
+		return
+	}
+
+	if err := fp.fpState.updateState(&store.FinalityProviderStateUpdate{
+		LastProcessedHeight: height,
+	}); err != nil {
+		fp.logger.Fatal("failed to update the last processed height",
+			zap.String("pk", fp.GetBtcPkHex()), zap.Uint64("height", height))
+	}
+	fp.recordHeightMetrics()
+}
+
+// recordHeightMetrics records the heights from the stored state
+// so that the metrics never disagree with the state
+func (fp *FinalityProviderInstance) recordHeightMetrics() {
+	storedFp := fp.GetStoreFinalityProvider()
+	fp.metrics.RecordFpLastVotedHeight(fp.GetBtcPkHex(), storedFp.LastVotedHeight)
+	fp.metrics.RecordFpLastProcessedHeight(fp.GetBtcPkHex(), storedFp.LastProcessedHeight)
 }
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+)
+
+// unavailableRetryDelay is the delay advised to the clients before retrying
+// the requests rejected as unavailable
+const unavailableRetryDelay = 5 * time.Second
+
+// grpcCodes maps the errors of the app to the gRPC status codes, the first
+// matched error of the list determines the code
+var grpcCodes = []struct {
+	err  error
+	code codes.Code
+}{
+	{ErrInvalidEotsPk, codes.InvalidArgument},
+	{ErrInvalidDescription, codes.InvalidArgument},
+	{ErrInvalidCommission, codes.InvalidArgument},
+	{ErrInvalidHeightRange, codes.InvalidArgument},
+	{ErrUnjailNotConfirmed, codes.InvalidArgument},
+	{ErrMessageNotSignable, codes.InvalidArgument},
+	{ErrInvalidLogLevel, codes.InvalidArgument},
+	{store.ErrInvalidBackupPassphrase, codes.InvalidArgument},
+	{ErrFinalityProviderAlreadyRegistered, codes.AlreadyExists},
+	{store.ErrFinalityProviderExists, codes.AlreadyExists},
+	{store.ErrFinalityProviderConflict, codes.AlreadyExists},
+	{ErrFinalityProviderNotRunning, codes.NotFound},
+	{ErrChainKeyNotFound, codes.NotFound},
+	{store.ErrFinalityProviderNotFound, codes.NotFound},
+	{store.ErrPubRandProofNotFound, codes.NotFound},
+	{store.ErrSignRecordNotFound, codes.NotFound},
+	{ErrInMaintenance, codes.FailedPrecondition},
+	{ErrNotInMaintenance, codes.FailedPrecondition},
+	{ErrFinalityProviderJailed, codes.FailedPrecondition},
+	{ErrFinalityProviderSlashed, codes.FailedPrecondition},
+	{ErrFinalityProviderStandby, codes.FailedPrecondition},
+	{ErrClockSkewTooLarge, codes.FailedPrecondition},
+	{ErrPubRandCommitmentMismatch, codes.FailedPrecondition},
+	{ErrNoWithdrawableRewards, codes.FailedPrecondition},
+	{store.ErrDoubleSign, codes.FailedPrecondition},
+	{ErrSubmissionRateLimited, codes.ResourceExhausted},
+	{ErrAppShuttingDown, codes.Unavailable},
+	{ErrFinalityProviderShutDown, codes.Unavailable},
+	{context.Canceled, codes.Canceled},
+	{context.DeadlineExceeded, codes.DeadlineExceeded},
+}
+
+// GRPCCode returns the gRPC status code reflecting the cause of the given
+// error of the app, the code of the status error itself if the cause is not
+// typed, or codes.Unknown otherwise
+func GRPCCode(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+	for _, c := range grpcCodes {
+		if errors.Is(err, c.err) {
+			return c.code
+		}
+	}
+	if isStatusError(err) {
+		return status.Code(err)
+	}
+
+	return codes.Unknown
+}
+
+// isStatusError returns whether the given error is a gRPC status error
+// itself. The wrapping errors and the errors merely implementing GRPCStatus,
+// e.g., the Cosmos SDK ones, are not, as their code does not reflect the
+// cause known to the app
+func isStatusError(err error) bool {
+	return reflect.TypeOf(err) == statusErrorType
+}
+
+var statusErrorType = reflect.TypeOf(status.Error(codes.Unknown, ""))
+
+// ToGRPCError converts the given error of the app to a gRPC status error
+// with the code of its cause and the same message, so that the clients of
+// the daemon can branch on status.Code
+func ToGRPCError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if isStatusError(err) {
+		return err
+	}
+
+	code := GRPCCode(err)
+	st := status.New(code, err.Error())
+	if code == codes.Unavailable {
+		// the clients should retry against the restarted daemon rather
+		// than hammer the stopping one
+		if withRetry, detailsErr := st.WithDetails(&errdetails.RetryInfo{
+			RetryDelay: durationpb.New(unavailableRetryDelay),
+		}); detailsErr == nil {
+			st = withRetry
+		}
+	}
+
+	return st.Err()
+}
+
+// grpcErrorInterceptor converts the errors returned by the gRPC handlers
+// to gRPC status errors
+func grpcErrorInterceptor(
+	ctx context.Context,
+	req interface{},
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	res, err := handler(ctx, req)
+
+	return res, ToGRPCError(err)
+}
+
+// grpcErrorStreamInterceptor converts the errors returned by the gRPC
+// stream handlers to gRPC status errors
+func grpcErrorStreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	_ *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	return ToGRPCError(handler(srv, ss))
+}
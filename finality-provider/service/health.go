@@ -0,0 +1,204 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// HealthStatus is the status of a health check
+type HealthStatus string
+
+const (
+	HealthStatusOK      HealthStatus = "ok"
+	HealthStatusFailing HealthStatus = "failing"
+	// HealthStatusWarning is the status of a check whose failure does not
+	// make the report failing
+	HealthStatusWarning HealthStatus = "warning"
+)
+
+const (
+	healthCheckBabylon     = "babylon"
+	healthCheckEOTSD       = "eotsd"
+	healthCheckDB          = "db"
+	healthCheckHeartbeat   = "heartbeat"
+	healthCheckBlockLag    = "block_lag"
+	healthCheckMaintenance = "maintenance"
+)
+
+// HealthCheckResult is the outcome of a single health check
+type HealthCheckResult struct {
+	Name   string       `json:"name"`
+	Status HealthStatus `json:"status"`
+	Error  string       `json:"error,omitempty"`
+	// Hint suggests a remediation of a failing or warning check
+	Hint string `json:"hint,omitempty"`
+}
+
+// HealthReport aggregates the health checks, its status is failing if
+// any of the checks is failing
+type HealthReport struct {
+	Status HealthStatus         `json:"status"`
+	Checks []*HealthCheckResult `json:"checks"`
+}
+
+// Healthy returns whether all the checks of the report pass
+func (r *HealthReport) Healthy() bool {
+	return r.Status == HealthStatusOK
+}
+
+func (r *HealthReport) add(name string, err error) {
+	res := &HealthCheckResult{Name: name, Status: HealthStatusOK}
+	if err != nil {
+		res.Status = HealthStatusFailing
+		res.Error = err.Error()
+		r.Status = HealthStatusFailing
+	}
+	r.Checks = append(r.Checks, res)
+}
+
+func (r *HealthReport) warn(name string, err error) {
+	res := &HealthCheckResult{Name: name, Status: HealthStatusOK}
+	if err != nil {
+		res.Status = HealthStatusWarning
+		res.Error = err.Error()
+	}
+	r.Checks = append(r.Checks, res)
+}
+
+// eotsPinger is implemented by the remote EOTS manager client
+type eotsPinger interface {
+	Ping() error
+}
+
+// Liveness checks that the signature submission loops of the running
+// finality provider instances are not stuck
+func (app *FinalityProviderApp) Liveness() *HealthReport {
+	report := &HealthReport{Status: HealthStatusOK}
+	report.add(healthCheckHeartbeat, app.checkHeartbeats())
+
+	return report
+}
+
+// Readiness checks the reachability of Babylon and the EOTS manager, the
+// writability of the db, the heartbeats of the running finality provider
+// instances and their lag behind the tip of Babylon. The db and the lag are
+// not checked in the maintenance mode
+func (app *FinalityProviderApp) Readiness() *HealthReport {
+	timeout := app.config().HealthConfig.CheckTimeout
+	report := &HealthReport{Status: HealthStatusOK}
+
+	// the tip is sent through a channel as the query might outlive the check
+	tipChan := make(chan uint64, 1)
+	err := runWithTimeout(timeout, func() error {
+		tip, err := app.cc.QueryBestBlock()
+		if err != nil {
+			return fmt.Errorf("failed to query the best block: %w", err)
+		}
+		tipChan <- tip.Height
+		return nil
+	})
+	report.add(healthCheckBabylon, err)
+
+	var tipHeight uint64
+	if err == nil {
+		tipHeight = <-tipChan
+	}
+
+	// the local EOTS manager is always reachable
+	if pinger, ok := app.eotsManager.(eotsPinger); ok {
+		report.add(healthCheckEOTSD, runWithTimeout(timeout, pinger.Ping))
+	}
+
+	// the db is not written and the instances do not pull the blocks during
+	// the maintenance
+	if app.IsInMaintenance() {
+		report.warn(healthCheckMaintenance, ErrInMaintenance)
+		report.add(healthCheckHeartbeat, app.checkHeartbeats())
+
+		return report
+	}
+
+	report.add(healthCheckDB, runWithTimeout(timeout, app.fps.CheckWritable))
+	report.add(healthCheckHeartbeat, app.checkHeartbeats())
+	report.add(healthCheckBlockLag, app.checkBlockLag(tipHeight))
+
+	return report
+}
+
+// checkHeartbeats fails if the signature submission loop of any running
+// finality provider instance has not iterated within MaxHeartbeatAge
+func (app *FinalityProviderApp) checkHeartbeats() error {
+	maxAge := app.config().HealthConfig.MaxHeartbeatAge
+
+	var stale []string
+	for _, fpi := range app.fpManager.listFinalityProviderInstances() {
+		if !fpi.IsRunning() {
+			continue
+		}
+		if age := time.Since(fpi.LastHeartbeat()); age > maxAge {
+			stale = append(stale, fmt.Sprintf("%s (%s ago)", fpi.GetBtcPkHex(), age.Truncate(time.Second)))
+		}
+	}
+
+	if len(stale) > 0 {
+		return fmt.Errorf("stale finality provider loops: %s", strings.Join(stale, ", "))
+	}
+
+	return nil
+}
+
+// checkBlockLag fails if any running finality provider instance is more
+// than MaxBlockLag blocks behind the given tip height. The instances which
+// have not received any block yet, e.g., before the finality activation,
+// are not checked
+func (app *FinalityProviderApp) checkBlockLag(tipHeight uint64) error {
+	maxLag := app.config().HealthConfig.MaxBlockLag
+	if maxLag == 0 {
+		return nil
+	}
+	if tipHeight == 0 {
+		return errors.New("the tip height is unknown")
+	}
+
+	var lagging []string
+	for _, fpi := range app.fpManager.listFinalityProviderInstances() {
+		if !fpi.IsRunning() {
+			continue
+		}
+		height := fpi.LastReceivedHeight()
+		if height == 0 || height >= tipHeight {
+			continue
+		}
+		if lag := tipHeight - height; lag > maxLag {
+			lagging = append(lagging, fmt.Sprintf("%s (%d blocks)", fpi.GetBtcPkHex(), lag))
+		}
+	}
+
+	if len(lagging) > 0 {
+		return fmt.Errorf("finality providers behind the tip: %s", strings.Join(lagging, ", "))
+	}
+
+	return nil
+}
+
+// runWithTimeout runs f and returns its error, or an error if it does not
+// complete within the timeout. f keeps running in the background after
+// the timeout
+func runWithTimeout(timeout time.Duration, f func() error) error {
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- f()
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-timer.C:
+		return fmt.Errorf("timed out after %v", timeout)
+	}
+}
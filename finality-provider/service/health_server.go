@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+)
+
+// healthServer serves the liveness and readiness endpoints over HTTP and
+// keeps the status of the gRPC health service up to date
+type healthServer struct {
+	app    *FinalityProviderApp
+	cfg    *fpcfg.HealthConfig
+	logger *zap.Logger
+
+	httpServer *http.Server
+	grpcHealth *health.Server
+
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+func newHealthServer(app *FinalityProviderApp, cfg *fpcfg.HealthConfig, logger *zap.Logger) *healthServer {
+	hs := &healthServer{
+		app:        app,
+		cfg:        cfg,
+		logger:     logger,
+		grpcHealth: health.NewServer(),
+		quit:       make(chan struct{}),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/livez", hs.handleReport(app.Liveness))
+	mux.HandleFunc("/readyz", hs.handleReport(app.Readiness))
+	mux.HandleFunc("/vote-timings", hs.handleVoteTimings)
+
+	hs.httpServer = &http.Server{
+		Addr:              cfg.ListenAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: 2 * time.Second,
+		ReadTimeout:       5 * time.Second,
+		WriteTimeout:      cfg.CheckTimeout + 5*time.Second,
+		IdleTimeout:       30 * time.Second,
+	}
+
+	return hs
+}
+
+// RegisterWithGrpcServer registers the gRPC health service, which reports
+// NOT_SERVING until the first readiness check passes
+func (hs *healthServer) RegisterWithGrpcServer(grpcServer *grpc.Server) {
+	hs.grpcHealth.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(grpcServer, hs.grpcHealth)
+}
+
+// Start listens on the configured address and starts serving the
+// endpoints and updating the gRPC health status
+func (hs *healthServer) Start() error {
+	lis, err := net.Listen("tcp", hs.cfg.ListenAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", hs.cfg.ListenAddr, err)
+	}
+
+	hs.wg.Add(2)
+	go func() {
+		defer hs.wg.Done()
+		hs.logger.Info("Health server is starting", zap.String("addr", hs.cfg.ListenAddr))
+		if err := hs.httpServer.Serve(lis); err != nil && err != http.ErrServerClosed {
+			hs.logger.Error("Health server failed", zap.Error(err))
+		}
+	}()
+	go hs.grpcStatusUpdateLoop()
+
+	return nil
+}
+
+// Stop shuts down the endpoints and marks the gRPC health service as
+// not serving
+func (hs *healthServer) Stop(ctx context.Context) {
+	close(hs.quit)
+	hs.grpcHealth.Shutdown()
+	if err := hs.httpServer.Shutdown(ctx); err != nil {
+		hs.logger.Error("Health server shutdown failed", zap.Error(err))
+	}
+	hs.wg.Wait()
+}
+
+// grpcStatusUpdateLoop periodically runs the readiness checks and sets the
+// status of the gRPC health service accordingly
+func (hs *healthServer) grpcStatusUpdateLoop() {
+	defer hs.wg.Done()
+
+	ticker := time.NewTicker(hs.cfg.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		hs.updateGrpcStatus()
+
+		select {
+		case <-ticker.C:
+		case <-hs.quit:
+			return
+		}
+	}
+}
+
+func (hs *healthServer) updateGrpcStatus() {
+	status := healthpb.HealthCheckResponse_SERVING
+	if report := hs.app.Readiness(); !report.Healthy() {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+		hs.logger.Debug("the readiness check is failing", zap.Any("checks", report.Checks))
+	}
+
+	hs.grpcHealth.SetServingStatus("", status)
+}
+
+// handleReport writes the report as JSON, with the status code 503 if any
+// of the checks is failing
+func (hs *healthServer) handleReport(check func() *HealthReport) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		report := check()
+
+		w.Header().Set("Content-Type", "application/json")
+		if report.Healthy() {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+
+		if err := json.NewEncoder(w).Encode(report); err != nil {
+			hs.logger.Debug("failed to write the health report", zap.Error(err))
+		}
+	}
+}
+
+// handleVoteTimings writes the timings of the latest votes of the running
+// finality provider instances as JSON
+func (hs *healthServer) handleVoteTimings(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(hs.app.RecentVoteTimings()); err != nil {
+		hs.logger.Debug("failed to write the vote timings", zap.Error(err))
+	}
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// SubmissionKind is the kind of a submission passed to the hooks
+type SubmissionKind string
+
+const (
+	SubmissionKindVote          SubmissionKind = submissionVote
+	SubmissionKindPubRandCommit SubmissionKind = submissionPubRandCommit
+)
+
+// Submission describes a transaction which is about to be broadcast
+type Submission struct {
+	Kind    SubmissionKind
+	FpBtcPk *bbntypes.BIP340PubKey
+	// Blocks are the voted blocks, only set for the votes
+	Blocks []*types.BlockInfo
+	// StartHeight and NumPubRand describe the committed public randomness,
+	// only set for the public randomness commits
+	StartHeight uint64
+	NumPubRand  uint64
+}
+
+// SubmissionHook intercepts the signing and the submission pipeline of the
+// finality providers, e.g., to enforce custom policies, to wait for external
+// approvals or to log extra information. An error returned by a Before hook
+// aborts the vote or the commit, which is retried as any failed one. The
+// hooks are called synchronously by the loops of the instances so they
+// should return promptly, and concurrently for different finality providers
+type SubmissionHook interface {
+	// BeforeSign is called before the blocks are signed by the EOTS manager
+	BeforeSign(ctx context.Context, fpPk *bbntypes.BIP340PubKey, blocks []*types.BlockInfo) error
+	// AfterSign is called with the EOTS signatures of the blocks before they
+	// are submitted
+	AfterSign(ctx context.Context, fpPk *bbntypes.BIP340PubKey, blocks []*types.BlockInfo, sigs []*btcec.ModNScalar) error
+	// BeforeBroadcast is called before the submission is broadcast
+	BeforeBroadcast(ctx context.Context, sub *Submission) error
+	// AfterBroadcast is called with the outcome of the broadcast
+	AfterBroadcast(ctx context.Context, sub *Submission, res *types.TxResponse, err error)
+}
+
+// NoopSubmissionHook implements SubmissionHook without intercepting
+// anything, it is to be embedded by the hooks which only implement some of
+// the methods
+type NoopSubmissionHook struct{}
+
+func (NoopSubmissionHook) BeforeSign(context.Context, *bbntypes.BIP340PubKey, []*types.BlockInfo) error {
+	return nil
+}
+
+func (NoopSubmissionHook) AfterSign(context.Context, *bbntypes.BIP340PubKey, []*types.BlockInfo, []*btcec.ModNScalar) error {
+	return nil
+}
+
+func (NoopSubmissionHook) BeforeBroadcast(context.Context, *Submission) error {
+	return nil
+}
+
+func (NoopSubmissionHook) AfterBroadcast(context.Context, *Submission, *types.TxResponse, error) {}
+
+// submissionHooks are the hooks registered to the manager, which are shared
+// by its instances and called in order of registration
+type submissionHooks struct {
+	mu    sync.RWMutex
+	hooks []SubmissionHook
+}
+
+func newSubmissionHooks() *submissionHooks {
+	return &submissionHooks{}
+}
+
+func (h *submissionHooks) register(hook SubmissionHook) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.hooks = append(h.hooks, hook)
+}
+
+// list returns the registered hooks, nil if the hooks are nil
+func (h *submissionHooks) list() []SubmissionHook {
+	if h == nil {
+		return nil
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.hooks
+}
+
+func (h *submissionHooks) beforeSign(ctx context.Context, fpPk *bbntypes.BIP340PubKey, blocks []*types.BlockInfo) error {
+	for _, hook := range h.list() {
+		if err := hook.BeforeSign(ctx, fpPk, blocks); err != nil {
+			return fmt.Errorf("%w: %w", ErrRejectedByHook, err)
+		}
+	}
+
+	return nil
+}
+
+func (h *submissionHooks) afterSign(ctx context.Context, fpPk *bbntypes.BIP340PubKey, blocks []*types.BlockInfo, sigs []*btcec.ModNScalar) error {
+	for _, hook := range h.list() {
+		if err := hook.AfterSign(ctx, fpPk, blocks, sigs); err != nil {
+			return fmt.Errorf("%w: %w", ErrRejectedByHook, err)
+		}
+	}
+
+	return nil
+}
+
+func (h *submissionHooks) beforeBroadcast(ctx context.Context, sub *Submission) error {
+	for _, hook := range h.list() {
+		if err := hook.BeforeBroadcast(ctx, sub); err != nil {
+			return fmt.Errorf("%w: %w", ErrRejectedByHook, err)
+		}
+	}
+
+	return nil
+}
+
+func (h *submissionHooks) afterBroadcast(ctx context.Context, sub *Submission, res *types.TxResponse, err error) {
+	for _, hook := range h.list() {
+		hook.AfterBroadcast(ctx, sub, res, err)
+	}
+}
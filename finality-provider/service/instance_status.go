@@ -0,0 +1,60 @@
+package service
+
+import (
+	"time"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+)
+
+// InstanceStatus returns the live status of the running instance of the
+// given finality provider along with the tip of the consumer chain, the
+// last height covered by its committed public randomness and the end of its
+// jailing. The consumer chain is queried with the timeout of the health
+// checks, the heights it could not return being left to 0
+func (app *FinalityProviderApp) InstanceStatus(fpPk *bbntypes.BIP340PubKey) (*proto.QueryInstanceStatusResponse, error) {
+	fpi, err := app.fpManager.GetFinalityProviderInstance(fpPk)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &proto.QueryInstanceStatusResponse{
+		Status:              fpi.GetStatus().String(),
+		IsPaused:            fpi.IsPaused(),
+		LastVotedHeight:     fpi.GetLastVotedHeight(),
+		LastProcessedHeight: fpi.GetLastProcessedHeight(),
+		Jailed:              fpi.IsJailed(),
+	}
+
+	timeout := app.config().HealthConfig.CheckTimeout
+	logger := app.logger.With(zap.String("pk", fpPk.MarshalHex()))
+
+	tip, err := queryWithTimeout(timeout, app.cc.QueryBestBlock)
+	if err != nil {
+		logger.Debug("failed to query the best block", zap.Error(err))
+	} else {
+		res.ChainTip = tip.Height
+	}
+
+	lastCommittedHeight, err := queryWithTimeout(timeout, fpi.GetLastCommittedHeight)
+	if err != nil {
+		logger.Debug("failed to query the last committed public randomness", zap.Error(err))
+	} else {
+		res.LastCommittedPubRandHeight = lastCommittedHeight
+	}
+
+	if res.Jailed {
+		jailedUntil, err := queryWithTimeout(timeout, func() (time.Time, error) {
+			return app.cc.QueryFinalityProviderJailedUntil(fpi.GetBtcPk())
+		})
+		if err != nil {
+			logger.Debug("failed to query the end of the jailing", zap.Error(err))
+		} else {
+			res.JailedUntil = jailedUntil.Unix()
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager"
+	eotstypes "github.com/babylonlabs-io/finality-provider/eotsmanager/types"
+)
+
+// haEnabled returns whether the instance takes part in a leader election
+// with the other daemons running the same finality provider
+func (fp *FinalityProviderInstance) haEnabled() bool {
+	return fp.cfg().HAConfig != nil && fp.cfg().HAConfig.Enabled
+}
+
+// IsLeader returns whether the instance is allowed to submit finality
+// signatures, which is always the case if high availability is disabled
+func (fp *FinalityProviderInstance) IsLeader() bool {
+	return fp.isLeader.Load()
+}
+
+// leaser returns the EOTS manager as a Leaser if it supports the leader
+// election
+func (fp *FinalityProviderInstance) leaser() (eotsmanager.Leaser, error) {
+	leaser, ok := fp.em.(eotsmanager.Leaser)
+	if !ok {
+		return nil, fmt.Errorf("the EOTS manager does not support leader election")
+	}
+
+	return leaser, nil
+}
+
+// leaderElectionLoop keeps acquiring the lease of the EOTS key. The instance
+// becomes the leader once it holds the lease and steps down to standby as
+// soon as it fails to renew it before expiry. A standby keeps polling the
+// chain, so it can take over once the lease of the leader expires
+func (fp *FinalityProviderInstance) leaderElectionLoop(leaser eotsmanager.Leaser) {
+	defer fp.wg.Done()
+
+	// the lease is renewed three times per ttl to tolerate transient
+	// failures of the EOTS manager
+	renewInterval := fp.cfg().HAConfig.LeaseTTL / 3
+	var leaseExpiry time.Time
+
+	renewTicker := time.NewTicker(renewInterval)
+	defer renewTicker.Stop()
+
+	for {
+		lease, err := leaser.AcquireLease(fp.btcPk.MustMarshal(), fp.cfg().HAConfig.HolderID, fp.cfg().HAConfig.LeaseTTL)
+		switch {
+		case err == nil:
+			leaseExpiry = lease.ExpiresAt
+			if !fp.isLeader.Swap(true) {
+				fp.promoted.Store(true)
+				fp.logger.Info("the finality provider instance became the leader",
+					zap.String("pk", fp.GetBtcPkHex()),
+					zap.String("holder", lease.Holder),
+					zap.Uint64("term", lease.Term),
+				)
+			}
+		case isNotLeaderErr(err) || time.Now().After(leaseExpiry):
+			if fp.isLeader.Swap(false) {
+				fp.logger.Warn("the finality provider instance stepped down to standby",
+					zap.String("pk", fp.GetBtcPkHex()),
+					zap.Error(err),
+				)
+			}
+		default:
+			fp.logger.Debug("failed to renew the lease, keep leading until it expires",
+				zap.String("pk", fp.GetBtcPkHex()),
+				zap.Time("expiry", leaseExpiry),
+				zap.Error(err),
+			)
+		}
+
+		select {
+		case <-renewTicker.C:
+		case <-fp.quit:
+			if fp.isLeader.Swap(false) {
+				// let a standby take over right away
+				if err := leaser.ReleaseLease(fp.btcPk.MustMarshal(), fp.cfg().HAConfig.HolderID); err != nil {
+					fp.logger.Debug("failed to release the lease", zap.String("pk", fp.GetBtcPkHex()), zap.Error(err))
+				}
+			}
+			fp.logger.Info("the leader election loop is closing")
+			return
+		}
+	}
+}
+
+// rewindPollerOnPromotion rewinds the poller of the new leader to the height
+// following the last one voted by the previous leader or the last finalized
+// one, whichever is higher, as the blocks received as a standby are dropped.
+// The rewind is retried on the next blocks if it fails
+func (fp *FinalityProviderInstance) rewindPollerOnPromotion() {
+	height, err := fp.promotionStartHeight()
+	if err == nil {
+		err = fp.poller.RewindToHeight(height)
+	}
+	if err != nil {
+		fp.logger.Warn("failed to rewind the poller upon the promotion to leader",
+			zap.String("pk", fp.GetBtcPkHex()),
+			zap.Error(err),
+		)
+		fp.promoted.Store(true)
+		return
+	}
+
+	// the blocks waiting to be voted are received again
+	fp.pendingBlocks = nil
+
+	fp.logger.Info("the poller is rewound upon the promotion to leader",
+		zap.String("pk", fp.GetBtcPkHex()),
+		zap.Uint64("height", height),
+	)
+}
+
+// promotionStartHeight returns
+// max(lastVotedHeight+1, lastFinalizedHeight+1), the last voted height being
+// the highest one the finality provider has voted for on chain, by any
+// daemon, among the blocks which are not finalized
+func (fp *FinalityProviderInstance) promotionStartHeight() (uint64, error) {
+	var lastFinalizedHeight uint64
+	finalizedBlocks, err := fp.latestFinalizedBlocksWithRetry(1)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the last finalized block: %w", err)
+	}
+	if len(finalizedBlocks) > 0 {
+		lastFinalizedHeight = finalizedBlocks[0].Height
+	}
+
+	tip, err := fp.cc.QueryBestBlock()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the best block: %w", err)
+	}
+
+	for height := tip.Height; height > lastFinalizedHeight; height-- {
+		voters, err := fp.cc.QueryVotesAtHeight(height)
+		if err != nil {
+			return 0, fmt.Errorf("failed to query the votes at height %d: %w", height, err)
+		}
+		if slices.ContainsFunc(voters, func(pk bbntypes.BIP340PubKey) bool { return pk.Equals(fp.btcPk) }) {
+			return max(height, fp.GetLastVotedHeight()) + 1, nil
+		}
+	}
+
+	return max(lastFinalizedHeight, fp.GetLastVotedHeight()) + 1, nil
+}
+
+// isNotLeaderErr returns whether the error is returned by the EOTS manager
+// because the lease is held by another daemon or not held by this one. The
+// EOTS manager client converts the gRPC status errors back to the lease
+// errors
+func isNotLeaderErr(err error) bool {
+	return errors.Is(err, eotstypes.ErrLeaseHeld) || errors.Is(err, eotstypes.ErrNotLeaseHolder)
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/audit"
+	"github.com/babylonlabs-io/finality-provider/log"
+)
+
+// LogLevels describes the log levels of the daemon
+type LogLevels struct {
+	// Root is the level of the modules without their own level
+	Root string
+	// Modules are the levels of the modules set apart from the root level
+	Modules map[string]string
+}
+
+// SetLogLevel sets the log level of the given module, or the root level if
+// the module is empty, e.g., to switch to the debug logs while diagnosing an
+// incident without restarting the daemon. An empty level makes the module
+// follow the root level again. The change lasts until the daemon is
+// restarted or the log level of the config is reloaded
+func (app *FinalityProviderApp) SetLogLevel(level, module string) (levels *LogLevels, err error) {
+	defer func() {
+		app.fpManager.audit.record(audit.OpSetLogLevel, map[string]string{"level": level, "module": module}, err)
+	}()
+
+	app.reloadMu.Lock()
+	defer app.reloadMu.Unlock()
+
+	if app.logLevels == nil {
+		return nil, errors.New("the log level cannot be changed at runtime")
+	}
+
+	if err := log.ValidateModule(module); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidLogLevel, err)
+	}
+
+	if level == "" {
+		if module == "" {
+			return nil, fmt.Errorf("%w: the root level should be specified", ErrInvalidLogLevel)
+		}
+		app.logLevels.ResetLevel(module)
+	} else {
+		lvl, err := log.ParseLevel(level)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidLogLevel, err)
+		}
+		app.logLevels.SetLevel(module, lvl)
+	}
+
+	levels = &LogLevels{
+		Root:    app.logLevels.Root().String(),
+		Modules: make(map[string]string),
+	}
+	for m, lvl := range app.logLevels.ModuleLevels() {
+		levels.Modules[m] = lvl.String()
+	}
+
+	app.logger.Info("set the log level",
+		zap.String("level", level),
+		zap.String("module", module),
+	)
+
+	return levels, nil
+}
@@ -0,0 +1,176 @@
+package service
+
+import "sync"
+
+// maintenanceGate freezes the chain submissions and the db writes of the
+// loops of the daemon during the maintenance mode, e.g., while the db is
+// backed up or compacted, whereas the queries keep being served
+type maintenanceGate struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+	// active is whether the maintenance mode is on
+	active bool
+	// inFlight is the number of the operations which are running
+	inFlight int
+}
+
+func newMaintenanceGate() *maintenanceGate {
+	g := &maintenanceGate{}
+	g.cond = sync.NewCond(&g.mu)
+
+	return g
+}
+
+// do runs f unless the maintenance mode is on and returns whether f is run.
+// It never blocks so that the loops keep serving their quit channels
+func (g *maintenanceGate) do(f func()) bool {
+	if g == nil {
+		f()
+		return true
+	}
+
+	if !g.acquire() {
+		return false
+	}
+	defer g.release()
+
+	f()
+
+	return true
+}
+
+// acquire registers a running operation unless the maintenance mode is on,
+// and returns whether it is registered, in which case release must be
+// called once the operation completes
+func (g *maintenanceGate) acquire() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.active {
+		return false
+	}
+	g.inFlight++
+
+	return true
+}
+
+// release unregisters an operation registered by acquire
+func (g *maintenanceGate) release() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.inFlight--
+	if g.inFlight == 0 {
+		g.cond.Broadcast()
+	}
+}
+
+// enter turns the maintenance mode on and waits for the running operations
+// to complete. It returns false if the maintenance mode is already on
+func (g *maintenanceGate) enter() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.active {
+		return false
+	}
+	g.active = true
+	for g.inFlight > 0 {
+		g.cond.Wait()
+	}
+
+	return true
+}
+
+// exit turns the maintenance mode off. It returns false if the maintenance
+// mode is not on
+func (g *maintenanceGate) exit() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.active {
+		return false
+	}
+	g.active = false
+
+	return true
+}
+
+func (g *maintenanceGate) isActive() bool {
+	if g == nil {
+		return false
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.active
+}
+
+// EnterMaintenance freezes the chain submissions and the db writes of the
+// daemon once the running ones complete. The running finality provider
+// instances keep polling the blocks, which are voted after the maintenance
+// unless finalized in the meantime
+func (fpm *FinalityProviderManager) EnterMaintenance() error {
+	fpm.logger.Info("entering the maintenance mode, waiting for the running operations to complete")
+
+	if !fpm.maintenance.enter() {
+		return ErrInMaintenance
+	}
+
+	fpm.metrics.RecordMaintenanceMode(true)
+	fpm.logger.Info("the maintenance mode is on, the chain submissions and the db writes are frozen")
+
+	return nil
+}
+
+// ExitMaintenance resumes the chain submissions and the db writes
+func (fpm *FinalityProviderManager) ExitMaintenance() error {
+	if !fpm.maintenance.exit() {
+		return ErrNotInMaintenance
+	}
+
+	fpm.metrics.RecordMaintenanceMode(false)
+	fpm.logger.Info("the maintenance mode is off, the chain submissions and the db writes are resumed")
+
+	return nil
+}
+
+// IsInMaintenance returns whether the maintenance mode is on
+func (fpm *FinalityProviderManager) IsInMaintenance() bool {
+	return fpm.maintenance.isActive()
+}
+
+// EnterMaintenance freezes the chain submissions and the db writes of the
+// daemon, e.g., to back up or compact the db without restarting it, while
+// the status queries, the metrics and the health checks keep being served
+func (app *FinalityProviderApp) EnterMaintenance() error {
+	return app.fpManager.EnterMaintenance()
+}
+
+// ExitMaintenance resumes the chain submissions and the db writes of the
+// daemon frozen by EnterMaintenance
+func (app *FinalityProviderApp) ExitMaintenance() error {
+	return app.fpManager.ExitMaintenance()
+}
+
+// IsInMaintenance returns whether the daemon is in the maintenance mode
+func (app *FinalityProviderApp) IsInMaintenance() bool {
+	return app.fpManager.IsInMaintenance()
+}
+
+// beginWrite registers a request which writes as running, so that the
+// maintenance mode is entered only once it completes. It returns
+// ErrInMaintenance if the daemon is in the maintenance mode, otherwise the
+// function to call once the request completes
+func (app *FinalityProviderApp) beginWrite() (func(), error) {
+	gate := app.fpManager.maintenance
+	if gate == nil {
+		return func() {}, nil
+	}
+	if !gate.acquire() {
+		return nil, ErrInMaintenance
+	}
+
+	return gate.release, nil
+}
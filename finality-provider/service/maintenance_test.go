@@ -0,0 +1,44 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestMaintenanceGateWaitsForWrites tests that the maintenance mode is
+// entered only once the registered writes complete and that no write is
+// registered while it is on
+func TestMaintenanceGateWaitsForWrites(t *testing.T) {
+	t.Parallel()
+
+	g := newMaintenanceGate()
+	require.True(t, g.acquire())
+
+	entered := make(chan struct{})
+	go func() {
+		defer close(entered)
+		require.True(t, g.enter())
+	}()
+
+	// the maintenance mode waits for the running write
+	select {
+	case <-entered:
+		t.Fatal("the maintenance mode is entered while a write is running")
+	case <-time.After(100 * time.Millisecond):
+	}
+	require.True(t, g.isActive())
+	require.False(t, g.acquire())
+
+	g.release()
+	select {
+	case <-entered:
+	case <-time.After(time.Second):
+		t.Fatal("the maintenance mode is not entered once the write completes")
+	}
+
+	require.True(t, g.exit())
+	require.True(t, g.acquire())
+	g.release()
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"fmt"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"go.uber.org/zap"
+)
+
+// maxManualPubRandCommits is the maximum number of commit transactions sent
+// by a manual commitment, so that a wrong target height cannot drain the
+// balance of the finality provider
+const maxManualPubRandCommits = 10
+
+// CommitPubRandUntil commits public randomness right away, without waiting
+// for the randomness commitment loop, until the committed randomness covers
+// the target height, e.g., before a planned downtime. With a zero target
+// height, a single batch is committed after the last committed height
+// regardless of the runway. It returns the hashes of the commit transactions
+// and the last committed height
+func (fp *FinalityProviderInstance) CommitPubRandUntil(targetHeight uint64) ([]string, uint64, error) {
+	if !fp.IsLeader() {
+		return nil, 0, ErrFinalityProviderStandby
+	}
+
+	fp.pubRandCommitMu.Lock()
+	defer fp.pubRandCommitMu.Unlock()
+
+	lastCommittedHeight, err := fp.GetLastCommittedHeight()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	startHeight := lastCommittedHeight + 1
+	if lastCommittedHeight == 0 {
+		// the finality provider has never committed public randomness
+		tipBlock, err := fp.getLatestBlockWithRetry()
+		if err != nil {
+			return nil, 0, err
+		}
+		startHeight = tipBlock.Height + 1
+	}
+	activationBlkHeight, err := fp.cc.QueryFinalityActivationBlockHeight()
+	if err != nil {
+		return nil, 0, err
+	}
+	startHeight = max(startHeight, activationBlkHeight)
+
+	if targetHeight == 0 || lastCommittedHeight == 0 {
+		targetHeight = max(targetHeight, startHeight)
+	}
+	if targetHeight < startHeight {
+		fp.logger.Info(
+			"the public randomness is already committed to the target height",
+			zap.String("pk", fp.GetBtcPkHex()),
+			zap.Uint64("target_height", targetHeight),
+			zap.Uint64("last_committed_height", lastCommittedHeight),
+		)
+		return nil, lastCommittedHeight, nil
+	}
+
+	numPubRand := uint64(fp.cfg().NumPubRand)
+	numCommits := (targetHeight-startHeight)/numPubRand + 1
+	if numCommits > maxManualPubRandCommits {
+		return nil, lastCommittedHeight, fmt.Errorf(
+			"%w: reaching the target height %d from %d requires %d commits, more than %d",
+			ErrInvalidHeightRange, targetHeight, startHeight, numCommits, maxManualPubRandCommits)
+	}
+
+	txHashes := make([]string, 0, numCommits)
+	for ; startHeight <= targetHeight; startHeight += numPubRand {
+		res, err := fp.commitPubRandPairs(startHeight)
+		if err != nil {
+			return txHashes, lastCommittedHeight, err
+		}
+		txHashes = append(txHashes, res.TxHash)
+		lastCommittedHeight = startHeight + numPubRand - 1
+
+		fp.logger.Info(
+			"manually committed public randomness to the consumer chain",
+			zap.String("pk", fp.GetBtcPkHex()),
+			zap.String("tx_hash", res.TxHash),
+			zap.Uint64("start_height", startHeight),
+			zap.Uint64("last_committed_height", lastCommittedHeight),
+		)
+	}
+
+	return txHashes, lastCommittedHeight, nil
+}
+
+// CommitPubRand commits the public randomness of the given running finality
+// provider until the target height without waiting for its schedule
+func (fpm *FinalityProviderManager) CommitPubRand(fpPk *bbntypes.BIP340PubKey, targetHeight uint64) ([]string, uint64, error) {
+	fpi, err := fpm.GetFinalityProviderInstance(fpPk)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return fpi.CommitPubRandUntil(targetHeight)
+}
+
+// CommitPubRand commits the public randomness of the finality provider with
+// the given EOTS public key until the target height, returning the hashes of
+// the commit transactions and the last committed height
+func (app *FinalityProviderApp) CommitPubRand(fpPk *bbntypes.BIP340PubKey, targetHeight uint64) ([]string, uint64, error) {
+	return app.fpManager.CommitPubRand(fpPk, targetHeight)
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"go.uber.org/zap"
+)
+
+const (
+	// SignedMessagePrefix is prepended to the arbitrary messages signed with
+	// the EOTS keys, so that the signatures cannot be valid for the payloads
+	// signed by the finality providers on-chain
+	SignedMessagePrefix = "Babylon Finality Provider Signed Message:\n"
+
+	// maxSignedMessageLen is the maximum length of an arbitrary message
+	maxSignedMessageLen = 1024
+
+	// the votes sign the height followed by the block hash, while the public
+	// randomness commits and the proofs of possession sign a hash
+	voteMsgLen = 8 + 32
+	hashMsgLen = 32
+)
+
+// SignedMessageHash returns the hash signed for the given arbitrary message,
+// i.e., the SHA-256 of the message prefixed with SignedMessagePrefix
+func SignedMessageHash(msg []byte) []byte {
+	hash := sha256.Sum256(append([]byte(SignedMessagePrefix), msg...))
+
+	return hash[:]
+}
+
+// checkSignableMessage refuses the empty and too long messages, and the
+// ones shaped like the payloads signed on-chain or their hex encodings, so
+// that an arbitrary message cannot be mistaken for a vote or a commit
+func checkSignableMessage(msg []byte) error {
+	switch {
+	case len(msg) == 0:
+		return fmt.Errorf("%w: the message is empty", ErrMessageNotSignable)
+	case len(msg) > maxSignedMessageLen:
+		return fmt.Errorf("%w: the message exceeds %d bytes", ErrMessageNotSignable, maxSignedMessageLen)
+	}
+
+	payload := msg
+	if decoded, err := hex.DecodeString(strings.TrimPrefix(string(msg), "0x")); err == nil {
+		payload = decoded
+	}
+	switch len(payload) {
+	case voteMsgLen:
+		return fmt.Errorf("%w: the message is shaped like a finality vote", ErrMessageNotSignable)
+	case hashMsgLen:
+		return fmt.Errorf("%w: the message is shaped like a hash signed on-chain", ErrMessageNotSignable)
+	}
+
+	return nil
+}
+
+// SignSchnorrMessage signs the arbitrary message with the EOTS key of the
+// given finality provider managed by the daemon, for the integrations
+// proving its identity off-chain. The hash of the message prefixed with
+// SignedMessagePrefix is signed, and each request is logged for the audit
+func (app *FinalityProviderApp) SignSchnorrMessage(
+	fpPk *bbntypes.BIP340PubKey,
+	msg []byte,
+	passphrase string,
+) ([]byte, *schnorr.Signature, error) {
+	msgDigest := sha256.Sum256(msg)
+	logger := app.logger.With(
+		zap.String("pk", fpPk.MarshalHex()),
+		zap.String("msg_sha256", hex.EncodeToString(msgDigest[:])),
+		zap.Int("msg_len", len(msg)),
+	)
+
+	if err := checkSignableMessage(msg); err != nil {
+		logger.Warn("refused to sign the message with the EOTS key", zap.Error(err))
+		return nil, nil, err
+	}
+
+	if _, err := app.fps.GetFinalityProvider(fpPk.MustToBTCPK()); err != nil {
+		return nil, nil, fmt.Errorf("failed to get finality provider from db: %w", err)
+	}
+
+	hash := SignedMessageHash(msg)
+	sig, err := app.eotsManager.SignSchnorrSig(fpPk.MustMarshal(), hash, passphrase)
+	if err != nil {
+		logger.Warn("failed to sign the message with the EOTS key", zap.Error(err))
+		return nil, nil, fmt.Errorf("failed to sign the message: %w", err)
+	}
+
+	logger.Info("signed the message with the EOTS key")
+
+	return hash, sig, nil
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	jsonrpcclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/metrics"
+)
+
+const (
+	nodeEndpoint      = "node"
+	referenceEndpoint = "reference"
+
+	websocketEndpoint = "/websocket"
+	// websocketPollInterval is the interval between the checks of the
+	// websocket connection, short enough to notice the brief disconnections
+	websocketPollInterval = time.Second
+)
+
+// nodeHealthEnabled returns whether the health of the configured node is
+// monitored
+func (app *FinalityProviderApp) nodeHealthEnabled() bool {
+	return app.config().NodeHealthConfig != nil && app.config().NodeHealthConfig.Enabled
+}
+
+// nodeHeightFunc returns the latest height of a node
+type nodeHeightFunc func(ctx context.Context) (uint64, error)
+
+// nodeHealthMonitor measures the latest height of the configured node
+// relative to the reference node along with the latency of their RPC, so
+// that a stalled node is told apart from a failing finality provider
+type nodeHealthMonitor struct {
+	cfg     *fpcfg.NodeHealthConfig
+	metrics *metrics.FpMetrics
+	logger  *zap.Logger
+
+	// node queries the configured node through the client controller, i.e.,
+	// as the finality providers see it
+	node nodeHeightFunc
+	// reference is nil if no reference node is configured
+	reference nodeHeightFunc
+}
+
+func newNodeHealthMonitor(
+	cfg *fpcfg.NodeHealthConfig,
+	cc clientcontroller.ClientController,
+	m *metrics.FpMetrics,
+	logger *zap.Logger,
+) (*nodeHealthMonitor, error) {
+	mon := &nodeHealthMonitor{
+		cfg:     cfg,
+		metrics: m,
+		logger:  logger,
+		node: func(context.Context) (uint64, error) {
+			block, err := cc.QueryBestBlock()
+			if err != nil {
+				return 0, err
+			}
+			return block.Height, nil
+		},
+	}
+	if cfg.ReferenceRPCAddr != "" {
+		reference, err := rpchttp.New(cfg.ReferenceRPCAddr, websocketEndpoint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the RPC client of the reference node %s: %w", cfg.ReferenceRPCAddr, err)
+		}
+		mon.reference = func(ctx context.Context) (uint64, error) {
+			res, err := reference.Status(ctx)
+			if err != nil {
+				return 0, err
+			}
+			// #nosec G115 -- the heights are not negative
+			return uint64(res.SyncInfo.LatestBlockHeight), nil
+		}
+	}
+
+	return mon, nil
+}
+
+// queryHeight returns the latest height of the given node and records the
+// latency of the request
+func (mon *nodeHealthMonitor) queryHeight(ctx context.Context, endpoint string, query nodeHeightFunc) (uint64, error) {
+	ctx, cancel := context.WithTimeout(ctx, mon.cfg.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	height, err := query(ctx)
+	mon.metrics.ObserveNodeRPC(endpoint, time.Since(start), err != nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query the latest height of the %s: %w", endpoint, err)
+	}
+	mon.metrics.RecordNodeBlockHeight(endpoint, height)
+
+	return height, nil
+}
+
+// check measures the health of the node and returns an error if it is not
+// reachable or if it lags behind the reference node
+func (mon *nodeHealthMonitor) check(ctx context.Context) error {
+	nodeHeight, err := mon.queryHeight(ctx, nodeEndpoint, mon.node)
+	if err != nil {
+		return err
+	}
+	if mon.reference == nil {
+		return nil
+	}
+
+	referenceHeight, err := mon.queryHeight(ctx, referenceEndpoint, mon.reference)
+	if err != nil {
+		return err
+	}
+
+	var lag uint64
+	if referenceHeight > nodeHeight {
+		lag = referenceHeight - nodeHeight
+	}
+	mon.metrics.RecordNodeBlockLag(lag)
+	if lag > mon.cfg.MaxBlockLag {
+		return fmt.Errorf("the node is %d blocks behind the reference node, at height %d, above %d",
+			lag, referenceHeight, mon.cfg.MaxBlockLag)
+	}
+
+	return nil
+}
+
+// nodeHealthMonitorLoop periodically measures the health of the configured
+// node, so that the missed votes caused by a stalled node are obvious
+func (app *FinalityProviderApp) nodeHealthMonitorLoop() {
+	defer app.wg.Done()
+
+	cfg := app.config().NodeHealthConfig
+	mon, err := newNodeHealthMonitor(cfg, app.cc, app.metrics, app.logger)
+	if err != nil {
+		app.logger.Error("failed to start the node health monitor", zap.Error(err))
+		return
+	}
+	if mon.reference == nil {
+		app.logger.Info("no reference node is configured, the lag of the node is not measured")
+	}
+
+	if cfg.Websocket {
+		app.wg.Add(1)
+		go app.nodeWebsocketMonitorLoop()
+	}
+
+	app.logger.Info("starting node health monitor loop",
+		zap.Float64("interval seconds", cfg.CheckInterval.Seconds()))
+	ticker := time.NewTicker(cfg.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := mon.check(app.ctx); err != nil {
+				app.logger.Warn("the node health check failed", zap.Error(err))
+			}
+		case <-app.quit:
+			app.logger.Info("exiting node health monitor loop")
+			return
+		}
+	}
+}
+
+// nodeWebsocketProbe keeps a websocket connection to the configured node and
+// counts its disconnections, the client reconnecting on its own
+type nodeWebsocketProbe struct {
+	addr    string
+	metrics *metrics.FpMetrics
+	logger  *zap.Logger
+
+	// client is nil until it is started, it is re-created once it gives
+	// up reconnecting
+	client *jsonrpcclient.WSClient
+	// connected is the state of the connection as of the last poll
+	connected bool
+	// reconnected is set by the client after each reconnection, so that
+	// the disconnections shorter than the poll interval are counted
+	reconnected atomic.Bool
+}
+
+// poll checks the state of the connection and counts the disconnections
+// since the last poll
+func (p *nodeWebsocketProbe) poll() {
+	if p.client == nil || !p.client.IsRunning() {
+		if err := p.start(); err != nil {
+			p.logger.Debug("failed to connect to the websocket of the node", zap.Error(err))
+		}
+	}
+
+	active := p.client != nil && p.client.IsActive()
+	reconnected := p.reconnected.Swap(false)
+	switch {
+	case p.connected && !active:
+		p.metrics.IncrementNodeTotalWebsocketDisconnects()
+		p.logger.Warn("the websocket connection to the node is down", zap.String("address", p.addr))
+	case p.connected && reconnected:
+		// the connection has been lost and restored since the last poll
+		p.metrics.IncrementNodeTotalWebsocketDisconnects()
+		p.logger.Warn("the websocket connection to the node has been restored", zap.String("address", p.addr))
+	case !p.connected && active:
+		p.logger.Info("the websocket connection to the node is up", zap.String("address", p.addr))
+	}
+	p.connected = active
+	p.metrics.RecordNodeWebsocketConnected(active)
+}
+
+func (p *nodeWebsocketProbe) start() error {
+	client, err := jsonrpcclient.NewWS(p.addr, websocketEndpoint, jsonrpcclient.OnReconnect(func() {
+		p.reconnected.Store(true)
+	}))
+	if err != nil {
+		return err
+	}
+	if err := client.Start(); err != nil {
+		return err
+	}
+
+	// the responses are not used but should be drained, the channel is
+	// closed once the client stops
+	go func() {
+		for range client.ResponsesCh {
+			// drop the response
+		}
+	}()
+	p.client = client
+
+	return nil
+}
+
+func (p *nodeWebsocketProbe) stop() {
+	if p.client != nil && p.client.IsRunning() {
+		_ = p.client.Stop()
+	}
+}
+
+// nodeWebsocketMonitorLoop keeps the websocket probe of the configured node
+// and polls the state of its connection
+func (app *FinalityProviderApp) nodeWebsocketMonitorLoop() {
+	defer app.wg.Done()
+
+	p := &nodeWebsocketProbe{
+		addr:    app.config().BabylonConfig.RPCAddr,
+		metrics: app.metrics,
+		logger:  app.logger,
+	}
+	defer p.stop()
+
+	ticker := time.NewTicker(websocketPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.poll()
+		case <-app.quit:
+			return
+		}
+	}
+}
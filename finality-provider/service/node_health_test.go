@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/metrics"
+	"github.com/babylonlabs-io/finality-provider/testutil/mocks"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// TestNodeHealthCheck tests that the check of the configured node fails if
+// it cannot be reached or if it lags behind the reference node
+func TestNodeHealthCheck(t *testing.T) {
+	t.Parallel()
+
+	errUnreachable := errors.New("connection refused")
+	referenceAt := func(height uint64, err error) nodeHeightFunc {
+		return func(context.Context) (uint64, error) {
+			return height, err
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		nodeHeight uint64
+		nodeErr    error
+		// reference is nil if no reference node is configured
+		reference nodeHeightFunc
+		expectErr string
+	}{
+		{
+			name:       "healthy",
+			nodeHeight: 100,
+			reference:  referenceAt(103, nil),
+		},
+		{
+			name:       "node ahead of the reference",
+			nodeHeight: 105,
+			reference:  referenceAt(100, nil),
+		},
+		{
+			name:       "lag at the threshold",
+			nodeHeight: 95,
+			reference:  referenceAt(100, nil),
+		},
+		{
+			name:       "lagging",
+			nodeHeight: 90,
+			reference:  referenceAt(100, nil),
+			expectErr:  "the node is 10 blocks behind the reference node",
+		},
+		{
+			name:       "no reference node",
+			nodeHeight: 1,
+		},
+		{
+			name:      "unreachable node",
+			nodeErr:   errUnreachable,
+			reference: referenceAt(100, nil),
+			expectErr: "failed to query the latest height of the node",
+		},
+		{
+			name:       "unreachable reference node",
+			nodeHeight: 100,
+			reference:  referenceAt(0, errUnreachable),
+			expectErr:  "failed to query the latest height of the reference",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctl := gomock.NewController(t)
+			mockClientController := mocks.NewMockClientController(ctl)
+			if tc.nodeErr != nil {
+				mockClientController.EXPECT().QueryBestBlock().Return(nil, tc.nodeErr).Times(1)
+			} else {
+				mockClientController.EXPECT().QueryBestBlock().Return(&types.BlockInfo{Height: tc.nodeHeight}, nil).Times(1)
+			}
+
+			cfg := fpcfg.DefaultNodeHealthConfig()
+			cfg.Timeout = time.Second
+			cfg.MaxBlockLag = 5
+			mon, err := newNodeHealthMonitor(&cfg, mockClientController, metrics.NewFpMetrics(), zap.NewNop())
+			require.NoError(t, err)
+			mon.reference = tc.reference
+
+			err = mon.check(context.Background())
+			if tc.expectErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.expectErr)
+			if tc.nodeErr != nil {
+				require.ErrorIs(t, err, tc.nodeErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/notifier"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+)
+
+// probableCauses describes the usual reason of each event to the operator
+var probableCauses = map[notifier.EventType]string{
+	notifier.EventJailed:  "missed too many finality votes within the signing window",
+	notifier.EventSlashed: "equivocation, i.e., conflicting finality votes at the same height",
+	notifier.EventEquivocated: "conflicting finality votes at the same height, " +
+		"which expose the EOTS key of the finality provider",
+	notifier.EventCompromised: "a finality vote not signed by this daemon was found on-chain, " +
+		"the EOTS key of the finality provider is likely used elsewhere",
+	notifier.EventRateLimited: "the submissions exceeded the max submissions per minute, " +
+		"which is likely caused by a bug or a misbehaving chain submitting in a loop",
+	notifier.EventVoteLagging: "the votes are not submitted or not included, e.g., the chain is unreachable, " +
+		"the fee account is empty or the public randomness is not committed",
+	notifier.EventFinalizationLagging: "the blocks are not finalized by the finality providers, " +
+		"e.g., too few of them are voting or the chain is halted",
+	notifier.EventMissedVotes: "the votes fail to be submitted, e.g., the chain or the EOTS manager is unreachable " +
+		"or the fee account is empty, which gets the finality provider jailed if it lasts",
+	notifier.EventPubRandRunwayLow: "the public randomness fails to be committed, e.g., the fee account is empty, " +
+		"the votes stop once the committed randomness is used up",
+	notifier.EventLowParticipation: "part of the votes fail to be submitted, e.g., the chain or the EOTS manager " +
+		"is intermittently unreachable or the fee account runs low",
+	notifier.EventLowBalance: "the account paying the fees is running out of funds, " +
+		"the votes and the public randomness commits fail once it is empty",
+}
+
+// notifyStatusChange alerts the configured notifiers that the given finality
+// provider has been jailed or slashed. The notification is sent in the
+// background so that it does not delay the termination of the instance
+func (fpm *FinalityProviderManager) notifyStatusChange(
+	fpi *FinalityProviderInstance,
+	eventType notifier.EventType,
+	oldStatus proto.FinalityProviderStatus,
+) {
+	if !fpm.notifier.Enabled() {
+		return
+	}
+
+	event := &notifier.Event{
+		Type:            eventType,
+		FpBtcPk:         fpi.GetBtcPkHex(),
+		ChainID:         string(fpi.GetChainID()),
+		OldStatus:       oldStatus.String(),
+		LastVotedHeight: fpi.GetLastVotedHeight(),
+		ProbableCause:   probableCauses[eventType],
+		Time:            time.Now().UTC(),
+	}
+
+	fpm.sendNotification(event)
+}
+
+// sendNotification sends the event to the configured notifiers in the
+// background. The height of the event is set to the tip height if empty
+func (fpm *FinalityProviderManager) sendNotification(event *notifier.Event) {
+	fpm.wg.Add(1)
+	go func() {
+		defer fpm.wg.Done()
+
+		// the height is only informative, the notification is sent anyway
+		if event.Height == 0 {
+			if tip, err := fpm.cc.QueryBestBlock(); err == nil {
+				event.Height = tip.Height
+			}
+		}
+
+		timeout := fpcfg.DefaultNotifierConfig().Timeout
+		if cfg := fpm.config().NotifierConfig; cfg != nil && cfg.Timeout > 0 {
+			timeout = cfg.Timeout
+		}
+		ctx, cancel := context.WithTimeout(fpm.ctx, timeout)
+		defer cancel()
+
+		if err := fpm.notifier.Notify(ctx, event); err != nil {
+			if errors.Is(err, notifier.ErrRateLimited) {
+				fpm.logger.Debug("the notification is suppressed",
+					zap.String("pk", event.FpBtcPk),
+					zap.String("event", string(event.Type)),
+					zap.Error(err),
+				)
+				return
+			}
+			fpm.logger.Error("failed to send the notification",
+				zap.String("pk", event.FpBtcPk),
+				zap.String("event", string(event.Type)),
+				zap.Error(err),
+			)
+			return
+		}
+
+		fpm.logger.Info("sent the notification",
+			zap.String("pk", event.FpBtcPk),
+			zap.String("event", string(event.Type)),
+		)
+	}()
+}
+
+// RegisterNotifier registers the notifier to which the alerts are sent along
+// with the configured ones, subject to the same rate limits
+func (fpm *FinalityProviderManager) RegisterNotifier(n notifier.Notifier) {
+	fpm.notifier.Register(n)
+}
+
+// notifyInstanceAlert alerts the notifiers that the given finality provider
+// has crossed the threshold of the given alert, e.g., of the missed votes
+func (fpm *FinalityProviderManager) notifyInstanceAlert(fpi *FinalityProviderInstance, eventType notifier.EventType, height uint64) {
+	if !fpm.notifier.Enabled() {
+		return
+	}
+
+	fpm.sendNotification(&notifier.Event{
+		Type:            eventType,
+		FpBtcPk:         fpi.GetBtcPkHex(),
+		ChainID:         string(fpi.GetChainID()),
+		OldStatus:       fpi.GetStatus().String(),
+		Height:          height,
+		LastVotedHeight: fpi.GetLastVotedHeight(),
+		ProbableCause:   probableCauses[eventType],
+		Time:            time.Now().UTC(),
+	})
+}
+
+// instanceAlerts fires the alerts of a finality provider instance once its
+// consecutive missed votes reach the threshold or its public randomness
+// runway falls below the min, each alert being fired once per crossing of
+// its threshold. A nil instanceAlerts is a no-op
+type instanceAlerts struct {
+	missedVotesThreshold uint64
+	minPubRandRunway     uint64
+	notify               func(eventType notifier.EventType, height uint64)
+
+	missedVotesFired atomic.Bool
+	runwayLowFired   atomic.Bool
+}
+
+func newInstanceAlerts(cfg *fpcfg.NotifierConfig, notify func(eventType notifier.EventType, height uint64)) *instanceAlerts {
+	if cfg == nil {
+		return nil
+	}
+
+	return &instanceAlerts{
+		missedVotesThreshold: cfg.MissedVotesThreshold,
+		minPubRandRunway:     cfg.MinPubRandRunway,
+		notify:               notify,
+	}
+}
+
+// checkMissedVotes fires the alert of the missed votes if the number of the
+// consecutive missed votes has reached the threshold
+func (a *instanceAlerts) checkMissedVotes(missed uint64) {
+	if a == nil || a.missedVotesThreshold == 0 {
+		return
+	}
+
+	if missed < a.missedVotesThreshold {
+		a.missedVotesFired.Store(false)
+		return
+	}
+	if a.missedVotesFired.CompareAndSwap(false, true) {
+		a.notify(notifier.EventMissedVotes, 0)
+	}
+}
+
+// checkPubRandRunway fires the alert of the low runway if the committed
+// public randomness ahead of the tip has fallen below the min
+func (a *instanceAlerts) checkPubRandRunway(runway, tipHeight uint64) {
+	if a == nil || a.minPubRandRunway == 0 {
+		return
+	}
+
+	if runway >= a.minPubRandRunway {
+		a.runwayLowFired.Store(false)
+		return
+	}
+	if a.runwayLowFired.CompareAndSwap(false, true) {
+		a.notify(notifier.EventPubRandRunwayLow, tipHeight)
+	}
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// recordPendingVotes records the votes for the given blocks in the outbox
+// before they are broadcast
+func (fp *FinalityProviderInstance) recordPendingVotes(blocks []*types.BlockInfo) error {
+	if fp.outbox == nil {
+		return nil
+	}
+
+	subs := make([]*store.PendingSubmission, 0, len(blocks))
+	for _, b := range blocks {
+		subs = append(subs, &store.PendingSubmission{
+			Kind:      store.SubmissionVote,
+			Height:    b.Height,
+			BlockHash: b.Hash,
+		})
+	}
+
+	if err := fp.outbox.AddPendingSubmissions(fp.GetChainID(), fp.btcPk.MustMarshal(), subs); err != nil {
+		return fmt.Errorf("failed to record the pending votes in the outbox: %w", err)
+	}
+
+	return nil
+}
+
+// recordPendingPubRandCommit records the public randomness commit in the
+// outbox before it is broadcast
+func (fp *FinalityProviderInstance) recordPendingPubRandCommit(startHeight, numPubRand uint64, commitment []byte) error {
+	if fp.outbox == nil {
+		return nil
+	}
+
+	sub := &store.PendingSubmission{
+		Kind:       store.SubmissionPubRandCommit,
+		Height:     startHeight,
+		NumPubRand: numPubRand,
+		Commitment: commitment,
+	}
+	if err := fp.outbox.AddPendingSubmissions(fp.GetChainID(), fp.btcPk.MustMarshal(), []*store.PendingSubmission{sub}); err != nil {
+		return fmt.Errorf("failed to record the pending public randomness commit in the outbox: %w", err)
+	}
+
+	return nil
+}
+
+// completeSubmissions removes the confirmed submissions from the outbox. The
+// failure is only logged as the submissions left are reconciled upon restart
+func (fp *FinalityProviderInstance) completeSubmissions(kind store.SubmissionKind, heights []uint64) {
+	if fp.outbox == nil {
+		return
+	}
+
+	if err := fp.outbox.CompleteSubmissions(fp.GetChainID(), fp.btcPk.MustMarshal(), kind, heights); err != nil {
+		fp.logger.Error("failed to complete the submissions in the outbox",
+			zap.String("pk", fp.GetBtcPkHex()),
+			zap.Stringer("submission", kind),
+			zap.Uint64s("heights", heights),
+			zap.Error(err),
+		)
+	}
+}
+
+// reconcileOutbox checks the submissions left in the outbox by a crash or a
+// failure against the chain before the instance starts. The votes found
+// on-chain update the state as if they were confirmed, the other ones are
+// queued to be retried if the vote retry is enabled. The public randomness
+// commits not found on-chain are dropped as the randomness is committed again
+// from the last committed height
+func (fp *FinalityProviderInstance) reconcileOutbox() error {
+	if fp.outbox == nil {
+		return nil
+	}
+
+	chainID, pk := fp.GetChainID(), fp.btcPk.MustMarshal()
+	subs, err := fp.outbox.ListPendingSubmissions(chainID, pk)
+	if err != nil {
+		return fmt.Errorf("failed to list the pending submissions: %w", err)
+	}
+	if len(subs) == 0 {
+		return nil
+	}
+
+	var (
+		votes   []*store.PendingSubmission
+		commits []*store.PendingSubmission
+	)
+	for _, sub := range subs {
+		if sub.Kind == store.SubmissionVote {
+			votes = append(votes, sub)
+		} else {
+			commits = append(commits, sub)
+		}
+	}
+
+	if err := fp.reconcilePendingVotes(votes); err != nil {
+		return err
+	}
+
+	return fp.reconcilePendingPubRandCommits(commits)
+}
+
+func (fp *FinalityProviderInstance) reconcilePendingVotes(votes []*store.PendingSubmission) error {
+	if len(votes) == 0 {
+		return nil
+	}
+
+	pkHex := fp.GetBtcPkHex()
+	heights := make([]uint64, 0, len(votes))
+	var unconfirmed []*types.BlockInfo
+	for _, v := range votes {
+		voters, err := fp.cc.QueryVotesAtHeight(v.Height)
+		if err != nil {
+			return fmt.Errorf("failed to query the votes at height %d: %w", v.Height, err)
+		}
+
+		voted := false
+		for _, voter := range voters {
+			if voter.MarshalHex() == pkHex {
+				voted = true
+				break
+			}
+		}
+		heights = append(heights, v.Height)
+
+		if !voted {
+			unconfirmed = append(unconfirmed, &types.BlockInfo{Height: v.Height, Hash: v.BlockHash})
+			continue
+		}
+		if v.Height > fp.GetLastVotedHeight() {
+			fp.MustUpdateStateAfterFinalitySigSubmission(v.Height)
+		}
+	}
+
+	if len(unconfirmed) > 0 {
+		if fp.voteRetryEnabled() {
+			if err := fp.enqueueFailedVotes(unconfirmed); err != nil {
+				return err
+			}
+		} else {
+			fp.logger.Warn("the pending votes are not found on-chain and the vote retry is disabled",
+				zap.String("pk", pkHex),
+				zap.Uint64("start_height", unconfirmed[0].Height),
+				zap.Uint64("end_height", unconfirmed[len(unconfirmed)-1].Height),
+			)
+		}
+	}
+
+	if err := fp.outbox.CompleteSubmissions(fp.GetChainID(), fp.btcPk.MustMarshal(), store.SubmissionVote, heights); err != nil {
+		return fmt.Errorf("failed to complete the reconciled votes: %w", err)
+	}
+
+	fp.logger.Info("reconciled the pending votes",
+		zap.String("pk", pkHex),
+		zap.Int("num_confirmed", len(votes)-len(unconfirmed)),
+		zap.Int("num_unconfirmed", len(unconfirmed)),
+	)
+
+	return nil
+}
+
+func (fp *FinalityProviderInstance) reconcilePendingPubRandCommits(commits []*store.PendingSubmission) error {
+	if len(commits) == 0 {
+		return nil
+	}
+
+	// the pending commits are the latest ones if they are included
+	committed, err := fp.cc.QueryLastCommittedPublicRand(fp.GetBtcPk(), uint64(len(commits)))
+	if err != nil {
+		return fmt.Errorf("failed to query the last committed public randomness: %w", err)
+	}
+
+	heights := make([]uint64, 0, len(commits))
+	numConfirmed := 0
+	for _, c := range commits {
+		heights = append(heights, c.Height)
+		if res, ok := committed[c.Height]; ok && res.NumPubRand == c.NumPubRand && bytes.Equal(res.Commitment, c.Commitment) {
+			numConfirmed++
+			fp.metrics.RecordFpLastCommittedRandomnessHeight(fp.GetBtcPkHex(), c.Height+c.NumPubRand-1)
+			continue
+		}
+		fp.logger.Warn("the pending public randomness commit is not found on-chain",
+			zap.String("pk", fp.GetBtcPkHex()),
+			zap.Uint64("start_height", c.Height),
+			zap.Uint64("num_pub_rand", c.NumPubRand),
+		)
+	}
+
+	if err := fp.outbox.CompleteSubmissions(fp.GetChainID(), fp.btcPk.MustMarshal(), store.SubmissionPubRandCommit, heights); err != nil {
+		return fmt.Errorf("failed to complete the reconciled public randomness commits: %w", err)
+	}
+
+	fp.logger.Info("reconciled the pending public randomness commits",
+		zap.String("pk", fp.GetBtcPkHex()),
+		zap.Int("num_confirmed", numConfirmed),
+		zap.Int("num_unconfirmed", len(commits)-numConfirmed),
+	)
+
+	return nil
+}
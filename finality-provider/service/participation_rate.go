@@ -0,0 +1,183 @@
+package service
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// participationHeight is a height with voting power processed by a finality
+// provider
+type participationHeight struct {
+	height     uint64
+	receivedAt time.Time
+	voted      bool
+}
+
+// participationTracker tracks the heights with voting power processed by a
+// finality provider within the longest rolling window and whether they were
+// voted, from which the rolling participation rates are measured
+type participationTracker struct {
+	mu        sync.Mutex
+	maxWindow time.Duration
+	// heights are in ascending order of height and of receipt time
+	heights []participationHeight
+}
+
+func newParticipationTracker(maxWindow time.Duration) *participationTracker {
+	return &participationTracker{maxWindow: maxWindow}
+}
+
+// heightsEligible records the given blocks as expected to be voted, the
+// blocks which are already tracked being ignored, e.g., if they are
+// processed again after a restart
+func (t *participationTracker) heightsEligible(blocks []*types.BlockInfo) {
+	if t == nil {
+		return
+	}
+
+	now := time.Now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, b := range blocks {
+		if n := len(t.heights); n > 0 && b.Height <= t.heights[n-1].height {
+			continue
+		}
+		t.heights = append(t.heights, participationHeight{height: b.Height, receivedAt: now})
+	}
+	t.prune(now)
+}
+
+// heightsVoted marks the given blocks as voted, the heights which are no
+// longer tracked being ignored
+func (t *participationTracker) heightsVoted(blocks []*types.BlockInfo) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, b := range blocks {
+		i := sort.Search(len(t.heights), func(i int) bool {
+			return t.heights[i].height >= b.Height
+		})
+		if i < len(t.heights) && t.heights[i].height == b.Height {
+			t.heights[i].voted = true
+		}
+	}
+}
+
+// prune drops the heights received before the longest window
+func (t *participationTracker) prune(now time.Time) {
+	cutoff := now.Add(-t.maxWindow)
+	i := sort.Search(len(t.heights), func(i int) bool {
+		return !t.heights[i].receivedAt.Before(cutoff)
+	})
+	t.heights = t.heights[i:]
+}
+
+// rate returns the ratio of the voted heights to the tracked heights
+// received within the given window, or false if there is no such height
+func (t *participationTracker) rate(now time.Time, window time.Duration) (float64, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.prune(now)
+
+	cutoff := now.Add(-window)
+	i := sort.Search(len(t.heights), func(i int) bool {
+		return !t.heights[i].receivedAt.Before(cutoff)
+	})
+	eligible := len(t.heights) - i
+	if eligible == 0 {
+		return 0, false
+	}
+	var voted int
+	for _, h := range t.heights[i:] {
+		if h.voted {
+			voted++
+		}
+	}
+
+	return float64(voted) / float64(eligible), true
+}
+
+func (fpm *FinalityProviderManager) participationRateEnabled() bool {
+	return fpm.config().ParticipationRateConfig != nil && fpm.config().ParticipationRateConfig.Enabled
+}
+
+// getParticipationTracker returns the participation tracker of the given
+// finality provider, which is created upon the first call so that it is kept
+// across the restarts of the instance, or nil if the rate is disabled
+func (fpm *FinalityProviderManager) getParticipationTracker(fpBtcPkHex string) *participationTracker {
+	if !fpm.participationRateEnabled() {
+		return nil
+	}
+
+	fpm.participationTrackersMu.Lock()
+	defer fpm.participationTrackersMu.Unlock()
+
+	if t, ok := fpm.participationTrackers[fpBtcPkHex]; ok {
+		return t
+	}
+
+	// the windows have been validated
+	windows, _ := fpm.config().ParticipationRateConfig.ParsedWindows()
+	var maxWindow time.Duration
+	for _, w := range windows {
+		maxWindow = max(maxWindow, w)
+	}
+	t := newParticipationTracker(maxWindow)
+	fpm.participationTrackers[fpBtcPkHex] = t
+
+	return t
+}
+
+// participationRateLoop periodically records the rolling participation rates
+// of the running finality providers
+func (fpm *FinalityProviderManager) participationRateLoop() {
+	defer fpm.wg.Done()
+
+	cfg := fpm.config().ParticipationRateConfig
+	windows, err := cfg.ParsedWindows()
+	if err != nil {
+		fpm.logger.Error("failed to parse the participation rate windows", zap.Error(err))
+		return
+	}
+
+	fpm.logger.Info("starting participation rate loop",
+		zap.Float64("interval seconds", cfg.UpdateInterval.Seconds()),
+		zap.Strings("windows", cfg.Windows),
+	)
+
+	ticker := time.NewTicker(cfg.UpdateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			now := time.Now()
+			for _, fpi := range fpm.ListRunningInstances() {
+				pkHex := fpi.GetBtcPkHex()
+				tracker := fpm.getParticipationTracker(pkHex)
+				for i, w := range windows {
+					if rate, ok := tracker.rate(now, w); ok {
+						fpm.metrics.RecordFpRollingParticipationRate(pkHex, cfg.Windows[i], rate)
+					} else {
+						fpm.metrics.RemoveFpRollingParticipationRate(pkHex, cfg.Windows[i])
+					}
+				}
+			}
+		case <-fpm.quit:
+			fpm.logger.Info("exiting participation rate loop")
+			return
+		}
+	}
+}
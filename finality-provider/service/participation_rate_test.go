@@ -0,0 +1,146 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+func TestParticipationRate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	// ago returns the height received the given duration before now
+	ago := func(height uint64, d time.Duration, voted bool) participationHeight {
+		return participationHeight{height: height, receivedAt: now.Add(-d), voted: voted}
+	}
+
+	testCases := []struct {
+		name     string
+		heights  []participationHeight
+		window   time.Duration
+		rate     float64
+		eligible bool
+	}{
+		{
+			name:   "no eligible height",
+			window: time.Hour,
+		},
+		{
+			name:    "no eligible height within the window",
+			heights: []participationHeight{ago(1, 2*time.Hour, true), ago(2, 90*time.Minute, true)},
+			window:  time.Hour,
+		},
+		{
+			name:     "all heights voted",
+			heights:  []participationHeight{ago(1, 3*time.Minute, true), ago(2, 2*time.Minute, true), ago(3, time.Minute, true)},
+			window:   time.Hour,
+			rate:     1,
+			eligible: true,
+		},
+		{
+			name:     "no height voted",
+			heights:  []participationHeight{ago(1, 2*time.Minute, false), ago(2, time.Minute, false)},
+			window:   time.Hour,
+			rate:     0,
+			eligible: true,
+		},
+		{
+			name: "some heights voted",
+			heights: []participationHeight{
+				ago(1, 4*time.Minute, true), ago(2, 3*time.Minute, false),
+				ago(3, 2*time.Minute, true), ago(4, time.Minute, true),
+			},
+			window:   time.Hour,
+			rate:     0.75,
+			eligible: true,
+		},
+		{
+			name: "height received at the start of the window",
+			heights: []participationHeight{
+				ago(1, time.Hour, false), ago(2, time.Minute, true),
+			},
+			window:   time.Hour,
+			rate:     0.5,
+			eligible: true,
+		},
+		{
+			name: "height received right before the window",
+			heights: []participationHeight{
+				ago(1, time.Hour+time.Nanosecond, false), ago(2, time.Minute, true),
+			},
+			window:   time.Hour,
+			rate:     1,
+			eligible: true,
+		},
+		{
+			name: "height received now",
+			heights: []participationHeight{
+				ago(1, time.Minute, false), ago(2, 0, true),
+			},
+			window:   time.Hour,
+			rate:     0.5,
+			eligible: true,
+		},
+		{
+			name: "shorter window",
+			heights: []participationHeight{
+				ago(1, 50*time.Minute, false), ago(2, 40*time.Minute, false),
+				ago(3, 5*time.Minute, true), ago(4, time.Minute, false),
+			},
+			window:   10 * time.Minute,
+			rate:     0.5,
+			eligible: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tracker := newParticipationTracker(2 * time.Hour)
+			tracker.heights = tc.heights
+			rate, eligible := tracker.rate(now, tc.window)
+			require.Equal(t, tc.eligible, eligible)
+			require.InDelta(t, tc.rate, rate, 1e-9)
+		})
+	}
+}
+
+func TestParticipationTracker(t *testing.T) {
+	t.Parallel()
+
+	blocks := func(heights ...uint64) []*types.BlockInfo {
+		bs := make([]*types.BlockInfo, 0, len(heights))
+		for _, h := range heights {
+			bs = append(bs, &types.BlockInfo{Height: h})
+		}
+		return bs
+	}
+
+	tracker := newParticipationTracker(time.Hour)
+	tracker.heightsEligible(blocks(1, 2, 3, 4))
+	// the heights processed again, e.g., after a restart, are not counted
+	// twice
+	tracker.heightsEligible(blocks(3, 4, 5))
+	// the heights which are not tracked are ignored
+	tracker.heightsVoted(blocks(2, 4, 6))
+
+	rate, ok := tracker.rate(time.Now(), time.Hour)
+	require.True(t, ok)
+	require.InDelta(t, 0.4, rate, 1e-9)
+
+	// the heights received before the longest window are pruned
+	rate, ok = tracker.rate(time.Now().Add(2*time.Hour), 3*time.Hour)
+	require.False(t, ok)
+	require.Zero(t, rate)
+	require.Empty(t, tracker.heights)
+
+	// a disabled tracker ignores the heights
+	var disabled *participationTracker
+	disabled.heightsEligible(blocks(1))
+	disabled.heightsVoted(blocks(1))
+}
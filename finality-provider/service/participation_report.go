@@ -0,0 +1,238 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"go.uber.org/zap"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+)
+
+// the probable reasons of a missed vote, derived from the local records
+const (
+	// missedReasonPendingBroadcast is used if the vote is recorded in the
+	// outbox but not confirmed
+	missedReasonPendingBroadcast = "pending_broadcast"
+	// missedReasonQueuedForRetry is used if the vote failed and is queued to
+	// be retried
+	missedReasonQueuedForRetry = "queued_for_retry"
+	// missedReasonSignedNotIncluded is used if the vote is signed but not
+	// included, e.g., it was rejected or dropped from the mempool
+	missedReasonSignedNotIncluded = "signed_not_included"
+	// missedReasonNotProcessed is used if the height has not been processed
+	// by the finality provider yet
+	missedReasonNotProcessed = "not_processed"
+	// missedReasonNotSigned is used if the height has been processed without
+	// being signed, e.g., the daemon was down or the voting was paused
+	missedReasonNotSigned = "not_signed"
+)
+
+var missedReasons = []string{
+	missedReasonPendingBroadcast,
+	missedReasonQueuedForRetry,
+	missedReasonSignedNotIncluded,
+	missedReasonNotProcessed,
+	missedReasonNotSigned,
+}
+
+func (fpm *FinalityProviderManager) participationReportEnabled() bool {
+	return fpm.config().ParticipationReportConfig != nil && fpm.config().ParticipationReportConfig.Enabled
+}
+
+// ParticipationReport compares the on-chain votes of the given finality
+// provider within the given heights with the local records. The window of
+// the config ending ConfirmationDepth blocks below the tip is used for the
+// zero heights
+func (fpm *FinalityProviderManager) ParticipationReport(fpPk *bbntypes.BIP340PubKey, startHeight, endHeight uint64) (*proto.ParticipationReport, error) {
+	sfp, err := fpm.fps.GetFinalityProvider(fpPk.MustToBTCPK())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get finality provider from db: %w", err)
+	}
+
+	cfg := fpm.config().ParticipationReportConfig
+	if cfg == nil {
+		defaultCfg := fpcfg.DefaultParticipationReportConfig()
+		cfg = &defaultCfg
+	}
+	if endHeight == 0 {
+		tip, err := fpm.cc.QueryBestBlock()
+		if err != nil {
+			return nil, fmt.Errorf("failed to query the tip: %w", err)
+		}
+		if tip.Height <= cfg.ConfirmationDepth {
+			return nil, fmt.Errorf("the tip height %d is within the confirmation depth", tip.Height)
+		}
+		endHeight = tip.Height - cfg.ConfirmationDepth
+	}
+	if startHeight == 0 {
+		startHeight = 1
+		if endHeight > cfg.WindowBlocks {
+			startHeight = endHeight - cfg.WindowBlocks + 1
+		}
+	}
+	if startHeight > endHeight {
+		return nil, fmt.Errorf("the start height %d should not be greater than the end height %d", startHeight, endHeight)
+	}
+	if endHeight-startHeight+1 > fpcfg.MaxParticipationReportWindowBlocks {
+		return nil, fmt.Errorf("the window should not exceed %d blocks", fpcfg.MaxParticipationReportWindowBlocks)
+	}
+
+	return fpm.participationReport(sfp, startHeight, endHeight)
+}
+
+func (fpm *FinalityProviderManager) participationReport(sfp *store.StoredFinalityProvider, startHeight, endHeight uint64) (*proto.ParticipationReport, error) {
+	chainID, pk := []byte(sfp.ChainID), sfp.GetBIP340BTCPK().MustMarshal()
+	pkHex := sfp.GetBIP340BTCPK().MarshalHex()
+
+	// the local records of the votes which are not confirmed
+	pending := make(map[uint64]struct{})
+	if fpm.outbox != nil {
+		subs, err := fpm.outbox.ListPendingSubmissions(chainID, pk)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the pending submissions: %w", err)
+		}
+		for _, sub := range subs {
+			if sub.Kind == store.SubmissionVote {
+				pending[sub.Height] = struct{}{}
+			}
+		}
+	}
+	queued := make(map[uint64]struct{})
+	if fpm.voteRetries != nil {
+		votes, err := fpm.voteRetries.ListFailedVotes(chainID, pk)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the queued votes: %w", err)
+		}
+		for _, v := range votes {
+			queued[v.Height] = struct{}{}
+		}
+	}
+	lastProcessedHeight := max(sfp.LastProcessedHeight, sfp.LastVotedHeight)
+
+	report := &proto.ParticipationReport{
+		BtcPkHex:    pkHex,
+		StartHeight: startHeight,
+		EndHeight:   endHeight,
+	}
+	for height := startHeight; height <= endHeight; height++ {
+		voters, err := fpm.cc.QueryVotesAtHeight(height)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query the votes at height %d: %w", height, err)
+		}
+		voted := false
+		for _, voter := range voters {
+			if voter.MarshalHex() == pkHex {
+				voted = true
+				break
+			}
+		}
+		if voted {
+			report.NumExpected++
+			report.NumVoted++
+			continue
+		}
+
+		power, err := fpm.cc.QueryFinalityProviderVotingPower(sfp.BtcPk, height)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query the voting power at height %d: %w", height, err)
+		}
+		if power == 0 {
+			continue
+		}
+		report.NumExpected++
+
+		var reason string
+		_, signErr := fpm.signRecords.GetSignRecord(chainID, pk, height)
+		_, isPending := pending[height]
+		_, isQueued := queued[height]
+		switch {
+		case isPending:
+			reason = missedReasonPendingBroadcast
+		case isQueued:
+			reason = missedReasonQueuedForRetry
+		case signErr == nil:
+			reason = missedReasonSignedNotIncluded
+		case !errors.Is(signErr, store.ErrSignRecordNotFound):
+			return nil, fmt.Errorf("failed to get the sign record at height %d: %w", height, signErr)
+		case height > lastProcessedHeight:
+			reason = missedReasonNotProcessed
+		default:
+			reason = missedReasonNotSigned
+		}
+		report.MissedVotes = append(report.MissedVotes, &proto.MissedVote{Height: height, Reason: reason})
+	}
+
+	return report, nil
+}
+
+// participationReportLoop periodically reports the missed votes of the
+// running finality providers within the window of the config through the
+// logs and the metrics
+func (fpm *FinalityProviderManager) participationReportLoop() {
+	defer fpm.wg.Done()
+
+	cfg := fpm.config().ParticipationReportConfig
+	fpm.logger.Info("starting participation report loop",
+		zap.Float64("interval seconds", cfg.Interval.Seconds()),
+		zap.Uint64("window_blocks", cfg.WindowBlocks),
+	)
+
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			for _, fpi := range fpm.ListRunningInstances() {
+				fpm.reportParticipation(fpi)
+			}
+		case <-fpm.quit:
+			fpm.logger.Info("exiting participation report loop")
+			return
+		}
+	}
+}
+
+func (fpm *FinalityProviderManager) reportParticipation(fpi *FinalityProviderInstance) {
+	pkHex := fpi.GetBtcPkHex()
+
+	report, err := fpm.ParticipationReport(fpi.GetBtcPkBIP340(), 0, 0)
+	if err != nil {
+		fpm.logger.Warn("failed to generate the participation report", zap.String("pk", pkHex), zap.Error(err))
+		return
+	}
+
+	missedByReason := make(map[string]int, len(missedReasons))
+	for _, missed := range report.MissedVotes {
+		missedByReason[missed.Reason]++
+	}
+	for _, reason := range missedReasons {
+		fpm.metrics.RecordFpMissedVotesInWindow(pkHex, reason, missedByReason[reason])
+	}
+	if report.NumExpected > 0 {
+		fpm.metrics.RecordFpParticipationRate(pkHex, float64(report.NumVoted)/float64(report.NumExpected))
+	}
+
+	fields := []zap.Field{
+		zap.String("pk", pkHex),
+		zap.Uint64("start_height", report.StartHeight),
+		zap.Uint64("end_height", report.EndHeight),
+		zap.Uint64("num_expected", report.NumExpected),
+		zap.Uint64("num_voted", report.NumVoted),
+	}
+	if len(report.MissedVotes) == 0 {
+		fpm.logger.Info("no missed votes within the participation report window", fields...)
+		return
+	}
+	for _, reason := range missedReasons {
+		if n := missedByReason[reason]; n > 0 {
+			fields = append(fields, zap.Int(reason, n))
+		}
+	}
+	fpm.logger.Warn("found missed votes within the participation report window", fields...)
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strings"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"go.uber.org/zap"
+)
+
+const (
+	preflightCheckChainID  = "chain_id"
+	preflightCheckEOTSD    = "eotsd"
+	preflightCheckEOTSKeys = "eots_keys"
+	preflightCheckBalance  = "balance"
+	preflightCheckDB       = "db"
+	preflightCheckPubRand  = "pub_rand"
+	preflightCheckClock    = "clock_skew"
+)
+
+// preflightProbeMsg is signed by the EOTS manager to check that it holds
+// the keys of the finality providers
+var preflightProbeMsg = sha256.Sum256([]byte("finality-provider preflight check"))
+
+// Preflight checks, before the instances of the given finality providers
+// are started, that the node is on the configured chain, that the EOTS
+// manager is reachable and holds their keys, that the key signing the
+// transactions has the minimum balance, that the db is writable, that
+// their committed public randomness covers the tip and that the local clock
+// is not skewed beyond MaxSkew. The public randomness check only warns as
+// the instances commit upon start, and so does the clock skew check below
+// MaxSkew
+func (app *FinalityProviderApp) Preflight(fpPks []*bbntypes.BIP340PubKey, passphrase string) *HealthReport {
+	cfg := app.config().PreflightConfig
+	report := &HealthReport{Status: HealthStatusOK}
+
+	report.add(preflightCheckChainID, runWithTimeout(cfg.CheckTimeout, app.checkChainID))
+
+	// the local EOTS manager is always reachable
+	if pinger, ok := app.eotsManager.(eotsPinger); ok {
+		report.add(preflightCheckEOTSD, runWithTimeout(cfg.CheckTimeout, pinger.Ping))
+	}
+	report.add(preflightCheckEOTSKeys, runWithTimeout(cfg.CheckTimeout, func() error {
+		return app.checkEOTSKeys(fpPks, passphrase)
+	}))
+
+	report.add(preflightCheckBalance, runWithTimeout(cfg.CheckTimeout, app.checkSignerBalance))
+	// the db is not written during the maintenance
+	if app.IsInMaintenance() {
+		report.warn(preflightCheckDB, ErrInMaintenance)
+	} else {
+		report.add(preflightCheckDB, runWithTimeout(cfg.CheckTimeout, app.fps.CheckWritable))
+	}
+	report.warn(preflightCheckPubRand, runWithTimeout(cfg.CheckTimeout, func() error {
+		return app.checkPubRandCoverage(fpPks)
+	}))
+	if app.clockSkewEnabled() {
+		err := runWithTimeout(cfg.CheckTimeout, app.checkClockSkew)
+		if errors.Is(err, ErrClockSkewTooLarge) {
+			report.add(preflightCheckClock, err)
+		} else {
+			report.warn(preflightCheckClock, err)
+		}
+	}
+
+	report.addHints(preflightHints)
+
+	return report
+}
+
+// preflightHints are the remediations of the preflight checks
+var preflightHints = map[string]string{
+	preflightCheckChainID:  "check that the rpc-address of the Babylon config points to a node of the configured chain-id",
+	preflightCheckEOTSD:    "check that eotsd is running and that eotsmanageraddress points to its RPC listener",
+	preflightCheckEOTSKeys: "check that the EOTS keys of the finality providers are in the keyring of eotsd and that the passphrase is correct",
+	preflightCheckBalance:  "fund the key signing the transactions, i.e., the key of the Babylon config, above preflightconfig.minbalance",
+	preflightCheckDB:       "check the permissions and the free space of the db directory, and that no other process holds the db",
+	preflightCheckPubRand:  "run fpd commit-pubrand or wait for the randomness commitment loop of the running instances",
+	preflightCheckClock:    "synchronize the clock of the host, e.g., with NTP",
+}
+
+// addHints sets the hints of the failing and warning checks
+func (r *HealthReport) addHints(hints map[string]string) {
+	for _, c := range r.Checks {
+		if c.Status != HealthStatusOK {
+			c.Hint = hints[c.Name]
+		}
+	}
+}
+
+// Err returns the consolidated error of the failing checks of the
+// report, nil if all the checks pass
+func (r *HealthReport) Err() error {
+	var failing []string
+	for _, c := range r.Checks {
+		if c.Status == HealthStatusFailing {
+			failing = append(failing, fmt.Sprintf("%s: %s", c.Name, c.Error))
+		}
+	}
+	if len(failing) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%d check(s) failing: %s", len(failing), strings.Join(failing, "; "))
+}
+
+// LogPreflightReport logs the result of each check of the report
+func (app *FinalityProviderApp) LogPreflightReport(report *HealthReport) {
+	for _, c := range report.Checks {
+		switch c.Status {
+		case HealthStatusFailing:
+			app.logger.Error("preflight check failed",
+				zap.String("check", c.Name), zap.String("error", c.Error), zap.String("hint", c.Hint))
+		case HealthStatusWarning:
+			app.logger.Warn("preflight check warning",
+				zap.String("check", c.Name), zap.String("error", c.Error), zap.String("hint", c.Hint))
+		default:
+			app.logger.Info("preflight check passed", zap.String("check", c.Name))
+		}
+	}
+}
+
+func (app *FinalityProviderApp) checkChainID() error {
+	chainID, err := app.cc.QueryNodeChainID()
+	if err != nil {
+		return err
+	}
+
+	if expected := app.config().BabylonConfig.ChainID; chainID != expected {
+		return fmt.Errorf("the node is on chain %s while %s is configured", chainID, expected)
+	}
+
+	return nil
+}
+
+// checkEOTSKeys signs a probe message with the key of each finality
+// provider and verifies the signature against its public key. The keys
+// leased by another daemon, e.g., the primary of a standby, cannot be
+// probed and are skipped
+func (app *FinalityProviderApp) checkEOTSKeys(fpPks []*bbntypes.BIP340PubKey, passphrase string) error {
+	var errs []error
+	for _, fpPk := range fpPks {
+		sig, err := app.eotsManager.SignSchnorrSig(fpPk.MustMarshal(), preflightProbeMsg[:], passphrase)
+		if isNotLeaderErr(err) {
+			app.logger.Warn("skip the preflight check of the EOTS key leased by another daemon",
+				zap.String("pk", fpPk.MarshalHex()), zap.Error(err))
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to sign with the key of %s: %w", fpPk.MarshalHex(), err))
+			continue
+		}
+		if !sig.Verify(preflightProbeMsg[:], fpPk.MustToBTCPK()) {
+			errs = append(errs, fmt.Errorf("the key of %s held by the EOTS manager does not match", fpPk.MarshalHex()))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// checkSignerBalance fails if the key signing the transactions does not
+// exist or its balance is below the configured minimum
+func (app *FinalityProviderApp) checkSignerBalance() error {
+	balance, err := app.cc.QuerySignerBalance()
+	if err != nil {
+		return err
+	}
+
+	minBalance := app.config().PreflightConfig.MinBalanceCoins()
+	if !balance.IsAllGTE(minBalance) {
+		return fmt.Errorf("the balance %s is below the minimum %s", balance, minBalance)
+	}
+
+	return nil
+}
+
+// checkPubRandCoverage fails if the last committed public randomness of
+// any of the finality providers ends below the tip
+func (app *FinalityProviderApp) checkPubRandCoverage(fpPks []*bbntypes.BIP340PubKey) error {
+	if len(fpPks) == 0 {
+		return nil
+	}
+
+	tip, err := app.cc.QueryBestBlock()
+	if err != nil {
+		return fmt.Errorf("failed to query the best block: %w", err)
+	}
+
+	var errs []error
+	for _, fpPk := range fpPks {
+		res, err := app.cc.QueryLastCommittedPublicRand(fpPk.MustToBTCPK(), 1)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to query the committed public randomness of %s: %w", fpPk.MarshalHex(), err))
+			continue
+		}
+
+		var lastCommittedHeight uint64
+		for startHeight, commit := range res {
+			lastCommittedHeight = startHeight + commit.NumPubRand - 1
+		}
+		if len(res) == 0 || lastCommittedHeight < tip.Height {
+			errs = append(errs, fmt.Errorf("the public randomness of %s is committed up to height %d, below the tip %d",
+				fpPk.MarshalHex(), lastCommittedHeight, tip.Height))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+)
+
+// PrunedFinalityProvider reports the data pruned for a finality provider
+type PrunedFinalityProvider struct {
+	BtcPkHex         string `json:"btc_pk_hex"`
+	NumPubRandProofs int    `json:"num_pub_rand_proofs"`
+	NumVoteRecords   int    `json:"num_vote_records"`
+}
+
+// PruneResult reports the data pruned below a height
+type PruneResult struct {
+	BeforeHeight      uint64                    `json:"before_height"`
+	FinalizedHeight   uint64                    `json:"finalized_height"`
+	FinalityProviders []*PrunedFinalityProvider `json:"finality_providers"`
+}
+
+// PruneData deletes the public randomness proofs and the vote records of
+// the stored finality providers of the configured chain at heights below the
+// given height, which must not be above the last finalized height of the
+// chain, as the proofs of the heights which are not finalized are still
+// needed for voting. The finality providers of the other chains are left
+// untouched. The sign records are kept in a database of their own, which is
+// never pruned
+func (app *FinalityProviderApp) PruneData(beforeHeight uint64) (*PruneResult, error) {
+	if beforeHeight == 0 {
+		return nil, fmt.Errorf("the height to prune before must be positive")
+	}
+
+	blocks, err := app.cc.QueryLatestFinalizedBlocks(1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the latest finalized block: %w", err)
+	}
+	if len(blocks) == 0 {
+		return nil, fmt.Errorf("no block is finalized yet, refusing to prune")
+	}
+	finalizedHeight := blocks[0].Height
+	if beforeHeight > finalizedHeight {
+		return nil, fmt.Errorf("refusing to prune before height %d, which is above the last finalized height %d",
+			beforeHeight, finalizedHeight)
+	}
+
+	res := &PruneResult{
+		BeforeHeight:      beforeHeight,
+		FinalizedHeight:   finalizedHeight,
+		FinalityProviders: []*PrunedFinalityProvider{},
+	}
+	q := &store.FinalityProviderQuery{
+		ChainID: app.config().BabylonConfig.ChainID,
+		Limit:   metricsUpdatePageSize,
+	}
+	for {
+		fps, nextKey, err := app.fps.QueryFinalityProviders(q)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, fp := range fps {
+			pruned, err := app.pruneFinalityProvider(fp, beforeHeight)
+			if err != nil {
+				return nil, fmt.Errorf("failed to prune the data of %s: %w", fp.GetBIP340BTCPK().MarshalHex(), err)
+			}
+			res.FinalityProviders = append(res.FinalityProviders, pruned)
+
+			app.logger.Info("pruned the data of the finality provider",
+				zap.String("pk", pruned.BtcPkHex),
+				zap.Uint64("before_height", beforeHeight),
+				zap.Int("num_pub_rand_proofs", pruned.NumPubRandProofs),
+				zap.Int("num_vote_records", pruned.NumVoteRecords),
+			)
+		}
+
+		if nextKey == nil {
+			return res, nil
+		}
+		q.StartKey = nextKey
+	}
+}
+
+func (app *FinalityProviderApp) pruneFinalityProvider(fp *store.StoredFinalityProvider, beforeHeight uint64) (*PrunedFinalityProvider, error) {
+	chainID := []byte(fp.ChainID)
+	pk := fp.GetBIP340BTCPK().MustMarshal()
+	pruned := &PrunedFinalityProvider{BtcPkHex: fp.GetBIP340BTCPK().MarshalHex()}
+
+	var err error
+	pruned.NumPubRandProofs, err = app.pubRandStore.PrunePubRandProofs(chainID, pk, beforeHeight)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prune the public randomness proofs: %w", err)
+	}
+	pruned.NumVoteRecords, err = app.historyStore.PruneVoteRecords(chainID, pk, beforeHeight)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prune the vote records: %w", err)
+	}
+
+	return pruned, nil
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+)
+
+// pubRandArchivalLoop periodically moves the public randomness proofs of
+// long-finalized heights from the db to the archive to keep the db small
+func (app *FinalityProviderApp) pubRandArchivalLoop() {
+	defer app.wg.Done()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-app.quit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	interval := app.config().ArchiveConfig.Interval
+	app.logger.Info("starting public randomness proof archival loop",
+		zap.Float64("interval seconds", interval.Seconds()))
+	archivalTicker := time.NewTicker(interval)
+	defer archivalTicker.Stop()
+
+	for {
+		select {
+		case <-archivalTicker.C:
+			var err error
+			if !app.fpManager.maintenance.do(func() { err = app.archivePubRandProofs(ctx) }) {
+				app.logger.Debug("the daemon is in the maintenance mode, skip archiving")
+				continue
+			}
+			if err != nil {
+				app.logger.Error("failed to archive public randomness proofs", zap.Error(err))
+			}
+		case <-app.quit:
+			app.logger.Info("exiting public randomness proof archival loop")
+			return
+		}
+	}
+}
+
+// archivePubRandProofs archives the proofs of all the stored finality providers
+// at heights lower than the last finalized height by at least the retained heights
+func (app *FinalityProviderApp) archivePubRandProofs(ctx context.Context) error {
+	blocks, err := app.cc.QueryLatestFinalizedBlocks(1)
+	if err != nil {
+		return fmt.Errorf("failed to query the latest finalized block: %w", err)
+	}
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	retainHeights := app.config().ArchiveConfig.RetainHeights
+	if blocks[0].Height <= retainHeights {
+		return nil
+	}
+	toHeight := blocks[0].Height - retainHeights
+
+	q := &store.FinalityProviderQuery{Limit: metricsUpdatePageSize}
+	for {
+		fps, nextKey, err := app.fps.QueryFinalityProviders(q)
+		if err != nil {
+			return err
+		}
+
+		for _, fp := range fps {
+			numArchived, err := app.pubRandStore.ArchivePubRandProofs(
+				ctx,
+				app.pubRandArchive,
+				app.config().ArchiveConfig.ObjectPrefix,
+				[]byte(fp.ChainID),
+				fp.GetBIP340BTCPK().MustMarshal(),
+				toHeight,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to archive the proofs of %s: %w", fp.GetBIP340BTCPK().MarshalHex(), err)
+			}
+
+			if numArchived > 0 {
+				app.logger.Info("archived public randomness proofs",
+					zap.String("pk", fp.GetBIP340BTCPK().MarshalHex()),
+					zap.Uint64("to_height", toHeight),
+					zap.Int("num_archived", numArchived),
+				)
+			}
+		}
+
+		if nextKey == nil {
+			return nil
+		}
+		q.StartKey = nextKey
+	}
+}
+
+// GetPubRandProof returns the proof of the public randomness of the finality
+// provider at the given height, which is fetched back from the archive if
+// it has been archived
+func (app *FinalityProviderApp) GetPubRandProof(fpPk *bbntypes.BIP340PubKey, height uint64) ([]byte, error) {
+	fp, err := app.fps.GetFinalityProvider(fpPk.MustToBTCPK())
+	if err != nil {
+		return nil, err
+	}
+
+	chainID := []byte(fp.ChainID)
+	proof, err := app.pubRandStore.GetPubRandProof(chainID, fpPk.MustMarshal(), height)
+	if err == nil || !errors.Is(err, store.ErrPubRandProofNotFound) || app.pubRandArchive == nil {
+		return proof, err
+	}
+
+	return app.pubRandStore.GetArchivedPubRandProof(context.Background(), app.pubRandArchive, chainID, fpPk.MustMarshal(), height)
+}
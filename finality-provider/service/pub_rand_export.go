@@ -0,0 +1,62 @@
+package service
+
+import (
+	"fmt"
+	"math"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+)
+
+// pubRandProofExportPageSize is the number of proofs read from the db at a
+// time, so that a slow client does not hold a read transaction
+const pubRandProofExportPageSize = 1000
+
+// ExportPubRandProofs sends the proofs of the public randomness of the given
+// finality provider at the heights between fromHeight and toHeight
+// inclusive in ascending order, up to the last proof if toHeight is zero.
+// The proofs are read from the db in pages, so that the external verifiers
+// and the backup tools can fetch them incrementally. The proofs moved to
+// the archive are not exported
+func (app *FinalityProviderApp) ExportPubRandProofs(
+	fpPk *bbntypes.BIP340PubKey,
+	fromHeight, toHeight uint64,
+	send func(*store.PubRandProofRecord) error,
+) error {
+	if toHeight == 0 {
+		toHeight = math.MaxUint64
+	}
+	if fromHeight > toHeight {
+		return fmt.Errorf("%w: the start height %d should not be greater than the end height %d",
+			ErrInvalidHeightRange, fromHeight, toHeight)
+	}
+
+	fp, err := app.fps.GetFinalityProvider(fpPk.MustToBTCPK())
+	if err != nil {
+		return fmt.Errorf("failed to get finality provider from db: %w", err)
+	}
+
+	for {
+		proofs, err := app.pubRandStore.ListPubRandProofs(
+			[]byte(fp.ChainID), fpPk.MustMarshal(), fromHeight, toHeight, pubRandProofExportPageSize)
+		if err != nil {
+			return fmt.Errorf("failed to list the public randomness proofs: %w", err)
+		}
+
+		for _, p := range proofs {
+			if err := send(p); err != nil {
+				return err
+			}
+		}
+
+		if len(proofs) < pubRandProofExportPageSize {
+			return nil
+		}
+		last := proofs[len(proofs)-1].Height
+		if last >= toHeight {
+			return nil
+		}
+		fromHeight = last + 1
+	}
+}
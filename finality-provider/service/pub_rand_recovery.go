@@ -0,0 +1,156 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	ftypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// getPubRandProofsByHeights returns the proofs of the public randomness at
+// the given heights. If any of them is missing from the proof store, e.g.,
+// after the db is lost while the EOTS key is kept, the missing proofs are
+// regenerated before retrying
+func (fp *FinalityProviderInstance) getPubRandProofsByHeights(heights []uint64) ([][]byte, error) {
+	proofBytesList, err := fp.pubRandState.getPubRandProofsByHeights(fp.GetChainID(), fp.btcPk.MustMarshal(), heights)
+	if !errors.Is(err, store.ErrPubRandProofNotFound) {
+		return proofBytesList, err
+	}
+
+	fp.logger.Warn("public randomness proofs are missing, regenerating them from the EOTS manager",
+		zap.String("pk", fp.GetBtcPkHex()),
+		zap.Error(err),
+	)
+	if _, err := fp.regenerateMissingPubRandProofs(heights[0]); err != nil {
+		return nil, fmt.Errorf("failed to regenerate the missing public randomness proofs: %w", err)
+	}
+
+	return fp.pubRandState.getPubRandProofsByHeights(fp.GetChainID(), fp.btcPk.MustMarshal(), heights)
+}
+
+// regenerateMissingPubRandProofs repopulates the proofs of the public
+// randomness which is committed on-chain for the heights from startHeight
+// but missing from the proof store. The randomness of each affected
+// commitment is re-derived from the EOTS manager and the rebuilt Merkle tree
+// is checked against the on-chain commitment. It returns the number of the
+// commitments whose proofs are regenerated
+func (fp *FinalityProviderInstance) regenerateMissingPubRandProofs(startHeight uint64) (int, error) {
+	commits, err := fp.committedPubRandSince(startHeight)
+	if err != nil {
+		return 0, err
+	}
+
+	commitStartHeights := make([]uint64, 0, len(commits))
+	for height := range commits {
+		commitStartHeights = append(commitStartHeights, height)
+	}
+	sort.Slice(commitStartHeights, func(i, j int) bool { return commitStartHeights[i] < commitStartHeights[j] })
+
+	numRegenerated := 0
+	for _, commitStartHeight := range commitStartHeights {
+		commit := commits[commitStartHeight]
+		if commit.NumPubRand == 0 {
+			continue
+		}
+		commitEndHeight := commitStartHeight + commit.NumPubRand - 1
+		if commitEndHeight < startHeight {
+			continue
+		}
+
+		missing, err := fp.hasMissingPubRandProofs(max(commitStartHeight, startHeight), commitEndHeight)
+		if err != nil {
+			return numRegenerated, err
+		}
+		if !missing {
+			continue
+		}
+
+		if err := fp.regeneratePubRandProofs(commitStartHeight, commit); err != nil {
+			return numRegenerated, err
+		}
+		numRegenerated++
+
+		fp.logger.Info("regenerated the missing public randomness proofs",
+			zap.String("pk", fp.GetBtcPkHex()),
+			zap.Uint64("start_height", commitStartHeight),
+			zap.Uint64("end_height", commitEndHeight),
+		)
+	}
+
+	return numRegenerated, nil
+}
+
+// committedPubRandSince returns the on-chain commitments of public
+// randomness from the latest one down to the one covering the given height
+func (fp *FinalityProviderInstance) committedPubRandSince(height uint64) (map[uint64]*ftypes.PubRandCommitResponse, error) {
+	// the number of queried commitments is doubled until the lowest one
+	// covers the height or all the commitments are returned
+	for count := uint64(1); ; count *= 2 {
+		commits, err := fp.lastCommittedPublicRandWithRetry(count)
+		if err != nil {
+			return nil, err
+		}
+		if uint64(len(commits)) < count {
+			return commits, nil
+		}
+		for commitStartHeight := range commits {
+			if commitStartHeight <= height {
+				return commits, nil
+			}
+		}
+	}
+}
+
+// hasMissingPubRandProofs returns whether the proof of any height within
+// the given range is missing from the proof store
+func (fp *FinalityProviderInstance) hasMissingPubRandProofs(startHeight, endHeight uint64) (bool, error) {
+	heights := make([]uint64, 0, endHeight-startHeight+1)
+	for h := startHeight; h <= endHeight; h++ {
+		heights = append(heights, h)
+	}
+
+	_, err := fp.pubRandState.getPubRandProofsByHeights(fp.GetChainID(), fp.btcPk.MustMarshal(), heights)
+	switch {
+	case err == nil:
+		return false, nil
+	case errors.Is(err, store.ErrPubRandProofNotFound):
+		return true, nil
+	default:
+		return false, err
+	}
+}
+
+// regeneratePubRandProofs re-derives the public randomness of the given
+// commitment, verifies the rebuilt commitment against the on-chain one and
+// saves the proofs
+func (fp *FinalityProviderInstance) regeneratePubRandProofs(startHeight uint64, commit *ftypes.PubRandCommitResponse) error {
+	if commit.NumPubRand > math.MaxUint32 {
+		return fmt.Errorf("too many public randomness to regenerate: %d", commit.NumPubRand)
+	}
+
+	// #nosec G115 -- performed the conversion check above
+	pubRandList, err := fp.getPubRandList(startHeight, uint32(commit.NumPubRand))
+	if err != nil {
+		return fmt.Errorf("failed to re-derive the public randomness: %w", err)
+	}
+
+	commitment, proofList := types.GetPubRandCommitAndProofs(pubRandList)
+	if !bytes.Equal(commitment, commit.Commitment) {
+		return fmt.Errorf("%w: start height %d", ErrPubRandCommitmentMismatch, startHeight)
+	}
+
+	if err := fp.pubRandState.addPubRandProofList(
+		fp.GetChainID(), fp.btcPk.MustMarshal(), startHeight, commit.NumPubRand, proofList,
+	); err != nil {
+		return fmt.Errorf("failed to save the regenerated public randomness proofs: %w", err)
+	}
+
+	return nil
+}
@@ -15,16 +15,32 @@ func newPubRandState(s *store.PubRandProofStore) *pubRandState {
 }
 
 func (st *pubRandState) addPubRandProofList(
-	pubRandList []*btcec.FieldVal,
+	chainID []byte,
+	pk []byte,
+	height uint64,
+	numPubRand uint64,
 	proofList []*merkle.Proof,
 ) error {
-	return st.s.AddPubRandProofList(pubRandList, proofList)
+	return st.s.AddPubRandProofList(chainID, pk, height, numPubRand, proofList)
+}
+
+func (st *pubRandState) getPubRandProof(chainID []byte, pk []byte, height uint64) ([]byte, error) {
+	return st.s.GetPubRandProof(chainID, pk, height)
 }
 
-func (st *pubRandState) getPubRandProof(pubRand *btcec.FieldVal) ([]byte, error) {
-	return st.s.GetPubRandProof(pubRand)
+func (st *pubRandState) getPubRandProofsByHeights(chainID []byte, pk []byte, heights []uint64) ([][]byte, error) {
+	return st.s.GetPubRandProofsByHeights(chainID, pk, heights)
 }
 
-func (st *pubRandState) getPubRandProofList(pubRandList []*btcec.FieldVal) ([][]byte, error) {
-	return st.s.GetPubRandProofList(pubRandList)
+func (st *pubRandState) hasLegacyPubRandProofs() (bool, error) {
+	return st.s.HasLegacyPubRandProofs()
+}
+
+func (st *pubRandState) migrateLegacyPubRandProofs(
+	chainID []byte,
+	pk []byte,
+	startHeight uint64,
+	pubRandList []*btcec.FieldVal,
+) (int, error) {
+	return st.s.MigrateLegacyPubRandProofs(chainID, pk, startHeight, pubRandList)
 }
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+)
+
+// RecoverFinalityProvider reconstructs the stored finality provider from the
+// on-chain data, e.g., after the db is lost while the EOTS key is kept. The
+// key with the given name must be the one of the finality provider address.
+// The last voted height is searched within the last scanBlocks blocks, it is
+// left as zero if no vote is found, in which case the finality provider
+// has not voted for any of these blocks and can safely vote for them
+func (app *FinalityProviderApp) RecoverFinalityProvider(
+	fpPk *bbntypes.BIP340PubKey,
+	keyName string,
+	scanBlocks uint64,
+) (*proto.FinalityProviderInfo, error) {
+	btcPk := fpPk.MustToBTCPK()
+
+	if _, err := app.fps.GetFinalityProvider(btcPk); err == nil {
+		return nil, fmt.Errorf("%w: %s", store.ErrFinalityProviderExists, fpPk.MarshalHex())
+	} else if !errors.Is(err, store.ErrFinalityProviderNotFound) {
+		return nil, err
+	}
+
+	res, err := app.cc.QueryFinalityProvider(btcPk)
+	if err != nil {
+		return nil, err
+	}
+	fpRes := res.FinalityProvider
+	if fpRes.Pop == nil || fpRes.Description == nil || fpRes.Commission == nil {
+		return nil, fmt.Errorf("the finality provider %s returned by the chain is incomplete", fpPk.MarshalHex())
+	}
+
+	keyRecord, err := app.kr.Key(keyName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the key %s: %w", keyName, err)
+	}
+	keyAddr, err := keyRecord.GetAddress()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the address of the key %s: %w", keyName, err)
+	}
+	if keyAddr.String() != fpRes.Addr {
+		return nil, fmt.Errorf("the address %s of the key %s does not match the finality provider address %s",
+			keyAddr.String(), keyName, fpRes.Addr)
+	}
+
+	chainID, err := app.cc.QueryNodeChainID()
+	if err != nil {
+		return nil, err
+	}
+
+	tip, err := app.cc.QueryBestBlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the best block: %w", err)
+	}
+
+	status, err := app.queryRecoveredStatus(btcPk, fpRes.SlashedBabylonHeight > 0 || fpRes.SlashedBtcHeight > 0,
+		fpRes.Jailed, tip.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	lastVotedHeight, err := app.queryLastVotedHeight(fpPk, tip.Height, scanBlocks)
+	if err != nil {
+		return nil, err
+	}
+
+	sfp := &store.StoredFinalityProvider{
+		FPAddr:          fpRes.Addr,
+		BtcPk:           btcPk,
+		Description:     fpRes.Description,
+		Commission:      fpRes.Commission,
+		Pop:             &proto.ProofOfPossession{BtcSig: fpRes.Pop.BtcSig},
+		KeyName:         keyName,
+		ChainID:         chainID,
+		LastVotedHeight: lastVotedHeight,
+		Status:          status,
+	}
+	if err := app.fps.SaveRecoveredFinalityProvider(sfp); err != nil {
+		return nil, fmt.Errorf("failed to save the recovered finality provider: %w", err)
+	}
+
+	app.logger.Info("recovered the finality provider from the chain",
+		zap.String("pk", fpPk.MarshalHex()),
+		zap.String("addr", sfp.FPAddr),
+		zap.String("status", status.String()),
+		zap.Uint64("last_voted_height", lastVotedHeight),
+	)
+
+	return sfp.ToFinalityProviderInfo(), nil
+}
+
+// queryRecoveredStatus determines the status of a recovered finality
+// provider from its on-chain state and its voting power at the tip
+func (app *FinalityProviderApp) queryRecoveredStatus(
+	btcPk *btcec.PublicKey,
+	slashed, jailed bool,
+	tipHeight uint64,
+) (proto.FinalityProviderStatus, error) {
+	if slashed {
+		return proto.FinalityProviderStatus_SLASHED, nil
+	}
+	if jailed {
+		return proto.FinalityProviderStatus_JAILED, nil
+	}
+
+	power, err := app.cc.QueryFinalityProviderVotingPower(btcPk, tipHeight)
+	if err != nil {
+		return 0, err
+	}
+	if power > 0 {
+		return proto.FinalityProviderStatus_ACTIVE, nil
+	}
+
+	return proto.FinalityProviderStatus_REGISTERED, nil
+}
+
+// queryLastVotedHeight returns the highest height among the last scanBlocks
+// blocks up to the tip for which the finality provider has voted, zero if
+// there is none
+func (app *FinalityProviderApp) queryLastVotedHeight(
+	fpPk *bbntypes.BIP340PubKey,
+	tipHeight, scanBlocks uint64,
+) (uint64, error) {
+	var lowest uint64 = 1
+	if tipHeight > scanBlocks {
+		lowest = tipHeight - scanBlocks + 1
+	}
+
+	for height := tipHeight; height >= lowest && height > 0; height-- {
+		voters, err := app.cc.QueryVotesAtHeight(height)
+		if err != nil {
+			return 0, err
+		}
+		if slices.ContainsFunc(voters, func(pk bbntypes.BIP340PubKey) bool { return pk.Equals(fpPk) }) {
+			return height, nil
+		}
+	}
+
+	return 0, nil
+}
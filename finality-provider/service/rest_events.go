@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+)
+
+const (
+	// sseKeepAliveInterval is the interval of the comments sent on the idle
+	// event streams, so that the proxies do not close them
+	sseKeepAliveInterval = 15 * time.Second
+
+	// sseAccessTokenParam is the query parameter of the auth token, as the
+	// browsers cannot set the header of an event stream
+	sseAccessTokenParam = "access_token"
+)
+
+// handleEvents streams the events of the finality providers as Server-Sent
+// Events. The events are subscribed through the SubscribeEvents RPC, so
+// that the subscription goes through the same interceptors as the other
+// endpoints, and filtered with the same query parameters, i.e., btc_pk and
+// the repeated types. Each event is sent with its type as the event name
+// and its JSON encoding as the data
+func (rg *restGateway) handleEvents(gwMux *runtime.ServeMux) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		query := r.URL.Query()
+		req := &proto.SubscribeEventsRequest{
+			BtcPk: query.Get("btc_pk"),
+			Types: query["types"],
+		}
+
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+
+		auth := r.Header.Get("Authorization")
+		if token := query.Get(sseAccessTokenParam); auth == "" && token != "" {
+			auth = authSchemePrefix + token
+		}
+		if auth != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, authMetadataKey, auth)
+		}
+
+		stream, err := rg.eventsClient.SubscribeEvents(ctx, req)
+		if err != nil {
+			writeGrpcError(w, err)
+			return
+		}
+		// the header is only received once subscribed, otherwise the
+		// subscription failed with the error returned by Recv
+		if md, _ := stream.Header(); md == nil {
+			_, err := stream.Recv()
+			writeGrpcError(w, err)
+			return
+		}
+
+		// the write timeout of the server does not apply to the stream
+		rc := http.NewResponseController(w)
+		if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+			rg.logger.Debug("failed to clear the write deadline of the event stream", zap.Error(err))
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		if err := rc.Flush(); err != nil {
+			rg.logger.Debug("failed to flush the event stream", zap.Error(err))
+			return
+		}
+
+		events := make(chan *proto.FinalityProviderEvent)
+		errChan := make(chan error, 1)
+		go func() {
+			for {
+				ev, err := stream.Recv()
+				if err != nil {
+					errChan <- err
+					return
+				}
+				select {
+				case events <- ev:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		_, marshaler := runtime.MarshalerForRequest(gwMux, r)
+		ticker := time.NewTicker(sseKeepAliveInterval)
+		defer ticker.Stop()
+
+		for {
+			var err error
+			select {
+			case ev := <-events:
+				var data []byte
+				data, err = marshaler.Marshal(ev)
+				if err != nil {
+					rg.logger.Error("failed to encode the event", zap.Error(err))
+					return
+				}
+				_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", ev.Type, data)
+			case <-ticker.C:
+				_, err = fmt.Fprint(w, ": keep-alive\n\n")
+			case err := <-errChan:
+				if status.Code(err) != codes.Canceled {
+					rg.logger.Debug("the event stream ended", zap.Error(err))
+				}
+				return
+			case <-rg.quit:
+				return
+			case <-ctx.Done():
+				return
+			}
+
+			if err == nil {
+				err = rc.Flush()
+			}
+			if err != nil {
+				rg.logger.Debug("failed to write the event stream", zap.Error(err))
+				return
+			}
+		}
+	}
+}
+
+// writeGrpcError writes the message of the gRPC error with the
+// corresponding HTTP status code
+func writeGrpcError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	http.Error(w, st.Message(), runtime.HTTPStatusFromCode(st.Code()))
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+)
+
+// restGateway serves the read-only RPCs as JSON over HTTP by proxying them
+// to the gRPC server, so that they go through the same interceptors
+type restGateway struct {
+	cfg    *fpcfg.RestGatewayConfig
+	logger *zap.Logger
+
+	httpServer *http.Server
+	cancel     context.CancelFunc
+
+	// eventsConn is the connection of the event streams to the gRPC server
+	eventsConn   *grpc.ClientConn
+	eventsClient proto.FinalityProvidersClient
+
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+func newRestGateway(cfg *fpcfg.RestGatewayConfig, logger *zap.Logger) *restGateway {
+	return &restGateway{
+		cfg:    cfg,
+		logger: logger,
+		quit:   make(chan struct{}),
+	}
+}
+
+// Start registers the gateway against the gRPC server at the given address
+// and starts serving it on the configured address. The auth token of the
+// HTTP requests, if any, is forwarded to the gRPC server
+func (rg *restGateway) Start(grpcAddr string, authCfg *fpcfg.RPCAuthConfig) error {
+	creds := insecure.NewCredentials()
+	if authCfg != nil && authCfg.TLSEnabled {
+		tlsCreds, err := credentials.NewClientTLSFromFile(authCfg.TLSCertPath, tlsServerName(grpcAddr))
+		if err != nil {
+			return fmt.Errorf("failed to load the TLS certificate of the RPC listener: %w", err)
+		}
+		creds = tlsCreds
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	gwMux := runtime.NewServeMux()
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	if err := proto.RegisterFinalityProvidersHandlerFromEndpoint(ctx, gwMux, grpcAddr, opts); err != nil {
+		cancel()
+		return fmt.Errorf("failed to register the REST gateway: %w", err)
+	}
+
+	eventsConn, err := grpc.NewClient(grpcAddr, opts...)
+	if err != nil {
+		cancel()
+		return fmt.Errorf("failed to connect the event stream to the RPC listener: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/v1/", gwMux)
+	mux.HandleFunc("/v1/events", rg.handleEvents(gwMux))
+	mux.HandleFunc("/openapi.json", rg.handleOpenAPISpec)
+
+	lis, err := net.Listen("tcp", rg.cfg.ListenAddr)
+	if err != nil {
+		cancel()
+		_ = eventsConn.Close()
+		return fmt.Errorf("failed to listen on %s: %w", rg.cfg.ListenAddr, err)
+	}
+
+	rg.cancel = cancel
+	rg.eventsConn = eventsConn
+	rg.eventsClient = proto.NewFinalityProvidersClient(eventsConn)
+	rg.httpServer = &http.Server{
+		Handler:           rg.withCORS(mux),
+		ReadHeaderTimeout: 2 * time.Second,
+		ReadTimeout:       5 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		IdleTimeout:       30 * time.Second,
+	}
+
+	rg.wg.Add(1)
+	go func() {
+		defer rg.wg.Done()
+		rg.logger.Info("REST gateway is starting", zap.String("addr", rg.cfg.ListenAddr))
+		if err := rg.httpServer.Serve(lis); err != nil && err != http.ErrServerClosed {
+			rg.logger.Error("REST gateway failed", zap.Error(err))
+		}
+	}()
+
+	return nil
+}
+
+// tlsServerName returns the name against which the TLS certificate of the
+// gRPC server at the given address is verified, i.e., the host of the
+// address, or localhost if the server listens on all the interfaces
+func tlsServerName(grpcAddr string) string {
+	host, _, err := net.SplitHostPort(grpcAddr)
+	if err != nil || host == "" {
+		return "localhost"
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsUnspecified() {
+		return "localhost"
+	}
+
+	return host
+}
+
+// Stop ends the event streams, shuts down the gateway and closes its
+// connections to the gRPC server
+func (rg *restGateway) Stop(ctx context.Context) {
+	// the event streams never become idle, so they are ended first for the
+	// shutdown not to wait for them
+	close(rg.quit)
+	if err := rg.httpServer.Shutdown(ctx); err != nil {
+		rg.logger.Error("REST gateway shutdown failed", zap.Error(err))
+	}
+	rg.cancel()
+	if err := rg.eventsConn.Close(); err != nil {
+		rg.logger.Debug("failed to close the connection of the event streams", zap.Error(err))
+	}
+	rg.wg.Wait()
+}
+
+// withCORS allows the cross-origin requests from the configured origins
+// and answers their preflight requests
+func (rg *restGateway) withCORS(next http.Handler) http.Handler {
+	if len(rg.cfg.CORSAllowedOrigins) == 0 {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" || !slices.ContainsFunc(rg.cfg.CORSAllowedOrigins, func(allowed string) bool {
+			return allowed == "*" || allowed == origin
+		}) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Add("Vary", "Origin")
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (rg *restGateway) handleOpenAPISpec(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(proto.OpenAPISpec); err != nil {
+		rg.logger.Debug("failed to write the OpenAPI spec", zap.Error(err))
+	}
+}
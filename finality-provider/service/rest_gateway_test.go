@@ -0,0 +1,33 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestTLSServerName tests that the REST gateway verifies the TLS certificate
+// of the gRPC server against the host of its listener
+func TestTLSServerName(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		addr       string
+		serverName string
+	}{
+		{addr: "127.0.0.1:12581", serverName: "127.0.0.1"},
+		{addr: "[::1]:12581", serverName: "::1"},
+		{addr: "10.0.0.5:12581", serverName: "10.0.0.5"},
+		{addr: "fpd.example.com:12581", serverName: "fpd.example.com"},
+		{addr: "0.0.0.0:12581", serverName: "localhost"},
+		{addr: "[::]:12581", serverName: "localhost"},
+		{addr: ":12581", serverName: "localhost"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.addr, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tc.serverName, tlsServerName(tc.addr))
+		})
+	}
+}
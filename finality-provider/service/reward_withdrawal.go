@@ -0,0 +1,179 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/audit"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// ErrNoWithdrawableRewards is returned if there is no reward to withdraw
+var ErrNoWithdrawableRewards = errors.New("no withdrawable finality provider rewards")
+
+// rewardWithdrawalLoop periodically withdraws the accumulated finality
+// provider rewards once they reach the configured threshold
+func (app *FinalityProviderApp) rewardWithdrawalLoop() {
+	defer app.wg.Done()
+
+	cfg := app.config().RewardWithdrawalConfig
+	app.logger.Info("starting reward withdrawal loop",
+		zap.Float64("interval seconds", cfg.Interval.Seconds()),
+		zap.String("threshold", cfg.Threshold),
+		zap.String("recipient", cfg.Recipient),
+	)
+	withdrawalTicker := time.NewTicker(cfg.Interval)
+	defer withdrawalTicker.Stop()
+
+	for {
+		select {
+		case <-withdrawalTicker.C:
+			rewards, err := app.cc.QueryFinalityProviderRewards()
+			if err != nil {
+				app.logger.Error("failed to query the withdrawable rewards", zap.Error(err))
+				continue
+			}
+			if rewards.IsZero() || !rewards.IsAllGTE(cfg.ThresholdCoins()) {
+				app.logger.Debug("the withdrawable rewards are below the threshold, skip withdrawing",
+					zap.String("rewards", rewards.String()),
+					zap.String("threshold", cfg.Threshold),
+				)
+				continue
+			}
+
+			var txHash string
+			if !app.fpManager.maintenance.do(func() {
+				txHash, err = app.withdrawRewards(rewards, cfg.Recipient)
+			}) {
+				app.logger.Debug("the daemon is in the maintenance mode, skip withdrawing")
+				continue
+			}
+			if err != nil {
+				app.logger.Error("failed to withdraw the rewards", zap.Error(err))
+				continue
+			}
+			app.logger.Info("successfully withdrew the rewards",
+				zap.String("amount", rewards.String()),
+				zap.String("recipient", cfg.Recipient),
+				zap.String("tx_hash", txHash),
+			)
+		case <-app.quit:
+			app.logger.Info("exiting reward withdrawal loop")
+			return
+		}
+	}
+}
+
+// WithdrawRewards withdraws all the accumulated finality provider rewards
+// and sends them to the given recipient, or the configured one if empty.
+// It returns the hash of the transaction and the withdrawn amount
+func (app *FinalityProviderApp) WithdrawRewards(recipient string) (string, sdk.Coins, error) {
+	rewards, err := app.cc.QueryFinalityProviderRewards()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to query the withdrawable rewards: %w", err)
+	}
+	if rewards.IsZero() {
+		return "", nil, ErrNoWithdrawableRewards
+	}
+
+	if recipient == "" {
+		recipient = app.config().RewardWithdrawalConfig.Recipient
+	}
+
+	txHash, err := app.withdrawRewards(rewards, recipient)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return txHash, rewards, nil
+}
+
+// QueryRewards returns the withdrawable rewards of the finality provider with
+// the given EOTS public key along with the Babylon address they accrue to
+func (app *FinalityProviderApp) QueryRewards(fpPk *bbntypes.BIP340PubKey) (string, sdk.Coins, error) {
+	sfp, err := app.fps.GetFinalityProvider(fpPk.MustToBTCPK())
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get finality provider from db: %w", err)
+	}
+
+	rewards, err := app.cc.QueryRewardsOfFinalityProvider(fpPk.MustToBTCPK())
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to query the withdrawable rewards: %w", err)
+	}
+
+	return sfp.FPAddr, rewards, nil
+}
+
+// WithdrawFinalityProviderRewards withdraws the rewards of the finality
+// provider with the given EOTS public key and sends them to the given
+// recipient, or the configured one if empty. The Babylon address of the
+// finality provider must be the key signing the transactions. It returns the
+// hash of the transaction and the withdrawn amount
+func (app *FinalityProviderApp) WithdrawFinalityProviderRewards(
+	fpPk *bbntypes.BIP340PubKey,
+	recipient string,
+) (string, sdk.Coins, error) {
+	_, rewards, err := app.QueryRewards(fpPk)
+	if err != nil {
+		return "", nil, err
+	}
+	if rewards.IsZero() {
+		return "", nil, ErrNoWithdrawableRewards
+	}
+
+	if recipient == "" {
+		recipient = app.config().RewardWithdrawalConfig.Recipient
+	}
+
+	res, err := app.cc.WithdrawRewardsOfFinalityProvider(fpPk.MustToBTCPK(), rewards, recipient)
+	app.recordWithdrawalAudit(fpPk.MarshalHex(), rewards, recipient, res, err)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to send the withdraw reward transaction: %w", err)
+	}
+	app.recordWithdrawnRewards(rewards)
+
+	return res.TxHash, rewards, nil
+}
+
+func (app *FinalityProviderApp) withdrawRewards(rewards sdk.Coins, recipient string) (string, error) {
+	res, err := app.cc.WithdrawFinalityProviderRewards(rewards, recipient)
+	app.recordWithdrawalAudit("", rewards, recipient, res, err)
+	if err != nil {
+		return "", fmt.Errorf("failed to send the withdraw reward transaction: %w", err)
+	}
+	app.recordWithdrawnRewards(rewards)
+
+	return res.TxHash, nil
+}
+
+func (app *FinalityProviderApp) recordWithdrawnRewards(rewards sdk.Coins) {
+	for _, coin := range rewards {
+		amount, _ := coin.Amount.ToLegacyDec().Float64()
+		app.metrics.AddWithdrawnRewards(coin.Denom, amount)
+	}
+}
+
+// recordWithdrawalAudit records the reward withdrawal of the given finality
+// provider, or of the finality provider of the signing key if empty, in the
+// audit log
+func (app *FinalityProviderApp) recordWithdrawalAudit(
+	fpPkHex string,
+	rewards sdk.Coins,
+	recipient string,
+	res *types.TxResponse,
+	err error,
+) {
+	details := map[string]string{"rewards": rewards.String(), "recipient": recipient}
+	if fpPkHex != "" {
+		details["fp_btc_pk_hex"] = fpPkHex
+	}
+	if res != nil {
+		details["tx_hash"] = res.TxHash
+	}
+	app.fpManager.audit.record(audit.OpWithdrawRewards, details, err)
+}
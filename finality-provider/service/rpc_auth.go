@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+const (
+	tlsCertValidity   = 14 * 30 * 24 * time.Hour
+	tlsCertOrg        = "fpd autogenerated cert"
+	authTokenNumBytes = 32
+
+	// authMetadataKey is the key of the metadata holding the auth token,
+	// prefixed by authSchemePrefix
+	authMetadataKey  = "authorization"
+	authSchemePrefix = "Bearer "
+
+	// grpcHealthServicePrefix is the prefix of the methods of the gRPC
+	// health service, which stay reachable without the auth token for the
+	// probes
+	grpcHealthServicePrefix = "/grpc.health.v1.Health/"
+)
+
+// readOnlyServicePrefixes are the prefixes of the methods of the read-only
+// service and of the reflection service, which are reachable with the
+// read-only auth token
+var readOnlyServicePrefixes = []string{
+	"/" + proto.FinalityProviders_ServiceDesc.ServiceName + "/",
+	"/grpc.reflection.v1.ServerReflection/",
+	"/grpc.reflection.v1alpha.ServerReflection/",
+}
+
+// serverTLSConfig returns the TLS config of the RPC listener, generating a
+// self-signed certificate if neither the certificate nor the key exists
+func serverTLSConfig(cfg *fpcfg.RPCAuthConfig) (*tls.Config, error) {
+	certExists, keyExists := util.FileExists(cfg.TLSCertPath), util.FileExists(cfg.TLSKeyPath)
+	if certExists != keyExists {
+		return nil, fmt.Errorf("only one of the TLS certificate %s and key %s exists", cfg.TLSCertPath, cfg.TLSKeyPath)
+	}
+	if !certExists {
+		if err := generateTLSCert(cfg); err != nil {
+			return nil, fmt.Errorf("failed to generate the TLS certificate: %w", err)
+		}
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.TLSCertPath, cfg.TLSKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the TLS certificate: %w", err)
+	}
+	if cert.Leaf != nil && time.Now().After(cert.Leaf.NotAfter) {
+		return nil, fmt.Errorf("the TLS certificate %s expired on %s, remove it along with its key to generate a new one",
+			cfg.TLSCertPath, cert.Leaf.NotAfter)
+	}
+
+	tlsCfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if cfg.ClientCAPath != "" {
+		caPEM, err := os.ReadFile(cfg.ClientCAPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the client CA certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no valid certificate in the client CA file %s", cfg.ClientCAPath)
+		}
+		tlsCfg.ClientCAs = pool
+		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsCfg, nil
+}
+
+// generateTLSCert writes a self-signed certificate valid for the loopback
+// addresses and the extra domains and IPs of the config, along with its key
+func generateTLSCert(cfg *fpcfg.RPCAuthConfig) error {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return err
+	}
+
+	host, err := os.Hostname()
+	if err != nil {
+		host = "localhost"
+	}
+
+	dnsNames := append([]string{"localhost", host}, cfg.TLSExtraDomains...)
+	ips := []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback}
+	for _, ipStr := range cfg.TLSExtraIPs {
+		ip := net.ParseIP(ipStr)
+		if ip == nil {
+			return fmt.Errorf("invalid extra IP address %s", ipStr)
+		}
+		ips = append(ips, ip)
+	}
+
+	now := time.Now()
+	template := x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{tlsCertOrg}, CommonName: host},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(tlsCertValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		DNSNames:              dnsNames,
+		IPAddresses:           ips,
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, &template, &template, &priv.PublicKey, priv)
+	if err != nil {
+		return err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(priv)
+	if err != nil {
+		return err
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	if err := os.WriteFile(cfg.TLSCertPath, certPEM, 0644); err != nil {
+		return err
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return os.WriteFile(cfg.TLSKeyPath, keyPEM, 0600)
+}
+
+// loadOrGenerateAuthToken returns the auth token stored at the given path,
+// generating a random one if the file does not exist
+func loadOrGenerateAuthToken(path string) (string, error) {
+	if util.FileExists(path) {
+		tokenBytes, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read the auth token: %w", err)
+		}
+		token := strings.TrimSpace(string(tokenBytes))
+		if token == "" {
+			return "", fmt.Errorf("the auth token file %s is empty", path)
+		}
+
+		return token, nil
+	}
+
+	tokenBytes := make([]byte, authTokenNumBytes)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", fmt.Errorf("failed to generate the auth token: %w", err)
+	}
+	token := hex.EncodeToString(tokenBytes)
+	if err := os.WriteFile(path, []byte(token+"\n"), 0600); err != nil {
+		return "", fmt.Errorf("failed to write the auth token: %w", err)
+	}
+
+	return token, nil
+}
+
+// tokenAuthenticator rejects the requests which do not carry the auth token,
+// or the read-only auth token for the methods of the read-only service. The
+// scoped auth tokens are further restricted to their finality providers
+type tokenAuthenticator struct {
+	token         []byte
+	readOnlyToken []byte
+	scopedTokens  []*scopedToken
+}
+
+func newTokenAuthenticator(token, readOnlyToken string, scopedTokens []*scopedToken) *tokenAuthenticator {
+	return &tokenAuthenticator{
+		token:         []byte(token),
+		readOnlyToken: []byte(readOnlyToken),
+		scopedTokens:  scopedTokens,
+	}
+}
+
+// authenticate returns the context of the request along with the scope of
+// its auth token, if scoped
+func (ta *tokenAuthenticator) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if strings.HasPrefix(method, grpcHealthServicePrefix) {
+		return ctx, nil
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing the auth token")
+	}
+
+	readOnly := slices.ContainsFunc(readOnlyServicePrefixes, func(prefix string) bool {
+		return strings.HasPrefix(method, prefix)
+	})
+	for _, v := range md.Get(authMetadataKey) {
+		token, found := strings.CutPrefix(v, authSchemePrefix)
+		if !found {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(token), ta.token) == 1 {
+			return ctx, nil
+		}
+		if subtle.ConstantTimeCompare([]byte(token), ta.readOnlyToken) == 1 {
+			if readOnly {
+				return ctx, nil
+			}
+			return nil, status.Error(codes.PermissionDenied, "the read-only auth token does not grant access to the admin service")
+		}
+		for _, st := range ta.scopedTokens {
+			if subtle.ConstantTimeCompare([]byte(token), st.token) != 1 {
+				continue
+			}
+			if st.scope.readOnly && !readOnly {
+				return nil, status.Errorf(codes.PermissionDenied, "the read-only auth token %s does not grant access to the admin service", st.scope.name)
+			}
+			return contextWithRPCScope(ctx, st.scope), nil
+		}
+	}
+
+	return nil, status.Error(codes.Unauthenticated, "invalid or missing auth token")
+}
+
+// unaryInterceptor authenticates the unary requests and checks that the
+// finality provider of the request is within the scope of the auth token
+func (ta *tokenAuthenticator) unaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	ctx, err := ta.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRPCScope(ctx, info.FullMethod, req); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// streamInterceptor authenticates the stream requests, the stream handlers
+// check the scope of the auth token themselves
+func (ta *tokenAuthenticator) streamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	ctx, err := ta.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	if err := checkRPCScope(ctx, info.FullMethod, nil); err != nil {
+		return err
+	}
+
+	return handler(srv, &scopedServerStream{ServerStream: ss, ctx: ctx})
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+)
+
+// tlsHandshake connects to the listener with the given server name, trusting
+// the given certificate only
+func tlsHandshake(t *testing.T, addr string, certPEM []byte, serverName string) error {
+	pool := x509.NewCertPool()
+	require.True(t, pool.AppendCertsFromPEM(certPEM))
+
+	conn, err := tls.Dial("tcp", addr, &tls.Config{
+		RootCAs:    pool,
+		ServerName: serverName,
+		MinVersion: tls.VersionTLS12,
+	})
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
+
+// TestGenerateTLSCert tests that the generated self-signed certificate is
+// valid for the loopback addresses and the extra domains and IPs of the
+// config, that its key is only readable by the owner and that the clients
+// trusting it complete the handshake
+func TestGenerateTLSCert(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg := &fpcfg.RPCAuthConfig{
+		TLSEnabled:      true,
+		TLSCertPath:     filepath.Join(dir, "tls.cert"),
+		TLSKeyPath:      filepath.Join(dir, "tls.key"),
+		TLSExtraDomains: []string{"fpd.example.com"},
+		TLSExtraIPs:     []string{"10.0.0.5"},
+	}
+	tlsCfg, err := serverTLSConfig(cfg)
+	require.NoError(t, err)
+
+	keyInfo, err := os.Stat(cfg.TLSKeyPath)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), keyInfo.Mode().Perm())
+	certInfo, err := os.Stat(cfg.TLSCertPath)
+	require.NoError(t, err)
+	require.Zero(t, certInfo.Mode().Perm()&0022)
+
+	require.Len(t, tlsCfg.Certificates, 1)
+	cert, err := x509.ParseCertificate(tlsCfg.Certificates[0].Certificate[0])
+	require.NoError(t, err)
+	host, err := os.Hostname()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"localhost", host, "fpd.example.com"}, cert.DNSNames)
+	var ips []string
+	for _, ip := range cert.IPAddresses {
+		ips = append(ips, ip.String())
+	}
+	require.ElementsMatch(t, []string{"127.0.0.1", "::1", "10.0.0.5"}, ips)
+
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", tlsCfg)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, lis.Close())
+	})
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			// the handshake is completed by the first read
+			go func(conn net.Conn) {
+				_, _ = conn.Read(make([]byte, 1))
+				_ = conn.Close()
+			}(conn)
+		}
+	}()
+
+	certPEM, err := os.ReadFile(cfg.TLSCertPath)
+	require.NoError(t, err)
+	for _, serverName := range []string{"localhost", "127.0.0.1", "fpd.example.com", "10.0.0.5"} {
+		require.NoError(t, tlsHandshake(t, lis.Addr().String(), certPEM, serverName), serverName)
+	}
+	require.Error(t, tlsHandshake(t, lis.Addr().String(), certPEM, "other.example.com"))
+
+	// the existing certificate is kept
+	tlsCfg, err = serverTLSConfig(cfg)
+	require.NoError(t, err)
+	require.Equal(t, cert.Raw, tlsCfg.Certificates[0].Certificate[0])
+
+	// the certificate cannot be generated if only its key exists
+	require.NoError(t, os.Remove(cfg.TLSCertPath))
+	_, err = serverTLSConfig(cfg)
+	require.Error(t, err)
+
+	// nor with an invalid extra IP
+	invalidCfg := *cfg
+	invalidCfg.TLSCertPath = filepath.Join(dir, "invalid.cert")
+	invalidCfg.TLSKeyPath = filepath.Join(dir, "invalid.key")
+	invalidCfg.TLSExtraIPs = []string{"not-an-ip"}
+	_, err = serverTLSConfig(&invalidCfg)
+	require.Error(t, err)
+}
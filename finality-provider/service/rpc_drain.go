@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// isDraining returns whether the RPC server is stopping, in which case the
+// new requests are rejected while the in-flight ones complete
+func (r *rpcServer) isDraining() bool {
+	select {
+	case <-r.quit:
+		return true
+	default:
+		return false
+	}
+}
+
+// drainUnaryInterceptor rejects the requests received once the RPC server
+// is stopping as unavailable, except for the health checks which report
+// the server as not serving meanwhile
+func (r *rpcServer) drainUnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if r.isDraining() && !strings.HasPrefix(info.FullMethod, grpcHealthServicePrefix) {
+		return nil, fmt.Errorf("the RPC server is draining: %w", ErrAppShuttingDown)
+	}
+
+	return handler(ctx, req)
+}
+
+// drainStreamInterceptor rejects the streams opened once the RPC server is
+// stopping as unavailable, the open ones are ended by their handlers
+func (r *rpcServer) drainStreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if r.isDraining() && !strings.HasPrefix(info.FullMethod, grpcHealthServicePrefix) {
+		return fmt.Errorf("the RPC server is draining: %w", ErrAppShuttingDown)
+	}
+
+	return handler(srv, ss)
+}
+
+// drainGrpcServers stops the given gRPC servers gracefully, so that the
+// in-flight requests complete, and cancels the requests still running
+// after the timeout
+func drainGrpcServers(timeout time.Duration, logger *zap.Logger, grpcServers ...*grpc.Server) {
+	var wg sync.WaitGroup
+	for _, grpcServer := range grpcServers {
+		wg.Add(1)
+		go func(grpcServer *grpc.Server) {
+			defer wg.Done()
+			grpcServer.GracefulStop()
+		}(grpcServer)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		logger.Info("drained the RPC requests")
+	case <-timer.C:
+		logger.Warn("the RPC requests did not complete within the drain timeout, cancelling them",
+			zap.Duration("rpc_drain_timeout", timeout))
+		for _, grpcServer := range grpcServers {
+			grpcServer.Stop()
+		}
+		<-done
+	}
+}
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/audit"
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/errreport"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/metrics"
+)
+
+const (
+	// requestIDMetadataKey is the key of the metadata holding the
+	// correlation id of a request, which is taken from the request if set
+	// and returned in the header of the response
+	requestIDMetadataKey = "x-request-id"
+	requestIDNumBytes    = 8
+	maxRequestIDLen      = 64
+)
+
+// adminServicePrefix is the prefix of the methods of the admin service,
+// whose calls are recorded in the audit log
+var adminServicePrefix = "/" + proto.FinalityProvidersAdmin_ServiceDesc.ServiceName + "/"
+
+// rpcInterceptors log the RPC requests along with their correlation ids,
+// record their per-method metrics, enforce the rate limit and recover from
+// the panics of the handlers, which are returned as codes.Internal instead
+// of crashing the daemon. They are the outermost of the chain, so that the
+// status codes are the ones returned to the clients
+type rpcInterceptors struct {
+	cfg     *fpcfg.RPCInterceptorConfig
+	metrics *metrics.FpMetrics
+	logger  *zap.Logger
+	// limiter is nil if the rate limit is disabled
+	limiter *rpcRateLimiter
+	// audit records the calls to the admin service, it is nil if the audit
+	// log is disabled
+	audit *auditRecorder
+	// errReporters ships the panics of the handlers to the error reporters
+	errReporters *errorReporters
+}
+
+func newRPCInterceptors(
+	cfg *fpcfg.RPCInterceptorConfig,
+	metrics *metrics.FpMetrics,
+	recorder *auditRecorder,
+	reporters *errorReporters,
+	logger *zap.Logger,
+) *rpcInterceptors {
+	ri := &rpcInterceptors{
+		cfg:          cfg,
+		metrics:      metrics,
+		logger:       logger,
+		audit:        recorder,
+		errReporters: reporters,
+	}
+	if cfg.RateLimit > 0 {
+		ri.limiter = newRPCRateLimiter(cfg.RateLimit, cfg.RateLimitBurst)
+	}
+
+	return ri
+}
+
+func (ri *rpcInterceptors) unaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (res interface{}, err error) {
+	start := time.Now()
+	requestID := requestIDFromContext(ctx)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDMetadataKey, requestID))
+	defer func() {
+		ri.observe(ctx, info.FullMethod, requestID, start, err)
+	}()
+
+	if err := ri.checkRateLimit(info.FullMethod); err != nil {
+		return nil, err
+	}
+
+	defer ri.recoverPanic(info.FullMethod, requestID, &err)
+
+	return handler(ctx, req)
+}
+
+func (ri *rpcInterceptors) streamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) (err error) {
+	start := time.Now()
+	requestID := requestIDFromContext(ss.Context())
+	_ = ss.SetHeader(metadata.Pairs(requestIDMetadataKey, requestID))
+	defer func() {
+		ri.observe(ss.Context(), info.FullMethod, requestID, start, err)
+	}()
+
+	if err := ri.checkRateLimit(info.FullMethod); err != nil {
+		return err
+	}
+
+	defer ri.recoverPanic(info.FullMethod, requestID, &err)
+
+	return handler(srv, ss)
+}
+
+// checkRateLimit rejects the request if the rate limit is reached, except
+// for the health service polled by the probes
+func (ri *rpcInterceptors) checkRateLimit(method string) error {
+	if ri.limiter == nil || strings.HasPrefix(method, grpcHealthServicePrefix) {
+		return nil
+	}
+	if !ri.limiter.allow() {
+		return status.Error(codes.ResourceExhausted, "the RPC requests are rate limited")
+	}
+
+	return nil
+}
+
+// recoverPanic recovers from a panic of the handler and sets the error to
+// codes.Internal, it should be deferred
+func (ri *rpcInterceptors) recoverPanic(method, requestID string, err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	ri.metrics.IncrementRPCTotalPanics(method)
+	ri.logger.Error("recovered from a panic of the RPC handler",
+		zap.String("method", method),
+		zap.String("request_id", requestID),
+		zap.Any("panic", r),
+		zap.Stack("stack"),
+	)
+	// the request is not attached to the report as it may hold secrets
+	ri.errReporters.report(context.Background(), &errreport.Report{
+		Kind:      errreport.KindPanic,
+		Component: errorReportComponentRPC,
+		Message:   fmt.Sprintf("%s: %v", method, r),
+		Stack:     string(debug.Stack()),
+		Time:      time.Now().UTC(),
+	})
+	*err = status.Error(codes.Internal, "internal error")
+}
+
+func (ri *rpcInterceptors) observe(ctx context.Context, method, requestID string, start time.Time, err error) {
+	duration := time.Since(start)
+	code := status.Code(err)
+	ri.metrics.ObserveRPCRequest(method, code.String(), duration)
+	ri.recordAdminCall(ctx, method, requestID, err)
+
+	if !ri.cfg.LogRequests {
+		return
+	}
+
+	fields := []zap.Field{
+		zap.String("method", method),
+		zap.String("request_id", requestID),
+		zap.String("code", code.String()),
+		zap.Duration("duration", duration),
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		fields = append(fields, zap.String("peer", p.Addr.String()))
+	}
+	if err != nil {
+		fields = append(fields, zap.Error(err))
+	}
+	ri.logger.Info("handled the RPC request", fields...)
+}
+
+// recordAdminCall records the calls to the admin service in the audit log
+// along with their peer and correlation id, including the rejected ones
+func (ri *rpcInterceptors) recordAdminCall(ctx context.Context, method, requestID string, err error) {
+	if ri.audit == nil || !strings.HasPrefix(method, adminServicePrefix) {
+		return
+	}
+
+	details := map[string]string{
+		"method":     method,
+		"request_id": requestID,
+		"code":       status.Code(err).String(),
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		details["peer"] = p.Addr.String()
+	}
+	ri.audit.record(audit.OpRPCAdminCall, details, err)
+}
+
+// requestIDFromContext returns the correlation id set by the client, or a
+// random one if none is set or if it is too long
+func requestIDFromContext(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(requestIDMetadataKey); len(ids) > 0 && ids[0] != "" && len(ids[0]) <= maxRequestIDLen {
+			return ids[0]
+		}
+	}
+
+	idBytes := make([]byte, requestIDNumBytes)
+	if _, err := rand.Read(idBytes); err != nil {
+		return "unknown"
+	}
+
+	return hex.EncodeToString(idBytes)
+}
+
+// rpcRateLimiter is a token bucket refilled at the given rate up to the
+// given burst
+type rpcRateLimiter struct {
+	mu sync.Mutex
+
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRPCRateLimiter(rate float64, burst int) *rpcRateLimiter {
+	return &rpcRateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// allow takes a token from the bucket, it returns false if the bucket is
+// empty
+func (l *rpcRateLimiter) allow() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+
+	return true
+}
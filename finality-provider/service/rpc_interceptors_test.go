@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/metrics"
+)
+
+// panickingServer panics upon GetInfo and answers the other RPCs
+type panickingServer struct {
+	proto.UnimplementedFinalityProvidersServer
+}
+
+func (s *panickingServer) GetInfo(context.Context, *proto.GetInfoRequest) (*proto.GetInfoResponse, error) {
+	panic("unexpected state")
+}
+
+func (s *panickingServer) QueryFinalityProviderList(context.Context, *proto.QueryFinalityProviderListRequest) (*proto.QueryFinalityProviderListResponse, error) {
+	return &proto.QueryFinalityProviderListResponse{}, nil
+}
+
+func (s *panickingServer) SubscribeEvents(*proto.SubscribeEventsRequest, proto.FinalityProviders_SubscribeEventsServer) error {
+	panic("unexpected state")
+}
+
+// startInterceptedServer serves the panicking server and the health service
+// through the RPC interceptors of the given config and returns a connection
+// to it
+func startInterceptedServer(t *testing.T, cfg *fpcfg.RPCInterceptorConfig) *grpc.ClientConn {
+	ri := newRPCInterceptors(cfg, metrics.NewFpMetrics(), nil, &errorReporters{}, zap.NewNop())
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(ri.unaryInterceptor),
+		grpc.ChainStreamInterceptor(ri.streamInterceptor),
+	)
+	proto.RegisterFinalityProvidersServer(grpcServer, &panickingServer{})
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		_ = grpcServer.Serve(lis)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, conn.Close())
+	})
+
+	return conn
+}
+
+// TestRateLimitInterceptor tests that the requests beyond the burst are
+// rejected with codes.ResourceExhausted, except for the health checks
+func TestRateLimitInterceptor(t *testing.T) {
+	t.Parallel()
+
+	// the bucket is refilled once every 1000 seconds
+	conn := startInterceptedServer(t, &fpcfg.RPCInterceptorConfig{RateLimit: 0.001, RateLimitBurst: 3})
+	client := proto.NewFinalityProvidersClient(conn)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := client.QueryFinalityProviderList(ctx, &proto.QueryFinalityProviderListRequest{})
+		require.NoError(t, err)
+	}
+	_, err := client.QueryFinalityProviderList(ctx, &proto.QueryFinalityProviderListRequest{})
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// the streams are rate limited as well
+	stream, err := client.SubscribeEvents(ctx, &proto.SubscribeEventsRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// the probes keep polling the health service
+	res, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	require.Equal(t, healthpb.HealthCheckResponse_SERVING, res.Status)
+}
+
+// TestPanicRecoveryInterceptor tests that the panics of the handlers are
+// returned as codes.Internal while the server keeps serving the requests
+func TestPanicRecoveryInterceptor(t *testing.T) {
+	t.Parallel()
+
+	defaultCfg := fpcfg.DefaultRPCInterceptorConfig()
+	conn := startInterceptedServer(t, &defaultCfg)
+	client := proto.NewFinalityProvidersClient(conn)
+	ctx := context.Background()
+
+	_, err := client.GetInfo(ctx, &proto.GetInfoRequest{})
+	require.Equal(t, codes.Internal, status.Code(err))
+	// the panic is not leaked to the client
+	require.Equal(t, "internal error", status.Convert(err).Message())
+
+	stream, err := client.SubscribeEvents(ctx, &proto.SubscribeEventsRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.Internal, status.Code(err))
+
+	// the server keeps running
+	_, err = client.QueryFinalityProviderList(ctx, &proto.QueryFinalityProviderListRequest{})
+	require.NoError(t, err)
+	_, err = client.GetInfo(ctx, &proto.GetInfoRequest{})
+	require.Equal(t, codes.Internal, status.Code(err))
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+)
+
+// scopeFilteredMethods are the methods which are reachable with a scoped
+// auth token without their request being checked by the interceptor, their
+// handlers restrict the results to the finality providers of the scope
+var scopeFilteredMethods = map[string]struct{}{
+	proto.FinalityProviders_GetInfo_FullMethodName:                   {},
+	proto.FinalityProviders_QueryFinalityProviderList_FullMethodName: {},
+	proto.FinalityProviders_SubscribeEvents_FullMethodName:           {},
+	proto.FinalityProviders_ExportPubRandProofs_FullMethodName:       {},
+}
+
+// rpcScope restricts the requests made with a scoped auth token to the
+// given finality providers
+type rpcScope struct {
+	name     string
+	readOnly bool
+	// fpPks are the hex of the BIP-340 public keys of the finality providers
+	fpPks map[string]struct{}
+}
+
+// allows returns whether the finality provider with the given BIP-340
+// public key in hex is within the scope, a nil scope allows everything
+func (s *rpcScope) allows(fpPkHex string) bool {
+	if s == nil {
+		return true
+	}
+	_, ok := s.fpPks[fpPkHex]
+
+	return ok
+}
+
+// btcPks returns the BIP-340 public keys of the finality providers of the
+// scope, nil for a nil scope
+func (s *rpcScope) btcPks() [][]byte {
+	if s == nil {
+		return nil
+	}
+
+	pks := make([][]byte, 0, len(s.fpPks))
+	for pkHex := range s.fpPks {
+		pk, err := bbntypes.NewBIP340PubKeyFromHex(pkHex)
+		if err != nil {
+			continue
+		}
+		pks = append(pks, pk.MustMarshal())
+	}
+
+	return pks
+}
+
+type scopedToken struct {
+	token []byte
+	scope *rpcScope
+}
+
+// scopedTokensFile is the layout of the file of the scoped auth tokens
+type scopedTokensFile struct {
+	Tokens []struct {
+		// Name identifies the holder of the token, e.g., a customer
+		Name     string   `json:"name"`
+		Token    string   `json:"token"`
+		ReadOnly bool     `json:"read_only"`
+		FpPks    []string `json:"fp_pks"`
+	} `json:"tokens"`
+}
+
+// loadScopedTokens reads the scoped auth tokens from the given file, which
+// should differ from each other and from the unscoped ones
+func loadScopedTokens(path string, unscopedTokens ...string) ([]*scopedToken, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the scoped auth tokens: %w", err)
+	}
+
+	var file scopedTokensFile
+	dec := json.NewDecoder(bytes.NewReader(bz))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&file); err != nil {
+		return nil, fmt.Errorf("failed to parse the scoped auth tokens %s: %w", path, err)
+	}
+
+	seen := make(map[string]struct{}, len(file.Tokens)+len(unscopedTokens))
+	for _, t := range unscopedTokens {
+		seen[t] = struct{}{}
+	}
+
+	scopedTokens := make([]*scopedToken, 0, len(file.Tokens))
+	for _, t := range file.Tokens {
+		if t.Name == "" || t.Token == "" {
+			return nil, fmt.Errorf("the scoped auth tokens should have a name and a token")
+		}
+		if _, ok := seen[t.Token]; ok {
+			return nil, fmt.Errorf("the scoped auth token %s is not unique", t.Name)
+		}
+		seen[t.Token] = struct{}{}
+		if len(t.FpPks) == 0 {
+			return nil, fmt.Errorf("the scoped auth token %s has no finality provider", t.Name)
+		}
+
+		scope := &rpcScope{
+			name:     t.Name,
+			readOnly: t.ReadOnly,
+			fpPks:    make(map[string]struct{}, len(t.FpPks)),
+		}
+		for _, pkHex := range t.FpPks {
+			pk, err := bbntypes.NewBIP340PubKeyFromHex(pkHex)
+			if err != nil {
+				return nil, fmt.Errorf("invalid finality provider public key %s of the scoped auth token %s: %w", pkHex, t.Name, err)
+			}
+			scope.fpPks[pk.MarshalHex()] = struct{}{}
+		}
+		scopedTokens = append(scopedTokens, &scopedToken{token: []byte(t.Token), scope: scope})
+	}
+
+	return scopedTokens, nil
+}
+
+type rpcScopeKey struct{}
+
+func contextWithRPCScope(ctx context.Context, scope *rpcScope) context.Context {
+	return context.WithValue(ctx, rpcScopeKey{}, scope)
+}
+
+// rpcScopeFromContext returns the scope of the auth token of the request,
+// nil if the request is not restricted
+func rpcScopeFromContext(ctx context.Context) *rpcScope {
+	scope, _ := ctx.Value(rpcScopeKey{}).(*rpcScope)
+
+	return scope
+}
+
+// btcPkRequest is implemented by the requests targeting a finality provider
+type btcPkRequest interface {
+	GetBtcPk() string
+}
+
+// checkRPCScope rejects the request made with a scoped auth token unless it
+// targets a finality provider of the scope or its results are restricted
+// to the scope. The requests of the streams are checked by their handlers
+func checkRPCScope(ctx context.Context, method string, req interface{}) error {
+	scope := rpcScopeFromContext(ctx)
+	if scope == nil {
+		return nil
+	}
+	if _, ok := scopeFilteredMethods[method]; ok || strings.HasPrefix(method, "/grpc.reflection.") {
+		return nil
+	}
+
+	if r, ok := req.(btcPkRequest); ok {
+		return checkFpInRPCScope(scope, r.GetBtcPk())
+	}
+
+	return status.Errorf(codes.PermissionDenied, "the auth token %s does not grant access to the daemon-wide requests", scope.name)
+}
+
+// checkFpInRPCScope rejects the finality provider not within the scope
+func checkFpInRPCScope(scope *rpcScope, fpPkHex string) error {
+	if scope == nil {
+		return nil
+	}
+
+	pk, err := bbntypes.NewBIP340PubKeyFromHex(fpPkHex)
+	if err != nil || !scope.allows(pk.MarshalHex()) {
+		return status.Errorf(codes.PermissionDenied, "the auth token %s does not grant access to the finality provider %q", scope.name, fpPkHex)
+	}
+
+	return nil
+}
+
+// scopedServerStream carries the scope of the auth token of the stream
+type scopedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *scopedServerStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/babylonlabs-io/babylon/testutil/datagen"
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+)
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func genRandomFpPkHex(t *testing.T, r *rand.Rand) string {
+	_, btcPk, err := datagen.GenRandomBTCKeyPair(r)
+	require.NoError(t, err)
+
+	return bbntypes.NewBIP340PubKeyFromBTCPK(btcPk).MarshalHex()
+}
+
+func authContext(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(authMetadataKey, authSchemePrefix+token))
+}
+
+// TestRPCScopeInterceptors tests that the unary and the stream interceptors
+// let the requests made with a scoped auth token through only if they target
+// a finality provider of the scope or their results are restricted to it,
+// the scope being passed to the handlers
+func TestRPCScopeInterceptors(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(10))
+
+	inScopePk, outOfScopePk := genRandomFpPkHex(t, r), genRandomFpPkHex(t, r)
+	scope := &rpcScope{name: "customer-a", fpPks: map[string]struct{}{inScopePk: {}}}
+	ta := newTokenAuthenticator("admin-token", "", []*scopedToken{{token: []byte("scoped-token"), scope: scope}})
+
+	unaryTestCases := []struct {
+		name   string
+		token  string
+		method string
+		req    interface{}
+		scope  *rpcScope
+		code   codes.Code
+	}{
+		{
+			name:   "unscoped token",
+			token:  "admin-token",
+			method: proto.FinalityProvidersAdmin_CreateFinalityProvider_FullMethodName,
+			req:    &proto.CreateFinalityProviderRequest{},
+			code:   codes.OK,
+		},
+		{
+			name:   "finality provider within the scope",
+			token:  "scoped-token",
+			method: proto.FinalityProviders_QueryFinalityProvider_FullMethodName,
+			req:    &proto.QueryFinalityProviderRequest{BtcPk: inScopePk},
+			scope:  scope,
+			code:   codes.OK,
+		},
+		{
+			name:   "finality provider out of the scope",
+			token:  "scoped-token",
+			method: proto.FinalityProvidersAdmin_UnjailFinalityProvider_FullMethodName,
+			req:    &proto.UnjailFinalityProviderRequest{BtcPk: outOfScopePk},
+			code:   codes.PermissionDenied,
+		},
+		{
+			name:   "filtered daemon info",
+			token:  "scoped-token",
+			method: proto.FinalityProviders_GetInfo_FullMethodName,
+			req:    &proto.GetInfoRequest{},
+			scope:  scope,
+			code:   codes.OK,
+		},
+		{
+			name:   "daemon-wide request",
+			token:  "scoped-token",
+			method: proto.FinalityProvidersAdmin_CreateFinalityProvider_FullMethodName,
+			req:    &proto.CreateFinalityProviderRequest{},
+			code:   codes.PermissionDenied,
+		},
+		{
+			name:   "invalid token",
+			token:  "invalid-token",
+			method: proto.FinalityProviders_GetInfo_FullMethodName,
+			req:    &proto.GetInfoRequest{},
+			code:   codes.Unauthenticated,
+		},
+	}
+
+	for _, tc := range unaryTestCases {
+		t.Run("unary "+tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var handlerScope *rpcScope
+			handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+				handlerScope = rpcScopeFromContext(ctx)
+				return struct{}{}, nil
+			}
+			_, err := ta.unaryInterceptor(authContext(tc.token), tc.req, &grpc.UnaryServerInfo{FullMethod: tc.method}, handler)
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.scope, handlerScope)
+		})
+	}
+
+	streamTestCases := []struct {
+		name   string
+		token  string
+		method string
+		scope  *rpcScope
+		code   codes.Code
+	}{
+		{
+			name:   "unscoped token",
+			token:  "admin-token",
+			method: proto.FinalityProvidersAdmin_ExportState_FullMethodName,
+			code:   codes.OK,
+		},
+		{
+			name:   "filtered event stream",
+			token:  "scoped-token",
+			method: proto.FinalityProviders_SubscribeEvents_FullMethodName,
+			scope:  scope,
+			code:   codes.OK,
+		},
+		{
+			name:   "public randomness proofs checked by the handler",
+			token:  "scoped-token",
+			method: proto.FinalityProviders_ExportPubRandProofs_FullMethodName,
+			scope:  scope,
+			code:   codes.OK,
+		},
+		{
+			name:   "daemon-wide stream",
+			token:  "scoped-token",
+			method: proto.FinalityProvidersAdmin_ExportState_FullMethodName,
+			code:   codes.PermissionDenied,
+		},
+	}
+
+	for _, tc := range streamTestCases {
+		t.Run("stream "+tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var handlerScope *rpcScope
+			handler := func(_ interface{}, ss grpc.ServerStream) error {
+				handlerScope = rpcScopeFromContext(ss.Context())
+				return nil
+			}
+			ss := &fakeServerStream{ctx: authContext(tc.token)}
+			err := ta.streamInterceptor(nil, ss, &grpc.StreamServerInfo{FullMethod: tc.method}, handler)
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.scope, handlerScope)
+		})
+	}
+}
@@ -9,13 +9,14 @@ import (
 
 	sdkmath "cosmossdk.io/math"
 	bbntypes "github.com/babylonlabs-io/babylon/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"google.golang.org/grpc"
-	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
 	"github.com/babylonlabs-io/finality-provider/types"
-	"github.com/babylonlabs-io/finality-provider/version"
 )
 
 // rpcServer is the main RPC server for the Finality Provider daemon that handles
@@ -25,6 +26,7 @@ type rpcServer struct {
 	shutdown int32
 
 	proto.UnimplementedFinalityProvidersServer
+	proto.UnimplementedFinalityProvidersAdminServer
 
 	app *FinalityProviderApp
 
@@ -65,19 +67,24 @@ func (r *rpcServer) Stop() error {
 	return nil
 }
 
-// RegisterWithGrpcServer registers the rpcServer with the passed root gRPC
-// server.
+// RegisterWithGrpcServer registers the read-only service of the rpcServer
+// with the passed root gRPC server.
 func (r *rpcServer) RegisterWithGrpcServer(grpcServer *grpc.Server) error {
 	// Register the main RPC server.
 	proto.RegisterFinalityProvidersServer(grpcServer, r)
 	return nil
 }
 
-// GetInfo returns general information relating to the active daemon
-func (r *rpcServer) GetInfo(context.Context, *proto.GetInfoRequest) (*proto.GetInfoResponse, error) {
-	return &proto.GetInfoResponse{
-		Version: version.RPC(),
-	}, nil
+// RegisterAdminWithGrpcServer registers the admin service of the rpcServer
+// with the passed gRPC server.
+func (r *rpcServer) RegisterAdminWithGrpcServer(grpcServer *grpc.Server) {
+	proto.RegisterFinalityProvidersAdminServer(grpcServer, r)
+}
+
+// GetInfo returns general information relating to the active daemon, the
+// instances being restricted to the scope of the auth token
+func (r *rpcServer) GetInfo(ctx context.Context, _ *proto.GetInfoRequest) (*proto.GetInfoResponse, error) {
+	return r.app.getInfo(rpcScopeFromContext(ctx)), nil
 }
 
 // CreateFinalityProvider generates a finality-provider object and saves it in the database
@@ -85,6 +92,12 @@ func (r *rpcServer) CreateFinalityProvider(
 	_ context.Context,
 	req *proto.CreateFinalityProviderRequest,
 ) (*proto.CreateFinalityProviderResponse, error) {
+	endWrite, err := r.app.beginWrite()
+	if err != nil {
+		return nil, err
+	}
+	defer endWrite()
+
 	commissionRate, err := sdkmath.LegacyNewDecFromStr(req.Commission)
 	if err != nil {
 		return nil, err
@@ -114,14 +127,30 @@ func (r *rpcServer) CreateFinalityProvider(
 		return nil, err
 	}
 
+	pop, err := r.app.GetPop(eotsPk)
+	if err != nil {
+		return nil, err
+	}
+	popHex, err := pop.ToHexStr()
+	if err != nil {
+		return nil, err
+	}
+
 	return &proto.CreateFinalityProviderResponse{
 		FinalityProvider: result.FpInfo,
+		PopHex:           popHex,
 	}, nil
 }
 
 // RegisterFinalityProvider sends a transactions to Babylon to register a BTC finality-provider
 func (r *rpcServer) RegisterFinalityProvider(_ context.Context, req *proto.RegisterFinalityProviderRequest) (
 	*proto.RegisterFinalityProviderResponse, error) {
+	endWrite, err := r.app.beginWrite()
+	if err != nil {
+		return nil, err
+	}
+	defer endWrite()
+
 	txRes, err := r.app.RegisterFinalityProvider(req.BtcPk)
 	if err != nil {
 		return nil, fmt.Errorf("failed to register the finality-provider to Babylon: %w", err)
@@ -141,6 +170,12 @@ func (r *rpcServer) AddFinalitySignature(_ context.Context, req *proto.AddFinali
 	*proto.AddFinalitySignatureResponse,
 	error,
 ) {
+	endWrite, err := r.app.beginWrite()
+	if err != nil {
+		return nil, err
+	}
+	defer endWrite()
+
 	r.app.wg.Add(1)
 	defer r.app.wg.Done()
 
@@ -156,15 +191,9 @@ func (r *rpcServer) AddFinalitySignature(_ context.Context, req *proto.AddFinali
 			return nil, err
 		}
 
-		fpi, err := r.app.GetFinalityProviderInstance()
+		fpi, err := r.app.fpManager.GetFinalityProviderInstance(fpPk)
 		if err != nil {
-			return nil, err
-		}
-
-		if fpi.GetBtcPkHex() != req.BtcPk {
-			errMsg := fmt.Sprintf("the finality provider running does not match the request, got: %s, expected: %s",
-				req.BtcPk, fpi.GetBtcPkHex())
-			return nil, errors.New(errMsg)
+			return nil, fmt.Errorf("the finality provider %s is not running: %w", req.BtcPk, err)
 		}
 
 		b := &types.BlockInfo{
@@ -209,13 +238,23 @@ func (r *rpcServer) AddFinalitySignature(_ context.Context, req *proto.AddFinali
 	}
 }
 
-// UnjailFinalityProvider unjails a finality-provider
+// UnjailFinalityProvider unjails a finality-provider once the request is
+// confirmed and restarts its instance
 func (r *rpcServer) UnjailFinalityProvider(_ context.Context, req *proto.UnjailFinalityProviderRequest) (
 	*proto.UnjailFinalityProviderResponse, error) {
-	fpPk, err := bbntypes.NewBIP340PubKeyFromHex(req.BtcPk)
+	endWrite, err := r.app.beginWrite()
 	if err != nil {
 		return nil, err
 	}
+	defer endWrite()
+
+	fpPk, err := parseEotsPk(req.BtcPk)
+	if err != nil {
+		return nil, err
+	}
+	if !req.Confirm {
+		return nil, fmt.Errorf("%w: unjailing %s sends a transaction to the consumer chain", ErrUnjailNotConfirmed, req.BtcPk)
+	}
 
 	txHash, err := r.app.UnjailFinalityProvider(fpPk)
 	if err != nil {
@@ -227,7 +266,12 @@ func (r *rpcServer) UnjailFinalityProvider(_ context.Context, req *proto.UnjailF
 		return nil, fmt.Errorf("failed to start the finality provider instance after unjailing: %w", err)
 	}
 
-	return &proto.UnjailFinalityProviderResponse{TxHash: txHash}, nil
+	fp, err := r.app.GetFinalityProviderInfo(fpPk)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.UnjailFinalityProviderResponse{TxHash: txHash, Status: fp.Status}, nil
 }
 
 // QueryFinalityProvider queries the information of the finality-provider
@@ -245,44 +289,169 @@ func (r *rpcServer) QueryFinalityProvider(_ context.Context, req *proto.QueryFin
 	return &proto.QueryFinalityProviderResponse{FinalityProvider: fp}, nil
 }
 
-func (r *rpcServer) EditFinalityProvider(_ context.Context, req *proto.EditFinalityProviderRequest) (*proto.EmptyResponse, error) {
-	fpPk, err := bbntypes.NewBIP340PubKeyFromHex(req.BtcPk)
+// QueryParticipationReport reports the missed votes of the finality provider
+// within the given heights
+func (r *rpcServer) QueryParticipationReport(_ context.Context, req *proto.QueryParticipationReportRequest) (
+	*proto.QueryParticipationReportResponse, error) {
+	fpPk, err := parseEotsPk(req.BtcPk)
+	if err != nil {
+		return nil, err
+	}
+	report, err := r.app.ParticipationReport(fpPk, req.StartHeight, req.EndHeight)
 	if err != nil {
 		return nil, err
 	}
 
-	rate, err := sdkmath.LegacyNewDecFromStr(req.Commission)
+	return &proto.QueryParticipationReportResponse{Report: report}, nil
+}
+
+// QueryVotes returns the recorded votes of the finality provider within the
+// given heights
+func (r *rpcServer) QueryVotes(_ context.Context, req *proto.QueryVotesRequest) (*proto.QueryVotesResponse, error) {
+	fpPk, err := parseEotsPk(req.BtcPk)
+	if err != nil {
+		return nil, err
+	}
+	votes, err := r.app.QueryVotes(fpPk, req.StartHeight, req.EndHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.QueryVotesResponse{Votes: votes}, nil
+}
+
+// QueryPubRandCommits returns the recorded public randomness commits of the
+// finality provider
+func (r *rpcServer) QueryPubRandCommits(_ context.Context, req *proto.QueryPubRandCommitsRequest) (
+	*proto.QueryPubRandCommitsResponse, error) {
+	fpPk, err := parseEotsPk(req.BtcPk)
 	if err != nil {
 		return nil, err
 	}
+	commits, err := r.app.QueryPubRandCommits(fpPk)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.QueryPubRandCommitsResponse{Commits: commits}, nil
+}
+
+// SubscribeEvents streams the events of the finality providers matching the
+// request until the client cancels the stream or the daemon stops. The
+// events are dropped while the client cannot keep up with them
+func (r *rpcServer) SubscribeEvents(req *proto.SubscribeEventsRequest, stream proto.FinalityProviders_SubscribeEventsServer) error {
+	// the events of a scoped auth token are restricted to its finality
+	// providers
+	scope := rpcScopeFromContext(stream.Context())
+	var fpPkHex string
+	if req.BtcPk != "" {
+		fpPk, err := parseEotsPk(req.BtcPk)
+		if err != nil {
+			return err
+		}
+		if err := checkFpInRPCScope(scope, req.BtcPk); err != nil {
+			return err
+		}
+		fpPkHex = fpPk.MarshalHex()
+	}
+	eventTypes := make(map[string]struct{}, len(req.Types))
+	for _, t := range req.Types {
+		eventTypes[t] = struct{}{}
+	}
+
+	events, unsubscribe := r.app.SubscribeEvents("grpc")
+	defer unsubscribe()
+
+	// the header tells the client that the subscription is active, e.g.,
+	// before the REST gateway starts its event stream
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				return ErrAppShuttingDown
+			}
+			if fpPkHex != "" && ev.FpBtcPk != fpPkHex {
+				continue
+			}
+			if !scope.allows(ev.FpBtcPk) {
+				continue
+			}
+			if _, ok := eventTypes[string(ev.Type)]; len(eventTypes) > 0 && !ok {
+				continue
+			}
+			if err := stream.Send(&proto.FinalityProviderEvent{
+				Type:        string(ev.Type),
+				Time:        ev.Time.UnixMilli(),
+				FpBtcPk:     ev.FpBtcPk,
+				TxHash:      ev.TxHash,
+				StartHeight: ev.StartHeight,
+				EndHeight:   ev.EndHeight,
+				Height:      ev.Height,
+				OldStatus:   ev.OldStatus,
+				NewStatus:   ev.NewStatus,
+				Error:       ev.Error,
+			}); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		case <-r.quit:
+			// the streams never complete, so they are ended for the RPC
+			// server to drain
+			return ErrAppShuttingDown
+		}
+	}
+}
 
-	descBytes, err := protobuf.Marshal(req.Description)
+func (r *rpcServer) EditFinalityProvider(_ context.Context, req *proto.EditFinalityProviderRequest) (*proto.EmptyResponse, error) {
+	endWrite, err := r.app.beginWrite()
 	if err != nil {
 		return nil, err
 	}
+	defer endWrite()
 
-	fpPub := fpPk.MustToBTCPK()
-	updatedMsg, err := r.app.cc.EditFinalityProvider(fpPub, &rate, descBytes)
+	fpPk, err := bbntypes.NewBIP340PubKeyFromHex(req.BtcPk)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := r.app.fps.SetFpDescription(fpPub, updatedMsg.Description, updatedMsg.Commission); err != nil {
+	// the commission is left unchanged if not specified
+	var rate *sdkmath.LegacyDec
+	if req.Commission != "" {
+		parsedRate, err := sdkmath.LegacyNewDecFromStr(req.Commission)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid commission rate %s: %w", ErrInvalidCommission, req.Commission, err)
+		}
+		rate = &parsedRate
+	}
+
+	if err := r.app.EditFinalityProvider(fpPk, req.Description, rate); err != nil {
 		return nil, err
 	}
 
-	return nil, nil
+	return &proto.EmptyResponse{}, nil
 }
 
 // QueryFinalityProviderList queries the information of a list of finality providers
-func (r *rpcServer) QueryFinalityProviderList(_ context.Context, _ *proto.QueryFinalityProviderListRequest) (
+func (r *rpcServer) QueryFinalityProviderList(ctx context.Context, req *proto.QueryFinalityProviderListRequest) (
 	*proto.QueryFinalityProviderListResponse, error) {
-	fps, err := r.app.ListAllFinalityProvidersInfo()
+	fps, nextKey, err := r.app.QueryFinalityProvidersInfo(&store.FinalityProviderQuery{
+		Statuses: req.Statuses,
+		ChainID:  req.ChainId,
+		StartKey: req.PaginationKey,
+		Limit:    req.Limit,
+		// a scoped auth token only lists the finality providers of its scope
+		BtcPks: rpcScopeFromContext(ctx).btcPks(),
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &proto.QueryFinalityProviderListResponse{FinalityProviders: fps}, nil
+	return &proto.QueryFinalityProviderListResponse{FinalityProviders: fps, NextKey: nextKey}, nil
 }
 
 // SignMessageFromChainKey signs a message from the chain keyring.
@@ -296,10 +465,314 @@ func (r *rpcServer) SignMessageFromChainKey(_ context.Context, req *proto.SignMe
 	return &proto.SignMessageFromChainKeyResponse{Signature: signature}, nil
 }
 
+// SignSchnorrMessage signs an arbitrary message with the EOTS key of the
+// given finality provider
+func (r *rpcServer) SignSchnorrMessage(_ context.Context, req *proto.SignSchnorrMessageRequest) (
+	*proto.SignSchnorrMessageResponse, error) {
+	fpPk, err := parseEotsPk(req.BtcPk)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, sig, err := r.app.SignSchnorrMessage(fpPk, req.Msg, req.Passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.SignSchnorrMessageResponse{
+		MsgHash:   hash,
+		Signature: bbntypes.NewBIP340SignatureFromBTCSig(sig).MustMarshal(),
+	}, nil
+}
+
+// StartFinalityProvider starts the instance of the given finality provider
+// alongside the ones already running in the daemon
+func (r *rpcServer) StartFinalityProvider(_ context.Context, req *proto.StartFinalityProviderRequest) (*proto.EmptyResponse, error) {
+	endWrite, err := r.app.beginWrite()
+	if err != nil {
+		return nil, err
+	}
+	defer endWrite()
+
+	fpPk, err := parseEotsPk(req.BtcPk)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.app.StartHandlingFinalityProvider(fpPk, req.Passphrase); err != nil {
+		return nil, err
+	}
+
+	return &proto.EmptyResponse{}, nil
+}
+
+// StopFinalityProvider stops the instance of the given finality provider,
+// leaving the daemon and the other instances running
+func (r *rpcServer) StopFinalityProvider(_ context.Context, req *proto.StopFinalityProviderRequest) (*proto.EmptyResponse, error) {
+	fpPk, err := parseEotsPk(req.BtcPk)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.app.StopFinalityProvider(fpPk); err != nil {
+		return nil, err
+	}
+
+	return &proto.EmptyResponse{}, nil
+}
+
+// PauseFinalityProvider pauses the voting of the given finality provider,
+// keeping its status sync and metrics alive
+func (r *rpcServer) PauseFinalityProvider(_ context.Context, req *proto.PauseFinalityProviderRequest) (*proto.EmptyResponse, error) {
+	fpPk, err := parseEotsPk(req.BtcPk)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.app.PauseFinalityProvider(fpPk); err != nil {
+		return nil, err
+	}
+
+	return &proto.EmptyResponse{}, nil
+}
+
+// ResumeFinalityProvider resumes the voting of the given finality provider
+func (r *rpcServer) ResumeFinalityProvider(_ context.Context, req *proto.ResumeFinalityProviderRequest) (*proto.EmptyResponse, error) {
+	fpPk, err := parseEotsPk(req.BtcPk)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.app.ResumeFinalityProvider(fpPk); err != nil {
+		return nil, err
+	}
+
+	return &proto.EmptyResponse{}, nil
+}
+
+// ReloadConfig re-reads the config file and applies the changes of the
+// reloadable fields
+func (r *rpcServer) ReloadConfig(_ context.Context, _ *proto.ReloadConfigRequest) (*proto.ReloadConfigResponse, error) {
+	res, err := r.app.ReloadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.ReloadConfigResponse{
+		UpdatedFields:         res.Updated,
+		RestartRequiredFields: res.RestartRequired,
+	}, nil
+}
+
+// SetLogLevel changes the log level of the daemon or of one of its modules
+func (r *rpcServer) SetLogLevel(_ context.Context, req *proto.SetLogLevelRequest) (*proto.SetLogLevelResponse, error) {
+	levels, err := r.app.SetLogLevel(req.Level, req.Module)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.SetLogLevelResponse{
+		Level:        levels.Root,
+		ModuleLevels: levels.Modules,
+	}, nil
+}
+
+// Doctor runs the preflight checks of the daemon on demand
+func (r *rpcServer) Doctor(_ context.Context, req *proto.DoctorRequest) (*proto.DoctorResponse, error) {
+	fpPks := make([]*bbntypes.BIP340PubKey, 0, len(req.BtcPks))
+	for _, pkHex := range req.BtcPks {
+		fpPk, err := parseEotsPk(pkHex)
+		if err != nil {
+			return nil, err
+		}
+		fpPks = append(fpPks, fpPk)
+	}
+
+	report := r.app.Doctor(fpPks, req.Passphrase)
+
+	res := &proto.DoctorResponse{Status: string(report.Status)}
+	for _, c := range report.Checks {
+		res.Checks = append(res.Checks, &proto.DoctorCheck{
+			Name:   c.Name,
+			Status: string(c.Status),
+			Error:  c.Error,
+			Hint:   c.Hint,
+		})
+	}
+
+	return res, nil
+}
+
+// EnterMaintenance freezes the chain submissions and the db writes of the
+// daemon once the running ones complete
+func (r *rpcServer) EnterMaintenance(_ context.Context, _ *proto.EnterMaintenanceRequest) (*proto.EmptyResponse, error) {
+	if err := r.app.EnterMaintenance(); err != nil {
+		return nil, err
+	}
+
+	return &proto.EmptyResponse{}, nil
+}
+
+// ExitMaintenance resumes the chain submissions and the db writes of the
+// daemon
+func (r *rpcServer) ExitMaintenance(_ context.Context, _ *proto.ExitMaintenanceRequest) (*proto.EmptyResponse, error) {
+	if err := r.app.ExitMaintenance(); err != nil {
+		return nil, err
+	}
+
+	return &proto.EmptyResponse{}, nil
+}
+
+// WithdrawRewards withdraws the accumulated finality provider rewards
+func (r *rpcServer) WithdrawRewards(_ context.Context, req *proto.WithdrawRewardsRequest) (*proto.WithdrawRewardsResponse, error) {
+	endWrite, err := r.app.beginWrite()
+	if err != nil {
+		return nil, err
+	}
+	defer endWrite()
+
+	var (
+		txHash string
+		amount sdk.Coins
+	)
+	if req.BtcPk == "" {
+		txHash, amount, err = r.app.WithdrawRewards(req.Recipient)
+	} else {
+		fpPk, pkErr := parseEotsPk(req.BtcPk)
+		if pkErr != nil {
+			return nil, pkErr
+		}
+		txHash, amount, err = r.app.WithdrawFinalityProviderRewards(fpPk, req.Recipient)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to withdraw the rewards: %w", err)
+	}
+
+	return &proto.WithdrawRewardsResponse{TxHash: txHash, Amount: amount.String()}, nil
+}
+
+// QueryRewards queries the withdrawable rewards of the given finality provider
+func (r *rpcServer) QueryRewards(_ context.Context, req *proto.QueryRewardsRequest) (*proto.QueryRewardsResponse, error) {
+	fpPk, err := parseEotsPk(req.BtcPk)
+	if err != nil {
+		return nil, err
+	}
+
+	fpAddr, rewards, err := r.app.QueryRewards(fpPk)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.QueryRewardsResponse{FpAddr: fpAddr, Rewards: rewards.String()}, nil
+}
+
+// QueryInstanceStatus queries the live status of the running instance of the
+// given finality provider
+func (r *rpcServer) QueryInstanceStatus(_ context.Context, req *proto.QueryInstanceStatusRequest) (*proto.QueryInstanceStatusResponse, error) {
+	fpPk, err := parseEotsPk(req.BtcPk)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.app.InstanceStatus(fpPk)
+}
+
+// UpdateCommission updates the commission rate of a finality-provider
+func (r *rpcServer) UpdateCommission(_ context.Context, req *proto.UpdateCommissionRequest) (*proto.UpdateCommissionResponse, error) {
+	endWrite, err := r.app.beginWrite()
+	if err != nil {
+		return nil, err
+	}
+	defer endWrite()
+
+	fpPk, err := bbntypes.NewBIP340PubKeyFromHex(req.BtcPk)
+	if err != nil {
+		return nil, err
+	}
+
+	rate, err := sdkmath.LegacyNewDecFromStr(req.Commission)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid commission rate %s: %w", ErrInvalidCommission, req.Commission, err)
+	}
+
+	txHash, err := r.app.UpdateFinalityProviderCommission(fpPk, rate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update the commission rate: %w", err)
+	}
+
+	return &proto.UpdateCommissionResponse{TxHash: txHash}, nil
+}
+
+// CommitPubRand commits the public randomness of the given finality provider
+// until the target height without waiting for its schedule
+func (r *rpcServer) CommitPubRand(_ context.Context, req *proto.CommitPubRandRequest) (*proto.CommitPubRandResponse, error) {
+	endWrite, err := r.app.beginWrite()
+	if err != nil {
+		return nil, err
+	}
+	defer endWrite()
+
+	fpPk, err := parseEotsPk(req.BtcPk)
+	if err != nil {
+		return nil, err
+	}
+
+	txHashes, lastCommittedHeight, err := r.app.CommitPubRand(fpPk, req.TargetHeight)
+	if err != nil {
+		return nil, fmt.Errorf("failed to commit public randomness: %w", err)
+	}
+
+	return &proto.CommitPubRandResponse{TxHashes: txHashes, LastCommittedHeight: lastCommittedHeight}, nil
+}
+
+// ExportPubRandProofs streams the proofs of the public randomness of the
+// given finality provider within the range of heights of the request
+func (r *rpcServer) ExportPubRandProofs(
+	req *proto.ExportPubRandProofsRequest,
+	stream proto.FinalityProviders_ExportPubRandProofsServer,
+) error {
+	fpPk, err := parseEotsPk(req.BtcPk)
+	if err != nil {
+		return err
+	}
+	if err := checkFpInRPCScope(rpcScopeFromContext(stream.Context()), req.BtcPk); err != nil {
+		return err
+	}
+
+	return r.app.ExportPubRandProofs(fpPk, req.FromHeight, req.ToHeight, func(p *store.PubRandProofRecord) error {
+		return stream.Send(&proto.PubRandProof{Height: p.Height, Proof: p.Proof})
+	})
+}
+
+// ExportState streams the backup bundle of the db of the daemon encrypted
+// with the passphrase of the request
+func (r *rpcServer) ExportState(req *proto.ExportStateRequest, stream proto.FinalityProvidersAdmin_ExportStateServer) error {
+	return r.app.ExportState(&exportStateWriter{stream: stream}, req.Passphrase)
+}
+
+// exportStateWriter sends the written bytes as the chunks of the stream of
+// the state export
+type exportStateWriter struct {
+	stream proto.FinalityProvidersAdmin_ExportStateServer
+}
+
+func (w *exportStateWriter) Write(p []byte) (int, error) {
+	if err := w.stream.Send(&proto.ExportStateResponse{Data: p}); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
 func parseEotsPk(eotsPkHex string) (*bbntypes.BIP340PubKey, error) {
 	if eotsPkHex == "" {
-		return nil, fmt.Errorf("eots-pk cannot be empty")
+		return nil, fmt.Errorf("%w: eots-pk cannot be empty", ErrInvalidEotsPk)
+	}
+
+	eotsPk, err := bbntypes.NewBIP340PubKeyFromHex(eotsPkHex)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEotsPk, err)
 	}
 
-	return bbntypes.NewBIP340PubKeyFromHex(eotsPkHex)
+	return eotsPk, nil
 }
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/eventbus"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/notifier"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+)
+
+// maxSelfCompromiseCheckHeights is the maximum number of heights whose votes
+// are checked in each round, the remaining heights are checked in the next ones
+const maxSelfCompromiseCheckHeights = 1000
+
+func (fpm *FinalityProviderManager) selfCompromiseCheckEnabled() bool {
+	return fpm.config().SelfCompromiseConfig != nil && fpm.config().SelfCompromiseConfig.Enabled
+}
+
+// selfCompromiseCheckLoop periodically checks that each on-chain vote of the
+// running finality providers has been signed by the daemon, i.e., that it
+// has a sign record. A vote without sign record means that the EOTS key of
+// the finality provider is used elsewhere
+func (fpm *FinalityProviderManager) selfCompromiseCheckLoop() {
+	defer fpm.wg.Done()
+
+	cfg := fpm.config().SelfCompromiseConfig
+	fpm.logger.Info("starting self-compromise check loop",
+		zap.Float64("interval seconds", cfg.CheckInterval.Seconds()),
+		zap.Uint64("confirmation_depth", cfg.ConfirmationDepth),
+		zap.Bool("halt_signing", cfg.HaltSigning),
+	)
+
+	// checkedHeights maps the EOTS public key hex of each finality provider
+	// to the height up to which its votes have been checked
+	checkedHeights := make(map[string]uint64)
+	// compromised contains the EOTS public key hex of the finality providers
+	// which have been reported to the notifiers
+	compromised := make(map[string]struct{})
+
+	ticker := time.NewTicker(cfg.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			fpm.checkOwnVotes(checkedHeights, compromised)
+		case <-fpm.quit:
+			fpm.logger.Info("exiting self-compromise check loop")
+			return
+		}
+	}
+}
+
+// checkOwnVotes checks the votes of the running finality providers from the
+// height following the last checked one up to ConfirmationDepth blocks below
+// the tip. The votes up to the last voted height of a finality provider upon
+// its first check are not checked, as they might predate the sign records
+func (fpm *FinalityProviderManager) checkOwnVotes(checkedHeights map[string]uint64, compromised map[string]struct{}) {
+	cfg := fpm.config().SelfCompromiseConfig
+
+	var fpInstances []*FinalityProviderInstance
+	for _, fpi := range fpm.listFinalityProviderInstances() {
+		if fpi.IsRunning() {
+			fpInstances = append(fpInstances, fpi)
+		}
+	}
+	if len(fpInstances) == 0 {
+		return
+	}
+
+	tip, err := fpm.cc.QueryBestBlock()
+	if err != nil {
+		fpm.logger.Warn("failed to query the tip to check the votes", zap.Error(err))
+		return
+	}
+	if tip.Height <= cfg.ConfirmationDepth {
+		return
+	}
+
+	startHeight := uint64(math.MaxUint64)
+	for _, fpi := range fpInstances {
+		pkHex := fpi.GetBtcPkHex()
+		if _, ok := checkedHeights[pkHex]; !ok {
+			checkedHeights[pkHex] = fpi.GetLastVotedHeight()
+		}
+		startHeight = min(startHeight, checkedHeights[pkHex]+1)
+	}
+	endHeight := min(tip.Height-cfg.ConfirmationDepth, startHeight+maxSelfCompromiseCheckHeights-1)
+
+	for height := startHeight; height <= endHeight; height++ {
+		voters, err := fpm.cc.QueryVotesAtHeight(height)
+		if err != nil {
+			fpm.logger.Warn("failed to query the votes", zap.Uint64("height", height), zap.Error(err))
+			return
+		}
+		voted := make(map[string]struct{}, len(voters))
+		for _, pk := range voters {
+			voted[pk.MarshalHex()] = struct{}{}
+		}
+
+		for _, fpi := range fpInstances {
+			pkHex := fpi.GetBtcPkHex()
+			if checkedHeights[pkHex] >= height {
+				continue
+			}
+
+			if _, ok := voted[pkHex]; ok {
+				_, err := fpm.signRecords.GetSignRecord(fpi.GetChainID(), fpi.GetBtcPkBIP340().MustMarshal(), height)
+				switch {
+				case errors.Is(err, store.ErrSignRecordNotFound):
+					fpm.reportUnknownVote(fpi, height, compromised)
+				case err != nil:
+					fpm.logger.Warn("failed to get the sign record",
+						zap.String("pk", pkHex), zap.Uint64("height", height), zap.Error(err))
+					return
+				}
+			}
+
+			checkedHeights[pkHex] = height
+		}
+	}
+}
+
+// reportUnknownVote surfaces the vote not signed by the daemon through the
+// logs, the metrics and the event bus, and through the notifiers once per
+// finality provider. The voting of the finality provider is paused if
+// HaltSigning is set
+func (fpm *FinalityProviderManager) reportUnknownVote(fpi *FinalityProviderInstance, height uint64, compromised map[string]struct{}) {
+	pkHex := fpi.GetBtcPkHex()
+
+	fpm.logger.Error("found an on-chain vote not signed by the daemon, the EOTS key of the finality provider is likely used elsewhere",
+		zap.String("pk", pkHex),
+		zap.Uint64("height", height),
+	)
+
+	fpm.metrics.IncrementFpTotalUnknownVotes(pkHex)
+
+	fpm.events.Publish(&eventbus.Event{
+		Type:    eventbus.EventUnknownVoteDetected,
+		FpBtcPk: pkHex,
+		Height:  height,
+	})
+
+	if fpm.config().SelfCompromiseConfig.HaltSigning && !fpi.IsPaused() {
+		if err := fpi.Pause(); err != nil {
+			fpm.logger.Error("failed to pause the compromised finality provider", zap.String("pk", pkHex), zap.Error(err))
+		} else {
+			fpm.logger.Warn("paused the voting of the compromised finality provider until it is resumed",
+				zap.String("pk", pkHex))
+		}
+	}
+
+	if _, ok := compromised[pkHex]; ok || !fpm.notifier.Enabled() {
+		return
+	}
+	compromised[pkHex] = struct{}{}
+
+	fpm.sendNotification(&notifier.Event{
+		Type:            notifier.EventCompromised,
+		FpBtcPk:         pkHex,
+		ChainID:         string(fpi.GetChainID()),
+		Height:          height,
+		LastVotedHeight: fpi.GetLastVotedHeight(),
+		ProbableCause:   probableCauses[notifier.EventCompromised],
+		Time:            time.Now().UTC(),
+	})
+}
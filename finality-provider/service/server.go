@@ -4,15 +4,23 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"os"
+	ossignal "os/signal"
 	"sync"
 	"sync/atomic"
+	"syscall"
 
 	"github.com/lightningnetwork/lnd/kvdb"
 	"github.com/lightningnetwork/lnd/signal"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/log"
 	"github.com/babylonlabs-io/finality-provider/metrics"
 )
 
@@ -56,7 +64,7 @@ func (s *Server) RunUntilShutdown() error {
 	if err != nil {
 		return fmt.Errorf("failed to get prometheus address: %w", err)
 	}
-	metricsServer := metrics.Start(promAddr, s.logger)
+	metricsServer := metrics.Start(promAddr, s.cfg.Metrics.Pprof, s.logger)
 
 	defer func() {
 		s.logger.Info("Shutdown complete")
@@ -86,24 +94,257 @@ func (s *Server) RunUntilShutdown() error {
 		}
 	}()
 
-	grpcServer := grpc.NewServer()
+	serverOpts, err := s.grpcServerOptions(true)
+	if err != nil {
+		return err
+	}
+	grpcServer := grpc.NewServer(serverOpts...)
 	defer grpcServer.Stop()
 
 	if err := s.rpcServer.RegisterWithGrpcServer(grpcServer); err != nil {
 		return fmt.Errorf("failed to register gRPC server: %w", err)
 	}
 
+	// The admin service is served on a separate listener along with the
+	// read-only one if configured, and on the RPC listener otherwise
+	adminGrpcServer := grpcServer
+	var adminLis net.Listener
+	if adminAddr := s.cfg.AdminRPCListener; adminAddr != "" {
+		adminLis, err = net.Listen("tcp", adminAddr)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", adminAddr, err)
+		}
+		defer func() {
+			if err := adminLis.Close(); err != nil {
+				s.logger.Error(fmt.Sprintf("Failed to close admin network listener: %v", err))
+			}
+		}()
+
+		adminGrpcServer = grpc.NewServer(serverOpts...)
+		defer adminGrpcServer.Stop()
+
+		if err := s.rpcServer.RegisterWithGrpcServer(adminGrpcServer); err != nil {
+			return fmt.Errorf("failed to register admin gRPC server: %w", err)
+		}
+	}
+	s.rpcServer.RegisterAdminWithGrpcServer(adminGrpcServer)
+
+	// the unix socket is meant for the local clients, which are given both
+	// services and are restricted by the permissions of the socket file, so
+	// it is served without TLS nor auth token
+	var (
+		unixLis        net.Listener
+		unixGrpcServer *grpc.Server
+	)
+	if socketPath := s.cfg.RPCUnixSocket; socketPath != "" {
+		perm, err := s.cfg.RPCUnixSocketFileMode()
+		if err != nil {
+			return err
+		}
+		unixLis, err = listenUnixSocket(socketPath, perm)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := unixLis.Close(); err != nil {
+				s.logger.Debug("failed to close the unix socket listener", zap.Error(err))
+			}
+		}()
+
+		unixOpts, err := s.grpcServerOptions(false)
+		if err != nil {
+			return err
+		}
+		unixGrpcServer = grpc.NewServer(unixOpts...)
+		defer unixGrpcServer.Stop()
+
+		if err := s.rpcServer.RegisterWithGrpcServer(unixGrpcServer); err != nil {
+			return fmt.Errorf("failed to register unix socket gRPC server: %w", err)
+		}
+		s.rpcServer.RegisterAdminWithGrpcServer(unixGrpcServer)
+		reflection.Register(unixGrpcServer)
+	}
+
+	// the reflection service lets grpcurl and the like call the RPCs
+	// without the compiled protos
+	reflection.Register(grpcServer)
+	if adminGrpcServer != grpcServer {
+		reflection.Register(adminGrpcServer)
+	}
+
+	var stopHealth func()
+	if healthCfg := s.cfg.HealthConfig; healthCfg != nil && healthCfg.Enabled {
+		hs := newHealthServer(s.rpcServer.app, healthCfg, s.logger)
+		hs.RegisterWithGrpcServer(grpcServer)
+		if err := hs.Start(); err != nil {
+			return fmt.Errorf("failed to start the health server: %w", err)
+		}
+		stopHealth = sync.OnceFunc(func() { hs.Stop(context.Background()) })
+	} else {
+		// without the readiness checks, the gRPC health service reports
+		// SERVING as long as the RPC listener is up
+		grpcHealth := health.NewServer()
+		healthpb.RegisterHealthServer(grpcServer, grpcHealth)
+		stopHealth = grpcHealth.Shutdown
+	}
+	defer stopHealth()
+
 	// All the necessary parts have been registered, so we can
 	// actually start listening for requests.
 	s.startGrpcListen(grpcServer, []net.Listener{lis})
+	if adminLis != nil {
+		s.startGrpcListen(adminGrpcServer, []net.Listener{adminLis})
+	}
+	if unixLis != nil {
+		s.startGrpcListen(unixGrpcServer, []net.Listener{unixLis})
+	}
+
+	stopRestGateway := func(context.Context) {}
+	if gwCfg := s.cfg.RestGatewayConfig; gwCfg != nil && gwCfg.Enabled {
+		rg := newRestGateway(gwCfg, s.logger)
+		if err := rg.Start(lis.Addr().String(), s.cfg.RPCAuthConfig); err != nil {
+			return fmt.Errorf("failed to start the REST gateway: %w", err)
+		}
+		var stopOnce sync.Once
+		stopRestGateway = func(ctx context.Context) {
+			stopOnce.Do(func() { rg.Stop(ctx) })
+		}
+		defer stopRestGateway(context.Background())
+	}
 
 	s.logger.Info("Finality Provider Daemon is fully active!")
 
+	// Reload the config upon SIGHUP
+	reloadChan := make(chan os.Signal, 1)
+	ossignal.Notify(reloadChan, syscall.SIGHUP)
+	defer ossignal.Stop(reloadChan)
+
 	// Wait for shutdown signal from either a graceful server stop or from
 	// the interrupt handler.
-	<-s.interceptor.ShutdownChannel()
+	for {
+		select {
+		case <-reloadChan:
+			s.logger.Info("received SIGHUP, reloading the config")
+			if _, err := s.rpcServer.app.ReloadConfig(); err != nil {
+				s.logger.Error("failed to reload the config", zap.Error(err))
+			}
+		case <-s.interceptor.ShutdownChannel():
+			// the RPC requests are drained before the app is stopped, so
+			// that they complete against a running app
+			drainTimeout := s.rpcServer.app.config().RPCDrainTimeout
+			s.logger.Info("Draining the RPC requests...", zap.Duration("rpc_drain_timeout", drainTimeout))
+			stopHealth()
+			ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+			stopRestGateway(ctx)
+			cancel()
+			if err := s.rpcServer.Stop(); err != nil {
+				s.logger.Error("failed to stop the RPC server", zap.Error(err))
+			}
+			grpcServers := []*grpc.Server{grpcServer}
+			if adminGrpcServer != grpcServer {
+				grpcServers = append(grpcServers, adminGrpcServer)
+			}
+			if unixGrpcServer != nil {
+				grpcServers = append(grpcServers, unixGrpcServer)
+			}
+			drainGrpcServers(drainTimeout, s.logger, grpcServers...)
+
+			s.logger.Info("Stopping the finality provider app...")
+			if err := s.rpcServer.app.Stop(); err != nil {
+				s.logger.Error("failed to stop the finality provider app", zap.Error(err))
+			}
+			return nil
+		}
+	}
+}
+
+// grpcServerOptions returns the options of the gRPC server. If authenticated,
+// it serves over TLS and authenticates the requests with the auth token
+// depending on the config
+func (s *Server) grpcServerOptions(authenticated bool) ([]grpc.ServerOption, error) {
+	unaryInterceptors := []grpc.UnaryServerInterceptor{grpcErrorInterceptor, s.rpcServer.drainUnaryInterceptor}
+	streamInterceptors := []grpc.StreamServerInterceptor{grpcErrorStreamInterceptor, s.rpcServer.drainStreamInterceptor}
+	var opts []grpc.ServerOption
+
+	interceptorCfg := s.cfg.RPCInterceptorConfig
+	if interceptorCfg == nil {
+		defaultCfg := fpcfg.DefaultRPCInterceptorConfig()
+		interceptorCfg = &defaultCfg
+	}
+	fpm := s.rpcServer.app.fpManager
+	ri := newRPCInterceptors(interceptorCfg, s.rpcServer.app.metrics, fpm.audit, fpm.errReporters, s.logger.Named(log.ModuleRPC))
+
+	if authCfg := s.cfg.RPCAuthConfig; authCfg != nil && authenticated {
+		if authCfg.TLSEnabled {
+			tlsCfg, err := serverTLSConfig(authCfg)
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, grpc.Creds(credentials.NewTLS(tlsCfg)))
+			s.logger.Info("the RPC listener is served over TLS", zap.String("cert", authCfg.TLSCertPath))
+		}
+
+		if authCfg.TokenAuthEnabled {
+			token, err := loadOrGenerateAuthToken(authCfg.TokenPath)
+			if err != nil {
+				return nil, err
+			}
+			readOnlyToken, err := loadOrGenerateAuthToken(authCfg.ReadOnlyTokenPath)
+			if err != nil {
+				return nil, err
+			}
+			var scopedTokens []*scopedToken
+			if authCfg.ScopedTokensPath != "" {
+				scopedTokens, err = loadScopedTokens(authCfg.ScopedTokensPath, token, readOnlyToken)
+				if err != nil {
+					return nil, err
+				}
+				s.logger.Info("loaded the scoped auth tokens",
+					zap.String("scoped_tokens_path", authCfg.ScopedTokensPath),
+					zap.Int("num_scoped_tokens", len(scopedTokens)))
+			}
+			ta := newTokenAuthenticator(token, readOnlyToken, scopedTokens)
+			unaryInterceptors = append([]grpc.UnaryServerInterceptor{ta.unaryInterceptor}, unaryInterceptors...)
+			streamInterceptors = append([]grpc.StreamServerInterceptor{ta.streamInterceptor}, streamInterceptors...)
+			s.logger.Info("the RPC requests require the auth token",
+				zap.String("token_path", authCfg.TokenPath),
+				zap.String("read_only_token_path", authCfg.ReadOnlyTokenPath))
+		}
+	}
+
+	// the logging, the metrics, the rate limit and the panic recovery wrap
+	// the authentication, so that the rejected requests are accounted for
+	unaryInterceptors = append([]grpc.UnaryServerInterceptor{ri.unaryInterceptor}, unaryInterceptors...)
+	streamInterceptors = append([]grpc.StreamServerInterceptor{ri.streamInterceptor}, streamInterceptors...)
+
+	return append(opts,
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	), nil
+}
+
+// listenUnixSocket listens on the unix domain socket at the given path with
+// the given file permissions, replacing the socket left by a previous run
+func listenUnixSocket(path string, perm os.FileMode) (net.Listener, error) {
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("the RPC unix socket path %s exists and is not a socket", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove the stale RPC unix socket %s: %w", path, err)
+		}
+	}
+
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on the unix socket %s: %w", path, err)
+	}
+	if err := os.Chmod(path, perm); err != nil {
+		_ = lis.Close()
+		return nil, fmt.Errorf("failed to set the permissions of the unix socket %s: %w", path, err)
+	}
 
-	return nil
+	return lis, nil
 }
 
 // startGrpcListen starts the GRPC server on the passed listeners.
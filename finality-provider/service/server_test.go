@@ -0,0 +1,116 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/golang/mock/gomock"
+	"github.com/lightningnetwork/lnd/signal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager"
+	eotscfg "github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+	fpclient "github.com/babylonlabs-io/finality-provider/finality-provider/service/client"
+	"github.com/babylonlabs-io/finality-provider/log"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+	"github.com/babylonlabs-io/finality-provider/testutil/mocks"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// TestUnixSocket tests that the unix socket serves both services without TLS
+// nor auth token, the access being restricted by the permissions of the
+// socket file, while the RPC listener keeps requiring them
+func TestUnixSocket(t *testing.T) {
+	fpHomeDir := filepath.Join(t.TempDir(), "fp-home")
+	fpCfg := config.DefaultConfigWithHome(fpHomeDir)
+	fpCfg.RPCListener = fmt.Sprintf("127.0.0.1:%d", testutil.AllocateUniquePort(t))
+	fpCfg.Metrics.Port = testutil.AllocateUniquePort(t)
+	fpCfg.RPCAuthConfig.TLSEnabled = true
+	fpCfg.RPCAuthConfig.TokenAuthEnabled = true
+	// the path of a unix socket is limited to about 100 characters
+	socketDir, err := os.MkdirTemp("", "fpd")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, os.RemoveAll(socketDir))
+	})
+	socketPath := filepath.Join(socketDir, "fpd.sock")
+	fpCfg.RPCUnixSocket = socketPath
+	fpCfg.RPCUnixSocketPerm = "0600"
+
+	fpdb, err := fpCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+
+	ctl := gomock.NewController(t)
+	mockClientController := mocks.NewMockClientController(ctl)
+	mockClientController.EXPECT().QueryBestBlock().Return(&types.BlockInfo{Height: 100}, nil).AnyTimes()
+	mockClientController.EXPECT().QuerySignerBalance().Return(sdk.NewCoins(sdk.NewInt64Coin("ubbn", 1000)), nil).AnyTimes()
+	eotsHomeDir := filepath.Join(t.TempDir(), "eots-home")
+	eotsCfg := eotscfg.DefaultConfigWithHomePath(eotsHomeDir)
+	eotsdb, err := eotsCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	em, err := eotsmanager.NewLocalEOTSManager(eotsHomeDir, eotsCfg.KeyringBackend, eotsdb, zap.NewNop())
+	require.NoError(t, err)
+	app, err := service.NewFinalityProviderApp(&fpCfg, mockClientController, em, fpdb, zap.NewNop())
+	require.NoError(t, err)
+	app.EnableConfigReload(func() (*config.Config, error) {
+		cfg := fpCfg
+		return &cfg, nil
+	}, log.NewLevels(zap.InfoLevel))
+
+	shutdownInterceptor, err := signal.Intercept()
+	require.NoError(t, err)
+	fpServer := service.NewFinalityProviderServer(&fpCfg, zap.NewNop(), app, fpdb, shutdownInterceptor)
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- fpServer.RunUntilShutdown()
+	}()
+	t.Cleanup(func() {
+		shutdownInterceptor.RequestShutdown()
+		require.NoError(t, <-errChan)
+	})
+
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(socketPath)
+		return err == nil
+	}, 10*time.Second, 50*time.Millisecond)
+	info, err := os.Stat(socketPath)
+	require.NoError(t, err)
+	require.NotZero(t, info.Mode()&os.ModeSocket)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// the socket is served in plaintext without the auth token
+	socketClient, cleanUpSocket, err := fpclient.NewFinalityProviderServiceGRpcClient("unix://" + socketPath)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, cleanUpSocket())
+	}()
+	res, err := socketClient.GetInfo(ctx)
+	require.NoError(t, err)
+	require.Equal(t, fpCfg.BabylonConfig.ChainID, res.ChainId)
+	// the admin service is served as well
+	levels, err := socketClient.SetLogLevel(ctx, "debug", "")
+	require.NoError(t, err)
+	require.Equal(t, "debug", levels.GetLevel())
+
+	// the RPC listener keeps requiring TLS
+	tcpClient, cleanUpTCP, err := fpclient.NewFinalityProviderServiceGRpcClient(fpCfg.RPCListener)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, cleanUpTCP())
+	}()
+	_, err = tcpClient.GetInfo(ctx)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"fmt"
+	"io"
+
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/audit"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+)
+
+// ExportState writes the backup bundle of the db of the daemon, encrypted
+// with the given passphrase, to w. The db keeps being written while it is
+// exported, as the copy is made within a read transaction
+func (app *FinalityProviderApp) ExportState(w io.Writer, passphrase string) error {
+	err := store.WriteBackupBundle(w, app.db, app.config().DatabaseConfig.Backend, []byte(passphrase))
+	app.fpManager.audit.record(audit.OpExportState, map[string]string{"backend": app.config().DatabaseConfig.Backend}, err)
+	if err != nil {
+		return fmt.Errorf("failed to export the state: %w", err)
+	}
+
+	app.logger.Info("exported the state of the daemon", zap.String("backend", app.config().DatabaseConfig.Backend))
+
+	return nil
+}
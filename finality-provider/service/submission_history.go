@@ -0,0 +1,164 @@
+package service
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"time"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// maxVoteHistoryRange is the maximum number of heights of a query of the
+// vote history
+const maxVoteHistoryRange = 10000
+
+// recordVoteResults records the result of the broadcast of the votes over
+// the given blocks in the submission history
+func (fp *FinalityProviderInstance) recordVoteResults(blocks []*types.BlockInfo, res *types.TxResponse, broadcastErr error) {
+	if fp.history == nil {
+		return
+	}
+
+	now := time.Now()
+	records := make([]*store.SubmissionRecord, 0, len(blocks))
+	for _, b := range blocks {
+		records = append(records, newSubmissionRecord(store.SubmissionVote, b.Height, res, broadcastErr, now, func(rec *store.SubmissionRecord) {
+			rec.BlockHash = b.Hash
+		}))
+	}
+
+	fp.recordSubmissions(records)
+}
+
+// recordPubRandCommitResult records the result of the broadcast of the
+// public randomness commit in the submission history
+func (fp *FinalityProviderInstance) recordPubRandCommitResult(startHeight, numPubRand uint64, res *types.TxResponse, broadcastErr error) {
+	if fp.history == nil {
+		return
+	}
+
+	fp.recordSubmissions([]*store.SubmissionRecord{
+		newSubmissionRecord(store.SubmissionPubRandCommit, startHeight, res, broadcastErr, time.Now(), func(rec *store.SubmissionRecord) {
+			rec.NumPubRand = numPubRand
+		}),
+	})
+}
+
+func newSubmissionRecord(
+	kind store.SubmissionKind,
+	height uint64,
+	res *types.TxResponse,
+	broadcastErr error,
+	now time.Time,
+	setDetails func(rec *store.SubmissionRecord),
+) *store.SubmissionRecord {
+	rec := &store.SubmissionRecord{
+		Kind:      kind,
+		Height:    height,
+		Result:    store.SubmissionResultSubmitted,
+		Timestamp: now,
+	}
+	setDetails(rec)
+	if broadcastErr != nil {
+		rec.Result = store.SubmissionResultFailed
+		rec.Error = broadcastErr.Error()
+	} else if res != nil {
+		rec.TxHash = res.TxHash
+	}
+
+	return rec
+}
+
+// recordSubmissions saves the records, the failure of which does not affect
+// the submissions
+func (fp *FinalityProviderInstance) recordSubmissions(records []*store.SubmissionRecord) {
+	var retainHeights uint64
+	if cfg := fp.cfg().SubmissionHistoryConfig; cfg != nil {
+		retainHeights = cfg.RetainVoteHeights
+	}
+
+	if err := fp.history.RecordSubmissions(fp.GetChainID(), fp.btcPk.MustMarshal(), records, retainHeights); err != nil {
+		fp.logger.Warn(
+			"failed to record the submission history",
+			zap.String("pk", fp.GetBtcPkHex()),
+			zap.Error(err),
+		)
+	}
+}
+
+// QueryVotes returns the recorded votes of the given finality provider
+// between the given heights inclusive. The end height defaults to the start
+// height, so that a single height can be looked up
+func (fpm *FinalityProviderManager) QueryVotes(fpPk *bbntypes.BIP340PubKey, startHeight, endHeight uint64) ([]*proto.VoteRecord, error) {
+	if endHeight == 0 {
+		endHeight = startHeight
+	}
+	if startHeight > endHeight {
+		return nil, fmt.Errorf("%w: the start height %d should not be greater than the end height %d",
+			ErrInvalidHeightRange, startHeight, endHeight)
+	}
+	if endHeight-startHeight >= maxVoteHistoryRange {
+		return nil, fmt.Errorf("%w: the range should not exceed %d heights", ErrInvalidHeightRange, maxVoteHistoryRange)
+	}
+
+	records, err := fpm.listSubmissions(fpPk, store.SubmissionVote, startHeight, endHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	votes := make([]*proto.VoteRecord, 0, len(records))
+	for _, rec := range records {
+		votes = append(votes, &proto.VoteRecord{
+			Height:    rec.Height,
+			BlockHash: hex.EncodeToString(rec.BlockHash),
+			Result:    rec.Result.String(),
+			TxHash:    rec.TxHash,
+			Error:     rec.Error,
+			Time:      rec.Timestamp.UnixMilli(),
+		})
+	}
+
+	return votes, nil
+}
+
+// QueryPubRandCommits returns the recorded public randomness commits of the
+// given finality provider
+func (fpm *FinalityProviderManager) QueryPubRandCommits(fpPk *bbntypes.BIP340PubKey) ([]*proto.PubRandCommitRecord, error) {
+	records, err := fpm.listSubmissions(fpPk, store.SubmissionPubRandCommit, 0, math.MaxUint64)
+	if err != nil {
+		return nil, err
+	}
+
+	commits := make([]*proto.PubRandCommitRecord, 0, len(records))
+	for _, rec := range records {
+		commits = append(commits, &proto.PubRandCommitRecord{
+			StartHeight: rec.Height,
+			NumPubRand:  rec.NumPubRand,
+			Result:      rec.Result.String(),
+			TxHash:      rec.TxHash,
+			Error:       rec.Error,
+			Time:        rec.Timestamp.UnixMilli(),
+		})
+	}
+
+	return commits, nil
+}
+
+func (fpm *FinalityProviderManager) listSubmissions(
+	fpPk *bbntypes.BIP340PubKey,
+	kind store.SubmissionKind,
+	startHeight, endHeight uint64,
+) ([]*store.SubmissionRecord, error) {
+	sfp, err := fpm.fps.GetFinalityProvider(fpPk.MustToBTCPK())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get finality provider from db: %w", err)
+	}
+
+	return fpm.history.ListSubmissions([]byte(sfp.ChainID), fpPk.MustMarshal(), kind, startHeight, endHeight)
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/notifier"
+	"github.com/babylonlabs-io/finality-provider/metrics"
+)
+
+const (
+	submissionVote          = "vote"
+	submissionPubRandCommit = "pubrand_commit"
+	submissionUnjail        = "unjail"
+
+	submissionLimitWindow = time.Minute
+)
+
+// submissionLimiter caps the number of the transactions submitted by a
+// finality provider within a sliding window of a minute, so that a runaway
+// bug does not drain the fee account. It is kept by the manager for each
+// finality provider so that it survives the restarts of the instance
+type submissionLimiter struct {
+	mu sync.Mutex
+
+	fpBtcPkHex string
+	max        uint32
+	// sent are the times of the submissions within the window
+	sent []time.Time
+	// engaged is whether the last submission was rejected
+	engaged bool
+	// onEngaged is called once the limiter starts rejecting the submissions
+	onEngaged func()
+	// now returns the current time, replaced in the tests
+	now func() time.Time
+
+	metrics *metrics.FpMetrics
+	logger  *zap.Logger
+}
+
+func newSubmissionLimiter(
+	fpBtcPkHex string,
+	maxPerMinute uint32,
+	metrics *metrics.FpMetrics,
+	logger *zap.Logger,
+	onEngaged func(),
+) *submissionLimiter {
+	return &submissionLimiter{
+		fpBtcPkHex: fpBtcPkHex,
+		max:        maxPerMinute,
+		onEngaged:  onEngaged,
+		now:        time.Now,
+		metrics:    metrics,
+		logger:     logger,
+	}
+}
+
+// allow records the given submission and returns ErrSubmissionRateLimited
+// if the max submissions within the last minute are reached. A nil limiter
+// allows everything
+func (l *submissionLimiter) allow(submission string) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	expired := 0
+	for expired < len(l.sent) && now.Sub(l.sent[expired]) >= submissionLimitWindow {
+		expired++
+	}
+	l.sent = l.sent[expired:]
+
+	if uint32(len(l.sent)) >= l.max {
+		l.metrics.IncrementFpTotalRateLimitedSubmissions(l.fpBtcPkHex, submission)
+		if !l.engaged {
+			l.engaged = true
+			l.metrics.RecordFpSubmissionRateLimited(l.fpBtcPkHex, true)
+			l.logger.Warn("the submissions of the finality provider are rate limited",
+				zap.String("pk", l.fpBtcPkHex),
+				zap.String("submission", submission),
+				zap.Uint32("max_submissions_per_minute", l.max))
+			if l.onEngaged != nil {
+				l.onEngaged()
+			}
+		}
+
+		return fmt.Errorf("%w: %d submissions within the last minute, the %s is rejected",
+			ErrSubmissionRateLimited, len(l.sent), submission)
+	}
+
+	if l.engaged {
+		l.engaged = false
+		l.metrics.RecordFpSubmissionRateLimited(l.fpBtcPkHex, false)
+		l.logger.Info("the submissions of the finality provider are no longer rate limited",
+			zap.String("pk", l.fpBtcPkHex))
+	}
+	l.sent = append(l.sent, now)
+
+	return nil
+}
+
+// getSubmissionLimiter returns the submission rate limiter of the given
+// finality provider, which is created upon the first call, or nil if the
+// limit is disabled
+func (fpm *FinalityProviderManager) getSubmissionLimiter(fpBtcPkHex string) *submissionLimiter {
+	cfg := fpm.config().SubmissionLimitConfig
+	if !cfg.Enabled() {
+		return nil
+	}
+
+	fpm.submissionLimitersMu.Lock()
+	defer fpm.submissionLimitersMu.Unlock()
+
+	if l, ok := fpm.submissionLimiters[fpBtcPkHex]; ok {
+		return l
+	}
+
+	l := newSubmissionLimiter(fpBtcPkHex, cfg.MaxSubmissionsPerMinute, fpm.metrics, fpm.logger, func() {
+		fpm.notifyRateLimited(fpBtcPkHex)
+	})
+	fpm.submissionLimiters[fpBtcPkHex] = l
+
+	return l
+}
+
+// notifyRateLimited alerts the configured notifiers that the submissions of
+// the given finality provider start being rate limited
+func (fpm *FinalityProviderManager) notifyRateLimited(fpBtcPkHex string) {
+	if !fpm.notifier.Enabled() {
+		return
+	}
+
+	fpm.sendNotification(&notifier.Event{
+		Type:          notifier.EventRateLimited,
+		FpBtcPk:       fpBtcPkHex,
+		ChainID:       fpm.config().BabylonConfig.ChainID,
+		ProbableCause: probableCauses[notifier.EventRateLimited],
+		Time:          time.Now().UTC(),
+	})
+}
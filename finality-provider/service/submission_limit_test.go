@@ -0,0 +1,100 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/metrics"
+)
+
+// newTestSubmissionLimiter returns a limiter whose clock is the returned
+// time, which the test moves forward
+func newTestSubmissionLimiter(maxPerMinute uint32, onEngaged func()) (*submissionLimiter, *time.Time) {
+	now := time.Unix(1_700_000_000, 0)
+	l := newSubmissionLimiter("fp-limit", maxPerMinute, metrics.NewFpMetrics(), zap.NewNop(), onEngaged)
+	l.now = func() time.Time { return now }
+
+	return l, &now
+}
+
+func TestSubmissionLimiterBurst(t *testing.T) {
+	t.Parallel()
+	var engaged int
+	l, _ := newTestSubmissionLimiter(3, func() { engaged++ })
+
+	// the max submissions are allowed at once, whatever their kind
+	require.NoError(t, l.allow(submissionVote))
+	require.NoError(t, l.allow(submissionPubRandCommit))
+	require.NoError(t, l.allow(submissionVote))
+
+	// the next ones are rejected, the limiter being engaged once
+	require.ErrorIs(t, l.allow(submissionVote), ErrSubmissionRateLimited)
+	require.ErrorIs(t, l.allow(submissionUnjail), ErrSubmissionRateLimited)
+	require.Equal(t, 1, engaged)
+	require.Len(t, l.sent, 3)
+}
+
+func TestSubmissionLimiterRefill(t *testing.T) {
+	t.Parallel()
+	var engaged int
+	l, now := newTestSubmissionLimiter(2, func() { engaged++ })
+
+	require.NoError(t, l.allow(submissionVote))
+	*now = now.Add(20 * time.Second)
+	require.NoError(t, l.allow(submissionVote))
+	require.ErrorIs(t, l.allow(submissionVote), ErrSubmissionRateLimited)
+
+	// the first submission leaves the window after a minute, which frees
+	// a single slot
+	*now = now.Add(40 * time.Second)
+	require.NoError(t, l.allow(submissionVote))
+	require.False(t, l.engaged)
+	require.ErrorIs(t, l.allow(submissionVote), ErrSubmissionRateLimited)
+	require.Equal(t, 2, engaged)
+
+	// all the slots are freed once the window has elapsed
+	*now = now.Add(submissionLimitWindow)
+	require.NoError(t, l.allow(submissionVote))
+	require.NoError(t, l.allow(submissionVote))
+	require.ErrorIs(t, l.allow(submissionVote), ErrSubmissionRateLimited)
+}
+
+func TestSubmissionLimiterNil(t *testing.T) {
+	t.Parallel()
+	var l *submissionLimiter
+	for i := 0; i < 100; i++ {
+		require.NoError(t, l.allow(submissionVote))
+	}
+}
+
+func TestSubmissionLimiterPerFinalityProvider(t *testing.T) {
+	t.Parallel()
+	cfg := fpcfg.DefaultConfig()
+	cfg.SubmissionLimitConfig.MaxSubmissionsPerMinute = 1
+	fpm := &FinalityProviderManager{
+		cfgSnapshot:        atomic.NewPointer(&cfg),
+		submissionLimiters: make(map[string]*submissionLimiter),
+		metrics:            metrics.NewFpMetrics(),
+		logger:             zap.NewNop(),
+	}
+
+	// each finality provider has its own limiter, kept across the calls
+	l1 := fpm.getSubmissionLimiter("fp-1")
+	l2 := fpm.getSubmissionLimiter("fp-2")
+	require.NotSame(t, l1, l2)
+	require.Same(t, l1, fpm.getSubmissionLimiter("fp-1"))
+
+	// the limit of one does not affect the other
+	require.NoError(t, l1.allow(submissionVote))
+	require.ErrorIs(t, l1.allow(submissionVote), ErrSubmissionRateLimited)
+	require.NoError(t, l2.allow(submissionVote))
+
+	// no limiter is created if the limit is disabled
+	cfg.SubmissionLimitConfig.MaxSubmissionsPerMinute = 0
+	require.Nil(t, fpm.getSubmissionLimiter("fp-3"))
+}
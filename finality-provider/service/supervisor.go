@@ -0,0 +1,142 @@
+package service
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+)
+
+// crashRecord tracks the crashes of the instance of a finality provider
+type crashRecord struct {
+	// total is the number of crashes since the daemon started
+	total uint64
+	// recent are the times of the crashes within the restart window
+	recent []time.Time
+	// restarting is true while the instance is being restarted, the
+	// critical errors reported meanwhile are not counted as crashes
+	restarting bool
+}
+
+// handleInstanceCrash applies the restart policy to the instance which
+// reported an unexpected critical error. The daemon is terminated if the
+// policy is never or if the instance has crashed more than the max restarts
+// within the restart window, otherwise the instance is restarted after an
+// exponential backoff
+func (fpm *FinalityProviderManager) handleInstanceCrash(fpi *FinalityProviderInstance, crashErr error) {
+	cfg := fpm.config().SupervisorConfig
+	pkHex := fpi.GetBtcPkHex()
+
+	if cfg == nil || cfg.RestartPolicy == fpcfg.RestartPolicyNever {
+		fpm.metrics.IncrementFpTotalInstanceCrashes(pkHex)
+		fpm.logger.Fatal(instanceTerminatingMsg, zap.String("pk", pkHex), zap.Error(crashErr))
+	}
+
+	fpm.crashesMu.Lock()
+	record, exists := fpm.crashes[pkHex]
+	if !exists {
+		record = &crashRecord{}
+		fpm.crashes[pkHex] = record
+	}
+	if record.restarting {
+		fpm.crashesMu.Unlock()
+		fpm.logger.Debug("the finality-provider instance is already being restarted",
+			zap.String("pk", pkHex), zap.Error(crashErr))
+
+		return
+	}
+
+	now := time.Now()
+	recent := record.recent[:0]
+	for _, t := range record.recent {
+		if now.Sub(t) < cfg.RestartWindow {
+			recent = append(recent, t)
+		}
+	}
+	record.recent = append(recent, now)
+	record.total++
+	numRecent := len(record.recent)
+	total := record.total
+	record.restarting = uint32(numRecent) <= cfg.MaxRestarts
+	fpm.crashesMu.Unlock()
+
+	fpm.metrics.IncrementFpTotalInstanceCrashes(pkHex)
+
+	if uint32(numRecent) > cfg.MaxRestarts {
+		fpm.logger.Fatal(instanceTerminatingMsg+" after max restarts",
+			zap.String("pk", pkHex),
+			zap.Int("crashes", numRecent),
+			zap.Duration("restart_window", cfg.RestartWindow),
+			zap.Error(crashErr),
+		)
+	}
+
+	backoff := cfg.InitialBackoff
+	for i := 1; i < numRecent && backoff < cfg.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, cfg.MaxBackoff)
+
+	fpm.logger.Error("the finality-provider instance crashed, restarting it",
+		zap.String("pk", pkHex),
+		zap.Uint64("total_crashes", total),
+		zap.Int("recent_crashes", numRecent),
+		zap.Uint32("max_restarts", cfg.MaxRestarts),
+		zap.Duration("backoff", backoff),
+		zap.Error(crashErr),
+	)
+
+	fpm.wg.Add(1)
+	go fpm.restartFinalityProvider(fpi, backoff)
+}
+
+// restartFinalityProvider stops the crashed instance and starts a new one
+// from the stored finality provider after the backoff, unless the finality
+// provider is stopped or started by the operator in the meantime. The pause
+// of the voting is carried over to the new instance
+func (fpm *FinalityProviderManager) restartFinalityProvider(fpi *FinalityProviderInstance, backoff time.Duration) {
+	defer fpm.wg.Done()
+
+	fpPk := fpi.GetBtcPkBIP340()
+	pkHex := fpPk.MarshalHex()
+	defer func() {
+		fpm.crashesMu.Lock()
+		fpm.crashes[pkHex].restarting = false
+		fpm.crashesMu.Unlock()
+	}()
+
+	paused := fpi.IsPaused()
+	if err := fpm.removeFinalityProviderInstance(fpPk); err != nil {
+		fpm.logger.Warn("failed to remove the crashed finality-provider instance, skip restarting it",
+			zap.String("pk", pkHex), zap.Error(err))
+		return
+	}
+
+	if !fpm.sleepOrQuit(backoff) {
+		return
+	}
+
+	if fpm.isStoppedByOperator(fpPk) || fpm.IsFinalityProviderRunning(fpPk) {
+		fpm.logger.Info("the finality provider has been stopped or started in the meantime, skip restarting it",
+			zap.String("pk", pkHex))
+		return
+	}
+
+	if err := fpm.startFinalityProviderInstance(fpPk, fpi.passphrase); err != nil {
+		fpm.logger.Error("failed to restart the crashed finality-provider instance",
+			zap.String("pk", pkHex), zap.Error(err))
+		return
+	}
+
+	fpm.metrics.IncrementFpTotalInstanceRestarts(pkHex)
+
+	if paused {
+		if err := fpm.PauseFinalityProvider(fpPk); err != nil {
+			fpm.logger.Error("failed to pause the restarted finality-provider instance",
+				zap.String("pk", pkHex), zap.Error(err))
+		}
+	}
+
+	fpm.logger.Info("the crashed finality-provider instance is restarted", zap.String("pk", pkHex))
+}
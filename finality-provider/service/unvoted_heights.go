@@ -0,0 +1,81 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// the reasons of the heights which are not voted
+const (
+	// unvotedReasonNoVotingPower is used if the finality provider has no
+	// voting power at the height
+	unvotedReasonNoVotingPower = "no_voting_power"
+	// unvotedReasonPaused is used if the voting is paused
+	unvotedReasonPaused = "paused"
+	// unvotedReasonRandomnessMissing is used if the public randomness or its
+	// inclusion proof of the height cannot be obtained
+	unvotedReasonRandomnessMissing = "randomness_missing"
+	// unvotedReasonSignerError is used if the vote cannot be recorded or
+	// signed by the EOTS manager
+	unvotedReasonSignerError = "signer_error"
+	// unvotedReasonBroadcastFailure is used if the broadcast of the vote
+	// fails
+	unvotedReasonBroadcastFailure = "broadcast_failure"
+	// unvotedReasonRejectedByHook is used if a submission hook rejects the
+	// vote
+	unvotedReasonRejectedByHook = "rejected_by_hook"
+	// unvotedReasonRateLimited is used if the vote exceeds the submission
+	// rate limit
+	unvotedReasonRateLimited = "rate_limited"
+	// unvotedReasonFinalized is used if the height is finalized before the
+	// vote is submitted
+	unvotedReasonFinalized = "finalized"
+	// unvotedReasonRetriesExhausted is used if the queued vote is abandoned
+	// after its attempts or its window are exhausted
+	unvotedReasonRetriesExhausted = "retries_exhausted"
+	// unvotedReasonOther is used for the other failures
+	unvotedReasonOther = "other"
+)
+
+// unvotedError attaches the reason of the heights not being voted to the
+// error of a vote, without changing its message
+type unvotedError struct {
+	reason string
+	err    error
+}
+
+func (e *unvotedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *unvotedError) Unwrap() error {
+	return e.err
+}
+
+// withUnvotedReason attaches the given reason to the error of a vote
+func withUnvotedReason(reason string, err error) error {
+	return &unvotedError{reason: reason, err: err}
+}
+
+// unvotedReasonOf returns the reason of the heights not being voted given
+// the error of their vote
+func unvotedReasonOf(err error) string {
+	var unvotedErr *unvotedError
+	switch {
+	case errors.Is(err, ErrRejectedByHook):
+		return unvotedReasonRejectedByHook
+	case errors.Is(err, ErrSubmissionRateLimited):
+		return unvotedReasonRateLimited
+	case errors.As(err, &unvotedErr):
+		return unvotedErr.reason
+	default:
+		return unvotedReasonOther
+	}
+}
+
+// recordUnvotedHeights counts the given blocks as not voted for the given
+// reason
+func (fp *FinalityProviderInstance) recordUnvotedHeights(reason string, blocks []*types.BlockInfo) {
+	fp.metrics.AddToFpTotalUnvotedHeights(fp.GetBtcPkHex(), reason, len(blocks))
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/metrics"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// the stages of the pipeline of a finality vote
+const (
+	// voteStageQueue is the time from the receipt of the block to the
+	// start of the submission attempt which succeeds
+	voteStageQueue = "queue"
+	// voteStageRandomness is the time to get the public randomness and
+	// the proofs of the voted heights
+	voteStageRandomness = "randomness"
+	// voteStageSigning is the time to sign the votes with the EOTS manager
+	voteStageSigning = "signing"
+	// voteStageBroadcast is the time to broadcast the votes until the
+	// transaction is included in a block
+	voteStageBroadcast = "broadcast"
+	// voteStageTotal is the time from the receipt of the block to the
+	// confirmation of the vote, including the failed attempts
+	voteStageTotal = "total"
+)
+
+const (
+	// maxRecentVoteTimings is the number of the latest voted heights whose
+	// timings are kept
+	maxRecentVoteTimings = 100
+	// maxTrackedBlockReceipts bounds the receipt times kept for the blocks
+	// which are not voted, e.g., skipped or finalized in the meantime
+	maxTrackedBlockReceipts = 1000
+)
+
+// VoteTiming is the time spent in each stage of the pipeline of the vote
+// for a height. The durations depending on the receipt time are zero if
+// the block was not received from the poller, e.g., while catching up.
+// The durations are encoded in nanoseconds
+type VoteTiming struct {
+	Height     uint64        `json:"height"`
+	ReceivedAt time.Time     `json:"received_at"`
+	Queue      time.Duration `json:"queue_ns"`
+	Randomness time.Duration `json:"randomness_ns"`
+	Signing    time.Duration `json:"signing_ns"`
+	Broadcast  time.Duration `json:"broadcast_ns"`
+	Total      time.Duration `json:"total_ns"`
+}
+
+// voteAttemptTiming is the timing of a submission attempt of a batch of votes
+type voteAttemptTiming struct {
+	start      time.Time
+	randomness time.Duration
+	signing    time.Duration
+	broadcast  time.Duration
+}
+
+// votePipelineRecorder tracks the receipt time of the blocks and records
+// the timing of the votes once confirmed
+type votePipelineRecorder struct {
+	mu         sync.Mutex
+	receivedAt map[uint64]time.Time
+	recent     []*VoteTiming
+
+	fpBtcPkHex string
+	metrics    *metrics.FpMetrics
+	logger     *zap.Logger
+}
+
+func newVotePipelineRecorder(fpBtcPkHex string, metrics *metrics.FpMetrics, logger *zap.Logger) *votePipelineRecorder {
+	return &votePipelineRecorder{
+		receivedAt: make(map[uint64]time.Time),
+		fpBtcPkHex: fpBtcPkHex,
+		metrics:    metrics,
+		logger:     logger,
+	}
+}
+
+// blockReceived records the receipt time of the block at the given height,
+// the first receipt is kept if the block is received again
+func (vr *votePipelineRecorder) blockReceived(height uint64) {
+	vr.mu.Lock()
+	defer vr.mu.Unlock()
+
+	if _, ok := vr.receivedAt[height]; ok {
+		return
+	}
+	vr.receivedAt[height] = time.Now()
+
+	if len(vr.receivedAt) > maxTrackedBlockReceipts && height > maxTrackedBlockReceipts {
+		for h := range vr.receivedAt {
+			if h <= height-maxTrackedBlockReceipts {
+				delete(vr.receivedAt, h)
+			}
+		}
+	}
+}
+
+// votesConfirmed records the timing of the votes for the given blocks
+// submitted by the given attempt, and forgets the receipt times up to the
+// highest voted height
+func (vr *votePipelineRecorder) votesConfirmed(blocks []*types.BlockInfo, attempt *voteAttemptTiming) {
+	now := time.Now()
+
+	vr.mu.Lock()
+	defer vr.mu.Unlock()
+
+	for _, b := range blocks {
+		timing := &VoteTiming{
+			Height:     b.Height,
+			Randomness: attempt.randomness,
+			Signing:    attempt.signing,
+			Broadcast:  attempt.broadcast,
+		}
+		if receivedAt, ok := vr.receivedAt[b.Height]; ok {
+			timing.ReceivedAt = receivedAt
+			timing.Queue = attempt.start.Sub(receivedAt)
+			timing.Total = now.Sub(receivedAt)
+			vr.metrics.RecordFpVoteStageDuration(vr.fpBtcPkHex, voteStageQueue, timing.Queue)
+			vr.metrics.RecordFpVoteStageDuration(vr.fpBtcPkHex, voteStageTotal, timing.Total)
+		}
+		vr.metrics.RecordFpVoteStageDuration(vr.fpBtcPkHex, voteStageRandomness, timing.Randomness)
+		vr.metrics.RecordFpVoteStageDuration(vr.fpBtcPkHex, voteStageSigning, timing.Signing)
+		vr.metrics.RecordFpVoteStageDuration(vr.fpBtcPkHex, voteStageBroadcast, timing.Broadcast)
+
+		vr.logger.Debug("the vote pipeline timing",
+			zap.String("pk", vr.fpBtcPkHex),
+			zap.Uint64("height", timing.Height),
+			zap.Duration(voteStageQueue, timing.Queue),
+			zap.Duration(voteStageRandomness, timing.Randomness),
+			zap.Duration(voteStageSigning, timing.Signing),
+			zap.Duration(voteStageBroadcast, timing.Broadcast),
+			zap.Duration(voteStageTotal, timing.Total),
+		)
+
+		vr.recent = append(vr.recent, timing)
+	}
+	if len(vr.recent) > maxRecentVoteTimings {
+		vr.recent = vr.recent[len(vr.recent)-maxRecentVoteTimings:]
+	}
+
+	highest := blocks[len(blocks)-1].Height
+	for h := range vr.receivedAt {
+		if h <= highest {
+			delete(vr.receivedAt, h)
+		}
+	}
+}
+
+// recentVoteTimings returns the timings of the latest voted heights in the
+// order of the votes
+func (vr *votePipelineRecorder) recentVoteTimings() []*VoteTiming {
+	vr.mu.Lock()
+	defer vr.mu.Unlock()
+
+	timings := make([]*VoteTiming, len(vr.recent))
+	copy(timings, vr.recent)
+
+	return timings
+}
+
+// RecentVoteTimings returns the time spent in each stage of the pipeline of
+// the latest votes of the finality provider
+func (fp *FinalityProviderInstance) RecentVoteTimings() []*VoteTiming {
+	return fp.votePipeline.recentVoteTimings()
+}
+
+// RecentVoteTimings returns the timings of the latest votes of the running
+// finality provider instances by the hex of their public key
+func (app *FinalityProviderApp) RecentVoteTimings() map[string][]*VoteTiming {
+	timings := make(map[string][]*VoteTiming)
+	for _, fpi := range app.fpManager.listFinalityProviderInstances() {
+		if !fpi.IsRunning() {
+			continue
+		}
+		timings[fpi.GetBtcPkHex()] = fpi.RecentVoteTimings()
+	}
+
+	return timings
+}
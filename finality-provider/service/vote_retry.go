@@ -0,0 +1,179 @@
+package service
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// the reasons for which a queued vote is abandoned
+const (
+	// abandonReasonExpired is used if the voted block is too far below the
+	// tip, i.e., out of the voting window
+	abandonReasonExpired = "expired"
+	// abandonReasonFinalized is used if the voted block is finalized
+	// without the vote
+	abandonReasonFinalized = "finalized"
+	// abandonReasonMaxAttempts is used if the vote still fails after the
+	// max attempts
+	abandonReasonMaxAttempts = "max_attempts"
+)
+
+// voteRetryEnabled returns whether the votes which still fail after the max
+// retries are queued and retried
+func (fp *FinalityProviderInstance) voteRetryEnabled() bool {
+	return fp.voteRetries != nil && fp.cfg().VoteRetryConfig != nil && fp.cfg().VoteRetryConfig.Enabled
+}
+
+// enqueueFailedVotes persists the votes for the given blocks in the retry
+// queue
+func (fp *FinalityProviderInstance) enqueueFailedVotes(blocks []*types.BlockInfo) error {
+	votes := make([]*store.FailedVote, 0, len(blocks))
+	for _, b := range blocks {
+		votes = append(votes, &store.FailedVote{Height: b.Height, BlockHash: b.Hash})
+	}
+
+	if err := fp.voteRetries.EnqueueFailedVotes(fp.GetChainID(), fp.btcPk.MustMarshal(), votes); err != nil {
+		return fmt.Errorf("failed to queue the failed votes: %w", err)
+	}
+
+	fp.logger.Warn("queued the failed votes to be retried",
+		zap.String("pk", fp.GetBtcPkHex()),
+		zap.Uint64("start_height", blocks[0].Height),
+		zap.Uint64("end_height", blocks[len(blocks)-1].Height),
+	)
+
+	return fp.recordVoteRetryQueueDepth()
+}
+
+// recordVoteRetryQueueDepth records the number of the queued votes
+func (fp *FinalityProviderInstance) recordVoteRetryQueueDepth() error {
+	votes, err := fp.voteRetries.ListFailedVotes(fp.GetChainID(), fp.btcPk.MustMarshal())
+	if err != nil {
+		return fmt.Errorf("failed to list the queued votes: %w", err)
+	}
+	fp.metrics.RecordFpVoteRetryQueueDepth(fp.GetBtcPkHex(), len(votes))
+
+	return nil
+}
+
+// retryFailedVotes abandons the queued votes which cannot be submitted
+// anymore and submits a batch of the other ones. The submitted votes are
+// removed from the queue, the attempts of the votes are incremented if the
+// submission fails again
+func (fp *FinalityProviderInstance) retryFailedVotes() {
+	chainID, pk := fp.GetChainID(), fp.btcPk.MustMarshal()
+
+	votes, err := fp.voteRetries.ListFailedVotes(chainID, pk)
+	if err != nil {
+		fp.logger.Error("failed to list the queued votes", zap.String("pk", fp.GetBtcPkHex()), zap.Error(err))
+		return
+	}
+	if len(votes) == 0 {
+		return
+	}
+	defer func() {
+		if err := fp.recordVoteRetryQueueDepth(); err != nil {
+			fp.logger.Error("failed to record the vote retry queue depth", zap.String("pk", fp.GetBtcPkHex()), zap.Error(err))
+		}
+	}()
+
+	tip, err := fp.getLatestBlockWithRetry()
+	if err != nil {
+		fp.logger.Debug("failed to query the tip to retry the queued votes", zap.String("pk", fp.GetBtcPkHex()), zap.Error(err))
+		return
+	}
+	lastFinalizedHeight, hasFinalized, err := fp.lastFinalizedHeight()
+	if err != nil {
+		fp.logger.Debug("failed to query the last finalized block to retry the queued votes",
+			zap.String("pk", fp.GetBtcPkHex()), zap.Error(err))
+		return
+	}
+
+	cfg := fp.cfg().VoteRetryConfig
+	var abandoned []uint64
+	blocks := make([]*types.BlockInfo, 0, len(votes))
+	for _, v := range votes {
+		var reason string
+		switch {
+		case v.Attempts >= cfg.MaxAttempts:
+			reason = abandonReasonMaxAttempts
+		case hasFinalized && v.Height <= lastFinalizedHeight:
+			reason = abandonReasonFinalized
+		case tip.Height > v.Height+cfg.WindowBlocks:
+			reason = abandonReasonExpired
+		}
+		if reason != "" {
+			fp.logger.Warn("abandoned the queued vote",
+				zap.String("pk", fp.GetBtcPkHex()),
+				zap.Uint64("height", v.Height),
+				zap.Uint32("attempts", v.Attempts),
+				zap.String("reason", reason),
+			)
+			fp.metrics.IncrementFpTotalAbandonedVotes(fp.GetBtcPkHex(), reason)
+			unvotedReason := unvotedReasonRetriesExhausted
+			if reason == abandonReasonFinalized {
+				unvotedReason = unvotedReasonFinalized
+			}
+			fp.metrics.AddToFpTotalUnvotedHeights(fp.GetBtcPkHex(), unvotedReason, 1)
+			abandoned = append(abandoned, v.Height)
+			continue
+		}
+		if len(blocks) < int(fp.cfg().BatchSubmissionSize) {
+			blocks = append(blocks, &types.BlockInfo{Height: v.Height, Hash: v.BlockHash})
+		}
+	}
+	if err := fp.voteRetries.RemoveFailedVotes(chainID, pk, abandoned); err != nil {
+		fp.logger.Error("failed to remove the abandoned votes", zap.String("pk", fp.GetBtcPkHex()), zap.Error(err))
+		return
+	}
+	if len(blocks) == 0 {
+		return
+	}
+
+	heights := make([]uint64, 0, len(blocks))
+	for _, b := range blocks {
+		heights = append(heights, b.Height)
+	}
+
+	fp.isSubmittingSigs.Store(true)
+	res, err := fp.SubmitBatchFinalitySignatures(blocks)
+	fp.isSubmittingSigs.Store(false)
+	if err != nil && !clientcontroller.IsExpected(err) {
+		fp.logger.Debug("failed to retry the queued votes",
+			zap.String("pk", fp.GetBtcPkHex()),
+			zap.Uint64("start_height", heights[0]),
+			zap.Uint64("end_height", heights[len(heights)-1]),
+			zap.Error(err),
+		)
+		// the lease was lost, the new leader retries the votes if it shares
+		// the db
+		if isNotLeaderErr(err) {
+			fp.isLeader.Store(false)
+			return
+		}
+		if err := fp.voteRetries.IncrementFailedVoteAttempts(chainID, pk, heights); err != nil {
+			fp.logger.Error("failed to increment the attempts of the queued votes",
+				zap.String("pk", fp.GetBtcPkHex()), zap.Error(err))
+		}
+		return
+	}
+
+	// the votes are either submitted or submitted before
+	if err := fp.voteRetries.RemoveFailedVotes(chainID, pk, heights); err != nil {
+		fp.logger.Error("failed to remove the submitted votes", zap.String("pk", fp.GetBtcPkHex()), zap.Error(err))
+		return
+	}
+	if res != nil {
+		fp.logger.Info("successfully submitted the queued votes",
+			zap.String("pk", fp.GetBtcPkHex()),
+			zap.Uint64("start_height", heights[0]),
+			zap.Uint64("end_height", heights[len(heights)-1]),
+			zap.String("tx_hash", res.TxHash),
+		)
+	}
+}
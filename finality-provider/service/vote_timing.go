@@ -0,0 +1,129 @@
+package service
+
+import (
+	"errors"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// voteTimingStrategy decides when the received blocks are voted
+type voteTimingStrategy interface {
+	// readyBlocks returns the number of the first pending blocks, sorted by
+	// height, which are ready to be voted given the height of the last
+	// received block
+	readyBlocks(pending []*types.BlockInfo, lastReceivedHeight uint64) (int, error)
+}
+
+// immediateVoteTiming votes for the blocks as soon as they are received
+type immediateVoteTiming struct{}
+
+func (immediateVoteTiming) readyBlocks(pending []*types.BlockInfo, _ uint64) (int, error) {
+	return len(pending), nil
+}
+
+// delayedVoteTiming votes for a block once the given number of subsequent
+// blocks are received, so that the block is less likely to be reorged
+type delayedVoteTiming struct {
+	delay uint64
+}
+
+func (s delayedVoteTiming) readyBlocks(pending []*types.BlockInfo, lastReceivedHeight uint64) (int, error) {
+	n := 0
+	for n < len(pending) && pending[n].Height+s.delay <= lastReceivedHeight {
+		n++
+	}
+
+	return n, nil
+}
+
+// quorumWindowVoteTiming votes for a block once it is within the given
+// number of blocks above the last finalized block, i.e., once the quorum of
+// the block is about to be formed
+type quorumWindowVoteTiming struct {
+	window uint64
+	// lastFinalizedHeight returns false if no block is finalized yet
+	lastFinalizedHeight func() (uint64, bool, error)
+}
+
+func (s quorumWindowVoteTiming) readyBlocks(pending []*types.BlockInfo, _ uint64) (int, error) {
+	if len(pending) == 0 {
+		return 0, nil
+	}
+
+	finalizedHeight, ok, err := s.lastFinalizedHeight()
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		// the first blocks to finalize are voted right away
+		return len(pending), nil
+	}
+
+	n := 0
+	for n < len(pending) && pending[n].Height <= finalizedHeight+s.window {
+		n++
+	}
+
+	return n, nil
+}
+
+func newVoteTimingStrategy(cfg *fpcfg.VoteTimingConfig, fp *FinalityProviderInstance) voteTimingStrategy {
+	if cfg == nil {
+		return immediateVoteTiming{}
+	}
+
+	switch cfg.Strategy {
+	case fpcfg.VoteTimingDelayed:
+		return delayedVoteTiming{delay: cfg.DelayBlocks}
+	case fpcfg.VoteTimingQuorumWindow:
+		return quorumWindowVoteTiming{window: cfg.QuorumWindow, lastFinalizedHeight: fp.lastFinalizedHeight}
+	default:
+		return immediateVoteTiming{}
+	}
+}
+
+// lastFinalizedHeight returns the height of the last finalized block, false
+// if no block is finalized yet
+func (fp *FinalityProviderInstance) lastFinalizedHeight() (uint64, bool, error) {
+	blocks, err := fp.latestFinalizedBlocksWithRetry(1)
+	if err != nil {
+		return 0, false, err
+	}
+	if len(blocks) == 0 {
+		return 0, false, nil
+	}
+
+	return blocks[0].Height, true, nil
+}
+
+// nextBlocksToVote queues the received blocks and returns the queued ones
+// which are ready to be voted according to the vote timing strategy, at
+// most BatchSubmissionSize of them
+func (fp *FinalityProviderInstance) nextBlocksToVote(received []*types.BlockInfo) []*types.BlockInfo {
+	fp.pendingBlocks = append(fp.pendingBlocks, received...)
+
+	n, err := fp.voteTiming.readyBlocks(fp.pendingBlocks, fp.lastReceivedHeight.Load())
+	if err != nil {
+		if !errors.Is(err, ErrFinalityProviderShutDown) {
+			fp.reportCriticalErr(err)
+		}
+		return nil
+	}
+	n = min(n, int(fp.cfg().BatchSubmissionSize))
+
+	ready := fp.pendingBlocks[:n:n]
+	fp.pendingBlocks = fp.pendingBlocks[n:]
+
+	return ready
+}
+
+// processedHeight returns the height up to which the received blocks are
+// processed, i.e., right below the first block waiting to be voted
+func (fp *FinalityProviderInstance) processedHeight() uint64 {
+	if len(fp.pendingBlocks) > 0 {
+		return fp.pendingBlocks[0].Height - 1
+	}
+
+	return fp.lastReceivedHeight.Load()
+}
@@ -0,0 +1,35 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+)
+
+// ErrObjectNotFound is returned when the object does not exist in the archive
+var ErrObjectNotFound = errors.New("archived object not found")
+
+// ObjectArchive is a cold storage of immutable objects
+type ObjectArchive interface {
+	// Put saves the object with the given name
+	Put(ctx context.Context, name string, data []byte) error
+	// Get returns the object with the given name,
+	// ErrObjectNotFound if it does not exist
+	Get(ctx context.Context, name string) ([]byte, error)
+}
+
+// NewObjectArchive creates the object archive of the configured backend
+func NewObjectArchive(ctx context.Context, cfg *fpcfg.ArchiveConfig) (ObjectArchive, error) {
+	switch cfg.Backend {
+	case fpcfg.ArchiveBackendLocal:
+		return NewLocalArchive(cfg.LocalDir)
+	case fpcfg.ArchiveBackendS3:
+		return NewS3Archive(cfg.Bucket, cfg.S3Region, cfg.S3Endpoint)
+	case fpcfg.ArchiveBackendGCS:
+		return NewGCSArchive(ctx, cfg.Bucket)
+	default:
+		return nil, fmt.Errorf("unsupported archive backend: %s", cfg.Backend)
+	}
+}
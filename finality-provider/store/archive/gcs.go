@@ -0,0 +1,47 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"cloud.google.com/go/storage"
+)
+
+// GCSArchive archives objects to Google Cloud Storage.
+// The credentials are loaded from the application default credentials
+type GCSArchive struct {
+	bucket *storage.BucketHandle
+}
+
+func NewGCSArchive(ctx context.Context, bucket string) (*GCSArchive, error) {
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GCSArchive{bucket: client.Bucket(bucket)}, nil
+}
+
+func (a *GCSArchive) Put(ctx context.Context, name string, data []byte) error {
+	w := a.bucket.Object(name).NewWriter(ctx)
+	if _, err := w.Write(data); err != nil {
+		_ = w.Close()
+		return err
+	}
+
+	return w.Close()
+}
+
+func (a *GCSArchive) Get(ctx context.Context, name string) ([]byte, error) {
+	r, err := a.bucket.Object(name).NewReader(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return io.ReadAll(r)
+}
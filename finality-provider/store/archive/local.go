@@ -0,0 +1,66 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalArchive archives objects as files under a local directory
+type LocalArchive struct {
+	dir string
+}
+
+func NewLocalArchive(dir string) (*LocalArchive, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create the archive directory %s: %w", dir, err)
+	}
+
+	return &LocalArchive{dir: dir}, nil
+}
+
+func (a *LocalArchive) path(name string) (string, error) {
+	p := filepath.Join(a.dir, filepath.FromSlash(name))
+	if !strings.HasPrefix(p, filepath.Clean(a.dir)+string(os.PathSeparator)) {
+		return "", fmt.Errorf("invalid object name %s", name)
+	}
+
+	return p, nil
+}
+
+func (a *LocalArchive) Put(_ context.Context, name string, data []byte) error {
+	p, err := a.path(name)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+		return err
+	}
+
+	// write to a temporary file first so that a partially
+	// written object is never visible under its name
+	tmpPath := p + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, p)
+}
+
+func (a *LocalArchive) Get(_ context.Context, name string) ([]byte, error) {
+	p, err := a.path(name)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrObjectNotFound
+	}
+
+	return data, err
+}
@@ -0,0 +1,68 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// S3Archive archives objects to an S3 compatible object storage.
+// The credentials are loaded from the default AWS credential chain
+type S3Archive struct {
+	client *s3.S3
+	bucket string
+}
+
+func NewS3Archive(bucket, region, endpoint string) (*S3Archive, error) {
+	awsCfg := &aws.Config{}
+	if region != "" {
+		awsCfg.Region = aws.String(region)
+	}
+	if endpoint != "" {
+		awsCfg.Endpoint = aws.String(endpoint)
+		awsCfg.S3ForcePathStyle = aws.Bool(true)
+	}
+
+	sess, err := session.NewSession(awsCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &S3Archive{
+		client: s3.New(sess),
+		bucket: bucket,
+	}, nil
+}
+
+func (a *S3Archive) Put(ctx context.Context, name string, data []byte) error {
+	_, err := a.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(a.bucket),
+		Key:    aws.String(name),
+		Body:   bytes.NewReader(data),
+	})
+
+	return err
+}
+
+func (a *S3Archive) Get(ctx context.Context, name string) ([]byte, error) {
+	out, err := a.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(a.bucket),
+		Key:    aws.String(name),
+	})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, ErrObjectNotFound
+		}
+		return nil, err
+	}
+	defer out.Body.Close()
+
+	return io.ReadAll(out.Body)
+}
@@ -0,0 +1,246 @@
+package store
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/lightningnetwork/lnd/kvdb"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+)
+
+// The backup bundle is the copy of the db encrypted with a key derived from a
+// passphrase. It starts with a header made of the magic, the version, the
+// backend of the db, whose copy format differs between the backends, and the
+// salt of the key. The header is followed by the frames of the encrypted
+// chunks of the copy, each of which is the flag of the frame, the length of
+// the sealed chunk and the sealed chunk. The last frame is flagged as final
+// so that a truncated bundle is detected.
+const (
+	backupMagic                = "FPDSTATE"
+	backupVersion         byte = 1
+	backupSaltSize             = 16
+	backupChunkSize            = 64 * 1024
+	backupFrameHeaderSize      = 1 + 4
+	backupFrameFinal      byte = 1
+
+	// the scrypt parameters recommended for interactive logins
+	backupScryptN = 1 << 15
+	backupScryptR = 8
+	backupScryptP = 1
+
+	// MinBackupPassphraseLen is the minimum length of the passphrase
+	// encrypting a backup bundle
+	MinBackupPassphraseLen = 8
+)
+
+// WriteBackupBundle writes the copy of the db, backed by the given backend,
+// encrypted with the passphrase to w. The copy is made within a read
+// transaction held until it is fully written
+func WriteBackupBundle(w io.Writer, db kvdb.Backend, backend string, passphrase []byte) error {
+	if len(passphrase) < MinBackupPassphraseLen {
+		return fmt.Errorf("%w: it should have at least %d characters", ErrInvalidBackupPassphrase, MinBackupPassphraseLen)
+	}
+	if len(backend) == 0 || len(backend) > 0xff {
+		return fmt.Errorf("invalid db backend %q", backend)
+	}
+
+	salt := make([]byte, backupSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("failed to generate the salt: %w", err)
+	}
+	header := make([]byte, 0, len(backupMagic)+2+len(backend)+backupSaltSize)
+	header = append(header, backupMagic...)
+	header = append(header, backupVersion, byte(len(backend)))
+	header = append(header, backend...)
+	header = append(header, salt...)
+
+	aead, err := newBackupCipher(passphrase, salt)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+
+	enc := &backupEncrypter{
+		w:      w,
+		aead:   aead,
+		header: header,
+		buf:    make([]byte, 0, backupChunkSize),
+	}
+	if err := db.Copy(enc); err != nil {
+		return fmt.Errorf("failed to copy the db: %w", err)
+	}
+
+	return enc.sealFrame(backupFrameFinal)
+}
+
+// ReadBackupBundle reads the header of the backup bundle from r and returns
+// the backend of the db and the reader of the decrypted copy of the db. The
+// reader returns ErrInvalidBackupBundle if the passphrase is wrong or the
+// bundle is corrupted or truncated
+func ReadBackupBundle(r io.Reader, passphrase []byte) (string, io.Reader, error) {
+	prefix := make([]byte, len(backupMagic)+2)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		return "", nil, fmt.Errorf("%w: failed to read the header: %w", ErrInvalidBackupBundle, err)
+	}
+	if !bytes.Equal(prefix[:len(backupMagic)], []byte(backupMagic)) {
+		return "", nil, fmt.Errorf("%w: not a backup bundle", ErrInvalidBackupBundle)
+	}
+	if version := prefix[len(backupMagic)]; version != backupVersion {
+		return "", nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidBackupBundle, version)
+	}
+
+	rest := make([]byte, int(prefix[len(backupMagic)+1])+backupSaltSize)
+	if _, err := io.ReadFull(r, rest); err != nil {
+		return "", nil, fmt.Errorf("%w: failed to read the header: %w", ErrInvalidBackupBundle, err)
+	}
+	backend := string(rest[:len(rest)-backupSaltSize])
+
+	aead, err := newBackupCipher(passphrase, rest[len(rest)-backupSaltSize:])
+	if err != nil {
+		return "", nil, err
+	}
+
+	return backend, &backupDecrypter{
+		r:      r,
+		aead:   aead,
+		header: append(prefix, rest...),
+	}, nil
+}
+
+func newBackupCipher(passphrase, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(passphrase, salt, backupScryptN, backupScryptR, backupScryptP, chacha20poly1305.KeySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive the key of the backup: %w", err)
+	}
+
+	return chacha20poly1305.New(key)
+}
+
+// backupNonce returns the nonce of the frame, made of its flag and its
+// counter so that the frames cannot be reordered or flagged otherwise
+func backupNonce(flag byte, counter uint64) []byte {
+	nonce := make([]byte, chacha20poly1305.NonceSize)
+	nonce[0] = flag
+	binary.BigEndian.PutUint64(nonce[chacha20poly1305.NonceSize-8:], counter)
+
+	return nonce
+}
+
+// backupAdditionalData binds the frame to the header of the bundle
+func backupAdditionalData(header []byte, flag byte) []byte {
+	return append(append([]byte{}, header...), flag)
+}
+
+// backupEncrypter seals the written bytes by chunks into frames
+type backupEncrypter struct {
+	w       io.Writer
+	aead    cipher.AEAD
+	header  []byte
+	buf     []byte
+	counter uint64
+}
+
+func (e *backupEncrypter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := min(len(p), backupChunkSize-len(e.buf))
+		e.buf = append(e.buf, p[:n]...)
+		p = p[n:]
+		written += n
+
+		if len(e.buf) == backupChunkSize {
+			if err := e.sealFrame(0); err != nil {
+				return written, err
+			}
+		}
+	}
+
+	return written, nil
+}
+
+func (e *backupEncrypter) sealFrame(flag byte) error {
+	frame := make([]byte, backupFrameHeaderSize, backupFrameHeaderSize+len(e.buf)+e.aead.Overhead())
+	frame[0] = flag
+	// #nosec G115 -- the chunks are at most backupChunkSize
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(e.buf)+e.aead.Overhead()))
+	frame = e.aead.Seal(frame, backupNonce(flag, e.counter), e.buf, backupAdditionalData(e.header, flag))
+
+	if _, err := e.w.Write(frame); err != nil {
+		return err
+	}
+	e.counter++
+	e.buf = e.buf[:0]
+
+	return nil
+}
+
+// backupDecrypter opens the frames of the bundle
+type backupDecrypter struct {
+	r       io.Reader
+	aead    cipher.AEAD
+	header  []byte
+	pending []byte
+	counter uint64
+	final   bool
+}
+
+func (d *backupDecrypter) Read(p []byte) (int, error) {
+	for len(d.pending) == 0 {
+		if d.final {
+			return 0, io.EOF
+		}
+		if err := d.openFrame(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, d.pending)
+	d.pending = d.pending[n:]
+
+	return n, nil
+}
+
+func (d *backupDecrypter) openFrame() error {
+	frameHeader := make([]byte, backupFrameHeaderSize)
+	if _, err := io.ReadFull(d.r, frameHeader); err != nil {
+		return fmt.Errorf("%w: the bundle is truncated: %w", ErrInvalidBackupBundle, err)
+	}
+	flag := frameHeader[0]
+	sealedLen := int(binary.BigEndian.Uint32(frameHeader[1:]))
+	if (flag != 0 && flag != backupFrameFinal) || sealedLen > backupChunkSize+d.aead.Overhead() {
+		return fmt.Errorf("%w: invalid frame", ErrInvalidBackupBundle)
+	}
+
+	sealed := make([]byte, sealedLen)
+	if _, err := io.ReadFull(d.r, sealed); err != nil {
+		return fmt.Errorf("%w: the bundle is truncated: %w", ErrInvalidBackupBundle, err)
+	}
+	chunk, err := d.aead.Open(sealed[:0], backupNonce(flag, d.counter), sealed, backupAdditionalData(d.header, flag))
+	if err != nil {
+		return fmt.Errorf("%w: wrong passphrase or corrupted bundle", ErrInvalidBackupBundle)
+	}
+	d.counter++
+	d.pending = chunk
+
+	if flag == backupFrameFinal {
+		d.final = true
+		// nothing should follow the final frame
+		_, err := io.ReadFull(d.r, make([]byte, 1))
+		switch {
+		case err == nil:
+			return fmt.Errorf("%w: unexpected data after the final frame", ErrInvalidBackupBundle)
+		case !errors.Is(err, io.EOF):
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package store_test
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	fpstore "github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+)
+
+// TestBackupBundle tests that the backup bundle restores the db only with
+// the right passphrase and that a tampered or truncated bundle is rejected
+func TestBackupBundle(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	cfg := config.DefaultDBConfigWithHomePath(t.TempDir())
+	db, err := cfg.GetDBBackend()
+	require.NoError(t, err)
+	defer func() {
+		err := db.Close()
+		require.NoError(t, err)
+	}()
+
+	fps, err := fpstore.NewFinalityProviderStore(db)
+	require.NoError(t, err)
+	fp := testutil.GenRandomFinalityProvider(r, t)
+	fpAddr, err := sdk.AccAddressFromBech32(fp.FPAddr)
+	require.NoError(t, err)
+	err = fps.CreateFinalityProvider(fpAddr, fp.BtcPk, fp.Description, fp.Commission, fp.KeyName, fp.ChainID, fp.Pop.BtcSig)
+	require.NoError(t, err)
+
+	passphrase := []byte(testutil.GenRandomHexStr(r, 8))
+	err = fpstore.WriteBackupBundle(io.Discard, db, cfg.Backend, []byte("short"))
+	require.ErrorIs(t, err, fpstore.ErrInvalidBackupPassphrase)
+
+	var bundle bytes.Buffer
+	err = fpstore.WriteBackupBundle(&bundle, db, cfg.Backend, passphrase)
+	require.NoError(t, err)
+
+	// the db is restored from the bundle
+	backend, copyReader, err := fpstore.ReadBackupBundle(bytes.NewReader(bundle.Bytes()), passphrase)
+	require.NoError(t, err)
+	require.Equal(t, cfg.Backend, backend)
+
+	restoredCfg := config.DefaultDBConfigWithHomePath(t.TempDir())
+	require.NoError(t, os.MkdirAll(restoredCfg.DBPath, 0700))
+	f, err := os.Create(filepath.Join(restoredCfg.DBPath, restoredCfg.DBFileName))
+	require.NoError(t, err)
+	_, err = io.Copy(f, copyReader)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	restoredDB, err := restoredCfg.GetDBBackend()
+	require.NoError(t, err)
+	defer func() {
+		err := restoredDB.Close()
+		require.NoError(t, err)
+	}()
+	restoredFps, err := fpstore.NewFinalityProviderStore(restoredDB)
+	require.NoError(t, err)
+	restoredFp, err := restoredFps.GetFinalityProvider(fp.BtcPk)
+	require.NoError(t, err)
+	require.Equal(t, fp.FPAddr, restoredFp.FPAddr)
+
+	readAll := func(bz []byte, passphrase []byte) error {
+		_, copyReader, err := fpstore.ReadBackupBundle(bytes.NewReader(bz), passphrase)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(io.Discard, copyReader)
+
+		return err
+	}
+
+	// a wrong passphrase is rejected
+	err = readAll(bundle.Bytes(), []byte("wrong-passphrase"))
+	require.ErrorIs(t, err, fpstore.ErrInvalidBackupBundle)
+
+	// a tampered bundle is rejected
+	tampered := bytes.Clone(bundle.Bytes())
+	tampered[len(tampered)/2] ^= 0xff
+	err = readAll(tampered, passphrase)
+	require.ErrorIs(t, err, fpstore.ErrInvalidBackupBundle)
+
+	// a truncated bundle is rejected
+	for _, size := range []int{len(bundle.Bytes()) / 2, len(bundle.Bytes()) - 1} {
+		err = readAll(bundle.Bytes()[:size], passphrase)
+		require.ErrorIs(t, err, fpstore.ErrInvalidBackupBundle)
+	}
+}
@@ -0,0 +1,101 @@
+package store_test
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/babylonlabs-io/babylon/crypto/eots"
+	"github.com/babylonlabs-io/babylon/testutil/datagen"
+	bbn "github.com/babylonlabs-io/babylon/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	fpstore "github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// BenchmarkSubmissionLoop compares the throughput of the db backends in the
+// submission loop, in which every finality provider concurrently reads the
+// proof of its public randomness and persists its voted height for each
+// height. An op is one height voted by all the finality providers
+func BenchmarkSubmissionLoop(b *testing.B) {
+	for _, backend := range []string{config.DBBackendBolt, config.DBBackendPebble, config.DBBackendMemory} {
+		for _, numFps := range []int{1, 10, 50} {
+			b.Run(fmt.Sprintf("%s/fps-%d", backend, numFps), func(b *testing.B) {
+				benchmarkSubmissionLoop(b, backend, numFps)
+			})
+		}
+	}
+}
+
+func benchmarkSubmissionLoop(b *testing.B, backend string, numFps int) {
+	r := rand.New(rand.NewSource(10))
+	const numPubRand = 100
+	chainID := []byte("chain-test")
+
+	cfg := config.DefaultDBConfigWithHomePath(b.TempDir())
+	cfg.Backend = backend
+	db, err := cfg.GetDBBackend()
+	require.NoError(b, err)
+	b.Cleanup(func() {
+		require.NoError(b, db.Close())
+	})
+
+	fpStore, err := fpstore.NewFinalityProviderStore(db)
+	require.NoError(b, err)
+	prStore, err := fpstore.NewPubRandProofStore(db)
+	require.NoError(b, err)
+
+	pubRandList := make([]*btcec.FieldVal, 0, numPubRand)
+	for i := 0; i < numPubRand; i++ {
+		_, pr, err := eots.RandGen(r)
+		require.NoError(b, err)
+		pubRandList = append(pubRandList, pr)
+	}
+	_, proofList := types.GetPubRandCommitAndProofs(pubRandList)
+
+	fpPks := make([]*btcec.PublicKey, 0, numFps)
+	for i := 0; i < numFps; i++ {
+		_, btcPk, err := datagen.GenRandomBTCKeyPair(r)
+		require.NoError(b, err)
+		fpAddr, err := sdk.AccAddressFromBech32(datagen.GenRandomAccount().Address)
+		require.NoError(b, err)
+		err = fpStore.CreateFinalityProvider(fpAddr, btcPk, testutil.RandomDescription(r),
+			testutil.ZeroCommissionRate(), testutil.GenRandomHexStr(r, 4), string(chainID), []byte("sig"))
+		require.NoError(b, err)
+		err = prStore.AddPubRandProofList(chainID, bbn.NewBIP340PubKeyFromBTCPK(btcPk).MustMarshal(), 1, numPubRand, proofList)
+		require.NoError(b, err)
+		fpPks = append(fpPks, btcPk)
+	}
+
+	b.ResetTimer()
+
+	var wg sync.WaitGroup
+	for _, fpPk := range fpPks {
+		wg.Add(1)
+		go func(fpPk *btcec.PublicKey) {
+			defer wg.Done()
+			pk := bbn.NewBIP340PubKeyFromBTCPK(fpPk).MustMarshal()
+			for height := uint64(1); height <= uint64(b.N); height++ {
+				if _, err := prStore.GetPubRandProof(chainID, pk, (height-1)%numPubRand+1); err != nil {
+					b.Error(err)
+					return
+				}
+				_, err := fpStore.UpdateFpState(fpPk, &fpstore.FinalityProviderStateUpdate{
+					LastVotedHeight:     height,
+					LastProcessedHeight: height,
+				})
+				if err != nil {
+					b.Error(err)
+					return
+				}
+			}
+		}(fpPk)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,75 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/lightningnetwork/lnd/kvdb"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store/pebbledb"
+)
+
+// CopyDB copies all the buckets of src into dst, which should be empty. The
+// copy is made within a single read transaction of src, so that it is
+// consistent, and a single write transaction of dst
+func CopyDB(dst, src kvdb.Backend) error {
+	return src.View(func(rtx kvdb.RTx) error {
+		return kvdb.Update(dst, func(wtx kvdb.RwTx) error {
+			return rtx.ForEachBucket(func(name []byte) error {
+				srcBucket := rtx.ReadBucket(name)
+				if srcBucket == nil {
+					return nil
+				}
+				dstBucket, err := wtx.CreateTopLevelBucket(name)
+				if err != nil {
+					return err
+				}
+
+				return copyBucket(dstBucket, srcBucket)
+			})
+		}, func() {})
+	}, func() {})
+}
+
+// copyBucket copies the keys and the nested buckets of src into dst
+func copyBucket(dst walletdb.ReadWriteBucket, src walletdb.ReadBucket) error {
+	return src.ForEach(func(k, v []byte) error {
+		if v != nil {
+			return dst.Put(k, v)
+		}
+
+		// a nil value is a nested bucket
+		srcNested := src.NestedReadBucket(k)
+		if srcNested == nil {
+			return dst.Put(k, v)
+		}
+		dstNested, err := dst.CreateBucket(k)
+		if err != nil {
+			return err
+		}
+
+		return copyBucket(dstNested, srcNested)
+	})
+}
+
+// OpenInMemoryCopy opens the db with the given function and returns an
+// in-memory copy of it, the db being closed once copied, so that writing to
+// the copy leaves the db unchanged
+func OpenInMemoryCopy(open func() (kvdb.Backend, error)) (kvdb.Backend, error) {
+	db, err := open()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	memDB, err := pebbledb.OpenInMemory()
+	if err != nil {
+		return nil, err
+	}
+	if err := CopyDB(memDB, db); err != nil {
+		_ = memDB.Close()
+		return nil, fmt.Errorf("failed to copy the db into memory: %w", err)
+	}
+
+	return memDB, nil
+}
@@ -0,0 +1,70 @@
+package store_test
+
+import (
+	"math/rand"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	fpstore "github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store/pebbledb"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+)
+
+// TestCopyDB tests that the top level and the nested buckets of a db are
+// copied into an in-memory db, the source db being left unchanged
+func TestCopyDB(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(10))
+
+	cfg := config.DefaultDBConfigWithHomePath(t.TempDir())
+	src, err := cfg.GetDBBackend()
+	require.NoError(t, err)
+	defer func() {
+		err := src.Close()
+		require.NoError(t, err)
+	}()
+
+	fpStore, err := fpstore.NewFinalityProviderStore(src)
+	require.NoError(t, err)
+	srStore, err := fpstore.NewSignRecordStore(src)
+	require.NoError(t, err)
+
+	fp := testutil.GenRandomFinalityProvider(r, t)
+	fpAddr, err := sdk.AccAddressFromBech32(fp.FPAddr)
+	require.NoError(t, err)
+	err = fpStore.CreateFinalityProvider(fpAddr, fp.BtcPk, fp.Description, fp.Commission, fp.KeyName, fp.ChainID, fp.Pop.BtcSig)
+	require.NoError(t, err)
+	chainID, pk := []byte(fp.ChainID), fp.GetBIP340BTCPK().MustMarshal()
+	msg := testutil.GenRandomByteArray(r, 40)
+	err = srStore.SaveSignRecord(chainID, pk, 10, msg)
+	require.NoError(t, err)
+
+	dst, err := pebbledb.OpenInMemory()
+	require.NoError(t, err)
+	defer func() {
+		err := dst.Close()
+		require.NoError(t, err)
+	}()
+	err = fpstore.CopyDB(dst, src)
+	require.NoError(t, err)
+
+	dstFpStore, err := fpstore.NewFinalityProviderStore(dst)
+	require.NoError(t, err)
+	copiedFp, err := dstFpStore.GetFinalityProvider(fp.BtcPk)
+	require.NoError(t, err)
+	require.Equal(t, fp.KeyName, copiedFp.KeyName)
+	dstSrStore, err := fpstore.NewSignRecordStore(dst)
+	require.NoError(t, err)
+	signed, err := dstSrStore.GetSignRecord(chainID, pk, 10)
+	require.NoError(t, err)
+	require.Equal(t, msg, signed)
+
+	// writing to the copy does not change the source
+	err = dstSrStore.SaveSignRecord(chainID, pk, 11, msg)
+	require.NoError(t, err)
+	_, err = srStore.GetSignRecord(chainID, pk, 11)
+	require.ErrorIs(t, err, fpstore.ErrSignRecordNotFound)
+}
@@ -9,12 +9,42 @@ var (
 	// ErrFinalityProviderNotFound The finality provider we try update is not found in db
 	ErrFinalityProviderNotFound = errors.New("finality provider not found")
 
-	// ErrDuplicateFinalityProvider, The finality provider we try to add already exists in db
-	ErrDuplicateFinalityProvider = errors.New("finality provider already exists")
+	// ErrFinalityProviderConflict The finality provider we try to add already exists in db
+	// with different metadata
+	ErrFinalityProviderConflict = errors.New("finality provider already exists with different metadata")
+
+	// ErrFinalityProviderExists The finality provider we try to recover already exists in db
+	ErrFinalityProviderExists = errors.New("finality provider already exists")
 
 	// ErrCorruptedPubRandProofDB For some reason, db on disk representation have changed
 	ErrCorruptedPubRandProofDB = errors.New("public randomness proof db is corrupted")
 
 	// ErrPubRandProofNotFound The finality provider we try update is not found in db
 	ErrPubRandProofNotFound = errors.New("public randomness proof not found")
+
+	// ErrCorruptedSignRecordDB For some reason, db on disk representation have changed
+	ErrCorruptedSignRecordDB = errors.New("sign record db is corrupted")
+
+	// ErrSignRecordNotFound The finality provider has not signed at the height
+	ErrSignRecordNotFound = errors.New("sign record not found")
+
+	// ErrCorruptedVoteRetryDB For some reason, db on disk representation have changed
+	ErrCorruptedVoteRetryDB = errors.New("vote retry db is corrupted")
+
+	// ErrCorruptedOutboxDB For some reason, db on disk representation have changed
+	ErrCorruptedOutboxDB = errors.New("outbox db is corrupted")
+
+	// ErrCorruptedHistoryDB For some reason, db on disk representation have changed
+	ErrCorruptedHistoryDB = errors.New("submission history db is corrupted")
+
+	// ErrInvalidBackupPassphrase The passphrase of the backup is too weak
+	ErrInvalidBackupPassphrase = errors.New("invalid backup passphrase")
+
+	// ErrInvalidBackupBundle The backup bundle cannot be decrypted, either the
+	// passphrase is wrong or the bundle is corrupted or truncated
+	ErrInvalidBackupBundle = errors.New("invalid backup bundle")
+
+	// ErrDoubleSign A different message has been signed at the same height,
+	// or a message is to be signed below the highest signed height
+	ErrDoubleSign = errors.New("refused to sign a different message at an already signed height")
 )
@@ -1,7 +1,9 @@
 package store
 
 import (
+	"bytes"
 	"fmt"
+	"slices"
 
 	sdkmath "cosmossdk.io/math"
 	"github.com/btcsuite/btcd/btcec/v2"
@@ -41,6 +43,11 @@ func (s *FinalityProviderStore) initBuckets() error {
 	})
 }
 
+// CreateFinalityProvider saves a new finality provider into the db.
+// It is idempotent: if a finality provider with the same BTC pk already
+// exists with identical metadata, it returns nil without modifying the
+// stored record. If the existing record differs, it returns an error
+// wrapping ErrFinalityProviderConflict
 func (s *FinalityProviderStore) CreateFinalityProvider(
 	fpAddr sdk.AccAddress,
 	btcPk *btcec.PublicKey,
@@ -79,14 +86,46 @@ func (s *FinalityProviderStore) createFinalityProviderInternal(
 		}
 
 		// check btc pk first to avoid duplicates
-		if fpBucket.Get(fp.BtcPk) != nil {
-			return ErrDuplicateFinalityProvider
+		if existingBytes := fpBucket.Get(fp.BtcPk); existingBytes != nil {
+			var existing proto.FinalityProvider
+			if err := pm.Unmarshal(existingBytes, &existing); err != nil {
+				return ErrCorruptedFinalityProviderDB
+			}
+
+			return checkFinalityProviderConflict(&existing, fp)
 		}
 
 		return saveFinalityProvider(fpBucket, fp)
 	})
 }
 
+// checkFinalityProviderConflict compares the metadata of the stored finality
+// provider, including its PoP, with the one being created. The status and
+// last voted height are runtime states which should not be overwritten by a
+// create request
+func checkFinalityProviderConflict(existing, fp *proto.FinalityProvider) error {
+	switch {
+	case existing.FpAddr != fp.FpAddr:
+		return fmt.Errorf("%w: fp address %s differs from the stored %s",
+			ErrFinalityProviderConflict, fp.FpAddr, existing.FpAddr)
+	case existing.KeyName != fp.KeyName:
+		return fmt.Errorf("%w: key name %s differs from the stored %s",
+			ErrFinalityProviderConflict, fp.KeyName, existing.KeyName)
+	case existing.ChainId != fp.ChainId:
+		return fmt.Errorf("%w: chain id %s differs from the stored %s",
+			ErrFinalityProviderConflict, fp.ChainId, existing.ChainId)
+	case existing.Commission != fp.Commission:
+		return fmt.Errorf("%w: commission %s differs from the stored %s",
+			ErrFinalityProviderConflict, fp.Commission, existing.Commission)
+	case !bytes.Equal(existing.Description, fp.Description):
+		return fmt.Errorf("%w: description differs from the stored one", ErrFinalityProviderConflict)
+	case !bytes.Equal(existing.GetPop().GetBtcSig(), fp.GetPop().GetBtcSig()):
+		return fmt.Errorf("%w: proof of possession differs from the stored one", ErrFinalityProviderConflict)
+	}
+
+	return nil
+}
+
 func saveFinalityProvider(
 	fpBucket walletdb.ReadWriteBucket,
 	fp *proto.FinalityProvider,
@@ -103,6 +142,45 @@ func saveFinalityProvider(
 	return fpBucket.Put(fp.BtcPk, marshalled)
 }
 
+// SaveRecoveredFinalityProvider saves a finality provider reconstructed from
+// the on-chain data, including its status and last voted height which is also
+// used as the last processed height. It returns ErrFinalityProviderExists if
+// the finality provider is already stored so that the local state is never
+// overwritten by a recovery
+func (s *FinalityProviderStore) SaveRecoveredFinalityProvider(sfp *StoredFinalityProvider) error {
+	desBytes, err := sfp.Description.Marshal()
+	if err != nil {
+		return fmt.Errorf("invalid description: %w", err)
+	}
+	fp := &proto.FinalityProvider{
+		FpAddr:      sfp.FPAddr,
+		BtcPk:       schnorr.SerializePubKey(sfp.BtcPk),
+		Description: desBytes,
+		Commission:  sfp.Commission.String(),
+		Pop: &proto.ProofOfPossession{
+			BtcSig: sfp.Pop.BtcSig,
+		},
+		KeyName:             sfp.KeyName,
+		ChainId:             sfp.ChainID,
+		LastVotedHeight:     sfp.LastVotedHeight,
+		LastProcessedHeight: sfp.LastVotedHeight,
+		Status:              sfp.Status,
+	}
+
+	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		fpBucket := tx.ReadWriteBucket(finalityProviderBucketName)
+		if fpBucket == nil {
+			return ErrCorruptedFinalityProviderDB
+		}
+
+		if fpBucket.Get(fp.BtcPk) != nil {
+			return ErrFinalityProviderExists
+		}
+
+		return saveFinalityProvider(fpBucket, fp)
+	})
+}
+
 func (s *FinalityProviderStore) SetFpStatus(btcPk *btcec.PublicKey, status proto.FinalityProviderStatus) error {
 	setFpStatus := func(fp *proto.FinalityProvider) error {
 		fp.Status = status
@@ -155,6 +233,51 @@ func (s *FinalityProviderStore) SetFpLastVotedHeight(btcPk *btcec.PublicKey, las
 	return s.setFinalityProviderState(btcPk, setFpLastVotedHeight)
 }
 
+// FinalityProviderStateUpdate defines the state of a finality provider
+// to be updated atomically
+type FinalityProviderStateUpdate struct {
+	// Status is not updated if nil
+	Status *proto.FinalityProviderStatus
+	// LastVotedHeight is only updated if it is larger than the stored one
+	LastVotedHeight uint64
+	// LastProcessedHeight is only updated if it is larger than the stored one
+	LastProcessedHeight uint64
+}
+
+// UpdateFpState updates the status, the last voted height, and the last processed
+// height of the finality provider in a single transaction so that they never
+// diverge after a crash. The heights increase monotonically. It returns the
+// finality provider state after the update
+func (s *FinalityProviderStore) UpdateFpState(
+	btcPk *btcec.PublicKey,
+	update *FinalityProviderStateUpdate,
+) (*StoredFinalityProvider, error) {
+	var updatedFp *proto.FinalityProvider
+	updateFpState := func(fp *proto.FinalityProvider) error {
+		if update.Status != nil {
+			fp.Status = *update.Status
+		}
+
+		if fp.LastVotedHeight < update.LastVotedHeight {
+			fp.LastVotedHeight = update.LastVotedHeight
+		}
+
+		if fp.LastProcessedHeight < update.LastProcessedHeight {
+			fp.LastProcessedHeight = update.LastProcessedHeight
+		}
+
+		updatedFp = fp
+
+		return nil
+	}
+
+	if err := s.setFinalityProviderState(btcPk, updateFpState); err != nil {
+		return nil, err
+	}
+
+	return protoFpToStoredFinalityProvider(updatedFp)
+}
+
 func (s *FinalityProviderStore) setFinalityProviderState(
 	btcPk *btcec.PublicKey,
 	stateTransitionFn func(provider *proto.FinalityProvider) error,
@@ -220,11 +343,54 @@ func (s *FinalityProviderStore) GetFinalityProvider(btcPk *btcec.PublicKey) (*St
 	return storedFp, nil
 }
 
-// GetAllStoredFinalityProviders fetches all the stored finality providers from db
-// pagination is probably not needed as the expected number of finality providers
-// in the store is small
-func (s *FinalityProviderStore) GetAllStoredFinalityProviders() ([]*StoredFinalityProvider, error) {
-	var storedFps []*StoredFinalityProvider
+// FinalityProviderQuery defines the filters and pagination used to
+// query the stored finality providers
+type FinalityProviderQuery struct {
+	// Statuses filters by status, empty means any status
+	Statuses []proto.FinalityProviderStatus
+	// ChainID filters by chain id, empty means any chain
+	ChainID string
+	// BtcPks filters by the BIP-340 public keys, nil means any public key
+	BtcPks [][]byte
+	// StartKey is the key to start the iteration from (inclusive),
+	// empty means starting from the first key
+	StartKey []byte
+	// Limit is the maximum number of results, 0 means no limit
+	Limit uint64
+}
+
+func (q *FinalityProviderQuery) match(fp *proto.FinalityProvider) bool {
+	if q.ChainID != "" && fp.ChainId != q.ChainID {
+		return false
+	}
+
+	if q.BtcPks != nil && !slices.ContainsFunc(q.BtcPks, func(pk []byte) bool {
+		return bytes.Equal(pk, fp.BtcPk)
+	}) {
+		return false
+	}
+
+	if len(q.Statuses) == 0 {
+		return true
+	}
+
+	for _, status := range q.Statuses {
+		if fp.Status == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// QueryFinalityProviders fetches the stored finality providers matching the
+// given query. It returns the next key to be used as the start key of the
+// following query, which is nil if there are no more results
+func (s *FinalityProviderStore) QueryFinalityProviders(q *FinalityProviderQuery) ([]*StoredFinalityProvider, []byte, error) {
+	var (
+		storedFps []*StoredFinalityProvider
+		nextKey   []byte
+	)
 
 	err := s.db.View(func(tx kvdb.RTx) error {
 		fpBucket := tx.ReadBucket(finalityProviderBucketName)
@@ -232,30 +398,66 @@ func (s *FinalityProviderStore) GetAllStoredFinalityProviders() ([]*StoredFinali
 			return ErrCorruptedFinalityProviderDB
 		}
 
-		return fpBucket.ForEach(func(_, v []byte) error {
+		c := fpBucket.ReadCursor()
+		k, v := c.First()
+		if len(q.StartKey) != 0 {
+			k, v = c.Seek(q.StartKey)
+		}
+
+		for ; k != nil; k, v = c.Next() {
 			var fpProto proto.FinalityProvider
 			if err := pm.Unmarshal(v, &fpProto); err != nil {
 				return ErrCorruptedFinalityProviderDB
 			}
 
+			if !q.match(&fpProto) {
+				continue
+			}
+
+			if q.Limit != 0 && uint64(len(storedFps)) == q.Limit {
+				nextKey = append([]byte{}, k...)
+				return nil
+			}
+
 			fpFromDB, err := protoFpToStoredFinalityProvider(&fpProto)
 			if err != nil {
 				return err
 			}
 			storedFps = append(storedFps, fpFromDB)
+		}
 
-			return nil
-		})
-	}, func() {})
+		return nil
+	}, func() {
+		storedFps = nil
+		nextKey = nil
+	})
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return storedFps, nil
+	return storedFps, nextKey, nil
+}
+
+// GetAllStoredFinalityProviders fetches all the stored finality providers from db
+// use QueryFinalityProviders for filtering and pagination
+func (s *FinalityProviderStore) GetAllStoredFinalityProviders() ([]*StoredFinalityProvider, error) {
+	storedFps, _, err := s.QueryFinalityProviders(&FinalityProviderQuery{})
+
+	return storedFps, err
 }
 
 // SetFpDescription updates description of finality provider
+// SetFpCommission sets the commission rate of the finality provider
+func (s *FinalityProviderStore) SetFpCommission(btcPk *btcec.PublicKey, rate sdkmath.LegacyDec) error {
+	setCommission := func(fp *proto.FinalityProvider) error {
+		fp.Commission = rate.String()
+		return nil
+	}
+
+	return s.setFinalityProviderState(btcPk, setCommission)
+}
+
 func (s *FinalityProviderStore) SetFpDescription(btcPk *btcec.PublicKey, desc *stakingtypes.Description, rate *sdkmath.LegacyDec) error {
 	setDescription := func(fp *proto.FinalityProvider) error {
 		descBytes, err := desc.Marshal()
@@ -5,13 +5,16 @@ import (
 	"os"
 	"testing"
 
+	sdkmath "cosmossdk.io/math"
 	"github.com/babylonlabs-io/babylon/testutil/datagen"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/stretchr/testify/require"
 
 	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
 	fpstore "github.com/babylonlabs-io/finality-provider/finality-provider/store"
 	"github.com/babylonlabs-io/finality-provider/testutil"
+	"github.com/babylonlabs-io/finality-provider/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -54,7 +57,7 @@ func FuzzFinalityProvidersStore(f *testing.F) {
 		require.NoError(t, err)
 
 		// create same finality provider again
-		// and expect duplicate error
+		// and expect no error as the metadata is identical
 		err = vs.CreateFinalityProvider(
 			fpAddr,
 			fp.BtcPk,
@@ -64,7 +67,33 @@ func FuzzFinalityProvidersStore(f *testing.F) {
 			fp.ChainID,
 			fp.Pop.BtcSig,
 		)
-		require.ErrorIs(t, err, fpstore.ErrDuplicateFinalityProvider)
+		require.NoError(t, err)
+
+		// create the same finality provider with a different key name
+		// and expect conflict error
+		err = vs.CreateFinalityProvider(
+			fpAddr,
+			fp.BtcPk,
+			fp.Description,
+			fp.Commission,
+			fp.KeyName+"-other",
+			fp.ChainID,
+			fp.Pop.BtcSig,
+		)
+		require.ErrorIs(t, err, fpstore.ErrFinalityProviderConflict)
+
+		// create the same finality provider with a different PoP and
+		// expect conflict error
+		err = vs.CreateFinalityProvider(
+			fpAddr,
+			fp.BtcPk,
+			fp.Description,
+			fp.Commission,
+			fp.KeyName,
+			fp.ChainID,
+			datagen.GenRandomByteArray(r, 64),
+		)
+		require.ErrorIs(t, err, fpstore.ErrFinalityProviderConflict)
 
 		fpList, err := vs.GetAllStoredFinalityProviders()
 		require.NoError(t, err)
@@ -74,6 +103,14 @@ func FuzzFinalityProvidersStore(f *testing.F) {
 		require.NoError(t, err)
 		require.Equal(t, fp.BtcPk, actualFp.BtcPk)
 
+		// update the commission
+		newCommission := sdkmath.LegacyNewDecWithPrec(r.Int63n(100), 2)
+		err = vs.SetFpCommission(fp.BtcPk, newCommission)
+		require.NoError(t, err)
+		actualFp, err = vs.GetFinalityProvider(fp.BtcPk)
+		require.NoError(t, err)
+		require.True(t, newCommission.Equal(*actualFp.Commission))
+
 		_, randomBtcPk, err := datagen.GenRandomBTCKeyPair(r)
 		require.NoError(t, err)
 		_, err = vs.GetFinalityProvider(randomBtcPk)
@@ -223,3 +260,171 @@ func TestUpdateFpStatusFromVotingPower(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryFinalityProviders(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(10))
+
+	homePath := t.TempDir()
+	cfg := config.DefaultDBConfigWithHomePath(homePath)
+
+	fpdb, err := cfg.GetDBBackend()
+	require.NoError(t, err)
+	fps, err := fpstore.NewFinalityProviderStore(fpdb)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		err := fpdb.Close()
+		require.NoError(t, err)
+	})
+
+	numFps := 7
+	numActive := 0
+	var btcPks [][]byte
+	for i := 0; i < numFps; i++ {
+		fp := testutil.GenRandomFinalityProvider(r, t)
+		btcPks = append(btcPks, schnorr.SerializePubKey(fp.BtcPk))
+		err = fps.CreateFinalityProvider(
+			sdk.MustAccAddressFromBech32(fp.FPAddr),
+			fp.BtcPk,
+			fp.Description,
+			fp.Commission,
+			fp.KeyName,
+			fp.ChainID,
+			fp.Pop.BtcSig,
+		)
+		require.NoError(t, err)
+
+		if i%2 == 0 {
+			err = fps.SetFpStatus(fp.BtcPk, proto.FinalityProviderStatus_ACTIVE)
+			require.NoError(t, err)
+			numActive++
+		}
+	}
+
+	// filter by status
+	activeFps, nextKey, err := fps.QueryFinalityProviders(&fpstore.FinalityProviderQuery{
+		Statuses: []proto.FinalityProviderStatus{proto.FinalityProviderStatus_ACTIVE},
+	})
+	require.NoError(t, err)
+	require.Nil(t, nextKey)
+	require.Len(t, activeFps, numActive)
+	for _, fp := range activeFps {
+		require.Equal(t, proto.FinalityProviderStatus_ACTIVE, fp.Status)
+	}
+
+	// filter by a non-existing chain id
+	noFps, _, err := fps.QueryFinalityProviders(&fpstore.FinalityProviderQuery{ChainID: "non-existing"})
+	require.NoError(t, err)
+	require.Empty(t, noFps)
+
+	// filter by the public keys
+	scopedFps, _, err := fps.QueryFinalityProviders(&fpstore.FinalityProviderQuery{BtcPks: btcPks[:2]})
+	require.NoError(t, err)
+	require.Len(t, scopedFps, 2)
+	for _, fp := range scopedFps {
+		require.Contains(t, btcPks[:2], schnorr.SerializePubKey(fp.BtcPk))
+	}
+	noFps, _, err = fps.QueryFinalityProviders(&fpstore.FinalityProviderQuery{BtcPks: [][]byte{}})
+	require.NoError(t, err)
+	require.Empty(t, noFps)
+
+	// paginate through all the finality providers
+	q := &fpstore.FinalityProviderQuery{Limit: 3}
+	seen := make(map[string]struct{})
+	for {
+		page, nextKey, err := fps.QueryFinalityProviders(q)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(page), 3)
+		for _, fp := range page {
+			seen[fp.GetBIP340BTCPK().MarshalHex()] = struct{}{}
+		}
+		if nextKey == nil {
+			break
+		}
+		q.StartKey = nextKey
+	}
+	require.Len(t, seen, numFps)
+}
+
+func TestUpdateFpState(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(10))
+
+	homePath := t.TempDir()
+	cfg := config.DefaultDBConfigWithHomePath(homePath)
+
+	fpdb, err := cfg.GetDBBackend()
+	require.NoError(t, err)
+	fps, err := fpstore.NewFinalityProviderStore(fpdb)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		err := fpdb.Close()
+		require.NoError(t, err)
+	})
+
+	fp := testutil.GenRandomFinalityProvider(r, t)
+	err = fps.CreateFinalityProvider(
+		sdk.MustAccAddressFromBech32(fp.FPAddr),
+		fp.BtcPk,
+		fp.Description,
+		fp.Commission,
+		fp.KeyName,
+		fp.ChainID,
+		fp.Pop.BtcSig,
+	)
+	require.NoError(t, err)
+
+	activeStatus := proto.FinalityProviderStatus_ACTIVE
+	updatedFp, err := fps.UpdateFpState(fp.BtcPk, &fpstore.FinalityProviderStateUpdate{
+		Status:              &activeStatus,
+		LastVotedHeight:     10,
+		LastProcessedHeight: 12,
+	})
+	require.NoError(t, err)
+	require.Equal(t, activeStatus, updatedFp.Status)
+	require.Equal(t, uint64(10), updatedFp.LastVotedHeight)
+	require.Equal(t, uint64(12), updatedFp.LastProcessedHeight)
+
+	// lower heights should not be updated and nil status should be kept
+	updatedFp, err = fps.UpdateFpState(fp.BtcPk, &fpstore.FinalityProviderStateUpdate{
+		LastVotedHeight:     5,
+		LastProcessedHeight: 13,
+	})
+	require.NoError(t, err)
+	require.Equal(t, activeStatus, updatedFp.Status)
+	require.Equal(t, uint64(10), updatedFp.LastVotedHeight)
+	require.Equal(t, uint64(13), updatedFp.LastProcessedHeight)
+
+	storedFp, err := fps.GetFinalityProvider(fp.BtcPk)
+	require.NoError(t, err)
+	require.Equal(t, updatedFp, storedFp)
+
+	_, randomBtcPk, err := datagen.GenRandomBTCKeyPair(r)
+	require.NoError(t, err)
+	_, err = fps.UpdateFpState(randomBtcPk, &fpstore.FinalityProviderStateUpdate{LastVotedHeight: 1})
+	require.ErrorIs(t, err, fpstore.ErrFinalityProviderNotFound)
+}
+
+func TestPopExport(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(10))
+
+	fp := testutil.GenRandomFinalityProvider(r, t)
+	export, err := fp.ToPopExport()
+	require.NoError(t, err)
+	require.Equal(t, fp.GetBIP340BTCPK().MarshalHex(), export.EotsPkHex)
+	require.NoError(t, types.VerifyPopExport(export))
+
+	// the proof-of-possession does not hold for another address
+	otherFp := testutil.GenRandomFinalityProvider(r, t)
+	tampered := *export
+	tampered.FpAddr = otherFp.FPAddr
+	require.Error(t, types.VerifyPopExport(&tampered))
+
+	// nor for another EOTS key
+	tampered = *export
+	tampered.EotsPkHex = otherFp.GetBIP340BTCPK().MarshalHex()
+	require.Error(t, types.VerifyPopExport(&tampered))
+}
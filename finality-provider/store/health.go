@@ -0,0 +1,39 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+var (
+	// holds the probe written by the health check
+	healthCheckBucketName = []byte("healthCheck")
+	healthCheckKey        = []byte("probe")
+)
+
+// CheckWritable writes a probe into the db and reads it back to check
+// that the db is writable
+func (s *FinalityProviderStore) CheckWritable() error {
+	probe := []byte(strconv.FormatInt(time.Now().UnixNano(), 10))
+
+	return kvdb.Update(s.db, func(tx kvdb.RwTx) error {
+		bucket, err := tx.CreateTopLevelBucket(healthCheckBucketName)
+		if err != nil {
+			return err
+		}
+
+		if err := bucket.Put(healthCheckKey, probe); err != nil {
+			return err
+		}
+
+		if !bytes.Equal(bucket.Get(healthCheckKey), probe) {
+			return fmt.Errorf("the written probe cannot be read back")
+		}
+
+		return nil
+	}, func() {})
+}
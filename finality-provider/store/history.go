@@ -0,0 +1,278 @@
+package store
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcwallet/walletdb"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+var (
+	// mapping: chain_id -> pk -> kind || height -> submission record
+	historyBucketName = []byte("submission_history")
+)
+
+// SubmissionResult is the result of the broadcast of a submission
+type SubmissionResult byte
+
+const (
+	// SubmissionResultSubmitted is a submission included in a transaction
+	SubmissionResultSubmitted SubmissionResult = iota + 1
+	// SubmissionResultFailed is a submission whose broadcast failed
+	SubmissionResultFailed
+)
+
+func (r SubmissionResult) String() string {
+	switch r {
+	case SubmissionResultSubmitted:
+		return "submitted"
+	case SubmissionResultFailed:
+		return "failed"
+	default:
+		return fmt.Sprintf("unknown(%d)", byte(r))
+	}
+}
+
+// SubmissionRecord is the latest result of the broadcast of a vote or of a
+// public randomness commit of a finality provider
+type SubmissionRecord struct {
+	Kind SubmissionKind
+	// Height is the voted height or the start height of the committed
+	// public randomness
+	Height uint64
+	// BlockHash is the hash of the voted block, only set for the votes
+	BlockHash []byte
+	// NumPubRand is the number of committed public randomness, only set
+	// for the public randomness commits
+	NumPubRand uint64
+	Result     SubmissionResult
+	// TxHash is the hash of the transaction including the submission, only
+	// set if submitted
+	TxHash string
+	// Error is the error of the broadcast, only set if failed
+	Error     string
+	Timestamp time.Time
+}
+
+// SubmissionHistoryStore keeps the results of the votes and the public
+// randomness commits of the finality providers, so that the operators can
+// check locally whether a height has been voted on
+type SubmissionHistoryStore struct {
+	db kvdb.Backend
+}
+
+// NewSubmissionHistoryStore returns a new store backed by db
+func NewSubmissionHistoryStore(db kvdb.Backend) (*SubmissionHistoryStore, error) {
+	store := &SubmissionHistoryStore{db}
+	if err := store.initBuckets(); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func (s *SubmissionHistoryStore) initBuckets() error {
+	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		_, err := tx.CreateTopLevelBucket(historyBucketName)
+		return err
+	})
+}
+
+// createHistoryBucket returns the bucket storing the submission records of
+// the given finality provider on the given chain, which is created if not
+// exists
+func createHistoryBucket(tx kvdb.RwTx, chainID, pk []byte) (walletdb.ReadWriteBucket, error) {
+	bucket := tx.ReadWriteBucket(historyBucketName)
+	if bucket == nil {
+		return nil, ErrCorruptedHistoryDB
+	}
+
+	chainBucket, err := bucket.CreateBucketIfNotExists(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	return chainBucket.CreateBucketIfNotExists(pk)
+}
+
+func historyKey(kind SubmissionKind, height uint64) []byte {
+	return append([]byte{byte(kind)}, sdk.Uint64ToBigEndian(height)...)
+}
+
+// the value of a record is the result, the timestamp in unix nanoseconds,
+// the number of public randomness, the length-prefixed tx hash, the
+// length-prefixed block hash and the error
+func encodeSubmissionRecord(rec *SubmissionRecord) []byte {
+	bz := make([]byte, 0, 1+8+8+2+len(rec.TxHash)+2+len(rec.BlockHash)+len(rec.Error))
+	bz = append(bz, byte(rec.Result))
+	// #nosec G115 -- the timestamps are after the unix epoch
+	bz = binary.BigEndian.AppendUint64(bz, uint64(rec.Timestamp.UnixNano()))
+	bz = binary.BigEndian.AppendUint64(bz, rec.NumPubRand)
+	// #nosec G115 -- the lengths are checked before the encoding
+	bz = binary.BigEndian.AppendUint16(bz, uint16(len(rec.TxHash)))
+	bz = append(bz, rec.TxHash...)
+	// #nosec G115 -- the lengths are checked before the encoding
+	bz = binary.BigEndian.AppendUint16(bz, uint16(len(rec.BlockHash)))
+	bz = append(bz, rec.BlockHash...)
+
+	return append(bz, rec.Error...)
+}
+
+func decodeSubmissionRecord(k, v []byte) (*SubmissionRecord, error) {
+	if len(k) != 9 || len(v) < 1+8+8+2 {
+		return nil, ErrCorruptedHistoryDB
+	}
+	rec := &SubmissionRecord{
+		Kind:   SubmissionKind(k[0]),
+		Height: sdk.BigEndianToUint64(k[1:]),
+		Result: SubmissionResult(v[0]),
+		// #nosec G115 -- encoded from a unix nano timestamp
+		Timestamp:  time.Unix(0, int64(binary.BigEndian.Uint64(v[1:9]))),
+		NumPubRand: binary.BigEndian.Uint64(v[9:17]),
+	}
+	v = v[17:]
+
+	txHashLen := int(binary.BigEndian.Uint16(v))
+	if len(v) < 2+txHashLen+2 {
+		return nil, ErrCorruptedHistoryDB
+	}
+	rec.TxHash = string(v[2 : 2+txHashLen])
+	v = v[2+txHashLen:]
+
+	blockHashLen := int(binary.BigEndian.Uint16(v))
+	if len(v) < 2+blockHashLen {
+		return nil, ErrCorruptedHistoryDB
+	}
+	if blockHashLen > 0 {
+		rec.BlockHash = make([]byte, blockHashLen)
+		copy(rec.BlockHash, v[2:2+blockHashLen])
+	}
+	rec.Error = string(v[2+blockHashLen:])
+
+	return rec, nil
+}
+
+// RecordSubmissions saves the given records of the finality provider. A
+// submitted record is not overwritten by a failed one, so that a failed
+// resubmission does not hide the transaction which included the submission.
+// The votes more than retainHeights below the highest recorded one are
+// pruned, 0 keeps all of them
+func (s *SubmissionHistoryStore) RecordSubmissions(
+	chainID []byte,
+	pk []byte,
+	records []*SubmissionRecord,
+	retainHeights uint64,
+) error {
+	if len(chainID) == 0 || len(pk) == 0 {
+		return fmt.Errorf("chain id and public key cannot be empty")
+	}
+
+	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		bucket, err := createHistoryBucket(tx, chainID, pk)
+		if err != nil {
+			return err
+		}
+
+		var highestVote uint64
+		for _, rec := range records {
+			if rec.Kind != SubmissionVote && rec.Kind != SubmissionPubRandCommit {
+				return fmt.Errorf("unknown submission kind %s", rec.Kind)
+			}
+			if len(rec.TxHash) > 0xffff || len(rec.BlockHash) > 0xffff {
+				return fmt.Errorf("the tx hash or the block hash of the record is too long")
+			}
+			if rec.Kind == SubmissionVote {
+				highestVote = max(highestVote, rec.Height)
+			}
+
+			key := historyKey(rec.Kind, rec.Height)
+			if rec.Result == SubmissionResultFailed {
+				if prev := bucket.Get(key); len(prev) > 0 && SubmissionResult(prev[0]) == SubmissionResultSubmitted {
+					continue
+				}
+			}
+			if err := bucket.Put(key, encodeSubmissionRecord(rec)); err != nil {
+				return err
+			}
+		}
+
+		if retainHeights == 0 || highestVote <= retainHeights {
+			return nil
+		}
+
+		return pruneVoteRecords(bucket, highestVote-retainHeights)
+	})
+}
+
+// pruneVoteRecords deletes the vote records below the given height
+func pruneVoteRecords(bucket walletdb.ReadWriteBucket, belowHeight uint64) error {
+	var keys [][]byte
+	end := historyKey(SubmissionVote, belowHeight)
+	c := bucket.ReadCursor()
+	for k, _ := c.Seek(historyKey(SubmissionVote, 0)); k != nil && bytes.Compare(k, end) < 0; k, _ = c.Next() {
+		keys = append(keys, append([]byte{}, k...))
+	}
+
+	for _, k := range keys {
+		if err := bucket.Delete(k); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListSubmissions returns the records of the given kind of the finality
+// provider between fromHeight and toHeight inclusive, in ascending order of
+// height
+func (s *SubmissionHistoryStore) ListSubmissions(
+	chainID []byte,
+	pk []byte,
+	kind SubmissionKind,
+	fromHeight, toHeight uint64,
+) ([]*SubmissionRecord, error) {
+	var records []*SubmissionRecord
+
+	err := s.db.View(func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(historyBucketName)
+		if bucket == nil {
+			return ErrCorruptedHistoryDB
+		}
+
+		chainBucket := bucket.NestedReadBucket(chainID)
+		if chainBucket == nil {
+			return nil
+		}
+
+		pkBucket := chainBucket.NestedReadBucket(pk)
+		if pkBucket == nil {
+			return nil
+		}
+
+		c := pkBucket.ReadCursor()
+		for k, v := c.Seek(historyKey(kind, fromHeight)); k != nil && k[0] == byte(kind); k, v = c.Next() {
+			rec, err := decodeSubmissionRecord(k, v)
+			if err != nil {
+				return err
+			}
+			if rec.Height > toHeight {
+				break
+			}
+			records = append(records, rec)
+		}
+
+		return nil
+	}, func() {
+		records = nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
@@ -0,0 +1,113 @@
+package store_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	fpstore "github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+)
+
+// TestSubmissionHistoryStore tests that the results of the submissions are
+// recorded by kind and height and that the old votes are pruned
+func TestSubmissionHistoryStore(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	cfg := config.DefaultDBConfigWithHomePath(t.TempDir())
+	db, err := cfg.GetDBBackend()
+	require.NoError(t, err)
+	defer func() {
+		err := db.Close()
+		require.NoError(t, err)
+	}()
+
+	history, err := fpstore.NewSubmissionHistoryStore(db)
+	require.NoError(t, err)
+
+	fp := testutil.GenRandomFinalityProvider(r, t)
+	pk := fp.GetBIP340BTCPK().MustMarshal()
+	chainID := []byte("chain-test")
+	now := time.Unix(0, time.Now().UnixNano())
+
+	height := uint64(r.Int63n(1000) + 1)
+	failedVote := &fpstore.SubmissionRecord{
+		Kind:      fpstore.SubmissionVote,
+		Height:    height + 1,
+		BlockHash: testutil.GenRandomByteArray(r, 32),
+		Result:    fpstore.SubmissionResultFailed,
+		Error:     "broadcast failed",
+		Timestamp: now,
+	}
+	submittedVote := &fpstore.SubmissionRecord{
+		Kind:      fpstore.SubmissionVote,
+		Height:    height,
+		BlockHash: testutil.GenRandomByteArray(r, 32),
+		Result:    fpstore.SubmissionResultSubmitted,
+		TxHash:    testutil.GenRandomHexStr(r, 32),
+		Timestamp: now,
+	}
+	commit := &fpstore.SubmissionRecord{
+		Kind:       fpstore.SubmissionPubRandCommit,
+		Height:     height,
+		NumPubRand: 100,
+		Result:     fpstore.SubmissionResultSubmitted,
+		TxHash:     testutil.GenRandomHexStr(r, 32),
+		Timestamp:  now,
+	}
+	err = history.RecordSubmissions(chainID, pk, []*fpstore.SubmissionRecord{failedVote, submittedVote, commit}, 0)
+	require.NoError(t, err)
+
+	votes, err := history.ListSubmissions(chainID, pk, fpstore.SubmissionVote, 0, math.MaxUint64)
+	require.NoError(t, err)
+	require.Equal(t, []*fpstore.SubmissionRecord{submittedVote, failedVote}, votes)
+
+	votes, err = history.ListSubmissions(chainID, pk, fpstore.SubmissionVote, height+1, height+1)
+	require.NoError(t, err)
+	require.Equal(t, []*fpstore.SubmissionRecord{failedVote}, votes)
+
+	commits, err := history.ListSubmissions(chainID, pk, fpstore.SubmissionPubRandCommit, 0, math.MaxUint64)
+	require.NoError(t, err)
+	require.Equal(t, []*fpstore.SubmissionRecord{commit}, commits)
+
+	// a submitted vote is not overwritten by a failed one, while a failed
+	// vote is overwritten by a submitted one
+	resubmittedVote := *failedVote
+	resubmittedVote.Result = fpstore.SubmissionResultSubmitted
+	resubmittedVote.Error = ""
+	resubmittedVote.TxHash = testutil.GenRandomHexStr(r, 32)
+	refailedVote := *submittedVote
+	refailedVote.Result = fpstore.SubmissionResultFailed
+	refailedVote.TxHash = ""
+	refailedVote.Error = "broadcast failed"
+	err = history.RecordSubmissions(chainID, pk, []*fpstore.SubmissionRecord{&resubmittedVote, &refailedVote}, 0)
+	require.NoError(t, err)
+
+	votes, err = history.ListSubmissions(chainID, pk, fpstore.SubmissionVote, 0, math.MaxUint64)
+	require.NoError(t, err)
+	require.Equal(t, []*fpstore.SubmissionRecord{submittedVote, &resubmittedVote}, votes)
+
+	// the votes below the retained heights are pruned, not the commits
+	newVote := *submittedVote
+	newVote.Height = height + 10
+	err = history.RecordSubmissions(chainID, pk, []*fpstore.SubmissionRecord{&newVote}, 9)
+	require.NoError(t, err)
+
+	votes, err = history.ListSubmissions(chainID, pk, fpstore.SubmissionVote, 0, math.MaxUint64)
+	require.NoError(t, err)
+	require.Equal(t, []*fpstore.SubmissionRecord{&resubmittedVote, &newVote}, votes)
+
+	commits, err = history.ListSubmissions(chainID, pk, fpstore.SubmissionPubRandCommit, 0, math.MaxUint64)
+	require.NoError(t, err)
+	require.Len(t, commits, 1)
+
+	// the records are namespaced by chain id
+	votes, err = history.ListSubmissions([]byte("chain-other"), pk, fpstore.SubmissionVote, 0, math.MaxUint64)
+	require.NoError(t, err)
+	require.Empty(t, votes)
+}
@@ -0,0 +1,311 @@
+package store
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/lightningnetwork/lnd/kvdb"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protowire"
+	pm "google.golang.org/protobuf/proto"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+)
+
+var (
+	// mapping db_version -> uint32
+	metadataBucketName = []byte("metadata")
+	dbVersionKey       = []byte("db_version")
+)
+
+// migration defines a change of the db layout from version-1 to version
+type migration struct {
+	version     uint32
+	description string
+	migrate     func(tx kvdb.RwTx, logger *zap.Logger) error
+}
+
+// migrations is the ordered list of migrations applied to the db,
+// new migrations must be appended with an incremented version
+var migrations = []migration{
+	{
+		version:     1,
+		description: "migrate legacy finality provider records and public randomness proofs",
+		migrate:     migrateLegacyLayout,
+	},
+}
+
+// LatestDBVersion is the version of the db layout used by the current release
+func LatestDBVersion() uint32 {
+	return migrations[len(migrations)-1].version
+}
+
+// GetDBVersion returns the version of the db layout, 0 means that the db
+// either is empty or has a legacy layout that predates versioning
+func GetDBVersion(db kvdb.Backend) (uint32, error) {
+	var version uint32
+	err := db.View(func(tx kvdb.RTx) error {
+		version = getDBVersion(tx)
+		return nil
+	}, func() {})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+func getDBVersion(tx kvdb.RTx) uint32 {
+	metadataBucket := tx.ReadBucket(metadataBucketName)
+	if metadataBucket == nil {
+		return 0
+	}
+
+	versionBytes := metadataBucket.Get(dbVersionKey)
+	if len(versionBytes) != 4 {
+		return 0
+	}
+
+	return binary.BigEndian.Uint32(versionBytes)
+}
+
+func putDBVersion(tx kvdb.RwTx, version uint32) error {
+	metadataBucket, err := tx.CreateTopLevelBucket(metadataBucketName)
+	if err != nil {
+		return err
+	}
+
+	versionBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(versionBytes, version)
+
+	return metadataBucket.Put(dbVersionKey, versionBytes)
+}
+
+// isEmptyDB returns true if none of the data buckets exist,
+// i.e., the db is newly created and needs no migration
+func isEmptyDB(tx kvdb.RTx) bool {
+	return tx.ReadBucket(finalityProviderBucketName) == nil &&
+		tx.ReadBucket(pubRandProofBucketName) == nil &&
+		tx.ReadBucket(legacyPubRandProofBucketName) == nil
+}
+
+// MigrateDB detects whether the db has a legacy layout and migrates it in
+// place to the latest version. Before migrating, a copy of the db is written
+// to backupPath unless it is empty. All the pending migrations are applied
+// in a single transaction so that a failure leaves the db untouched.
+// It must be called before the stores are created
+func MigrateDB(db kvdb.Backend, backupPath string, logger *zap.Logger) error {
+	var (
+		version uint32
+		isEmpty bool
+	)
+	err := db.View(func(tx kvdb.RTx) error {
+		version = getDBVersion(tx)
+		isEmpty = isEmptyDB(tx)
+		return nil
+	}, func() {})
+	if err != nil {
+		return fmt.Errorf("failed to read the db version: %w", err)
+	}
+
+	latestVersion := LatestDBVersion()
+	if version > latestVersion {
+		return fmt.Errorf("the db version %d is newer than the supported version %d", version, latestVersion)
+	}
+
+	if version == latestVersion {
+		return nil
+	}
+
+	// a new db does not need to be migrated
+	if version == 0 && isEmpty {
+		return kvdb.Update(db, func(tx kvdb.RwTx) error {
+			return putDBVersion(tx, latestVersion)
+		}, func() {})
+	}
+
+	logger.Info("detected an outdated db layout, migrating",
+		zap.Uint32("db_version", version),
+		zap.Uint32("latest_version", latestVersion),
+	)
+
+	if backupPath != "" {
+		if err := backupDB(db, backupPath); err != nil {
+			return fmt.Errorf("failed to back up the db before migration: %w", err)
+		}
+		logger.Info("backed up the db before migration", zap.String("path", backupPath))
+	}
+
+	err = kvdb.Update(db, func(tx kvdb.RwTx) error {
+		for _, m := range migrations {
+			if m.version <= version {
+				continue
+			}
+
+			logger.Info("applying db migration",
+				zap.Uint32("version", m.version),
+				zap.String("description", m.description),
+			)
+			if err := m.migrate(tx, logger); err != nil {
+				return fmt.Errorf("failed to apply db migration %d: %w", m.version, err)
+			}
+		}
+
+		return putDBVersion(tx, latestVersion)
+	}, func() {})
+	if err != nil {
+		return err
+	}
+
+	logger.Info("successfully migrated the db", zap.Uint32("db_version", latestVersion))
+
+	return nil
+}
+
+func backupDB(db kvdb.Backend, backupPath string) error {
+	// refuse to overwrite an existing backup
+	f, err := os.OpenFile(backupPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+
+	if err := db.Copy(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// migrateLegacyLayout migrates the layouts of releases that predate db versioning
+func migrateLegacyLayout(tx kvdb.RwTx, logger *zap.Logger) error {
+	fpBucket, err := tx.CreateTopLevelBucket(finalityProviderBucketName)
+	if err != nil {
+		return err
+	}
+	if err := migrateLegacyFinalityProviders(fpBucket, logger); err != nil {
+		return err
+	}
+
+	// the legacy proofs are not namespaced by chain id and are migrated
+	// by each finality provider instance upon start, see
+	// PubRandProofStore.MigrateLegacyPubRandProofs
+	pubRandBucket := tx.ReadWriteBucket(legacyPubRandProofBucketName)
+	if pubRandBucket == nil {
+		return nil
+	}
+
+	return migrateLegacyPubRandProofs(pubRandBucket, logger)
+}
+
+// legacyFpStatusFieldNum is the field number of the status in the legacy
+// finality provider records, in which field 9 was the last processed height
+const legacyFpStatusFieldNum protowire.Number = 10
+
+// migrateLegacyFinalityProviders rewrites the finality provider records
+// stored with the legacy layout, where the last processed height is
+// decoded as the status and the actual status is an unknown field
+func migrateLegacyFinalityProviders(fpBucket walletdb.ReadWriteBucket, logger *zap.Logger) error {
+	var legacyFps []*proto.FinalityProvider
+	err := fpBucket.ForEach(func(_, v []byte) error {
+		var fp proto.FinalityProvider
+		if err := pm.Unmarshal(v, &fp); err != nil {
+			return ErrCorruptedFinalityProviderDB
+		}
+
+		legacyStatus, hasLegacyStatus, err := parseLegacyFpStatus(fp.ProtoReflect().GetUnknown())
+		if err != nil {
+			return err
+		}
+
+		_, isKnownStatus := proto.FinalityProviderStatus_name[int32(fp.Status)]
+		switch {
+		case hasLegacyStatus:
+			fp.Status = legacyStatus
+		case !isKnownStatus:
+			// the status is omitted when it is the zero value
+			fp.Status = proto.FinalityProviderStatus_CREATED
+		default:
+			return nil
+		}
+
+		fp.ProtoReflect().SetUnknown(nil)
+		legacyFps = append(legacyFps, &fp)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, fp := range legacyFps {
+		if err := saveFinalityProvider(fpBucket, fp); err != nil {
+			return err
+		}
+	}
+
+	logger.Info("migrated legacy finality provider records", zap.Int("count", len(legacyFps)))
+
+	return nil
+}
+
+func parseLegacyFpStatus(unknown []byte) (proto.FinalityProviderStatus, bool, error) {
+	for len(unknown) > 0 {
+		num, typ, n := protowire.ConsumeTag(unknown)
+		if n < 0 {
+			return 0, false, ErrCorruptedFinalityProviderDB
+		}
+		unknown = unknown[n:]
+
+		if num == legacyFpStatusFieldNum && typ == protowire.VarintType {
+			v, n := protowire.ConsumeVarint(unknown)
+			if n < 0 {
+				return 0, false, ErrCorruptedFinalityProviderDB
+			}
+
+			return proto.FinalityProviderStatus(v), true, nil
+		}
+
+		n = protowire.ConsumeFieldValue(num, typ, unknown)
+		if n < 0 {
+			return 0, false, ErrCorruptedFinalityProviderDB
+		}
+		unknown = unknown[n:]
+	}
+
+	return 0, false, nil
+}
+
+// migrateLegacyPubRandProofs removes the public randomness proofs whose keys
+// are not a 32-byte public randomness, as they can never be looked up
+func migrateLegacyPubRandProofs(pubRandBucket walletdb.ReadWriteBucket, logger *zap.Logger) error {
+	var invalidKeys [][]byte
+	err := pubRandBucket.ForEach(func(k, _ []byte) error {
+		if len(k) != 32 {
+			invalidKeys = append(invalidKeys, append([]byte{}, k...))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, k := range invalidKeys {
+		if err := pubRandBucket.Delete(k); err != nil {
+			return err
+		}
+	}
+
+	logger.Info("removed legacy public randomness proofs", zap.Int("count", len(invalidKeys)))
+
+	return nil
+}
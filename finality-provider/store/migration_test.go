@@ -0,0 +1,89 @@
+package store_test
+
+import (
+	"math/rand"
+	"path/filepath"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protowire"
+	pm "google.golang.org/protobuf/proto"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	fpstore "github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+)
+
+// TestMigrateLegacyDB tests that finality provider records stored with the
+// legacy layout are migrated and the db is backed up beforehand
+func TestMigrateLegacyDB(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(10))
+
+	homePath := t.TempDir()
+	cfg := config.DefaultDBConfigWithHomePath(homePath)
+
+	fpdb, err := cfg.GetDBBackend()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err := fpdb.Close()
+		require.NoError(t, err)
+	})
+
+	// write a legacy record in which field 9 is the last processed
+	// height and field 10 is the status
+	fp := testutil.GenRandomFinalityProvider(r, t)
+	desBytes, err := fp.Description.Marshal()
+	require.NoError(t, err)
+	legacyFp := &proto.FinalityProvider{
+		FpAddr:      fp.FPAddr,
+		BtcPk:       schnorr.SerializePubKey(fp.BtcPk),
+		Description: desBytes,
+		Commission:  fp.Commission.String(),
+		Pop:         &proto.ProofOfPossession{BtcSig: fp.Pop.BtcSig},
+		KeyName:     fp.KeyName,
+		ChainId:     fp.ChainID,
+		Status:      proto.FinalityProviderStatus(1000),
+	}
+	legacyBytes, err := pm.Marshal(legacyFp)
+	require.NoError(t, err)
+	legacyBytes = protowire.AppendTag(legacyBytes, 10, protowire.VarintType)
+	legacyBytes = protowire.AppendVarint(legacyBytes, uint64(proto.FinalityProviderStatus_INACTIVE))
+
+	err = kvdb.Update(fpdb, func(tx kvdb.RwTx) error {
+		bucket, err := tx.CreateTopLevelBucket([]byte("finalityProviders"))
+		if err != nil {
+			return err
+		}
+
+		return bucket.Put(legacyFp.BtcPk, legacyBytes)
+	}, func() {})
+	require.NoError(t, err)
+
+	version, err := fpstore.GetDBVersion(fpdb)
+	require.NoError(t, err)
+	require.Zero(t, version)
+
+	backupPath := filepath.Join(homePath, "backup.db")
+	err = fpstore.MigrateDB(fpdb, backupPath, zap.NewNop())
+	require.NoError(t, err)
+	require.FileExists(t, backupPath)
+
+	version, err = fpstore.GetDBVersion(fpdb)
+	require.NoError(t, err)
+	require.Equal(t, fpstore.LatestDBVersion(), version)
+
+	fps, err := fpstore.NewFinalityProviderStore(fpdb)
+	require.NoError(t, err)
+	storedFp, err := fps.GetFinalityProvider(fp.BtcPk)
+	require.NoError(t, err)
+	require.Equal(t, proto.FinalityProviderStatus_INACTIVE, storedFp.Status)
+
+	// migrating again is a no-op
+	err = fpstore.MigrateDB(fpdb, backupPath, zap.NewNop())
+	require.NoError(t, err)
+}
@@ -0,0 +1,224 @@
+package store
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/btcsuite/btcwallet/walletdb"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+var (
+	// mapping: chain_id -> pk -> kind || height -> pending submission
+	outboxBucketName = []byte("outbox")
+)
+
+// SubmissionKind is the kind of a submission recorded in the outbox
+type SubmissionKind byte
+
+const (
+	// SubmissionVote is a finality vote, keyed by the voted height
+	SubmissionVote SubmissionKind = iota + 1
+	// SubmissionPubRandCommit is a public randomness commit, keyed by the
+	// start height of the committed public randomness
+	SubmissionPubRandCommit
+)
+
+func (k SubmissionKind) String() string {
+	switch k {
+	case SubmissionVote:
+		return "vote"
+	case SubmissionPubRandCommit:
+		return "pubrand_commit"
+	default:
+		return fmt.Sprintf("unknown(%d)", byte(k))
+	}
+}
+
+// PendingSubmission is a submission which is recorded before its broadcast
+// and not yet confirmed
+type PendingSubmission struct {
+	Kind SubmissionKind
+	// Height is the voted height or the start height of the committed
+	// public randomness
+	Height uint64
+	// BlockHash is the hash of the voted block, only set for the votes
+	BlockHash []byte
+	// NumPubRand and Commitment describe the committed public randomness,
+	// only set for the public randomness commits
+	NumPubRand uint64
+	Commitment []byte
+}
+
+// OutboxStore records the submissions of the finality providers before they
+// are broadcast until they are confirmed, so that the submissions which
+// might have been sent before a crash can be reconciled against the chain
+type OutboxStore struct {
+	db kvdb.Backend
+}
+
+// NewOutboxStore returns a new store backed by db
+func NewOutboxStore(db kvdb.Backend) (*OutboxStore, error) {
+	store := &OutboxStore{db}
+	if err := store.initBuckets(); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func (s *OutboxStore) initBuckets() error {
+	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		_, err := tx.CreateTopLevelBucket(outboxBucketName)
+		return err
+	})
+}
+
+// createOutboxBucket returns the bucket storing the pending submissions of
+// the given finality provider on the given chain, which is created if not
+// exists
+func createOutboxBucket(tx kvdb.RwTx, chainID, pk []byte) (walletdb.ReadWriteBucket, error) {
+	bucket := tx.ReadWriteBucket(outboxBucketName)
+	if bucket == nil {
+		return nil, ErrCorruptedOutboxDB
+	}
+
+	chainBucket, err := bucket.CreateBucketIfNotExists(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	return chainBucket.CreateBucketIfNotExists(pk)
+}
+
+func outboxKey(kind SubmissionKind, height uint64) []byte {
+	return append([]byte{byte(kind)}, sdk.Uint64ToBigEndian(height)...)
+}
+
+// the value of a vote is the block hash, the value of a public randomness
+// commit is the number of public randomness followed by the commitment
+func encodePendingSubmission(sub *PendingSubmission) []byte {
+	if sub.Kind == SubmissionVote {
+		return sub.BlockHash
+	}
+
+	bz := make([]byte, 8+len(sub.Commitment))
+	binary.BigEndian.PutUint64(bz, sub.NumPubRand)
+	copy(bz[8:], sub.Commitment)
+
+	return bz
+}
+
+func decodePendingSubmission(k, v []byte) (*PendingSubmission, error) {
+	if len(k) != 9 {
+		return nil, ErrCorruptedOutboxDB
+	}
+	sub := &PendingSubmission{
+		Kind:   SubmissionKind(k[0]),
+		Height: sdk.BigEndianToUint64(k[1:]),
+	}
+
+	switch sub.Kind {
+	case SubmissionVote:
+		sub.BlockHash = make([]byte, len(v))
+		copy(sub.BlockHash, v)
+	case SubmissionPubRandCommit:
+		if len(v) < 8 {
+			return nil, ErrCorruptedOutboxDB
+		}
+		sub.NumPubRand = binary.BigEndian.Uint64(v)
+		sub.Commitment = make([]byte, len(v)-8)
+		copy(sub.Commitment, v[8:])
+	default:
+		return nil, ErrCorruptedOutboxDB
+	}
+
+	return sub, nil
+}
+
+// AddPendingSubmissions records the given submissions of the finality
+// provider, a submission of the same kind at the same height is overwritten
+func (s *OutboxStore) AddPendingSubmissions(chainID []byte, pk []byte, subs []*PendingSubmission) error {
+	if len(chainID) == 0 || len(pk) == 0 {
+		return fmt.Errorf("chain id and public key cannot be empty")
+	}
+
+	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		bucket, err := createOutboxBucket(tx, chainID, pk)
+		if err != nil {
+			return err
+		}
+
+		for _, sub := range subs {
+			if sub.Kind != SubmissionVote && sub.Kind != SubmissionPubRandCommit {
+				return fmt.Errorf("unknown submission kind %s", sub.Kind)
+			}
+			if err := bucket.Put(outboxKey(sub.Kind, sub.Height), encodePendingSubmission(sub)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// ListPendingSubmissions returns the pending submissions of the finality
+// provider, the votes first, each kind in ascending order of height
+func (s *OutboxStore) ListPendingSubmissions(chainID []byte, pk []byte) ([]*PendingSubmission, error) {
+	var subs []*PendingSubmission
+
+	err := s.db.View(func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(outboxBucketName)
+		if bucket == nil {
+			return ErrCorruptedOutboxDB
+		}
+
+		chainBucket := bucket.NestedReadBucket(chainID)
+		if chainBucket == nil {
+			return nil
+		}
+
+		pkBucket := chainBucket.NestedReadBucket(pk)
+		if pkBucket == nil {
+			return nil
+		}
+
+		return pkBucket.ForEach(func(k, v []byte) error {
+			sub, err := decodePendingSubmission(k, v)
+			if err != nil {
+				return err
+			}
+			subs = append(subs, sub)
+
+			return nil
+		})
+	}, func() {
+		subs = nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return subs, nil
+}
+
+// CompleteSubmissions removes the submissions of the given kind at the given
+// heights, the heights not recorded are ignored
+func (s *OutboxStore) CompleteSubmissions(chainID []byte, pk []byte, kind SubmissionKind, heights []uint64) error {
+	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		bucket, err := createOutboxBucket(tx, chainID, pk)
+		if err != nil {
+			return err
+		}
+
+		for _, height := range heights {
+			if err := bucket.Delete(outboxKey(kind, height)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,77 @@
+package store_test
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	fpstore "github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+)
+
+// TestOutboxStore tests that the pending submissions are recorded by kind
+// and height until completed
+func TestOutboxStore(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	cfg := config.DefaultDBConfigWithHomePath(t.TempDir())
+	db, err := cfg.GetDBBackend()
+	require.NoError(t, err)
+	defer func() {
+		err := db.Close()
+		require.NoError(t, err)
+	}()
+
+	outbox, err := fpstore.NewOutboxStore(db)
+	require.NoError(t, err)
+
+	fp := testutil.GenRandomFinalityProvider(r, t)
+	pk := fp.GetBIP340BTCPK().MustMarshal()
+	chainID := []byte("chain-test")
+
+	subs, err := outbox.ListPendingSubmissions(chainID, pk)
+	require.NoError(t, err)
+	require.Empty(t, subs)
+
+	height := uint64(r.Int63n(1000) + 1)
+	votes := []*fpstore.PendingSubmission{
+		{Kind: fpstore.SubmissionVote, Height: height + 1, BlockHash: testutil.GenRandomByteArray(r, 32)},
+		{Kind: fpstore.SubmissionVote, Height: height, BlockHash: testutil.GenRandomByteArray(r, 32)},
+	}
+	commit := &fpstore.PendingSubmission{
+		Kind:       fpstore.SubmissionPubRandCommit,
+		Height:     height,
+		NumPubRand: 100,
+		Commitment: testutil.GenRandomByteArray(r, 32),
+	}
+	err = outbox.AddPendingSubmissions(chainID, pk, append(votes, commit))
+	require.NoError(t, err)
+
+	subs, err = outbox.ListPendingSubmissions(chainID, pk)
+	require.NoError(t, err)
+	require.Equal(t, []*fpstore.PendingSubmission{votes[1], votes[0], commit}, subs)
+
+	// the submissions are namespaced by chain id
+	subs, err = outbox.ListPendingSubmissions([]byte("chain-other"), pk)
+	require.NoError(t, err)
+	require.Empty(t, subs)
+
+	// the completion of a vote leaves the commit at the same height
+	err = outbox.CompleteSubmissions(chainID, pk, fpstore.SubmissionVote, []uint64{height})
+	require.NoError(t, err)
+	subs, err = outbox.ListPendingSubmissions(chainID, pk)
+	require.NoError(t, err)
+	require.Equal(t, []*fpstore.PendingSubmission{votes[0], commit}, subs)
+
+	err = outbox.CompleteSubmissions(chainID, pk, fpstore.SubmissionPubRandCommit, []uint64{height})
+	require.NoError(t, err)
+	err = outbox.CompleteSubmissions(chainID, pk, fpstore.SubmissionVote, []uint64{height + 1})
+	require.NoError(t, err)
+	subs, err = outbox.ListPendingSubmissions(chainID, pk)
+	require.NoError(t, err)
+	require.Empty(t, subs)
+}
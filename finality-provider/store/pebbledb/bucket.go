@@ -0,0 +1,319 @@
+package pebbledb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcwallet/walletdb"
+)
+
+// bucket implements both the read-only and the read-write buckets
+type bucket struct {
+	tx *transaction
+	id uint64
+}
+
+// entry is the decoded value of a bucket entry
+type entry struct {
+	value    []byte
+	isBucket bool
+	bucketID uint64
+}
+
+func decodeEntry(v []byte) (*entry, error) {
+	if len(v) == 0 {
+		return nil, fmt.Errorf("empty entry")
+	}
+
+	switch v[0] {
+	case valueTag:
+		return &entry{value: v[1:]}, nil
+	case bucketTag:
+		if len(v) != 9 {
+			return nil, fmt.Errorf("invalid nested bucket entry")
+		}
+		return &entry{isBucket: true, bucketID: binary.BigEndian.Uint64(v[1:])}, nil
+	default:
+		return nil, fmt.Errorf("unknown entry tag %d", v[0])
+	}
+}
+
+// getEntry returns the entry of the key or nil if it does not exist
+func (b *bucket) getEntry(key []byte) (*entry, error) {
+	v, err := b.tx.get(dataKey(b.id, key))
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	return decodeEntry(v)
+}
+
+// nestedBucket returns the nested bucket of the key or nil if it
+// does not exist
+func (b *bucket) nestedBucket(key []byte) *bucket {
+	if len(key) == 0 {
+		return nil
+	}
+
+	e, err := b.getEntry(key)
+	if err != nil {
+		panic(err)
+	}
+	if e == nil || !e.isBucket {
+		return nil
+	}
+
+	return &bucket{tx: b.tx, id: e.bucketID}
+}
+
+// NestedReadBucket returns the nested bucket of the key or nil if it
+// does not exist
+func (b *bucket) NestedReadBucket(key []byte) walletdb.ReadBucket {
+	nested := b.nestedBucket(key)
+	if nested == nil {
+		return nil
+	}
+
+	return nested
+}
+
+// ForEach calls f with every key/value pair of the bucket. The value is
+// nil for the nested buckets
+func (b *bucket) ForEach(f func(k, v []byte) error) error {
+	c := b.ReadCursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		if err := f(k, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Get returns the value of the key or nil if it does not exist or
+// is a nested bucket
+func (b *bucket) Get(key []byte) []byte {
+	if len(key) == 0 {
+		return nil
+	}
+
+	e, err := b.getEntry(key)
+	if err != nil {
+		panic(err)
+	}
+	if e == nil || e.isBucket {
+		return nil
+	}
+
+	return e.value
+}
+
+// ReadCursor returns a cursor over the entries of the bucket
+func (b *bucket) ReadCursor() walletdb.ReadCursor {
+	return newCursor(b)
+}
+
+// NestedReadWriteBucket returns the nested bucket of the key or nil if
+// it does not exist
+func (b *bucket) NestedReadWriteBucket(key []byte) walletdb.ReadWriteBucket {
+	nested := b.nestedBucket(key)
+	if nested == nil {
+		return nil
+	}
+
+	return nested
+}
+
+// CreateBucket creates the nested bucket of the key
+func (b *bucket) CreateBucket(key []byte) (walletdb.ReadWriteBucket, error) {
+	if err := b.tx.writable(); err != nil {
+		return nil, err
+	}
+	if len(key) == 0 {
+		return nil, walletdb.ErrBucketNameRequired
+	}
+
+	e, err := b.getEntry(key)
+	if err != nil {
+		return nil, err
+	}
+	if e != nil {
+		if e.isBucket {
+			return nil, walletdb.ErrBucketExists
+		}
+		return nil, walletdb.ErrIncompatibleValue
+	}
+
+	id, err := b.tx.getUint64([]byte{nextBucketIDKey})
+	if err != nil {
+		return nil, err
+	}
+	// the id 0 is the root bucket
+	if id == rootBucketID {
+		id++
+	}
+	if err := b.tx.setUint64([]byte{nextBucketIDKey}, id+1); err != nil {
+		return nil, err
+	}
+
+	v := binary.BigEndian.AppendUint64([]byte{bucketTag}, id)
+	if err := b.tx.batch.Set(dataKey(b.id, key), v, nil); err != nil {
+		return nil, err
+	}
+
+	return &bucket{tx: b.tx, id: id}, nil
+}
+
+// CreateBucketIfNotExists creates the nested bucket of the key if it
+// does not exist and returns it
+func (b *bucket) CreateBucketIfNotExists(key []byte) (walletdb.ReadWriteBucket, error) {
+	if err := b.tx.writable(); err != nil {
+		return nil, err
+	}
+
+	nested, err := b.CreateBucket(key)
+	if errors.Is(err, walletdb.ErrBucketExists) {
+		return b.nestedBucket(key), nil
+	}
+
+	return nested, err
+}
+
+// DeleteNestedBucket deletes the nested bucket of the key and all of
+// its content
+func (b *bucket) DeleteNestedBucket(key []byte) error {
+	if err := b.tx.writable(); err != nil {
+		return err
+	}
+	if len(key) == 0 {
+		return walletdb.ErrBucketNameRequired
+	}
+
+	e, err := b.getEntry(key)
+	if err != nil {
+		return err
+	}
+	if e == nil {
+		return walletdb.ErrBucketNotFound
+	}
+	if !e.isBucket {
+		return walletdb.ErrIncompatibleValue
+	}
+
+	if err := b.tx.deleteBucketContent(e.bucketID); err != nil {
+		return err
+	}
+
+	return b.tx.batch.Delete(dataKey(b.id, key), nil)
+}
+
+func (tx *transaction) deleteBucketContent(id uint64) error {
+	// collect the nested buckets first as the content is deleted
+	// in a single range deletion afterwards
+	var nestedIDs []uint64
+	c := newCursor(&bucket{tx: tx, id: id})
+	for k, _ := c.First(); k != nil; k, _ = c.Next() {
+		if c.currentEntry.isBucket {
+			nestedIDs = append(nestedIDs, c.currentEntry.bucketID)
+		}
+	}
+
+	for _, nestedID := range nestedIDs {
+		if err := tx.deleteBucketContent(nestedID); err != nil {
+			return err
+		}
+	}
+
+	lower, upper := bucketBounds(id)
+	if err := tx.batch.DeleteRange(lower, upper, nil); err != nil {
+		return err
+	}
+
+	return tx.batch.Delete(sequenceKey(id), nil)
+}
+
+// Put sets the value of the key
+func (b *bucket) Put(key, value []byte) error {
+	if err := b.tx.writable(); err != nil {
+		return err
+	}
+	if len(key) == 0 {
+		return walletdb.ErrKeyRequired
+	}
+
+	e, err := b.getEntry(key)
+	if err != nil {
+		return err
+	}
+	if e != nil && e.isBucket {
+		return walletdb.ErrIncompatibleValue
+	}
+
+	return b.tx.batch.Set(dataKey(b.id, key), append([]byte{valueTag}, value...), nil)
+}
+
+// Delete deletes the key. Deleting a key that does not exist is not
+// an error
+func (b *bucket) Delete(key []byte) error {
+	if err := b.tx.writable(); err != nil {
+		return err
+	}
+
+	e, err := b.getEntry(key)
+	if err != nil {
+		return err
+	}
+	if e == nil {
+		return nil
+	}
+	if e.isBucket {
+		return walletdb.ErrIncompatibleValue
+	}
+
+	return b.tx.batch.Delete(dataKey(b.id, key), nil)
+}
+
+// ReadWriteCursor returns a cursor over the entries of the bucket
+func (b *bucket) ReadWriteCursor() walletdb.ReadWriteCursor {
+	return newCursor(b)
+}
+
+// Tx returns the transaction of the bucket
+func (b *bucket) Tx() walletdb.ReadWriteTx {
+	return b.tx
+}
+
+// NextSequence increments the sequence of the bucket and returns it
+func (b *bucket) NextSequence() (uint64, error) {
+	if err := b.tx.writable(); err != nil {
+		return 0, err
+	}
+
+	seq, err := b.tx.getUint64(sequenceKey(b.id))
+	if err != nil {
+		return 0, err
+	}
+	seq++
+
+	return seq, b.tx.setUint64(sequenceKey(b.id), seq)
+}
+
+// SetSequence sets the sequence of the bucket
+func (b *bucket) SetSequence(v uint64) error {
+	if err := b.tx.writable(); err != nil {
+		return err
+	}
+
+	return b.tx.setUint64(sequenceKey(b.id), v)
+}
+
+// Sequence returns the sequence of the bucket
+func (b *bucket) Sequence() uint64 {
+	seq, err := b.tx.getUint64(sequenceKey(b.id))
+	if err != nil {
+		panic(err)
+	}
+
+	return seq
+}
@@ -0,0 +1,103 @@
+package pebbledb
+
+import (
+	"github.com/btcsuite/btcwallet/walletdb"
+)
+
+// cursor iterates over the entries of a bucket. As kvdb cursors are never
+// closed explicitly, the cursor does not hold a pebble iterator but opens
+// a short-lived one on every move, starting from the current key. This
+// also lets the cursor of a read-write transaction see its own writes
+type cursor struct {
+	bucket       *bucket
+	lower        []byte
+	upper        []byte
+	currentKey   []byte
+	currentEntry *entry
+}
+
+func newCursor(b *bucket) *cursor {
+	lower, upper := bucketBounds(b.id)
+
+	return &cursor{
+		bucket: b,
+		lower:  lower,
+		upper:  upper,
+	}
+}
+
+func (c *cursor) moveTo(k, v []byte, err error) ([]byte, []byte) {
+	if err != nil {
+		panic(err)
+	}
+	if k == nil {
+		c.currentKey, c.currentEntry = nil, nil
+		return nil, nil
+	}
+
+	e, err := decodeEntry(v)
+	if err != nil {
+		panic(err)
+	}
+	c.currentKey, c.currentEntry = k, e
+
+	// the user key follows the data prefix and the bucket id
+	key := k[len(c.lower):]
+	if e.isBucket {
+		return key, nil
+	}
+
+	return key, e.value
+}
+
+// First moves to the first entry of the bucket
+func (c *cursor) First() ([]byte, []byte) {
+	return c.moveTo(c.bucket.tx.seekGE(c.lower, c.upper, c.lower))
+}
+
+// Last moves to the last entry of the bucket
+func (c *cursor) Last() ([]byte, []byte) {
+	return c.moveTo(c.bucket.tx.seekLT(c.lower, c.upper, c.upper))
+}
+
+// Next moves to the entry following the current one
+func (c *cursor) Next() ([]byte, []byte) {
+	if c.currentKey == nil {
+		return nil, nil
+	}
+
+	// the smallest key greater than the current one
+	next := append(append([]byte{}, c.currentKey...), 0x00)
+
+	return c.moveTo(c.bucket.tx.seekGE(c.lower, c.upper, next))
+}
+
+// Prev moves to the entry preceding the current one
+func (c *cursor) Prev() ([]byte, []byte) {
+	if c.currentKey == nil {
+		return nil, nil
+	}
+
+	return c.moveTo(c.bucket.tx.seekLT(c.lower, c.upper, c.currentKey))
+}
+
+// Seek moves to the first entry whose key is not lower than the given one
+func (c *cursor) Seek(seek []byte) ([]byte, []byte) {
+	return c.moveTo(c.bucket.tx.seekGE(c.lower, c.upper, dataKey(c.bucket.id, seek)))
+}
+
+// Delete deletes the current entry. The nested buckets can only be deleted
+// through DeleteNestedBucket
+func (c *cursor) Delete() error {
+	if err := c.bucket.tx.writable(); err != nil {
+		return err
+	}
+	if c.currentKey == nil {
+		return nil
+	}
+	if c.currentEntry.isBucket {
+		return walletdb.ErrIncompatibleValue
+	}
+
+	return c.bucket.tx.batch.Delete(c.currentKey, nil)
+}
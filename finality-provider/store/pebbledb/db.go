@@ -0,0 +1,186 @@
+// Package pebbledb implements the kvdb backend interface on top of pebble.
+//
+// The nested buckets of kvdb are flattened into the pebble key space:
+// every bucket is assigned a unique id and each of its entries is stored
+// under (dataPrefix || bucket id || key). The value of an entry is tagged
+// to tell plain values from nested buckets, in which case it holds the id
+// of the nested bucket. The root bucket has the id 0.
+//
+// Writers are serialized like in bbolt so that the read-modify-write
+// patterns of the stores remain safe, but committing a write transaction
+// only appends the batch to the pebble WAL instead of rewriting the
+// modified B+tree pages, which is what makes the backend scale with the
+// number of finality providers and chains. Readers work on snapshots and
+// never block writers.
+package pebbledb
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/vfs"
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+const (
+	// dataPrefix prefixes the entries of the buckets
+	dataPrefix byte = 0x00
+	// sequencePrefix prefixes the sequences of the buckets
+	sequencePrefix byte = 0x01
+	// nextBucketIDKey stores the id to assign to the next created bucket
+	nextBucketIDKey byte = 0x02
+
+	// valueTag tags the entries holding plain values
+	valueTag byte = 0x00
+	// bucketTag tags the entries holding nested buckets
+	bucketTag byte = 0x01
+
+	rootBucketID uint64 = 0
+)
+
+var _ kvdb.Backend = (*DB)(nil)
+
+// DB is a kvdb backend storing the data in pebble
+type DB struct {
+	db *pebble.DB
+	// writeMtx serializes the read-write transactions
+	writeMtx sync.Mutex
+}
+
+// Open opens or creates the pebble database in the given directory
+func Open(dir string) (*DB, error) {
+	db, err := pebble.Open(dir, &pebble.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open pebble db at %s: %w", dir, err)
+	}
+
+	return &DB{db: db}, nil
+}
+
+// OpenInMemory creates a database that is kept in memory only and is
+// discarded once closed
+func OpenInMemory() (*DB, error) {
+	db, err := pebble.Open("", &pebble.Options{FS: vfs.NewMem()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open in-memory pebble db: %w", err)
+	}
+
+	return &DB{db: db}, nil
+}
+
+// BeginReadTx opens a read-only transaction on a snapshot of the database
+func (db *DB) BeginReadTx() (walletdb.ReadTx, error) {
+	return newReadTx(db.db.NewSnapshot()), nil
+}
+
+// BeginReadWriteTx opens a read-write transaction. It blocks until the
+// other read-write transactions are committed or rolled back
+func (db *DB) BeginReadWriteTx() (walletdb.ReadWriteTx, error) {
+	db.writeMtx.Lock()
+
+	return newReadWriteTx(db.db.NewIndexedBatch(), db.writeMtx.Unlock), nil
+}
+
+// Copy writes a consistent dump of the database to w as a sequence of
+// (key length || key || value length || value), all lengths being
+// big endian uint32
+func (db *DB) Copy(w io.Writer) error {
+	snapshot := db.db.NewSnapshot()
+	defer snapshot.Close()
+
+	iter, err := snapshot.NewIter(&pebble.IterOptions{})
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+
+	for iter.First(); iter.Valid(); iter.Next() {
+		for _, bz := range [][]byte{iter.Key(), iter.Value()} {
+			// #nosec G115 -- kvdb keys and values are far smaller than 4GB
+			if err := binary.Write(w, binary.BigEndian, uint32(len(bz))); err != nil {
+				return err
+			}
+			if _, err := w.Write(bz); err != nil {
+				return err
+			}
+		}
+	}
+
+	return iter.Error()
+}
+
+// Compact compacts the whole key space, so that the space of the deleted
+// entries is reclaimed
+func (db *DB) Compact() error {
+	return db.db.Compact([]byte{dataPrefix}, []byte{nextBucketIDKey + 1}, true)
+}
+
+// Close flushes and closes the database
+func (db *DB) Close() error {
+	return db.db.Close()
+}
+
+// PrintStats returns the pebble metrics
+func (db *DB) PrintStats() string {
+	return db.db.Metrics().String()
+}
+
+// View opens a read-only transaction and executes f within it
+func (db *DB) View(f func(tx walletdb.ReadTx) error, reset func()) error {
+	reset()
+
+	tx, err := db.BeginReadTx()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	return f(tx)
+}
+
+// Update opens a read-write transaction and executes f within it. The
+// transaction is committed if f succeeds and rolled back otherwise
+func (db *DB) Update(f func(tx walletdb.ReadWriteTx) error, reset func()) error {
+	reset()
+
+	tx, err := db.BeginReadWriteTx()
+	if err != nil {
+		return err
+	}
+
+	if err := f(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func dataKey(bucketID uint64, key []byte) []byte {
+	k := make([]byte, 0, 9+len(key))
+	k = append(k, dataPrefix)
+	k = binary.BigEndian.AppendUint64(k, bucketID)
+
+	return append(k, key...)
+}
+
+// bucketBounds returns the key range of the entries of the bucket
+func bucketBounds(bucketID uint64) ([]byte, []byte) {
+	lower := dataKey(bucketID, nil)
+	upper := dataKey(bucketID+1, nil)
+
+	return lower, upper
+}
+
+func sequenceKey(bucketID uint64) []byte {
+	k := make([]byte, 0, 9)
+	k = append(k, sequencePrefix)
+
+	return binary.BigEndian.AppendUint64(k, bucketID)
+}
@@ -0,0 +1,127 @@
+package pebbledb_test
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store/pebbledb"
+)
+
+func TestPebbleBackend(t *testing.T) {
+	t.Parallel()
+
+	db, err := pebbledb.Open(t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+
+	testBackend(t, db)
+}
+
+func TestInMemoryBackend(t *testing.T) {
+	t.Parallel()
+
+	db, err := pebbledb.OpenInMemory()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+
+	testBackend(t, db)
+}
+
+func testBackend(t *testing.T, db kvdb.Backend) {
+	err := kvdb.Update(db, func(tx kvdb.RwTx) error {
+		top, err := tx.CreateTopLevelBucket([]byte("top"))
+		if err != nil {
+			return err
+		}
+		nested, err := top.CreateBucketIfNotExists([]byte("nested"))
+		if err != nil {
+			return err
+		}
+		for _, k := range []string{"c", "a", "b"} {
+			if err := nested.Put([]byte(k), []byte("v"+k)); err != nil {
+				return err
+			}
+		}
+		if err := top.Put([]byte("empty"), []byte{}); err != nil {
+			return err
+		}
+		_, err = top.NextSequence()
+
+		return err
+	}, func() {})
+	require.NoError(t, err)
+
+	err = db.View(func(tx kvdb.RTx) error {
+		top := tx.ReadBucket([]byte("top"))
+		require.NotNil(t, top)
+		require.Nil(t, tx.ReadBucket([]byte("unknown")))
+
+		// empty values are not nil and nested buckets have nil values
+		require.NotNil(t, top.Get([]byte("empty")))
+		require.Nil(t, top.Get([]byte("nested")))
+
+		nested := top.NestedReadBucket([]byte("nested"))
+		require.NotNil(t, nested)
+
+		var keys []string
+		c := nested.ReadCursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			require.Equal(t, "v"+string(k), string(v))
+			keys = append(keys, string(k))
+		}
+		require.Equal(t, []string{"a", "b", "c"}, keys)
+
+		k, _ := c.Seek([]byte("b"))
+		require.Equal(t, []byte("b"), k)
+		k, _ = c.Prev()
+		require.Equal(t, []byte("a"), k)
+		k, _ = c.Last()
+		require.Equal(t, []byte("c"), k)
+		k, _ = c.Seek([]byte("d"))
+		require.Nil(t, k)
+
+		return nil
+	}, func() {})
+	require.NoError(t, err)
+
+	// failed updates are rolled back
+	err = kvdb.Update(db, func(tx kvdb.RwTx) error {
+		if err := tx.ReadWriteBucket([]byte("top")).Put([]byte("rolled-back"), []byte("v")); err != nil {
+			return err
+		}
+
+		return walletdb.ErrDryRunRollBack
+	}, func() {})
+	require.ErrorIs(t, err, walletdb.ErrDryRunRollBack)
+
+	err = kvdb.Update(db, func(tx kvdb.RwTx) error {
+		top := tx.ReadWriteBucket([]byte("top"))
+		require.Nil(t, top.Get([]byte("rolled-back")))
+		require.Equal(t, uint64(1), top.Sequence())
+		require.ErrorIs(t, top.Put([]byte("nested"), []byte("v")), walletdb.ErrIncompatibleValue)
+
+		return tx.DeleteTopLevelBucket([]byte("top"))
+	}, func() {})
+	require.NoError(t, err)
+
+	// the content of the deleted buckets is gone with them
+	err = kvdb.Update(db, func(tx kvdb.RwTx) error {
+		top, err := tx.CreateTopLevelBucket([]byte("top"))
+		if err != nil {
+			return err
+		}
+		k, _ := top.ReadCursor().First()
+		require.Nil(t, k)
+		require.Equal(t, uint64(0), top.Sequence())
+
+		return nil
+	}, func() {})
+	require.NoError(t, err)
+}
@@ -0,0 +1,205 @@
+package pebbledb
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/cockroachdb/pebble"
+)
+
+// reader is implemented by both the snapshots of the read-only
+// transactions and the indexed batches of the read-write transactions
+type reader interface {
+	Get(key []byte) ([]byte, io.Closer, error)
+	NewIter(o *pebble.IterOptions) (*pebble.Iterator, error)
+}
+
+// transaction implements both the read-only and the read-write
+// transactions. The batch is nil for read-only transactions
+type transaction struct {
+	r        reader
+	batch    *pebble.Batch
+	release  func() error
+	onCommit []func()
+	closed   bool
+}
+
+func newReadTx(snapshot *pebble.Snapshot) *transaction {
+	return &transaction{
+		r:       snapshot,
+		release: snapshot.Close,
+	}
+}
+
+func newReadWriteTx(batch *pebble.Batch, unlock func()) *transaction {
+	return &transaction{
+		r:     batch,
+		batch: batch,
+		release: func() error {
+			defer unlock()
+			return batch.Close()
+		},
+	}
+}
+
+func (tx *transaction) rootBucket() *bucket {
+	return &bucket{tx: tx, id: rootBucketID}
+}
+
+// ReadBucket returns the top level bucket with the given key or nil if
+// it does not exist
+func (tx *transaction) ReadBucket(key []byte) walletdb.ReadBucket {
+	b := tx.rootBucket().nestedBucket(key)
+	if b == nil {
+		return nil
+	}
+
+	return b
+}
+
+// ForEachBucket calls f with the key of every top level bucket
+func (tx *transaction) ForEachBucket(f func(key []byte) error) error {
+	return tx.rootBucket().ForEach(func(k, v []byte) error {
+		if v != nil {
+			return nil
+		}
+
+		return f(k)
+	})
+}
+
+// Rollback discards the transaction
+func (tx *transaction) Rollback() error {
+	if tx.closed {
+		return walletdb.ErrTxClosed
+	}
+	tx.closed = true
+
+	return tx.release()
+}
+
+// ReadWriteBucket returns the top level bucket with the given key or nil
+// if it does not exist
+func (tx *transaction) ReadWriteBucket(key []byte) walletdb.ReadWriteBucket {
+	b := tx.rootBucket().nestedBucket(key)
+	if b == nil {
+		return nil
+	}
+
+	return b
+}
+
+// CreateTopLevelBucket creates the top level bucket with the given key
+// if it does not exist and returns it
+func (tx *transaction) CreateTopLevelBucket(key []byte) (walletdb.ReadWriteBucket, error) {
+	return tx.rootBucket().CreateBucketIfNotExists(key)
+}
+
+// DeleteTopLevelBucket deletes the top level bucket with the given key
+// and all of its content
+func (tx *transaction) DeleteTopLevelBucket(key []byte) error {
+	return tx.rootBucket().DeleteNestedBucket(key)
+}
+
+// Commit writes the batch of the transaction to the database
+func (tx *transaction) Commit() error {
+	if tx.closed {
+		return walletdb.ErrTxClosed
+	}
+	if tx.batch == nil {
+		return walletdb.ErrTxNotWritable
+	}
+	tx.closed = true
+
+	if err := tx.batch.Commit(pebble.Sync); err != nil {
+		_ = tx.release()
+		return err
+	}
+	if err := tx.release(); err != nil {
+		return err
+	}
+
+	for _, f := range tx.onCommit {
+		f()
+	}
+
+	return nil
+}
+
+// OnCommit registers f to be called once the transaction is committed
+func (tx *transaction) OnCommit(f func()) {
+	tx.onCommit = append(tx.onCommit, f)
+}
+
+func (tx *transaction) writable() error {
+	if tx.closed {
+		return walletdb.ErrTxClosed
+	}
+	if tx.batch == nil {
+		return walletdb.ErrTxNotWritable
+	}
+
+	return nil
+}
+
+// get returns a copy of the value of the key or nil if it does not exist
+func (tx *transaction) get(key []byte) ([]byte, error) {
+	v, closer, err := tx.r.Get(key)
+	if errors.Is(err, pebble.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+
+	return append([]byte{}, v...), nil
+}
+
+func (tx *transaction) getUint64(key []byte) (uint64, error) {
+	v, err := tx.get(key)
+	if err != nil || v == nil {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint64(v), nil
+}
+
+func (tx *transaction) setUint64(key []byte, v uint64) error {
+	return tx.batch.Set(key, binary.BigEndian.AppendUint64(nil, v), nil)
+}
+
+// seekGE returns a copy of the first entry not lower than key
+// within [lower, upper)
+func (tx *transaction) seekGE(lower, upper, key []byte) ([]byte, []byte, error) {
+	return tx.seek(lower, upper, func(iter *pebble.Iterator) bool {
+		return iter.SeekGE(key)
+	})
+}
+
+// seekLT returns a copy of the last entry lower than key
+// within [lower, upper)
+func (tx *transaction) seekLT(lower, upper, key []byte) ([]byte, []byte, error) {
+	return tx.seek(lower, upper, func(iter *pebble.Iterator) bool {
+		return iter.SeekLT(key)
+	})
+}
+
+func (tx *transaction) seek(lower, upper []byte, seek func(iter *pebble.Iterator) bool) ([]byte, []byte, error) {
+	iter, err := tx.r.NewIter(&pebble.IterOptions{
+		LowerBound: lower,
+		UpperBound: upper,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	defer iter.Close()
+
+	if !seek(iter) {
+		return nil, nil, iter.Error()
+	}
+
+	return append([]byte{}, iter.Key()...), append([]byte{}, iter.Value()...), nil
+}
@@ -0,0 +1,80 @@
+package store
+
+import (
+	"bytes"
+
+	"github.com/btcsuite/btcwallet/walletdb"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+// pruneBatchSize bounds the number of keys deleted within a db transaction
+const pruneBatchSize = 10000
+
+// getPkBucket returns the bucket of the given finality provider on the given
+// chain nested in the given top level bucket, nil if it does not exist
+func getPkBucket(tx kvdb.RwTx, topLevelBucket, chainID, pk []byte) walletdb.ReadWriteBucket {
+	bucket := tx.ReadWriteBucket(topLevelBucket)
+	if bucket == nil {
+		return nil
+	}
+	chainBucket := bucket.NestedReadWriteBucket(chainID)
+	if chainBucket == nil {
+		return nil
+	}
+
+	return chainBucket.NestedReadWriteBucket(pk)
+}
+
+// pruneRange deletes the keys of the bucket of the given finality provider
+// within [from, to) by batches, and returns the number of deleted keys
+func pruneRange(db kvdb.Backend, topLevelBucket, chainID, pk, from, to []byte) (int, error) {
+	var numPruned int
+	for {
+		var numDeleted int
+		err := kvdb.Update(db, func(tx kvdb.RwTx) error {
+			bucket := getPkBucket(tx, topLevelBucket, chainID, pk)
+			if bucket == nil {
+				return nil
+			}
+
+			var keys [][]byte
+			c := bucket.ReadCursor()
+			for k, _ := c.Seek(from); k != nil && bytes.Compare(k, to) < 0 && len(keys) < pruneBatchSize; k, _ = c.Next() {
+				keys = append(keys, append([]byte{}, k...))
+			}
+			for _, k := range keys {
+				if err := bucket.Delete(k); err != nil {
+					return err
+				}
+			}
+			numDeleted = len(keys)
+
+			return nil
+		}, func() {
+			numDeleted = 0
+		})
+		if err != nil {
+			return numPruned, err
+		}
+
+		numPruned += numDeleted
+		if numDeleted < pruneBatchSize {
+			return numPruned, nil
+		}
+	}
+}
+
+// PrunePubRandProofs deletes the proofs of the finality provider at heights
+// below the given height, and returns the number of deleted proofs. The
+// archived proofs are kept
+func (s *PubRandProofStore) PrunePubRandProofs(chainID, pk []byte, belowHeight uint64) (int, error) {
+	return pruneRange(s.db, pubRandProofBucketName, chainID, pk, sdk.Uint64ToBigEndian(0), sdk.Uint64ToBigEndian(belowHeight))
+}
+
+// PruneVoteRecords deletes the records of the votes of the finality
+// provider at heights below the given height, and returns the number of
+// deleted records. The records of the public randomness commits are kept
+func (s *SubmissionHistoryStore) PruneVoteRecords(chainID, pk []byte, belowHeight uint64) (int, error) {
+	return pruneRange(s.db, historyBucketName, chainID, pk, historyKey(SubmissionVote, 0), historyKey(SubmissionVote, belowHeight))
+}
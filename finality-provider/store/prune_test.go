@@ -0,0 +1,100 @@
+package store_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	fpstore "github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// TestPruneData tests that the proofs and the votes below the given height
+// are pruned and that the db can be compacted afterwards
+func TestPruneData(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	cfg := config.DefaultDBConfigWithHomePath(t.TempDir())
+	db, err := cfg.GetDBBackend()
+	require.NoError(t, err)
+
+	prStore, err := fpstore.NewPubRandProofStore(db)
+	require.NoError(t, err)
+	history, err := fpstore.NewSubmissionHistoryStore(db)
+	require.NoError(t, err)
+
+	chainID := []byte("chain-test")
+	pk := testutil.GenRandomFinalityProvider(r, t).GetBIP340BTCPK().MustMarshal()
+	startHeight, numPubRand := uint64(1), uint64(30)
+	_, proofList := types.GetPubRandCommitAndProofs(genPubRandList(r, t, int(numPubRand)))
+	err = prStore.AddPubRandProofList(chainID, pk, startHeight, numPubRand, proofList)
+	require.NoError(t, err)
+
+	var records []*fpstore.SubmissionRecord
+	for height := startHeight; height < startHeight+numPubRand; height++ {
+		records = append(records, &fpstore.SubmissionRecord{
+			Kind:      fpstore.SubmissionVote,
+			Height:    height,
+			Result:    fpstore.SubmissionResultSubmitted,
+			Timestamp: time.Now(),
+		})
+	}
+	records = append(records, &fpstore.SubmissionRecord{
+		Kind:       fpstore.SubmissionPubRandCommit,
+		Height:     startHeight,
+		NumPubRand: numPubRand,
+		Result:     fpstore.SubmissionResultSubmitted,
+		Timestamp:  time.Now(),
+	})
+	err = history.RecordSubmissions(chainID, pk, records, 0)
+	require.NoError(t, err)
+
+	beforeHeight := uint64(21)
+	numPruned, err := prStore.PrunePubRandProofs(chainID, pk, beforeHeight)
+	require.NoError(t, err)
+	require.Equal(t, 20, numPruned)
+	numPruned, err = history.PruneVoteRecords(chainID, pk, beforeHeight)
+	require.NoError(t, err)
+	require.Equal(t, 20, numPruned)
+
+	// the data at and above the height is kept
+	_, err = prStore.GetPubRandProof(chainID, pk, beforeHeight-1)
+	require.ErrorIs(t, err, fpstore.ErrPubRandProofNotFound)
+	_, err = prStore.GetPubRandProof(chainID, pk, beforeHeight)
+	require.NoError(t, err)
+	votes, err := history.ListSubmissions(chainID, pk, fpstore.SubmissionVote, 0, math.MaxUint64)
+	require.NoError(t, err)
+	require.Len(t, votes, int(startHeight+numPubRand-beforeHeight))
+	require.Equal(t, beforeHeight, votes[0].Height)
+	commits, err := history.ListSubmissions(chainID, pk, fpstore.SubmissionPubRandCommit, 0, math.MaxUint64)
+	require.NoError(t, err)
+	require.Len(t, commits, 1)
+
+	// pruning again or an unknown finality provider is a no-op
+	numPruned, err = prStore.PrunePubRandProofs(chainID, pk, beforeHeight)
+	require.NoError(t, err)
+	require.Zero(t, numPruned)
+	numPruned, err = history.PruneVoteRecords([]byte("chain-other"), pk, beforeHeight)
+	require.NoError(t, err)
+	require.Zero(t, numPruned)
+
+	require.NoError(t, db.Close())
+	require.NoError(t, cfg.CompactDB())
+
+	db, err = cfg.GetDBBackend()
+	require.NoError(t, err)
+	defer func() {
+		err := db.Close()
+		require.NoError(t, err)
+	}()
+	prStore, err = fpstore.NewPubRandProofStore(db)
+	require.NoError(t, err)
+	_, err = prStore.GetPubRandProof(chainID, pk, beforeHeight)
+	require.NoError(t, err)
+}
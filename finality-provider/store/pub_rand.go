@@ -1,16 +1,24 @@
 package store
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcwallet/walletdb"
 	"github.com/cometbft/cometbft/crypto/merkle"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/lightningnetwork/lnd/kvdb"
 )
 
 var (
+	// mapping: chain_id -> pk -> height -> proof
+	pubRandProofBucketName = []byte("pub_rand_proofs")
+
 	// mapping: pub_rand -> proof
-	pubRandProofBucketName = []byte("pub_rand_proof")
+	// legacy layout which is not namespaced by chain id,
+	// kept for migrating existing single-chain data
+	legacyPubRandProofBucketName = []byte("pub_rand_proof")
 )
 
 type PubRandProofStore struct {
@@ -29,25 +37,67 @@ func NewPubRandProofStore(db kvdb.Backend) (*PubRandProofStore, error) {
 
 func (s *PubRandProofStore) initBuckets() error {
 	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
-		_, err := tx.CreateTopLevelBucket(pubRandProofBucketName)
+		if _, err := tx.CreateTopLevelBucket(pubRandProofBucketName); err != nil {
+			return err
+		}
+
+		_, err := tx.CreateTopLevelBucket(pubRandProofArchiveBucketName)
 		return err
 	})
 }
 
+// getProofBucket returns the bucket storing the proofs of the given
+// finality provider on the given chain, nil if it does not exist
+func getProofBucket(tx kvdb.RTx, chainID, pk []byte) (walletdb.ReadBucket, error) {
+	bucket := tx.ReadBucket(pubRandProofBucketName)
+	if bucket == nil {
+		return nil, ErrCorruptedPubRandProofDB
+	}
+
+	chainBucket := bucket.NestedReadBucket(chainID)
+	if chainBucket == nil {
+		return nil, nil
+	}
+
+	return chainBucket.NestedReadBucket(pk), nil
+}
+
+// createProofBucket returns the bucket storing the proofs of the given
+// finality provider on the given chain, which is created if not exists
+func createProofBucket(tx kvdb.RwTx, chainID, pk []byte) (walletdb.ReadWriteBucket, error) {
+	bucket := tx.ReadWriteBucket(pubRandProofBucketName)
+	if bucket == nil {
+		return nil, ErrCorruptedPubRandProofDB
+	}
+
+	chainBucket, err := bucket.CreateBucketIfNotExists(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	return chainBucket.CreateBucketIfNotExists(pk)
+}
+
+// AddPubRandProofList saves the proofs of numPubRand public randomness
+// of the finality provider starting from the given height
 func (s *PubRandProofStore) AddPubRandProofList(
-	pubRandList []*btcec.FieldVal,
+	chainID []byte,
+	pk []byte,
+	height uint64,
+	numPubRand uint64,
 	proofList []*merkle.Proof,
 ) error {
-	if len(pubRandList) != len(proofList) {
+	if len(chainID) == 0 || len(pk) == 0 {
+		return fmt.Errorf("chain id and public key cannot be empty")
+	}
+
+	if uint64(len(proofList)) != numPubRand {
 		return fmt.Errorf("the number of public randomness is not same as the number of proofs")
 	}
 
-	pubRandBytesList := [][]byte{}
-	proofBytesList := [][]byte{}
-	for i := range pubRandList {
-		pubRandBytes := *pubRandList[i].Bytes()
-		pubRandBytesList = append(pubRandBytesList, pubRandBytes[:])
-		proofBytes, err := proofList[i].ToProto().Marshal()
+	proofBytesList := make([][]byte, 0, len(proofList))
+	for _, proof := range proofList {
+		proofBytes, err := proof.ToProto().Marshal()
 		if err != nil {
 			return fmt.Errorf("invalid proof: %w", err)
 		}
@@ -55,18 +105,19 @@ func (s *PubRandProofStore) AddPubRandProofList(
 	}
 
 	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
-		bucket := tx.ReadWriteBucket(pubRandProofBucketName)
-		if bucket == nil {
-			return ErrCorruptedPubRandProofDB
+		bucket, err := createProofBucket(tx, chainID, pk)
+		if err != nil {
+			return err
 		}
 
-		for i := range pubRandBytesList {
+		for i, proofBytes := range proofBytesList {
+			heightKey := sdk.Uint64ToBigEndian(height + uint64(i))
 			// skip if already committed
-			if bucket.Get(pubRandBytesList[i]) != nil {
+			if bucket.Get(heightKey) != nil {
 				continue
 			}
 			// set to DB
-			if err := bucket.Put(pubRandBytesList[i], proofBytesList[i]); err != nil {
+			if err := bucket.Put(heightKey, proofBytes); err != nil {
 				return err
 			}
 		}
@@ -75,17 +126,21 @@ func (s *PubRandProofStore) AddPubRandProofList(
 	})
 }
 
-func (s *PubRandProofStore) GetPubRandProof(pubRand *btcec.FieldVal) ([]byte, error) {
-	pubRandBytes := *pubRand.Bytes()
+// GetPubRandProof returns the proof of the public randomness
+// of the finality provider at the given height
+func (s *PubRandProofStore) GetPubRandProof(chainID []byte, pk []byte, height uint64) ([]byte, error) {
 	var proofBytes []byte
 
 	err := s.db.View(func(tx kvdb.RTx) error {
-		bucket := tx.ReadBucket(pubRandProofBucketName)
+		bucket, err := getProofBucket(tx, chainID, pk)
+		if err != nil {
+			return err
+		}
 		if bucket == nil {
-			return ErrCorruptedPubRandProofDB
+			return ErrPubRandProofNotFound
 		}
 
-		proofBytes = bucket.Get(pubRandBytes[:])
+		proofBytes = bucket.Get(sdk.Uint64ToBigEndian(height))
 		if proofBytes == nil {
 			return ErrPubRandProofNotFound
 		}
@@ -100,31 +155,73 @@ func (s *PubRandProofStore) GetPubRandProof(pubRand *btcec.FieldVal) ([]byte, er
 	return proofBytes, nil
 }
 
-func (s *PubRandProofStore) GetPubRandProofList(pubRandList []*btcec.FieldVal) ([][]byte, error) {
-	pubRandBytesList := [][]byte{}
-	for i := range pubRandList {
-		pubRandBytes := *pubRandList[i].Bytes()
-		pubRandBytesList = append(pubRandBytesList, pubRandBytes[:])
+// GetPubRandProofList returns the proofs of numPubRand public randomness
+// of the finality provider starting from the given height
+func (s *PubRandProofStore) GetPubRandProofList(
+	chainID []byte,
+	pk []byte,
+	height uint64,
+	numPubRand uint64,
+) ([][]byte, error) {
+	heights := make([]uint64, 0, numPubRand)
+	for i := uint64(0); i < numPubRand; i++ {
+		heights = append(heights, height+i)
 	}
 
-	proofBytesList := [][]byte{}
+	return s.GetPubRandProofsByHeights(chainID, pk, heights)
+}
+
+// GetPubRandProofsByHeights returns the proofs of the public randomness of
+// the finality provider at the given heights within one transaction.
+// The heights must be in ascending order so that contiguous heights
+// are read with a forward cursor scan
+func (s *PubRandProofStore) GetPubRandProofsByHeights(
+	chainID []byte,
+	pk []byte,
+	heights []uint64,
+) ([][]byte, error) {
+	for i := 1; i < len(heights); i++ {
+		if heights[i] <= heights[i-1] {
+			return nil, fmt.Errorf("the heights should be in strictly ascending order")
+		}
+	}
+
+	var proofBytesList [][]byte
 
 	err := s.db.View(func(tx kvdb.RTx) error {
-		bucket := tx.ReadBucket(pubRandProofBucketName)
+		if len(heights) == 0 {
+			return nil
+		}
+
+		bucket, err := getProofBucket(tx, chainID, pk)
+		if err != nil {
+			return err
+		}
 		if bucket == nil {
-			return ErrCorruptedPubRandProofDB
+			return ErrPubRandProofNotFound
 		}
 
-		for i := range pubRandBytesList {
-			proofBytes := bucket.Get(pubRandBytesList[i])
-			if proofBytes == nil {
-				return ErrPubRandProofNotFound
+		var k, v []byte
+		c := bucket.ReadCursor()
+		for i, height := range heights {
+			heightKey := sdk.Uint64ToBigEndian(height)
+			// the keys are ordered by big endian encoded heights so
+			// contiguous heights are read by moving the cursor forward
+			if i > 0 && height == heights[i-1]+1 {
+				k, v = c.Next()
+			} else {
+				k, v = c.Seek(heightKey)
+			}
+			if k == nil || !bytes.Equal(k, heightKey) {
+				return fmt.Errorf("%w: height %d", ErrPubRandProofNotFound, height)
 			}
-			proofBytesList = append(proofBytesList, proofBytes)
+			proofBytesList = append(proofBytesList, v)
 		}
 
 		return nil
-	}, func() {})
+	}, func() {
+		proofBytesList = nil
+	})
 
 	if err != nil {
 		return nil, err
@@ -133,4 +230,136 @@ func (s *PubRandProofStore) GetPubRandProofList(pubRandList []*btcec.FieldVal) (
 	return proofBytesList, nil
 }
 
-// TODO: delete function?
+// PubRandProofRecord is the proof of the public randomness at a height
+type PubRandProofRecord struct {
+	Height uint64
+	Proof  []byte
+}
+
+// ListPubRandProofs returns up to limit proofs of the public randomness of
+// the finality provider at the heights between fromHeight and toHeight
+// inclusive, in ascending order of heights. The proofs are read within one
+// transaction per call, so that a long range is read in pages by calling it
+// again from the height following the last returned one
+func (s *PubRandProofStore) ListPubRandProofs(
+	chainID []byte,
+	pk []byte,
+	fromHeight uint64,
+	toHeight uint64,
+	limit uint64,
+) ([]*PubRandProofRecord, error) {
+	var proofs []*PubRandProofRecord
+
+	err := s.db.View(func(tx kvdb.RTx) error {
+		bucket, err := getProofBucket(tx, chainID, pk)
+		if err != nil {
+			return err
+		}
+		if bucket == nil {
+			return nil
+		}
+
+		c := bucket.ReadCursor()
+		for k, v := c.Seek(sdk.Uint64ToBigEndian(fromHeight)); k != nil && uint64(len(proofs)) < limit; k, v = c.Next() {
+			height := sdk.BigEndianToUint64(k)
+			if height > toHeight {
+				break
+			}
+			proofs = append(proofs, &PubRandProofRecord{
+				Height: height,
+				Proof:  append([]byte{}, v...),
+			})
+		}
+
+		return nil
+	}, func() {
+		proofs = nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return proofs, nil
+}
+
+// HasLegacyPubRandProofs returns true if there are proofs stored with
+// the legacy layout which is keyed by public randomness only
+func (s *PubRandProofStore) HasLegacyPubRandProofs() (bool, error) {
+	var hasLegacy bool
+
+	err := s.db.View(func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(legacyPubRandProofBucketName)
+		if bucket == nil {
+			return nil
+		}
+
+		k, _ := bucket.ReadCursor().First()
+		hasLegacy = k != nil
+
+		return nil
+	}, func() {})
+
+	if err != nil {
+		return false, err
+	}
+
+	return hasLegacy, nil
+}
+
+// MigrateLegacyPubRandProofs moves the proofs of the given public randomness
+// list from the legacy layout to the one namespaced by chain id, assuming
+// pubRandList[i] is the public randomness at height startHeight+i.
+// It returns the number of migrated proofs
+func (s *PubRandProofStore) MigrateLegacyPubRandProofs(
+	chainID []byte,
+	pk []byte,
+	startHeight uint64,
+	pubRandList []*btcec.FieldVal,
+) (int, error) {
+	var numMigrated int
+
+	err := kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		numMigrated = 0
+
+		legacyBucket := tx.ReadWriteBucket(legacyPubRandProofBucketName)
+		if legacyBucket == nil {
+			return nil
+		}
+
+		bucket, err := createProofBucket(tx, chainID, pk)
+		if err != nil {
+			return err
+		}
+
+		for i, pubRand := range pubRandList {
+			pubRandBytes := *pubRand.Bytes()
+			legacyProofBytes := legacyBucket.Get(pubRandBytes[:])
+			if legacyProofBytes == nil {
+				continue
+			}
+			// copy as the value is invalidated upon modifying the bucket
+			proofBytes := append([]byte{}, legacyProofBytes...)
+
+			heightKey := sdk.Uint64ToBigEndian(startHeight + uint64(i))
+			if bucket.Get(heightKey) == nil {
+				if err := bucket.Put(heightKey, proofBytes); err != nil {
+					return err
+				}
+			}
+
+			if err := legacyBucket.Delete(pubRandBytes[:]); err != nil {
+				return err
+			}
+			numMigrated++
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return numMigrated, nil
+}
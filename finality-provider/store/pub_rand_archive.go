@@ -0,0 +1,243 @@
+package store
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"path"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+var (
+	// mapping: chain_id -> pk -> last archived height -> (first archived height || object name)
+	pubRandProofArchiveBucketName = []byte("pub_rand_proof_archives")
+)
+
+// maxProofsPerArchivedObject bounds the size of each archived object
+// so that fetching back a single proof stays cheap
+const maxProofsPerArchivedObject = 10000
+
+// PubRandProofArchive is the cold storage that the proofs of
+// long-finalized heights are moved to
+type PubRandProofArchive interface {
+	Put(ctx context.Context, name string, data []byte) error
+	Get(ctx context.Context, name string) ([]byte, error)
+}
+
+type archivedProof struct {
+	height uint64
+	proof  []byte
+}
+
+// ArchivePubRandProofs moves the proofs of the finality provider at heights
+// up to toHeight (inclusive) from the db to the archive. The proofs are
+// deleted from the db only after they are saved in the archive.
+// It returns the number of archived proofs
+func (s *PubRandProofStore) ArchivePubRandProofs(
+	ctx context.Context,
+	archive PubRandProofArchive,
+	objectPrefix string,
+	chainID []byte,
+	pk []byte,
+	toHeight uint64,
+) (int, error) {
+	var numArchived int
+	for {
+		proofs, err := s.getProofsToArchive(chainID, pk, toHeight)
+		if err != nil {
+			return numArchived, err
+		}
+		if len(proofs) == 0 {
+			return numArchived, nil
+		}
+
+		fromHeight, lastHeight := proofs[0].height, proofs[len(proofs)-1].height
+		name := path.Join(
+			objectPrefix,
+			string(chainID),
+			hex.EncodeToString(pk),
+			fmt.Sprintf("%020d-%020d", fromHeight, lastHeight),
+		)
+		if err := archive.Put(ctx, name, encodeArchivedProofs(proofs)); err != nil {
+			return numArchived, fmt.Errorf("failed to save the proofs to the archive: %w", err)
+		}
+
+		err = kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+			indexBucket, err := createArchiveIndexBucket(tx, chainID, pk)
+			if err != nil {
+				return err
+			}
+
+			indexValue := append(sdk.Uint64ToBigEndian(fromHeight), []byte(name)...)
+			if err := indexBucket.Put(sdk.Uint64ToBigEndian(lastHeight), indexValue); err != nil {
+				return err
+			}
+
+			bucket, err := createProofBucket(tx, chainID, pk)
+			if err != nil {
+				return err
+			}
+			for _, p := range proofs {
+				if err := bucket.Delete(sdk.Uint64ToBigEndian(p.height)); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return numArchived, err
+		}
+
+		numArchived += len(proofs)
+	}
+}
+
+func (s *PubRandProofStore) getProofsToArchive(chainID, pk []byte, toHeight uint64) ([]*archivedProof, error) {
+	var proofs []*archivedProof
+
+	err := s.db.View(func(tx kvdb.RTx) error {
+		bucket, err := getProofBucket(tx, chainID, pk)
+		if err != nil {
+			return err
+		}
+		if bucket == nil {
+			return nil
+		}
+
+		c := bucket.ReadCursor()
+		for k, v := c.First(); k != nil && len(proofs) < maxProofsPerArchivedObject; k, v = c.Next() {
+			height := sdk.BigEndianToUint64(k)
+			if height > toHeight {
+				break
+			}
+			proofs = append(proofs, &archivedProof{
+				height: height,
+				proof:  append([]byte{}, v...),
+			})
+		}
+
+		return nil
+	}, func() {
+		proofs = nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return proofs, nil
+}
+
+// GetArchivedPubRandProof fetches the proof of the public randomness of the
+// finality provider at the given height back from the archive
+func (s *PubRandProofStore) GetArchivedPubRandProof(
+	ctx context.Context,
+	archive PubRandProofArchive,
+	chainID []byte,
+	pk []byte,
+	height uint64,
+) ([]byte, error) {
+	var name string
+
+	err := s.db.View(func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(pubRandProofArchiveBucketName)
+		if bucket == nil {
+			return ErrCorruptedPubRandProofDB
+		}
+
+		chainBucket := bucket.NestedReadBucket(chainID)
+		if chainBucket == nil {
+			return ErrPubRandProofNotFound
+		}
+		indexBucket := chainBucket.NestedReadBucket(pk)
+		if indexBucket == nil {
+			return ErrPubRandProofNotFound
+		}
+
+		// the first object whose last height is not lower than the height
+		k, v := indexBucket.ReadCursor().Seek(sdk.Uint64ToBigEndian(height))
+		if k == nil || len(v) < 8 || sdk.BigEndianToUint64(v[:8]) > height {
+			return ErrPubRandProofNotFound
+		}
+		name = string(v[8:])
+
+		return nil
+	}, func() {})
+
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := archive.Get(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s from the archive: %w", name, err)
+	}
+
+	proofs, err := decodeArchivedProofs(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid archived object %s: %w", name, err)
+	}
+
+	for _, p := range proofs {
+		if p.height == height {
+			return p.proof, nil
+		}
+	}
+
+	return nil, ErrPubRandProofNotFound
+}
+
+func createArchiveIndexBucket(tx kvdb.RwTx, chainID, pk []byte) (kvdb.RwBucket, error) {
+	bucket := tx.ReadWriteBucket(pubRandProofArchiveBucketName)
+	if bucket == nil {
+		return nil, ErrCorruptedPubRandProofDB
+	}
+
+	chainBucket, err := bucket.CreateBucketIfNotExists(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	return chainBucket.CreateBucketIfNotExists(pk)
+}
+
+// encodeArchivedProofs encodes the proofs as a sequence of
+// (height || proof length || proof)
+func encodeArchivedProofs(proofs []*archivedProof) []byte {
+	var data []byte
+	for _, p := range proofs {
+		data = binary.BigEndian.AppendUint64(data, p.height)
+		// #nosec G115 -- a merkle proof is far smaller than 4GB
+		data = binary.BigEndian.AppendUint32(data, uint32(len(p.proof)))
+		data = append(data, p.proof...)
+	}
+
+	return data
+}
+
+func decodeArchivedProofs(data []byte) ([]*archivedProof, error) {
+	var proofs []*archivedProof
+	for len(data) > 0 {
+		if len(data) < 12 {
+			return nil, fmt.Errorf("truncated proof header")
+		}
+		height := binary.BigEndian.Uint64(data[:8])
+		proofLen := binary.BigEndian.Uint32(data[8:12])
+		data = data[12:]
+
+		if uint64(len(data)) < uint64(proofLen) {
+			return nil, fmt.Errorf("truncated proof at height %d", height)
+		}
+		proofs = append(proofs, &archivedProof{
+			height: height,
+			proof:  data[:proofLen],
+		})
+		data = data[proofLen:]
+	}
+
+	return proofs, nil
+}
@@ -0,0 +1,202 @@
+package store_test
+
+import (
+	"context"
+	"math/rand"
+	"path/filepath"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	fpstore "github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store/archive"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+func genPubRandList(r *rand.Rand, t *testing.T, num int) []*btcec.FieldVal {
+	pubRandList := make([]*btcec.FieldVal, 0, num)
+	for i := 0; i < num; i++ {
+		pubRandList = append(pubRandList, testutil.GenPublicRand(r, t).ToFieldVal())
+	}
+
+	return pubRandList
+}
+
+// FuzzPubRandProofStore tests that the proofs are namespaced by chain id
+// and the legacy proofs are migrated properly
+func FuzzPubRandProofStore(f *testing.F) {
+	testutil.AddRandomSeedsToFuzzer(f, 10)
+	f.Fuzz(func(t *testing.T, seed int64) {
+		t.Parallel()
+		r := rand.New(rand.NewSource(seed))
+
+		homePath := t.TempDir()
+		cfg := config.DefaultDBConfigWithHomePath(homePath)
+
+		db, err := cfg.GetDBBackend()
+		require.NoError(t, err)
+		defer func() {
+			err := db.Close()
+			require.NoError(t, err)
+		}()
+
+		prStore, err := fpstore.NewPubRandProofStore(db)
+		require.NoError(t, err)
+
+		fp := testutil.GenRandomFinalityProvider(r, t)
+		pk := fp.GetBIP340BTCPK().MustMarshal()
+		startHeight := uint64(r.Int63n(1000) + 1)
+		numPubRand := uint64(r.Int63n(10) + 1)
+
+		// the same fp commits different randomness on two chains
+		chainIDs := [][]byte{[]byte("chain-a"), []byte("chain-b")}
+		for _, chainID := range chainIDs {
+			_, proofList := types.GetPubRandCommitAndProofs(genPubRandList(r, t, int(numPubRand)))
+			err = prStore.AddPubRandProofList(chainID, pk, startHeight, numPubRand, proofList)
+			require.NoError(t, err)
+		}
+
+		proofsA, err := prStore.GetPubRandProofList(chainIDs[0], pk, startHeight, numPubRand)
+		require.NoError(t, err)
+		require.Len(t, proofsA, int(numPubRand))
+		proofsB, err := prStore.GetPubRandProofList(chainIDs[1], pk, startHeight, numPubRand)
+		require.NoError(t, err)
+		require.NotEqual(t, proofsA, proofsB)
+
+		proof, err := prStore.GetPubRandProof(chainIDs[0], pk, startHeight+numPubRand-1)
+		require.NoError(t, err)
+		require.Equal(t, proofsA[numPubRand-1], proof)
+
+		// read non-contiguous heights in one batch
+		gappedHeights := []uint64{startHeight, startHeight + numPubRand - 1}
+		if numPubRand == 1 {
+			gappedHeights = gappedHeights[:1]
+		}
+		gappedProofs, err := prStore.GetPubRandProofsByHeights(chainIDs[0], pk, gappedHeights)
+		require.NoError(t, err)
+		require.Equal(t, proofsA[0], gappedProofs[0])
+		require.Equal(t, proofsA[numPubRand-1], gappedProofs[len(gappedProofs)-1])
+
+		_, err = prStore.GetPubRandProofsByHeights(chainIDs[0], pk, []uint64{startHeight, startHeight + numPubRand})
+		require.ErrorIs(t, err, fpstore.ErrPubRandProofNotFound)
+
+		// list the proofs by range in pages
+		listed, err := prStore.ListPubRandProofs(chainIDs[0], pk, 0, startHeight+numPubRand, numPubRand+1)
+		require.NoError(t, err)
+		require.Len(t, listed, int(numPubRand))
+		for i, p := range listed {
+			require.Equal(t, startHeight+uint64(i), p.Height)
+			require.Equal(t, proofsA[i], p.Proof)
+		}
+		listed, err = prStore.ListPubRandProofs(chainIDs[0], pk, startHeight+numPubRand-1, startHeight+numPubRand-1, 1)
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+		require.Equal(t, proofsA[numPubRand-1], listed[0].Proof)
+		listed, err = prStore.ListPubRandProofs(chainIDs[0], pk, startHeight, startHeight+numPubRand, 1)
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+		listed, err = prStore.ListPubRandProofs([]byte("chain-c"), pk, 0, startHeight+numPubRand, numPubRand)
+		require.NoError(t, err)
+		require.Empty(t, listed)
+
+		_, err = prStore.GetPubRandProof(chainIDs[0], pk, startHeight+numPubRand)
+		require.ErrorIs(t, err, fpstore.ErrPubRandProofNotFound)
+		_, err = prStore.GetPubRandProof([]byte("chain-c"), pk, startHeight)
+		require.ErrorIs(t, err, fpstore.ErrPubRandProofNotFound)
+
+		// write legacy proofs keyed by public randomness
+		legacyChainID := []byte("chain-legacy")
+		pubRandList := genPubRandList(r, t, int(numPubRand))
+		_, proofList := types.GetPubRandCommitAndProofs(pubRandList)
+		err = kvdb.Update(db, func(tx kvdb.RwTx) error {
+			bucket, err := tx.CreateTopLevelBucket([]byte("pub_rand_proof"))
+			if err != nil {
+				return err
+			}
+			for i, pr := range pubRandList {
+				proofBytes, err := proofList[i].ToProto().Marshal()
+				if err != nil {
+					return err
+				}
+				prBytes := *pr.Bytes()
+				if err := bucket.Put(prBytes[:], proofBytes); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}, func() {})
+		require.NoError(t, err)
+
+		hasLegacy, err := prStore.HasLegacyPubRandProofs()
+		require.NoError(t, err)
+		require.True(t, hasLegacy)
+
+		numMigrated, err := prStore.MigrateLegacyPubRandProofs(legacyChainID, pk, startHeight, pubRandList)
+		require.NoError(t, err)
+		require.Equal(t, int(numPubRand), numMigrated)
+
+		hasLegacy, err = prStore.HasLegacyPubRandProofs()
+		require.NoError(t, err)
+		require.False(t, hasLegacy)
+
+		migratedProofs, err := prStore.GetPubRandProofList(legacyChainID, pk, startHeight, numPubRand)
+		require.NoError(t, err)
+		for i, proofBytes := range migratedProofs {
+			expectedBytes, err := proofList[i].ToProto().Marshal()
+			require.NoError(t, err)
+			require.Equal(t, expectedBytes, proofBytes)
+		}
+	})
+}
+
+func TestArchivePubRandProofs(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(10))
+
+	homePath := t.TempDir()
+	cfg := config.DefaultDBConfigWithHomePath(homePath)
+
+	db, err := cfg.GetDBBackend()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err := db.Close()
+		require.NoError(t, err)
+	})
+
+	prStore, err := fpstore.NewPubRandProofStore(db)
+	require.NoError(t, err)
+	localArchive, err := archive.NewLocalArchive(filepath.Join(homePath, "archive"))
+	require.NoError(t, err)
+
+	chainID := []byte("chain-test")
+	pk := testutil.GenRandomFinalityProvider(r, t).GetBIP340BTCPK().MustMarshal()
+	startHeight, numPubRand := uint64(1), uint64(25)
+	_, proofList := types.GetPubRandCommitAndProofs(genPubRandList(r, t, int(numPubRand)))
+	err = prStore.AddPubRandProofList(chainID, pk, startHeight, numPubRand, proofList)
+	require.NoError(t, err)
+	proofs, err := prStore.GetPubRandProofList(chainID, pk, startHeight, numPubRand)
+	require.NoError(t, err)
+
+	numArchived, err := prStore.ArchivePubRandProofs(context.Background(), localArchive, "proofs", chainID, pk, 20)
+	require.NoError(t, err)
+	require.Equal(t, 20, numArchived)
+
+	// archived proofs are removed from the db but can be fetched back
+	_, err = prStore.GetPubRandProof(chainID, pk, 10)
+	require.ErrorIs(t, err, fpstore.ErrPubRandProofNotFound)
+	archivedProof, err := prStore.GetArchivedPubRandProof(context.Background(), localArchive, chainID, pk, 10)
+	require.NoError(t, err)
+	require.Equal(t, proofs[10-startHeight], archivedProof)
+
+	// the proofs above the archived height are kept in the db
+	proof, err := prStore.GetPubRandProof(chainID, pk, 21)
+	require.NoError(t, err)
+	require.Equal(t, proofs[21-startHeight], proof)
+	_, err = prStore.GetArchivedPubRandProof(context.Background(), localArchive, chainID, pk, 21)
+	require.ErrorIs(t, err, fpstore.ErrPubRandProofNotFound)
+}
@@ -0,0 +1,138 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/btcsuite/btcwallet/walletdb"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+var (
+	// mapping: chain_id -> pk -> height -> signed message
+	signRecordBucketName = []byte("sign_records")
+)
+
+// SignRecordStore keeps the messages signed by the finality providers at
+// each height. The records are kept in a database of their own, apart from
+// the state of the finality providers, so that neither rolling back the last
+// voted height nor restoring or pruning the state allows signing a different
+// message at a height which has been signed already
+type SignRecordStore struct {
+	db kvdb.Backend
+}
+
+// NewSignRecordStore returns a new store backed by db
+func NewSignRecordStore(db kvdb.Backend) (*SignRecordStore, error) {
+	store := &SignRecordStore{db}
+	if err := store.initBuckets(); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func (s *SignRecordStore) initBuckets() error {
+	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		_, err := tx.CreateTopLevelBucket(signRecordBucketName)
+		return err
+	})
+}
+
+// createSignRecordBucket returns the bucket storing the sign records of the
+// given finality provider on the given chain, which is created if not exists
+func createSignRecordBucket(tx kvdb.RwTx, chainID, pk []byte) (walletdb.ReadWriteBucket, error) {
+	bucket := tx.ReadWriteBucket(signRecordBucketName)
+	if bucket == nil {
+		return nil, ErrCorruptedSignRecordDB
+	}
+
+	chainBucket, err := bucket.CreateBucketIfNotExists(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	return chainBucket.CreateBucketIfNotExists(pk)
+}
+
+// SaveSignRecord records that the finality provider is about to sign the
+// given message at the given height. It returns ErrDoubleSign if a different
+// message has been recorded at the height, in which case signing the message
+// would be an equivocation, or if no message has been recorded at the height
+// but one has been at a higher height, as the record of the height might be
+// missing. Recording the same message again is a no-op
+func (s *SignRecordStore) SaveSignRecord(chainID []byte, pk []byte, height uint64, msg []byte) error {
+	if len(chainID) == 0 || len(pk) == 0 {
+		return fmt.Errorf("chain id and public key cannot be empty")
+	}
+
+	if len(msg) == 0 {
+		return fmt.Errorf("the signed message cannot be empty")
+	}
+
+	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		bucket, err := createSignRecordBucket(tx, chainID, pk)
+		if err != nil {
+			return err
+		}
+
+		heightKey := sdk.Uint64ToBigEndian(height)
+		if signed := bucket.Get(heightKey); signed != nil {
+			if !bytes.Equal(signed, msg) {
+				return fmt.Errorf("%w: height %d", ErrDoubleSign, height)
+			}
+
+			return nil
+		}
+
+		if lastKey, _ := bucket.ReadWriteCursor().Last(); lastKey != nil {
+			if lastHeight := sdk.BigEndianToUint64(lastKey); lastHeight > height {
+				return fmt.Errorf("%w: height %d is below the highest signed height %d",
+					ErrDoubleSign, height, lastHeight)
+			}
+		}
+
+		return bucket.Put(heightKey, msg)
+	})
+}
+
+// GetSignRecord returns the message signed by the finality provider at the
+// given height
+func (s *SignRecordStore) GetSignRecord(chainID []byte, pk []byte, height uint64) ([]byte, error) {
+	var msg []byte
+
+	err := s.db.View(func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(signRecordBucketName)
+		if bucket == nil {
+			return ErrCorruptedSignRecordDB
+		}
+
+		chainBucket := bucket.NestedReadBucket(chainID)
+		if chainBucket == nil {
+			return ErrSignRecordNotFound
+		}
+
+		pkBucket := chainBucket.NestedReadBucket(pk)
+		if pkBucket == nil {
+			return ErrSignRecordNotFound
+		}
+
+		signed := pkBucket.Get(sdk.Uint64ToBigEndian(height))
+		if signed == nil {
+			return ErrSignRecordNotFound
+		}
+		msg = make([]byte, len(signed))
+		copy(msg, signed)
+
+		return nil
+	}, func() {
+		msg = nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
@@ -0,0 +1,72 @@
+package store_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	fpstore "github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+)
+
+// FuzzSignRecordStore tests that a different message cannot be recorded at
+// an already signed height
+func FuzzSignRecordStore(f *testing.F) {
+	testutil.AddRandomSeedsToFuzzer(f, 10)
+	f.Fuzz(func(t *testing.T, seed int64) {
+		t.Parallel()
+		r := rand.New(rand.NewSource(seed))
+
+		cfg := config.DefaultDBConfigWithHomePath(t.TempDir())
+		db, err := cfg.GetDBBackend()
+		require.NoError(t, err)
+		defer func() {
+			err := db.Close()
+			require.NoError(t, err)
+		}()
+
+		srStore, err := fpstore.NewSignRecordStore(db)
+		require.NoError(t, err)
+
+		fp := testutil.GenRandomFinalityProvider(r, t)
+		pk := fp.GetBIP340BTCPK().MustMarshal()
+		chainID := []byte("chain-test")
+		height := uint64(r.Int63n(1000) + 1)
+		msg := testutil.GenRandomByteArray(r, 40)
+
+		_, err = srStore.GetSignRecord(chainID, pk, height)
+		require.ErrorIs(t, err, fpstore.ErrSignRecordNotFound)
+
+		err = srStore.SaveSignRecord(chainID, pk, height, msg)
+		require.NoError(t, err)
+		signed, err := srStore.GetSignRecord(chainID, pk, height)
+		require.NoError(t, err)
+		require.Equal(t, msg, signed)
+
+		// recording the same message again is allowed
+		err = srStore.SaveSignRecord(chainID, pk, height, msg)
+		require.NoError(t, err)
+
+		// a different message at the same height is refused
+		err = srStore.SaveSignRecord(chainID, pk, height, testutil.GenRandomByteArray(r, 40))
+		require.ErrorIs(t, err, fpstore.ErrDoubleSign)
+		signed, err = srStore.GetSignRecord(chainID, pk, height)
+		require.NoError(t, err)
+		require.Equal(t, msg, signed)
+
+		// the records are namespaced by chain id and height
+		err = srStore.SaveSignRecord([]byte("chain-other"), pk, height, testutil.GenRandomByteArray(r, 40))
+		require.NoError(t, err)
+		err = srStore.SaveSignRecord(chainID, pk, height+1, testutil.GenRandomByteArray(r, 40))
+		require.NoError(t, err)
+
+		// a height below the highest signed one is refused, while the
+		// messages recorded below it can be recorded again
+		err = srStore.SaveSignRecord(chainID, pk, height-1, testutil.GenRandomByteArray(r, 40))
+		require.ErrorIs(t, err, fpstore.ErrDoubleSign)
+		err = srStore.SaveSignRecord(chainID, pk, height, msg)
+		require.NoError(t, err)
+	})
+}
@@ -10,18 +10,20 @@ import (
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 
 	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/types"
 )
 
 type StoredFinalityProvider struct {
-	FPAddr          string
-	BtcPk           *btcec.PublicKey
-	Description     *stakingtypes.Description
-	Commission      *sdkmath.LegacyDec
-	Pop             *proto.ProofOfPossession
-	KeyName         string
-	ChainID         string
-	LastVotedHeight uint64
-	Status          proto.FinalityProviderStatus
+	FPAddr              string
+	BtcPk               *btcec.PublicKey
+	Description         *stakingtypes.Description
+	Commission          *sdkmath.LegacyDec
+	Pop                 *proto.ProofOfPossession
+	KeyName             string
+	ChainID             string
+	LastVotedHeight     uint64
+	LastProcessedHeight uint64
+	Status              proto.FinalityProviderStatus
 }
 
 func protoFpToStoredFinalityProvider(fp *proto.FinalityProvider) (*StoredFinalityProvider, error) {
@@ -48,10 +50,11 @@ func protoFpToStoredFinalityProvider(fp *proto.FinalityProvider) (*StoredFinalit
 		Pop: &proto.ProofOfPossession{
 			BtcSig: fp.Pop.BtcSig,
 		},
-		KeyName:         fp.KeyName,
-		ChainID:         fp.ChainId,
-		LastVotedHeight: fp.LastVotedHeight,
-		Status:          fp.Status,
+		KeyName:             fp.KeyName,
+		ChainID:             fp.ChainId,
+		LastVotedHeight:     fp.LastVotedHeight,
+		LastProcessedHeight: fp.LastProcessedHeight,
+		Status:              fp.Status,
 	}, nil
 }
 
@@ -76,6 +79,16 @@ func (sfp *StoredFinalityProvider) ToFinalityProviderInfo() *proto.FinalityProvi
 	}
 }
 
+// ToPopExport returns the verifiable binding between the EOTS public key
+// and the Babylon address of the finality provider
+func (sfp *StoredFinalityProvider) ToPopExport() (*types.PopExport, error) {
+	if sfp.Pop == nil || len(sfp.Pop.BtcSig) == 0 {
+		return nil, fmt.Errorf("the proof-of-possession of %s is not stored", sfp.GetBIP340BTCPK().MarshalHex())
+	}
+
+	return types.NewPopExport(sfp.GetBIP340BTCPK(), sfp.FPAddr, sfp.Pop.BtcSig)
+}
+
 // ShouldStart returns true if the finality provider should start his instance
 // based on the current status of the finality provider.
 //
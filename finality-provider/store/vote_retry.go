@@ -0,0 +1,203 @@
+package store
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/btcsuite/btcwallet/walletdb"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+var (
+	// mapping: chain_id -> pk -> height -> failed vote
+	voteRetryBucketName = []byte("vote_retries")
+)
+
+// FailedVote is a finality vote whose submission failed, which is queued
+// to be retried
+type FailedVote struct {
+	Height    uint64
+	BlockHash []byte
+	// Attempts is the number of the failed retries of the vote
+	Attempts uint32
+}
+
+// VoteRetryStore is a durable queue of the failed finality votes of the
+// finality providers, ordered by height
+type VoteRetryStore struct {
+	db kvdb.Backend
+}
+
+// NewVoteRetryStore returns a new store backed by db
+func NewVoteRetryStore(db kvdb.Backend) (*VoteRetryStore, error) {
+	store := &VoteRetryStore{db}
+	if err := store.initBuckets(); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func (s *VoteRetryStore) initBuckets() error {
+	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		_, err := tx.CreateTopLevelBucket(voteRetryBucketName)
+		return err
+	})
+}
+
+// createVoteRetryBucket returns the bucket storing the failed votes of the
+// given finality provider on the given chain, which is created if not exists
+func createVoteRetryBucket(tx kvdb.RwTx, chainID, pk []byte) (walletdb.ReadWriteBucket, error) {
+	bucket := tx.ReadWriteBucket(voteRetryBucketName)
+	if bucket == nil {
+		return nil, ErrCorruptedVoteRetryDB
+	}
+
+	chainBucket, err := bucket.CreateBucketIfNotExists(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	return chainBucket.CreateBucketIfNotExists(pk)
+}
+
+// the value of a failed vote is the number of attempts followed by the
+// block hash
+func encodeFailedVote(v *FailedVote) []byte {
+	bz := make([]byte, 4+len(v.BlockHash))
+	binary.BigEndian.PutUint32(bz, v.Attempts)
+	copy(bz[4:], v.BlockHash)
+
+	return bz
+}
+
+func decodeFailedVote(height uint64, bz []byte) (*FailedVote, error) {
+	if len(bz) < 4 {
+		return nil, ErrCorruptedVoteRetryDB
+	}
+	hash := make([]byte, len(bz)-4)
+	copy(hash, bz[4:])
+
+	return &FailedVote{
+		Height:    height,
+		BlockHash: hash,
+		Attempts:  binary.BigEndian.Uint32(bz),
+	}, nil
+}
+
+// EnqueueFailedVotes adds the given votes of the finality provider to the
+// queue. The attempts of a vote already queued at the same height are kept
+func (s *VoteRetryStore) EnqueueFailedVotes(chainID []byte, pk []byte, votes []*FailedVote) error {
+	if len(chainID) == 0 || len(pk) == 0 {
+		return fmt.Errorf("chain id and public key cannot be empty")
+	}
+
+	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		bucket, err := createVoteRetryBucket(tx, chainID, pk)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range votes {
+			heightKey := sdk.Uint64ToBigEndian(v.Height)
+			if bucket.Get(heightKey) != nil {
+				continue
+			}
+			if err := bucket.Put(heightKey, encodeFailedVote(v)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// ListFailedVotes returns the queued votes of the finality provider in
+// ascending order of height
+func (s *VoteRetryStore) ListFailedVotes(chainID []byte, pk []byte) ([]*FailedVote, error) {
+	var votes []*FailedVote
+
+	err := s.db.View(func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(voteRetryBucketName)
+		if bucket == nil {
+			return ErrCorruptedVoteRetryDB
+		}
+
+		chainBucket := bucket.NestedReadBucket(chainID)
+		if chainBucket == nil {
+			return nil
+		}
+
+		pkBucket := chainBucket.NestedReadBucket(pk)
+		if pkBucket == nil {
+			return nil
+		}
+
+		return pkBucket.ForEach(func(k, v []byte) error {
+			vote, err := decodeFailedVote(sdk.BigEndianToUint64(k), v)
+			if err != nil {
+				return err
+			}
+			votes = append(votes, vote)
+
+			return nil
+		})
+	}, func() {
+		votes = nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return votes, nil
+}
+
+// IncrementFailedVoteAttempts increments the number of attempts of the
+// queued votes at the given heights, the heights not queued are ignored
+func (s *VoteRetryStore) IncrementFailedVoteAttempts(chainID []byte, pk []byte, heights []uint64) error {
+	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		bucket, err := createVoteRetryBucket(tx, chainID, pk)
+		if err != nil {
+			return err
+		}
+
+		for _, height := range heights {
+			heightKey := sdk.Uint64ToBigEndian(height)
+			bz := bucket.Get(heightKey)
+			if bz == nil {
+				continue
+			}
+			vote, err := decodeFailedVote(height, bz)
+			if err != nil {
+				return err
+			}
+			vote.Attempts++
+			if err := bucket.Put(heightKey, encodeFailedVote(vote)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// RemoveFailedVotes removes the queued votes at the given heights from the
+// queue, the heights not queued are ignored
+func (s *VoteRetryStore) RemoveFailedVotes(chainID []byte, pk []byte, heights []uint64) error {
+	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		bucket, err := createVoteRetryBucket(tx, chainID, pk)
+		if err != nil {
+			return err
+		}
+
+		for _, height := range heights {
+			if err := bucket.Delete(sdk.Uint64ToBigEndian(height)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
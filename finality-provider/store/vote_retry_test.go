@@ -0,0 +1,75 @@
+package store_test
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	fpstore "github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+)
+
+// TestVoteRetryStore tests that the failed votes are queued in order of
+// height and their attempts are tracked until removed
+func TestVoteRetryStore(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	cfg := config.DefaultDBConfigWithHomePath(t.TempDir())
+	db, err := cfg.GetDBBackend()
+	require.NoError(t, err)
+	defer func() {
+		err := db.Close()
+		require.NoError(t, err)
+	}()
+
+	vrStore, err := fpstore.NewVoteRetryStore(db)
+	require.NoError(t, err)
+
+	fp := testutil.GenRandomFinalityProvider(r, t)
+	pk := fp.GetBIP340BTCPK().MustMarshal()
+	chainID := []byte("chain-test")
+
+	votes, err := vrStore.ListFailedVotes(chainID, pk)
+	require.NoError(t, err)
+	require.Empty(t, votes)
+
+	height := uint64(r.Int63n(1000) + 1)
+	queued := []*fpstore.FailedVote{
+		{Height: height + 1, BlockHash: testutil.GenRandomByteArray(r, 32)},
+		{Height: height, BlockHash: testutil.GenRandomByteArray(r, 32)},
+	}
+	err = vrStore.EnqueueFailedVotes(chainID, pk, queued)
+	require.NoError(t, err)
+
+	err = vrStore.IncrementFailedVoteAttempts(chainID, pk, []uint64{height})
+	require.NoError(t, err)
+
+	// queuing a vote again keeps its attempts
+	err = vrStore.EnqueueFailedVotes(chainID, pk, queued[1:])
+	require.NoError(t, err)
+
+	votes, err = vrStore.ListFailedVotes(chainID, pk)
+	require.NoError(t, err)
+	require.Len(t, votes, 2)
+	require.Equal(t, height, votes[0].Height)
+	require.Equal(t, queued[1].BlockHash, votes[0].BlockHash)
+	require.Equal(t, uint32(1), votes[0].Attempts)
+	require.Equal(t, height+1, votes[1].Height)
+	require.Equal(t, uint32(0), votes[1].Attempts)
+
+	// the votes are namespaced by chain id
+	votes, err = vrStore.ListFailedVotes([]byte("chain-other"), pk)
+	require.NoError(t, err)
+	require.Empty(t, votes)
+
+	err = vrStore.RemoveFailedVotes(chainID, pk, []uint64{height})
+	require.NoError(t, err)
+	votes, err = vrStore.ListFailedVotes(chainID, pk)
+	require.NoError(t, err)
+	require.Len(t, votes, 1)
+	require.Equal(t, height+1, votes[0].Height)
+}
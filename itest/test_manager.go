@@ -228,7 +228,7 @@ func StartManagerWithFinalityProvider(t *testing.T) (*TestManager, *service.Fina
 	require.NoError(t, err)
 	err = app.StartHandlingFinalityProvider(fpPk, passphrase)
 	require.NoError(t, err)
-	fpIns, err := app.GetFinalityProviderInstance()
+	fpIns, err := app.GetFinalityProviderInstance(fpPk)
 	require.NoError(t, err)
 	require.True(t, fpIns.IsRunning())
 	require.NoError(t, err)
@@ -420,8 +420,7 @@ func (tm *TestManager) WaitForNFinalizedBlocks(t *testing.T, n int) []*types.Blo
 
 func (tm *TestManager) WaitForFpShutDown(t *testing.T) {
 	require.Eventually(t, func() bool {
-		_, err := tm.Fpa.GetFinalityProviderInstance()
-		return err != nil
+		return len(tm.Fpa.ListRunningInstances()) == 0
 	}, eventuallyWaitTimeOut, eventuallyPollTime)
 
 	t.Logf("the finality-provider instance is shutdown")
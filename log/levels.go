@@ -0,0 +1,203 @@
+package log
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// the modules are the names of the loggers of the components of the daemon,
+// whose levels can be set apart from the root level
+const (
+	ModuleClientController = "clientcontroller"
+	ModuleEOTSClient       = "eotsclient"
+	ModuleEventBus         = "eventbus"
+	ModuleFpInstance       = "fp"
+	ModuleChainPoller      = "poller"
+	ModuleRPC              = "rpc"
+)
+
+// Levels holds the root log level along with the levels of the modules,
+// which can all be changed at runtime. The level of a module applies to the
+// logger of the module and to its descendants, e.g., "fp" applies to
+// "fp.poller" unless "fp.poller" has its own level
+type Levels struct {
+	root zap.AtomicLevel
+
+	mu      sync.RWMutex
+	modules map[string]zapcore.Level
+}
+
+func NewLevels(root zapcore.Level) *Levels {
+	return &Levels{
+		root:    zap.NewAtomicLevelAt(root),
+		modules: make(map[string]zapcore.Level),
+	}
+}
+
+// Root returns the root level
+func (l *Levels) Root() zapcore.Level {
+	return l.root.Level()
+}
+
+// SetLevel sets the level of the given module, or the root level if the
+// module is empty
+func (l *Levels) SetLevel(module string, level zapcore.Level) {
+	if module == "" {
+		l.root.SetLevel(level)
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.modules[module] = level
+}
+
+// ResetLevel makes the given module follow the root level again
+func (l *Levels) ResetLevel(module string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.modules, module)
+}
+
+// SetModuleLevels replaces the levels of the modules, the other modules
+// following the root level again
+func (l *Levels) SetModuleLevels(levels map[string]zapcore.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.modules = make(map[string]zapcore.Level, len(levels))
+	for module, level := range levels {
+		l.modules[module] = level
+	}
+}
+
+// ModuleLevels returns the levels of the modules set apart from the root
+// level
+func (l *Levels) ModuleLevels() map[string]zapcore.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	levels := make(map[string]zapcore.Level, len(l.modules))
+	for module, level := range l.modules {
+		levels[module] = level
+	}
+
+	return levels
+}
+
+// LevelOf returns the level applying to the logger of the given name
+func (l *Levels) LevelOf(name string) zapcore.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	for name != "" {
+		if level, ok := l.modules[name]; ok {
+			return level
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+
+	return l.root.Level()
+}
+
+// minLevel returns the lowest of the levels, below which nothing is logged
+func (l *Levels) minLevel() zapcore.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	lvl := l.root.Level()
+	for _, level := range l.modules {
+		lvl = min(lvl, level)
+	}
+
+	return lvl
+}
+
+// Modules returns the names of the modules whose level can be set
+func Modules() []string {
+	modules := []string{
+		ModuleClientController,
+		ModuleEOTSClient,
+		ModuleEventBus,
+		ModuleFpInstance,
+		ModuleFpInstance + "." + ModuleChainPoller,
+		ModuleRPC,
+	}
+	sort.Strings(modules)
+
+	return modules
+}
+
+// ValidateModule checks that the module is known, the empty module being
+// the root
+func ValidateModule(module string) error {
+	if module == "" {
+		return nil
+	}
+	for _, m := range Modules() {
+		if m == module {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown log module %q, expected one of %s", module, strings.Join(Modules(), ", "))
+}
+
+// ParseModuleLevels parses the levels of the modules given as
+// <module>=<level>
+func ParseModuleLevels(moduleLevels []string) (map[string]zapcore.Level, error) {
+	levels := make(map[string]zapcore.Level, len(moduleLevels))
+	for _, moduleLevel := range moduleLevels {
+		module, level, ok := strings.Cut(moduleLevel, "=")
+		module, level = strings.TrimSpace(module), strings.TrimSpace(level)
+		if !ok || module == "" {
+			return nil, fmt.Errorf("invalid module level %q, expected <module>=<level>", moduleLevel)
+		}
+		if err := ValidateModule(module); err != nil {
+			return nil, err
+		}
+		lvl, err := ParseLevel(level)
+		if err != nil {
+			return nil, fmt.Errorf("invalid level of the module %s: %w", module, err)
+		}
+		if _, ok := levels[module]; ok {
+			return nil, fmt.Errorf("duplicate level of the module %s", module)
+		}
+		levels[module] = lvl
+	}
+
+	return levels, nil
+}
+
+// levelsCore filters the entries by the level of the logger they are
+// written to
+type levelsCore struct {
+	zapcore.Core
+	levels *Levels
+}
+
+func (c *levelsCore) Enabled(lvl zapcore.Level) bool {
+	return lvl >= c.levels.minLevel()
+}
+
+func (c *levelsCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelsCore{Core: c.Core.With(fields), levels: c.levels}
+}
+
+func (c *levelsCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if ent.Level < c.levels.LevelOf(ent.LoggerName) {
+		return ce
+	}
+
+	// the wrapped core checks the entry, so that it can be sampled
+	return c.Core.Check(ent, ce)
+}
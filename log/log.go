@@ -4,18 +4,32 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"path/filepath"
 	"strings"
 	"time"
 
-	"github.com/babylonlabs-io/finality-provider/util"
-
 	zaplogfmt "github.com/jsternberg/zap-logfmt"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
 func NewRootLogger(format string, level string, w io.Writer) (*zap.Logger, error) {
+	lvl, err := ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewRootLoggerWithLevels(format, NewLevels(lvl), w)
+}
+
+// NewRootLoggerWithLevels creates a logger whose levels, i.e., the root one
+// and the ones of the modules, can be changed at runtime through levels
+func NewRootLoggerWithLevels(format string, levels *Levels, w io.Writer) (*zap.Logger, error) {
+	return NewRootLoggerWithSampling(format, levels, SamplingConfig{}, w)
+}
+
+// NewRootLoggerWithSampling is like NewRootLoggerWithLevels but the entries
+// are sampled and deduplicated according to sampling
+func NewRootLoggerWithSampling(format string, levels *Levels, sampling SamplingConfig, w io.Writer) (*zap.Logger, error) {
 	cfg := zap.NewProductionEncoderConfig()
 	cfg.EncodeTime = func(ts time.Time, encoder zapcore.PrimitiveArrayEncoder) {
 		encoder.AppendString(ts.UTC().Format("2006-01-02T15:04:05.000000Z07:00"))
@@ -34,45 +48,66 @@ func NewRootLogger(format string, level string, w io.Writer) (*zap.Logger, error
 		return nil, fmt.Errorf("unrecognized log format %q", format)
 	}
 
-	var lvl zapcore.Level
+	// the entries are filtered by the levels of their loggers before being
+	// sampled
+	return zap.New(&levelsCore{
+		Core:   wrapSampling(zapcore.NewCore(enc, zapcore.AddSync(w), zapcore.DebugLevel), sampling),
+		levels: levels,
+	}), nil
+}
+
+// ParseLevel parses the log level from its name
+func ParseLevel(level string) (zapcore.Level, error) {
 	switch strings.ToLower(level) {
 	case "panic":
-		lvl = zap.PanicLevel
+		return zap.PanicLevel, nil
 	case "fatal":
-		lvl = zap.FatalLevel
+		return zap.FatalLevel, nil
 	case "error":
-		lvl = zap.ErrorLevel
+		return zap.ErrorLevel, nil
 	case "warn", "warning":
-		lvl = zap.WarnLevel
+		return zap.WarnLevel, nil
 	case "info":
-		lvl = zap.InfoLevel
+		return zap.InfoLevel, nil
 	case "debug":
-		lvl = zap.DebugLevel
+		return zap.DebugLevel, nil
 	default:
-		return nil, fmt.Errorf("unsupported log level: %s", level)
+		return zap.InfoLevel, fmt.Errorf("unsupported log level: %s", level)
 	}
-
-	return zap.New(
-		zapcore.NewCore(
-			enc,
-			zapcore.AddSync(w),
-			lvl,
-		),
-	), nil
 }
 
 func NewRootLoggerWithFile(logFile string, level string) (*zap.Logger, error) {
-	if err := util.MakeDirectory(filepath.Dir(logFile)); err != nil {
+	lvl, err := ParseLevel(level)
+	if err != nil {
 		return nil, err
 	}
-	// #nosec G304 - The log file path is provided by the user and not externally
-	f, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+
+	return NewRootLoggerWithFileAndLevels(logFile, NewLevels(lvl))
+}
+
+// NewRootLoggerWithFileAndLevels is like NewRootLoggerWithFile but the
+// levels of the logger can be changed at runtime through levels
+func NewRootLoggerWithFileAndLevels(logFile string, levels *Levels) (*zap.Logger, error) {
+	return NewRootLoggerWithRotatingFile(logFile, "console", RotationConfig{}, SamplingConfig{}, levels)
+}
+
+// NewRootLoggerWithRotatingFile creates a logger writing the entries in the
+// given format to stdout and to the log file, which is rotated according to
+// rotation. The entries are sampled and deduplicated according to sampling
+// and the levels of the logger can be changed at runtime through levels
+func NewRootLoggerWithRotatingFile(
+	logFile, format string,
+	rotation RotationConfig,
+	sampling SamplingConfig,
+	levels *Levels,
+) (*zap.Logger, error) {
+	f, err := NewRotatingFile(logFile, rotation)
 	if err != nil {
 		return nil, err
 	}
 	mw := io.MultiWriter(os.Stdout, f)
 
-	logger, err := NewRootLogger("console", level, mw)
+	logger, err := NewRootLoggerWithSampling(format, levels, sampling, mw)
 	if err != nil {
 		return nil, err
 	}
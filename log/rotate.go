@@ -0,0 +1,180 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+// backupTimeFormat is the format of the time appended to the name of the
+// rotated log files, which sorts chronologically
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// RotationConfig defines when the log file is rotated and how long the
+// rotated files are kept
+type RotationConfig struct {
+	// MaxSizeMB is the size in megabytes above which the log file is
+	// rotated, 0 disables the rotation
+	MaxSizeMB int
+	// MaxAge is the age above which the rotated files are deleted, 0 keeps
+	// them regardless of their age
+	MaxAge time.Duration
+	// MaxBackups is the number of rotated files above which the oldest ones
+	// are deleted, 0 keeps them all
+	MaxBackups int
+}
+
+// RotatingFile is a log file which is renamed with the time of its rotation
+// once it exceeds its max size, the writes going to a new file
+type RotatingFile struct {
+	path string
+	cfg  RotationConfig
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// NewRotatingFile opens the log file at the given path, appending to it
+func NewRotatingFile(path string, cfg RotationConfig) (*RotatingFile, error) {
+	if err := util.MakeDirectory(filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+
+	rf := &RotatingFile{path: path, cfg: cfg}
+	if err := rf.open(); err != nil {
+		return nil, err
+	}
+
+	return rf, nil
+}
+
+func (rf *RotatingFile) open() error {
+	// #nosec G304 - The log file path is provided by the user and not externally
+	f, err := os.OpenFile(rf.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	rf.file = f
+	rf.size = info.Size()
+
+	return nil
+}
+
+func (rf *RotatingFile) Write(p []byte) (int, error) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	maxSize := int64(rf.cfg.MaxSizeMB) * 1024 * 1024
+	// an entry larger than the max size is written to an empty file
+	if maxSize > 0 && rf.size > 0 && rf.size+int64(len(p)) > maxSize {
+		if err := rf.rotate(); err != nil {
+			return 0, fmt.Errorf("failed to rotate the log file: %w", err)
+		}
+	}
+
+	n, err := rf.file.Write(p)
+	rf.size += int64(n)
+
+	return n, err
+}
+
+// Sync flushes the log file to disk
+func (rf *RotatingFile) Sync() error {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	return rf.file.Sync()
+}
+
+// Close closes the log file
+func (rf *RotatingFile) Close() error {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	return rf.file.Close()
+}
+
+// rotate renames the log file with the current time, opens a new one and
+// deletes the rotated files which should not be kept
+func (rf *RotatingFile) rotate() error {
+	if err := rf.file.Close(); err != nil {
+		return err
+	}
+
+	ext := filepath.Ext(rf.path)
+	backup := fmt.Sprintf("%s-%s%s", strings.TrimSuffix(rf.path, ext), time.Now().UTC().Format(backupTimeFormat), ext)
+	if err := os.Rename(rf.path, backup); err != nil {
+		return err
+	}
+
+	if err := rf.open(); err != nil {
+		return err
+	}
+
+	return rf.removeOldBackups()
+}
+
+// removeOldBackups deletes the rotated files older than the max age and the
+// oldest ones above the max number of backups
+func (rf *RotatingFile) removeOldBackups() error {
+	if rf.cfg.MaxAge == 0 && rf.cfg.MaxBackups == 0 {
+		return nil
+	}
+
+	ext := filepath.Ext(rf.path)
+	prefix := filepath.Base(strings.TrimSuffix(rf.path, ext)) + "-"
+	entries, err := os.ReadDir(filepath.Dir(rf.path))
+	if err != nil {
+		return err
+	}
+
+	type backupFile struct {
+		path string
+		time time.Time
+	}
+	var backups []backupFile
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		t, err := time.Parse(backupTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext))
+		if err != nil {
+			// not a rotated file
+			continue
+		}
+		backups = append(backups, backupFile{path: filepath.Join(filepath.Dir(rf.path), name), time: t})
+	}
+
+	// the newest first
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].time.After(backups[j].time)
+	})
+
+	now := time.Now().UTC()
+	for i, backup := range backups {
+		tooMany := rf.cfg.MaxBackups > 0 && i >= rf.cfg.MaxBackups
+		tooOld := rf.cfg.MaxAge > 0 && now.Sub(backup.time) > rf.cfg.MaxAge
+		if !tooMany && !tooOld {
+			continue
+		}
+		if err := os.Remove(backup.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,354 @@
+package log
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	defaultSamplingTick = time.Second
+	defaultDedupWindow  = time.Minute
+
+	// maxDedupEntries is the max number of distinct entries tracked by the
+	// deduplication, the entries beyond it being logged as is
+	maxDedupEntries = 1024
+)
+
+// LevelSampling defines the sampling of the entries of a log level
+type LevelSampling struct {
+	// First is the number of entries with the same message which are
+	// logged per tick
+	First int
+	// Thereafter is the interval of the entries logged after the first
+	// ones in the same tick, 0 drops them all
+	Thereafter int
+}
+
+// SamplingConfig defines the sampling and the deduplication of the entries
+// per log level, which keep the high-frequency messages, e.g., the per-block
+// ones of the catch-up, from flooding the logs. A level is either sampled or
+// deduplicated
+type SamplingConfig struct {
+	// Tick is the interval over which the entries with the same level and
+	// message are counted by the sampling
+	Tick time.Duration
+	// Levels maps the sampled levels to their sampling
+	Levels map[zapcore.Level]LevelSampling
+	// DedupLevels are the levels whose identical repeated entries, i.e.,
+	// with the same logger, message, context and error, are logged once per
+	// DedupWindow, followed by the number of the repetitions
+	DedupLevels []zapcore.Level
+	DedupWindow time.Duration
+}
+
+// ParseLevelSamplings parses the sampling of the levels given as
+// <level>=<first>:<thereafter>
+func ParseLevelSamplings(levelSamplings []string) (map[zapcore.Level]LevelSampling, error) {
+	samplings := make(map[zapcore.Level]LevelSampling, len(levelSamplings))
+	for _, levelSampling := range levelSamplings {
+		level, sampling, ok := strings.Cut(levelSampling, "=")
+		first, thereafter, ok2 := strings.Cut(sampling, ":")
+		if !ok || !ok2 {
+			return nil, fmt.Errorf("invalid level sampling %q, expected <level>=<first>:<thereafter>", levelSampling)
+		}
+		lvl, err := ParseLevel(strings.TrimSpace(level))
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := samplings[lvl]; ok {
+			return nil, fmt.Errorf("duplicate sampling of the level %s", lvl)
+		}
+
+		var s LevelSampling
+		if s.First, err = strconv.Atoi(strings.TrimSpace(first)); err != nil || s.First <= 0 {
+			return nil, fmt.Errorf("invalid sampling of the level %s: the first entries should be a positive number", lvl)
+		}
+		if s.Thereafter, err = strconv.Atoi(strings.TrimSpace(thereafter)); err != nil || s.Thereafter < 0 {
+			return nil, fmt.Errorf("invalid sampling of the level %s: the interval should be a non-negative number", lvl)
+		}
+		samplings[lvl] = s
+	}
+
+	return samplings, nil
+}
+
+// ParseDedupLevels parses the names of the deduplicated levels, which should
+// not be sampled
+func ParseDedupLevels(levels []string, samplings map[zapcore.Level]LevelSampling) ([]zapcore.Level, error) {
+	dedupLevels := make([]zapcore.Level, 0, len(levels))
+	for _, level := range levels {
+		lvl, err := ParseLevel(strings.TrimSpace(level))
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := samplings[lvl]; ok {
+			return nil, fmt.Errorf("the level %s cannot be both sampled and deduplicated", lvl)
+		}
+		dedupLevels = append(dedupLevels, lvl)
+	}
+
+	return dedupLevels, nil
+}
+
+// wrapSampling wraps the core with the sampling and the deduplication of
+// the config, if any
+func wrapSampling(core zapcore.Core, cfg SamplingConfig) zapcore.Core {
+	if len(cfg.DedupLevels) > 0 {
+		window := cfg.DedupWindow
+		if window <= 0 {
+			window = defaultDedupWindow
+		}
+		core = newDedupCore(core, cfg.DedupLevels, window)
+	}
+
+	if len(cfg.Levels) > 0 {
+		tick := cfg.Tick
+		if tick <= 0 {
+			tick = defaultSamplingTick
+		}
+		core = newSamplingCore(core, cfg.Levels, tick)
+	}
+
+	return core
+}
+
+// samplingCore samples the entries of each sampled level apart, the entries
+// of the other levels being logged as is
+type samplingCore struct {
+	zapcore.Core
+	samplers map[zapcore.Level]zapcore.Core
+}
+
+func newSamplingCore(core zapcore.Core, levels map[zapcore.Level]LevelSampling, tick time.Duration) *samplingCore {
+	samplers := make(map[zapcore.Level]zapcore.Core, len(levels))
+	for lvl, s := range levels {
+		samplers[lvl] = zapcore.NewSamplerWithOptions(core, tick, s.First, s.Thereafter)
+	}
+
+	return &samplingCore{Core: core, samplers: samplers}
+}
+
+func (c *samplingCore) With(fields []zapcore.Field) zapcore.Core {
+	samplers := make(map[zapcore.Level]zapcore.Core, len(c.samplers))
+	for lvl, s := range c.samplers {
+		samplers[lvl] = s.With(fields)
+	}
+
+	return &samplingCore{Core: c.Core.With(fields), samplers: samplers}
+}
+
+func (c *samplingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if s, ok := c.samplers[ent.Level]; ok {
+		return s.Check(ent, ce)
+	}
+
+	return c.Core.Check(ent, ce)
+}
+
+// dedupEntry is a deduplicated entry, whose repetitions are counted until
+// the end of its window
+type dedupEntry struct {
+	expiry   time.Time
+	repeated int
+
+	// the last repetition and the core it has been written to, which are
+	// used to log the summary of the repetitions
+	ent    zapcore.Entry
+	fields []zapcore.Field
+	core   zapcore.Core
+	// timer flushes the summary at the end of the window
+	timer *time.Timer
+}
+
+// dedupState is shared by the deduplicating cores derived through With
+type dedupState struct {
+	window time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*dedupEntry
+}
+
+// suppress returns whether the entry is a repetition within the window of
+// an identical entry, in which case it is counted rather than logged
+func (s *dedupState) suppress(key string, ent zapcore.Entry, fields []zapcore.Field, core zapcore.Core) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[key]; ok && ent.Time.Before(e.expiry) {
+		e.repeated++
+		e.ent, e.fields, e.core = ent, fields, core
+		if e.repeated == 1 {
+			e.timer = time.AfterFunc(e.expiry.Sub(ent.Time), func() {
+				s.flush(key, e)
+			})
+		}
+		return true
+	}
+
+	if len(s.entries) >= maxDedupEntries {
+		s.prune(ent.Time)
+		if len(s.entries) >= maxDedupEntries {
+			return false
+		}
+	}
+	s.entries[key] = &dedupEntry{expiry: ent.Time.Add(s.window)}
+
+	return false
+}
+
+// prune drops the expired entries without repetitions, the other ones being
+// dropped once their summary is logged
+func (s *dedupState) prune(now time.Time) {
+	for key, e := range s.entries {
+		if e.repeated == 0 && !now.Before(e.expiry) {
+			delete(s.entries, key)
+		}
+	}
+}
+
+// flush logs the summary of the repetitions of the entry at the end of its
+// window, unless it has been flushed already through flushAll
+func (s *dedupState) flush(key string, e *dedupEntry) {
+	s.mu.Lock()
+	if s.entries[key] != e {
+		s.mu.Unlock()
+		return
+	}
+	delete(s.entries, key)
+	s.mu.Unlock()
+
+	e.writeSummary()
+}
+
+// flushAll logs the summaries of the repetitions of all the entries before
+// the end of their windows, e.g., upon shutdown
+func (s *dedupState) flushAll() {
+	s.mu.Lock()
+	var pending []*dedupEntry
+	for key, e := range s.entries {
+		if e.repeated == 0 {
+			continue
+		}
+		e.timer.Stop()
+		delete(s.entries, key)
+		pending = append(pending, e)
+	}
+	s.mu.Unlock()
+
+	for _, e := range pending {
+		e.writeSummary()
+	}
+}
+
+// writeSummary logs the last repetition of the entry along with the number
+// of its repetitions. The entry should not be tracked anymore, so that it is
+// not modified concurrently
+func (e *dedupEntry) writeSummary() {
+	ent := e.ent
+	ent.Message = fmt.Sprintf("%s (repeated %d times)", ent.Message, e.repeated)
+	ent.Time = time.Now()
+	fields := append(e.fields[:len(e.fields):len(e.fields)], zap.Int("repeated", e.repeated))
+	_ = e.core.Write(ent, fields)
+}
+
+// dedupCore logs the identical entries of the deduplicated levels once per
+// window, followed by a summary of their repetitions
+type dedupCore struct {
+	zapcore.Core
+	levels map[zapcore.Level]bool
+	state  *dedupState
+	// context is the encoding of the fields added through With, which are
+	// part of the identity of the entries
+	context string
+}
+
+func newDedupCore(core zapcore.Core, levels []zapcore.Level, window time.Duration) *dedupCore {
+	c := &dedupCore{
+		Core:   core,
+		levels: make(map[zapcore.Level]bool, len(levels)),
+		state: &dedupState{
+			window:  window,
+			entries: make(map[string]*dedupEntry),
+		},
+	}
+	for _, lvl := range levels {
+		c.levels[lvl] = true
+	}
+
+	return c
+}
+
+func (c *dedupCore) With(fields []zapcore.Field) zapcore.Core {
+	return &dedupCore{
+		Core:    c.Core.With(fields),
+		levels:  c.levels,
+		state:   c.state,
+		context: c.context + encodeFields(fields),
+	}
+}
+
+func (c *dedupCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.levels[ent.Level] {
+		return c.Core.Check(ent, ce)
+	}
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+func (c *dedupCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if c.state.suppress(c.key(ent, fields), ent, fields, c.Core) {
+		return nil
+	}
+
+	return c.Core.Write(ent, fields)
+}
+
+// Sync logs the summaries of the pending repetitions before syncing the
+// wrapped core, so that they are not lost upon shutdown
+func (c *dedupCore) Sync() error {
+	c.state.flushAll()
+
+	return c.Core.Sync()
+}
+
+// key returns the identity of the entry, i.e., its logger, level, message,
+// context and errors, regardless of its other fields, e.g., the heights
+func (c *dedupCore) key(ent zapcore.Entry, fields []zapcore.Field) string {
+	var sb strings.Builder
+	sb.WriteString(ent.LoggerName)
+	sb.WriteByte(0)
+	sb.WriteString(ent.Level.String())
+	sb.WriteByte(0)
+	sb.WriteString(ent.Message)
+	sb.WriteByte(0)
+	sb.WriteString(c.context)
+	for _, f := range fields {
+		if f.Type != zapcore.ErrorType {
+			continue
+		}
+		if err, ok := f.Interface.(error); ok {
+			sb.WriteByte(0)
+			sb.WriteString(err.Error())
+		}
+	}
+
+	return sb.String()
+}
+
+func encodeFields(fields []zapcore.Field) string {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	return fmt.Sprint(enc.Fields)
+}
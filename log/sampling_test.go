@@ -0,0 +1,233 @@
+package log
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newObservedLogger(cfg SamplingConfig) (*zap.Logger, *observer.ObservedLogs) {
+	core, logs := observer.New(zapcore.DebugLevel)
+
+	return zap.New(wrapSampling(core, cfg)), logs
+}
+
+func TestSamplingPerLevel(t *testing.T) {
+	t.Parallel()
+
+	logger, logs := newObservedLogger(SamplingConfig{
+		Tick: time.Hour,
+		Levels: map[zapcore.Level]LevelSampling{
+			zapcore.DebugLevel: {First: 2, Thereafter: 0},
+			zapcore.InfoLevel:  {First: 1, Thereafter: 3},
+		},
+	})
+
+	for i := 0; i < 10; i++ {
+		logger.Debug("processing block", zap.Int("height", i))
+		logger.Info("committed randomness", zap.Int("height", i))
+		logger.Warn("lagging behind", zap.Int("height", i))
+	}
+	// the entries with another message are sampled apart
+	logger.Debug("another message")
+
+	// the first 2 debug entries, then none
+	require.Equal(t, 2, logs.FilterMessage("processing block").Len())
+	require.Equal(t, 1, logs.FilterMessage("another message").Len())
+	// the first info entry, then one every 3, i.e., the 4th, 7th and 10th
+	infoLogs := logs.FilterMessage("committed randomness").All()
+	require.Len(t, infoLogs, 4)
+	for i, height := range []int64{0, 3, 6, 9} {
+		require.Equal(t, height, infoLogs[i].ContextMap()["height"])
+	}
+	// the levels which are not sampled are logged as is
+	require.Equal(t, 10, logs.FilterMessage("lagging behind").Len())
+}
+
+func TestDedupKey(t *testing.T) {
+	t.Parallel()
+
+	errRPC := errors.New("rpc error")
+	testCases := []struct {
+		name string
+		// log logs the same entry twice, the second one being logged only
+		// if it is not identical to the first
+		log    func(logger *zap.Logger)
+		logged int
+	}{
+		{
+			name: "other fields",
+			log: func(logger *zap.Logger) {
+				logger.Warn("failed to vote", zap.Int("height", 1))
+				logger.Warn("failed to vote", zap.Int("height", 2))
+			},
+			logged: 1,
+		},
+		{
+			name: "same error",
+			log: func(logger *zap.Logger) {
+				logger.Warn("failed to vote", zap.Error(errRPC))
+				logger.Warn("failed to vote", zap.Error(fmt.Errorf("%w", errRPC)))
+			},
+			logged: 1,
+		},
+		{
+			name: "different errors",
+			log: func(logger *zap.Logger) {
+				logger.Warn("failed to vote", zap.Error(errRPC))
+				logger.Warn("failed to vote", zap.Error(errors.New("timeout")))
+			},
+			logged: 2,
+		},
+		{
+			name: "different context",
+			log: func(logger *zap.Logger) {
+				logger.With(zap.String("pk", "a")).Warn("failed to vote")
+				logger.With(zap.String("pk", "b")).Warn("failed to vote")
+			},
+			logged: 2,
+		},
+		{
+			name: "same context",
+			log: func(logger *zap.Logger) {
+				logger.With(zap.String("pk", "a")).Warn("failed to vote")
+				logger.With(zap.String("pk", "a")).Warn("failed to vote")
+			},
+			logged: 1,
+		},
+		{
+			name: "different loggers",
+			log: func(logger *zap.Logger) {
+				logger.Named("a").Warn("failed to vote")
+				logger.Named("b").Warn("failed to vote")
+			},
+			logged: 2,
+		},
+		{
+			name: "different levels",
+			log: func(logger *zap.Logger) {
+				logger.Warn("failed to vote")
+				logger.Error("failed to vote")
+			},
+			logged: 2,
+		},
+		{
+			name: "level not deduplicated",
+			log: func(logger *zap.Logger) {
+				logger.Info("failed to vote")
+				logger.Info("failed to vote")
+			},
+			logged: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger, logs := newObservedLogger(SamplingConfig{
+				DedupLevels: []zapcore.Level{zapcore.WarnLevel, zapcore.ErrorLevel},
+				DedupWindow: time.Hour,
+			})
+			tc.log(logger)
+			require.Equal(t, tc.logged, logs.Len())
+		})
+	}
+}
+
+func TestDedupSummary(t *testing.T) {
+	t.Parallel()
+
+	logger, logs := newObservedLogger(SamplingConfig{
+		DedupLevels: []zapcore.Level{zapcore.WarnLevel},
+		DedupWindow: 100 * time.Millisecond,
+	})
+
+	for i := 1; i <= 4; i++ {
+		logger.Warn("failed to vote", zap.Int("height", i))
+	}
+	require.Equal(t, 1, logs.Len())
+
+	// the last repetition is logged with the number of the repetitions at
+	// the end of the window
+	require.Eventually(t, func() bool {
+		return logs.FilterMessage("failed to vote (repeated 3 times)").Len() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	summary := logs.FilterMessage("failed to vote (repeated 3 times)").All()[0]
+	require.Equal(t, zapcore.WarnLevel, summary.Level)
+	require.Equal(t, int64(4), summary.ContextMap()["height"])
+	require.Equal(t, int64(3), summary.ContextMap()["repeated"])
+
+	// the entry is logged again after the window
+	logger.Warn("failed to vote", zap.Int("height", 5))
+	require.Equal(t, 3, logs.Len())
+
+	// an entry without repetitions has no summary
+	logger.Warn("lagging behind")
+	time.Sleep(200 * time.Millisecond)
+	require.Equal(t, 1, logs.FilterMessage("lagging behind").Len())
+	require.Equal(t, 4, logs.Len())
+}
+
+func TestDedupSync(t *testing.T) {
+	t.Parallel()
+
+	logger, logs := newObservedLogger(SamplingConfig{
+		DedupLevels: []zapcore.Level{zapcore.WarnLevel},
+		DedupWindow: time.Hour,
+	})
+
+	for i := 0; i < 3; i++ {
+		logger.Warn("failed to vote")
+	}
+	logger.Warn("lagging behind")
+	require.Equal(t, 2, logs.Len())
+
+	// the pending summaries are logged by the sync rather than at the end
+	// of the window
+	require.NoError(t, logger.Sync())
+	require.Equal(t, 1, logs.FilterMessage("failed to vote (repeated 2 times)").Len())
+	require.Equal(t, 3, logs.Len())
+
+	// the summaries are logged once
+	require.NoError(t, logger.Sync())
+	require.Equal(t, 3, logs.Len())
+
+	// the flushed entries are logged again
+	logger.Warn("failed to vote")
+	require.Equal(t, 4, logs.Len())
+}
+
+func TestDedupMaxEntries(t *testing.T) {
+	t.Parallel()
+
+	window := 200 * time.Millisecond
+	logger, logs := newObservedLogger(SamplingConfig{
+		DedupLevels: []zapcore.Level{zapcore.WarnLevel},
+		DedupWindow: window,
+	})
+
+	for i := 0; i < maxDedupEntries; i++ {
+		logger.Warn(fmt.Sprintf("message %d", i))
+	}
+	// the entries beyond the max number of tracked ones are logged as is
+	logger.Warn("untracked message")
+	logger.Warn("untracked message")
+	require.Equal(t, 2, logs.FilterMessage("untracked message").Len())
+	// while the tracked ones are still deduplicated
+	logger.Warn("message 0")
+	require.Equal(t, 1, logs.FilterMessage("message 0").Len())
+
+	// the expired entries without repetitions are pruned to make room for
+	// the new ones
+	time.Sleep(window)
+	logger.Warn("new message")
+	logger.Warn("new message")
+	require.Equal(t, 1, logs.FilterMessage("new message").Len())
+}
@@ -0,0 +1,98 @@
+package metrics_test
+
+import (
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/metrics"
+)
+
+var (
+	// promQLIgnored matches the label matchers, the ranges and the strings
+	// of a PromQL expression, which do not hold metric names
+	promQLIgnored = regexp.MustCompile(`\{[^}]*\}|\[[^\]]*\]|"[^"]*"`)
+	promQLIdent   = regexp.MustCompile(`[a-zA-Z_:][a-zA-Z0-9_:]*\s*\(?`)
+	metricName    = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	promQLKeyword = map[string]bool{
+		"and": true, "or": true, "unless": true, "by": true, "without": true,
+		"on": true, "ignoring": true, "group_left": true, "group_right": true,
+		"offset": true, "bool": true,
+	}
+)
+
+// alertRuleMetrics returns the names of the metrics referenced by the
+// expressions of the alerting rules of the given markdown document
+func alertRuleMetrics(t *testing.T, path string) []string {
+	doc, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var names []string
+	inYAML := false
+	for _, line := range strings.Split(string(doc), "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "```yaml":
+			inYAML = true
+		case strings.HasPrefix(trimmed, "```"):
+			inYAML = false
+		case inYAML:
+			expr, ok := strings.CutPrefix(trimmed, "expr:")
+			if !ok {
+				continue
+			}
+			expr = promQLIgnored.ReplaceAllString(expr, " ")
+			for _, ident := range promQLIdent.FindAllString(expr, -1) {
+				ident = strings.TrimSpace(ident)
+				// the functions and the aggregations are followed by their
+				// arguments
+				if strings.HasSuffix(ident, "(") || promQLKeyword[ident] {
+					continue
+				}
+				names = append(names, ident)
+			}
+		}
+	}
+
+	return names
+}
+
+// isRegistered returns whether a metric with the given valid name is
+// registered, in which case a gauge of the same name and another help
+// cannot be
+func isRegistered(name string) bool {
+	probe := prometheus.NewGauge(prometheus.GaugeOpts{Name: name, Help: "probe of the registered metrics"})
+	if err := prometheus.Register(probe); err != nil {
+		return true
+	}
+	prometheus.Unregister(probe)
+
+	return false
+}
+
+// TestAlertRuleMetrics tests that the metrics referenced by the alerting
+// rules of the docs are registered under these names
+func TestAlertRuleMetrics(t *testing.T) {
+	metrics.NewFpMetrics()
+
+	names := alertRuleMetrics(t, "../docs/finality-provider.md")
+	require.NotEmpty(t, names)
+	for _, name := range names {
+		require.Regexp(t, metricName, name)
+		// the series of the histograms are suffixed
+		base := name
+		for _, suffix := range []string{"_bucket", "_sum", "_count"} {
+			if trimmed, ok := strings.CutSuffix(name, suffix); ok && isRegistered(trimmed) {
+				base = trimmed
+			}
+		}
+		require.True(t, isRegistered(base), "the metric %s of the alerting rules is not registered", name)
+	}
+
+	// the probe tells the unregistered metrics apart
+	require.False(t, isRegistered("fp_unregistered_metric"))
+}
@@ -17,6 +17,7 @@ type Config struct {
 	Host           string        `long:"host" description:"IP of the Prometheus server"`
 	Port           int           `long:"port" description:"Port of the Prometheus server"`
 	UpdateInterval time.Duration `long:"updateinterval" description:"The interval of Prometheus metrics updated"`
+	Pprof          bool          `long:"pprof" description:"Serve the goroutine and heap profiles of the process under /debug/pprof/ on the metrics server, e.g., for the debug bundle"`
 }
 
 func (cfg *Config) Validate() error {
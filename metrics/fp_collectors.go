@@ -1,6 +1,7 @@
 package metrics
 
 import (
+	"strconv"
 	"sync"
 	"time"
 
@@ -13,12 +14,37 @@ import (
 type FpMetrics struct {
 	// all finality provider metrics
 	runningFpGauge prometheus.Gauge
+	// maintenanceMode is 1 if the daemon is in the maintenance mode
+	maintenanceMode prometheus.Gauge
+	// clockSkewSeconds is the difference between the local time and the
+	// timestamp of the latest block
+	clockSkewSeconds prometheus.Gauge
+	// finalizationLagBlocks is the number of blocks between the tip and the
+	// last finalized height
+	finalizationLagBlocks prometheus.Gauge
+	// feeAccountBalance and feeAccountRunwayHours track the balances of the
+	// accounts paying the fees and how long they last at the spend rate
+	feeAccountBalance     *prometheus.GaugeVec
+	feeAccountRunwayHours *prometheus.GaugeVec
+	// node health metrics, i.e., of the configured node relative to the
+	// reference node
+	nodeBlockHeight               *prometheus.GaugeVec
+	nodeBlockLag                  prometheus.Gauge
+	nodeRPCDuration               *prometheus.HistogramVec
+	nodeTotalRPCErrors            *prometheus.CounterVec
+	nodeWebsocketConnected        prometheus.Gauge
+	nodeTotalWebsocketDisconnects prometheus.Counter
 	// poller metrics
 	babylonTipHeight     prometheus.Gauge
 	lastPolledHeight     prometheus.Gauge
 	pollerStartingHeight prometheus.Gauge
+	// pollerBufferOccupancy and pollerTotalBackpressureStalls track the
+	// blocks prefetched by the poller ahead of the finality provider
+	pollerBufferOccupancy         prometheus.Gauge
+	pollerTotalBackpressureStalls prometheus.Counter
 	// single finality provider metrics
 	fpStatus                        *prometheus.GaugeVec
+	fpPaused                        *prometheus.GaugeVec
 	fpSecondsSinceLastVote          *prometheus.GaugeVec
 	fpSecondsSinceLastRandomness    *prometheus.GaugeVec
 	fpLastVotedHeight               *prometheus.GaugeVec
@@ -29,10 +55,50 @@ type FpMetrics struct {
 	fpTotalCommittedRandomness      *prometheus.GaugeVec
 	fpTotalFailedVotes              *prometheus.CounterVec
 	fpTotalFailedRandomness         *prometheus.CounterVec
+	fpTotalDoubleSignRefusals       *prometheus.CounterVec
+	fpTotalWithdrawnRewards         *prometheus.CounterVec
+	fpTotalInstanceCrashes          *prometheus.CounterVec
+	fpTotalInstanceRestarts         *prometheus.CounterVec
+	fpTotalEquivocationEvidences    *prometheus.CounterVec
+	fpTotalUnknownVotes             *prometheus.CounterVec
+	fpTotalCriticalErrors           *prometheus.CounterVec
+	fpVoteStageDuration             *prometheus.HistogramVec
+	fpVoteRetryQueueDepth           *prometheus.GaugeVec
+	fpTotalAbandonedVotes           *prometheus.CounterVec
+	fpTotalUnvotedHeights           *prometheus.CounterVec
+	fpSubmissionRateLimited         *prometheus.GaugeVec
+	fpTotalRateLimitedSubmissions   *prometheus.CounterVec
+	fpMissedVotesInWindow           *prometheus.GaugeVec
+	fpParticipationRate             *prometheus.GaugeVec
+	fpRollingParticipationRate      *prometheus.GaugeVec
+	fpDelegations                   *prometheus.GaugeVec
+	fpDelegatedSat                  *prometheus.GaugeVec
+	fpVoteLagBlocks                 *prometheus.GaugeVec
+	// eots signing metrics, i.e., the round trips to the EOTS manager
+	fpEOTSSignDuration    *prometheus.HistogramVec
+	fpTotalEOTSSignErrors *prometheus.CounterVec
+	// alerting metrics, whose names are kept stable for the alerting rules
+	fpConsecutiveMissedHeights    *prometheus.GaugeVec
+	fpConsecutiveFailedBroadcasts *prometheus.GaugeVec
+	fpPubRandRunwayHeights        *prometheus.GaugeVec
+	// public randomness commitment metrics
+	fpLastPubRandCommitmentSize   *prometheus.GaugeVec
+	fpTotalPubRandCommitments     *prometheus.CounterVec
+	fpTotalFailedPubRandCommitTxs *prometheus.CounterVec
+	fpPubRandUsedRatio            *prometheus.GaugeVec
+	// rpc metrics
+	rpcTotalRequests   *prometheus.CounterVec
+	rpcRequestDuration *prometheus.HistogramVec
+	rpcTotalPanics     *prometheus.CounterVec
 	// time keeper
 	mu                     sync.Mutex
 	previousVoteByFp       map[string]*time.Time
 	previousRandomnessByFp map[string]*time.Time
+	// votedBlocksByFp and committedRandomnessByFp are the numbers of the
+	// voted blocks and of the committed randomness since the start, from
+	// which the ratio of the used randomness is derived
+	votedBlocksByFp         map[string]float64
+	committedRandomnessByFp map[string]float64
 }
 
 // Declare a package-level variable for sync.Once to ensure metrics are registered only once
@@ -49,10 +115,59 @@ func NewFpMetrics() *FpMetrics {
 				Name: "total_running_fps",
 				Help: "Current number of finality providers that are running",
 			}),
+			maintenanceMode: prometheus.NewGauge(prometheus.GaugeOpts{
+				Name: "maintenance_mode",
+				Help: "Whether the daemon is in the maintenance mode, in which the chain submissions and the db writes are frozen",
+			}),
+			clockSkewSeconds: prometheus.NewGauge(prometheus.GaugeOpts{
+				Name: "clock_skew_seconds",
+				Help: "The difference between the local time and the timestamp of the latest block, positive if the local clock is ahead",
+			}),
+			finalizationLagBlocks: prometheus.NewGauge(prometheus.GaugeOpts{
+				Name: "finalization_lag_blocks",
+				Help: "The number of blocks between the tip and the last finalized height",
+			}),
+			feeAccountBalance: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "fee_account_balance",
+				Help: "The balance of an account paying the fees, i.e., the key signing the transactions or a fee payer, by denom",
+			}, []string{"address", "denom"}),
+			feeAccountRunwayHours: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "fee_account_runway_hours",
+				Help: "The number of hours the balance of an account paying the fees lasts at its recent spend rate, by denom",
+			}, []string{"address", "denom"}),
+			nodeBlockHeight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "node_block_height",
+				Help: "The latest block height of the Babylon node, by endpoint: node for the configured node and reference for the reference node",
+			}, []string{"endpoint"}),
+			nodeBlockLag: prometheus.NewGauge(prometheus.GaugeOpts{
+				Name: "node_block_lag",
+				Help: "The number of blocks the configured Babylon node is behind the reference node",
+			}),
+			nodeRPCDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+				Name:    "node_rpc_duration_seconds",
+				Help:    "The round-trip time of the latest height queries to the Babylon node, by endpoint: node or reference",
+				Buckets: prometheus.ExponentialBuckets(0.001, 2, 14),
+			}, []string{"endpoint"}),
+			nodeTotalRPCErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+				Name: "node_total_rpc_errors",
+				Help: "The total number of the failed latest height queries to the Babylon node, by endpoint: node or reference",
+			}, []string{"endpoint"}),
+			nodeWebsocketConnected: prometheus.NewGauge(prometheus.GaugeOpts{
+				Name: "node_websocket_connected",
+				Help: "Whether the websocket connection to the configured Babylon node is up (1) or not (0)",
+			}),
+			nodeTotalWebsocketDisconnects: prometheus.NewCounter(prometheus.CounterOpts{
+				Name: "node_total_websocket_disconnects",
+				Help: "The total number of the disconnections of the websocket connection to the configured Babylon node",
+			}),
 			fpStatus: prometheus.NewGaugeVec(prometheus.GaugeOpts{
 				Name: "fp_status",
 				Help: "Current status of a finality provider",
 			}, []string{"fp_btc_pk_hex"}),
+			fpPaused: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "fp_paused",
+				Help: "Whether the voting of a finality provider is paused (1) or not (0)",
+			}, []string{"fp_btc_pk_hex"}),
 			babylonTipHeight: prometheus.NewGauge(prometheus.GaugeOpts{
 				Name: "babylon_tip_height",
 				Help: "The current tip height of the Babylon network",
@@ -65,6 +180,14 @@ func NewFpMetrics() *FpMetrics {
 				Name: "poller_starting_height",
 				Help: "The initial block height when the poller started operation",
 			}),
+			pollerBufferOccupancy: prometheus.NewGauge(prometheus.GaugeOpts{
+				Name: "poller_buffer_occupancy",
+				Help: "The number of the blocks prefetched by the poller which are not yet pulled by the finality provider",
+			}),
+			pollerTotalBackpressureStalls: prometheus.NewCounter(prometheus.CounterOpts{
+				Name: "poller_total_backpressure_stalls",
+				Help: "The total number of the polling cycles skipped by the poller as its buffer is full",
+			}),
 			fpSecondsSinceLastVote: prometheus.NewGaugeVec(
 				prometheus.GaugeOpts{
 					Name: "fp_seconds_since_last_vote",
@@ -135,15 +258,249 @@ func NewFpMetrics() *FpMetrics {
 				},
 				[]string{"fp_btc_pk_hex"},
 			),
+			fpTotalDoubleSignRefusals: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Name: "fp_total_double_sign_refusals",
+					Help: "The total number of refusals to sign a conflicting finality vote by a finality provider.",
+				},
+				[]string{"fp_btc_pk_hex"},
+			),
+			fpTotalWithdrawnRewards: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Name: "fp_total_withdrawn_rewards",
+					Help: "The total amount of finality provider rewards withdrawn by the daemon.",
+				},
+				[]string{"denom"},
+			),
+			fpTotalInstanceCrashes: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Name: "fp_total_instance_crashes",
+					Help: "The total number of crashes of the instance of a finality provider due to an unexpected error or a panic.",
+				},
+				[]string{"fp_btc_pk_hex"},
+			),
+			fpTotalInstanceRestarts: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Name: "fp_total_instance_restarts",
+					Help: "The total number of restarts of the instance of a finality provider by the supervisor.",
+				},
+				[]string{"fp_btc_pk_hex"},
+			),
+			fpTotalEquivocationEvidences: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Name: "fp_total_equivocation_evidences",
+					Help: "The total number of equivocation evidences found on-chain against a finality provider, own is true for the finality providers managed by the daemon.",
+				},
+				[]string{"fp_btc_pk_hex", "own"},
+			),
+			fpTotalUnknownVotes: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Name: "fp_total_unknown_votes",
+					Help: "The total number of on-chain votes of a finality provider which have not been signed by the daemon.",
+				},
+				[]string{"fp_btc_pk_hex"},
+			),
+			fpTotalCriticalErrors: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Name: "fp_total_critical_errors",
+					Help: "The total number of critical errors reported by a finality provider instance, by class and applied policy.",
+				},
+				[]string{"fp_btc_pk_hex", "class", "policy"},
+			),
+			fpVoteStageDuration: prometheus.NewHistogramVec(
+				prometheus.HistogramOpts{
+					Name:    "fp_vote_stage_duration_seconds",
+					Help:    "The time spent by the vote of a finality provider for a height in each stage of the pipeline: queue, randomness, signing, broadcast, and total from the block receipt to the confirmation.",
+					Buckets: prometheus.ExponentialBuckets(0.01, 2, 14),
+				},
+				[]string{"fp_btc_pk_hex", "stage"},
+			),
+			fpVoteRetryQueueDepth: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Name: "fp_vote_retry_queue_depth",
+					Help: "The number of the failed votes of a finality provider queued to be retried.",
+				},
+				[]string{"fp_btc_pk_hex"},
+			),
+			fpTotalAbandonedVotes: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Name: "fp_total_abandoned_votes",
+					Help: "The total number of the queued failed votes of a finality provider which are abandoned, by reason.",
+				},
+				[]string{"fp_btc_pk_hex", "reason"},
+			),
+			fpTotalUnvotedHeights: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Name: "fp_total_unvoted_heights",
+					Help: "The total number of the heights which a finality provider did not vote, by reason.",
+				},
+				[]string{"fp_btc_pk_hex", "reason"},
+			),
+			fpSubmissionRateLimited: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Name: "fp_submission_rate_limited",
+					Help: "Whether the submissions of a finality provider are held back by the submission rate limiter (1) or not (0).",
+				},
+				[]string{"fp_btc_pk_hex"},
+			),
+			fpTotalRateLimitedSubmissions: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Name: "fp_total_rate_limited_submissions",
+					Help: "The total number of the submissions of a finality provider rejected by the submission rate limiter, by submission.",
+				},
+				[]string{"fp_btc_pk_hex", "submission"},
+			),
+			fpMissedVotesInWindow: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Name: "fp_missed_votes_in_window",
+					Help: "The number of the missed votes of a finality provider within the window of the last participation report, by probable reason.",
+				},
+				[]string{"fp_btc_pk_hex", "reason"},
+			),
+			fpParticipationRate: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Name: "fp_participation_rate",
+					Help: "The ratio of the heights voted on-chain to the heights with voting power of a finality provider within the window of the last participation report.",
+				},
+				[]string{"fp_btc_pk_hex"},
+			),
+			fpRollingParticipationRate: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Name: "fp_rolling_participation_rate",
+					Help: "The ratio of the voted heights to the heights with voting power of a running finality provider within a rolling window, by window.",
+				},
+				[]string{"fp_btc_pk_hex", "window"},
+			),
+			fpDelegations: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Name: "fp_delegations",
+					Help: "The number of the BTC delegations to a finality provider, by status.",
+				},
+				[]string{"fp_btc_pk_hex", "status"},
+			),
+			fpDelegatedSat: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Name: "fp_delegated_sat",
+					Help: "The total amount of the BTC delegations to a finality provider in satoshis, by status.",
+				},
+				[]string{"fp_btc_pk_hex", "status"},
+			),
+			fpVoteLagBlocks: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Name: "fp_vote_lag_blocks",
+					Help: "The number of blocks between the tip and the last voted height of a finality provider.",
+				},
+				[]string{"fp_btc_pk_hex"},
+			),
+			rpcTotalRequests: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Name: "rpc_total_requests",
+					Help: "The total number of the RPC requests handled by the daemon, by method and status code.",
+				},
+				[]string{"method", "code"},
+			),
+			rpcRequestDuration: prometheus.NewHistogramVec(
+				prometheus.HistogramOpts{
+					Name:    "rpc_request_duration_seconds",
+					Help:    "The time spent handling the RPC requests, by method.",
+					Buckets: prometheus.ExponentialBuckets(0.001, 2, 16),
+				},
+				[]string{"method"},
+			),
+			fpEOTSSignDuration: prometheus.NewHistogramVec(
+				prometheus.HistogramOpts{
+					Name:    "fp_eots_sign_duration_seconds",
+					Help:    "The round-trip time of the signing requests of a finality provider to the EOTS manager, by method: SignEOTS, SignEOTSBatch and SignSchnorrSig.",
+					Buckets: prometheus.ExponentialBuckets(0.001, 2, 14),
+				},
+				[]string{"fp_btc_pk_hex", "method"},
+			),
+			fpTotalEOTSSignErrors: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Name: "fp_total_eots_sign_errors",
+					Help: "The total number of the failed signing requests of a finality provider to the EOTS manager, by method and type of error, e.g., unavailable or deadline_exceeded.",
+				},
+				[]string{"fp_btc_pk_hex", "method", "type"},
+			),
+			fpConsecutiveMissedHeights: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Name: "fp_consecutive_missed_heights",
+					Help: "The number of the consecutive heights for which the vote of a finality provider has failed or has been skipped while paused, reset once a vote is included.",
+				},
+				[]string{"fp_btc_pk_hex"},
+			),
+			fpConsecutiveFailedBroadcasts: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Name: "fp_consecutive_failed_broadcasts",
+					Help: "The number of the consecutive failed broadcasts of the transactions of a finality provider, by submission: vote or pubrand_commit, reset once a transaction is included.",
+				},
+				[]string{"fp_btc_pk_hex", "submission"},
+			),
+			fpPubRandRunwayHeights: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Name: "fp_pub_rand_runway_heights",
+					Help: "The number of the heights above the tip for which a finality provider has committed public randomness, it cannot vote once it reaches 0.",
+				},
+				[]string{"fp_btc_pk_hex"},
+			),
+			fpLastPubRandCommitmentSize: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Name: "fp_last_pub_rand_commitment_size",
+					Help: "The number of the public randomness values, i.e., of the heights, of the last commitment of a finality provider.",
+				},
+				[]string{"fp_btc_pk_hex"},
+			),
+			fpTotalPubRandCommitments: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Name: "fp_total_pub_rand_commitments",
+					Help: "The total number of the public randomness commitments of a finality provider included on the chain.",
+				},
+				[]string{"fp_btc_pk_hex"},
+			),
+			fpTotalFailedPubRandCommitTxs: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Name: "fp_total_failed_pub_rand_commit_txs",
+					Help: "The total number of the failed broadcasts of the public randomness commitments of a finality provider, each retry being counted.",
+				},
+				[]string{"fp_btc_pk_hex"},
+			),
+			fpPubRandUsedRatio: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Name: "fp_pub_rand_used_ratio",
+					Help: "The ratio of the voted blocks to the committed public randomness of a finality provider since the daemon started.",
+				},
+				[]string{"fp_btc_pk_hex"},
+			),
+			rpcTotalPanics: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Name: "rpc_total_panics",
+					Help: "The total number of the panics of the RPC handlers recovered by the daemon, by method.",
+				},
+				[]string{"method"},
+			),
 			mu: sync.Mutex{},
 		}
 
 		// Register the metrics with Prometheus
 		prometheus.MustRegister(fpMetricsInstance.runningFpGauge)
+		prometheus.MustRegister(fpMetricsInstance.maintenanceMode)
+		prometheus.MustRegister(fpMetricsInstance.clockSkewSeconds)
+		prometheus.MustRegister(fpMetricsInstance.finalizationLagBlocks)
+		prometheus.MustRegister(fpMetricsInstance.feeAccountBalance)
+		prometheus.MustRegister(fpMetricsInstance.feeAccountRunwayHours)
+		prometheus.MustRegister(fpMetricsInstance.nodeBlockHeight)
+		prometheus.MustRegister(fpMetricsInstance.nodeBlockLag)
+		prometheus.MustRegister(fpMetricsInstance.nodeRPCDuration)
+		prometheus.MustRegister(fpMetricsInstance.nodeTotalRPCErrors)
+		prometheus.MustRegister(fpMetricsInstance.nodeWebsocketConnected)
+		prometheus.MustRegister(fpMetricsInstance.nodeTotalWebsocketDisconnects)
 		prometheus.MustRegister(fpMetricsInstance.fpStatus)
+		prometheus.MustRegister(fpMetricsInstance.fpPaused)
 		prometheus.MustRegister(fpMetricsInstance.babylonTipHeight)
 		prometheus.MustRegister(fpMetricsInstance.lastPolledHeight)
 		prometheus.MustRegister(fpMetricsInstance.pollerStartingHeight)
+		prometheus.MustRegister(fpMetricsInstance.pollerBufferOccupancy)
+		prometheus.MustRegister(fpMetricsInstance.pollerTotalBackpressureStalls)
 		prometheus.MustRegister(fpMetricsInstance.fpSecondsSinceLastVote)
 		prometheus.MustRegister(fpMetricsInstance.fpSecondsSinceLastRandomness)
 		prometheus.MustRegister(fpMetricsInstance.fpLastVotedHeight)
@@ -154,6 +511,37 @@ func NewFpMetrics() *FpMetrics {
 		prometheus.MustRegister(fpMetricsInstance.fpLastCommittedRandomnessHeight)
 		prometheus.MustRegister(fpMetricsInstance.fpTotalFailedVotes)
 		prometheus.MustRegister(fpMetricsInstance.fpTotalFailedRandomness)
+		prometheus.MustRegister(fpMetricsInstance.fpTotalDoubleSignRefusals)
+		prometheus.MustRegister(fpMetricsInstance.fpTotalWithdrawnRewards)
+		prometheus.MustRegister(fpMetricsInstance.fpTotalInstanceCrashes)
+		prometheus.MustRegister(fpMetricsInstance.fpTotalInstanceRestarts)
+		prometheus.MustRegister(fpMetricsInstance.fpTotalEquivocationEvidences)
+		prometheus.MustRegister(fpMetricsInstance.fpTotalUnknownVotes)
+		prometheus.MustRegister(fpMetricsInstance.fpTotalCriticalErrors)
+		prometheus.MustRegister(fpMetricsInstance.fpVoteStageDuration)
+		prometheus.MustRegister(fpMetricsInstance.fpVoteRetryQueueDepth)
+		prometheus.MustRegister(fpMetricsInstance.fpTotalAbandonedVotes)
+		prometheus.MustRegister(fpMetricsInstance.fpTotalUnvotedHeights)
+		prometheus.MustRegister(fpMetricsInstance.fpSubmissionRateLimited)
+		prometheus.MustRegister(fpMetricsInstance.fpTotalRateLimitedSubmissions)
+		prometheus.MustRegister(fpMetricsInstance.fpMissedVotesInWindow)
+		prometheus.MustRegister(fpMetricsInstance.fpParticipationRate)
+		prometheus.MustRegister(fpMetricsInstance.fpRollingParticipationRate)
+		prometheus.MustRegister(fpMetricsInstance.fpDelegations)
+		prometheus.MustRegister(fpMetricsInstance.fpDelegatedSat)
+		prometheus.MustRegister(fpMetricsInstance.fpVoteLagBlocks)
+		prometheus.MustRegister(fpMetricsInstance.fpEOTSSignDuration)
+		prometheus.MustRegister(fpMetricsInstance.fpTotalEOTSSignErrors)
+		prometheus.MustRegister(fpMetricsInstance.fpConsecutiveMissedHeights)
+		prometheus.MustRegister(fpMetricsInstance.fpConsecutiveFailedBroadcasts)
+		prometheus.MustRegister(fpMetricsInstance.fpPubRandRunwayHeights)
+		prometheus.MustRegister(fpMetricsInstance.fpLastPubRandCommitmentSize)
+		prometheus.MustRegister(fpMetricsInstance.fpTotalPubRandCommitments)
+		prometheus.MustRegister(fpMetricsInstance.fpTotalFailedPubRandCommitTxs)
+		prometheus.MustRegister(fpMetricsInstance.fpPubRandUsedRatio)
+		prometheus.MustRegister(fpMetricsInstance.rpcTotalRequests)
+		prometheus.MustRegister(fpMetricsInstance.rpcRequestDuration)
+		prometheus.MustRegister(fpMetricsInstance.rpcTotalPanics)
 	})
 	return fpMetricsInstance
 }
@@ -173,6 +561,133 @@ func (fm *FpMetrics) RecordFpStatus(fpBtcPkHex string, status proto.FinalityProv
 	fm.fpStatus.WithLabelValues(fpBtcPkHex).Set(float64(status))
 }
 
+// RecordFpPaused records whether the voting of a finality provider is paused
+func (fm *FpMetrics) RecordFpPaused(fpBtcPkHex string, paused bool) {
+	var v float64
+	if paused {
+		v = 1
+	}
+	fm.fpPaused.WithLabelValues(fpBtcPkHex).Set(v)
+}
+
+// RecordFpSubmissionRateLimited records whether the submissions of a finality
+// provider are held back by the submission rate limiter
+func (fm *FpMetrics) RecordFpSubmissionRateLimited(fpBtcPkHex string, limited bool) {
+	var v float64
+	if limited {
+		v = 1
+	}
+	fm.fpSubmissionRateLimited.WithLabelValues(fpBtcPkHex).Set(v)
+}
+
+// IncrementFpTotalRateLimitedSubmissions increments the total number of the
+// submissions of a finality provider rejected by the submission rate limiter
+func (fm *FpMetrics) IncrementFpTotalRateLimitedSubmissions(fpBtcPkHex, submission string) {
+	fm.fpTotalRateLimitedSubmissions.WithLabelValues(fpBtcPkHex, submission).Inc()
+}
+
+// RecordFpMissedVotesInWindow records the number of the missed votes of a
+// finality provider for the given reason within the participation report window
+func (fm *FpMetrics) RecordFpMissedVotesInWindow(fpBtcPkHex, reason string, n int) {
+	fm.fpMissedVotesInWindow.WithLabelValues(fpBtcPkHex, reason).Set(float64(n))
+}
+
+// RecordFpParticipationRate records the participation rate of a finality
+// provider within the participation report window
+func (fm *FpMetrics) RecordFpParticipationRate(fpBtcPkHex string, rate float64) {
+	fm.fpParticipationRate.WithLabelValues(fpBtcPkHex).Set(rate)
+}
+
+// RecordFpRollingParticipationRate records the participation rate of a
+// finality provider within the given rolling window
+func (fm *FpMetrics) RecordFpRollingParticipationRate(fpBtcPkHex, window string, rate float64) {
+	fm.fpRollingParticipationRate.WithLabelValues(fpBtcPkHex, window).Set(rate)
+}
+
+// RemoveFpRollingParticipationRate removes the participation rate of a
+// finality provider within the given rolling window, e.g., as no height with
+// voting power falls within it
+func (fm *FpMetrics) RemoveFpRollingParticipationRate(fpBtcPkHex, window string) {
+	fm.fpRollingParticipationRate.DeleteLabelValues(fpBtcPkHex, window)
+}
+
+// RecordFpDelegations records the number and the total amount of the BTC
+// delegations to a finality provider with the given status
+func (fm *FpMetrics) RecordFpDelegations(fpBtcPkHex, status string, num, sat uint64) {
+	fm.fpDelegations.WithLabelValues(fpBtcPkHex, status).Set(float64(num))
+	fm.fpDelegatedSat.WithLabelValues(fpBtcPkHex, status).Set(float64(sat))
+}
+
+// RecordFinalizationLag records the number of blocks between the tip and the last finalized height
+func (fm *FpMetrics) RecordFinalizationLag(lag uint64) {
+	fm.finalizationLagBlocks.Set(float64(lag))
+}
+
+// RecordFpVoteLag records the number of blocks between the tip and the last
+// voted height of a finality provider
+func (fm *FpMetrics) RecordFpVoteLag(fpBtcPkHex string, lag uint64) {
+	fm.fpVoteLagBlocks.WithLabelValues(fpBtcPkHex).Set(float64(lag))
+}
+
+// RecordMaintenanceMode records whether the daemon is in the maintenance mode
+func (fm *FpMetrics) RecordMaintenanceMode(active bool) {
+	var v float64
+	if active {
+		v = 1
+	}
+	fm.maintenanceMode.Set(v)
+}
+
+// RecordFeeAccountBalance records the balance of the given denom of an account paying the fees
+func (fm *FpMetrics) RecordFeeAccountBalance(address, denom string, amount float64) {
+	fm.feeAccountBalance.WithLabelValues(address, denom).Set(amount)
+}
+
+// RecordFeeAccountRunway records how long the balance of the given denom of an account paying the fees
+// lasts at its recent spend rate
+func (fm *FpMetrics) RecordFeeAccountRunway(address, denom string, hours float64) {
+	fm.feeAccountRunwayHours.WithLabelValues(address, denom).Set(hours)
+}
+
+// RecordNodeBlockHeight records the latest block height of the given endpoint of the Babylon node
+func (fm *FpMetrics) RecordNodeBlockHeight(endpoint string, height uint64) {
+	fm.nodeBlockHeight.WithLabelValues(endpoint).Set(float64(height))
+}
+
+// RecordNodeBlockLag records the number of blocks the configured Babylon node is behind the reference node
+func (fm *FpMetrics) RecordNodeBlockLag(lag uint64) {
+	fm.nodeBlockLag.Set(float64(lag))
+}
+
+// ObserveNodeRPC records the round-trip time of a latest height query to the given endpoint of the Babylon node
+// along with its failure, if any
+func (fm *FpMetrics) ObserveNodeRPC(endpoint string, d time.Duration, failed bool) {
+	fm.nodeRPCDuration.WithLabelValues(endpoint).Observe(d.Seconds())
+	if failed {
+		fm.nodeTotalRPCErrors.WithLabelValues(endpoint).Inc()
+	}
+}
+
+// RecordNodeWebsocketConnected records whether the websocket connection to the Babylon node is up
+func (fm *FpMetrics) RecordNodeWebsocketConnected(connected bool) {
+	var v float64
+	if connected {
+		v = 1
+	}
+	fm.nodeWebsocketConnected.Set(v)
+}
+
+// IncrementNodeTotalWebsocketDisconnects increments the number of the disconnections of the websocket
+// connection to the Babylon node
+func (fm *FpMetrics) IncrementNodeTotalWebsocketDisconnects() {
+	fm.nodeTotalWebsocketDisconnects.Inc()
+}
+
+// RecordClockSkew records the difference between the local time and the timestamp of the latest block
+func (fm *FpMetrics) RecordClockSkew(skew time.Duration) {
+	fm.clockSkewSeconds.Set(skew.Seconds())
+}
+
 // RecordBabylonTipHeight records the current tip height of the Babylon network
 func (fm *FpMetrics) RecordBabylonTipHeight(height uint64) {
 	fm.babylonTipHeight.Set(float64(height))
@@ -188,6 +703,16 @@ func (fm *FpMetrics) RecordPollerStartingHeight(height uint64) {
 	fm.pollerStartingHeight.Set(float64(height))
 }
 
+// RecordPollerBufferOccupancy records the number of the blocks prefetched by the poller which are not yet pulled
+func (fm *FpMetrics) RecordPollerBufferOccupancy(numBlocks int) {
+	fm.pollerBufferOccupancy.Set(float64(numBlocks))
+}
+
+// IncrementPollerTotalBackpressureStalls increments the total number of the polling cycles skipped as the buffer is full
+func (fm *FpMetrics) IncrementPollerTotalBackpressureStalls() {
+	fm.pollerTotalBackpressureStalls.Inc()
+}
+
 // RecordFpSecondsSinceLastVote records the seconds since the last finality sig vote by a finality provider
 func (fm *FpMetrics) RecordFpSecondsSinceLastVote(fpBtcPkHex string, seconds float64) {
 	fm.fpSecondsSinceLastVote.WithLabelValues(fpBtcPkHex).Set(seconds)
@@ -220,17 +745,54 @@ func (fm *FpMetrics) IncrementFpTotalBlocksWithoutVotingPower(fpBtcPkHex string)
 
 // IncrementFpTotalVotedBlocks increments the total number of blocks voted by a finality provider
 func (fm *FpMetrics) IncrementFpTotalVotedBlocks(fpBtcPkHex string) {
-	fm.fpTotalVotedBlocks.WithLabelValues(fpBtcPkHex).Inc()
+	fm.AddToFpTotalVotedBlocks(fpBtcPkHex, 1)
 }
 
 // AddToFpTotalVotedBlocks adds a number to the total number of blocks voted by a finality provider
 func (fm *FpMetrics) AddToFpTotalVotedBlocks(fpBtcPkHex string, num float64) {
 	fm.fpTotalVotedBlocks.WithLabelValues(fpBtcPkHex).Add(num)
+
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	if fm.votedBlocksByFp == nil {
+		fm.votedBlocksByFp = make(map[string]float64)
+	}
+	fm.votedBlocksByFp[fpBtcPkHex] += num
+	fm.recordFpPubRandUsedRatio(fpBtcPkHex)
 }
 
 // AddToFpTotalCommittedRandomness adds a number to the total number of randomness commitments by a finality provider
 func (fm *FpMetrics) AddToFpTotalCommittedRandomness(fpBtcPkHex string, num float64) {
 	fm.fpTotalCommittedRandomness.WithLabelValues(fpBtcPkHex).Add(num)
+
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	if fm.committedRandomnessByFp == nil {
+		fm.committedRandomnessByFp = make(map[string]float64)
+	}
+	fm.committedRandomnessByFp[fpBtcPkHex] += num
+	fm.recordFpPubRandUsedRatio(fpBtcPkHex)
+}
+
+// recordFpPubRandUsedRatio records the ratio of the voted blocks to the
+// committed randomness of a finality provider, fm.mu must be held
+func (fm *FpMetrics) recordFpPubRandUsedRatio(fpBtcPkHex string) {
+	committed := fm.committedRandomnessByFp[fpBtcPkHex]
+	if committed == 0 {
+		return
+	}
+	fm.fpPubRandUsedRatio.WithLabelValues(fpBtcPkHex).Set(fm.votedBlocksByFp[fpBtcPkHex] / committed)
+}
+
+// RecordFpPubRandCommitment records a public randomness commitment of the given size included for a finality provider
+func (fm *FpMetrics) RecordFpPubRandCommitment(fpBtcPkHex string, numPubRand uint64) {
+	fm.fpLastPubRandCommitmentSize.WithLabelValues(fpBtcPkHex).Set(float64(numPubRand))
+	fm.fpTotalPubRandCommitments.WithLabelValues(fpBtcPkHex).Inc()
+}
+
+// IncrementFpTotalFailedPubRandCommitTxs increments the total number of the failed broadcasts of the public randomness commitments of a finality provider
+func (fm *FpMetrics) IncrementFpTotalFailedPubRandCommitTxs(fpBtcPkHex string) {
+	fm.fpTotalFailedPubRandCommitTxs.WithLabelValues(fpBtcPkHex).Inc()
 }
 
 // IncrementFpTotalFailedVotes increments the total number of failed votes by a finality provider
@@ -243,6 +805,86 @@ func (fm *FpMetrics) IncrementFpTotalFailedRandomness(fpBtcPkHex string) {
 	fm.fpTotalFailedRandomness.WithLabelValues(fpBtcPkHex).Inc()
 }
 
+// IncrementFpTotalDoubleSignRefusals increments the total number of refusals to sign a conflicting finality vote by a finality provider
+func (fm *FpMetrics) IncrementFpTotalDoubleSignRefusals(fpBtcPkHex string) {
+	fm.fpTotalDoubleSignRefusals.WithLabelValues(fpBtcPkHex).Inc()
+}
+
+// AddWithdrawnRewards adds the amount of the given denom to the total withdrawn finality provider rewards
+func (fm *FpMetrics) AddWithdrawnRewards(denom string, amount float64) {
+	fm.fpTotalWithdrawnRewards.WithLabelValues(denom).Add(amount)
+}
+
+// IncrementFpTotalInstanceCrashes increments the total number of crashes of the instance of a finality provider
+func (fm *FpMetrics) IncrementFpTotalInstanceCrashes(fpBtcPkHex string) {
+	fm.fpTotalInstanceCrashes.WithLabelValues(fpBtcPkHex).Inc()
+}
+
+// IncrementFpTotalInstanceRestarts increments the total number of restarts of the instance of a finality provider
+func (fm *FpMetrics) IncrementFpTotalInstanceRestarts(fpBtcPkHex string) {
+	fm.fpTotalInstanceRestarts.WithLabelValues(fpBtcPkHex).Inc()
+}
+
+// IncrementFpTotalEquivocationEvidences increments the total number of equivocation evidences against a finality provider
+func (fm *FpMetrics) IncrementFpTotalEquivocationEvidences(fpBtcPkHex string, own bool) {
+	fm.fpTotalEquivocationEvidences.WithLabelValues(fpBtcPkHex, strconv.FormatBool(own)).Inc()
+}
+
+// IncrementFpTotalUnknownVotes increments the total number of on-chain votes of a finality provider not signed by the daemon
+func (fm *FpMetrics) IncrementFpTotalUnknownVotes(fpBtcPkHex string) {
+	fm.fpTotalUnknownVotes.WithLabelValues(fpBtcPkHex).Inc()
+}
+
+// IncrementFpTotalCriticalErrors increments the total number of critical errors of a finality provider of the given class handled with the given policy
+func (fm *FpMetrics) IncrementFpTotalCriticalErrors(fpBtcPkHex, class, policy string) {
+	fm.fpTotalCriticalErrors.WithLabelValues(fpBtcPkHex, class, policy).Inc()
+}
+
+// RecordFpVoteStageDuration records the time spent by the vote of a finality provider for a height in the given stage of the pipeline
+func (fm *FpMetrics) RecordFpVoteStageDuration(fpBtcPkHex, stage string, d time.Duration) {
+	fm.fpVoteStageDuration.WithLabelValues(fpBtcPkHex, stage).Observe(d.Seconds())
+}
+
+// RecordFpEOTSSignDuration records the round-trip time of a signing request of a finality provider to the EOTS manager
+func (fm *FpMetrics) RecordFpEOTSSignDuration(fpBtcPkHex, method string, d time.Duration) {
+	fm.fpEOTSSignDuration.WithLabelValues(fpBtcPkHex, method).Observe(d.Seconds())
+}
+
+// IncrementFpTotalEOTSSignErrors increments the total number of the failed signing requests of a finality provider to the EOTS manager
+func (fm *FpMetrics) IncrementFpTotalEOTSSignErrors(fpBtcPkHex, method, errType string) {
+	fm.fpTotalEOTSSignErrors.WithLabelValues(fpBtcPkHex, method, errType).Inc()
+}
+
+// RecordFpConsecutiveMissedHeights records the number of the consecutive heights missed by a finality provider
+func (fm *FpMetrics) RecordFpConsecutiveMissedHeights(fpBtcPkHex string, n uint64) {
+	fm.fpConsecutiveMissedHeights.WithLabelValues(fpBtcPkHex).Set(float64(n))
+}
+
+// RecordFpConsecutiveFailedBroadcasts records the number of the consecutive failed broadcasts of the given submission of a finality provider
+func (fm *FpMetrics) RecordFpConsecutiveFailedBroadcasts(fpBtcPkHex, submission string, n uint64) {
+	fm.fpConsecutiveFailedBroadcasts.WithLabelValues(fpBtcPkHex, submission).Set(float64(n))
+}
+
+// RecordFpPubRandRunway records the number of the heights above the tip for which a finality provider has committed public randomness
+func (fm *FpMetrics) RecordFpPubRandRunway(fpBtcPkHex string, heights uint64) {
+	fm.fpPubRandRunwayHeights.WithLabelValues(fpBtcPkHex).Set(float64(heights))
+}
+
+// RecordFpVoteRetryQueueDepth records the number of the failed votes of a finality provider queued to be retried
+func (fm *FpMetrics) RecordFpVoteRetryQueueDepth(fpBtcPkHex string, depth int) {
+	fm.fpVoteRetryQueueDepth.WithLabelValues(fpBtcPkHex).Set(float64(depth))
+}
+
+// IncrementFpTotalAbandonedVotes increments the total number of the queued failed votes of a finality provider which are abandoned
+func (fm *FpMetrics) IncrementFpTotalAbandonedVotes(fpBtcPkHex, reason string) {
+	fm.fpTotalAbandonedVotes.WithLabelValues(fpBtcPkHex, reason).Inc()
+}
+
+// AddToFpTotalUnvotedHeights adds to the total number of the heights which a finality provider did not vote for the given reason
+func (fm *FpMetrics) AddToFpTotalUnvotedHeights(fpBtcPkHex, reason string, n int) {
+	fm.fpTotalUnvotedHeights.WithLabelValues(fpBtcPkHex, reason).Add(float64(n))
+}
+
 // RecordFpVoteTime records the time of a finality sig vote by a finality provider
 func (fm *FpMetrics) RecordFpVoteTime(fpBtcPkHex string) {
 	fm.mu.Lock()
@@ -269,6 +911,19 @@ func (fm *FpMetrics) RecordFpRandomnessTime(fpBtcPkHex string) {
 	fm.previousRandomnessByFp[fpBtcPkHex] = &now
 }
 
+// ObserveRPCRequest records an RPC request handled by the daemon with the
+// given status code and the time spent handling it
+func (fm *FpMetrics) ObserveRPCRequest(method, code string, d time.Duration) {
+	fm.rpcTotalRequests.WithLabelValues(method, code).Inc()
+	fm.rpcRequestDuration.WithLabelValues(method).Observe(d.Seconds())
+}
+
+// IncrementRPCTotalPanics increments the number of the recovered panics of
+// the handlers of the given RPC method
+func (fm *FpMetrics) IncrementRPCTotalPanics(method string) {
+	fm.rpcTotalPanics.WithLabelValues(method).Inc()
+}
+
 func (fm *FpMetrics) UpdateFpMetrics(fps []*store.StoredFinalityProvider) {
 	fm.mu.Lock()
 	defer fm.mu.Unlock()
@@ -3,6 +3,7 @@ package metrics
 import (
 	"context"
 	"net/http"
+	"net/http/pprof"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -15,9 +16,16 @@ type Server struct {
 	logger     *zap.Logger
 }
 
-func Start(addr string, logger *zap.Logger) *Server {
+// Start starts the metrics server on the given address. The named pprof
+// profiles of the process, e.g., goroutine and heap, are served under
+// /debug/pprof/ if enablePprof is set, the CPU profile and the trace being
+// left out as they would exceed the write timeout
+func Start(addr string, enablePprof bool, logger *zap.Logger) *Server {
 	mux := http.NewServeMux()
 	mux.Handle("/metrics", promhttp.Handler())
+	if enablePprof {
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+	}
 
 	// Create the HTTP server with the custom ServeMux as the handler
 	server := &http.Server{
@@ -6,13 +6,16 @@ package mocks
 
 import (
 	reflect "reflect"
+	time "time"
 
 	math "cosmossdk.io/math"
-	types "github.com/babylonlabs-io/babylon/x/btcstaking/types"
-	types0 "github.com/babylonlabs-io/babylon/x/finality/types"
-	types1 "github.com/babylonlabs-io/finality-provider/types"
+	types "github.com/babylonlabs-io/babylon/types"
+	types0 "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+	types1 "github.com/babylonlabs-io/babylon/x/finality/types"
+	types2 "github.com/babylonlabs-io/finality-provider/types"
 	btcec "github.com/btcsuite/btcd/btcec/v2"
 	schnorr "github.com/btcsuite/btcd/btcec/v2/schnorr"
+	types3 "github.com/cosmos/cosmos-sdk/types"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -54,10 +57,10 @@ func (mr *MockClientControllerMockRecorder) Close() *gomock.Call {
 }
 
 // CommitPubRandList mocks base method.
-func (m *MockClientController) CommitPubRandList(fpPk *btcec.PublicKey, startHeight, numPubRand uint64, commitment []byte, sig *schnorr.Signature) (*types1.TxResponse, error) {
+func (m *MockClientController) CommitPubRandList(fpPk *btcec.PublicKey, startHeight, numPubRand uint64, commitment []byte, sig *schnorr.Signature) (*types2.TxResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CommitPubRandList", fpPk, startHeight, numPubRand, commitment, sig)
-	ret0, _ := ret[0].(*types1.TxResponse)
+	ret0, _ := ret[0].(*types2.TxResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -69,10 +72,10 @@ func (mr *MockClientControllerMockRecorder) CommitPubRandList(fpPk, startHeight,
 }
 
 // EditFinalityProvider mocks base method.
-func (m *MockClientController) EditFinalityProvider(fpPk *btcec.PublicKey, commission *math.LegacyDec, description []byte) (*types.MsgEditFinalityProvider, error) {
+func (m *MockClientController) EditFinalityProvider(fpPk *btcec.PublicKey, commission *math.LegacyDec, description []byte) (*types0.MsgEditFinalityProvider, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "EditFinalityProvider", fpPk, commission, description)
-	ret0, _ := ret[0].(*types.MsgEditFinalityProvider)
+	ret0, _ := ret[0].(*types0.MsgEditFinalityProvider)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -98,26 +101,11 @@ func (mr *MockClientControllerMockRecorder) QueryActivatedHeight() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryActivatedHeight", reflect.TypeOf((*MockClientController)(nil).QueryActivatedHeight))
 }
 
-// QueryFinalityActivationBlockHeight mocks base method.
-func (m *MockClientController) QueryFinalityActivationBlockHeight() (uint64, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueryFinalityActivationBlockHeight")
-	ret0, _ := ret[0].(uint64)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// QueryFinalityActivationBlockHeight indicates an expected call of QueryFinalityActivationBlockHeight.
-func (mr *MockClientControllerMockRecorder) QueryFinalityActivationBlockHeight() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryFinalityActivationBlockHeight", reflect.TypeOf((*MockClientController)(nil).QueryFinalityActivationBlockHeight))
-}
-
 // QueryBestBlock mocks base method.
-func (m *MockClientController) QueryBestBlock() (*types1.BlockInfo, error) {
+func (m *MockClientController) QueryBestBlock() (*types2.BlockInfo, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "QueryBestBlock")
-	ret0, _ := ret[0].(*types1.BlockInfo)
+	ret0, _ := ret[0].(*types2.BlockInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -129,10 +117,10 @@ func (mr *MockClientControllerMockRecorder) QueryBestBlock() *gomock.Call {
 }
 
 // QueryBlock mocks base method.
-func (m *MockClientController) QueryBlock(height uint64) (*types1.BlockInfo, error) {
+func (m *MockClientController) QueryBlock(height uint64) (*types2.BlockInfo, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "QueryBlock", height)
-	ret0, _ := ret[0].(*types1.BlockInfo)
+	ret0, _ := ret[0].(*types2.BlockInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -144,10 +132,10 @@ func (mr *MockClientControllerMockRecorder) QueryBlock(height interface{}) *gomo
 }
 
 // QueryBlocks mocks base method.
-func (m *MockClientController) QueryBlocks(startHeight, endHeight uint64, limit uint32) ([]*types1.BlockInfo, error) {
+func (m *MockClientController) QueryBlocks(startHeight, endHeight uint64, limit uint32) ([]*types2.BlockInfo, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "QueryBlocks", startHeight, endHeight, limit)
-	ret0, _ := ret[0].([]*types1.BlockInfo)
+	ret0, _ := ret[0].([]*types2.BlockInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -158,6 +146,111 @@ func (mr *MockClientControllerMockRecorder) QueryBlocks(startHeight, endHeight,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBlocks", reflect.TypeOf((*MockClientController)(nil).QueryBlocks), startHeight, endHeight, limit)
 }
 
+// QueryEvidences mocks base method.
+func (m *MockClientController) QueryEvidences(startHeight uint64) ([]*types1.Evidence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryEvidences", startHeight)
+	ret0, _ := ret[0].([]*types1.Evidence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryEvidences indicates an expected call of QueryEvidences.
+func (mr *MockClientControllerMockRecorder) QueryEvidences(startHeight interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryEvidences", reflect.TypeOf((*MockClientController)(nil).QueryEvidences), startHeight)
+}
+
+// QueryFeeAccountBalances mocks base method.
+func (m *MockClientController) QueryFeeAccountBalances() (map[string]types3.Coins, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryFeeAccountBalances")
+	ret0, _ := ret[0].(map[string]types3.Coins)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryFeeAccountBalances indicates an expected call of QueryFeeAccountBalances.
+func (mr *MockClientControllerMockRecorder) QueryFeeAccountBalances() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryFeeAccountBalances", reflect.TypeOf((*MockClientController)(nil).QueryFeeAccountBalances))
+}
+
+// QueryFinalityActivationBlockHeight mocks base method.
+func (m *MockClientController) QueryFinalityActivationBlockHeight() (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryFinalityActivationBlockHeight")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryFinalityActivationBlockHeight indicates an expected call of QueryFinalityActivationBlockHeight.
+func (mr *MockClientControllerMockRecorder) QueryFinalityActivationBlockHeight() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryFinalityActivationBlockHeight", reflect.TypeOf((*MockClientController)(nil).QueryFinalityActivationBlockHeight))
+}
+
+// QueryFinalityProvider mocks base method.
+func (m *MockClientController) QueryFinalityProvider(fpPk *btcec.PublicKey) (*types0.QueryFinalityProviderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryFinalityProvider", fpPk)
+	ret0, _ := ret[0].(*types0.QueryFinalityProviderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryFinalityProvider indicates an expected call of QueryFinalityProvider.
+func (mr *MockClientControllerMockRecorder) QueryFinalityProvider(fpPk interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryFinalityProvider", reflect.TypeOf((*MockClientController)(nil).QueryFinalityProvider), fpPk)
+}
+
+// QueryFinalityProviderDelegations mocks base method.
+func (m *MockClientController) QueryFinalityProviderDelegations(fpPk *btcec.PublicKey) (*types2.DelegationSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryFinalityProviderDelegations", fpPk)
+	ret0, _ := ret[0].(*types2.DelegationSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryFinalityProviderDelegations indicates an expected call of QueryFinalityProviderDelegations.
+func (mr *MockClientControllerMockRecorder) QueryFinalityProviderDelegations(fpPk interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryFinalityProviderDelegations", reflect.TypeOf((*MockClientController)(nil).QueryFinalityProviderDelegations), fpPk)
+}
+
+// QueryFinalityProviderJailedUntil mocks base method.
+func (m *MockClientController) QueryFinalityProviderJailedUntil(fpPk *btcec.PublicKey) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryFinalityProviderJailedUntil", fpPk)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryFinalityProviderJailedUntil indicates an expected call of QueryFinalityProviderJailedUntil.
+func (mr *MockClientControllerMockRecorder) QueryFinalityProviderJailedUntil(fpPk interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryFinalityProviderJailedUntil", reflect.TypeOf((*MockClientController)(nil).QueryFinalityProviderJailedUntil), fpPk)
+}
+
+// QueryFinalityProviderRewards mocks base method.
+func (m *MockClientController) QueryFinalityProviderRewards() (types3.Coins, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryFinalityProviderRewards")
+	ret0, _ := ret[0].(types3.Coins)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryFinalityProviderRewards indicates an expected call of QueryFinalityProviderRewards.
+func (mr *MockClientControllerMockRecorder) QueryFinalityProviderRewards() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryFinalityProviderRewards", reflect.TypeOf((*MockClientController)(nil).QueryFinalityProviderRewards))
+}
+
 // QueryFinalityProviderSlashedOrJailed mocks base method.
 func (m *MockClientController) QueryFinalityProviderSlashedOrJailed(fpPk *btcec.PublicKey) (bool, bool, error) {
 	m.ctrl.T.Helper()
@@ -190,10 +283,10 @@ func (mr *MockClientControllerMockRecorder) QueryFinalityProviderVotingPower(fpP
 }
 
 // QueryLastCommittedPublicRand mocks base method.
-func (m *MockClientController) QueryLastCommittedPublicRand(fpPk *btcec.PublicKey, count uint64) (map[uint64]*types0.PubRandCommitResponse, error) {
+func (m *MockClientController) QueryLastCommittedPublicRand(fpPk *btcec.PublicKey, count uint64) (map[uint64]*types1.PubRandCommitResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "QueryLastCommittedPublicRand", fpPk, count)
-	ret0, _ := ret[0].(map[uint64]*types0.PubRandCommitResponse)
+	ret0, _ := ret[0].(map[uint64]*types1.PubRandCommitResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -204,11 +297,26 @@ func (mr *MockClientControllerMockRecorder) QueryLastCommittedPublicRand(fpPk, c
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryLastCommittedPublicRand", reflect.TypeOf((*MockClientController)(nil).QueryLastCommittedPublicRand), fpPk, count)
 }
 
+// QueryLatestBlockTime mocks base method.
+func (m *MockClientController) QueryLatestBlockTime() (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryLatestBlockTime")
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryLatestBlockTime indicates an expected call of QueryLatestBlockTime.
+func (mr *MockClientControllerMockRecorder) QueryLatestBlockTime() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryLatestBlockTime", reflect.TypeOf((*MockClientController)(nil).QueryLatestBlockTime))
+}
+
 // QueryLatestFinalizedBlocks mocks base method.
-func (m *MockClientController) QueryLatestFinalizedBlocks(count uint64) ([]*types1.BlockInfo, error) {
+func (m *MockClientController) QueryLatestFinalizedBlocks(count uint64) ([]*types2.BlockInfo, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "QueryLatestFinalizedBlocks", count)
-	ret0, _ := ret[0].([]*types1.BlockInfo)
+	ret0, _ := ret[0].([]*types2.BlockInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -219,11 +327,86 @@ func (mr *MockClientControllerMockRecorder) QueryLatestFinalizedBlocks(count int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryLatestFinalizedBlocks", reflect.TypeOf((*MockClientController)(nil).QueryLatestFinalizedBlocks), count)
 }
 
+// QueryMinCommissionRate mocks base method.
+func (m *MockClientController) QueryMinCommissionRate() (math.LegacyDec, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryMinCommissionRate")
+	ret0, _ := ret[0].(math.LegacyDec)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryMinCommissionRate indicates an expected call of QueryMinCommissionRate.
+func (mr *MockClientControllerMockRecorder) QueryMinCommissionRate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryMinCommissionRate", reflect.TypeOf((*MockClientController)(nil).QueryMinCommissionRate))
+}
+
+// QueryNodeChainID mocks base method.
+func (m *MockClientController) QueryNodeChainID() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryNodeChainID")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryNodeChainID indicates an expected call of QueryNodeChainID.
+func (mr *MockClientControllerMockRecorder) QueryNodeChainID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryNodeChainID", reflect.TypeOf((*MockClientController)(nil).QueryNodeChainID))
+}
+
+// QueryRewardsOfFinalityProvider mocks base method.
+func (m *MockClientController) QueryRewardsOfFinalityProvider(fpPk *btcec.PublicKey) (types3.Coins, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryRewardsOfFinalityProvider", fpPk)
+	ret0, _ := ret[0].(types3.Coins)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryRewardsOfFinalityProvider indicates an expected call of QueryRewardsOfFinalityProvider.
+func (mr *MockClientControllerMockRecorder) QueryRewardsOfFinalityProvider(fpPk interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryRewardsOfFinalityProvider", reflect.TypeOf((*MockClientController)(nil).QueryRewardsOfFinalityProvider), fpPk)
+}
+
+// QuerySignerBalance mocks base method.
+func (m *MockClientController) QuerySignerBalance() (types3.Coins, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuerySignerBalance")
+	ret0, _ := ret[0].(types3.Coins)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuerySignerBalance indicates an expected call of QuerySignerBalance.
+func (mr *MockClientControllerMockRecorder) QuerySignerBalance() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuerySignerBalance", reflect.TypeOf((*MockClientController)(nil).QuerySignerBalance))
+}
+
+// QueryVotesAtHeight mocks base method.
+func (m *MockClientController) QueryVotesAtHeight(height uint64) ([]types.BIP340PubKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryVotesAtHeight", height)
+	ret0, _ := ret[0].([]types.BIP340PubKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryVotesAtHeight indicates an expected call of QueryVotesAtHeight.
+func (mr *MockClientControllerMockRecorder) QueryVotesAtHeight(height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryVotesAtHeight", reflect.TypeOf((*MockClientController)(nil).QueryVotesAtHeight), height)
+}
+
 // RegisterFinalityProvider mocks base method.
-func (m *MockClientController) RegisterFinalityProvider(fpPk *btcec.PublicKey, pop []byte, commission *math.LegacyDec, description []byte) (*types1.TxResponse, error) {
+func (m *MockClientController) RegisterFinalityProvider(fpPk *btcec.PublicKey, pop []byte, commission *math.LegacyDec, description []byte) (*types2.TxResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "RegisterFinalityProvider", fpPk, pop, commission, description)
-	ret0, _ := ret[0].(*types1.TxResponse)
+	ret0, _ := ret[0].(*types2.TxResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -235,10 +418,10 @@ func (mr *MockClientControllerMockRecorder) RegisterFinalityProvider(fpPk, pop,
 }
 
 // SubmitBatchFinalitySigs mocks base method.
-func (m *MockClientController) SubmitBatchFinalitySigs(fpPk *btcec.PublicKey, blocks []*types1.BlockInfo, pubRandList []*btcec.FieldVal, proofList [][]byte, sigs []*btcec.ModNScalar) (*types1.TxResponse, error) {
+func (m *MockClientController) SubmitBatchFinalitySigs(fpPk *btcec.PublicKey, blocks []*types2.BlockInfo, pubRandList []*btcec.FieldVal, proofList [][]byte, sigs []*btcec.ModNScalar) (*types2.TxResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SubmitBatchFinalitySigs", fpPk, blocks, pubRandList, proofList, sigs)
-	ret0, _ := ret[0].(*types1.TxResponse)
+	ret0, _ := ret[0].(*types2.TxResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -250,10 +433,10 @@ func (mr *MockClientControllerMockRecorder) SubmitBatchFinalitySigs(fpPk, blocks
 }
 
 // SubmitFinalitySig mocks base method.
-func (m *MockClientController) SubmitFinalitySig(fpPk *btcec.PublicKey, block *types1.BlockInfo, pubRand *btcec.FieldVal, proof []byte, sig *btcec.ModNScalar) (*types1.TxResponse, error) {
+func (m *MockClientController) SubmitFinalitySig(fpPk *btcec.PublicKey, block *types2.BlockInfo, pubRand *btcec.FieldVal, proof []byte, sig *btcec.ModNScalar) (*types2.TxResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SubmitFinalitySig", fpPk, block, pubRand, proof, sig)
-	ret0, _ := ret[0].(*types1.TxResponse)
+	ret0, _ := ret[0].(*types2.TxResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -264,11 +447,26 @@ func (mr *MockClientControllerMockRecorder) SubmitFinalitySig(fpPk, block, pubRa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitFinalitySig", reflect.TypeOf((*MockClientController)(nil).SubmitFinalitySig), fpPk, block, pubRand, proof, sig)
 }
 
+// SubmitSelectiveSlashingEvidence mocks base method.
+func (m *MockClientController) SubmitSelectiveSlashingEvidence(recoveredSk *btcec.PrivateKey) (*types2.TxResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitSelectiveSlashingEvidence", recoveredSk)
+	ret0, _ := ret[0].(*types2.TxResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitSelectiveSlashingEvidence indicates an expected call of SubmitSelectiveSlashingEvidence.
+func (mr *MockClientControllerMockRecorder) SubmitSelectiveSlashingEvidence(recoveredSk interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitSelectiveSlashingEvidence", reflect.TypeOf((*MockClientController)(nil).SubmitSelectiveSlashingEvidence), recoveredSk)
+}
+
 // UnjailFinalityProvider mocks base method.
-func (m *MockClientController) UnjailFinalityProvider(fpPk *btcec.PublicKey) (*types1.TxResponse, error) {
+func (m *MockClientController) UnjailFinalityProvider(fpPk *btcec.PublicKey) (*types2.TxResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UnjailFinalityProvider", fpPk)
-	ret0, _ := ret[0].(*types1.TxResponse)
+	ret0, _ := ret[0].(*types2.TxResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -278,3 +476,48 @@ func (mr *MockClientControllerMockRecorder) UnjailFinalityProvider(fpPk interfac
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnjailFinalityProvider", reflect.TypeOf((*MockClientController)(nil).UnjailFinalityProvider), fpPk)
 }
+
+// UpdateFinalityProviderCommission mocks base method.
+func (m *MockClientController) UpdateFinalityProviderCommission(fpPk *btcec.PublicKey, rate math.LegacyDec) (*types2.TxResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFinalityProviderCommission", fpPk, rate)
+	ret0, _ := ret[0].(*types2.TxResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFinalityProviderCommission indicates an expected call of UpdateFinalityProviderCommission.
+func (mr *MockClientControllerMockRecorder) UpdateFinalityProviderCommission(fpPk, rate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFinalityProviderCommission", reflect.TypeOf((*MockClientController)(nil).UpdateFinalityProviderCommission), fpPk, rate)
+}
+
+// WithdrawFinalityProviderRewards mocks base method.
+func (m *MockClientController) WithdrawFinalityProviderRewards(amount types3.Coins, recipient string) (*types2.TxResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithdrawFinalityProviderRewards", amount, recipient)
+	ret0, _ := ret[0].(*types2.TxResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WithdrawFinalityProviderRewards indicates an expected call of WithdrawFinalityProviderRewards.
+func (mr *MockClientControllerMockRecorder) WithdrawFinalityProviderRewards(amount, recipient interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithdrawFinalityProviderRewards", reflect.TypeOf((*MockClientController)(nil).WithdrawFinalityProviderRewards), amount, recipient)
+}
+
+// WithdrawRewardsOfFinalityProvider mocks base method.
+func (m *MockClientController) WithdrawRewardsOfFinalityProvider(fpPk *btcec.PublicKey, amount types3.Coins, recipient string) (*types2.TxResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithdrawRewardsOfFinalityProvider", fpPk, amount, recipient)
+	ret0, _ := ret[0].(*types2.TxResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WithdrawRewardsOfFinalityProvider indicates an expected call of WithdrawRewardsOfFinalityProvider.
+func (mr *MockClientControllerMockRecorder) WithdrawRewardsOfFinalityProvider(fpPk, amount, recipient interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithdrawRewardsOfFinalityProvider", reflect.TypeOf((*MockClientController)(nil).WithdrawRewardsOfFinalityProvider), fpPk, amount, recipient)
+}
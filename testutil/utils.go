@@ -39,6 +39,7 @@ func PrepareMockedClientController(t *testing.T, r *rand.Rand, startHeight, curr
 	mockClientController.EXPECT().QueryBestBlock().Return(currentBlockRes, nil).AnyTimes()
 	mockClientController.EXPECT().QueryActivatedHeight().Return(uint64(1), nil).AnyTimes()
 	mockClientController.EXPECT().QueryFinalityActivationBlockHeight().Return(finalityActivationBlkHeight, nil).AnyTimes()
+	mockClientController.EXPECT().QueryMinCommissionRate().Return(sdkmath.LegacyZeroDec(), nil).AnyTimes()
 
 	return mockClientController
 }
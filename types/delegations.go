@@ -0,0 +1,17 @@
+package types
+
+// DelegationSummary summarizes the BTC delegations to a finality provider
+// by status, the amounts are in satoshis
+type DelegationSummary struct {
+	// NumActive and ActiveSat count the delegations with voting power
+	NumActive uint64
+	ActiveSat uint64
+	// NumPending and PendingSat count the delegations waiting for the
+	// covenant signatures or the inclusion of the staking tx
+	NumPending uint64
+	PendingSat uint64
+	// NumUnbonded and UnbondedSat count the delegations which lost their
+	// voting power, i.e., unbonded early or close to the end of the timelock
+	NumUnbonded uint64
+	UnbondedSat uint64
+}
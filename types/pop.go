@@ -0,0 +1,71 @@
+package types
+
+import (
+	"fmt"
+
+	bbn "github.com/babylonlabs-io/babylon/types"
+	bstypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+)
+
+// PopExport is the binding between the EOTS public key and the Babylon
+// address of a finality provider, together with the proof-of-possession
+// that the EOTS key signed the address. Third parties can verify it
+// independently with VerifyPopExport
+type PopExport struct {
+	// EotsPkHex is the EOTS public key in BIP-340 hex format
+	EotsPkHex string `json:"eots_pk_hex"`
+	// FpAddr is the bech32 Babylon address of the finality provider
+	FpAddr string `json:"fp_addr"`
+	// PopHex is the proof-of-possession in hex, as accepted by the
+	// Babylon MsgCreateFinalityProvider
+	PopHex string `json:"pop_hex"`
+}
+
+// NewPopExport builds the export from the stored BIP-340 signature
+// of the EOTS key over the finality provider address
+func NewPopExport(eotsPk *bbn.BIP340PubKey, fpAddr string, btcSig []byte) (*PopExport, error) {
+	pop := &bstypes.ProofOfPossessionBTC{
+		BtcSigType: bstypes.BTCSigType_BIP340,
+		BtcSig:     btcSig,
+	}
+
+	popHex, err := pop.ToHexStr()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the proof-of-possession: %w", err)
+	}
+
+	return &PopExport{
+		EotsPkHex: eotsPk.MarshalHex(),
+		FpAddr:    fpAddr,
+		PopHex:    popHex,
+	}, nil
+}
+
+// VerifyPopExport verifies that the proof-of-possession in the export is
+// signed by the EOTS key over the address of the finality provider.
+// The address is decoded regardless of its bech32 prefix so that the
+// verification does not depend on the global sdk config of the caller
+func VerifyPopExport(export *PopExport) error {
+	eotsPk, err := bbn.NewBIP340PubKeyFromHex(export.EotsPkHex)
+	if err != nil {
+		return fmt.Errorf("invalid EOTS public key %s: %w", export.EotsPkHex, err)
+	}
+
+	_, addrBytes, err := bech32.DecodeAndConvert(export.FpAddr)
+	if err != nil {
+		return fmt.Errorf("invalid finality provider address %s: %w", export.FpAddr, err)
+	}
+
+	pop, err := bstypes.NewPoPBTCFromHex(export.PopHex)
+	if err != nil {
+		return fmt.Errorf("invalid proof-of-possession: %w", err)
+	}
+
+	if err := pop.VerifyBIP340(sdk.AccAddress(addrBytes), eotsPk); err != nil {
+		return fmt.Errorf("invalid proof-of-possession for %s: %w", export.FpAddr, err)
+	}
+
+	return nil
+}
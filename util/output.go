@@ -0,0 +1,97 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// OutputFlag is the flag of the output format of the commands, which
+	// is named after the one of the cosmos-sdk commands for them to share it
+	OutputFlag = "output"
+
+	OutputFormatText = "text"
+	OutputFormatJSON = "json"
+)
+
+// AddOutputFlag adds the output format flag to the given command and to
+// its subcommands
+func AddOutputFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().String(OutputFlag, OutputFormatText, "The output format of the commands, including their errors (text|json)")
+}
+
+// IsJSONOutput returns whether the given command should print JSON
+func IsJSONOutput(cmd *cobra.Command) bool {
+	f := cmd.Flag(OutputFlag)
+
+	return f != nil && f.Value.String() == OutputFormatJSON
+}
+
+// CheckOutputFormat validates the output format of the given command and,
+// for JSON, stops cobra from printing the errors and the usage as text, the
+// errors being printed by PrintError instead
+func CheckOutputFormat(cmd *cobra.Command) error {
+	f := cmd.Flag(OutputFlag)
+	if f == nil {
+		return nil
+	}
+
+	switch f.Value.String() {
+	case OutputFormatText:
+	case OutputFormatJSON:
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+	default:
+		return fmt.Errorf("invalid output format %q, expected %s or %s", f.Value.String(), OutputFormatText, OutputFormatJSON)
+	}
+
+	return nil
+}
+
+// SilenceFlagErrors is the flag error function of the root commands, which
+// stops cobra from printing the flag errors as text for JSON
+func SilenceFlagErrors(cmd *cobra.Command, err error) error {
+	if IsJSONOutput(cmd) {
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+	}
+
+	return err
+}
+
+// PrintJSON writes the given value as indented JSON
+func PrintJSON(w io.Writer, v interface{}) error {
+	bz, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		return fmt.Errorf("failed to encode the output: %w", err)
+	}
+	_, err = fmt.Fprintf(w, "%s\n", bz)
+
+	return err
+}
+
+// ErrorOutput is the JSON output of a failed command
+type ErrorOutput struct {
+	Error ErrorDetails `json:"error"`
+}
+
+// ErrorDetails describes the error of a failed command
+type ErrorDetails struct {
+	// Code is the gRPC status code of the error returned by the daemon,
+	// e.g., NotFound, Unknown for the errors of the command itself
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// PrintJSONError writes the given error as JSON along with its code
+func PrintJSONError(w io.Writer, err error) {
+	s, _ := status.FromError(err)
+	_ = PrintJSON(w, &ErrorOutput{Error: ErrorDetails{
+		Code:    s.Code().String(),
+		Message: s.Message(),
+	}})
+}
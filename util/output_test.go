@@ -0,0 +1,64 @@
+package util_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+func TestOutputFormat(t *testing.T) {
+	t.Parallel()
+	cmd := &cobra.Command{}
+	util.AddOutputFlag(cmd)
+
+	// the text output is the default
+	require.NoError(t, util.CheckOutputFormat(cmd))
+	require.False(t, util.IsJSONOutput(cmd))
+	require.False(t, cmd.SilenceErrors)
+
+	require.NoError(t, cmd.PersistentFlags().Set(util.OutputFlag, util.OutputFormatJSON))
+	require.NoError(t, util.CheckOutputFormat(cmd))
+	require.True(t, util.IsJSONOutput(cmd))
+	require.True(t, cmd.SilenceErrors)
+
+	// the subcommands inherit the flag
+	subCmd := &cobra.Command{Use: "sub"}
+	cmd.AddCommand(subCmd)
+	require.True(t, util.IsJSONOutput(subCmd))
+
+	require.NoError(t, cmd.PersistentFlags().Set(util.OutputFlag, "yaml"))
+	require.Error(t, util.CheckOutputFormat(cmd))
+
+	// the commands without the flag print text
+	require.NoError(t, util.CheckOutputFormat(&cobra.Command{}))
+	require.False(t, util.IsJSONOutput(&cobra.Command{}))
+}
+
+func TestPrintJSONError(t *testing.T) {
+	t.Parallel()
+	decode := func(err error) util.ErrorDetails {
+		var buf bytes.Buffer
+		util.PrintJSONError(&buf, err)
+		var out util.ErrorOutput
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+		return out.Error
+	}
+
+	// the errors of the daemon carry their code
+	details := decode(fmt.Errorf("failed to query: %w", status.Error(codes.NotFound, "finality provider not found")))
+	require.Equal(t, codes.NotFound.String(), details.Code)
+	require.Contains(t, details.Message, "finality provider not found")
+
+	details = decode(errors.New("invalid flag"))
+	require.Equal(t, codes.Unknown.String(), details.Code)
+	require.Equal(t, "invalid flag", details.Message)
+}
@@ -0,0 +1,111 @@
+package util
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// PassphraseEnvVar is the environment variable the passphrase is read from
+// if no other source is set
+const PassphraseEnvVar = "PASSPHRASE"
+
+// PassphraseSource defines where a passphrase is read from, at most one of
+// the sources should be set
+type PassphraseSource struct {
+	// Value is the passphrase itself
+	Value string
+	// File is the path of a file holding the passphrase
+	File string
+	// Command is a shell command printing the passphrase, e.g., fetching it
+	// from a secret manager
+	Command string
+	// Prompt asks the passphrase interactively
+	Prompt bool
+}
+
+// IsSet returns whether any of the sources is set
+func (s *PassphraseSource) IsSet() bool {
+	return s.Value != "" || s.File != "" || s.Command != "" || s.Prompt
+}
+
+// Read returns the passphrase from the set source, or from the PASSPHRASE
+// environment variable if no source is set. The trailing newline of the
+// file and the output of the command is trimmed
+func (s *PassphraseSource) Read(ctx context.Context) (string, error) {
+	set := 0
+	for _, isSet := range []bool{s.Value != "", s.File != "", s.Command != "", s.Prompt} {
+		if isSet {
+			set++
+		}
+	}
+	if set > 1 {
+		return "", errors.New("the passphrase should be read from only one source")
+	}
+
+	switch {
+	case s.Value != "":
+		return s.Value, nil
+	case s.File != "":
+		bz, err := os.ReadFile(CleanAndExpandPath(s.File))
+		if err != nil {
+			return "", fmt.Errorf("failed to read the passphrase file: %w", err)
+		}
+		return trimNewline(string(bz)), nil
+	case s.Command != "":
+		return readPassphraseFromCommand(ctx, s.Command)
+	case s.Prompt:
+		return promptPassphrase(os.Stdin, os.Stderr)
+	default:
+		return os.Getenv(PassphraseEnvVar), nil
+	}
+}
+
+func readPassphraseFromCommand(ctx context.Context, command string) (string, error) {
+	var stderr bytes.Buffer
+	// #nosec G204 -- the command is given by the operator
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to run the passphrase command: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return trimNewline(string(out)), nil
+}
+
+// promptPassphrase asks the passphrase without echoing it if the input is
+// a terminal, otherwise reads it from the first line of the input
+func promptPassphrase(in *os.File, out io.Writer) (string, error) {
+	fd := int(in.Fd()) // #nosec G115 -- file descriptors fit in an int
+	if !term.IsTerminal(fd) {
+		line, err := bufio.NewReader(in).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("failed to read the passphrase: %w", err)
+		}
+		return trimNewline(line), nil
+	}
+
+	if _, err := fmt.Fprint(out, "Enter the passphrase: "); err != nil {
+		return "", err
+	}
+	bz, err := term.ReadPassword(fd)
+	_, _ = fmt.Fprintln(out)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the passphrase: %w", err)
+	}
+
+	return string(bz), nil
+}
+
+func trimNewline(s string) string {
+	return strings.TrimRight(s, "\r\n")
+}
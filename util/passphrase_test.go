@@ -0,0 +1,43 @@
+package util_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+func TestReadPassphrase(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv(util.PassphraseEnvVar, "from-env")
+
+	// the env var is the fallback
+	passphrase, err := (&util.PassphraseSource{}).Read(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "from-env", passphrase)
+
+	passphrase, err = (&util.PassphraseSource{Value: "from-value"}).Read(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "from-value", passphrase)
+
+	// the trailing newline is trimmed
+	file := filepath.Join(t.TempDir(), "passphrase")
+	require.NoError(t, os.WriteFile(file, []byte("from-file\n"), 0600))
+	passphrase, err = (&util.PassphraseSource{File: file}).Read(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "from-file", passphrase)
+
+	passphrase, err = (&util.PassphraseSource{Command: "echo from-command"}).Read(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "from-command", passphrase)
+
+	_, err = (&util.PassphraseSource{Command: "exit 1"}).Read(ctx)
+	require.Error(t, err)
+
+	_, err = (&util.PassphraseSource{Value: "from-value", File: file}).Read(ctx)
+	require.Error(t, err)
+}